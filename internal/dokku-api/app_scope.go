@@ -0,0 +1,208 @@
+package dokkuApi
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
+)
+
+// appLessCommands never target an application: host settings, plugins, keys and
+// service-level commands. They run whatever the app scope.
+var appLessCommands = map[string]bool{
+	"events":                   true,
+	"help":                     true,
+	"version":                  true,
+	"domains:add-global":       true,
+	"domains:clear-global":     true,
+	"domains:remove-global":    true,
+	"domains:set-global":       true,
+	"git:allow-host":           true,
+	"git:public-key":           true,
+	"letsencrypt:cron-job":     true,
+	"network:create":           true,
+	"network:destroy":          true,
+	"network:exists":           true,
+	"network:info":             true,
+	"network:list":             true,
+	"registry:login":           true,
+	"registry:logout":          true,
+	"storage:ensure-directory": true,
+}
+
+// appListingCommands print one row per application, starting with its name; with a
+// restricted scope the rows of out-of-scope apps are dropped from their output
+var appListingCommands = map[string]bool{
+	"apps:list":        true,
+	"letsencrypt:list": true,
+}
+
+// appPairCommands take two application names (source and target)
+var appPairCommands = map[string]bool{
+	"apps:clone":  true,
+	"apps:rename": true,
+}
+
+// servicePlugins are datastore plugins whose commands take a service name first;
+// only link-style commands name an application, as their second argument
+var servicePlugins = map[string]bool{
+	"clickhouse":    true,
+	"couchdb":       true,
+	"elasticsearch": true,
+	"mariadb":       true,
+	"memcached":     true,
+	"mongo":         true,
+	"mysql":         true,
+	"postgres":      true,
+	"rabbitmq":      true,
+	"redis":         true,
+}
+
+// serviceAppVerbs are the service commands naming an application as their second argument
+var serviceAppVerbs = map[string]bool{
+	"link":    true,
+	"promote": true,
+	"unlink":  true,
+}
+
+// scopeOutput tells how a command's output must be filtered to the app scope
+type scopeOutput int
+
+const (
+	scopeOutputAsIs scopeOutput = iota
+	// scopeOutputRows drops the rows of out-of-scope apps (apps:list, letsencrypt:list)
+	scopeOutputRows
+	// scopeOutputSections drops the "=====> <app> ..." sections of out-of-scope apps (*:report)
+	scopeOutputSections
+)
+
+// commandApps returns the applications a command targets, following Dokku's
+// "<command> <app> [args...]" convention. all is set when the command runs
+// against every app: an explicit --all, or an app command given no app.
+func commandApps(commandName string, args []string) (apps []string, all bool) {
+	if appLessCommands[commandName] || appListingCommands[commandName] {
+		return nil, false
+	}
+	plugin, verb, _ := strings.Cut(commandName, ":")
+	if plugin == "plugin" || plugin == "ssh-keys" {
+		return nil, false
+	}
+
+	var positional []string
+	for _, arg := range args {
+		switch {
+		case arg == "--global":
+			return nil, false
+		case arg == "--all":
+			return nil, true
+		case strings.HasPrefix(arg, "-"):
+		default:
+			positional = append(positional, arg)
+		}
+	}
+
+	if servicePlugins[plugin] {
+		switch {
+		case verb == "app-links" && len(positional) > 0:
+			return positional[:1], false
+		case serviceAppVerbs[verb] && len(positional) > 1:
+			return positional[1:2], false
+		}
+		return nil, false
+	}
+
+	switch {
+	case len(positional) == 0:
+		return nil, true
+	case appPairCommands[commandName] && len(positional) > 1:
+		return positional[:2], false
+	}
+	return positional[:1], false
+}
+
+// checkAppScope refuses a command targeting an application outside the scope and
+// tells how its output must be filtered. Commands running against every app are
+// only allowed when their output can be filtered per app.
+func (c *client) checkAppScope(commandName string, args []string) (scopeOutput, error) {
+	scope := c.config.AppScope
+	if !scope.Restricted() {
+		return scopeOutputAsIs, nil
+	}
+	if appListingCommands[commandName] {
+		return scopeOutputRows, nil
+	}
+
+	apps, all := commandApps(commandName, args)
+	for _, appName := range apps {
+		if err := scope.Check(appName); err != nil {
+			c.logger.Warn("Blocked command targeting an out-of-scope app", "command", commandName, "app", appName)
+			return scopeOutputAsIs, err
+		}
+	}
+	if !all {
+		return scopeOutputAsIs, nil
+	}
+
+	if IsReadCommand(commandName) && commandVerb(commandName) == "report" && !hasFormatFlag(args) {
+		return scopeOutputSections, nil
+	}
+	c.logger.Warn("Blocked command running against every app with a restricted app scope", "command", commandName)
+	return scopeOutputAsIs, fmt.Errorf("%w: %s runs against every app, name one", shared.ErrAppOutOfScope, commandName)
+}
+
+func hasFormatFlag(args []string) bool {
+	for _, arg := range args {
+		if arg == "--format" || strings.HasPrefix(arg, "--format=") {
+			return true
+		}
+	}
+	return false
+}
+
+// filterAppScope drops the parts of output that belong to out-of-scope apps
+func (c *client) filterAppScope(output []byte, filter scopeOutput) []byte {
+	if len(output) == 0 {
+		return output
+	}
+	switch filter {
+	case scopeOutputRows:
+		return filterAppRows(output, c.config.AppScope)
+	case scopeOutputSections:
+		return filterAppSections(output, c.config.AppScope)
+	}
+	return output
+}
+
+// filterAppRows keeps header and warning lines and the rows whose first field is an in-scope app
+func filterAppRows(output []byte, scope *shared.AppScope) []byte {
+	lines := strings.Split(string(output), "\n")
+	kept := make([]string, 0, len(lines))
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || isReportMarker(fields[0]) || scope.Allows(fields[0]) {
+			kept = append(kept, line)
+		}
+	}
+	return []byte(strings.Join(kept, "\n"))
+}
+
+// filterAppSections keeps the "=====> <app> ..." report sections of in-scope apps
+// and anything printed before the first section
+func filterAppSections(output []byte, scope *shared.AppScope) []byte {
+	lines := strings.Split(string(output), "\n")
+	kept := make([]string, 0, len(lines))
+	keep := true
+	for _, line := range lines {
+		if fields := strings.Fields(line); len(fields) > 1 && fields[0] == "=====>" {
+			keep = scope.Allows(fields[1])
+		}
+		if keep {
+			kept = append(kept, line)
+		}
+	}
+	return []byte(strings.Join(kept, "\n"))
+}
+
+func isReportMarker(field string) bool {
+	return field == "=====>" || field == "----->" || field == "!"
+}
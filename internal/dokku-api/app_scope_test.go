@@ -0,0 +1,148 @@
+package dokkuApi
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
+)
+
+// newScopedTestClient returns a client restricted to "staging-*" apps that answers every command with output
+func newScopedTestClient(t *testing.T, output string) (*client, *[]string) {
+	t.Helper()
+
+	var ran []string
+	c := newRunnerTestClient(t, 0, func(ctx context.Context, sshArgs []string, env []string, stdin []byte) ([]byte, error) {
+		ran = append(ran, strings.Join(sshArgs, " "))
+		return []byte(output), nil
+	})
+	c.config.AppScope = shared.NewAppScope([]string{"staging-*"}, nil)
+	return c, &ran
+}
+
+// TestAppScopeCoversPluginCommands runs the commands each plugin sends for an app against an out-of-scope app
+func TestAppScopeCoversPluginCommands(t *testing.T) {
+	tests := []struct {
+		plugin  string
+		command string
+		args    []string
+	}{
+		{"app", "config:unset", []string{"--no-restart", "billing", "DEBUG"}},
+		{"app", "apps:rename", []string{"staging-api", "billing"}},
+		{"deployment", "git:sync", []string{"--build", "billing", "https://example.com/repo.git", "main"}},
+		{"deployment", "ps:rebuild", []string{"billing"}},
+		{"deployment", "logs", []string{"billing", "--num", "100"}},
+		{"cron", "cron:list", []string{"billing", "--format", "json"}},
+		{"cron", "cron:run", []string{"billing", "abc123"}},
+		{"postgres", "postgres:link", []string{"billing-db", "billing"}},
+		{"postgres", "postgres:unlink", []string{"billing-db", "billing"}},
+		{"letsencrypt", "letsencrypt:enable", []string{"billing"}},
+		{"maintenance", "maintenance:enable", []string{"billing"}},
+		{"storage", "storage:mount", []string{"billing", "/var/lib/dokku/data/storage/billing:/data"}},
+		{"checks", "checks:disable", []string{"billing", "web"}},
+		{"checks", "checks:report", []string{"billing"}},
+		{"app", "ps:restart", []string{"--all"}},
+		{"app", "ps:rebuild", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.plugin+" "+tt.command, func(t *testing.T) {
+			c, ran := newScopedTestClient(t, "")
+
+			if _, err := c.ExecuteCommand(context.Background(), tt.command, tt.args); !errors.Is(err, shared.ErrAppOutOfScope) {
+				t.Fatalf("expected ErrAppOutOfScope, got %v", err)
+			}
+			if len(*ran) != 0 {
+				t.Fatalf("expected nothing to run, got %v", *ran)
+			}
+		})
+	}
+}
+
+func TestAppScopeAllowsInScopeAndAppLessCommands(t *testing.T) {
+	commands := []struct {
+		command string
+		args    []string
+	}{
+		{"git:sync", []string{"--build", "staging-api", "https://example.com/repo.git"}},
+		{"postgres:link", []string{"billing-db", "staging-api"}},
+		{"postgres:info", []string{"billing-db"}},
+		{"proxy:report", []string{"--global", "--proxy-type"}},
+		{"plugin:list", nil},
+		{"version", nil},
+		{"events", nil},
+	}
+
+	for _, cmd := range commands {
+		c, ran := newScopedTestClient(t, "ok")
+		if _, err := c.ExecuteCommand(context.Background(), cmd.command, cmd.args); err != nil {
+			t.Fatalf("%s: expected the command to run, got %v", cmd.command, err)
+		}
+		if len(*ran) != 1 {
+			t.Fatalf("%s: expected one run, got %v", cmd.command, *ran)
+		}
+	}
+}
+
+func TestAppScopeFiltersAppListings(t *testing.T) {
+	c, _ := newScopedTestClient(t, "=====> My Apps\nbilling\nstaging-api\nstaging-web\n")
+
+	output, err := c.ExecuteCommand(context.Background(), "apps:list", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := string(output); got != "=====> My Apps\nstaging-api\nstaging-web\n" {
+		t.Fatalf("expected billing to be filtered out, got %q", got)
+	}
+
+	c, _ = newScopedTestClient(t, "-----> App name           Certificate Expiry\nbilling                  2026-01-01\nstaging-api              2026-02-01\n")
+	output, err = c.ExecuteCommand(context.Background(), "letsencrypt:list", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(string(output), "billing") || !strings.Contains(string(output), "staging-api") {
+		t.Fatalf("expected only in-scope certificates, got %q", output)
+	}
+}
+
+func TestAppScopeFiltersAllAppReports(t *testing.T) {
+	report := "=====> billing maintenance information\n       Maintenance enabled: true\n" +
+		"=====> staging-api maintenance information\n       Maintenance enabled: false\n"
+	c, _ := newScopedTestClient(t, report)
+
+	output, err := c.ExecuteCommand(context.Background(), "maintenance:report", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(string(output), "billing") || !strings.Contains(string(output), "staging-api maintenance information") {
+		t.Fatalf("expected only the in-scope section, got %q", output)
+	}
+
+	if _, err := c.ExecuteCommand(context.Background(), "ps:report", []string{"--format", "json"}); !errors.Is(err, shared.ErrAppOutOfScope) {
+		t.Fatalf("expected an unfilterable report to be refused, got %v", err)
+	}
+}
+
+func TestAppScopeAppliesToEveryExecutionPath(t *testing.T) {
+	c, ran := newScopedTestClient(t, "")
+	c.config.BreakGlass = true
+	c.SetBlacklist([]string{"destroy"})
+
+	if _, err := c.ExecuteBreakGlassCommand(context.Background(), "apps:destroy", []string{"billing", "--force"}, "cleanup"); !errors.Is(err, shared.ErrAppOutOfScope) {
+		t.Fatalf("expected break-glass to respect the scope, got %v", err)
+	}
+	if _, err := c.ExecuteCommandWithInput(context.Background(), "config:set", []string{"billing", "KEY=value"}, []byte("x")); !errors.Is(err, shared.ErrAppOutOfScope) {
+		t.Fatalf("expected commands with input to respect the scope, got %v", err)
+	}
+	if _, err := c.ExecuteCommandStreaming(context.Background(), "ps:rebuild", []string{"billing"}); !errors.Is(err, shared.ErrAppOutOfScope) {
+		t.Fatalf("expected streamed commands to respect the scope, got %v", err)
+	}
+	if _, err := c.ExecuteStructured(context.Background(), CommandSpec{Command: "ps:report", Args: []string{"billing"}}); !errors.Is(err, shared.ErrAppOutOfScope) {
+		t.Fatalf("expected structured commands to respect the scope, got %v", err)
+	}
+	if len(*ran) != 0 {
+		t.Fatalf("expected nothing to run, got %v", *ran)
+	}
+}
@@ -0,0 +1,62 @@
+package dokkuApi
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// ExecuteBreakGlassCommand runs a single blacklisted command under supervision.
+// It requires break-glass mode and a reason; every attempt is logged at warn level
+// with the reason and outcome so it can be audited. The blacklist itself is left
+// untouched, so every other call is still filtered.
+func (c *client) ExecuteBreakGlassCommand(ctx context.Context, commandName string, args []string, reason string) ([]byte, error) {
+	commandName = c.resolveCommandName(commandName)
+	reason = strings.TrimSpace(reason)
+
+	if !c.config.BreakGlass {
+		c.logger.Warn("Refused break-glass command, break-glass mode is disabled",
+			"audit", true,
+			"command", commandName,
+			"reason", reason)
+		return nil, fmt.Errorf("%w: %s is blacklisted", ErrBreakGlassDisabled, commandName)
+	}
+	if reason == "" {
+		return nil, ErrBreakGlassReasonRequired
+	}
+
+	pattern, blacklisted := c.blacklistMatch(commandName)
+	if !blacklisted {
+		return nil, fmt.Errorf("command %s is not blacklisted, run it without break-glass", commandName)
+	}
+	if err := c.validateCommandSyntax(commandName, args); err != nil {
+		return nil, fmt.Errorf("invalid command: %w", err)
+	}
+	if c.config.ReadOnly && !IsReadCommand(commandName) {
+		return nil, fmt.Errorf("%w: %s is not allowed", ErrReadOnlyMode, commandName)
+	}
+	scopeFilter, err := c.checkAppScope(commandName, args)
+	if err != nil {
+		return nil, err
+	}
+
+	c.logger.Warn("Running blacklisted command in break-glass mode",
+		"audit", true,
+		"command", commandName,
+		"args", c.newLogRedactor(commandName, args).values(args),
+		"blacklist_pattern", pattern,
+		"reason", reason)
+
+	output, err := c.executeCommandDirect(ctx, commandName, args)
+
+	// The command bypassed the cache and most likely changed state
+	c.cacheManager.Invalidate()
+
+	c.logger.Warn("Break-glass command finished",
+		"audit", true,
+		"command", commandName,
+		"reason", reason,
+		"success", err == nil)
+
+	return c.filterAppScope(output, scopeFilter), err
+}
@@ -0,0 +1,95 @@
+package dokkuApi
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+// newBreakGlassTestClient returns a client blacklisting "destroy" whose logs are captured
+func newBreakGlassTestClient(t *testing.T, breakGlass bool) (*client, *bytes.Buffer, *[]string) {
+	t.Helper()
+
+	var ran []string
+	c := newRunnerTestClient(t, 0, func(ctx context.Context, sshArgs []string, env []string, stdin []byte) ([]byte, error) {
+		ran = append(ran, strings.Join(sshArgs, " "))
+		return []byte("Destroying my-app (including all add-ons)"), nil
+	})
+
+	var logs bytes.Buffer
+	c.logger = slog.New(slog.NewTextHandler(&logs, nil))
+	c.config.BreakGlass = breakGlass
+	c.SetBlacklist([]string{"destroy"})
+
+	return c, &logs, &ran
+}
+
+func TestBreakGlassRefusedWhenDisabled(t *testing.T) {
+	c, logs, ran := newBreakGlassTestClient(t, false)
+
+	_, err := c.ExecuteBreakGlassCommand(context.Background(), "apps:destroy", []string{"my-app", "--force"}, "approved by on-call")
+	if !errors.Is(err, ErrBreakGlassDisabled) {
+		t.Fatalf("expected ErrBreakGlassDisabled, got %v", err)
+	}
+	if len(*ran) != 0 {
+		t.Fatalf("expected nothing to run, got %v", *ran)
+	}
+	if !strings.Contains(logs.String(), "Refused break-glass command") {
+		t.Fatalf("expected the refusal to be logged, got %q", logs.String())
+	}
+
+	if _, err := c.ExecuteCommand(context.Background(), "apps:destroy", []string{"my-app", "--force"}); err == nil {
+		t.Fatalf("expected the blacklist to still apply")
+	}
+}
+
+func TestBreakGlassRunsBlacklistedCommandAndAudits(t *testing.T) {
+	c, logs, ran := newBreakGlassTestClient(t, true)
+
+	if _, err := c.ExecuteBreakGlassCommand(context.Background(), "apps:destroy", []string{"my-app"}, "  "); !errors.Is(err, ErrBreakGlassReasonRequired) {
+		t.Fatalf("expected a missing reason to be refused, got %v", err)
+	}
+	if _, err := c.ExecuteBreakGlassCommand(context.Background(), "apps:list", nil, "just because"); err == nil {
+		t.Fatalf("expected a command that is not blacklisted to be refused")
+	}
+	if len(*ran) != 0 {
+		t.Fatalf("expected nothing to run before a valid request, got %v", *ran)
+	}
+
+	output, err := c.ExecuteBreakGlassCommand(context.Background(), "apps:destroy", []string{"my-app", "--force"}, "INC-42 approved by on-call")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(string(output), "Destroying my-app") || len(*ran) != 1 || !strings.Contains((*ran)[0], "apps:destroy my-app --force") {
+		t.Fatalf("expected apps:destroy to run once, got %q (%v)", output, *ran)
+	}
+
+	audit := logs.String()
+	for _, want := range []string{"level=WARN", "audit=true", "command=apps:destroy", `reason="INC-42 approved by on-call"`, `args="[my-app --force]"`, "blacklist_pattern=destroy", "success=true"} {
+		if !strings.Contains(audit, want) {
+			t.Errorf("expected audit log to contain %q, got %q", want, audit)
+		}
+	}
+
+	// The override covered that single call only
+	if _, err := c.ExecuteCommand(context.Background(), "apps:destroy", []string{"other-app", "--force"}); err == nil {
+		t.Fatalf("expected the blacklist to still apply to regular calls")
+	}
+}
+
+func TestBreakGlassAuditLogRedactsSecrets(t *testing.T) {
+	c, logs, _ := newBreakGlassTestClient(t, true)
+	c.SetBlacklist([]string{"config:set"})
+
+	if _, err := c.ExecuteBreakGlassCommand(context.Background(), "config:set", []string{"my-app", "DATABASE_URL=postgres://admin:s3cr3t@db"}, "INC-43 restore credentials"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	audit := logs.String()
+	if strings.Contains(audit, "s3cr3t") || !strings.Contains(audit, "DATABASE_URL=***") {
+		t.Fatalf("expected the audit log to name the arguments with their secrets masked, got %q", audit)
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"log/slog"
+	"slices"
+	"strings"
 	"time"
 )
 
@@ -74,13 +76,18 @@ func (cm *CommandCacheManager) Set(command string, args []string, result []byte,
 		return
 	}
 
-	key := cm.generateCacheKey(command, args)
-	ttl := cm.config.GetTTLForCommand(command)
-
 	cm.cache.mutex.Lock()
 	defer cm.cache.mutex.Unlock()
 
+	ttl := cm.config.GetTTLForCommand(command)
+	if ttl <= 0 {
+		return
+	}
+	key := cm.generateCacheKey(command, args)
+
 	cm.cache.entries[key] = &cacheEntry{
+		command:   command,
+		args:      slices.Clone(args),
 		result:    result,
 		error:     err,
 		expiresAt: time.Now().Add(ttl),
@@ -92,6 +99,23 @@ func (cm *CommandCacheManager) Set(command string, args []string, result []byte,
 		"ttl", ttl)
 }
 
+// SetDefaultTTL changes the TTL of commands without a policy. Entries already cached keep
+// their expiry; the cleanup interval follows the new TTL.
+func (cm *CommandCacheManager) SetDefaultTTL(ttl time.Duration) {
+	if cm == nil || ttl <= 0 {
+		return
+	}
+
+	cm.cache.mutex.Lock()
+	cm.config.DefaultTTL = ttl
+	cm.cache.mutex.Unlock()
+
+	if cm.cleanup != nil {
+		cm.cleanup.Reset(ttl / 2)
+	}
+	cm.logger.Debug("Cache default TTL updated", "default_ttl", ttl)
+}
+
 // Invalidate clears all cached entries
 func (cm *CommandCacheManager) Invalidate() {
 	if cm == nil {
@@ -105,6 +129,36 @@ func (cm *CommandCacheManager) Invalidate() {
 	cm.logger.Debug("Cache invalidated")
 }
 
+// InvalidateByApp drops the entries of commands that took appName as an argument,
+// leaving the cached results of other applications intact
+func (cm *CommandCacheManager) InvalidateByApp(appName string) {
+	if cm == nil || appName == "" {
+		return
+	}
+
+	removed := cm.invalidateMatching(func(entry *cacheEntry) bool {
+		return slices.Contains(entry.args, appName)
+	})
+	cm.logger.Debug("Cache invalidated for application",
+		"app_name", appName,
+		"count", removed)
+}
+
+// InvalidateByCommand drops the entries of commands starting with prefix
+// (e.g. "apps:list" or "config:")
+func (cm *CommandCacheManager) InvalidateByCommand(prefix string) {
+	if cm == nil || prefix == "" {
+		return
+	}
+
+	removed := cm.invalidateMatching(func(entry *cacheEntry) bool {
+		return strings.HasPrefix(entry.command, prefix)
+	})
+	cm.logger.Debug("Cache invalidated for command",
+		"prefix", prefix,
+		"count", removed)
+}
+
 // Stop stops the background cleanup process
 func (cm *CommandCacheManager) Stop() {
 	if cm != nil && cm.cleanup != nil {
@@ -114,16 +168,33 @@ func (cm *CommandCacheManager) Stop() {
 
 // Internal methods
 
-// generateCacheKey creates a unique key for command + args combination
+// generateCacheKey creates a unique key for command + args combination.
+// Parts are NUL separated so that e.g. ("ab", "c") and ("a", "bc") differ.
 func (cm *CommandCacheManager) generateCacheKey(command string, args []string) string {
 	hasher := sha256.New()
 	hasher.Write([]byte(command))
 	for _, arg := range args {
+		hasher.Write([]byte{0})
 		hasher.Write([]byte(arg))
 	}
 	return hex.EncodeToString(hasher.Sum(nil))[:16] // First 16 chars
 }
 
+// invalidateMatching removes the entries selected by match and returns how many were removed
+func (cm *CommandCacheManager) invalidateMatching(match func(entry *cacheEntry) bool) int {
+	cm.cache.mutex.Lock()
+	defer cm.cache.mutex.Unlock()
+
+	removed := 0
+	for key, entry := range cm.cache.entries {
+		if match(entry) {
+			delete(cm.cache.entries, key)
+			removed++
+		}
+	}
+	return removed
+}
+
 // startCleanup starts a background goroutine to clean expired entries
 func (cm *CommandCacheManager) startCleanup() {
 	cm.cleanup = time.NewTicker(cm.config.DefaultTTL / 2)
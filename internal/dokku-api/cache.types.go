@@ -7,9 +7,10 @@ import (
 
 // CacheConfig defines caching behavior
 type CacheConfig struct {
-	Enabled    bool                     `yaml:"enabled"`
-	DefaultTTL time.Duration            `yaml:"default_ttl"`
-	Policies   map[string]time.Duration `yaml:"policies,omitempty"`
+	Enabled    bool          `yaml:"enabled"`
+	DefaultTTL time.Duration `yaml:"default_ttl"`
+	// Policies overrides DefaultTTL per command; a zero TTL never caches the command
+	Policies map[string]time.Duration `yaml:"policies,omitempty"`
 }
 
 // DefaultCacheConfig returns sensible caching defaults
@@ -19,9 +20,14 @@ func DefaultCacheConfig() *CacheConfig {
 		DefaultTTL: 5 * time.Minute,
 		Policies: map[string]time.Duration{
 			// Fast-changing data - short cache
-			"logs":        30 * time.Second,
-			"ps:scale":    1 * time.Minute,
-			"apps:exists": 2 * time.Minute,
+			"logs":              30 * time.Second,
+			"nginx:access-logs": 30 * time.Second,
+			"nginx:error-logs":  30 * time.Second,
+			"ps:scale":          1 * time.Minute,
+			"apps:exists":       2 * time.Minute,
+
+			// Polled for new deploys, must always be read fresh
+			"events": 0,
 
 			// Semi-stable data - medium cache
 			"config:show":    5 * time.Minute,
@@ -43,8 +49,11 @@ func (c *CacheConfig) GetTTLForCommand(command string) time.Duration {
 	return c.DefaultTTL
 }
 
-// cacheEntry stores cached command results with TTL (internal to cache manager)
+// cacheEntry stores cached command results with TTL (internal to cache manager).
+// The command and args are kept so entries can be evicted selectively.
 type cacheEntry struct {
+	command   string
+	args      []string
 	result    []byte
 	error     error
 	expiresAt time.Time
@@ -0,0 +1,74 @@
+package dokkuApi
+
+import (
+	"io"
+	"log/slog"
+	"testing"
+)
+
+func newTestCacheManager(t *testing.T) *CommandCacheManager {
+	t.Helper()
+	manager := NewCommandCacheManager(DefaultCacheConfig(), slog.New(slog.NewTextHandler(io.Discard, nil)))
+	t.Cleanup(manager.Stop)
+	return manager
+}
+
+func TestInvalidateByAppKeepsOtherApps(t *testing.T) {
+	manager := newTestCacheManager(t)
+	manager.Set("apps:report", []string{"app-a"}, []byte("app-a report"), nil)
+	manager.Set("apps:report", []string{"app-b"}, []byte("app-b report"), nil)
+	manager.Set("config:show", []string{"app-a"}, []byte("A=1"), nil)
+	manager.Set("apps:list", nil, []byte("app-a\napp-b\n"), nil)
+
+	manager.InvalidateByApp("app-a")
+
+	if _, _, found := manager.Get("apps:report", []string{"app-a"}); found {
+		t.Fatal("expected app-a's apps:report to be invalidated")
+	}
+	if _, _, found := manager.Get("config:show", []string{"app-a"}); found {
+		t.Fatal("expected app-a's config:show to be invalidated")
+	}
+	if result, _, found := manager.Get("apps:report", []string{"app-b"}); !found || string(result) != "app-b report" {
+		t.Fatalf("expected app-b's apps:report to stay cached, got %q (found %v)", result, found)
+	}
+	if _, _, found := manager.Get("apps:list", nil); !found {
+		t.Fatal("expected apps:list to stay cached")
+	}
+}
+
+func TestInvalidateByCommandMatchesPrefix(t *testing.T) {
+	manager := newTestCacheManager(t)
+	manager.Set("config:show", []string{"app-a"}, []byte("A=1"), nil)
+	manager.Set("config:show", []string{"app-b"}, []byte("B=1"), nil)
+	manager.Set("apps:report", []string{"app-a"}, []byte("app-a report"), nil)
+
+	manager.InvalidateByCommand("config:")
+
+	if _, _, found := manager.Get("config:show", []string{"app-a"}); found {
+		t.Fatal("expected config:show for app-a to be invalidated")
+	}
+	if _, _, found := manager.Get("config:show", []string{"app-b"}); found {
+		t.Fatal("expected config:show for app-b to be invalidated")
+	}
+	if _, _, found := manager.Get("apps:report", []string{"app-a"}); !found {
+		t.Fatal("expected apps:report to stay cached")
+	}
+}
+
+func TestCacheKeySeparatesArguments(t *testing.T) {
+	manager := newTestCacheManager(t)
+	manager.Set("apps:report", []string{"ab", "c"}, []byte("first"), nil)
+
+	if _, _, found := manager.Get("apps:report", []string{"a", "bc"}); found {
+		t.Fatal("expected different argument splits to use different cache keys")
+	}
+}
+
+func TestEventsAreNeverCached(t *testing.T) {
+	manager := newTestCacheManager(t)
+	manager.Set("events", nil, []byte("deploy started"), nil)
+
+	if _, _, found := manager.Get("events", nil); found {
+		t.Fatal("expected the event log to be read fresh every time")
+	}
+}
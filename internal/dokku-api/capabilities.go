@@ -113,6 +113,30 @@ func (dc *DokkuCapabilities) AddJSONSupport(commandName string, supported bool)
 	dc.JSONSupport[commandName] = supported
 }
 
+// Pin seeds the capabilities from a known Dokku version instead of discovering them
+func (dc *DokkuCapabilities) Pin(version DokkuVersion) {
+	supportsJSON := version.Compare(jsonFormatMinVersion) >= 0
+
+	dc.mu.Lock()
+	dc.Version = version.String()
+	for _, cmd := range jsonFormatCommands {
+		dc.JSONSupport[cmd] = supportsJSON
+	}
+	dc.lastUpdated = time.Now()
+	dc.mu.Unlock()
+
+	for _, cmd := range jsonFormatCommands {
+		dc.CommandRegistry.Set(cmd, &CommandInfo{Name: cmd, SupportsJSON: supportsJSON})
+	}
+}
+
+// IsDiscovered reports whether the Dokku version is known, discovered from the host or pinned
+func (dc *DokkuCapabilities) IsDiscovered() bool {
+	dc.mu.RLock()
+	defer dc.mu.RUnlock()
+	return dc.Version != "" && dc.Version != "unknown"
+}
+
 // IsStale checks if the capabilities data is stale
 func (dc *DokkuCapabilities) IsStale(maxAge time.Duration) bool {
 	dc.mu.RLock()
@@ -203,6 +227,9 @@ func (c *client) discoverVersion(ctx context.Context) error {
 	}
 
 	version := strings.TrimSpace(string(output))
+	if parsed, err := ParseDokkuVersion(version); err == nil {
+		version = parsed.String()
+	}
 	c.capabilities.UpdateVersion(version)
 
 	c.logger.Debug("Discovered Dokku version", "version", version)
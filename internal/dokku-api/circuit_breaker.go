@@ -0,0 +1,181 @@
+package dokkuApi
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CircuitBreakerConfig defines when the client stops calling an unreachable Dokku host
+type CircuitBreakerConfig struct {
+	Enabled          bool          `yaml:"enabled"`
+	FailureThreshold int           `yaml:"failure_threshold"`
+	CoolDown         time.Duration `yaml:"cool_down"`
+}
+
+// DefaultCircuitBreakerConfig returns sensible circuit breaker defaults
+func DefaultCircuitBreakerConfig() *CircuitBreakerConfig {
+	return &CircuitBreakerConfig{
+		Enabled:          true,
+		FailureThreshold: 5,
+		CoolDown:         30 * time.Second,
+	}
+}
+
+// CircuitState is the state of the circuit breaker
+type CircuitState string
+
+const (
+	// CircuitClosed lets every command through
+	CircuitClosed CircuitState = "closed"
+	// CircuitOpen fast-fails every command until the cool-down elapses
+	CircuitOpen CircuitState = "open"
+	// CircuitHalfOpen lets a single probe command through
+	CircuitHalfOpen CircuitState = "half-open"
+)
+
+// CircuitBreaker fast-fails commands after repeated connection-level failures so a
+// down host does not make every tool call wait for the full command timeout
+type CircuitBreaker struct {
+	config   *CircuitBreakerConfig
+	logger   *slog.Logger
+	now      func() time.Time
+	mutex    sync.Mutex
+	state    CircuitState
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// NewCircuitBreaker creates a circuit breaker with the given configuration
+func NewCircuitBreaker(config *CircuitBreakerConfig, logger *slog.Logger) *CircuitBreaker {
+	if config == nil || !config.Enabled {
+		return nil
+	}
+
+	return &CircuitBreaker{
+		config: config,
+		logger: logger,
+		now:    time.Now,
+		state:  CircuitClosed,
+	}
+}
+
+// Allow reports whether a command may be sent to the host. When the circuit is
+// open it returns an error wrapping ErrSSHUnreachable.
+func (cb *CircuitBreaker) Allow() error {
+	if cb == nil {
+		return nil
+	}
+
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+
+	switch cb.state {
+	case CircuitOpen:
+		remaining := cb.config.CoolDown - cb.now().Sub(cb.openedAt)
+		if remaining > 0 {
+			return fmt.Errorf("%w: circuit open after %d consecutive connection failures, retry in %s",
+				ErrSSHUnreachable, cb.failures, remaining.Round(time.Second))
+		}
+		cb.state = CircuitHalfOpen
+		cb.probing = true
+		cb.logger.Info("Circuit breaker half-open, probing Dokku host")
+		return nil
+	case CircuitHalfOpen:
+		if cb.probing {
+			return fmt.Errorf("%w: waiting for probe command to complete", ErrSSHUnreachable)
+		}
+		cb.probing = true
+		return nil
+	default:
+		return nil
+	}
+}
+
+// RecordSuccess closes the circuit after the host answered a command
+func (cb *CircuitBreaker) RecordSuccess() {
+	if cb == nil {
+		return
+	}
+
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+
+	if cb.state != CircuitClosed {
+		cb.logger.Info("Circuit breaker closed, Dokku host reachable again")
+	}
+	cb.state = CircuitClosed
+	cb.failures = 0
+	cb.probing = false
+}
+
+// RecordFailure counts a connection-level failure and opens the circuit once
+// the threshold is reached or a half-open probe fails
+func (cb *CircuitBreaker) RecordFailure() {
+	if cb == nil {
+		return
+	}
+
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+
+	cb.failures++
+	cb.probing = false
+
+	if cb.state == CircuitHalfOpen || cb.failures >= cb.config.FailureThreshold {
+		if cb.state != CircuitOpen {
+			cb.logger.Warn("Circuit breaker opened, Dokku host unreachable",
+				"consecutive_failures", cb.failures,
+				"cool_down", cb.config.CoolDown)
+		}
+		cb.state = CircuitOpen
+		cb.openedAt = cb.now()
+	}
+}
+
+// State returns the current circuit state
+func (cb *CircuitBreaker) State() CircuitState {
+	if cb == nil {
+		return CircuitClosed
+	}
+
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+	return cb.state
+}
+
+// sshConnectionErrorMarkers are printed by ssh when the host cannot be reached
+var sshConnectionErrorMarkers = []string{
+	"connection refused",
+	"connection timed out",
+	"connection reset",
+	"no route to host",
+	"could not resolve hostname",
+	"network is unreachable",
+	"connection closed by",
+}
+
+// isConnectionFailure reports whether a command failed because the host could
+// not be reached, as opposed to Dokku reporting an error. A command timeout is
+// not one: a slow command on a reachable host must not open the circuit, and an
+// unreachable host already fails on the ssh ConnectTimeout with exit code 255.
+func isConnectionFailure(output []byte, execErr error) bool {
+	// ssh exits with 255 when the connection itself fails
+	var exitErr *exec.ExitError
+	if errors.As(execErr, &exitErr) && exitErr.ExitCode() == 255 {
+		return true
+	}
+
+	lower := strings.ToLower(string(output))
+	for _, marker := range sshConnectionErrorMarkers {
+		if strings.Contains(lower, "ssh:") && strings.Contains(lower, marker) {
+			return true
+		}
+	}
+	return false
+}
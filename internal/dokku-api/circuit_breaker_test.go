@@ -0,0 +1,137 @@
+package dokkuApi
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func newTestCircuitBreaker(threshold int, coolDown time.Duration) (*CircuitBreaker, *time.Time) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	cb := NewCircuitBreaker(&CircuitBreakerConfig{Enabled: true, FailureThreshold: threshold, CoolDown: coolDown}, logger)
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	cb.now = func() time.Time { return now }
+	return cb, &now
+}
+
+func TestCircuitBreakerOpensAfterThreshold(t *testing.T) {
+	cb, _ := newTestCircuitBreaker(3, time.Minute)
+
+	for i := 0; i < 2; i++ {
+		if err := cb.Allow(); err != nil {
+			t.Fatalf("closed circuit should allow commands, got %v", err)
+		}
+		cb.RecordFailure()
+	}
+	if cb.State() != CircuitClosed {
+		t.Fatalf("expected closed below threshold, got %s", cb.State())
+	}
+
+	cb.RecordFailure()
+	if cb.State() != CircuitOpen {
+		t.Fatalf("expected open at threshold, got %s", cb.State())
+	}
+
+	err := cb.Allow()
+	if !errors.Is(err, ErrSSHUnreachable) {
+		t.Fatalf("expected ErrSSHUnreachable while open, got %v", err)
+	}
+}
+
+func TestCircuitBreakerSuccessResetsFailures(t *testing.T) {
+	cb, _ := newTestCircuitBreaker(2, time.Minute)
+
+	cb.RecordFailure()
+	cb.RecordSuccess()
+	cb.RecordFailure()
+	if cb.State() != CircuitClosed {
+		t.Fatalf("expected non-consecutive failures to keep the circuit closed, got %s", cb.State())
+	}
+}
+
+func TestCircuitBreakerHalfOpenProbe(t *testing.T) {
+	t.Run("successful probe closes the circuit", func(t *testing.T) {
+		cb, now := newTestCircuitBreaker(1, time.Minute)
+		cb.RecordFailure()
+
+		*now = now.Add(time.Minute)
+		if err := cb.Allow(); err != nil {
+			t.Fatalf("expected probe to be allowed after cool-down, got %v", err)
+		}
+		if cb.State() != CircuitHalfOpen {
+			t.Fatalf("expected half-open during probe, got %s", cb.State())
+		}
+		if err := cb.Allow(); !errors.Is(err, ErrSSHUnreachable) {
+			t.Fatalf("expected concurrent command to fail fast during probe, got %v", err)
+		}
+
+		cb.RecordSuccess()
+		if cb.State() != CircuitClosed {
+			t.Fatalf("expected closed after successful probe, got %s", cb.State())
+		}
+		if err := cb.Allow(); err != nil {
+			t.Fatalf("expected closed circuit to allow commands, got %v", err)
+		}
+	})
+
+	t.Run("failed probe reopens the circuit", func(t *testing.T) {
+		cb, now := newTestCircuitBreaker(3, time.Minute)
+		for i := 0; i < 3; i++ {
+			cb.RecordFailure()
+		}
+
+		*now = now.Add(time.Minute)
+		if err := cb.Allow(); err != nil {
+			t.Fatalf("expected probe to be allowed after cool-down, got %v", err)
+		}
+		cb.RecordFailure()
+		if cb.State() != CircuitOpen {
+			t.Fatalf("expected open after failed probe, got %s", cb.State())
+		}
+		if err := cb.Allow(); !errors.Is(err, ErrSSHUnreachable) {
+			t.Fatalf("expected a new cool-down after failed probe, got %v", err)
+		}
+	})
+}
+
+func TestCircuitBreakerDisabled(t *testing.T) {
+	cb := NewCircuitBreaker(&CircuitBreakerConfig{Enabled: false}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	for i := 0; i < 10; i++ {
+		cb.RecordFailure()
+	}
+	if err := cb.Allow(); err != nil {
+		t.Fatalf("disabled breaker should never fail fast, got %v", err)
+	}
+}
+
+func TestIsConnectionFailure(t *testing.T) {
+	if !isConnectionFailure([]byte("ssh: connect to host dokku.example.com port 22: Connection refused"), errors.New("exit status 255")) {
+		t.Fatalf("expected ssh connection refused to be a connection failure")
+	}
+	if isConnectionFailure([]byte(" !     App my-app does not exist"), errors.New("exit status 1")) {
+		t.Fatalf("expected Dokku errors not to be connection failures")
+	}
+	if isConnectionFailure([]byte("-----> Building my-app..."), errors.New("signal: killed")) {
+		t.Fatalf("expected a killed slow command not to be a connection failure")
+	}
+}
+
+func TestCommandTimeoutsDoNotOpenCircuit(t *testing.T) {
+	c := newRunnerTestClient(t, 10*time.Millisecond, func(ctx context.Context, sshArgs []string, env []string, stdin []byte) ([]byte, error) {
+		<-ctx.Done()
+		return []byte("-----> Building my-app..."), errors.New("signal: killed")
+	})
+	c.circuitBreaker = NewCircuitBreaker(&CircuitBreakerConfig{Enabled: true, FailureThreshold: 2, CoolDown: time.Minute}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	for i := 0; i < 3; i++ {
+		if _, err := c.ExecuteCommand(context.Background(), "ps:rebuild", []string{"my-app"}); !errors.Is(err, ErrCommandTimeout) || errors.Is(err, ErrSSHUnreachable) {
+			t.Fatalf("expected a timeout, not an unreachable host, got %v", err)
+		}
+	}
+	if state := c.circuitBreaker.State(); state != CircuitClosed {
+		t.Fatalf("expected timeouts on a reachable host to keep the circuit closed, got %s", state)
+	}
+}
@@ -2,16 +2,30 @@ package dokkuApi
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"os/exec"
+	"slices"
 	"strings"
 	"syscall"
 	"time"
 )
 
+var (
+	// dangerousCommandChars could chain or substitute commands when placed in a command name
+	dangerousCommandChars = []string{";", "&", "|", "`", "$", "(", ")", "{", "}", "<", ">", "\n", "\r"}
+	// dangerousArgChars would split an argument into a second command line
+	dangerousArgChars = []string{"\n", "\r"}
+)
+
+// dokkuForcedCommandUser is the SSH user whose logins run Dokku's forced command,
+// which word-splits the command line without handing it to a shell
+const dokkuForcedCommandUser = "dokku"
+
 // isAppScopedCommand returns true for commands that target a specific app
 func isAppScopedCommand(commandName string) bool {
 	return strings.HasPrefix(commandName, "apps:") || strings.HasPrefix(commandName, "ps:") || commandName == "logs"
@@ -24,26 +38,49 @@ func (c *client) ValidateCommand(commandName string, args []string) error {
 	}
 
 	// Blacklist first (runtime configuration)
+	if pattern, blacklisted := c.blacklistMatch(commandName); blacklisted {
+		return fmt.Errorf("command is blacklisted (matches pattern '%s'): %s", pattern, commandName)
+	}
+
+	return c.validateCommandSyntax(commandName, args)
+}
+
+// blacklistMatch returns the first blacklist pattern contained in commandName
+func (c *client) blacklistMatch(commandName string) (string, bool) {
+	c.blacklistMu.RLock()
+	defer c.blacklistMu.RUnlock()
 	for _, blacklistedPattern := range c.blacklistedCommands {
 		if strings.Contains(commandName, blacklistedPattern) {
-			return fmt.Errorf("command is blacklisted (matches pattern '%s'): %s", blacklistedPattern, commandName)
+			return blacklistedPattern, true
 		}
 	}
+	return "", false
+}
 
+// validateCommandSyntax rejects command names and arguments that could inject shell commands.
+// When connecting as the dokku user, arguments are only checked for line breaks: the ssh
+// binary is run from an argv array without a local shell, and Dokku's forced command
+// word-splits the command line without evaluating it, so values such as passwords or
+// JSON may contain $, (, ) or {}. A line break would end the command line early. Any
+// other user gets a login shell evaluating the command line, so arguments get the same
+// strict check as command names.
+func (c *client) validateCommandSyntax(commandName string, args []string) error {
 	// Basic security validation - ensure no dangerous characters in command name
 	// These characters could be used for command injection
-	dangerousChars := []string{";", "&", "|", "`", "$", "(", ")", "{", "}", "<", ">", "\n", "\r"}
-	for _, char := range dangerousChars {
+	for _, char := range dangerousCommandChars {
 		if strings.Contains(commandName, char) {
 			return fmt.Errorf("command name contains dangerous character '%s': %s", char, commandName)
 		}
 	}
 
-	// Validate arguments - ensure no dangerous characters
+	argChars := dangerousCommandChars
+	if c.config.DokkuUser == dokkuForcedCommandUser {
+		argChars = dangerousArgChars
+	}
 	for i, arg := range args {
-		for _, char := range dangerousChars {
+		for _, char := range argChars {
 			if strings.Contains(arg, char) {
-				return fmt.Errorf("argument %d contains dangerous character '%s': %s", i, char, arg)
+				return fmt.Errorf("argument %d contains dangerous character %q", i, char)
 			}
 		}
 	}
@@ -84,29 +121,67 @@ func NewDokkuClient(config *ClientConfig, logger *slog.Logger) DokkuClient {
 	}
 
 	// Create SSH connection manager
-	sshConnManager := NewSSHConnectionManager(sshConfig, logger)
+	authService := NewSSHAuthServiceWithConfig(logger, &SSHAuthConfig{
+		StrictKeyPermissions: config.StrictKeyPermissions,
+		MethodOrder:          config.AuthMethods,
+	})
+	sshConnManager := NewSSHConnectionManagerWithAuth(sshConfig, authService, logger)
+	if err := sshConnManager.SetCommandEnvironment(config.CommandPath, config.CommandEnv); err != nil {
+		logger.Error("Invalid command environment, keeping the default one", "error", err)
+	}
 
 	client := &client{
 		config:         config,
 		logger:         logger,
 		sshConnManager: sshConnManager,
 		capabilities:   NewDokkuCapabilities(),
+		runner:         runSSHCommand,
 	}
 
 	// Initialize cache manager if caching is enabled
 	client.cacheManager = NewCommandCacheManager(config.Cache, logger)
+	client.circuitBreaker = NewCircuitBreaker(config.CircuitBreaker, logger)
+	client.rateLimiter = NewRateLimiter(config.RateLimit)
+
+	// A pinned version replaces discovery entirely
+	if client.pinCapabilities(config.DokkuVersion) {
+		return client
+	}
 
 	// Discover Dokku capabilities in the background
 	// This is non-blocking and will update capabilities asynchronously
+	startDiscovery(client)
+
+	return client
+}
+
+// startDiscovery runs capability discovery in the background; replaced in tests
+var startDiscovery = func(c *client) {
 	go func() {
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
-		if err := client.DiscoverCapabilities(ctx); err != nil {
-			logger.Warn("Failed to discover Dokku capabilities", "error", err)
+		if err := c.DiscoverCapabilities(ctx); err != nil {
+			c.logger.Warn("Failed to discover Dokku capabilities", "error", err)
 		}
 	}()
+}
 
-	return client
+// pinCapabilities seeds capabilities from a configured Dokku version.
+// It returns false when no version is pinned or it cannot be parsed, in which case discovery runs as usual.
+func (c *client) pinCapabilities(version string) bool {
+	if version == "" {
+		return false
+	}
+
+	parsed, err := ParseDokkuVersion(version)
+	if err != nil {
+		c.logger.Error("Ignoring pinned Dokku version, falling back to discovery", "error", err)
+		return false
+	}
+
+	c.capabilities.Pin(parsed)
+	c.logger.Info("Using pinned Dokku version, capability discovery disabled", "version", parsed.String())
+	return true
 }
 
 func (c *client) GetSSHConnectionManager() *SSHConnectionManager {
@@ -114,26 +189,72 @@ func (c *client) GetSSHConnectionManager() *SSHConnectionManager {
 }
 
 func (c *client) ExecuteCommand(ctx context.Context, commandName string, args []string) ([]byte, error) {
+	// Validation and the blacklist apply to the concrete command that will run
+	commandName = c.resolveCommandName(commandName)
 	if err := c.ValidateCommand(commandName, args); err != nil {
 		return nil, fmt.Errorf("invalid command: %w", err)
 	}
+	if c.config.ReadOnly && !IsReadCommand(commandName) {
+		c.logger.Warn("Blocked mutating command in read-only mode", "command", commandName)
+		return nil, fmt.Errorf("%w: %s is not allowed", ErrReadOnlyMode, commandName)
+	}
+	scopeFilter, err := c.checkAppScope(commandName, args)
+	if err != nil {
+		return nil, err
+	}
 
 	// Check cache first if caching is enabled
 	if result, err, found := c.cacheManager.Get(commandName, args); found {
-		return result, err
+		return c.filterAppScope(result, scopeFilter), err
+	}
+
+	// Execute command, retrying transient connection failures when allowed
+	result, err := c.executeWithRetry(ctx, commandName, args, nil)
+
+	// Cache the result if caching is enabled; unreachable-host errors are
+	// transient and must not outlive the circuit breaker cool-down
+	if !errors.Is(err, ErrSSHUnreachable) {
+		c.cacheManager.Set(commandName, args, result, err)
 	}
 
-	// Execute command
-	result, err := c.executeCommandDirect(ctx, commandName, args)
+	return c.filterAppScope(result, scopeFilter), err
+}
 
-	// Cache the result if caching is enabled
-	c.cacheManager.Set(commandName, args, result, err)
+// ExecuteCommandWithInput runs a command with stdin fed from input, for values that
+// must not appear on the command line (keys, passwords). It goes through the same
+// validation and read-only checks as ExecuteCommand but is never cached. Only the
+// arguments are checked for dangerous characters: stdin never becomes part of the
+// command line, so a password may contain any character, and it is never logged.
+func (c *client) ExecuteCommandWithInput(ctx context.Context, commandName string, args []string, stdin []byte) ([]byte, error) {
+	commandName = c.resolveCommandName(commandName)
+	if err := c.ValidateCommand(commandName, args); err != nil {
+		return nil, fmt.Errorf("invalid command: %w", err)
+	}
+	if c.config.ReadOnly && !IsReadCommand(commandName) {
+		c.logger.Warn("Blocked mutating command in read-only mode", "command", commandName)
+		return nil, fmt.Errorf("%w: %s is not allowed", ErrReadOnlyMode, commandName)
+	}
+	scopeFilter, err := c.checkAppScope(commandName, args)
+	if err != nil {
+		return nil, err
+	}
 
-	return result, err
+	output, err := c.executeWithRetry(ctx, commandName, args, stdin)
+	return c.filterAppScope(output, scopeFilter), err
 }
 
 // executeCommandDirect performs the actual command execution without caching
 func (c *client) executeCommandDirect(ctx context.Context, commandName string, args []string) ([]byte, error) {
+	return c.executeCommandDirectWithInput(ctx, commandName, args, nil)
+}
+
+// executeCommandDirectWithInput performs the command execution with stdin fed from input (nil for none)
+func (c *client) executeCommandDirectWithInput(ctx context.Context, commandName string, args []string, stdin []byte) ([]byte, error) {
+	// Waiting for the limiter does not count against the command timeout
+	if err := c.rateLimiter.Wait(ctx); err != nil {
+		return nil, fmt.Errorf("rate-limited command %s was cancelled: %w", commandName, err)
+	}
+
 	cmdCtx, cancel := c.commandContext(ctx)
 	defer cancel()
 
@@ -144,14 +265,30 @@ func (c *client) executeCommandDirect(ctx context.Context, commandName string, a
 		return nil, fmt.Errorf("failed to prepare SSH command: %w", err)
 	}
 
-	cmd, err := prepareSSHExecCommand(cmdCtx, sshArgs, env)
-	if err != nil {
-		return nil, fmt.Errorf("failed to prepare SSH command: %w", err)
+	if len(sshArgs) == 0 {
+		return nil, fmt.Errorf("failed to prepare SSH command: no SSH arguments provided")
+	}
+
+	if err := c.circuitBreaker.Allow(); err != nil {
+		return nil, err
 	}
 
 	c.logCommandExecutionStart(cmdCtx, commandName, args, dokkuCommand, sshArgs, env)
 
-	output, execErr := cmd.CombinedOutput()
+	output, execErr := c.runner(cmdCtx, sshArgs, env, stdin)
+	if execErr != nil && errors.Is(cmdCtx.Err(), context.DeadlineExceeded) {
+		execErr = fmt.Errorf("%w: %w", ErrCommandTimeout, execErr)
+	}
+	connectionFailure := execErr != nil && isConnectionFailure(output, execErr)
+	if connectionFailure {
+		c.circuitBreaker.RecordFailure()
+		// Typed so callers (and the retry policy) can tell the host was not reached
+		execErr = fmt.Errorf("%w: %w", ErrSSHUnreachable, execErr)
+		c.connection.record(time.Now(), execErr)
+	} else {
+		c.circuitBreaker.RecordSuccess()
+		c.connection.record(time.Now(), nil)
+	}
 	if execErr != nil {
 		return c.handleCommandError(cmdCtx, commandName, args, dokkuCommand, sshArgs, env, output, execErr)
 	}
@@ -163,7 +300,37 @@ func (c *client) executeCommandDirect(ctx context.Context, commandName string, a
 	return output, nil
 }
 
+// commandRunner runs a prepared SSH command, feeding stdin to it when not nil, and
+// returns its combined output. The client holds one so timeouts and cancellation
+// can be tested without SSH.
+type commandRunner func(ctx context.Context, sshArgs []string, env []string, stdin []byte) ([]byte, error)
+
+// runSSHCommand is the commandRunner executing the ssh binary
+func runSSHCommand(ctx context.Context, sshArgs []string, env []string, stdin []byte) ([]byte, error) {
+	cmd, err := prepareSSHExecCommand(ctx, sshArgs, env)
+	if err != nil {
+		return nil, err
+	}
+	if stdin != nil {
+		cmd.Stdin = bytes.NewReader(stdin)
+	}
+	if sink := OutputSinkFromContext(ctx); sink != nil {
+		return runStreaming(cmd, sink)
+	}
+	return cmd.CombinedOutput()
+}
+
+// commandTimeoutKey carries the timeout of a CommandSpec from ExecuteStructured to the command execution
+type commandTimeoutKey struct{}
+
+// commandContext bounds a command by CommandTimeout unless the caller already set
+// a deadline; a zero CommandTimeout leaves the command bounded only by the caller.
+// A CommandSpec timeout longer than CommandTimeout replaces it and is applied even
+// under a caller deadline, whichever comes first.
 func (c *client) commandContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if specTimeout, ok := ctx.Value(commandTimeoutKey{}).(time.Duration); ok && c.config.CommandTimeout > 0 {
+		return context.WithTimeout(ctx, max(specTimeout, c.config.CommandTimeout))
+	}
 	if _, hasDeadline := ctx.Deadline(); hasDeadline {
 		return ctx, func() {}
 	}
@@ -236,12 +403,13 @@ func (c *client) buildCommand(ctx context.Context, args []string) (*exec.Cmd, fu
 }
 
 func (c *client) logCommandExecutionStart(ctx context.Context, commandName string, args []string, dokkuCommand string, sshArgs []string, env []string) {
+	redact := c.newLogRedactor(commandName, args)
 	c.logger.Debug("Executing Dokku command via SSH",
 		"command", commandName,
-		"args", args,
-		"dokku_command", dokkuCommand,
+		"args", redact.values(args),
+		"dokku_command", redact.value(dokkuCommand),
 		"ssh_target", c.sshConnManager.Config().ConnectionString(),
-		"ssh_args", sshArgs,
+		"ssh_args", redact.values(sshArgs),
 		"env", env,
 		"timeout", c.config.CommandTimeout,
 		"context_deadline_ok", ctx.Err() == nil,
@@ -250,11 +418,12 @@ func (c *client) logCommandExecutionStart(ctx context.Context, commandName strin
 
 func (c *client) handleCommandError(ctx context.Context, commandName string, args []string, dokkuCommand string, sshArgs []string, env []string, output []byte, execErr error) ([]byte, error) {
 	if isUnsupportedJSONProbe(args, output, commandName) {
+		redact := c.newLogRedactor(commandName, args)
 		c.logger.Debug("JSON format not supported for command (probe)",
 			"command", commandName,
-			"args", args,
-			"dokku_command", dokkuCommand,
-			"combined_output", string(output))
+			"args", redact.values(args),
+			"dokku_command", redact.value(dokkuCommand),
+			"combined_output", redact.value(string(output)))
 		return nil, fmt.Errorf("failed to execute Dokku command %s: %w", commandName, execErr)
 	}
 
@@ -266,11 +435,47 @@ func (c *client) handleCommandError(ctx context.Context, commandName string, arg
 	c.logCommandFailure(ctx, commandName, args, dokkuCommand, sshArgs, env, output, execErr)
 	c.logExitDetails(execErr)
 
+	return nil, classifyCommandError(commandName, output, execErr)
+}
+
+// failureMarkers map the messages of common Dokku failures to their typed errors.
+// Output matching one of the exceptions is not that failure: an SSH key refused by
+// a git remote ("Permission denied (publickey)") is not a file permission problem.
+var failureMarkers = []struct {
+	sentinel   error
+	markers    []string
+	exceptions []string
+}{
+	{ErrPluginNotInstalled, []string{"is not a dokku command"}, nil},
+	{ErrPermissionDenied, []string{"permission denied", "operation not permitted", "access denied", "must be run as root"}, []string{"permission denied (publickey"}},
+	{ErrQuotaExceeded, []string{"quota exceeded", "no space left on device"}, nil},
+}
+
+// classifyCommandError turns a failed command into a typed error: a NotFoundError
+// for missing apps, a CommandError wrapping ErrAppNotDeployed or one of the
+// failureMarkers sentinels when the output names the cause, or a plain CommandError
+func classifyCommandError(commandName string, output []byte, execErr error) error {
 	if shouldWrapNotFound(commandName, output) {
-		return nil, fmt.Errorf("failed to execute Dokku command %s: %w", commandName, &NotFoundError{Command: commandName, Err: ErrAppNotFound})
+		return fmt.Errorf("failed to execute Dokku command %s: %w", commandName, &NotFoundError{Command: commandName, Err: ErrAppNotFound})
+	}
+
+	lower := strings.ToLower(string(output))
+	if isNotDeployedOutput(lower) {
+		return &CommandError{Command: commandName, Output: output, Err: fmt.Errorf("%w: %v", ErrAppNotDeployed, execErr)}
 	}
 
-	return nil, fmt.Errorf("failed to execute Dokku command %s: %w", commandName, execErr)
+	for _, failure := range failureMarkers {
+		if slices.ContainsFunc(failure.exceptions, func(exception string) bool { return strings.Contains(lower, exception) }) {
+			continue
+		}
+		for _, marker := range failure.markers {
+			if strings.Contains(lower, marker) {
+				return &CommandError{Command: commandName, Output: output, Err: fmt.Errorf("%w: %v", failure.sentinel, execErr)}
+			}
+		}
+	}
+
+	return &CommandError{Command: commandName, Output: output, Err: execErr}
 }
 
 func isUnsupportedJSONProbe(args []string, output []byte, commandName string) bool {
@@ -300,26 +505,26 @@ func shouldReturnEmptyLogs(commandName string, output []byte) bool {
 	if commandName != "logs" {
 		return false
 	}
-	lower := strings.ToLower(string(output))
-	return strings.Contains(lower, "has not been deployed")
+	return isNotDeployedOutput(strings.ToLower(string(output)))
 }
 
 func (c *client) logCommandFailure(ctx context.Context, commandName string, args []string, dokkuCommand string, sshArgs []string, env []string, output []byte, execErr error) {
 	logFn := c.logger.Error
 	lower := strings.ToLower(string(output))
-	if isAppScopedCommand(commandName) && isNotFoundOutput(lower) {
+	if (isAppScopedCommand(commandName) && isNotFoundOutput(lower)) || isNotDeployedOutput(lower) {
 		logFn = c.logger.Warn
 	}
 
+	redact := c.newLogRedactor(commandName, args)
 	logFn("Failed to execute Dokku command",
 		"error", execErr,
 		"command", commandName,
-		"args", args,
-		"dokku_command", dokkuCommand,
-		"ssh_args", sshArgs,
+		"args", redact.values(args),
+		"dokku_command", redact.value(dokkuCommand),
+		"ssh_args", redact.values(sshArgs),
 		"env", env,
 		"context_error", ctx.Err(),
-		"combined_output", string(output),
+		"combined_output", redact.value(string(output)),
 		"connection_info", c.sshConnManager.GetConnectionInfo())
 }
 
@@ -338,46 +543,106 @@ func shouldWrapNotFound(commandName string, output []byte) bool {
 }
 
 func isNotFoundOutput(lowerOutput string) bool {
-	if strings.Contains(lowerOutput, "does not exist") || strings.Contains(lowerOutput, "has not been deployed") {
+	if strings.Contains(lowerOutput, "does not exist") {
 		return true
 	}
 	return strings.Contains(lowerOutput, "docker options phase file") && strings.Contains(lowerOutput, "no such file or directory")
 }
 
+// notDeployedMarkers are the messages Dokku prints when an existing app has no deployed release
+// (e.g. logs, ps:restart, ps:rebuild or ps:start on a freshly created app)
+var notDeployedMarkers = []string{
+	"has not been deployed",
+	"is not deployed",
+	"not deployed yet",
+}
+
+func isNotDeployedOutput(lowerOutput string) bool {
+	for _, marker := range notDeployedMarkers {
+		if strings.Contains(lowerOutput, marker) {
+			return true
+		}
+	}
+	return false
+}
+
 // InvalidateCache clears all cached entries (delegates to cache manager)
 func (c *client) InvalidateCache() {
 	c.cacheManager.Invalidate()
 }
 
+// InvalidateByApp clears the cached entries of one application (delegates to cache manager)
+func (c *client) InvalidateByApp(appName string) {
+	c.cacheManager.InvalidateByApp(appName)
+}
+
+// InvalidateByCommand clears the cached entries of matching commands (delegates to cache manager).
+// Command aliases are resolved so the prefix matches the commands that actually ran.
+func (c *client) InvalidateByCommand(prefix string) {
+	c.cacheManager.InvalidateByCommand(c.resolveCommandName(prefix))
+}
+
 // SetBlacklist sets the blacklisted commands for runtime security configuration
 func (c *client) SetBlacklist(commands []string) {
-	c.blacklistedCommands = commands
+	c.blacklistMu.Lock()
+	c.blacklistedCommands = slices.Clone(commands)
+	c.blacklistMu.Unlock()
 	c.logger.Debug("Command blacklist updated", "patterns", commands) // Audit trail
 }
 
+// SetCacheTTL changes the default lifetime of cached command results (delegates to cache manager)
+func (c *client) SetCacheTTL(ttl time.Duration) {
+	c.cacheManager.SetDefaultTTL(ttl)
+}
+
 // Enhanced parsing methods
 
 // ExecuteStructured executes a command with automatic parsing based on the spec
 func (c *client) ExecuteStructured(ctx context.Context, spec CommandSpec) (*CommandResult, error) {
+	if spec.Timeout > 0 {
+		ctx = context.WithValue(ctx, commandTimeoutKey{}, spec.Timeout)
+	}
+
 	output, err := c.ExecuteCommand(ctx, spec.Command, spec.Args)
 	if err != nil {
 		return nil, fmt.Errorf("command execution failed: %w", err)
 	}
 
+	result, err := parseStructuredOutput(spec, output)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(result.Warnings) > 0 {
+		c.logger.Debug("Dokku printed warnings alongside command output",
+			"command", spec.Command,
+			"warnings", result.Warnings)
+	}
+
+	return result, nil
+}
+
+// parseStructuredOutput parses command output according to the spec. Warning banners
+// printed before or after the results (e.g. deprecation notices) are moved to
+// Warnings so they do not corrupt the parsed data; RawOutput is left untouched.
+func parseStructuredOutput(spec CommandSpec, output []byte) (*CommandResult, error) {
+	body, warnings := StripWarningLines(string(output))
+
 	result := &CommandResult{
 		RawOutput: output,
+		Warnings:  warnings,
 		ParsedAt:  time.Now(),
 	}
 
 	switch spec.OutputFormat {
 	case OutputFormatJSON:
-		result.JSONData = output
+		result.JSONData = json.RawMessage(body)
 	case OutputFormatKeyValue:
-		result.KeyValueData = ParseKeyValueOutput(string(output), spec.Separator)
+		result.KeyValueData = ParseKeyValueOutput(body, spec.Separator)
 	case OutputFormatList:
-		result.ListData = ParseListOutput(string(output), spec.FilterEmpty)
+		result.ListData = ParseListOutput(body, spec.FilterEmpty)
 	case OutputFormatTable:
-		result.TableData = ParseTableOutput(string(output), spec.SkipHeaders)
+		result.TableData = ParseTableOutput(body, spec.SkipHeaders)
 	case OutputFormatRaw:
 		// Raw output is already stored in RawOutput
 	default:
@@ -412,16 +677,12 @@ func (c *client) ExecuteWithAutoFormat(ctx context.Context, commandName string,
 			c.capabilities.CommandRegistry.Set(commandName, &CommandInfo{Name: commandName, SupportsJSON: false})
 			// Fall through to text parsing
 		} else {
-			// Validate it's actually JSON
-			if json.Valid(output) {
+			// Validate it's actually JSON (an array for list commands)
+			if result, ok := jsonFormatResult(commandName, output); ok {
 				// Persist confirmed JSON capability
 				c.capabilities.AddJSONSupport(commandName, true)
 				c.capabilities.CommandRegistry.Set(commandName, &CommandInfo{Name: commandName, SupportsJSON: true})
-				return &CommandResult{
-					RawOutput: output,
-					JSONData:  output,
-					ParsedAt:  time.Now(),
-				}, nil
+				return result, nil
 			}
 			c.logger.Warn("Command returned non-JSON output despite --format json flag",
 				"command", commandName)
@@ -431,21 +692,19 @@ func (c *client) ExecuteWithAutoFormat(ctx context.Context, commandName string,
 		}
 	}
 
-	// Opportunistic probe: for report/info commands with unknown capability, try JSON once
-	if !supportsJSON && (strings.Contains(commandName, ":report") || strings.Contains(commandName, ":info")) {
-		c.logger.Debug("Opportunistic JSON probe for report/info command",
+	// Opportunistic probe: for report/info/list commands with unknown capability, try JSON once
+	if !supportsJSON && (strings.Contains(commandName, ":report") || strings.Contains(commandName, ":info") || isListCommand(commandName)) {
+		c.logger.Debug("Opportunistic JSON probe for report/info/list command",
 			"command", commandName)
 		jsonArgs := append(args, "--format", "json")
 		output, err := c.ExecuteCommand(ctx, commandName, jsonArgs)
-		if err == nil && json.Valid(output) {
-			// Persist confirmed support and return
-			c.capabilities.AddJSONSupport(commandName, true)
-			c.capabilities.CommandRegistry.Set(commandName, &CommandInfo{Name: commandName, SupportsJSON: true})
-			return &CommandResult{
-				RawOutput: output,
-				JSONData:  output,
-				ParsedAt:  time.Now(),
-			}, nil
+		if err == nil {
+			if result, ok := jsonFormatResult(commandName, output); ok {
+				// Persist confirmed support and return
+				c.capabilities.AddJSONSupport(commandName, true)
+				c.capabilities.CommandRegistry.Set(commandName, &CommandInfo{Name: commandName, SupportsJSON: true})
+				return result, nil
+			}
 		}
 		// On failure, persist negative to avoid repeated probes
 		c.capabilities.AddJSONSupport(commandName, false)
@@ -471,7 +730,7 @@ func (c *client) ExecuteWithAutoFormat(ctx context.Context, commandName string,
 	}
 
 	// Default intelligent parsing based on command patterns
-	if strings.Contains(commandName, ":list") {
+	if isListCommand(commandName) {
 		result.ListData = ParseListOutput(string(output), true)
 	} else if strings.Contains(commandName, ":report") || strings.Contains(commandName, ":info") {
 		result.KeyValueData = ParseKeyValueOutput(string(output), ":")
@@ -482,6 +741,36 @@ func (c *client) ExecuteWithAutoFormat(ctx context.Context, commandName string,
 	return result, nil
 }
 
+// isListCommand reports whether a command lists items (e.g. apps:list, domains:list)
+func isListCommand(commandName string) bool {
+	return strings.Contains(commandName, ":list")
+}
+
+// jsonFormatResult builds the result of a --format json call. List commands
+// must return a JSON array, which is also flattened into ListData; anything
+// else is rejected so the caller falls back to text parsing.
+func jsonFormatResult(commandName string, output []byte) (*CommandResult, bool) {
+	if !json.Valid(output) {
+		return nil, false
+	}
+
+	result := &CommandResult{
+		RawOutput: output,
+		JSONData:  output,
+		ParsedAt:  time.Now(),
+	}
+
+	if isListCommand(commandName) {
+		items, ok := ParseJSONListOutput(output)
+		if !ok {
+			return nil, false
+		}
+		result.ListData = items
+	}
+
+	return result, true
+}
+
 // GetKeyValueOutput executes a command and parses key-value output
 func (c *client) GetKeyValueOutput(ctx context.Context, command string, args []string, separator string) (map[string]string, error) {
 	spec := CommandSpec{
@@ -533,6 +822,17 @@ func (c *client) GetTableOutput(ctx context.Context, command string, args []stri
 	return result.TableData, nil
 }
 
+// ParseLogTimestamp reads the leading RFC3339 timestamp of a Dokku log line.
+// It reports false for lines without one, such as continuation lines of a multi-line message.
+func ParseLogTimestamp(line string) (time.Time, bool) {
+	field, _, _ := strings.Cut(line, " ")
+	timestamp, err := time.Parse(time.RFC3339Nano, field)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return timestamp, true
+}
+
 // parseLogLine parses a Dokku log line
 // Format: "2025-12-13T01:30:00.000000000Z app[web.1]: message"
 func parseLogLine(line string) LogLine {
@@ -545,8 +845,8 @@ func parseLogLine(line string) LogLine {
 		}
 	}
 
-	timestamp, err := time.Parse(time.RFC3339Nano, parts[0])
-	if err != nil {
+	timestamp, ok := ParseLogTimestamp(line)
+	if !ok {
 		// Fall back to current time if parsing fails
 		timestamp = time.Now()
 	}
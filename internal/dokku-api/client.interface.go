@@ -1,12 +1,25 @@
 package dokkuApi
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // CommandExecutor defines the core command execution capability
 type CommandExecutor interface {
 	ExecuteCommand(ctx context.Context, command string, args []string) ([]byte, error)
 }
 
+// InputExecutor runs commands reading a value from stdin instead of their arguments
+type InputExecutor interface {
+	ExecuteCommandWithInput(ctx context.Context, command string, args []string, stdin []byte) ([]byte, error)
+}
+
+// StreamingExecutor runs commands whose output is read as it arrives, e.g. to report the progress of a deploy
+type StreamingExecutor interface {
+	ExecuteCommandStreaming(ctx context.Context, command string, args []string) (<-chan []byte, error)
+}
+
 // CommandParser defines parsing capabilities for different output formats
 type CommandParser interface {
 	GetKeyValueOutput(ctx context.Context, command string, args []string, separator string) (map[string]string, error)
@@ -31,21 +44,52 @@ type SSHManager interface {
 	GetSSHConnectionManager() *SSHConnectionManager
 }
 
+// ConnectionMonitor reports whether the Dokku host is currently reachable
+type ConnectionMonitor interface {
+	ConnectionStatus() ConnectionStatus
+}
+
 // CommandFilter defines command filtering/security capabilities
 type CommandFilter interface {
 	SetBlacklist(commands []string)
 	ValidateCommand(command string, args []string) error
 }
 
+// CacheInvalidator drops cached command results, e.g. after a change they may no longer reflect
+type CacheInvalidator interface {
+	InvalidateCache()
+	// InvalidateByApp only drops the results of commands run against appName
+	InvalidateByApp(appName string)
+	// InvalidateByCommand only drops the results of commands starting with prefix
+	InvalidateByCommand(prefix string)
+}
+
+// CacheTuner adjusts the command cache at runtime, e.g. on a configuration reload
+type CacheTuner interface {
+	// SetCacheTTL changes the lifetime of results cached from now on; per-command policies are kept
+	SetCacheTTL(ttl time.Duration)
+}
+
+// BreakGlassExecutor runs a single blacklisted command when break-glass mode is enabled
+type BreakGlassExecutor interface {
+	ExecuteBreakGlassCommand(ctx context.Context, command string, args []string, reason string) ([]byte, error)
+}
+
 // DokkuClient combines all Dokku-specific capabilities
 // This is the "convenience interface" that most consumers will use
 type DokkuClient interface {
 	CommandExecutor
+	InputExecutor
+	StreamingExecutor
 	CommandParser
 	StructuredExecutor
 	CapabilityManager
 	SSHManager
+	ConnectionMonitor
 	CommandFilter
+	BreakGlassExecutor
+	CacheInvalidator
+	CacheTuner
 }
 
 // For consumers that only need basic execution (better testability)
@@ -3,7 +3,10 @@ package dokkuApi
 import (
 	"encoding/json"
 	"log/slog"
+	"sync"
 	"time"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
 )
 
 // OutputFormat represents different output parsing strategies
@@ -24,6 +27,7 @@ type CommandResult struct {
 	ListData     []string
 	TableData    []map[string]string
 	JSONData     json.RawMessage
+	Warnings     []string // warning/deprecation lines Dokku printed around the results
 	ParsedAt     time.Time
 }
 
@@ -35,6 +39,9 @@ type CommandSpec struct {
 	Separator    string // for key-value parsing (e.g., ":", "=")
 	SkipHeaders  bool   // for table parsing
 	FilterEmpty  bool   // skip empty lines
+	// Timeout raises CommandTimeout for commands known to be slow (e.g. git:sync); it never lowers it.
+	// Unlike CommandTimeout it also applies within a longer caller deadline.
+	Timeout time.Duration
 }
 
 // LogOptions configures log retrieval
@@ -59,10 +66,35 @@ type ClientConfig struct {
 	DokkuPort      int           `yaml:"dokku_port"`
 	DokkuUser      string        `yaml:"dokku_user"`
 	DokkuPath      string        `yaml:"dokku_path"`
+	DokkuVersion   string        `yaml:"dokku_version"`
 	SSHKeyPath     string        `yaml:"ssh_key_path"`
 	CommandTimeout time.Duration `yaml:"command_timeout"`
 	DisablePTY     bool          `yaml:"disable_pty"`
-	Cache          *CacheConfig  `yaml:"cache"`
+	// StrictKeyPermissions refuses an SSH key file accessible to group or others
+	StrictKeyPermissions bool `yaml:"strict_key_permissions"`
+	// AuthMethods is the order SSH authentication methods are tried in
+	AuthMethods []string `yaml:"auth_methods"`
+	// CommandPath replaces the PATH commands run with (DefaultCommandPath when empty)
+	CommandPath string `yaml:"command_path"`
+	// CommandEnv holds extra variables passed to commands; unsafe names are rejected
+	CommandEnv map[string]string `yaml:"command_env"`
+	// ReadOnly blocks every command that is not a read (list, report, show...)
+	ReadOnly bool `yaml:"read_only"`
+	// BreakGlass allows running a single blacklisted command with an audited reason
+	BreakGlass bool `yaml:"break_glass"`
+	// AppScope refuses commands targeting other apps and filters app listings; nil allows every app
+	AppScope *shared.AppScope `yaml:"-"`
+	// CommandAliases forces the concrete command used for a logical command name, whatever the Dokku version
+	CommandAliases map[string]string `yaml:"command_aliases"`
+	// LogRedactCommands have their arguments masked in command logs, on top of the built-in ones
+	LogRedactCommands []string `yaml:"log_redact_commands"`
+	// LogAllowCommands are logged with their arguments; sensitive KEY=value pairs stay masked
+	LogAllowCommands []string              `yaml:"log_allow_commands"`
+	Cache            *CacheConfig          `yaml:"cache"`
+	CircuitBreaker   *CircuitBreakerConfig `yaml:"circuit_breaker"`
+	Retry            *RetryConfig          `yaml:"retry"`
+	// RateLimit spaces out the commands sent to the host; nil runs them as they come
+	RateLimit *RateLimitConfig `yaml:"rate_limit"`
 }
 
 func DefaultClientConfig() *ClientConfig {
@@ -74,6 +106,8 @@ func DefaultClientConfig() *ClientConfig {
 		SSHKeyPath:     "",
 		CommandTimeout: 30 * time.Second,
 		Cache:          DefaultCacheConfig(),
+		CircuitBreaker: DefaultCircuitBreakerConfig(),
+		Retry:          DefaultRetryConfig(),
 	}
 }
 
@@ -82,10 +116,24 @@ type client struct {
 	logger              *slog.Logger
 	sshConnManager      *SSHConnectionManager
 	blacklistedCommands []string
+	// Guards blacklistedCommands, which a configuration reload replaces while commands run
+	blacklistMu sync.RWMutex
+
+	// Executes the prepared SSH commands
+	runner commandRunner
 
 	// Optional caching - managed by cache manager
 	cacheManager *CommandCacheManager
 
 	// Capabilities tracking
 	capabilities *DokkuCapabilities
+
+	// Optional fast-fail protection when the host is unreachable
+	circuitBreaker *CircuitBreaker
+
+	// Optional spacing of the commands sent to the host
+	rateLimiter *RateLimiter
+
+	// Outcome of the last command sent to the host
+	connection connectionTracker
 }
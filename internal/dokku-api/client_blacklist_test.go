@@ -99,15 +99,53 @@ var _ = Describe("DokkuClient", func() {
 			})
 		})
 
-		Context("with dangerous characters in args", func() {
-			It("should block semicolon in args", func() {
-				err := client.ValidateCommand("apps:list", []string{"myapp;rm -rf /"})
+		Context("with shell characters in args", func() {
+			It("should allow env values with $, ( and )", func() {
+				err := client.ValidateCommand("config:set", []string{"myapp", "PASS=$ecret(1)"})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should allow JSON values", func() {
+				err := client.ValidateCommand("config:set", []string{"myapp", `SETTINGS={"a":[1,2]}`})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should allow semicolons and pipes", func() {
+				err := client.ValidateCommand("config:set", []string{"myapp", "DSN=a;b|c"})
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		Context("with shell characters in args for another SSH user", func() {
+			BeforeEach(func() {
+				config.DokkuUser = "deploy"
+				client = dokkuApi.NewDokkuClient(config, logger)
+			})
+
+			It("should block command substitution", func() {
+				err := client.ValidateCommand("config:set", []string{"myapp", "PASS=$(whoami)"})
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("dangerous character"))
+			})
+
+			It("should block semicolons and pipes", func() {
+				for _, arg := range []string{"myapp;rm -rf /", "myapp|cat /etc/passwd"} {
+					err := client.ValidateCommand("apps:info", []string{arg})
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("dangerous character"))
+				}
+			})
+		})
+
+		Context("with line breaks in args", func() {
+			It("should block a newline in args", func() {
+				err := client.ValidateCommand("apps:list", []string{"myapp\nrm -rf /"})
 				Expect(err).To(HaveOccurred())
 				Expect(err.Error()).To(ContainSubstring("dangerous character"))
 			})
 
-			It("should block pipe in args", func() {
-				err := client.ValidateCommand("apps:list", []string{"myapp|cat /etc/passwd"})
+			It("should block a carriage return in args", func() {
+				err := client.ValidateCommand("apps:list", []string{"myapp\rrm -rf /"})
 				Expect(err).To(HaveOccurred())
 				Expect(err.Error()).To(ContainSubstring("dangerous character"))
 			})
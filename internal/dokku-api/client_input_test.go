@@ -0,0 +1,97 @@
+package dokkuApi
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+// stdinRecorder is a commandRunner remembering the stdin of every run
+type stdinRecorder struct {
+	inputs [][]byte
+}
+
+func (r *stdinRecorder) run(ctx context.Context, sshArgs []string, env []string, stdin []byte) ([]byte, error) {
+	r.inputs = append(r.inputs, stdin)
+	return []byte("ok"), nil
+}
+
+func TestExecuteCommandWithInputFeedsStdin(t *testing.T) {
+	recorder := &stdinRecorder{}
+	c := newRunnerTestClient(t, time.Second, recorder.run)
+
+	key := []byte("ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIGx5 alice@example.com\n")
+	if _, err := c.ExecuteCommandWithInput(context.Background(), "ssh-keys:add", []string{"alice"}, key); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := c.ExecuteCommand(context.Background(), "ssh-keys:list", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(recorder.inputs) != 2 {
+		t.Fatalf("expected 2 runs, got %d", len(recorder.inputs))
+	}
+	if string(recorder.inputs[0]) != string(key) {
+		t.Fatalf("expected the key on stdin, got %q", recorder.inputs[0])
+	}
+	if recorder.inputs[1] != nil {
+		t.Fatalf("expected no stdin for a plain command, got %q", recorder.inputs[1])
+	}
+}
+
+func TestExecuteCommandWithInputRespectsReadOnlyMode(t *testing.T) {
+	recorder := &stdinRecorder{}
+	c := newRunnerTestClient(t, time.Second, recorder.run)
+	c.config.ReadOnly = true
+
+	_, err := c.ExecuteCommandWithInput(context.Background(), "ssh-keys:add", []string{"alice"}, []byte("ssh-ed25519 AAAA"))
+	if !errors.Is(err, ErrReadOnlyMode) {
+		t.Fatalf("expected ErrReadOnlyMode, got %v", err)
+	}
+	if len(recorder.inputs) != 0 {
+		t.Fatalf("expected nothing to run, got %d runs", len(recorder.inputs))
+	}
+}
+
+func TestExecuteCommandWithInputNeverLogsStdin(t *testing.T) {
+	// Line breaks ValidateCommand rejects in arguments are fine on stdin
+	password := "s3cr3t;|$(whoami)`&<>\nline"
+
+	for _, tc := range []struct {
+		name string
+		err  error
+	}{
+		{name: "success"},
+		{name: "failure", err: errors.New("exit status 1")},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			recorder := &stdinRecorder{}
+			c := newRunnerTestClient(t, time.Second, func(ctx context.Context, sshArgs []string, env []string, stdin []byte) ([]byte, error) {
+				_, _ = recorder.run(ctx, sshArgs, env, stdin)
+				return []byte("Error response from daemon: unauthorized"), tc.err
+			})
+			var logs bytes.Buffer
+			c.logger = slog.New(slog.NewJSONHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+			_, err := c.ExecuteCommandWithInput(context.Background(), "registry:login",
+				[]string{"--password-stdin", "ghcr.io", "deployer"}, []byte(password))
+			if (err != nil) != (tc.err != nil) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(recorder.inputs) != 1 || string(recorder.inputs[0]) != password {
+				t.Fatalf("expected the password on stdin, got %q", recorder.inputs)
+			}
+			if !strings.Contains(logs.String(), `"dokku_command":"registry:login --password-stdin ghcr.io deployer"`) {
+				t.Fatalf("expected the command to be logged, got %s", logs.String())
+			}
+			if strings.Contains(logs.String(), "s3cr3t") {
+				t.Fatalf("expected the password to stay out of the logs, got %s", logs.String())
+			}
+		})
+	}
+}
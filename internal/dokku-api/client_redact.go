@@ -0,0 +1,101 @@
+package dokkuApi
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
+)
+
+// redactedLogValue replaces secret values in command logs
+const redactedLogValue = "***"
+
+// minRedactedOutputLength is the shortest secret value also masked in free-form
+// fields (command output); shorter values would mangle unrelated words
+const minRedactedOutputLength = 4
+
+// defaultRedactedCommands lists the commands whose arguments are secrets, with the
+// number of leading positional arguments left readable (app, registry server...).
+// Every value passed to config:set is masked whatever its key name, since callers
+// may store generated secrets under keys that do not look sensitive.
+var defaultRedactedCommands = map[string]int{
+	"config:set":      1, // app KEY=value...
+	"registry:login":  2, // server username password
+	"letsencrypt:set": 2, // app property value (email, DNS provider credentials)
+}
+
+// logRedactor masks the secrets of a command in log fields
+type logRedactor struct {
+	replacer *strings.Replacer
+}
+
+// newLogRedactor builds the redactor of a command. Arguments of the redacted
+// commands are masked past their readable prefix, and the value of any
+// KEY=value argument whose key looks sensitive is masked for every command.
+// security.log_redaction.allow_commands lifts the first rule for a command,
+// security.log_redaction.redact_commands adds commands to it and wins over the allowlist.
+func (c *client) newLogRedactor(commandName string, args []string) logRedactor {
+	readable, builtIn := defaultRedactedCommands[commandName]
+	redacted := builtIn && !slices.Contains(c.config.LogAllowCommands, commandName)
+	if slices.Contains(c.config.LogRedactCommands, commandName) {
+		if !builtIn {
+			readable = 1 // the app
+		}
+		redacted = true
+	}
+
+	masked := make([]string, len(args))
+	var argPairs, valuePairs []string
+	positional := 0
+	for i, arg := range args {
+		masked[i] = arg
+		key, value, hasValue := strings.Cut(arg, "=")
+		isFlag := strings.HasPrefix(arg, "--")
+
+		switch {
+		case !isFlag && redacted && positional >= readable && !hasValue:
+			masked[i] = redactedLogValue
+			value = arg
+		case hasValue && value != "" && (shared.IsSensitiveKey(key) || (!isFlag && redacted && positional >= readable)):
+			masked[i] = key + "=" + redactedLogValue
+		}
+		if !isFlag {
+			positional++
+		}
+
+		if masked[i] == arg {
+			continue
+		}
+		argPairs = append(argPairs, arg, masked[i])
+		if len(value) >= minRedactedOutputLength {
+			valuePairs = append(valuePairs, value, redactedLogValue)
+		}
+	}
+	if len(argPairs) == 0 {
+		return logRedactor{}
+	}
+
+	// The whole command line first, so it is rewritten from the masked arguments
+	pairs := []string{buildDokkuCommand(commandName, args), buildDokkuCommand(commandName, masked)}
+	pairs = append(pairs, argPairs...)
+	pairs = append(pairs, valuePairs...)
+	return logRedactor{replacer: strings.NewReplacer(pairs...)}
+}
+
+func (r logRedactor) value(s string) string {
+	if r.replacer == nil {
+		return s
+	}
+	return r.replacer.Replace(s)
+}
+
+func (r logRedactor) values(list []string) []string {
+	if r.replacer == nil {
+		return list
+	}
+	redacted := make([]string, len(list))
+	for i, s := range list {
+		redacted[i] = r.replacer.Replace(s)
+	}
+	return redacted
+}
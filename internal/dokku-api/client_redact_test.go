@@ -0,0 +1,104 @@
+package dokkuApi
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newRedactTestClient(t *testing.T) *client {
+	t.Helper()
+	return newRunnerTestClient(t, time.Second, func(ctx context.Context, sshArgs []string, env []string, stdin []byte) ([]byte, error) {
+		return nil, nil
+	})
+}
+
+func TestLogRedactorMasksEveryConfigSetValue(t *testing.T) {
+	args := []string{"--no-restart", "my-app", "SESSION_ID=4f2a9c", "PLAIN=on"}
+	redact := newRedactTestClient(t).newLogRedactor("config:set", args)
+
+	if got := redact.values(args); !reflect.DeepEqual(got, []string{"--no-restart", "my-app", "SESSION_ID=***", "PLAIN=***"}) {
+		t.Fatalf("unexpected redacted args: %v", got)
+	}
+	if got := redact.value("config:set --no-restart my-app SESSION_ID=4f2a9c PLAIN=on"); got != "config:set --no-restart my-app SESSION_ID=*** PLAIN=***" {
+		t.Fatalf("unexpected redacted command: %q", got)
+	}
+
+	output := redact.value("-----> Setting config vars\n       SESSION_ID:  4f2a9c\n")
+	if strings.Contains(output, "4f2a9c") {
+		t.Fatalf("secret value leaked in output: %q", output)
+	}
+}
+
+func TestLogRedactorMasksSecretCommandArguments(t *testing.T) {
+	c := newRedactTestClient(t)
+
+	for _, tc := range []struct {
+		command string
+		args    []string
+		want    []string
+	}{
+		{command: "registry:login", args: []string{"ghcr.io", "deployer", "s3cr3t"}, want: []string{"ghcr.io", "deployer", "***"}},
+		{command: "registry:login", args: []string{"--password-stdin", "ghcr.io", "deployer"}, want: []string{"--password-stdin", "ghcr.io", "deployer"}},
+		{command: "letsencrypt:set", args: []string{"my-app", "email", "ops@example.com"}, want: []string{"my-app", "email", "***"}},
+		{command: "docker-options:add", args: []string{"my-app", "deploy", "--env", "API_TOKEN=abcd1234"}, want: []string{"my-app", "deploy", "--env", "API_TOKEN=***"}},
+		{command: "ps:scale", args: []string{"my-app", "web=2"}, want: []string{"my-app", "web=2"}},
+	} {
+		if got := c.newLogRedactor(tc.command, tc.args).values(tc.args); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s: expected %v, got %v", tc.command, tc.want, got)
+		}
+	}
+}
+
+func TestLogRedactorHonoursConfiguredCommands(t *testing.T) {
+	c := newRedactTestClient(t)
+	c.config.LogRedactCommands = []string{"nginx:set", "letsencrypt:set"}
+	c.config.LogAllowCommands = []string{"config:set", "letsencrypt:set"}
+
+	configArgs := []string{"my-app", "PORT=5000", "DB_PASSWORD=hunter22"}
+	if got := c.newLogRedactor("config:set", configArgs).values(configArgs); !reflect.DeepEqual(got, []string{"my-app", "PORT=5000", "DB_PASSWORD=***"}) {
+		t.Fatalf("expected allowed command to keep sensitive keys masked, got %v", got)
+	}
+
+	nginxArgs := []string{"my-app", "client-max-body-size", "50m"}
+	if got := c.newLogRedactor("nginx:set", nginxArgs).values(nginxArgs); !reflect.DeepEqual(got, []string{"my-app", "***", "***"}) {
+		t.Fatalf("expected configured command to be masked, got %v", got)
+	}
+
+	letsencryptArgs := []string{"my-app", "email", "ops@example.com"}
+	if got := c.newLogRedactor("letsencrypt:set", letsencryptArgs).values(letsencryptArgs); !reflect.DeepEqual(got, []string{"my-app", "email", "***"}) {
+		t.Fatalf("expected the redact list to win over the allow list, got %v", got)
+	}
+}
+
+func TestCommandLogsNeverContainConfigSecrets(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		err  error
+	}{
+		{name: "success"},
+		{name: "failure", err: errors.New("exit status 1")},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c := newRunnerTestClient(t, time.Second, func(ctx context.Context, sshArgs []string, env []string, stdin []byte) ([]byte, error) {
+				return []byte("-----> Setting config vars\n       SECRET:  foo-bar-baz\n"), tc.err
+			})
+			var logs bytes.Buffer
+			c.logger = slog.New(slog.NewJSONHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+			_, _ = c.ExecuteCommand(context.Background(), "config:set", []string{"app", "SECRET=foo-bar-baz"})
+
+			if !strings.Contains(logs.String(), `"dokku_command":"config:set app SECRET=***"`) {
+				t.Fatalf("expected the masked command to be logged, got %s", logs.String())
+			}
+			if strings.Contains(logs.String(), "foo-bar-baz") {
+				t.Fatalf("secret value leaked in logs: %s", logs.String())
+			}
+		})
+	}
+}
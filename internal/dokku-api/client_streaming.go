@@ -0,0 +1,118 @@
+package dokkuApi
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
+)
+
+// streamBufferSize is how many output chunks a stream holds before the command waits for its reader
+const streamBufferSize = 64
+
+// outputSinkKey carries the function receiving a command's output as it arrives
+type outputSinkKey struct{}
+
+// WithOutputSink returns a context under which the commands run over SSH hand their
+// output to sink line by line as it arrives, in addition to returning it once they
+// exit. Cached results are returned without calling sink.
+func WithOutputSink(ctx context.Context, sink func(chunk []byte)) context.Context {
+	return context.WithValue(ctx, outputSinkKey{}, sink)
+}
+
+// OutputSinkFromContext returns the sink set with WithOutputSink, or nil
+func OutputSinkFromContext(ctx context.Context) func(chunk []byte) {
+	sink, _ := ctx.Value(outputSinkKey{}).(func(chunk []byte))
+	return sink
+}
+
+// ExecuteCommandStreaming starts a command and returns its output as it arrives,
+// one line per chunk. It goes through the same validation and read-only checks as
+// ExecuteCommand; the channel is closed when the command exits. A failed command
+// is logged, so callers that need its exit status run ExecuteCommand under
+// WithOutputSink instead.
+func (c *client) ExecuteCommandStreaming(ctx context.Context, commandName string, args []string) (<-chan []byte, error) {
+	commandName = c.resolveCommandName(commandName)
+	if err := c.ValidateCommand(commandName, args); err != nil {
+		return nil, fmt.Errorf("invalid command: %w", err)
+	}
+	if c.config.ReadOnly && !IsReadCommand(commandName) {
+		c.logger.Warn("Blocked mutating command in read-only mode", "command", commandName)
+		return nil, fmt.Errorf("%w: %s is not allowed", ErrReadOnlyMode, commandName)
+	}
+	// Streamed chunks cannot be filtered per app, so only single-app commands stream
+	if scopeFilter, err := c.checkAppScope(commandName, args); err != nil {
+		return nil, err
+	} else if scopeFilter != scopeOutputAsIs {
+		return nil, fmt.Errorf("%w: %s lists several apps and cannot be streamed", shared.ErrAppOutOfScope, commandName)
+	}
+
+	chunks := make(chan []byte, streamBufferSize)
+	go func() {
+		defer close(chunks)
+
+		streamCtx := WithOutputSink(ctx, func(chunk []byte) {
+			select {
+			case chunks <- chunk:
+			case <-ctx.Done():
+			}
+		})
+		if _, err := c.executeCommandDirectWithInput(streamCtx, commandName, args, nil); err != nil {
+			c.logger.Warn("Streamed Dokku command failed", "command", commandName, "error", err)
+		}
+	}()
+
+	return chunks, nil
+}
+
+// runStreaming runs cmd reading its stdout and stderr incrementally: each line is
+// handed to sink as soon as it is complete, and the combined output is returned
+func runStreaming(cmd *exec.Cmd, sink func(chunk []byte)) ([]byte, error) {
+	var output bytes.Buffer
+	writer := newChunkWriter(&output, sink)
+	cmd.Stdout = writer
+	cmd.Stderr = writer
+
+	err := cmd.Run()
+	writer.Flush()
+	return output.Bytes(), err
+}
+
+// chunkWriter copies everything written to it into output and hands each complete
+// line to sink. Lines end with \n or with the \r git uses to redraw its progress,
+// blank lines are skipped and the line ending is not part of the chunk.
+type chunkWriter struct {
+	output  *bytes.Buffer
+	sink    func(chunk []byte)
+	pending []byte
+}
+
+func newChunkWriter(output *bytes.Buffer, sink func(chunk []byte)) *chunkWriter {
+	return &chunkWriter{output: output, sink: sink}
+}
+
+func (w *chunkWriter) Write(p []byte) (int, error) {
+	w.output.Write(p)
+	for _, b := range p {
+		if b == '\n' || b == '\r' {
+			w.emit()
+			continue
+		}
+		w.pending = append(w.pending, b)
+	}
+	return len(p), nil
+}
+
+// Flush hands the last line to sink when the output does not end with a line ending
+func (w *chunkWriter) Flush() {
+	w.emit()
+}
+
+func (w *chunkWriter) emit() {
+	if len(bytes.TrimSpace(w.pending)) > 0 {
+		w.sink(bytes.Clone(w.pending))
+	}
+	w.pending = w.pending[:0]
+}
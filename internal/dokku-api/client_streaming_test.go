@@ -0,0 +1,138 @@
+package dokkuApi
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os/exec"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestChunkWriterSplitsLines(t *testing.T) {
+	cases := []struct {
+		name   string
+		writes []string
+		want   []string
+	}{
+		{name: "one line per write", writes: []string{"-----> Cloning\n", "-----> Building\n"}, want: []string{"-----> Cloning", "-----> Building"}},
+		{name: "lines split across writes", writes: []string{"-----> Clo", "ning\n---", "--> Building\n"}, want: []string{"-----> Cloning", "-----> Building"}},
+		{name: "several lines in a write", writes: []string{"one\ntwo\nthree\n"}, want: []string{"one", "two", "three"}},
+		{name: "git progress redraws", writes: []string{"Receiving objects:  50%\rReceiving objects: 100%\r\n"}, want: []string{"Receiving objects:  50%", "Receiving objects: 100%"}},
+		{name: "blank lines skipped", writes: []string{"one\n\n  \ntwo\n"}, want: []string{"one", "two"}},
+		{name: "unterminated last line", writes: []string{"one\ntw", "o"}, want: []string{"one", "two"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var output bytes.Buffer
+			var chunks []string
+			writer := newChunkWriter(&output, func(chunk []byte) { chunks = append(chunks, string(chunk)) })
+
+			for _, write := range tc.writes {
+				if n, err := writer.Write([]byte(write)); err != nil || n != len(write) {
+					t.Fatalf("expected %d bytes written, got %d (%v)", len(write), n, err)
+				}
+			}
+			writer.Flush()
+
+			if !slices.Equal(chunks, tc.want) {
+				t.Fatalf("expected chunks %q, got %q", tc.want, chunks)
+			}
+			if want := []byte(strings.Join(tc.writes, "")); !bytes.Equal(output.Bytes(), want) {
+				t.Fatalf("expected the whole output %q, got %q", want, output.Bytes())
+			}
+		})
+	}
+}
+
+func TestRunStreamingReadsOutputIncrementally(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh is not available")
+	}
+
+	cmd := exec.Command("sh", "-c", `printf 'first\n'; sleep 0.3; printf 'second\n' >&2; printf 'last'`)
+	received := make(map[string]time.Time)
+	var order []string
+	start := time.Now()
+
+	output, err := runStreaming(cmd, func(chunk []byte) {
+		received[string(chunk)] = time.Now()
+		order = append(order, string(chunk))
+	})
+	exited := time.Now()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := []string{"first", "second", "last"}; !slices.Equal(order, want) {
+		t.Fatalf("expected stdout and stderr chunks %q, got %q", want, order)
+	}
+	if string(output) != "first\nsecond\nlast" {
+		t.Fatalf("expected the combined output, got %q", output)
+	}
+	if exited.Sub(received["first"]) < 200*time.Millisecond {
+		t.Fatalf("expected the first line before the command exited (received after %s, exited after %s)",
+			received["first"].Sub(start), exited.Sub(start))
+	}
+}
+
+func TestRunStreamingReturnsExitError(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh is not available")
+	}
+
+	var chunks []string
+	output, err := runStreaming(exec.Command("sh", "-c", `echo 'fatal: repository not found' >&2; exit 128`), func(chunk []byte) {
+		chunks = append(chunks, string(chunk))
+	})
+
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) || exitErr.ExitCode() != 128 {
+		t.Fatalf("expected exit status 128, got %v", err)
+	}
+	if string(output) != "fatal: repository not found\n" || !slices.Equal(chunks, []string{"fatal: repository not found"}) {
+		t.Fatalf("expected the failure output to be streamed and returned, got %q and %q", chunks, output)
+	}
+}
+
+func TestExecuteCommandStreaming(t *testing.T) {
+	c := newRunnerTestClient(t, 30*time.Second, func(ctx context.Context, sshArgs []string, env []string, stdin []byte) ([]byte, error) {
+		sink := OutputSinkFromContext(ctx)
+		if sink == nil {
+			t.Error("expected the stream to hand its output sink to the runner")
+			return nil, nil
+		}
+		for _, line := range []string{"-----> Fetching", "-----> Building", "=====> Application deployed"} {
+			sink([]byte(line))
+		}
+		return nil, nil
+	})
+
+	stream, err := c.ExecuteCommandStreaming(context.Background(), "git:sync", []string{"my-app", "https://github.com/acme/app.git", "main"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var chunks []string
+	for chunk := range stream {
+		chunks = append(chunks, string(chunk))
+	}
+	if want := []string{"-----> Fetching", "-----> Building", "=====> Application deployed"}; !slices.Equal(chunks, want) {
+		t.Fatalf("expected chunks %q, got %q", want, chunks)
+	}
+}
+
+func TestExecuteCommandStreamingValidatesCommand(t *testing.T) {
+	c := newRunnerTestClient(t, 30*time.Second, func(ctx context.Context, sshArgs []string, env []string, stdin []byte) ([]byte, error) {
+		t.Error("a rejected command must not run")
+		return nil, nil
+	})
+	c.SetBlacklist([]string{"apps:destroy"})
+
+	if _, err := c.ExecuteCommandStreaming(context.Background(), "apps:destroy", []string{"my-app"}); err == nil {
+		t.Fatal("expected a blacklisted command to be rejected")
+	}
+}
@@ -0,0 +1,150 @@
+package dokkuApi
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+// newRunnerTestClient builds a client whose commands go to runner instead of SSH
+func newRunnerTestClient(t *testing.T, commandTimeout time.Duration, runner commandRunner) *client {
+	t.Helper()
+
+	original := startDiscovery
+	startDiscovery = func(c *client) {}
+	t.Cleanup(func() { startDiscovery = original })
+
+	config := DefaultClientConfig()
+	config.Cache = nil
+	config.CircuitBreaker = nil
+	config.Retry = nil
+	config.CommandTimeout = commandTimeout
+
+	c := NewDokkuClient(config, slog.New(slog.NewTextHandler(io.Discard, nil))).(*client)
+	c.runner = runner
+	return c
+}
+
+// deadlineRecorder is a commandRunner remembering the deadline its context carried
+type deadlineRecorder struct {
+	deadline    time.Time
+	hasDeadline bool
+}
+
+func (r *deadlineRecorder) run(ctx context.Context, sshArgs []string, env []string, stdin []byte) ([]byte, error) {
+	r.deadline, r.hasDeadline = ctx.Deadline()
+	return []byte("ok"), nil
+}
+
+func TestCommandContextRespectsCallerDeadline(t *testing.T) {
+	recorder := &deadlineRecorder{}
+	c := newRunnerTestClient(t, 30*time.Second, recorder.run)
+
+	callerDeadline := time.Now().Add(2 * time.Minute)
+	ctx, cancel := context.WithDeadline(context.Background(), callerDeadline)
+	defer cancel()
+
+	if _, err := c.ExecuteCommand(ctx, "apps:list", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !recorder.hasDeadline || !recorder.deadline.Equal(callerDeadline) {
+		t.Fatalf("expected the caller deadline %v, got %v (set: %v)", callerDeadline, recorder.deadline, recorder.hasDeadline)
+	}
+}
+
+func TestCommandContextAppliesDefaultTimeout(t *testing.T) {
+	recorder := &deadlineRecorder{}
+	c := newRunnerTestClient(t, 30*time.Second, recorder.run)
+
+	start := time.Now()
+	if _, err := c.ExecuteCommand(context.Background(), "apps:list", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !recorder.hasDeadline {
+		t.Fatal("expected CommandTimeout to set a deadline")
+	}
+	if remaining := recorder.deadline.Sub(start); remaining <= 0 || remaining > 30*time.Second+time.Second {
+		t.Fatalf("expected a deadline about 30s ahead, got %v", remaining)
+	}
+}
+
+func TestCommandContextZeroTimeoutMeansNoDeadline(t *testing.T) {
+	recorder := &deadlineRecorder{}
+	c := newRunnerTestClient(t, 0, recorder.run)
+
+	if _, err := c.ExecuteCommand(context.Background(), "apps:list", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if recorder.hasDeadline {
+		t.Fatalf("expected no deadline with a zero CommandTimeout, got %v", recorder.deadline)
+	}
+}
+
+func TestCommandTimeoutCancelsRunningCommand(t *testing.T) {
+	c := newRunnerTestClient(t, 10*time.Millisecond, func(ctx context.Context, sshArgs []string, env []string, stdin []byte) ([]byte, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+
+	_, err := c.ExecuteCommand(context.Background(), "apps:list", nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the command to time out, got %v", err)
+	}
+}
+
+func TestCallerCancellationStopsRunningCommand(t *testing.T) {
+	started := make(chan struct{})
+	c := newRunnerTestClient(t, time.Minute, func(ctx context.Context, sshArgs []string, env []string, stdin []byte) ([]byte, error) {
+		close(started)
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-started
+		cancel()
+	}()
+
+	_, err := c.ExecuteCommand(ctx, "apps:list", nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the command to be canceled, got %v", err)
+	}
+}
+
+func TestCommandSpecTimeoutWithinCallerDeadline(t *testing.T) {
+	recorder := &deadlineRecorder{}
+	c := newRunnerTestClient(t, 30*time.Second, recorder.run)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	start := time.Now()
+	spec := CommandSpec{Command: "git:sync", Args: []string{"my-app"}, OutputFormat: OutputFormatRaw, Timeout: 2 * time.Minute}
+	if _, err := c.ExecuteStructured(ctx, spec); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !recorder.hasDeadline {
+		t.Fatal("expected the spec timeout to set a deadline")
+	}
+	if remaining := recorder.deadline.Sub(start); remaining < 2*time.Minute-time.Second || remaining > 2*time.Minute+time.Second {
+		t.Fatalf("expected a deadline about 2m ahead rather than the caller's 10m, got %v", remaining)
+	}
+}
+
+func TestCommandSpecTimeoutOnlyRaisesDefault(t *testing.T) {
+	recorder := &deadlineRecorder{}
+	c := newRunnerTestClient(t, 30*time.Second, recorder.run)
+
+	start := time.Now()
+	spec := CommandSpec{Command: "apps:list", OutputFormat: OutputFormatRaw, Timeout: time.Second}
+	if _, err := c.ExecuteStructured(context.Background(), spec); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if remaining := recorder.deadline.Sub(start); remaining < 29*time.Second || remaining > 31*time.Second {
+		t.Fatalf("expected the 30s default to be kept over a shorter spec timeout, got %v", remaining)
+	}
+}
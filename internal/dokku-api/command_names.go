@@ -0,0 +1,56 @@
+package dokkuApi
+
+// portsPluginVersion is the first Dokku release where port mappings moved from proxy:ports* to the ports plugin
+var portsPluginVersion = DokkuVersion{Major: 0, Minor: 31, Patch: 0}
+
+// commandRename records a command Dokku renamed: before Since it was called Legacy
+type commandRename struct {
+	Legacy string
+	Since  DokkuVersion
+}
+
+// commandRenames maps the logical command names used by tools (the current
+// Dokku names) to the names older Dokku releases expect
+var commandRenames = map[string]commandRename{
+	"ports:report": {Legacy: "proxy:ports", Since: portsPluginVersion},
+	"ports:add":    {Legacy: "proxy:ports-add", Since: portsPluginVersion},
+	"ports:set":    {Legacy: "proxy:ports-set", Since: portsPluginVersion},
+	"ports:clear":  {Legacy: "proxy:ports-clear", Since: portsPluginVersion},
+	"ports:remove": {Legacy: "proxy:ports-remove", Since: portsPluginVersion},
+}
+
+// NormalizeCommandName translates a logical command name into the command the
+// given Dokku version understands. Configured aliases win over the built-in
+// renames; an unknown version keeps the logical (current) name.
+func NormalizeCommandName(logical string, version string, aliases map[string]string) string {
+	if concrete, ok := aliases[logical]; ok && concrete != "" {
+		return concrete
+	}
+
+	rename, ok := commandRenames[logical]
+	if !ok {
+		return logical
+	}
+
+	parsed, err := ParseDokkuVersion(version)
+	if err != nil || parsed.Compare(rename.Since) >= 0 {
+		return logical
+	}
+	return rename.Legacy
+}
+
+// resolveCommandName applies NormalizeCommandName with the discovered (or pinned) Dokku version
+func (c *client) resolveCommandName(commandName string) string {
+	c.capabilities.mu.RLock()
+	version := c.capabilities.Version
+	c.capabilities.mu.RUnlock()
+
+	concrete := NormalizeCommandName(commandName, version, c.config.CommandAliases)
+	if concrete != commandName {
+		c.logger.Debug("Normalized Dokku command name",
+			"command", commandName,
+			"concrete_command", concrete,
+			"dokku_version", version)
+	}
+	return concrete
+}
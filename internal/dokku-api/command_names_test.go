@@ -0,0 +1,45 @@
+package dokkuApi
+
+import (
+	"io"
+	"log/slog"
+	"testing"
+)
+
+func TestResolveCommandNameFollowsDokkuVersion(t *testing.T) {
+	original := startDiscovery
+	startDiscovery = func(c *client) {}
+	defer func() { startDiscovery = original }()
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	cases := []struct {
+		name    string
+		version string
+		aliases map[string]string
+		want    string
+	}{
+		{name: "before the ports plugin", version: "0.30.7", want: "proxy:ports"},
+		{name: "with the ports plugin", version: "0.35.12", want: "ports:report"},
+		{name: "unknown version", version: "", want: "ports:report"},
+		{name: "configured alias wins", version: "0.35.12", aliases: map[string]string{"ports:report": "proxy:ports"}, want: "proxy:ports"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := DefaultClientConfig()
+			config.Cache = nil
+			config.DokkuVersion = tc.version
+			config.CommandAliases = tc.aliases
+
+			c := NewDokkuClient(config, logger).(*client)
+
+			if got := c.resolveCommandName("ports:report"); got != tc.want {
+				t.Fatalf("resolveCommandName(ports:report) = %q, want %q", got, tc.want)
+			}
+			if got := c.resolveCommandName("apps:list"); got != "apps:list" {
+				t.Fatalf("commands without renames must be kept, got %q", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package dokkuApi
+
+import (
+	"sync"
+	"time"
+)
+
+// ConnectionStatus is the outcome of the last command sent to the Dokku host over SSH
+type ConnectionStatus struct {
+	// LastCommandAt is zero until a command has been sent
+	LastCommandAt time.Time `json:"last_command_at"`
+	// Reachable is false when the last command could not reach the host
+	Reachable bool `json:"reachable"`
+	// LastError is the connection failure of the last command, if any
+	LastError    string       `json:"last_error,omitempty"`
+	CircuitState CircuitState `json:"circuit_state"`
+}
+
+// connectionTracker remembers the outcome of the last command sent to the host
+type connectionTracker struct {
+	mu     sync.RWMutex
+	status ConnectionStatus
+}
+
+// record stores the outcome of a command; connErr is nil when the host was reached,
+// even if the command itself failed
+func (t *connectionTracker) record(at time.Time, connErr error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.status.LastCommandAt = at
+	t.status.Reachable = connErr == nil
+	t.status.LastError = ""
+	if connErr != nil {
+		t.status.LastError = connErr.Error()
+	}
+}
+
+func (t *connectionTracker) get() ConnectionStatus {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.status
+}
+
+// ConnectionStatus reports whether the last command reached the Dokku host
+func (c *client) ConnectionStatus() ConnectionStatus {
+	status := c.connection.get()
+	status.CircuitState = c.circuitBreaker.State()
+	return status
+}
@@ -1,6 +1,7 @@
 package dokkuApi
 
 import (
+	"context"
 	"errors"
 	"fmt"
 )
@@ -8,6 +9,36 @@ import (
 // ErrAppNotFound is the sentinel error for missing Dokku applications.
 var ErrAppNotFound = errors.New("app not found")
 
+// ErrAppNotDeployed is returned when a command needs a deployed release but the app was never deployed.
+var ErrAppNotDeployed = errors.New("app has not been deployed")
+
+// ErrPluginNotInstalled is returned when Dokku does not know a command because the plugin providing it is missing.
+var ErrPluginNotInstalled = errors.New("dokku plugin not installed")
+
+// ErrPermissionDenied is returned when the Dokku host refuses an operation to the dokku user.
+var ErrPermissionDenied = errors.New("permission denied on the dokku host")
+
+// ErrQuotaExceeded is returned when the Dokku host is out of disk space or over a resource quota.
+var ErrQuotaExceeded = errors.New("quota exceeded on the dokku host")
+
+// ErrSSHUnreachable is returned when the Dokku host cannot be reached over SSH.
+var ErrSSHUnreachable = errors.New("dokku host unreachable over SSH")
+
+// ErrCommandTimeout is returned when a command outlives its timeout; the command may still have run on the host.
+var ErrCommandTimeout = fmt.Errorf("dokku command timed out: %w", context.DeadlineExceeded)
+
+// ErrReadOnlyMode is returned for mutating commands while the server runs in read-only mode.
+var ErrReadOnlyMode = errors.New("server is in read-only mode")
+
+// ErrBreakGlassDisabled is returned for break-glass commands unless security.break_glass is enabled.
+var ErrBreakGlassDisabled = errors.New("break-glass mode is disabled")
+
+// ErrBreakGlassReasonRequired is returned for break-glass commands without a justification.
+var ErrBreakGlassReasonRequired = errors.New("a reason is required to run a break-glass command")
+
+// ErrUnknownFeature is returned when asked about a feature that is not in the feature registry.
+var ErrUnknownFeature = errors.New("unknown feature")
+
 // NotFoundError indicates the target Dokku application/resource does not exist.
 type NotFoundError struct {
 	Command string
@@ -37,3 +68,34 @@ func IsNotFoundError(err error) bool {
 	}
 	return errors.Is(err, ErrAppNotFound)
 }
+
+// IsNotDeployedError returns true when err is (or wraps) ErrAppNotDeployed.
+func IsNotDeployedError(err error) bool {
+	return errors.Is(err, ErrAppNotDeployed)
+}
+
+// CommandError is returned when a Dokku command exits with a failure. It keeps
+// the combined output so callers can surface what Dokku actually said.
+type CommandError struct {
+	Command string
+	Output  []byte
+	Err     error
+}
+
+func (e *CommandError) Error() string {
+	if e == nil {
+		return ""
+	}
+	return fmt.Sprintf("failed to execute Dokku command %s: %v", e.Command, e.Err)
+}
+
+func (e *CommandError) Unwrap() error { return e.Err }
+
+// CommandOutput returns the raw output carried by a CommandError in err's chain.
+func CommandOutput(err error) (string, bool) {
+	var cmdErr *CommandError
+	if !errors.As(err, &cmdErr) || len(cmdErr.Output) == 0 {
+		return "", false
+	}
+	return string(cmdErr.Output), true
+}
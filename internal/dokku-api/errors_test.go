@@ -1,6 +1,10 @@
 package dokkuApi
 
-import "testing"
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
 
 func TestIsNotFoundError(t *testing.T) {
 	var err error
@@ -17,3 +21,86 @@ func TestIsNotFoundError(t *testing.T) {
 		t.Fatalf("sentinel should be classified not-found")
 	}
 }
+
+func TestCommandOutput(t *testing.T) {
+	if _, ok := CommandOutput(nil); ok {
+		t.Fatalf("nil should not carry output")
+	}
+
+	err := fmt.Errorf("wrapped: %w", &CommandError{Command: "apps:create", Output: []byte(" !     Name is already taken"), Err: errors.New("exit status 1")})
+	out, ok := CommandOutput(err)
+	if !ok || out != " !     Name is already taken" {
+		t.Fatalf("expected raw output through wrapping, got %q (ok=%v)", out, ok)
+	}
+}
+
+func TestClassifyCommandErrorNotDeployed(t *testing.T) {
+	exitErr := errors.New("exit status 1")
+	outputs := map[string]string{
+		"ps:restart": " !     App my-app has not been deployed",
+		"ps:rebuild": " !     App my-app has not been deployed\n",
+		"ps:start":   "-----> App my-app is not deployed",
+		"ps:scale":   " !     App my-app not deployed yet",
+	}
+
+	for command, output := range outputs {
+		err := classifyCommandError(command, []byte(output), exitErr)
+		if !IsNotDeployedError(err) {
+			t.Fatalf("%s: expected not-deployed classification, got %v", command, err)
+		}
+		if IsNotFoundError(err) {
+			t.Fatalf("%s: not-deployed output should not be classified not-found", command)
+		}
+		if out, ok := CommandOutput(err); !ok || out != output {
+			t.Fatalf("%s: expected raw output to be kept, got %q", command, out)
+		}
+	}
+
+	if err := classifyCommandError("ps:report", []byte(" !     App my-app does not exist"), exitErr); !IsNotFoundError(err) || IsNotDeployedError(err) {
+		t.Fatalf("expected not-found classification, got %v", err)
+	}
+	if err := classifyCommandError("config:set", []byte(" !     Invalid key"), exitErr); IsNotDeployedError(err) || IsNotFoundError(err) {
+		t.Fatalf("expected plain command error, got %v", err)
+	}
+	if !shouldReturnEmptyLogs("logs", []byte(" !     App my-app has not been deployed")) {
+		t.Fatalf("logs for a never-deployed app should return empty output")
+	}
+}
+
+func TestClassifyCommandErrorFailureCauses(t *testing.T) {
+	exitErr := errors.New("exit status 1")
+	tests := []struct {
+		name    string
+		command string
+		output  string
+		want    error
+	}{
+		{"plugin missing", "postgres:create", " !     `postgres:create` is not a dokku command.", ErrPluginNotInstalled},
+		{"permission denied", "storage:mount", "mkdir: cannot create directory '/var/lib/dokku/data/storage/my-app': Permission denied", ErrPermissionDenied},
+		{"operation not permitted", "nginx:set", "chown: changing ownership of '/home/dokku/my-app/nginx.conf': Operation not permitted", ErrPermissionDenied},
+		{"root required", "events", " !     This command must be run as root", ErrPermissionDenied},
+		{"disk full", "git:sync", "fatal: write error: No space left on device", ErrQuotaExceeded},
+		{"quota", "builder:set", "error: disk quota exceeded", ErrQuotaExceeded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := classifyCommandError(tt.command, []byte(tt.output), exitErr)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, err)
+			}
+			if out, ok := CommandOutput(err); !ok || out != tt.output {
+				t.Fatalf("expected raw output to be kept, got %q", out)
+			}
+		})
+	}
+
+	if err := classifyCommandError("config:set", []byte(" !     Invalid key"), exitErr); errors.Is(err, ErrPermissionDenied) || errors.Is(err, ErrPluginNotInstalled) || errors.Is(err, ErrQuotaExceeded) {
+		t.Fatalf("expected plain command error, got %v", err)
+	}
+
+	refusedKey := "git@github.com: Permission denied (publickey).\nfatal: Could not read from remote repository."
+	if err := classifyCommandError("git:sync", []byte(refusedKey), exitErr); errors.Is(err, ErrPermissionDenied) {
+		t.Fatalf("expected a refused SSH key not to be a host permission failure, got %v", err)
+	}
+}
@@ -0,0 +1,109 @@
+package dokkuApi
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// featureSpec describes an optional Dokku feature and the plugin providing it.
+// Core features ship with Dokku from Since; the others need an external plugin.
+type featureSpec struct {
+	Plugin string
+	Core   bool
+	Since  DokkuVersion
+}
+
+// knownFeatures lists the features tools may depend on, by the name check_feature accepts
+var knownFeatures = map[string]featureSpec{
+	"cron":        {Plugin: "cron", Core: true, Since: DokkuVersion{Major: 0, Minor: 23, Patch: 0}},
+	"cron-run":    {Plugin: "cron", Core: true, Since: DokkuVersion{Major: 0, Minor: 32, Patch: 0}},
+	"network":     {Plugin: "network", Core: true, Since: DokkuVersion{Major: 0, Minor: 11, Patch: 0}},
+	"storage":     {Plugin: "storage", Core: true, Since: DokkuVersion{Major: 0, Minor: 5, Patch: 0}},
+	"registry":    {Plugin: "registry", Core: true, Since: DokkuVersion{Major: 0, Minor: 25, Patch: 0}},
+	"ports":       {Plugin: "ports", Core: true, Since: portsPluginVersion},
+	"json-output": {Core: true, Since: jsonFormatMinVersion},
+	"letsencrypt": {Plugin: "letsencrypt"},
+	"maintenance": {Plugin: "maintenance"},
+	"http-auth":   {Plugin: "http-auth"},
+	"postgres":    {Plugin: "postgres"},
+	"mysql":       {Plugin: "mysql"},
+	"redis":       {Plugin: "redis"},
+	"mongo":       {Plugin: "mongo"},
+}
+
+// FeatureAvailability tells whether a Dokku feature can be used on the connected host
+type FeatureAvailability struct {
+	Feature   string `json:"feature"`
+	Plugin    string `json:"plugin,omitempty"`
+	Available bool   `json:"available"`
+	// Core features ship with Dokku; the others need `dokku plugin:install`
+	Core         bool   `json:"core"`
+	MinVersion   string `json:"min_version,omitempty"`
+	DokkuVersion string `json:"dokku_version"`
+	Reason       string `json:"reason,omitempty"`
+}
+
+// KnownFeatures returns the feature names CheckFeature accepts, sorted
+func KnownFeatures() []string {
+	names := make([]string, 0, len(knownFeatures))
+	for name := range knownFeatures {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// CheckFeature reports whether a feature is available from the discovered
+// plugins and Dokku version. An installed plugin is trusted first; a core
+// feature is otherwise available when the version is recent enough.
+func (dc *DokkuCapabilities) CheckFeature(name string) (*FeatureAvailability, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	spec, ok := knownFeatures[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s (known features: %s)", ErrUnknownFeature, name, strings.Join(KnownFeatures(), ", "))
+	}
+
+	dc.mu.RLock()
+	version := dc.Version
+	plugins := slices.Clone(dc.Plugins)
+	dc.mu.RUnlock()
+
+	result := &FeatureAvailability{
+		Feature:      name,
+		Plugin:       spec.Plugin,
+		Core:         spec.Core,
+		DokkuVersion: version,
+	}
+	if spec.Core {
+		result.MinVersion = spec.Since.String()
+	}
+
+	if spec.Plugin != "" && slices.Contains(plugins, spec.Plugin) {
+		result.Available = true
+		return result, nil
+	}
+
+	if !spec.Core {
+		if len(plugins) == 0 {
+			result.Reason = "installed plugins are unknown (plugin discovery has not completed)"
+		} else {
+			result.Reason = fmt.Sprintf("the %s plugin is not installed", spec.Plugin)
+		}
+		return result, nil
+	}
+
+	parsed, err := ParseDokkuVersion(version)
+	switch {
+	case err != nil:
+		result.Reason = "the Dokku version is unknown"
+	case parsed.Compare(spec.Since) < 0:
+		result.Reason = fmt.Sprintf("requires Dokku %s or later", spec.Since.String())
+	case spec.Plugin != "" && len(plugins) > 0:
+		result.Reason = fmt.Sprintf("the core %s plugin is disabled", spec.Plugin)
+	default:
+		result.Available = true
+	}
+	return result, nil
+}
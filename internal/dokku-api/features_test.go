@@ -0,0 +1,61 @@
+package dokkuApi
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCheckFeature(t *testing.T) {
+	discovered := NewDokkuCapabilities()
+	discovered.UpdateVersion("0.35.12")
+	discovered.UpdatePlugins([]string{"00_dokku-standard", "apps", "cron", "letsencrypt", "storage"})
+
+	pinned := NewDokkuCapabilities()
+	pinned.UpdateVersion("0.22.4")
+
+	cases := []struct {
+		name          string
+		capabilities  *DokkuCapabilities
+		feature       string
+		wantAvailable bool
+		wantReason    string
+	}{
+		{name: "installed external plugin", capabilities: discovered, feature: "letsencrypt", wantAvailable: true},
+		{name: "missing external plugin", capabilities: discovered, feature: "maintenance", wantReason: "the maintenance plugin is not installed"},
+		{name: "installed core plugin", capabilities: discovered, feature: "cron", wantAvailable: true},
+		{name: "disabled core plugin", capabilities: discovered, feature: "network", wantReason: "the core network plugin is disabled"},
+		{name: "version-gated feature", capabilities: discovered, feature: "json-output", wantAvailable: true},
+		{name: "core plugin too recent", capabilities: pinned, feature: "cron", wantReason: "requires Dokku 0.23.0 or later"},
+		{name: "core command too recent", capabilities: pinned, feature: "cron-run", wantReason: "requires Dokku 0.32.0 or later"},
+		{name: "core plugin from version", capabilities: pinned, feature: "storage", wantAvailable: true},
+		{name: "external plugin without discovery", capabilities: pinned, feature: "letsencrypt", wantReason: "installed plugins are unknown (plugin discovery has not completed)"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			availability, err := tc.capabilities.CheckFeature(tc.feature)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if availability.Available != tc.wantAvailable || availability.Reason != tc.wantReason {
+				t.Fatalf("CheckFeature(%s) = %+v, want available=%v reason=%q", tc.feature, availability, tc.wantAvailable, tc.wantReason)
+			}
+		})
+	}
+}
+
+func TestCheckFeatureReportsMinimumVersion(t *testing.T) {
+	availability, err := NewDokkuCapabilities().CheckFeature("Ports")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !availability.Core || availability.MinVersion != "0.31.0" || availability.Available {
+		t.Fatalf("unexpected availability: %+v", availability)
+	}
+}
+
+func TestCheckFeatureRejectsUnknownFeature(t *testing.T) {
+	if _, err := NewDokkuCapabilities().CheckFeature("teleport"); !errors.Is(err, ErrUnknownFeature) {
+		t.Fatalf("expected ErrUnknownFeature, got %v", err)
+	}
+}
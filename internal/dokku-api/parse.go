@@ -1,6 +1,7 @@
 package dokkuApi
 
 import (
+	"encoding/json"
 	"strings"
 )
 
@@ -30,6 +31,64 @@ func ParseKeyValueOutput(output string, separator string) map[string]string {
 	return result
 }
 
+// warningLinePrefixes identify the warning and deprecation banners Dokku prints to stdout
+// (compared against the lowercased, left-trimmed line)
+var warningLinePrefixes = []string{"!", "-----> warning", "warning:", "deprecated", "deprecation"}
+
+// StripWarningLines removes warning lines printed before or after the actual results and
+// returns the remaining output with the warning messages. Lines in the middle of the
+// output are kept, since a "!" there is more likely part of the data.
+func StripWarningLines(output string) (string, []string) {
+	lines := strings.Split(output, "\n")
+	start, end := 0, len(lines)
+
+	var leading, trailing []string
+	for start < end {
+		if strings.TrimSpace(lines[start]) == "" {
+			start++
+			continue
+		}
+		message, ok := warningLineMessage(lines[start])
+		if !ok {
+			break
+		}
+		leading = append(leading, message)
+		start++
+	}
+	for end > start {
+		if strings.TrimSpace(lines[end-1]) == "" {
+			end--
+			continue
+		}
+		message, ok := warningLineMessage(lines[end-1])
+		if !ok {
+			break
+		}
+		trailing = append([]string{message}, trailing...)
+		end--
+	}
+
+	if len(leading) == 0 && len(trailing) == 0 {
+		return output, nil
+	}
+	return strings.Join(lines[start:end], "\n"), append(leading, trailing...)
+}
+
+// warningLineMessage returns the message of a warning line without its marker
+func warningLineMessage(line string) (string, bool) {
+	trimmed := strings.TrimSpace(line)
+	lower := strings.ToLower(trimmed)
+	for _, prefix := range warningLinePrefixes {
+		if strings.HasPrefix(lower, prefix) {
+			if prefix == "!" || prefix == "-----> warning" {
+				trimmed = strings.TrimSpace(strings.TrimLeft(trimmed[len(prefix):], ":"))
+			}
+			return trimmed, true
+		}
+	}
+	return "", false
+}
+
 // ParseListOutput parses a list output (one item per line, optionally skipping headers/empty lines).
 func ParseListOutput(output string, filterEmpty bool) []string {
 	lines := strings.Split(strings.TrimSpace(output), "\n")
@@ -57,6 +116,46 @@ func ParseListOutput(output string, filterEmpty bool) []string {
 	return result
 }
 
+// jsonListNameKeys are the fields used to name an item when a list command returns objects
+var jsonListNameKeys = []string{"name", "app", "id"}
+
+// ParseJSONListOutput parses the JSON array returned by a list command with
+// --format json. String items are kept as is; objects are named by their
+// "name", "app" or "id" field. It returns false when the output is not an array.
+func ParseJSONListOutput(output []byte) ([]string, bool) {
+	var items []json.RawMessage
+	if err := json.Unmarshal(output, &items); err != nil {
+		return nil, false
+	}
+
+	result := make([]string, 0, len(items))
+	for _, item := range items {
+		var value string
+		if err := json.Unmarshal(item, &value); err == nil {
+			result = append(result, value)
+			continue
+		}
+
+		var object map[string]any
+		if err := json.Unmarshal(item, &object); err != nil {
+			result = append(result, string(item))
+			continue
+		}
+		for _, key := range jsonListNameKeys {
+			if name, ok := object[key].(string); ok && name != "" {
+				value = name
+				break
+			}
+		}
+		if value == "" {
+			value = string(item)
+		}
+		result = append(result, value)
+	}
+
+	return result, true
+}
+
 // ParseTableOutput parses table output (first line is header, rest are rows).
 func ParseTableOutput(output string, skipHeaders bool) []map[string]string {
 	lines := strings.Split(strings.TrimSpace(output), "\n")
@@ -0,0 +1,80 @@
+package dokkuApi
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseStructuredOutputStripsDeprecationBanner(t *testing.T) {
+	output := ` !     Deprecated: Please use ports:report
+=====> my-app ports information
+       Ports map:                     http:80:5000
+       Ports map detected:            http:80:5000
+-----> Warning: proxy:ports will be removed in a future release
+`
+	result, err := parseStructuredOutput(CommandSpec{
+		Command:      "proxy:ports",
+		OutputFormat: OutputFormatKeyValue,
+		Separator:    ":",
+	}, []byte(output))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	wantWarnings := []string{
+		"Deprecated: Please use ports:report",
+		"proxy:ports will be removed in a future release",
+	}
+	if !reflect.DeepEqual(result.Warnings, wantWarnings) {
+		t.Fatalf("expected warnings %q, got %q", wantWarnings, result.Warnings)
+	}
+	if got := result.KeyValueData["Ports map"]; got != "http:80:5000" {
+		t.Fatalf("expected ports map to be parsed, got %q", got)
+	}
+	for key := range result.KeyValueData {
+		if key == "!     Deprecated" || key == "-----> Warning" {
+			t.Fatalf("warning line leaked into parsed data: %q", key)
+		}
+	}
+	if string(result.RawOutput) != output {
+		t.Fatalf("raw output should be left untouched")
+	}
+}
+
+func TestStripWarningLinesKeepsOutputWithoutWarnings(t *testing.T) {
+	output := "app-one\n ! not a banner in the middle\napp-two\n"
+	body, warnings := StripWarningLines(output)
+	if body != output || warnings != nil {
+		t.Fatalf("expected output unchanged, got %q (warnings %q)", body, warnings)
+	}
+}
+
+func TestJSONFormatResultParsesListArray(t *testing.T) {
+	result, ok := jsonFormatResult("apps:list", []byte(`["api","web","worker"]`))
+	if !ok {
+		t.Fatalf("expected a JSON array to be accepted for a list command")
+	}
+	if !reflect.DeepEqual(result.ListData, []string{"api", "web", "worker"}) {
+		t.Fatalf("unexpected list data: %v", result.ListData)
+	}
+	if string(result.JSONData) != `["api","web","worker"]` {
+		t.Fatalf("expected the raw JSON to be kept, got %s", result.JSONData)
+	}
+
+	result, ok = jsonFormatResult("domains:list", []byte(`[{"name":"example.com"},{"name":"*.example.com"}]`))
+	if !ok || !reflect.DeepEqual(result.ListData, []string{"example.com", "*.example.com"}) {
+		t.Fatalf("expected objects to be named by their name field, got %+v", result)
+	}
+}
+
+func TestJSONFormatResultRejectsNonArrayForList(t *testing.T) {
+	if _, ok := jsonFormatResult("apps:list", []byte(`{"apps":["api"]}`)); ok {
+		t.Fatalf("expected a JSON object to be rejected for a list command")
+	}
+	if _, ok := jsonFormatResult("apps:list", []byte("=====> My Apps\napi\n")); ok {
+		t.Fatalf("expected text output to be rejected")
+	}
+	if _, ok := jsonFormatResult("apps:report", []byte(`{"app-dir":"/home/dokku/api"}`)); !ok {
+		t.Fatalf("expected a JSON object to be accepted for a report command")
+	}
+}
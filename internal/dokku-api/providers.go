@@ -1,32 +1,68 @@
 package dokkuApi
 
 import (
+	"fmt"
 	"log/slog"
+	"strings"
 
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
 	"github.com/dokku-mcp/dokku-mcp/pkg/config"
 )
 
 // NewDokkuClientFromConfig creates a DokkuClient from the server configuration.
-func NewDokkuClientFromConfig(cfg *config.ServerConfig, logger *slog.Logger) DokkuClient {
+func NewDokkuClientFromConfig(cfg *config.ServerConfig, scope *shared.AppScope, logger *slog.Logger) DokkuClient {
 	sshHost := cfg.SSH.Host
 	sshPort := cfg.SSH.Port
 	sshUser := cfg.SSH.User
 	sshKeyPath := cfg.SSH.KeyPath
 
 	dokkuConfig := &ClientConfig{
-		DokkuHost:      sshHost,
-		DokkuPort:      sshPort,
-		DokkuUser:      sshUser,
-		DokkuPath:      cfg.DokkuPath,
-		SSHKeyPath:     sshKeyPath,
-		CommandTimeout: cfg.Timeout,
-		DisablePTY:     cfg.SSH.DisablePTY,
-		Cache:          createCacheConfig(cfg),
+		DokkuHost:            sshHost,
+		DokkuPort:            sshPort,
+		DokkuUser:            sshUser,
+		DokkuPath:            cfg.DokkuPath,
+		DokkuVersion:         cfg.DokkuVersion,
+		SSHKeyPath:           sshKeyPath,
+		CommandTimeout:       cfg.Timeout,
+		DisablePTY:           cfg.SSH.DisablePTY,
+		StrictKeyPermissions: cfg.SSH.StrictKeyPermissions,
+		AuthMethods:          cfg.SSH.AuthMethods,
+		CommandPath:          cfg.SSH.CommandPath,
+		CommandEnv:           commandEnvFromConfig(cfg.SSH.CommandEnv),
+		ReadOnly:             cfg.ReadOnly,
+		BreakGlass:           cfg.Security.BreakGlass,
+		AppScope:             scope,
+		CommandAliases:       cfg.CommandAliases,
+		LogRedactCommands:    cfg.Security.LogRedaction.RedactCommands,
+		LogAllowCommands:     cfg.Security.LogRedaction.AllowCommands,
+		Cache:                createCacheConfig(cfg),
+		CircuitBreaker: &CircuitBreakerConfig{
+			Enabled:          cfg.CircuitBreaker.Enabled,
+			FailureThreshold: cfg.CircuitBreaker.FailureThreshold,
+			CoolDown:         cfg.CircuitBreaker.CoolDown,
+		},
+		Retry: &RetryConfig{
+			Enabled:     cfg.Retry.Enabled,
+			MaxAttempts: cfg.Retry.MaxAttempts,
+			BaseDelay:   cfg.Retry.BaseDelay,
+			MaxDelay:    cfg.Retry.MaxDelay,
+		},
+		RateLimit: &RateLimitConfig{
+			RequestsPerSecond: cfg.RateLimit.RequestsPerSecond,
+			Burst:             cfg.RateLimit.Burst,
+		},
 	}
 
 	client := NewDokkuClient(dokkuConfig, logger)
 	client.SetBlacklist(cfg.Security.Blacklist)
 
+	if cfg.ReadOnly {
+		logger.Warn("Read-only mode enabled, mutating Dokku commands are blocked")
+	}
+	if cfg.Security.BreakGlass {
+		logger.Warn("Break-glass mode enabled, blacklisted commands can be run one at a time with an audited reason")
+	}
+
 	if cfg.CacheEnabled {
 		logger.Info("Command-level caching enabled",
 			"cache_ttl", cfg.CacheTTL)
@@ -37,6 +73,53 @@ func NewDokkuClientFromConfig(cfg *config.ServerConfig, logger *slog.Logger) Dok
 	return client
 }
 
+// ValidateSSHKeyPermissions checks the configured SSH key file permissions at startup.
+// It only fails when ssh.strict_key_permissions is enabled; otherwise lax permissions are logged.
+func ValidateSSHKeyPermissions(cfg *config.ServerConfig, logger *slog.Logger) error {
+	if cfg.SSH.KeyPath == "" {
+		return nil
+	}
+
+	authService := NewSSHAuthServiceWithConfig(logger, &SSHAuthConfig{
+		StrictKeyPermissions: cfg.SSH.StrictKeyPermissions,
+	})
+	if err := authService.ValidateKeyFilePermissions(cfg.SSH.KeyPath); err != nil {
+		return fmt.Errorf("invalid SSH key file: %w", err)
+	}
+	return nil
+}
+
+// ValidateSSHAuthMethods checks ssh.auth_methods against the known authentication methods at startup.
+func ValidateSSHAuthMethods(cfg *config.ServerConfig) error {
+	if err := ValidateAuthMethodOrder(cfg.SSH.AuthMethods); err != nil {
+		return fmt.Errorf("invalid ssh.auth_methods: %w", err)
+	}
+	return nil
+}
+
+// ValidateSSHCommandEnvironment checks ssh.command_path and ssh.command_env at startup.
+func ValidateSSHCommandEnvironment(cfg *config.ServerConfig) error {
+	if err := ValidateCommandPath(cfg.SSH.CommandPath); err != nil {
+		return fmt.Errorf("invalid ssh.command_path: %w", err)
+	}
+	if err := ValidateCommandEnv(commandEnvFromConfig(cfg.SSH.CommandEnv)); err != nil {
+		return fmt.Errorf("invalid ssh.command_env: %w", err)
+	}
+	return nil
+}
+
+// commandEnvFromConfig upper-cases ssh.command_env names, which viper lowercases when loading maps
+func commandEnvFromConfig(vars map[string]string) map[string]string {
+	if len(vars) == 0 {
+		return nil
+	}
+	env := make(map[string]string, len(vars))
+	for name, value := range vars {
+		env[strings.ToUpper(name)] = value
+	}
+	return env
+}
+
 // createCacheConfig creates a cache configuration from server config
 func createCacheConfig(cfg *config.ServerConfig) *CacheConfig {
 	if !cfg.CacheEnabled {
@@ -0,0 +1,77 @@
+package dokkuApi
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimitConfig bounds how fast commands are sent to the Dokku host
+type RateLimitConfig struct {
+	// RequestsPerSecond is the sustained command rate; 0 disables the limiter
+	RequestsPerSecond float64 `yaml:"requests_per_second"`
+	// Burst is how many commands may start back to back before the rate applies (at least 1)
+	Burst int `yaml:"burst"`
+}
+
+// RateLimiter is a token bucket spacing out the commands run over SSH, so a
+// misbehaving MCP client cannot flood the Dokku host
+type RateLimiter struct {
+	rate   float64
+	burst  float64
+	now    func() time.Time
+	mutex  sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter creates a limiter with a full bucket; nil (no limit) when unconfigured
+func NewRateLimiter(config *RateLimitConfig) *RateLimiter {
+	if config == nil || config.RequestsPerSecond <= 0 {
+		return nil
+	}
+
+	burst := float64(max(config.Burst, 1))
+	return &RateLimiter{
+		rate:   config.RequestsPerSecond,
+		burst:  burst,
+		now:    time.Now,
+		tokens: burst,
+	}
+}
+
+// Wait blocks until a command may run or ctx is done. Waiting commands are served
+// in arrival order: each one reserves a token, possibly ahead of its refill.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	l.mutex.Lock()
+	now := l.now()
+	if !l.last.IsZero() {
+		l.tokens = min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	}
+	l.last = now
+	l.tokens--
+	delay := time.Duration(-l.tokens / l.rate * float64(time.Second))
+	l.mutex.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		// Hand the reserved token back to the commands still waiting
+		l.mutex.Lock()
+		l.tokens++
+		l.mutex.Unlock()
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,63 @@
+package dokkuApi
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterSpacesRapidCommands(t *testing.T) {
+	var runs []time.Time
+	c := newRunnerTestClient(t, 30*time.Second, func(ctx context.Context, sshArgs []string, env []string, stdin []byte) ([]byte, error) {
+		runs = append(runs, time.Now())
+		return []byte("ok"), nil
+	})
+	c.rateLimiter = NewRateLimiter(&RateLimitConfig{RequestsPerSecond: 20, Burst: 2})
+
+	for i := 0; i < 5; i++ {
+		if _, err := c.ExecuteCommand(context.Background(), "apps:list", nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	// The burst runs at once, then one command every 50ms
+	if gap := runs[1].Sub(runs[0]); gap > 20*time.Millisecond {
+		t.Fatalf("expected the burst to run without waiting, got %v", gap)
+	}
+	for i := 2; i < len(runs); i++ {
+		if gap := runs[i].Sub(runs[i-1]); gap < 40*time.Millisecond {
+			t.Fatalf("expected command %d to wait for a token, got a %v gap", i+1, gap)
+		}
+	}
+	if total := runs[len(runs)-1].Sub(runs[0]); total < 140*time.Millisecond {
+		t.Fatalf("expected 5 commands at 20/s with a burst of 2 to take at least 150ms, took %v", total)
+	}
+}
+
+func TestRateLimiterWaitStopsWithContext(t *testing.T) {
+	limiter := NewRateLimiter(&RateLimitConfig{RequestsPerSecond: 1, Burst: 1})
+	if err := limiter.Wait(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if err := limiter.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the wait to end with the context, got %v", err)
+	}
+	if waited := time.Since(start); waited > 500*time.Millisecond {
+		t.Fatalf("expected the wait to stop at the deadline, waited %v", waited)
+	}
+}
+
+func TestRateLimiterDisabledWhenUnconfigured(t *testing.T) {
+	if NewRateLimiter(nil) != nil || NewRateLimiter(&RateLimitConfig{Burst: 5}) != nil {
+		t.Fatalf("expected no limiter without a rate")
+	}
+	var limiter *RateLimiter
+	if err := limiter.Wait(context.Background()); err != nil {
+		t.Fatalf("expected a nil limiter to let commands through, got %v", err)
+	}
+}
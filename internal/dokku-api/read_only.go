@@ -0,0 +1,41 @@
+package dokkuApi
+
+import "strings"
+
+// readVerbs lists the command verbs that only inspect state. In read-only mode
+// anything else is treated as mutating, so unknown plugin commands are blocked.
+var readVerbs = map[string]bool{
+	"access-logs": true,
+	"error-logs":  true,
+	"events":      true,
+	"exists":      true,
+	"export":      true,
+	"failed":      true,
+	"get":         true,
+	"help":        true,
+	"info":        true,
+	"inspect":     true,
+	"keys":        true,
+	"links":       true,
+	"list":        true,
+	"logs":        true,
+	"public-key":  true,
+	"report":      true,
+	"show":        true,
+	"show-config": true,
+	"version":     true,
+}
+
+// commandVerb returns the verb of a Dokku command: the part after the last colon
+// (apps:destroy -> destroy), or the whole name for top-level commands (logs)
+func commandVerb(commandName string) string {
+	if i := strings.LastIndex(commandName, ":"); i >= 0 {
+		return commandName[i+1:]
+	}
+	return commandName
+}
+
+// IsReadCommand reports whether a Dokku command only reads state
+func IsReadCommand(commandName string) bool {
+	return readVerbs[commandVerb(commandName)]
+}
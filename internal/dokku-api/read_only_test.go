@@ -0,0 +1,62 @@
+package dokkuApi
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+)
+
+func TestReadOnlyModeBlocksMutatingCommands(t *testing.T) {
+	original := startDiscovery
+	startDiscovery = func(c *client) {}
+	defer func() { startDiscovery = original }()
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	config := DefaultClientConfig()
+	config.DokkuVersion = "0.35.12"
+	config.ReadOnly = true
+	c := NewDokkuClient(config, logger).(*client)
+
+	// A cached result stands in for the SSH round-trip of the read command
+	c.cacheManager.Set("apps:list", nil, []byte("my-app\n"), nil)
+
+	output, err := c.ExecuteCommand(context.Background(), "apps:list", nil)
+	if err != nil {
+		t.Fatalf("apps:list should pass through in read-only mode: %v", err)
+	}
+	if string(output) != "my-app\n" {
+		t.Fatalf("unexpected apps:list output: %q", output)
+	}
+
+	_, err = c.ExecuteCommand(context.Background(), "apps:destroy", []string{"my-app", "--force"})
+	if !errors.Is(err, ErrReadOnlyMode) {
+		t.Fatalf("apps:destroy should be blocked in read-only mode, got %v", err)
+	}
+}
+
+func TestIsReadCommand(t *testing.T) {
+	cases := map[string]bool{
+		"apps:list":         true,
+		"config:show":       true,
+		"git:report":        true,
+		"logs":              true,
+		"logs:failed":       true,
+		"nginx:show-config": true,
+		"version":           true,
+		"apps:destroy":      false,
+		"config:set":        false,
+		"git:sync":          false,
+		"ps:scale":          false,
+		"run":               false,
+		"postgres:unknown":  false,
+	}
+
+	for command, want := range cases {
+		if got := IsReadCommand(command); got != want {
+			t.Errorf("IsReadCommand(%q) = %v, want %v", command, got, want)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package dokkuApi
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// RetryConfig defines how commands failing on a transient connection error are retried
+type RetryConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// MaxAttempts counts every run of the command, including the first
+	MaxAttempts int           `yaml:"max_attempts"`
+	BaseDelay   time.Duration `yaml:"base_delay"`
+	MaxDelay    time.Duration `yaml:"max_delay"`
+	// Retryable reports whether a failed run may be retried; nil uses IsRetryableError
+	Retryable func(err error) bool `yaml:"-"`
+}
+
+// DefaultRetryConfig returns sensible retry defaults
+func DefaultRetryConfig() *RetryConfig {
+	return &RetryConfig{
+		Enabled:     true,
+		MaxAttempts: 3,
+		BaseDelay:   500 * time.Millisecond,
+		MaxDelay:    5 * time.Second,
+	}
+}
+
+// IsRetryableError reports whether a command failed because the host could not be
+// reached (connection refused or reset, timeout...), as opposed to Dokku reporting an error
+func IsRetryableError(err error) bool {
+	return errors.Is(err, ErrSSHUnreachable)
+}
+
+// idempotentCommands lists the mutating commands setting an absolute value: running
+// them twice leaves the host in the same state, so they are retried like reads
+var idempotentCommands = map[string]bool{
+	"builder:set":    true,
+	"buildpacks:set": true,
+	"checks:set":     true,
+	"config:set":     true,
+	"config:unset":   true,
+	"domains:set":    true,
+	"git:set":        true,
+	"nginx:set":      true,
+	"proxy:set":      true,
+	"ps:scale":       true,
+	"ps:set":         true,
+}
+
+// isRetryableCommand returns true for commands that can safely run again after a
+// connection failure: reads and idempotentCommands. A failed connection may hide a
+// run that already happened, so any other command is never retried.
+func isRetryableCommand(commandName string) bool {
+	return IsReadCommand(commandName) || idempotentCommands[commandName]
+}
+
+// backoff returns the delay before the given retry (1 for the first one): the base
+// delay doubled for every previous retry, capped at the maximum delay
+func (r *RetryConfig) backoff(retry int) time.Duration {
+	delay := r.BaseDelay
+	for i := 1; i < retry; i++ {
+		delay *= 2
+		if r.MaxDelay > 0 && delay >= r.MaxDelay {
+			return r.MaxDelay
+		}
+	}
+	if r.MaxDelay > 0 && delay > r.MaxDelay {
+		return r.MaxDelay
+	}
+	return delay
+}
+
+// executeWithRetry runs a command, retrying transient connection failures of
+// idempotent commands with exponential backoff. A command that timed out is never
+// retried: it may still have run on the host.
+func (c *client) executeWithRetry(ctx context.Context, commandName string, args []string, stdin []byte) ([]byte, error) {
+	policy := c.config.Retry
+	if policy == nil || !policy.Enabled || policy.MaxAttempts <= 1 || !isRetryableCommand(commandName) {
+		return c.executeCommandDirectWithInput(ctx, commandName, args, stdin)
+	}
+
+	retryable := policy.Retryable
+	if retryable == nil {
+		retryable = IsRetryableError
+	}
+
+	for attempt := 1; ; attempt++ {
+		output, err := c.executeCommandDirectWithInput(ctx, commandName, args, stdin)
+		if err == nil || attempt >= policy.MaxAttempts || errors.Is(err, ErrCommandTimeout) || !retryable(err) || ctx.Err() != nil {
+			return output, err
+		}
+		// An open circuit fast-fails every attempt until its cool-down elapses
+		if c.circuitBreaker.State() == CircuitOpen {
+			return output, err
+		}
+
+		delay := policy.backoff(attempt)
+		c.logger.Warn("Dokku command failed on a transient error, retrying",
+			"command", commandName,
+			"attempt", attempt,
+			"max_attempts", policy.MaxAttempts,
+			"delay", delay,
+			"error", err)
+
+		select {
+		case <-ctx.Done():
+			return output, err
+		case <-time.After(delay):
+		}
+	}
+}
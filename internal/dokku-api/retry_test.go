@@ -0,0 +1,178 @@
+package dokkuApi
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// flakyRunner is a commandRunner failing with a connection error a fixed number of times before succeeding
+type flakyRunner struct {
+	failures int
+	calls    int
+	output   []byte
+}
+
+func (r *flakyRunner) run(ctx context.Context, sshArgs []string, env []string, stdin []byte) ([]byte, error) {
+	r.calls++
+	if r.calls <= r.failures {
+		return []byte("ssh: connect to host dokku.example.com port 22: Connection refused"), errors.New("exit status 255")
+	}
+	return []byte("ok"), nil
+}
+
+func newRetryTestClient(t *testing.T, maxAttempts int, runner commandRunner) *client {
+	t.Helper()
+	c := newRunnerTestClient(t, time.Second, runner)
+	c.config.Retry = &RetryConfig{
+		Enabled:     true,
+		MaxAttempts: maxAttempts,
+		BaseDelay:   time.Millisecond,
+		MaxDelay:    4 * time.Millisecond,
+	}
+	return c
+}
+
+func TestRetrySucceedsAfterTransientFailures(t *testing.T) {
+	runner := &flakyRunner{failures: 2}
+	c := newRetryTestClient(t, 3, runner.run)
+
+	output, err := c.ExecuteCommand(context.Background(), "apps:list", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(output) != "ok" {
+		t.Fatalf("expected the output of the successful attempt, got %q", output)
+	}
+	if runner.calls != 3 {
+		t.Fatalf("expected 3 attempts, got %d", runner.calls)
+	}
+}
+
+func TestRetryGivesUpAfterMaxAttempts(t *testing.T) {
+	runner := &flakyRunner{failures: 5}
+	c := newRetryTestClient(t, 3, runner.run)
+
+	_, err := c.ExecuteCommand(context.Background(), "apps:list", nil)
+	if !errors.Is(err, ErrSSHUnreachable) {
+		t.Fatalf("expected an unreachable host error, got %v", err)
+	}
+	if runner.calls != 3 {
+		t.Fatalf("expected 3 attempts, got %d", runner.calls)
+	}
+}
+
+func TestRetrySkipsNonIdempotentCommands(t *testing.T) {
+	runner := &flakyRunner{failures: 1}
+	c := newRetryTestClient(t, 3, runner.run)
+
+	if _, err := c.ExecuteCommand(context.Background(), "apps:create", []string{"my-app"}); err == nil {
+		t.Fatal("expected apps:create to fail without being retried")
+	}
+	if runner.calls != 1 {
+		t.Fatalf("expected a single attempt, got %d", runner.calls)
+	}
+}
+
+func TestRetrySkipsDokkuErrors(t *testing.T) {
+	calls := 0
+	c := newRetryTestClient(t, 3, func(ctx context.Context, sshArgs []string, env []string, stdin []byte) ([]byte, error) {
+		calls++
+		return []byte(" !     Invalid key"), errors.New("exit status 1")
+	})
+
+	if _, err := c.ExecuteCommand(context.Background(), "config:get", []string{"my-app", "KEY"}); err == nil {
+		t.Fatal("expected the Dokku error to be returned")
+	}
+	if calls != 1 {
+		t.Fatalf("expected a single attempt, got %d", calls)
+	}
+}
+
+func TestRetryUsesCustomPredicate(t *testing.T) {
+	calls := 0
+	c := newRetryTestClient(t, 3, func(ctx context.Context, sshArgs []string, env []string, stdin []byte) ([]byte, error) {
+		calls++
+		if calls == 1 {
+			return []byte(" !     Lock held"), errors.New("exit status 1")
+		}
+		return []byte("ok"), nil
+	})
+	c.config.Retry.Retryable = func(err error) bool {
+		output, _ := CommandOutput(err)
+		return output == " !     Lock held"
+	}
+
+	if _, err := c.ExecuteCommand(context.Background(), "ps:report", []string{"my-app"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 2 {
+		t.Fatalf("expected 2 attempts, got %d", calls)
+	}
+}
+
+func TestRetryBackoffDoublesUpToMaxDelay(t *testing.T) {
+	policy := &RetryConfig{BaseDelay: 100 * time.Millisecond, MaxDelay: 300 * time.Millisecond}
+
+	expected := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 300 * time.Millisecond, 300 * time.Millisecond}
+	for i, want := range expected {
+		if got := policy.backoff(i + 1); got != want {
+			t.Fatalf("retry %d: expected %v, got %v", i+1, want, got)
+		}
+	}
+}
+
+func TestIsRetryableCommand(t *testing.T) {
+	cases := map[string]bool{
+		"apps:list":          true,
+		"config:set":         true,
+		"ps:scale":           true,
+		"apps:create":        false,
+		"apps:destroy":       false,
+		"git:sync":           false,
+		"ps:rebuild":         false,
+		"ps:restart":         false,
+		"ps:stop":            false,
+		"plugin:install":     false,
+		"letsencrypt:enable": false,
+		"storage:mount":      false,
+		"ssh-keys:add":       false,
+		"registry:login":     false,
+	}
+	for command, want := range cases {
+		if got := isRetryableCommand(command); got != want {
+			t.Errorf("isRetryableCommand(%q) = %v, want %v", command, got, want)
+		}
+	}
+}
+
+func TestRetrySkipsCommandsWithInput(t *testing.T) {
+	runner := &flakyRunner{failures: 1}
+	c := newRetryTestClient(t, 3, runner.run)
+
+	if _, err := c.ExecuteCommandWithInput(context.Background(), "ssh-keys:add", []string{"admin"}, []byte("ssh-ed25519 AAAA")); err == nil {
+		t.Fatal("expected ssh-keys:add to fail without being retried")
+	}
+	if runner.calls != 1 {
+		t.Fatalf("expected a single attempt, got %d", runner.calls)
+	}
+}
+
+func TestRetrySkipsTimedOutCommands(t *testing.T) {
+	calls := 0
+	c := newRetryTestClient(t, 3, func(ctx context.Context, sshArgs []string, env []string, stdin []byte) ([]byte, error) {
+		calls++
+		<-ctx.Done()
+		return nil, errors.New("signal: killed")
+	})
+	c.config.CommandTimeout = 10 * time.Millisecond
+
+	_, err := c.ExecuteCommand(context.Background(), "apps:list", nil)
+	if !errors.Is(err, ErrCommandTimeout) {
+		t.Fatalf("expected a command timeout, got %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected a single attempt, got %d", calls)
+	}
+}
@@ -2,11 +2,13 @@ package dokkuApi
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -23,8 +25,40 @@ type SSHAuthConfig struct {
 	HomeDir string
 	// CheckAgent allows to override the ssh-agent check for tests
 	CheckAgent func() bool
+	// StrictKeyPermissions refuses key files readable or writable by group or others
+	StrictKeyPermissions bool
+	// MethodOrder is the order authentication methods are tried in; empty means DefaultAuthMethodOrder
+	MethodOrder []string
 }
 
+// Authentication method names accepted in ssh.auth_methods
+const (
+	AuthMethodKey        = "key"         // the configured ssh.key_path
+	AuthMethodAgent      = "agent"       // keys loaded in ssh-agent
+	AuthMethodDefaultKey = "default_key" // ~/.ssh/id_rsa
+)
+
+// DefaultAuthMethodOrder tries the configured key first, then ssh-agent, then ~/.ssh/id_rsa
+var DefaultAuthMethodOrder = []string{AuthMethodKey, AuthMethodAgent, AuthMethodDefaultKey}
+
+// ValidateAuthMethodOrder checks that every method is known and listed once
+func ValidateAuthMethodOrder(order []string) error {
+	seen := make(map[string]bool, len(order))
+	for _, method := range order {
+		if !slices.Contains(DefaultAuthMethodOrder, method) {
+			return fmt.Errorf("unknown SSH auth method %q (expected one of: %s)", method, strings.Join(DefaultAuthMethodOrder, ", "))
+		}
+		if seen[method] {
+			return fmt.Errorf("SSH auth method %q is listed more than once", method)
+		}
+		seen[method] = true
+	}
+	return nil
+}
+
+// ErrInsecureKeyPermissions is returned when a key file is accessible to group or others, which ssh refuses
+var ErrInsecureKeyPermissions = errors.New("SSH key file permissions are too open")
+
 type SSHAuthService struct {
 	logger *slog.Logger
 	config *SSHAuthConfig
@@ -52,7 +86,8 @@ func NewSSHAuthServiceWithConfig(logger *slog.Logger, config *SSHAuthConfig) *SS
 	}
 }
 
-// Priority: 1. configured key, 2. ssh-agent, 3. ~/.ssh/id_rsa fallback
+// Methods are tried in the configured order (by default: configured key, ssh-agent, ~/.ssh/id_rsa),
+// falling back to ssh-agent when none is usable
 func (s *SSHAuthService) DetermineAuthMethod(configKeyPath string) *SSHAuthMethod {
 	// Check cache first
 	s.cacheMutex.RLock()
@@ -77,49 +112,73 @@ func (s *SSHAuthService) DetermineAuthMethod(configKeyPath string) *SSHAuthMetho
 }
 
 func (s *SSHAuthService) determineAuthMethodUncached(configKeyPath string) *SSHAuthMethod {
-	// 1. Use the configured key if specified and accessible (highest priority)
-	if configKeyPath != "" {
-		if s.isKeyFileAccessible(configKeyPath) {
-			s.logger.Debug("Using the configured SSH key",
-				"key_path", configKeyPath)
-			return &SSHAuthMethod{
-				KeyPath:     configKeyPath,
-				Description: fmt.Sprintf("configured key %s", configKeyPath),
-			}
-		} else {
+	order := s.config.MethodOrder
+	if len(order) == 0 {
+		order = DefaultAuthMethodOrder
+	}
+
+	for _, name := range order {
+		if method := s.tryAuthMethod(name, configKeyPath); method != nil {
+			return method
+		}
+	}
+
+	// No method available - use ssh-agent as fallback
+	s.logger.Warn("No reliable SSH authentication method found, using ssh-agent as fallback")
+	return &SSHAuthMethod{
+		UseAgent:    true,
+		Description: "ssh-agent (fallback)",
+	}
+}
+
+// tryAuthMethod returns the named authentication method when it is usable, nil otherwise
+func (s *SSHAuthService) tryAuthMethod(name string, configKeyPath string) *SSHAuthMethod {
+	switch name {
+	case AuthMethodKey:
+		if configKeyPath == "" {
+			return nil
+		}
+		if !s.isKeyFileAccessible(configKeyPath) {
 			s.logger.Warn("The configured SSH key is not accessible",
 				"key_path", configKeyPath)
+			return nil
+		}
+		s.logger.Debug("Using the configured SSH key",
+			"key_path", configKeyPath)
+		return &SSHAuthMethod{
+			KeyPath:     configKeyPath,
+			Description: fmt.Sprintf("configured key %s", configKeyPath),
 		}
-	}
 
-	// 2. Try ssh-agent if available (second priority)
-	if s.checkSshAgentAvailable() {
+	case AuthMethodAgent:
+		if !s.checkSshAgentAvailable() {
+			return nil
+		}
 		s.logger.Debug("Using ssh-agent for authentication")
 		return &SSHAuthMethod{
 			UseAgent:    true,
 			Description: "ssh-agent",
 		}
-	}
 
-	// 3. Fallback to ~/.ssh/id_rsa if the file exists
-	homeDir := s.getHomeDir()
-	if homeDir != "" {
+	case AuthMethodDefaultKey:
+		homeDir := s.getHomeDir()
+		if homeDir == "" {
+			return nil
+		}
 		defaultKeyPath := filepath.Join(homeDir, ".ssh", "id_rsa")
-		if s.isKeyFileAccessible(defaultKeyPath) {
-			s.logger.Debug("Using default SSH key",
-				"key_path", defaultKeyPath)
-			return &SSHAuthMethod{
-				KeyPath:     defaultKeyPath,
-				Description: "default key ~/.ssh/id_rsa",
-			}
+		if !s.isKeyFileAccessible(defaultKeyPath) {
+			return nil
+		}
+		s.logger.Debug("Using default SSH key",
+			"key_path", defaultKeyPath)
+		return &SSHAuthMethod{
+			KeyPath:     defaultKeyPath,
+			Description: "default key ~/.ssh/id_rsa",
 		}
-	}
 
-	// No method available - use ssh-agent as fallback
-	s.logger.Warn("No reliable SSH authentication method found, using ssh-agent as fallback")
-	return &SSHAuthMethod{
-		UseAgent:    true,
-		Description: "ssh-agent (fallback)",
+	default:
+		s.logger.Warn("Ignoring unknown SSH auth method", "method", name)
+		return nil
 	}
 }
 
@@ -189,8 +248,35 @@ func (s *SSHAuthService) isSshAgentAvailable() bool {
 }
 
 func (s *SSHAuthService) isKeyFileAccessible(keyPath string) bool {
+	err := s.checkKeyFile(keyPath)
+	if errors.Is(err, ErrInsecureKeyPermissions) && !s.config.StrictKeyPermissions {
+		return true
+	}
+	return err == nil
+}
+
+// ValidateKeyFilePermissions checks the mode bits of a configured key file at startup.
+// Lax permissions are logged as a warning, or returned when StrictKeyPermissions is set.
+// Missing keys are left to DetermineAuthMethod, which falls back to other methods.
+func (s *SSHAuthService) ValidateKeyFilePermissions(keyPath string) error {
+	err := s.checkKeyFile(keyPath)
+	if !errors.Is(err, ErrInsecureKeyPermissions) {
+		return nil
+	}
+	if s.config.StrictKeyPermissions {
+		return err
+	}
+
+	s.logger.Warn("The configured SSH key has permissions that ssh will refuse",
+		"key_path", keyPath,
+		"error", err,
+		"fix", fmt.Sprintf("chmod 600 %s", keyPath))
+	return nil
+}
+
+func (s *SSHAuthService) checkKeyFile(keyPath string) error {
 	if keyPath == "" {
-		return false
+		return fmt.Errorf("no key path")
 	}
 
 	// Clean the keyPath to prevent path traversal
@@ -199,26 +285,31 @@ func (s *SSHAuthService) isKeyFileAccessible(keyPath string) bool {
 	// Check if file exists and is readable
 	info, err := os.Stat(keyPath)
 	if err != nil {
-		return false
+		return err
 	}
 
 	// Check if it's a regular file
 	if !info.Mode().IsRegular() {
-		return false
+		return fmt.Errorf("%s is not a regular file", keyPath)
 	}
 
 	// Try to open the file to verify read access
 	file, err := os.Open(keyPath)
 	if err != nil {
-		return false
+		return err
 	}
 	// Handle error from file.Close() (G104)
 	if cerr := file.Close(); cerr != nil {
 		s.logger.Warn("Error closing key file", "error", cerr)
-		return false
+		return cerr
 	}
 
-	return true
+	// ssh only accepts private keys that are not accessible to group or others (0600/0400)
+	if perm := info.Mode().Perm(); perm&0o077 != 0 {
+		return fmt.Errorf("%w: %s has mode %04o, expected 0600 or 0400", ErrInsecureKeyPermissions, keyPath, perm)
+	}
+
+	return nil
 }
 
 func (s *SSHAuthService) PrepareSSHArgs(authMethod *SSHAuthMethod, baseArgs []string) []string {
@@ -0,0 +1,62 @@
+package dokkuApi
+
+import (
+	"io"
+	"log/slog"
+	"testing"
+)
+
+func newOrderedAuthService(order []string) *SSHAuthService {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	return NewSSHAuthServiceWithConfig(logger, &SSHAuthConfig{
+		HomeDir:     "/nonexistent",
+		CheckAgent:  func() bool { return true },
+		MethodOrder: order,
+	})
+}
+
+func TestDetermineAuthMethodHonorsConfiguredOrder(t *testing.T) {
+	keyPath := writeTestKey(t, 0o600)
+
+	cases := []struct {
+		name      string
+		order     []string
+		wantAgent bool
+	}{
+		{name: "configured key first", order: []string{AuthMethodKey, AuthMethodAgent}, wantAgent: false},
+		{name: "agent first", order: []string{AuthMethodAgent, AuthMethodKey}, wantAgent: true},
+		{name: "default order", order: nil, wantAgent: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			method := newOrderedAuthService(tc.order).DetermineAuthMethod(keyPath)
+			if method.UseAgent != tc.wantAgent {
+				t.Fatalf("expected agent=%v, got %q", tc.wantAgent, method.Description)
+			}
+			if !tc.wantAgent && method.KeyPath != keyPath {
+				t.Fatalf("expected the configured key %s, got %q", keyPath, method.KeyPath)
+			}
+		})
+	}
+}
+
+func TestDetermineAuthMethodSkipsUnavailableMethods(t *testing.T) {
+	// Without a configured key, the next method in the order is used
+	method := newOrderedAuthService([]string{AuthMethodKey, AuthMethodDefaultKey, AuthMethodAgent}).DetermineAuthMethod("")
+	if !method.UseAgent || method.Description != "ssh-agent" {
+		t.Fatalf("expected ssh-agent, got %q", method.Description)
+	}
+}
+
+func TestValidateAuthMethodOrder(t *testing.T) {
+	if err := ValidateAuthMethodOrder([]string{AuthMethodAgent, AuthMethodKey}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := ValidateAuthMethodOrder([]string{"password"}); err == nil {
+		t.Fatal("expected an unknown method to be rejected")
+	}
+	if err := ValidateAuthMethodOrder([]string{AuthMethodKey, AuthMethodKey}); err == nil {
+		t.Fatal("expected a duplicate method to be rejected")
+	}
+}
@@ -0,0 +1,67 @@
+package dokkuApi
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeTestKey(t *testing.T, mode os.FileMode) string {
+	t.Helper()
+	keyPath := filepath.Join(t.TempDir(), "id_ed25519")
+	if err := os.WriteFile(keyPath, []byte("fake-key-content"), 0o600); err != nil {
+		t.Fatalf("failed to write key: %v", err)
+	}
+	// Chmod explicitly so the umask does not interfere
+	if err := os.Chmod(keyPath, mode); err != nil {
+		t.Fatalf("failed to chmod key: %v", err)
+	}
+	return keyPath
+}
+
+func newTestAuthService(strict bool) *SSHAuthService {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	return NewSSHAuthServiceWithConfig(logger, &SSHAuthConfig{
+		CheckAgent:           func() bool { return false },
+		StrictKeyPermissions: strict,
+	})
+}
+
+func TestValidateKeyFilePermissionsWorldReadableKey(t *testing.T) {
+	keyPath := writeTestKey(t, 0o644)
+
+	if err := newTestAuthService(false).ValidateKeyFilePermissions(keyPath); err != nil {
+		t.Fatalf("expected only a warning without strict mode, got %v", err)
+	}
+
+	err := newTestAuthService(true).ValidateKeyFilePermissions(keyPath)
+	if !errors.Is(err, ErrInsecureKeyPermissions) {
+		t.Fatalf("expected ErrInsecureKeyPermissions in strict mode, got %v", err)
+	}
+}
+
+func TestValidateKeyFilePermissionsAcceptsPrivateModes(t *testing.T) {
+	for _, mode := range []os.FileMode{0o600, 0o400} {
+		keyPath := writeTestKey(t, mode)
+		if err := newTestAuthService(true).ValidateKeyFilePermissions(keyPath); err != nil {
+			t.Fatalf("mode %04o: unexpected error %v", mode, err)
+		}
+	}
+}
+
+func TestDetermineAuthMethodSkipsInsecureKeyInStrictMode(t *testing.T) {
+	keyPath := writeTestKey(t, 0o644)
+
+	method := newTestAuthService(false).DetermineAuthMethod(keyPath)
+	if method.KeyPath != keyPath {
+		t.Fatalf("expected the key to be used without strict mode, got %+v", method)
+	}
+
+	method = newTestAuthService(true).DetermineAuthMethod(keyPath)
+	if method.KeyPath == keyPath {
+		t.Fatalf("expected the insecure key to be skipped in strict mode")
+	}
+}
@@ -0,0 +1,107 @@
+package dokkuApi
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// DefaultCommandPath is the PATH the ssh command runs with unless configured otherwise
+const DefaultCommandPath = "/usr/bin:/bin"
+
+// ErrUnsafeCommandEnvironment is returned for environment entries that could alter how commands are executed
+var ErrUnsafeCommandEnvironment = errors.New("unsafe command environment")
+
+var (
+	// Pattern to validate environment variable names
+	envNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	// Pattern to validate a single PATH directory
+	pathEntryPattern = regexp.MustCompile(`^/[A-Za-z0-9._/+-]*$`)
+)
+
+// reservedEnvNames are set by the client itself and cannot be overridden
+var reservedEnvNames = map[string]bool{
+	"PATH":          true,
+	"DOKKU_HOST":    true,
+	"DOKKU_PORT":    true,
+	"SSH_AUTH_SOCK": true,
+}
+
+// dangerousEnvNames change how the shell, the dynamic linker or ssh behave
+var dangerousEnvNames = map[string]bool{
+	"BASH_ENV":        true,
+	"BASHOPTS":        true,
+	"ENV":             true,
+	"GIT_SSH":         true,
+	"GIT_SSH_COMMAND": true,
+	"IFS":             true,
+	"PROMPT_COMMAND":  true,
+	"PS4":             true,
+	"SHELLOPTS":       true,
+}
+
+// dangerousEnvPrefixes cover the dynamic linker variables and ssh's own settings (SSH_ASKPASS...)
+var dangerousEnvPrefixes = []string{"LD_", "DYLD_", "SSH_"}
+
+// ValidateCommandPath checks that every PATH entry is an absolute directory without shell metacharacters
+func ValidateCommandPath(path string) error {
+	if path == "" {
+		return nil
+	}
+	for _, entry := range strings.Split(path, ":") {
+		if !pathEntryPattern.MatchString(entry) {
+			return fmt.Errorf("%w: PATH entry %q must be an absolute directory", ErrUnsafeCommandEnvironment, entry)
+		}
+		for _, segment := range strings.Split(entry, "/") {
+			if segment == ".." {
+				return fmt.Errorf("%w: PATH entry %q cannot contain '..'", ErrUnsafeCommandEnvironment, entry)
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateCommandEnv checks that extra variables are well formed and cannot hijack command execution
+func ValidateCommandEnv(vars map[string]string) error {
+	for name, value := range vars {
+		if !envNamePattern.MatchString(name) {
+			return fmt.Errorf("%w: invalid variable name %q", ErrUnsafeCommandEnvironment, name)
+		}
+		upper := strings.ToUpper(name)
+		if reservedEnvNames[upper] {
+			return fmt.Errorf("%w: %s is set by the server and cannot be overridden", ErrUnsafeCommandEnvironment, name)
+		}
+		if dangerousEnvNames[upper] {
+			return fmt.Errorf("%w: %s is not allowed", ErrUnsafeCommandEnvironment, name)
+		}
+		for _, prefix := range dangerousEnvPrefixes {
+			if strings.HasPrefix(upper, prefix) {
+				return fmt.Errorf("%w: %s* variables are not allowed", ErrUnsafeCommandEnvironment, prefix)
+			}
+		}
+		if strings.ContainsAny(value, "\x00\r\n") {
+			return fmt.Errorf("%w: value of %s cannot contain control characters", ErrUnsafeCommandEnvironment, name)
+		}
+	}
+	return nil
+}
+
+// buildCommandEnv returns the PATH and extra variables as sorted KEY=value entries
+func buildCommandEnv(path string, vars map[string]string) []string {
+	if path == "" {
+		path = DefaultCommandPath
+	}
+
+	env := []string{"PATH=" + path}
+	names := make([]string, 0, len(vars))
+	for name := range vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		env = append(env, name+"="+vars[name])
+	}
+	return env
+}
@@ -0,0 +1,96 @@
+package dokkuApi
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newEnvTestManager(t *testing.T) *SSHConnectionManager {
+	t.Helper()
+	config := MustNewSSHConfig("dokku.example.com", 22, "dokku", "", 30*time.Second)
+	return NewSSHConnectionManager(config, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestPrepareSSHCommandUsesDefaultPath(t *testing.T) {
+	_, env, err := newEnvTestManager(t).PrepareSSHCommand("apps:list")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Contains(env, "PATH="+DefaultCommandPath) {
+		t.Fatalf("expected the default PATH, got %v", env)
+	}
+}
+
+func TestPrepareSSHCommandIncludesConfiguredEnvironment(t *testing.T) {
+	manager := newEnvTestManager(t)
+	err := manager.SetCommandEnvironment("/usr/local/bin:/usr/bin:/bin", map[string]string{
+		"LANG":        "C.UTF-8",
+		"HTTPS_PROXY": "http://proxy.internal:3128",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	sshArgs, env, err := manager.PrepareSSHCommand("apps:list")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	args := strings.Join(sshArgs, " ")
+	if !strings.Contains(args, "-o SendEnv=HTTPS_PROXY -o SendEnv=LANG dokku@dokku.example.com") || strings.Contains(args, "SendEnv=PATH") {
+		t.Errorf("expected the extra variables to be forwarded with SendEnv, got %v", sshArgs)
+	}
+	for _, entry := range []string{
+		"PATH=/usr/local/bin:/usr/bin:/bin",
+		"LANG=C.UTF-8",
+		"HTTPS_PROXY=http://proxy.internal:3128",
+		"DOKKU_HOST=dokku.example.com",
+	} {
+		if !slices.Contains(env, entry) {
+			t.Errorf("expected %q in %v", entry, env)
+		}
+	}
+	if slices.Contains(env, "PATH="+DefaultCommandPath) {
+		t.Errorf("expected the configured PATH to replace the default one, got %v", env)
+	}
+}
+
+func TestSetCommandEnvironmentRejectsDangerousEntries(t *testing.T) {
+	cases := []struct {
+		name string
+		path string
+		vars map[string]string
+	}{
+		{name: "relative PATH entry", path: "bin:/usr/bin"},
+		{name: "empty PATH entry", path: "/usr/bin::/bin"},
+		{name: "PATH traversal", path: "/usr/bin/../../tmp"},
+		{name: "PATH shell metacharacters", path: "/usr/bin;rm -rf /"},
+		{name: "dynamic linker", vars: map[string]string{"LD_PRELOAD": "/tmp/evil.so"}},
+		{name: "macOS dynamic linker", vars: map[string]string{"DYLD_INSERT_LIBRARIES": "/tmp/evil.dylib"}},
+		{name: "shell startup file", vars: map[string]string{"BASH_ENV": "/tmp/evil.sh"}},
+		{name: "ssh askpass", vars: map[string]string{"SSH_ASKPASS": "/tmp/evil"}},
+		{name: "PATH override", vars: map[string]string{"PATH": "/tmp"}},
+		{name: "reserved variable", vars: map[string]string{"DOKKU_HOST": "attacker.example.com"}},
+		{name: "invalid name", vars: map[string]string{"FOO=BAR": "x"}},
+		{name: "newline in value", vars: map[string]string{"LANG": "C\nLD_PRELOAD=/tmp/evil.so"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			manager := newEnvTestManager(t)
+			err := manager.SetCommandEnvironment(tc.path, tc.vars)
+			if !errors.Is(err, ErrUnsafeCommandEnvironment) {
+				t.Fatalf("expected ErrUnsafeCommandEnvironment, got %v", err)
+			}
+
+			_, env, _ := manager.PrepareSSHCommand("apps:list")
+			if !slices.Contains(env, "PATH="+DefaultCommandPath) {
+				t.Fatalf("expected the secure default to be kept, got %v", env)
+			}
+		})
+	}
+}
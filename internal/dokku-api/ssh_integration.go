@@ -3,7 +3,9 @@ package dokkuApi
 import (
 	"fmt"
 	"log/slog"
+	"maps"
 	"os/exec"
+	"slices"
 	"time"
 
 	"github.com/dokku-mcp/dokku-mcp/pkg/config"
@@ -14,14 +16,24 @@ type SSHConnectionManager struct {
 	config      *SSHConfig
 	authService *SSHAuthService
 	logger      *slog.Logger
+	// PATH and extra variables the ssh command runs with
+	commandEnv []string
+	// sendEnv names the extra variables ssh forwards to the Dokku host
+	sendEnv []string
 }
 
 // NewSSHConnectionManager creates a new SSH connection manager
 func NewSSHConnectionManager(config *SSHConfig, logger *slog.Logger) *SSHConnectionManager {
+	return NewSSHConnectionManagerWithAuth(config, NewSSHAuthService(logger), logger)
+}
+
+// NewSSHConnectionManagerWithAuth creates a new SSH connection manager with a preconfigured authentication service
+func NewSSHConnectionManagerWithAuth(config *SSHConfig, authService *SSHAuthService, logger *slog.Logger) *SSHConnectionManager {
 	return &SSHConnectionManager{
 		config:      config,
-		authService: NewSSHAuthService(logger),
+		authService: authService,
 		logger:      logger,
+		commandEnv:  buildCommandEnv(DefaultCommandPath, nil),
 	}
 }
 
@@ -52,6 +64,22 @@ func (m *SSHConnectionManager) UpdateConfig(newConfig *SSHConfig) {
 	m.config = newConfig
 }
 
+// SetCommandEnvironment replaces the PATH and extra variables commands run with.
+// An empty path keeps DefaultCommandPath; unsafe entries are rejected. The extra
+// variables are forwarded with SendEnv, so they only reach Dokku when the sshd of
+// the host accepts them (AcceptEnv); PATH only applies to the local ssh command.
+func (m *SSHConnectionManager) SetCommandEnvironment(path string, vars map[string]string) error {
+	if err := ValidateCommandPath(path); err != nil {
+		return err
+	}
+	if err := ValidateCommandEnv(vars); err != nil {
+		return err
+	}
+	m.commandEnv = buildCommandEnv(path, vars)
+	m.sendEnv = slices.Sorted(maps.Keys(vars))
+	return nil
+}
+
 // PrepareSSHCommand prepares a complete SSH command with authentication
 func (m *SSHConnectionManager) PrepareSSHCommand(command string) ([]string, []string, error) {
 	// Determine the best authentication method
@@ -64,6 +92,11 @@ func (m *SSHConnectionManager) PrepareSSHCommand(command string) ([]string, []st
 	// Apply authentication method
 	sshArgs = m.authService.PrepareSSHArgs(authMethod, sshArgs)
 
+	// Forward the extra variables, ssh sends none by default
+	for _, name := range m.sendEnv {
+		sshArgs = append(sshArgs, "-o", "SendEnv="+name)
+	}
+
 	// Add destination
 	sshArgs = append(sshArgs, m.config.ConnectionString())
 
@@ -73,11 +106,11 @@ func (m *SSHConnectionManager) PrepareSSHCommand(command string) ([]string, []st
 	}
 
 	// Prepare environment
-	baseEnv := []string{
-		"PATH=/usr/bin:/bin",
+	baseEnv := append([]string{}, m.commandEnv...)
+	baseEnv = append(baseEnv,
 		fmt.Sprintf("DOKKU_HOST=%s", m.config.Host()),
 		fmt.Sprintf("DOKKU_PORT=%d", m.config.Port()),
-	}
+	)
 	env := m.authService.PrepareEnvironment(authMethod, baseEnv)
 
 	m.logger.Debug("Prepared SSH command",
@@ -0,0 +1,63 @@
+package dokkuApi
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// dokkuVersionPattern matches the output of `dokku version` ("dokku version 0.35.12")
+// as well as bare versions, with or without a leading "v"
+var dokkuVersionPattern = regexp.MustCompile(`^(?:dokku version\s+)?v?(\d+)\.(\d+)\.(\d+)$`)
+
+// jsonFormatMinVersion is the first Dokku release whose report subcommands accept --format json
+var jsonFormatMinVersion = DokkuVersion{Major: 0, Minor: 25, Patch: 0}
+
+// jsonFormatCommands are the commands probed for JSON support during discovery
+// that accept --format json from jsonFormatMinVersion onwards
+var jsonFormatCommands = []string{"apps:report", "domains:report"}
+
+// DokkuVersion is a parsed Dokku release version
+type DokkuVersion struct {
+	Major int
+	Minor int
+	Patch int
+}
+
+// ParseDokkuVersion parses a Dokku version such as "0.35.12", "v0.35.12" or "dokku version 0.35.12"
+func ParseDokkuVersion(raw string) (DokkuVersion, error) {
+	matches := dokkuVersionPattern.FindStringSubmatch(strings.TrimSpace(raw))
+	if matches == nil {
+		return DokkuVersion{}, fmt.Errorf("invalid Dokku version %q: expected MAJOR.MINOR.PATCH", raw)
+	}
+
+	parts := make([]int, 3)
+	for i := range parts {
+		n, err := strconv.Atoi(matches[i+1])
+		if err != nil {
+			return DokkuVersion{}, fmt.Errorf("invalid Dokku version %q: %w", raw, err)
+		}
+		parts[i] = n
+	}
+
+	return DokkuVersion{Major: parts[0], Minor: parts[1], Patch: parts[2]}, nil
+}
+
+// Compare returns -1, 0 or 1 when v is older than, equal to or newer than other
+func (v DokkuVersion) Compare(other DokkuVersion) int {
+	for _, diff := range []int{v.Major - other.Major, v.Minor - other.Minor, v.Patch - other.Patch} {
+		if diff < 0 {
+			return -1
+		}
+		if diff > 0 {
+			return 1
+		}
+	}
+	return 0
+}
+
+// String returns the version as MAJOR.MINOR.PATCH
+func (v DokkuVersion) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
@@ -0,0 +1,81 @@
+package dokkuApi
+
+import (
+	"io"
+	"log/slog"
+	"testing"
+)
+
+func TestParseDokkuVersion(t *testing.T) {
+	cases := []struct {
+		raw     string
+		want    string
+		wantErr bool
+	}{
+		{raw: "0.35.12", want: "0.35.12"},
+		{raw: "v0.35.12", want: "0.35.12"},
+		{raw: "dokku version 0.35.12\n", want: "0.35.12"},
+		{raw: "0.35", wantErr: true},
+		{raw: "latest", wantErr: true},
+	}
+
+	for _, tc := range cases {
+		version, err := ParseDokkuVersion(tc.raw)
+		if tc.wantErr {
+			if err == nil {
+				t.Fatalf("ParseDokkuVersion(%q): expected an error", tc.raw)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("ParseDokkuVersion(%q): unexpected error: %v", tc.raw, err)
+		}
+		if version.String() != tc.want {
+			t.Fatalf("ParseDokkuVersion(%q) = %s, want %s", tc.raw, version, tc.want)
+		}
+	}
+}
+
+func TestPinnedVersionSkipsDiscovery(t *testing.T) {
+	discoveries := 0
+	original := startDiscovery
+	startDiscovery = func(c *client) { discoveries++ }
+	defer func() { startDiscovery = original }()
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	cases := []struct {
+		name          string
+		version       string
+		wantDiscovery bool
+		wantVersion   string
+		wantJSON      bool
+	}{
+		{name: "pinned with JSON support", version: "0.35.12", wantVersion: "0.35.12", wantJSON: true},
+		{name: "pinned before JSON support", version: "v0.24.10", wantVersion: "0.24.10", wantJSON: false},
+		{name: "not pinned", version: "", wantDiscovery: true, wantVersion: "unknown"},
+		{name: "invalid pin falls back", version: "latest", wantDiscovery: true, wantVersion: "unknown"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			discoveries = 0
+			config := DefaultClientConfig()
+			config.Cache = nil
+			config.DokkuVersion = tc.version
+
+			c := NewDokkuClient(config, logger)
+
+			if got := discoveries > 0; got != tc.wantDiscovery {
+				t.Fatalf("discovery started = %v, want %v", got, tc.wantDiscovery)
+			}
+			caps := c.GetCapabilities()
+			if caps.Version != tc.wantVersion {
+				t.Fatalf("version = %q, want %q", caps.Version, tc.wantVersion)
+			}
+			if got := caps.SupportsJSON("apps:report", caps.Version); got != tc.wantJSON {
+				t.Fatalf("apps:report JSON support = %v, want %v", got, tc.wantJSON)
+			}
+		})
+	}
+}
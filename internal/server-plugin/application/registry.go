@@ -93,6 +93,63 @@ type DynamicServerPluginRegistry struct {
 	allServerPlugins []domain.ServerPlugin
 	active           map[string]bool
 	mu               sync.RWMutex
+
+	syncMonitor *PluginSyncMonitor
+}
+
+// PluginSyncStatus describes the background synchronization of server plugins with the Dokku plugins
+type PluginSyncStatus struct {
+	// LoopEnabled is false when plugin discovery or its sync interval is disabled
+	LoopEnabled bool          `json:"loop_enabled"`
+	LoopRunning bool          `json:"loop_running"`
+	Interval    time.Duration `json:"interval"`
+	// LastSyncAt is zero until a synchronization has run
+	LastSyncAt time.Time `json:"last_sync_at"`
+	// LastError is why the last synchronization could not read the Dokku plugins, if it failed
+	LastError string `json:"last_error,omitempty"`
+}
+
+// PluginSyncMonitor tracks the plugin synchronization of a DynamicServerPluginRegistry.
+// It is provided on its own so readers such as the readiness probe do not depend on
+// the registry, which depends on every server plugin.
+type PluginSyncMonitor struct {
+	mu     sync.RWMutex
+	status PluginSyncStatus
+}
+
+// NewPluginSyncMonitor creates a monitor with no synchronization recorded
+func NewPluginSyncMonitor() *PluginSyncMonitor {
+	return &PluginSyncMonitor{}
+}
+
+// Status reports the state of the background plugin synchronization
+func (m *PluginSyncMonitor) Status() PluginSyncStatus {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.status
+}
+
+func (m *PluginSyncMonitor) setLoopRunning(running bool, interval time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.status.LoopRunning = running
+	if running {
+		m.status.LoopEnabled = true
+		m.status.Interval = interval
+	}
+}
+
+// recordSync remembers when the Dokku plugins were last read and whether it failed
+func (m *PluginSyncMonitor) recordSync(err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.status.LastSyncAt = time.Now()
+	m.status.LastError = ""
+	if err != nil {
+		m.status.LastError = err.Error()
+	}
 }
 
 type DynamicServerPluginRegistryParams struct {
@@ -102,10 +159,16 @@ type DynamicServerPluginRegistryParams struct {
 	Logger          *slog.Logger
 	SrvConfig       *config.ServerConfig
 	ServerPlugins   []domain.ServerPlugin `group:"server_plugins"`
+	SyncMonitor     *PluginSyncMonitor    `optional:"true"`
 }
 
 // NewDynamicServerPluginRegistry creates a new dynamic server plugin registry
 func NewDynamicServerPluginRegistry(params DynamicServerPluginRegistryParams) *DynamicServerPluginRegistry {
+	syncMonitor := params.SyncMonitor
+	if syncMonitor == nil {
+		syncMonitor = NewPluginSyncMonitor()
+	}
+
 	return &DynamicServerPluginRegistry{
 		pluginRegistry:   params.PluginRegistry,
 		pluginDiscovery:  params.PluginDiscovery,
@@ -113,6 +176,7 @@ func NewDynamicServerPluginRegistry(params DynamicServerPluginRegistryParams) *D
 		srvConfig:        params.SrvConfig,
 		allServerPlugins: params.ServerPlugins,
 		active:           make(map[string]bool),
+		syncMonitor:      syncMonitor,
 	}
 }
 
@@ -142,6 +206,7 @@ func (r *DynamicServerPluginRegistry) RegisterHooks(lc fx.Lifecycle) {
 			if r.srvConfig.PluginDiscovery.Enabled && r.srvConfig.PluginDiscovery.SyncInterval > 0 {
 				r.logger.Info("Starting plugin discovery sync loop",
 					"interval", r.srvConfig.PluginDiscovery.SyncInterval)
+				r.syncMonitor.setLoopRunning(true, r.srvConfig.PluginDiscovery.SyncInterval)
 				go r.runSyncLoop(ctx, r.srvConfig.PluginDiscovery.SyncInterval)
 			} else {
 				r.logger.Info("Plugin discovery sync loop disabled")
@@ -162,6 +227,7 @@ func (r *DynamicServerPluginRegistry) RegisterHooks(lc fx.Lifecycle) {
 func (r *DynamicServerPluginRegistry) runSyncLoop(ctx context.Context, interval time.Duration) {
 	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
+	defer r.syncMonitor.setLoopRunning(false, interval)
 
 	for {
 		select {
@@ -183,6 +249,7 @@ func (r *DynamicServerPluginRegistry) syncServerPlugins(ctx context.Context) err
 
 	// Get list of enabled Dokku plugins (with graceful error handling)
 	enabledDokkuPlugins, err := r.pluginDiscovery.GetEnabledDokkuPlugins(ctx)
+	r.syncMonitor.recordSync(err)
 	if err != nil {
 		r.logger.Error("Failed to get enabled Dokku plugins, proceeding with core plugins only", "error", err)
 		enabledDokkuPlugins = []string{} // Empty list - only core server plugins will be activated
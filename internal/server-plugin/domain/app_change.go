@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// AppChange runs a change on an application and returns the message reported on success
+type AppChange func(ctx context.Context, appName string) (string, error)
+
+// HandleAppChange is the handler of the tools changing one application (maintenance,
+// letsencrypt, storage, checks...). It reads the app_name parameter and runs change.
+// Errors wrapping one of invalidInput are reported as is, since they tell the caller
+// what to fix; other errors are reported as "Failed to <action>: <error>".
+func HandleAppChange(ctx context.Context, req mcp.CallToolRequest, action string, change AppChange, invalidInput ...error) (*mcp.CallToolResult, error) {
+	appName, err := req.RequireString("app_name")
+	if err != nil {
+		return mcp.NewToolResultError("Application name is required"), nil
+	}
+
+	message, err := change(ctx, appName)
+	if err != nil {
+		for _, target := range invalidInput {
+			if errors.Is(err, target) {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+		}
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to %s: %v", action, err)), nil
+	}
+
+	return mcp.NewToolResultText(message), nil
+}
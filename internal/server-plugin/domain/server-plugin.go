@@ -44,6 +44,8 @@ type Resource struct {
 	Description string
 	MIMEType    string
 	Handler     ResourceHandler
+	// Template marks URI as an RFC 6570 URI template (e.g. dokku://apps/list{?offset,limit})
+	Template bool
 }
 
 // Tool represents a plugin tool capability
@@ -52,6 +54,10 @@ type Tool struct {
 	Description string
 	Builder     func() mcp.Tool
 	Handler     ToolHandler
+
+	// MinDokkuVersion is the oldest Dokku release the tool works with (e.g. "0.25.0").
+	// Empty means the tool is available on every version.
+	MinDokkuVersion string
 }
 
 // Prompt represents a plugin prompt capability
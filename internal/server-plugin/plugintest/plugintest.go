@@ -0,0 +1,50 @@
+// Package plugintest holds the helpers shared by the tests of the server plugins that
+// change one application at a time (maintenance, letsencrypt, storage, checks...)
+package plugintest
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Logger returns a logger discarding its output
+func Logger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+// Calls records the arguments a fake repository is called with
+type Calls[T any] []T
+
+// Record appends the arguments of a call
+func (c *Calls[T]) Record(args T) {
+	*c = append(*c, args)
+}
+
+// ExpectRejectedBeforeExecuting fails the test unless err wraps want and the
+// command was never run, i.e. the input was validated before reaching Dokku
+func ExpectRejectedBeforeExecuting[T any](t testing.TB, err, want error, command string, calls Calls[T]) {
+	t.Helper()
+	if !errors.Is(err, want) {
+		t.Fatalf("expected %v, got %v", want, err)
+	}
+	if len(calls) != 0 {
+		t.Fatalf("%s should not run, got %v", command, calls)
+	}
+}
+
+// CallTool runs a tool handler with the given arguments and returns its result and text
+func CallTool(t testing.TB, handler func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error), args map[string]any) (*mcp.CallToolResult, string) {
+	t.Helper()
+	req := mcp.CallToolRequest{}
+	req.Params.Arguments = args
+	result, err := handler(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return result, result.Content[0].(mcp.TextContent).Text
+}
@@ -2,8 +2,12 @@ package usecases
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log/slog"
+	"strings"
+	"sync"
+	"time"
 
 	domain "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/app/domain"
 	"github.com/dokku-mcp/dokku-mcp/internal/shared"
@@ -16,6 +20,9 @@ type ApplicationUseCase struct {
 	deploymentSvc     shared.DeploymentService
 	validationService *domain.ValidationService
 	logger            *slog.Logger
+
+	// healthPollInterval is how often the process report is polled while waiting for the app to become healthy
+	healthPollInterval time.Duration
 }
 
 // NewApplicationUseCase creates a new application use case
@@ -25,10 +32,11 @@ func NewApplicationUseCase(
 	logger *slog.Logger,
 ) *ApplicationUseCase {
 	return &ApplicationUseCase{
-		applicationRepo:   applicationRepo,
-		deploymentSvc:     deploymentSvc,
-		validationService: domain.NewValidationService(),
-		logger:            logger,
+		applicationRepo:    applicationRepo,
+		deploymentSvc:      deploymentSvc,
+		validationService:  domain.NewValidationService(),
+		logger:             logger,
+		healthPollInterval: 2 * time.Second,
 	}
 }
 
@@ -38,7 +46,8 @@ type CreateApplicationCommand struct {
 }
 
 // CreateApplication orchestrates application creation
-func (uc *ApplicationUseCase) CreateApplication(ctx context.Context, cmd CreateApplicationCommand) error {
+// The returned validation result carries the errors and warnings found before creation
+func (uc *ApplicationUseCase) CreateApplication(ctx context.Context, cmd CreateApplicationCommand) (*domain.ValidationResult, error) {
 	uc.logger.Info("Creating application", "app_name", cmd.Name)
 
 	// Use domain validation service
@@ -48,7 +57,7 @@ func (uc *ApplicationUseCase) CreateApplication(ctx context.Context, cmd CreateA
 		for _, validationError := range validationResult.Errors {
 			errorMessages = append(errorMessages, validationError.Message)
 		}
-		return fmt.Errorf("validation failed: %v", errorMessages)
+		return validationResult, fmt.Errorf("validation failed: %v", errorMessages)
 	}
 
 	// Log warnings if any
@@ -64,25 +73,25 @@ func (uc *ApplicationUseCase) CreateApplication(ctx context.Context, cmd CreateA
 	// Create application entity
 	app, err := domain.NewApplication(cmd.Name)
 	if err != nil {
-		return fmt.Errorf("unable to create application: %w", err)
+		return validationResult, fmt.Errorf("unable to create application: %w", err)
 	}
 
 	// Check if application already exists
 	exists, err := uc.applicationRepo.Exists(ctx, app.Name())
 	if err != nil {
-		return fmt.Errorf("failed to check existence: %w", err)
+		return validationResult, fmt.Errorf("failed to check existence: %w", err)
 	}
 	if exists {
-		return domain.ErrApplicationAlreadyExists
+		return validationResult, domain.ErrApplicationAlreadyExists
 	}
 
 	// Save application
 	if err := uc.applicationRepo.Save(ctx, app); err != nil {
-		return fmt.Errorf("failed to save: %w", err)
+		return validationResult, fmt.Errorf("failed to save: %w", err)
 	}
 
 	uc.logger.Info("Application created successfully", "app_name", cmd.Name)
-	return nil
+	return validationResult, nil
 }
 
 // DeployApplicationCommand represents the data for deploying an application
@@ -92,10 +101,12 @@ type DeployApplicationCommand struct {
 	GitRef     string
 	BuildImage string
 	RunImage   string
+	BuildDir   string
 }
 
 // DeployApplication orchestrates application deployment
-func (uc *ApplicationUseCase) DeployApplication(ctx context.Context, cmd DeployApplicationCommand) error {
+// The returned validation result is nil when the deployment could not be validated
+func (uc *ApplicationUseCase) DeployApplication(ctx context.Context, cmd DeployApplicationCommand) (*domain.ValidationResult, error) {
 	uc.logger.Info("Deploying application",
 		"app_name", cmd.Name,
 		"repo_url", cmd.RepoURL,
@@ -104,12 +115,12 @@ func (uc *ApplicationUseCase) DeployApplication(ctx context.Context, cmd DeployA
 	// Get application
 	appName, err := domain.NewApplicationName(cmd.Name)
 	if err != nil {
-		return fmt.Errorf("invalid application name: %w", err)
+		return nil, fmt.Errorf("invalid application name: %w", err)
 	}
 
 	app, err := uc.applicationRepo.GetByName(ctx, appName)
 	if err != nil {
-		return fmt.Errorf("application not found: %w", err)
+		return nil, fmt.Errorf("application not found: %w", err)
 	}
 
 	// Create Git reference for validation
@@ -118,7 +129,7 @@ func (uc *ApplicationUseCase) DeployApplication(ctx context.Context, cmd DeployA
 		var err error
 		gitRef, err = shared.NewGitRef(cmd.GitRef)
 		if err != nil {
-			return fmt.Errorf("invalid Git reference: %w", err)
+			return nil, fmt.Errorf("invalid Git reference: %w", err)
 		}
 	}
 
@@ -129,7 +140,7 @@ func (uc *ApplicationUseCase) DeployApplication(ctx context.Context, cmd DeployA
 		for _, validationError := range validationResult.Errors {
 			errorMessages = append(errorMessages, validationError.Message)
 		}
-		return fmt.Errorf("deployment validation failed: %v", errorMessages)
+		return validationResult, fmt.Errorf("deployment validation failed: %v", errorMessages)
 	}
 
 	// Log warnings if any
@@ -146,44 +157,73 @@ func (uc *ApplicationUseCase) DeployApplication(ctx context.Context, cmd DeployA
 	if cmd.BuildImage != "" {
 		buildImage, err = shared.NewDockerImage(cmd.BuildImage)
 		if err != nil {
-			return fmt.Errorf("invalid build image: %w", err)
+			return validationResult, fmt.Errorf("invalid build image: %w", err)
 		}
 	}
 	if cmd.RunImage != "" {
 		runImage, err = shared.NewDockerImage(cmd.RunImage)
 		if err != nil {
-			return fmt.Errorf("invalid run image: %w", err)
+			return validationResult, fmt.Errorf("invalid run image: %w", err)
 		}
 	}
 
+	// The application is read per request: a deploy started by an earlier request
+	// is only known from the deployment service
+	active, err := uc.deploymentSvc.GetActiveDeployment(ctx, cmd.Name)
+	if err != nil {
+		return validationResult, fmt.Errorf("failed to check for a deployment in progress: %w", err)
+	}
+	if active != nil {
+		return validationResult, fmt.Errorf("%w: %s (deployment %s)", domain.ErrDeploymentInProgress, cmd.Name, active.ID)
+	}
+
+	// Enter the deploying state first: a deploy already in progress is refused
+	if err := app.Deploy(gitRef, &domain.DeploymentOptions{
+		BuildImage: buildImage,
+		RunImage:   runImage,
+	}); err != nil {
+		return validationResult, err
+	}
+
 	// Create deployment options using shared interface
 	deployOptions := shared.DeployOptions{
 		RepoURL:    cmd.RepoURL,
 		GitRef:     gitRef,
 		BuildImage: buildImage,
 		RunImage:   runImage,
+		BuildDir:   cmd.BuildDir,
 	}
 
-	// Perform deployment via shared service interface
+	// Perform deployment via shared service interface; the build goes on after it returns
 	deploymentResult, err := uc.deploymentSvc.Deploy(ctx, cmd.Name, deployOptions)
+	if err == nil {
+		deploymentResult, err = uc.waitForDeployment(ctx, deploymentResult)
+	}
+	if deploymentResult != nil {
+		// Surface post-deployment warnings (e.g. no web process in the formation)
+		for _, warning := range deploymentResult.Warnings {
+			validationResult.Warnings = append(validationResult.Warnings, domain.ValidationWarning{
+				Field:   "formation",
+				Message: warning,
+				Code:    "POST_DEPLOYMENT_CHECK",
+			})
+		}
+	}
 	if err != nil {
-		uc.logger.Error("Deployment service failed", "app_name", cmd.Name, "error", err)
-		// Rollback app state
+		uc.logger.Error("Deployment failed", "app_name", cmd.Name, "error", err)
+		// End the deploying state in error
 		if failErr := app.FailDeployment(err.Error()); failErr != nil {
 			uc.logger.Error("failed to mark deployment as failed", "error", failErr)
 		}
 		if saveErr := uc.applicationRepo.Save(ctx, app); saveErr != nil {
 			uc.logger.Error("failed to save app state after deployment failure", "error", saveErr)
 		}
-		return fmt.Errorf("deployment failed: %w", err)
+		return validationResult, fmt.Errorf("deployment failed: %w", err)
 	}
 
 	// Update domain entity
-	if err := app.Deploy(gitRef, &domain.DeploymentOptions{
-		BuildImage: buildImage,
-		RunImage:   runImage,
-	}); err != nil {
-		return fmt.Errorf("failed to update application state: %w", err)
+	if err := app.CompleteDeployment(); err != nil {
+		return validationResult, fmt.Errorf("failed to update application state: %w", err)
 	}
 
 	// Save changes
@@ -195,7 +235,23 @@ func (uc *ApplicationUseCase) DeployApplication(ctx context.Context, cmd DeployA
 	uc.logger.Info("Deployment completed successfully",
 		"app_name", cmd.Name,
 		"deployment_id", deploymentResult.ID)
-	return nil
+	return validationResult, nil
+}
+
+// waitForDeployment waits for a started deployment to reach its final status and
+// returns an error unless it succeeded
+func (uc *ApplicationUseCase) waitForDeployment(ctx context.Context, started *shared.DeploymentResult) (*shared.DeploymentResult, error) {
+	final, err := uc.deploymentSvc.WaitForCompletion(ctx, started.ID)
+	if err != nil {
+		return started, fmt.Errorf("deployment %s started but its outcome is unknown: %w", started.ID, err)
+	}
+	if final.Status != shared.DeploymentStatusSucceeded {
+		if final.ErrorMsg != "" {
+			return final, fmt.Errorf("deployment %s %s: %s", final.ID, final.Status, final.ErrorMsg)
+		}
+		return final, fmt.Errorf("deployment %s %s", final.ID, final.Status)
+	}
+	return final, nil
 }
 
 // ScaleApplicationCommand represents the data for scaling an application
@@ -206,7 +262,8 @@ type ScaleApplicationCommand struct {
 }
 
 // ScaleApplication orchestrates application scaling
-func (uc *ApplicationUseCase) ScaleApplication(ctx context.Context, cmd ScaleApplicationCommand) error {
+// The returned validation result is nil when the scaling could not be validated
+func (uc *ApplicationUseCase) ScaleApplication(ctx context.Context, cmd ScaleApplicationCommand) (*domain.ValidationResult, error) {
 	uc.logger.Info("Scaling application",
 		"app_name", cmd.Name,
 		"process_type", cmd.ProcessType,
@@ -215,18 +272,18 @@ func (uc *ApplicationUseCase) ScaleApplication(ctx context.Context, cmd ScaleApp
 	// Get application
 	appName, err := domain.NewApplicationName(cmd.Name)
 	if err != nil {
-		return fmt.Errorf("invalid application name: %w", err)
+		return nil, fmt.Errorf("invalid application name: %w", err)
 	}
 
 	app, err := uc.applicationRepo.GetByName(ctx, appName)
 	if err != nil {
-		return fmt.Errorf("application not found: %w", err)
+		return nil, fmt.Errorf("application not found: %w", err)
 	}
 
 	// Create process type
 	processType, err := process.NewProcessType(cmd.ProcessType)
 	if err != nil {
-		return fmt.Errorf("invalid process type: %w", err)
+		return nil, fmt.Errorf("invalid process type: %w", err)
 	}
 
 	// Use domain validation service for scaling
@@ -236,7 +293,7 @@ func (uc *ApplicationUseCase) ScaleApplication(ctx context.Context, cmd ScaleApp
 		for _, validationError := range validationResult.Errors {
 			errorMessages = append(errorMessages, validationError.Message)
 		}
-		return fmt.Errorf("scaling validation failed: %v", errorMessages)
+		return validationResult, fmt.Errorf("scaling validation failed: %v", errorMessages)
 	}
 
 	// Log warnings if any
@@ -251,33 +308,175 @@ func (uc *ApplicationUseCase) ScaleApplication(ctx context.Context, cmd ScaleApp
 
 	// Scale application via domain entity
 	if err := app.Scale(processType, cmd.Scale); err != nil {
-		return fmt.Errorf("scaling failed: %w", err)
+		return validationResult, fmt.Errorf("scaling failed: %w", err)
 	}
 
 	// Save changes
 	if err := uc.applicationRepo.Save(ctx, app); err != nil {
 		uc.logger.Warn("Failed to save after scaling",
 			"error", err)
+		return validationResult, fmt.Errorf("failed to scale application: %w", err)
 	}
 
 	uc.logger.Info("Scaling completed successfully",
 		"app_name", cmd.Name,
 		"process_type", cmd.ProcessType,
 		"scale", cmd.Scale)
-	return nil
+	return validationResult, nil
+}
+
+// ScaleApplicationBulkCommand represents the scales of several process types of an application
+type ScaleApplicationBulkCommand struct {
+	Name   string
+	Scales map[string]int
+}
+
+// ScaleApplicationBulk scales several process types with a single ps:scale and
+// returns the applied scales. Every process type and count is validated before
+// anything is scaled; the validation result merges those of each process type.
+func (uc *ApplicationUseCase) ScaleApplicationBulk(ctx context.Context, cmd ScaleApplicationBulkCommand) ([]domain.FormationChange, *domain.ValidationResult, error) {
+	uc.logger.Info("Scaling application process types",
+		"app_name", cmd.Name,
+		"scales", cmd.Scales)
+
+	if len(cmd.Scales) == 0 {
+		return nil, nil, fmt.Errorf("at least one process type is required")
+	}
+
+	appName, err := domain.NewApplicationName(cmd.Name)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid application name: %w", err)
+	}
+
+	app, err := uc.applicationRepo.GetByName(ctx, appName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("application not found: %w", err)
+	}
+
+	validation := &domain.ValidationResult{
+		IsValid:  true,
+		Errors:   make([]domain.ValidationError, 0),
+		Warnings: make([]domain.ValidationWarning, 0),
+	}
+	scales := make(map[process.ProcessType]int, len(cmd.Scales))
+	for name, scale := range cmd.Scales {
+		processType, err := process.NewProcessType(name)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid process type: %w", err)
+		}
+
+		result := uc.validationService.ValidateScale(ctx, app, processType, scale)
+		validation.IsValid = validation.IsValid && result.IsValid
+		validation.Errors = append(validation.Errors, result.Errors...)
+		validation.Warnings = append(validation.Warnings, result.Warnings...)
+		if !result.IsValid {
+			var errorMessages []string
+			for _, validationError := range result.Errors {
+				errorMessages = append(errorMessages, validationError.Message)
+			}
+			return nil, validation, fmt.Errorf("scaling validation failed for %s: %v", name, errorMessages)
+		}
+		scales[processType] = scale
+	}
+
+	changes, err := app.ScaleFormation(scales)
+	if err != nil {
+		return nil, validation, fmt.Errorf("scaling failed: %w", err)
+	}
+
+	if err := uc.applicationRepo.Save(ctx, app); err != nil {
+		return nil, validation, fmt.Errorf("failed to scale application: %w", err)
+	}
+
+	uc.logger.Info("Process types scaled successfully",
+		"app_name", cmd.Name,
+		"nb_process_types", len(changes))
+	return changes, validation, nil
+}
+
+// ReconcileFormationCommand represents a desired formation (process type to scale)
+type ReconcileFormationCommand struct {
+	Name      string
+	Formation map[string]int
+}
+
+// ReconcileFormation scales the process types whose scale differs from the
+// desired formation and returns what changed. It is a no-op when the
+// formation already matches.
+func (uc *ApplicationUseCase) ReconcileFormation(ctx context.Context, cmd ReconcileFormationCommand) ([]domain.FormationChange, error) {
+	uc.logger.Info("Reconciling application formation",
+		"app_name", cmd.Name,
+		"formation", cmd.Formation)
+
+	appName, err := domain.NewApplicationName(cmd.Name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid application name: %w", err)
+	}
+
+	app, err := uc.applicationRepo.GetByName(ctx, appName)
+	if err != nil {
+		return nil, fmt.Errorf("application not found: %w", err)
+	}
+
+	desired := make(map[process.ProcessType]int, len(cmd.Formation))
+	for name, scale := range cmd.Formation {
+		processType, err := process.NewProcessType(name)
+		if err != nil {
+			return nil, fmt.Errorf("invalid process type: %w", err)
+		}
+
+		validationResult := uc.validationService.ValidateScale(ctx, app, processType, scale)
+		if !validationResult.IsValid {
+			var errorMessages []string
+			for _, validationError := range validationResult.Errors {
+				errorMessages = append(errorMessages, validationError.Message)
+			}
+			return nil, fmt.Errorf("scaling validation failed for %s: %v", name, errorMessages)
+		}
+		desired[processType] = scale
+	}
+
+	changes, err := app.ReconcileFormation(desired)
+	if err != nil {
+		return nil, fmt.Errorf("reconciliation failed: %w", err)
+	}
+	if len(changes) == 0 {
+		uc.logger.Info("Formation already matches, nothing to change",
+			"app_name", cmd.Name)
+		return changes, nil
+	}
+
+	if err := uc.applicationRepo.Save(ctx, app); err != nil {
+		return nil, fmt.Errorf("failed to reconcile formation: %w", err)
+	}
+
+	uc.logger.Info("Formation reconciled successfully",
+		"app_name", cmd.Name,
+		"nb_changes", len(changes))
+	return changes, nil
 }
 
 // SetConfigCommand represents the data for configuring an application
 type SetConfigCommand struct {
-	Name   string
-	Config map[string]string
+	Name      string
+	Config    map[string]string
+	NoRestart bool
+	// RollbackOnFailure restores the previous values if the app is not healthy within HealthTimeout after the restart
+	RollbackOnFailure bool
+	HealthTimeout     time.Duration
 }
 
 // SetApplicationConfig orchestrates application configuration
 func (uc *ApplicationUseCase) SetApplicationConfig(ctx context.Context, cmd SetConfigCommand) error {
 	uc.logger.Info("Configuring application",
 		"app_name", cmd.Name,
-		"nb_vars", len(cmd.Config))
+		"nb_vars", len(cmd.Config),
+		"no_restart", cmd.NoRestart,
+		"rollback_on_failure", cmd.RollbackOnFailure)
+
+	if cmd.RollbackOnFailure && cmd.NoRestart {
+		return fmt.Errorf("rollback on failure needs the app to restart, it cannot be combined with no restart")
+	}
 
 	// Get application
 	appName, err := domain.NewApplicationName(cmd.Name)
@@ -290,11 +489,25 @@ func (uc *ApplicationUseCase) SetApplicationConfig(ctx context.Context, cmd SetC
 		return fmt.Errorf("application not found: %w", err)
 	}
 
-	// Apply configuration
-	for key, value := range cmd.Config {
-		if err := app.SetEnvironmentVariable(key, value); err != nil {
-			return fmt.Errorf("unable to set variable %s: %w", key, err)
+	// The snapshot is read from Dokku rather than from the loaded app, whose config
+	// is empty when config:show failed: restoring from it would unset existing variables
+	var previous map[string]string
+	var added []string
+	if cmd.RollbackOnFailure {
+		current, err := uc.applicationRepo.GetConfig(ctx, app.Name())
+		if err != nil {
+			return fmt.Errorf("cannot read the current configuration to roll back to, nothing was changed: %w", err)
 		}
+		keys := make([]string, 0, len(cmd.Config))
+		for key := range cmd.Config {
+			keys = append(keys, key)
+		}
+		previous, added = domain.EnvironmentSnapshot(current, keys)
+	}
+
+	// Apply configuration
+	if err := app.ConfigureEnvironment(cmd.Config, cmd.NoRestart); err != nil {
+		return err
 	}
 
 	// Save changes
@@ -302,11 +515,668 @@ func (uc *ApplicationUseCase) SetApplicationConfig(ctx context.Context, cmd SetC
 		return fmt.Errorf("failed to save after configuration: %w", err)
 	}
 
+	if cmd.RollbackOnFailure {
+		if healthErr := uc.waitForHealthy(ctx, app.Name(), cmd.HealthTimeout); healthErr != nil {
+			uc.logger.Warn("Application unhealthy after configuration, restoring previous values",
+				"app_name", cmd.Name,
+				"error", healthErr)
+
+			if err := app.RestoreEnvironment(previous, added); err != nil {
+				return fmt.Errorf("%w: %v; restoring the previous configuration failed: %v", domain.ErrHealthCheckFailed, healthErr, err)
+			}
+			if err := uc.applicationRepo.Save(ctx, app); err != nil {
+				return fmt.Errorf("%w: %v; restoring the previous configuration failed: %v", domain.ErrHealthCheckFailed, healthErr, err)
+			}
+			return fmt.Errorf("%w: %v; previous configuration restored", domain.ErrHealthCheckFailed, healthErr)
+		}
+	}
+
 	uc.logger.Info("Configuration applied successfully",
 		"app_name", cmd.Name)
 	return nil
 }
 
+// UnsetConfigCommand represents the data for removing environment variables from an application
+type UnsetConfigCommand struct {
+	Name      string
+	Keys      []string
+	NoRestart bool
+}
+
+// UnsetApplicationConfig removes environment variables from an application
+func (uc *ApplicationUseCase) UnsetApplicationConfig(ctx context.Context, cmd UnsetConfigCommand) error {
+	uc.logger.Info("Removing application configuration",
+		"app_name", cmd.Name,
+		"keys", cmd.Keys,
+		"no_restart", cmd.NoRestart)
+
+	keys := make([]string, 0, len(cmd.Keys))
+	for _, key := range cmd.Keys {
+		if key = strings.TrimSpace(key); key != "" {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return domain.ErrNoConfigKeys
+	}
+
+	app, err := uc.GetApplicationByName(ctx, cmd.Name)
+	if err != nil {
+		return err
+	}
+
+	if err := app.UnsetEnvironment(keys, cmd.NoRestart); err != nil {
+		return err
+	}
+
+	if err := uc.applicationRepo.Save(ctx, app); err != nil {
+		return fmt.Errorf("failed to save after removing configuration: %w", err)
+	}
+
+	uc.logger.Info("Configuration removed successfully",
+		"app_name", cmd.Name,
+		"nb_keys", len(keys))
+	return nil
+}
+
+// RotateSecretCommand represents the data for replacing a secret with a generated value
+type RotateSecretCommand struct {
+	Name string
+	Key  string
+}
+
+// RotateSecret stores a new random value under the key without restarting, then
+// restarts the app once so the new value is picked up. The value is never logged
+// nor returned.
+func (uc *ApplicationUseCase) RotateSecret(ctx context.Context, cmd RotateSecretCommand) error {
+	uc.logger.Info("Rotating application secret",
+		"app_name", cmd.Name,
+		"key", cmd.Key)
+
+	appName, err := domain.NewApplicationName(cmd.Name)
+	if err != nil {
+		return fmt.Errorf("invalid application name: %w", err)
+	}
+
+	app, err := uc.applicationRepo.GetByName(ctx, appName)
+	if err != nil {
+		return fmt.Errorf("application not found: %w", err)
+	}
+
+	value, err := domain.GenerateSecretValue()
+	if err != nil {
+		return err
+	}
+
+	if err := app.ConfigureEnvironment(map[string]string{cmd.Key: value}, true); err != nil {
+		return err
+	}
+	app.Restart()
+
+	if err := uc.applicationRepo.Save(ctx, app); err != nil {
+		return fmt.Errorf("failed to save after secret rotation: %w", err)
+	}
+
+	uc.logger.Info("Secret rotated successfully",
+		"app_name", cmd.Name,
+		"key", cmd.Key)
+	return nil
+}
+
+// waitForHealthy polls the process report until every process is running or the timeout expires
+func (uc *ApplicationUseCase) waitForHealthy(ctx context.Context, name *domain.ApplicationName, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		report, err := uc.applicationRepo.GetProcessReport(ctx, name)
+		if err == nil && report.IsHealthy() {
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			if err != nil {
+				return fmt.Errorf("no healthy process report after %s: %w", timeout, err)
+			}
+			return fmt.Errorf("processes not all running after %s", timeout)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(uc.healthPollInterval):
+		}
+	}
+}
+
+type SetForceHTTPSCommand struct {
+	Name    string
+	Enabled bool
+}
+
+// SetForceHTTPS enables or disables HTTPS enforcement at the proxy and returns the resulting HTTPS status
+func (uc *ApplicationUseCase) SetForceHTTPS(ctx context.Context, cmd SetForceHTTPSCommand) (*domain.HTTPSStatus, error) {
+	uc.logger.Info("Setting force-https",
+		"app_name", cmd.Name,
+		"enabled", cmd.Enabled)
+
+	app, err := uc.GetApplicationByName(ctx, cmd.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := uc.applicationRepo.GetHTTPSStatus(ctx, app.Name())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read HTTPS status: %w", err)
+	}
+
+	if !status.SupportsForceHTTPS() {
+		return status, fmt.Errorf("%w: force-https requires the nginx proxy, app uses %q", domain.ErrProxyNotSupported, status.ProxyType)
+	}
+
+	app.SetForceHTTPS(cmd.Enabled)
+
+	if err := uc.applicationRepo.Save(ctx, app); err != nil {
+		return status, fmt.Errorf("failed to save HTTPS enforcement: %w", err)
+	}
+
+	status.ForceHTTPS = cmd.Enabled
+	return status, nil
+}
+
+// GetNginxLogs reads the end of an application's nginx access and error logs.
+// Only apps served by the nginx proxy have them.
+func (uc *ApplicationUseCase) GetNginxLogs(ctx context.Context, appName string, lines int) (*domain.NginxLogs, error) {
+	app, err := uc.GetApplicationByName(ctx, appName)
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := uc.applicationRepo.GetHTTPSStatus(ctx, app.Name())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read proxy type: %w", err)
+	}
+	if !status.UsesNginx() {
+		return nil, fmt.Errorf("%w: nginx logs require the nginx proxy, app uses %q", domain.ErrProxyNotSupported, status.ProxyType)
+	}
+
+	return uc.applicationRepo.GetNginxLogs(ctx, app.Name(), lines)
+}
+
+type SetNginxPropertyCommand struct {
+	Name     string
+	Property string
+	Value    string
+}
+
+// SetNginxProperty validates and applies an app-level nginx setting
+func (uc *ApplicationUseCase) SetNginxProperty(ctx context.Context, cmd SetNginxPropertyCommand) error {
+	uc.logger.Info("Setting nginx property",
+		"app_name", cmd.Name,
+		"property", cmd.Property)
+
+	app, err := uc.GetApplicationByName(ctx, cmd.Name)
+	if err != nil {
+		return err
+	}
+
+	if err := app.SetNginxProperty(domain.NginxProperty(cmd.Property), cmd.Value); err != nil {
+		return err
+	}
+
+	if err := uc.applicationRepo.Save(ctx, app); err != nil {
+		return fmt.Errorf("failed to save nginx property: %w", err)
+	}
+
+	return nil
+}
+
+// GetNginxConfig retrieves the supported app-level nginx settings
+func (uc *ApplicationUseCase) GetNginxConfig(ctx context.Context, appName string) (map[domain.NginxProperty]string, error) {
+	app, err := uc.GetApplicationByName(ctx, appName)
+	if err != nil {
+		return nil, err
+	}
+	return uc.applicationRepo.GetNginxConfig(ctx, app.Name())
+}
+
+type SetChecksSettingsCommand struct {
+	Name string
+	// WaitToRetire and Timeout are seconds ("60") or durations ("90s"); empty leaves the setting unchanged
+	WaitToRetire string
+	Timeout      string
+}
+
+// SetChecksSettings validates and applies the zero-downtime checks durations of an application
+func (uc *ApplicationUseCase) SetChecksSettings(ctx context.Context, cmd SetChecksSettingsCommand) error {
+	uc.logger.Info("Setting checks settings",
+		"app_name", cmd.Name,
+		"wait_to_retire", cmd.WaitToRetire,
+		"timeout", cmd.Timeout)
+
+	if cmd.WaitToRetire == "" && cmd.Timeout == "" {
+		return fmt.Errorf("%w: set wait_to_retire, timeout or both", domain.ErrInvalidChecksDuration)
+	}
+
+	app, err := uc.GetApplicationByName(ctx, cmd.Name)
+	if err != nil {
+		return err
+	}
+
+	if cmd.WaitToRetire != "" {
+		seconds, err := domain.ParseChecksDuration(cmd.WaitToRetire)
+		if err != nil {
+			return fmt.Errorf("wait_to_retire: %w", err)
+		}
+		if err := app.SetChecksWaitToRetire(seconds); err != nil {
+			return err
+		}
+	}
+
+	if cmd.Timeout != "" {
+		seconds, err := domain.ParseChecksDuration(cmd.Timeout)
+		if err != nil {
+			return fmt.Errorf("timeout: %w", err)
+		}
+		if err := app.SetChecksTimeout(seconds); err != nil {
+			return err
+		}
+	}
+
+	if err := uc.applicationRepo.Save(ctx, app); err != nil {
+		return fmt.Errorf("failed to save checks settings: %w", err)
+	}
+
+	return nil
+}
+
+// GetChecksSettings retrieves the zero-downtime checks settings of an application
+func (uc *ApplicationUseCase) GetChecksSettings(ctx context.Context, appName string) (*domain.ChecksSettings, error) {
+	app, err := uc.GetApplicationByName(ctx, appName)
+	if err != nil {
+		return nil, err
+	}
+	return uc.applicationRepo.GetChecksSettings(ctx, app.Name())
+}
+
+// SetBuilder validates and selects the builder of an application's next builds
+func (uc *ApplicationUseCase) SetBuilder(ctx context.Context, appName string, builder string) (domain.Builder, error) {
+	uc.logger.Info("Setting builder",
+		"app_name", appName,
+		"builder", builder)
+
+	selected, err := domain.ParseBuilder(builder)
+	if err != nil {
+		return "", err
+	}
+
+	app, err := uc.GetApplicationByName(ctx, appName)
+	if err != nil {
+		return "", err
+	}
+
+	if err := app.SetBuilder(selected); err != nil {
+		return "", err
+	}
+
+	if err := uc.applicationRepo.Save(ctx, app); err != nil {
+		return "", fmt.Errorf("failed to save builder: %w", err)
+	}
+
+	return selected, nil
+}
+
+// GetBuilderSettings retrieves the builder settings of an application
+func (uc *ApplicationUseCase) GetBuilderSettings(ctx context.Context, appName string) (*domain.BuilderSettings, error) {
+	app, err := uc.GetApplicationByName(ctx, appName)
+	if err != nil {
+		return nil, err
+	}
+	return uc.applicationRepo.GetBuilderSettings(ctx, app.Name())
+}
+
+// GetApplicationConfig retrieves the environment variables of an application, unmasked
+func (uc *ApplicationUseCase) GetApplicationConfig(ctx context.Context, appName string) (map[string]string, error) {
+	app, err := uc.GetApplicationByName(ctx, appName)
+	if err != nil {
+		return nil, err
+	}
+	return uc.applicationRepo.GetConfig(ctx, app.Name())
+}
+
+// DomainCommand represents the data for adding a domain to or removing it from an application
+type DomainCommand struct {
+	Name   string
+	Domain string
+}
+
+// AddApplicationDomain adds a domain to an application after validating its format
+func (uc *ApplicationUseCase) AddApplicationDomain(ctx context.Context, cmd DomainCommand) error {
+	uc.logger.Info("Adding application domain",
+		"app_name", cmd.Name,
+		"domain", cmd.Domain)
+
+	if _, err := shared.NewDomainName(cmd.Domain); err != nil {
+		return fmt.Errorf("%w: %w", domain.ErrInvalidDomain, err)
+	}
+
+	app, err := uc.GetApplicationByName(ctx, cmd.Name)
+	if err != nil {
+		return err
+	}
+
+	if err := app.AddDomain(cmd.Domain); err != nil {
+		return err
+	}
+
+	if err := uc.applicationRepo.Save(ctx, app); err != nil {
+		return fmt.Errorf("failed to save after adding domain: %w", err)
+	}
+	return nil
+}
+
+// RemoveApplicationDomain removes a domain from an application after validating its format
+func (uc *ApplicationUseCase) RemoveApplicationDomain(ctx context.Context, cmd DomainCommand) error {
+	uc.logger.Info("Removing application domain",
+		"app_name", cmd.Name,
+		"domain", cmd.Domain)
+
+	if _, err := shared.NewDomainName(cmd.Domain); err != nil {
+		return fmt.Errorf("%w: %w", domain.ErrInvalidDomain, err)
+	}
+
+	app, err := uc.GetApplicationByName(ctx, cmd.Name)
+	if err != nil {
+		return err
+	}
+
+	if err := app.RemoveDomain(cmd.Domain); err != nil {
+		return err
+	}
+
+	if err := uc.applicationRepo.Save(ctx, app); err != nil {
+		return fmt.Errorf("failed to save after removing domain: %w", err)
+	}
+	return nil
+}
+
+// GetApplicationDomains retrieves the app and global domains of an application
+func (uc *ApplicationUseCase) GetApplicationDomains(ctx context.Context, appName string) (*domain.DomainsReport, error) {
+	app, err := uc.GetApplicationByName(ctx, appName)
+	if err != nil {
+		return nil, err
+	}
+	return uc.applicationRepo.GetDomainsReport(ctx, app.Name())
+}
+
+// GetApplicationURLs composes the public URLs of an application from its routed
+// domains, using https when the app has a certificate
+func (uc *ApplicationUseCase) GetApplicationURLs(ctx context.Context, appName string) (*domain.AppURLs, *domain.HTTPSStatus, error) {
+	app, err := uc.GetApplicationByName(ctx, appName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	report, err := uc.applicationRepo.GetDomainsReport(ctx, app.Name())
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read domains: %w", err)
+	}
+
+	status, err := uc.applicationRepo.GetHTTPSStatus(ctx, app.Name())
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read certificate status: %w", err)
+	}
+
+	return domain.NewAppURLs(app.Name().Value(), report, status), status, nil
+}
+
+// GetEffectiveConfig retrieves the environment an application runs with, global
+// variables merged with the app's own ones, unmasked
+func (uc *ApplicationUseCase) GetEffectiveConfig(ctx context.Context, appName string) ([]domain.EffectiveConfigEntry, error) {
+	app, err := uc.GetApplicationByName(ctx, appName)
+	if err != nil {
+		return nil, err
+	}
+
+	appConfig, err := uc.applicationRepo.GetConfig(ctx, app.Name())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read application config: %w", err)
+	}
+
+	globalConfig, err := uc.applicationRepo.GetGlobalConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read global config: %w", err)
+	}
+
+	return domain.MergeEffectiveConfig(globalConfig, appConfig), nil
+}
+
+// ValidateDeployManifest checks app.json and Procfile content without deploying anything
+func (uc *ApplicationUseCase) ValidateDeployManifest(ctx context.Context, appJSON string, procfile string) *domain.ValidationResult {
+	return uc.validationService.ValidateDeployManifest(ctx, appJSON, procfile)
+}
+
+// GetHealthchecks reads the healthchecks declared in app.json content and tells
+// which of them the application's checks settings keep Dokku from running
+func (uc *ApplicationUseCase) GetHealthchecks(ctx context.Context, appName string, appJSON string) (*domain.HealthchecksReport, error) {
+	checks, err := domain.ParseHealthchecks(appJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := uc.GetChecksSettings(ctx, appName)
+	if err != nil {
+		return nil, err
+	}
+	return domain.NewHealthchecksReport(settings, checks), nil
+}
+
+// SetHealthchecks returns app.json content with its healthchecks section replaced by
+// the given JSON section; Dokku picks the new checks up on the next deploy
+func (uc *ApplicationUseCase) SetHealthchecks(appJSON string, healthchecks string) (string, error) {
+	checks, err := domain.DecodeHealthchecks(json.RawMessage(healthchecks))
+	if err != nil {
+		return "", err
+	}
+	return domain.SetHealthchecks(appJSON, checks)
+}
+
+// GetProcessReport retrieves the per-process status of an application
+func (uc *ApplicationUseCase) GetProcessReport(ctx context.Context, appName string) (*domain.ProcessReport, error) {
+	app, err := uc.GetApplicationByName(ctx, appName)
+	if err != nil {
+		return nil, err
+	}
+	return uc.applicationRepo.GetProcessReport(ctx, app.Name())
+}
+
+// DetectCrashLoops reports the containers of an application restarting at least threshold
+// times with their last restart within window
+func (uc *ApplicationUseCase) DetectCrashLoops(ctx context.Context, appName string, threshold int, window time.Duration) (*domain.CrashLoopReport, error) {
+	report, err := uc.GetProcessReport(ctx, appName)
+	if err != nil {
+		return nil, err
+	}
+	return domain.DetectCrashLoops(report, threshold, window, time.Now()), nil
+}
+
+// GetGitInfo retrieves the deploy branch, git remote and deployed commit of an application
+func (uc *ApplicationUseCase) GetGitInfo(ctx context.Context, appName string) (*domain.GitInfo, error) {
+	app, err := uc.GetApplicationByName(ctx, appName)
+	if err != nil {
+		return nil, err
+	}
+	return uc.applicationRepo.GetGitInfo(ctx, app.Name())
+}
+
+// GetLogs retrieves the last lines of an application's logs, optionally for a single process type
+// and restricted to a time window
+func (uc *ApplicationUseCase) GetLogs(ctx context.Context, appName, processType string, lines int, window domain.LogTimeWindow) (*domain.RuntimeLogs, error) {
+	app, err := uc.GetApplicationByName(ctx, appName)
+	if err != nil {
+		return nil, err
+	}
+	return uc.applicationRepo.GetLogs(ctx, app.Name(), processType, lines, window)
+}
+
+// maxDiskUsageConcurrency bounds how many applications are inspected at once for system disk usage
+const maxDiskUsageConcurrency = 4
+
+// GetDiskUsage retrieves the disk space used by an application
+func (uc *ApplicationUseCase) GetDiskUsage(ctx context.Context, appName string) (*domain.DiskUsage, error) {
+	app, err := uc.GetApplicationByName(ctx, appName)
+	if err != nil {
+		return nil, err
+	}
+	return uc.applicationRepo.GetDiskUsage(ctx, app.Name())
+}
+
+// GetSystemDiskUsage aggregates the disk usage of all applications.
+// Apps that fail to be measured are reported as unsupported instead of failing the whole report.
+func (uc *ApplicationUseCase) GetSystemDiskUsage(ctx context.Context) (*domain.SystemDiskUsage, error) {
+	apps, err := uc.GetAllApplications(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	usages := make([]*domain.DiskUsage, len(apps))
+	semaphore := make(chan struct{}, maxDiskUsageConcurrency)
+	var wg sync.WaitGroup
+
+	for i, app := range apps {
+		wg.Add(1)
+		go func(i int, app *domain.Application) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			usage, err := uc.applicationRepo.GetDiskUsage(ctx, app.Name())
+			if err != nil {
+				uc.logger.Warn("Failed to measure application disk usage", "app_name", app.Name().Value(), "error", err)
+				usage = domain.NewUnsupportedDiskUsage(app.Name().Value(), "", err.Error())
+			}
+			usages[i] = usage
+		}(i, app)
+	}
+	wg.Wait()
+
+	return domain.NewSystemDiskUsage(usages), nil
+}
+
+// GetImages lists the images built for an application
+func (uc *ApplicationUseCase) GetImages(ctx context.Context, appName string) (*domain.AppImages, error) {
+	app, err := uc.GetApplicationByName(ctx, appName)
+	if err != nil {
+		return nil, err
+	}
+	return uc.applicationRepo.GetImages(ctx, app.Name())
+}
+
+// PruneImages removes every image of an application but the current one and the
+// keepPrevious newest others. A tag that cannot be removed is reported as failed
+// and leaves its image in the kept list, so the other removals still go through.
+// Untagged images cannot be removed through Dokku and are reported as such.
+func (uc *ApplicationUseCase) PruneImages(ctx context.Context, appName string, keepPrevious int) (*domain.ImagePruneResult, error) {
+	if keepPrevious < 0 {
+		return nil, fmt.Errorf("keep count must not be negative, got %d", keepPrevious)
+	}
+
+	app, err := uc.GetApplicationByName(ctx, appName)
+	if err != nil {
+		return nil, err
+	}
+	images, err := uc.applicationRepo.GetImages(ctx, app.Name())
+	if err != nil {
+		return nil, err
+	}
+
+	result := &domain.ImagePruneResult{
+		AppName:      images.AppName,
+		Scheduler:    images.Scheduler,
+		Supported:    images.Supported,
+		Note:         images.Note,
+		KeepPrevious: keepPrevious,
+		Kept:         []domain.AppImage{},
+		Removed:      []domain.AppImage{},
+	}
+	if !images.Supported {
+		return result, nil
+	}
+
+	kept, removed := domain.PlanImagePrune(images.Images, keepPrevious)
+	result.Kept = kept
+	for _, image := range removed {
+		if len(image.Tags) == 0 {
+			result.NotRemovable = append(result.NotRemovable, image)
+			continue
+		}
+		failed := false
+		for _, tag := range image.Tags {
+			if err := uc.applicationRepo.RemoveImageTag(ctx, app.Name(), tag); err != nil {
+				uc.logger.Warn("Failed to remove image tag", "app_name", appName, "tag", tag, "error", err)
+				if result.Failed == nil {
+					result.Failed = make(map[string]string)
+				}
+				result.Failed[tag] = err.Error()
+				failed = true
+			}
+		}
+		if failed {
+			result.Kept = append(result.Kept, image)
+		} else {
+			result.Removed = append(result.Removed, image)
+		}
+	}
+
+	uc.logger.Info("Pruned application images",
+		"app_name", appName,
+		"kept", len(result.Kept),
+		"removed", len(result.Removed),
+		"not_removable", len(result.NotRemovable))
+
+	return result, nil
+}
+
+// GetHTTPSStatus retrieves how an application is served over HTTPS
+func (uc *ApplicationUseCase) GetHTTPSStatus(ctx context.Context, app *domain.Application) (*domain.HTTPSStatus, error) {
+	return uc.applicationRepo.GetHTTPSStatus(ctx, app.Name())
+}
+
+// GetLastDeployStatus retrieves the outcome of the application's most recent deploy
+func (uc *ApplicationUseCase) GetLastDeployStatus(ctx context.Context, app *domain.Application) (shared.DeploymentStatus, error) {
+	if uc.deploymentSvc == nil {
+		return "", fmt.Errorf("deployment service unavailable")
+	}
+	return uc.deploymentSvc.GetLastDeployStatus(ctx, app.Name().Value())
+}
+
+// GetDeploymentHistory retrieves the limit most recent deployments of an application, all of them when limit is 0
+func (uc *ApplicationUseCase) GetDeploymentHistory(ctx context.Context, appName string, limit int) (*domain.DeploymentHistory, error) {
+	app, err := uc.GetApplicationByName(ctx, appName)
+	if err != nil {
+		return nil, err
+	}
+	if uc.deploymentSvc == nil {
+		return nil, fmt.Errorf("deployment service unavailable")
+	}
+
+	summaries, err := uc.deploymentSvc.GetHistory(ctx, app.Name().Value())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deployment history: %w", err)
+	}
+	return domain.NewDeploymentHistory(app.Name().Value(), summaries, limit), nil
+}
+
+// ListApplications retrieves a page of applications and the total number of applications.
+// An offset past the last application returns an empty page.
+func (uc *ApplicationUseCase) ListApplications(ctx context.Context, offset, limit int) ([]*domain.Application, int, error) {
+	if offset < 0 {
+		return nil, 0, fmt.Errorf("offset cannot be negative, got %d", offset)
+	}
+	if limit < 1 {
+		return nil, 0, fmt.Errorf("limit must be at least 1, got %d", limit)
+	}
+	return uc.applicationRepo.List(ctx, offset, limit)
+}
+
 // GetAllApplications retrieves all applications
 func (uc *ApplicationUseCase) GetAllApplications(ctx context.Context) ([]*domain.Application, error) {
 	uc.logger.Debug("Retrieving all applications")
@@ -0,0 +1,435 @@
+package usecases
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	domain "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/app/domain"
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
+	"github.com/dokku-mcp/dokku-mcp/internal/shared/process"
+)
+
+// fakeRepository only implements the methods exercised by the use cases under test
+type fakeRepository struct {
+	domain.ApplicationRepository
+	app       *domain.Application
+	report    *domain.ProcessReport
+	events    []domain.DomainEvent
+	config    map[string]string
+	configErr error
+}
+
+func (f *fakeRepository) GetByName(ctx context.Context, name *domain.ApplicationName) (*domain.Application, error) {
+	return f.app, nil
+}
+
+func (f *fakeRepository) Save(ctx context.Context, app *domain.Application) error {
+	f.events = append(f.events, app.GetEvents()...)
+	app.ClearEvents()
+	return nil
+}
+
+func (f *fakeRepository) GetConfig(ctx context.Context, name *domain.ApplicationName) (map[string]string, error) {
+	return f.config, f.configErr
+}
+
+func (f *fakeRepository) GetProcessReport(ctx context.Context, name *domain.ApplicationName) (*domain.ProcessReport, error) {
+	return f.report, nil
+}
+
+// fakeDeploymentService starts deploys that end with final, and reports active
+// as the deployment in progress
+type fakeDeploymentService struct {
+	shared.DeploymentService
+	active   *shared.DeploymentResult
+	final    *shared.DeploymentResult
+	deployed int
+}
+
+func (f *fakeDeploymentService) GetActiveDeployment(ctx context.Context, appName string) (*shared.DeploymentResult, error) {
+	return f.active, nil
+}
+
+func (f *fakeDeploymentService) Deploy(ctx context.Context, appName string, options shared.DeployOptions) (*shared.DeploymentResult, error) {
+	f.deployed++
+	return &shared.DeploymentResult{ID: f.final.ID, AppName: appName, Status: shared.DeploymentStatusRunning}, nil
+}
+
+func (f *fakeDeploymentService) WaitForCompletion(ctx context.Context, deploymentID string) (*shared.DeploymentResult, error) {
+	return f.final, nil
+}
+
+func TestDeployApplicationFollowsTheTrackedOutcome(t *testing.T) {
+	cases := []struct {
+		name      string
+		final     *shared.DeploymentResult
+		wantErr   string
+		wantState domain.StateValue
+	}{
+		{
+			name:      "succeeded",
+			final:     &shared.DeploymentResult{ID: "deploy-1", Status: shared.DeploymentStatusSucceeded, Warnings: []string{"no web process"}},
+			wantState: domain.StateRunning,
+		},
+		{
+			name:      "build failed",
+			final:     &shared.DeploymentResult{ID: "deploy-1", Status: shared.DeploymentStatusFailed, ErrorMsg: "Build failed"},
+			wantErr:   "Build failed",
+			wantState: domain.StateError,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			application, err := domain.NewApplication("my-app")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			repo := &fakeRepository{app: application}
+			uc := NewApplicationUseCase(repo, &fakeDeploymentService{final: tc.final}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+			validation, err := uc.DeployApplication(context.Background(), DeployApplicationCommand{
+				Name:    "my-app",
+				RepoURL: "https://github.com/acme/app.git",
+				GitRef:  "main",
+			})
+			if tc.wantErr == "" && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tc.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tc.wantErr)) {
+				t.Fatalf("expected an error containing %q, got %v", tc.wantErr, err)
+			}
+			if application.State().Value() != tc.wantState {
+				t.Fatalf("expected state %s, got %s", tc.wantState, application.State().Value())
+			}
+			for _, warning := range tc.final.Warnings {
+				found := false
+				for _, w := range validation.Warnings {
+					found = found || w.Message == warning
+				}
+				if !found {
+					t.Fatalf("expected the post-deployment warning %q, got %+v", warning, validation.Warnings)
+				}
+			}
+		})
+	}
+}
+
+func TestDeployApplicationRefusesWhileADeployIsTracked(t *testing.T) {
+	application, err := domain.NewApplication("my-app")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	deployments := &fakeDeploymentService{active: &shared.DeploymentResult{ID: "deploy-0", Status: shared.DeploymentStatusRunning}}
+	uc := NewApplicationUseCase(&fakeRepository{app: application}, deployments, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	_, err = uc.DeployApplication(context.Background(), DeployApplicationCommand{
+		Name:    "my-app",
+		RepoURL: "https://github.com/acme/app.git",
+		GitRef:  "main",
+	})
+	if !errors.Is(err, domain.ErrDeploymentInProgress) {
+		t.Fatalf("expected a deployment in progress error, got %v", err)
+	}
+	if deployments.deployed != 0 {
+		t.Fatalf("expected no deploy to start")
+	}
+}
+
+func TestSetApplicationConfigRollsBackWhenUnhealthy(t *testing.T) {
+	application, err := domain.NewApplication("my-app")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := application.SetEnvironmentVariable("DATABASE_URL", "postgres://old"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	application.ClearEvents()
+
+	repo := &fakeRepository{
+		app: application,
+		report: domain.ParseProcessReport("my-app", map[string]string{
+			"Status web 1": "restarting (CID: 03ea8977f37)",
+		}),
+		config: map[string]string{"DATABASE_URL": "postgres://old"},
+	}
+	uc := NewApplicationUseCase(repo, nil, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	uc.healthPollInterval = time.Millisecond
+
+	err = uc.SetApplicationConfig(context.Background(), SetConfigCommand{
+		Name:              "my-app",
+		Config:            map[string]string{"DATABASE_URL": "postgres://new", "FEATURE_FLAG": "on"},
+		RollbackOnFailure: true,
+		HealthTimeout:     10 * time.Millisecond,
+	})
+	if !errors.Is(err, domain.ErrHealthCheckFailed) {
+		t.Fatalf("expected a health check failure, got %v", err)
+	}
+
+	if len(repo.events) != 2 {
+		t.Fatalf("expected configure and restore events, got %d", len(repo.events))
+	}
+	restored, ok := repo.events[1].(*domain.EnvironmentRestoredEvent)
+	if !ok {
+		t.Fatalf("expected an environment restored event, got %T", repo.events[1])
+	}
+	if !reflect.DeepEqual(restored.Previous(), map[string]string{"DATABASE_URL": "postgres://old"}) {
+		t.Fatalf("unexpected restored values: %v", restored.Previous())
+	}
+	if !reflect.DeepEqual(restored.Removed(), []string{"FEATURE_FLAG"}) {
+		t.Fatalf("unexpected removed keys: %v", restored.Removed())
+	}
+}
+
+func TestSetApplicationConfigRefusesRollbackWithoutSnapshot(t *testing.T) {
+	application, err := domain.NewApplication("my-app")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	application.ClearEvents()
+
+	// The loaded app has no variables because config:show failed: a snapshot taken
+	// from it would unset DATABASE_URL on rollback
+	repo := &fakeRepository{app: application, configErr: errors.New("config:show failed")}
+	uc := NewApplicationUseCase(repo, nil, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	err = uc.SetApplicationConfig(context.Background(), SetConfigCommand{
+		Name:              "my-app",
+		Config:            map[string]string{"DATABASE_URL": "postgres://new"},
+		RollbackOnFailure: true,
+		HealthTimeout:     10 * time.Millisecond,
+	})
+	if err == nil || !strings.Contains(err.Error(), "config:show failed") {
+		t.Fatalf("expected the config read failure, got %v", err)
+	}
+	if len(repo.events) != 0 {
+		t.Fatalf("expected nothing to be changed, got %d events", len(repo.events))
+	}
+}
+
+func TestSetApplicationConfigKeepsChangeWhenHealthy(t *testing.T) {
+	application, err := domain.NewApplication("my-app")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	application.ClearEvents()
+
+	repo := &fakeRepository{
+		app: application,
+		report: domain.ParseProcessReport("my-app", map[string]string{
+			"Status web 1": "running (CID: 03ea8977f37)",
+		}),
+	}
+	uc := NewApplicationUseCase(repo, nil, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	uc.healthPollInterval = time.Millisecond
+
+	err = uc.SetApplicationConfig(context.Background(), SetConfigCommand{
+		Name:              "my-app",
+		Config:            map[string]string{"FEATURE_FLAG": "on"},
+		RollbackOnFailure: true,
+		HealthTimeout:     10 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(repo.events) != 1 {
+		t.Fatalf("expected only the configure event, got %d", len(repo.events))
+	}
+}
+
+func TestRotateSecretGeneratesNewValueWithoutLoggingIt(t *testing.T) {
+	application, err := domain.NewApplication("my-app")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	application.ClearEvents()
+
+	var logs bytes.Buffer
+	repo := &fakeRepository{app: application}
+	uc := NewApplicationUseCase(repo, nil, slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug})))
+
+	var values []string
+	for i := 0; i < 2; i++ {
+		if err := uc.RotateSecret(context.Background(), RotateSecretCommand{Name: "my-app", Key: "SESSION_ID"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if len(repo.events) != 4 {
+		t.Fatalf("expected a configure and a restart event per rotation, got %d", len(repo.events))
+	}
+	for i := 0; i < len(repo.events); i += 2 {
+		configured, ok := repo.events[i].(*domain.EnvironmentConfiguredEvent)
+		if !ok {
+			t.Fatalf("expected an environment configured event, got %T", repo.events[i])
+		}
+		if !configured.NoRestart() {
+			t.Fatal("expected the secret to be stored without restart")
+		}
+		if _, ok := repo.events[i+1].(*domain.ApplicationRestartRequestedEvent); !ok {
+			t.Fatalf("expected a restart event, got %T", repo.events[i+1])
+		}
+		values = append(values, configured.Variables()["SESSION_ID"])
+	}
+
+	if values[0] == "" || values[0] == values[1] {
+		t.Fatalf("expected a new value on each rotation, got %q and %q", values[0], values[1])
+	}
+	for _, value := range values {
+		if strings.Contains(logs.String(), value) {
+			t.Fatalf("secret value leaked in logs: %s", logs.String())
+		}
+	}
+}
+
+func TestUnsetApplicationConfig(t *testing.T) {
+	application, err := domain.NewApplication("my-app")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	application.ClearEvents()
+
+	repo := &fakeRepository{app: application}
+	uc := NewApplicationUseCase(repo, nil, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	if err := uc.UnsetApplicationConfig(context.Background(), UnsetConfigCommand{Name: "my-app", Keys: []string{" ", ""}}); !errors.Is(err, domain.ErrNoConfigKeys) {
+		t.Fatalf("expected ErrNoConfigKeys for blank keys, got %v", err)
+	}
+	if len(repo.events) != 0 {
+		t.Fatalf("expected nothing to be saved, got %d events", len(repo.events))
+	}
+
+	if err := uc.UnsetApplicationConfig(context.Background(), UnsetConfigCommand{Name: "my-app", Keys: []string{"OLD_TOKEN", "LEGACY_URL"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(repo.events) != 1 {
+		t.Fatalf("expected a single unset event, got %d", len(repo.events))
+	}
+	unset, ok := repo.events[0].(*domain.EnvironmentUnsetEvent)
+	if !ok {
+		t.Fatalf("expected an environment unset event, got %T", repo.events[0])
+	}
+	if !reflect.DeepEqual(unset.Keys(), []string{"OLD_TOKEN", "LEGACY_URL"}) {
+		t.Fatalf("unexpected keys %v", unset.Keys())
+	}
+}
+
+func newAppWithFormation(t *testing.T, formation map[process.ProcessType]int) *domain.Application {
+	t.Helper()
+	application, err := domain.NewApplication("my-app")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for processType, scale := range formation {
+		if err := application.AddProcessForScaling(processType, scale); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	application.ClearEvents()
+	return application
+}
+
+func TestReconcileFormationOnlyScalesDifferingProcessTypes(t *testing.T) {
+	repo := &fakeRepository{app: newAppWithFormation(t, map[process.ProcessType]int{
+		process.ProcessTypeWeb:    2,
+		process.ProcessTypeWorker: 1,
+	})}
+	uc := NewApplicationUseCase(repo, nil, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	changes, err := uc.ReconcileFormation(context.Background(), ReconcileFormationCommand{
+		Name:      "my-app",
+		Formation: map[string]int{"web": 2, "worker": 3},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(changes, []domain.FormationChange{{ProcessType: "worker", From: 1, To: 3}}) {
+		t.Fatalf("unexpected changes: %+v", changes)
+	}
+	if len(repo.events) != 1 {
+		t.Fatalf("expected a single scale event, got %d", len(repo.events))
+	}
+	scaled, ok := repo.events[0].(*domain.ApplicationScaledEvent)
+	if !ok {
+		t.Fatalf("expected a scaled event, got %T", repo.events[0])
+	}
+	if scaled.ProcessType() != "worker" || scaled.NewScale() != 3 {
+		t.Fatalf("unexpected scale event: %s=%d", scaled.ProcessType(), scaled.NewScale())
+	}
+}
+
+func TestReconcileFormationIsNoOpWhenMatching(t *testing.T) {
+	repo := &fakeRepository{app: newAppWithFormation(t, map[process.ProcessType]int{process.ProcessTypeWeb: 2})}
+	uc := NewApplicationUseCase(repo, nil, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	changes, err := uc.ReconcileFormation(context.Background(), ReconcileFormationCommand{
+		Name:      "my-app",
+		Formation: map[string]int{"web": 2},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(changes) != 0 || len(repo.events) != 0 {
+		t.Fatalf("expected no changes, got %+v and %d events", changes, len(repo.events))
+	}
+}
+
+func TestScaleApplicationBulkRecordsASingleEvent(t *testing.T) {
+	repo := &fakeRepository{app: newAppWithFormation(t, map[process.ProcessType]int{process.ProcessTypeWeb: 1})}
+	uc := NewApplicationUseCase(repo, nil, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	changes, _, err := uc.ScaleApplicationBulk(context.Background(), ScaleApplicationBulkCommand{
+		Name:   "my-app",
+		Scales: map[string]int{"web": 2, "worker": 3},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []domain.FormationChange{{ProcessType: "web", From: 1, To: 2}, {ProcessType: "worker", From: 0, To: 3}}
+	if !reflect.DeepEqual(changes, want) {
+		t.Fatalf("unexpected changes: %+v", changes)
+	}
+	if len(repo.events) != 1 {
+		t.Fatalf("expected a single event, got %d", len(repo.events))
+	}
+	scaled, ok := repo.events[0].(*domain.FormationScaledEvent)
+	if !ok {
+		t.Fatalf("expected a formation scaled event, got %T", repo.events[0])
+	}
+	if !reflect.DeepEqual(scaled.Scales(), map[string]int{"web": 2, "worker": 3}) {
+		t.Fatalf("unexpected scales: %v", scaled.Scales())
+	}
+}
+
+func TestScaleApplicationBulkValidatesEveryProcessTypeFirst(t *testing.T) {
+	cases := []struct {
+		name   string
+		scales map[string]int
+	}{
+		{name: "negative count", scales: map[string]int{"web": 2, "worker": -1}},
+		{name: "invalid process type", scales: map[string]int{"web": 2, "Worker!": 1}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &fakeRepository{app: newAppWithFormation(t, map[process.ProcessType]int{process.ProcessTypeWeb: 1})}
+			uc := NewApplicationUseCase(repo, nil, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+			if _, _, err := uc.ScaleApplicationBulk(context.Background(), ScaleApplicationBulkCommand{Name: "my-app", Scales: tc.scales}); err == nil {
+				t.Fatal("expected an error")
+			}
+			if len(repo.events) != 0 {
+				t.Fatalf("expected nothing to be scaled, got %d events", len(repo.events))
+			}
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	domain "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/app/domain"
+	"github.com/dokku-mcp/dokku-mcp/internal/shared/process"
+)
+
+// maxBackupConcurrency bounds how many applications are read at once during an export
+const maxBackupConcurrency = 4
+
+// ExportAppsCommand represents the options of a backup of every application
+type ExportAppsCommand struct {
+	// Encrypt seals sensitive values with EncryptionKey instead of masking them
+	Encrypt       bool
+	EncryptionKey string
+}
+
+// ExportAllApps combines the manifests of every application into a single backup
+func (uc *ApplicationUseCase) ExportAllApps(ctx context.Context, cmd ExportAppsCommand) (*domain.AppsBackup, error) {
+	uc.logger.Info("Exporting all applications", "encrypt", cmd.Encrypt)
+
+	if cmd.Encrypt && cmd.EncryptionKey == "" {
+		return nil, domain.ErrEncryptionKeyRequired
+	}
+
+	names, err := uc.applicationRepo.GetNames(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Every read happens in the bounded workers, and any failure fails the export:
+	// a backup silently missing an app or its variables would only show on restore
+	manifests := make([]domain.AppManifest, len(names))
+	errs := make([]error, len(names))
+	semaphore := make(chan struct{}, maxBackupConcurrency)
+	var wg sync.WaitGroup
+
+	for i, name := range names {
+		wg.Add(1)
+		go func(i int, name *domain.ApplicationName) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			manifest, err := uc.exportApp(ctx, name)
+			if err != nil {
+				errs[i] = fmt.Errorf("%s: %w", name.Value(), err)
+				return
+			}
+			manifests[i] = manifest
+		}(i, name)
+	}
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrIncompleteBackup, err)
+	}
+
+	backup, err := domain.NewAppsBackup(manifests, cmd.Encrypt, cmd.EncryptionKey)
+	if err != nil {
+		return nil, err
+	}
+
+	uc.logger.Info("Applications exported successfully", "count", len(backup.Apps))
+	return backup, nil
+}
+
+// exportApp reads the manifest of one application
+func (uc *ApplicationUseCase) exportApp(ctx context.Context, name *domain.ApplicationName) (domain.AppManifest, error) {
+	app, err := uc.applicationRepo.GetByName(ctx, name)
+	if err != nil {
+		return domain.AppManifest{}, err
+	}
+	environment, err := uc.applicationRepo.GetConfig(ctx, name)
+	if err != nil {
+		return domain.AppManifest{}, fmt.Errorf("failed to read the environment: %w", err)
+	}
+	nginx, err := uc.applicationRepo.GetNginxConfig(ctx, name)
+	if err != nil {
+		return domain.AppManifest{}, fmt.Errorf("failed to read the nginx settings: %w", err)
+	}
+	return domain.NewAppManifest(app, environment, nginx), nil
+}
+
+// ImportAppsCommand represents a backup to restore
+type ImportAppsCommand struct {
+	Backup        *domain.AppsBackup
+	EncryptionKey string
+}
+
+// ImportAllApps restores every application of a backup, creating missing ones.
+// Applications are restored one at a time and a failure does not stop the others.
+func (uc *ApplicationUseCase) ImportAllApps(ctx context.Context, cmd ImportAppsCommand) ([]domain.AppRestoreResult, error) {
+	manifests, skipped, err := cmd.Backup.RestorableManifests(cmd.EncryptionKey)
+	if err != nil {
+		return nil, err
+	}
+
+	uc.logger.Info("Importing applications", "count", len(manifests))
+
+	results := make([]domain.AppRestoreResult, 0, len(manifests))
+	for _, manifest := range manifests {
+		result := domain.AppRestoreResult{
+			Name:          manifest.Name,
+			SkippedMasked: skipped[manifest.Name],
+		}
+		if err := uc.restoreApp(ctx, manifest, &result); err != nil {
+			uc.logger.Warn("Failed to restore application", "app_name", manifest.Name, "error", err)
+			result.Error = err.Error()
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// restoreApp applies one manifest: environment, formation and nginx settings are saved together
+func (uc *ApplicationUseCase) restoreApp(ctx context.Context, manifest domain.AppManifest, result *domain.AppRestoreResult) error {
+	appName, err := domain.NewApplicationName(manifest.Name)
+	if err != nil {
+		return fmt.Errorf("invalid application name: %w", err)
+	}
+
+	exists, err := uc.applicationRepo.Exists(ctx, appName)
+	if err != nil {
+		return fmt.Errorf("failed to check application existence: %w", err)
+	}
+
+	var app *domain.Application
+	if exists {
+		app, err = uc.applicationRepo.GetByName(ctx, appName)
+	} else {
+		app, err = domain.NewApplication(manifest.Name)
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(manifest.Environment) > 0 {
+		if err := app.ConfigureEnvironment(manifest.Environment, false); err != nil {
+			return err
+		}
+	}
+
+	desired := make(map[process.ProcessType]int, len(manifest.Formation))
+	for name, scale := range manifest.Formation {
+		processType, err := process.NewProcessType(name)
+		if err != nil {
+			return fmt.Errorf("invalid process type: %w", err)
+		}
+		desired[processType] = scale
+	}
+	changes, err := app.ReconcileFormation(desired)
+	if err != nil {
+		return err
+	}
+
+	for property, value := range manifest.Nginx {
+		if err := app.SetNginxProperty(domain.NginxProperty(property), value); err != nil {
+			return err
+		}
+	}
+
+	if err := uc.applicationRepo.Save(ctx, app); err != nil {
+		return fmt.Errorf("failed to save restored application: %w", err)
+	}
+
+	result.Created = !exists
+	result.Variables = len(manifest.Environment)
+	result.FormationChanges = changes
+	return nil
+}
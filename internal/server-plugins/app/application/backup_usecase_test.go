@@ -0,0 +1,218 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	domain "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/app/domain"
+	"github.com/dokku-mcp/dokku-mcp/internal/shared/process"
+)
+
+// memoryRepository keeps applications in memory, saved as they are
+type memoryRepository struct {
+	domain.ApplicationRepository
+	mu   sync.Mutex
+	apps map[string]*domain.Application
+	// configErrs makes config:show fail for the named apps
+	configErrs map[string]error
+}
+
+func newMemoryRepository(apps ...*domain.Application) *memoryRepository {
+	repo := &memoryRepository{apps: make(map[string]*domain.Application)}
+	for _, app := range apps {
+		repo.apps[app.Name().Value()] = app
+	}
+	return repo
+}
+
+func (m *memoryRepository) GetAll(ctx context.Context) ([]*domain.Application, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	apps := make([]*domain.Application, 0, len(m.apps))
+	for _, app := range m.apps {
+		apps = append(apps, app)
+	}
+	sort.Slice(apps, func(i, j int) bool { return apps[i].Name().Value() < apps[j].Name().Value() })
+	return apps, nil
+}
+
+func (m *memoryRepository) GetNames(ctx context.Context) ([]*domain.ApplicationName, error) {
+	apps, _ := m.GetAll(ctx)
+	names := make([]*domain.ApplicationName, 0, len(apps))
+	for _, app := range apps {
+		names = append(names, app.Name())
+	}
+	return names, nil
+}
+
+func (m *memoryRepository) GetConfig(ctx context.Context, name *domain.ApplicationName) (map[string]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.configErrs[name.Value()]; err != nil {
+		return nil, err
+	}
+	app, ok := m.apps[name.Value()]
+	if !ok {
+		return nil, domain.ErrApplicationNotFound
+	}
+	return app.EnvironmentVariables(), nil
+}
+
+func (m *memoryRepository) GetByName(ctx context.Context, name *domain.ApplicationName) (*domain.Application, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if app, ok := m.apps[name.Value()]; ok {
+		return app, nil
+	}
+	return nil, domain.ErrApplicationNotFound
+}
+
+func (m *memoryRepository) Exists(ctx context.Context, name *domain.ApplicationName) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	_, ok := m.apps[name.Value()]
+	return ok, nil
+}
+
+func (m *memoryRepository) GetNginxConfig(ctx context.Context, name *domain.ApplicationName) (map[domain.NginxProperty]string, error) {
+	return map[domain.NginxProperty]string{domain.NginxClientMaxBodySize: "50m"}, nil
+}
+
+func (m *memoryRepository) Save(ctx context.Context, app *domain.Application) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	app.ClearEvents()
+	m.apps[app.Name().Value()] = app
+	return nil
+}
+
+func newBackupTestApp(t *testing.T, name string, env map[string]string, formation map[process.ProcessType]int) *domain.Application {
+	t.Helper()
+	application, err := domain.NewApplication(name)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for key, value := range env {
+		if err := application.SetEnvironmentVariable(key, value); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	for processType, scale := range formation {
+		if err := application.AddProcessForScaling(processType, scale); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	application.ClearEvents()
+	return application
+}
+
+func TestExportImportAllAppsRoundTrip(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	source := newMemoryRepository(
+		newBackupTestApp(t, "api", map[string]string{"DATABASE_URL": "postgres://db", "API_SECRET": "s3cr3t"},
+			map[process.ProcessType]int{process.ProcessTypeWeb: 2, process.ProcessTypeWorker: 1}),
+		newBackupTestApp(t, "frontend", map[string]string{"NODE_ENV": "production"},
+			map[process.ProcessType]int{process.ProcessTypeWeb: 1}),
+	)
+
+	backup, err := NewApplicationUseCase(source, nil, logger).ExportAllApps(context.Background(), ExportAppsCommand{
+		Encrypt:       true,
+		EncryptionKey: "correct horse battery staple",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(backup.Apps) != 2 {
+		t.Fatalf("expected 2 manifests, got %d", len(backup.Apps))
+	}
+	if secret := backup.Apps[0].Environment["API_SECRET"]; secret == "s3cr3t" || secret == "" {
+		t.Fatalf("sensitive value exported in clear: %q", secret)
+	}
+
+	// The backup goes through JSON like it does between the two tools
+	raw, err := json.Marshal(backup)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var decoded domain.AppsBackup
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	target := newMemoryRepository()
+	results, err := NewApplicationUseCase(target, nil, logger).ImportAllApps(context.Background(), ImportAppsCommand{
+		Backup:        &decoded,
+		EncryptionKey: "correct horse battery staple",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, result := range results {
+		if result.Error != "" || !result.Created {
+			t.Fatalf("unexpected restore result: %+v", result)
+		}
+	}
+
+	for name, original := range source.apps {
+		restored, ok := target.apps[name]
+		if !ok {
+			t.Fatalf("application %s was not restored", name)
+		}
+		if !reflect.DeepEqual(restored.EnvironmentVariables(), original.EnvironmentVariables()) {
+			t.Fatalf("environment of %s: got %v, want %v", name, restored.EnvironmentVariables(), original.EnvironmentVariables())
+		}
+		if !reflect.DeepEqual(restored.Formation(), original.Formation()) {
+			t.Fatalf("formation of %s: got %v, want %v", name, restored.Formation(), original.Formation())
+		}
+	}
+}
+
+func TestImportAllAppsSkipsMaskedValues(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	source := newMemoryRepository(newBackupTestApp(t, "api",
+		map[string]string{"DATABASE_URL": "postgres://db", "API_SECRET": "s3cr3t"},
+		map[process.ProcessType]int{process.ProcessTypeWeb: 1}))
+
+	backup, err := NewApplicationUseCase(source, nil, logger).ExportAllApps(context.Background(), ExportAppsCommand{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	target := newMemoryRepository()
+	results, err := NewApplicationUseCase(target, nil, logger).ImportAllApps(context.Background(), ImportAppsCommand{Backup: backup})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(results[0].SkippedMasked, []string{"API_SECRET"}) {
+		t.Fatalf("expected API_SECRET to be reported as skipped, got %v", results[0].SkippedMasked)
+	}
+	if !reflect.DeepEqual(target.apps["api"].EnvironmentVariables(), map[string]string{"DATABASE_URL": "postgres://db"}) {
+		t.Fatalf("unexpected restored environment: %v", target.apps["api"].EnvironmentVariables())
+	}
+}
+
+func TestExportAllAppsFailsWhenAnAppCannotBeRead(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	source := newMemoryRepository(
+		newBackupTestApp(t, "api", map[string]string{"DATABASE_URL": "postgres://db"}, map[process.ProcessType]int{process.ProcessTypeWeb: 1}),
+		newBackupTestApp(t, "frontend", map[string]string{"NODE_ENV": "production"}, map[process.ProcessType]int{process.ProcessTypeWeb: 1}),
+	)
+	source.configErrs = map[string]error{"api": errors.New("config:show failed")}
+
+	backup, err := NewApplicationUseCase(source, nil, logger).ExportAllApps(context.Background(), ExportAppsCommand{})
+	if !errors.Is(err, domain.ErrIncompleteBackup) {
+		t.Fatalf("expected an incomplete backup error, got %v (backup %+v)", err, backup)
+	}
+	if !strings.Contains(err.Error(), "api") || strings.Contains(err.Error(), "frontend") {
+		t.Fatalf("expected only the failing app to be named, got %v", err)
+	}
+}
@@ -0,0 +1,110 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	domain "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/app/domain"
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
+)
+
+// DriftPublisher receives the drift events found by a DriftDetector
+type DriftPublisher func(event *domain.ApplicationDriftDetectedEvent)
+
+// DriftDetector periodically snapshots every application and reports the ones
+// whose scale, domains or deployed commit changed since the previous snapshot,
+// such as an app scaled or redeployed directly on the Dokku host
+type DriftDetector struct {
+	repo      domain.ApplicationRepository
+	publish   DriftPublisher
+	logger    *slog.Logger
+	now       func() time.Time
+	snapshots map[string]*domain.AppSnapshot
+	mu        sync.Mutex
+	runner    *shared.PeriodicRunner
+}
+
+// NewDriftDetector creates a detector taking snapshots every interval and handing drift events to publish
+func NewDriftDetector(repo domain.ApplicationRepository, publish DriftPublisher, logger *slog.Logger, interval time.Duration) *DriftDetector {
+	detector := &DriftDetector{
+		repo:      repo,
+		publish:   publish,
+		logger:    logger,
+		now:       time.Now,
+		snapshots: make(map[string]*domain.AppSnapshot),
+	}
+	detector.runner = shared.NewPeriodicRunner(interval, true, detector.runCheck)
+	return detector
+}
+
+// Check takes a snapshot of every application and returns the drift found since
+// the previous check. Apps seen for the first time only record a baseline, and
+// apps that could not be read keep their previous snapshot.
+func (d *DriftDetector) Check(ctx context.Context) ([]*domain.ApplicationDriftDetectedEvent, error) {
+	apps, err := d.repo.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list applications: %w", err)
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	events := []*domain.ApplicationDriftDetectedEvent{}
+	seen := make(map[string]bool, len(apps))
+	for _, application := range apps {
+		name := application.Name().Value()
+		seen[name] = true
+
+		gitInfo, err := d.repo.GetGitInfo(ctx, application.Name())
+		if err != nil {
+			d.logger.Warn("Failed to read the deployed commit, skipping drift check", "app_name", name, "error", err)
+			continue
+		}
+
+		snapshot := domain.NewAppSnapshot(application, gitInfo.SHA, d.now())
+		previous, known := d.snapshots[name]
+		d.snapshots[name] = snapshot
+		if !known {
+			continue
+		}
+
+		changes := previous.Diff(snapshot)
+		if len(changes) == 0 {
+			continue
+		}
+
+		event := domain.NewApplicationDriftDetectedEvent(name, changes, snapshot.TakenAt)
+		d.logger.Warn("Application drift detected", "app_name", name, "changes", changes, "since", previous.TakenAt)
+		if d.publish != nil {
+			d.publish(event)
+		}
+		events = append(events, event)
+	}
+
+	for name := range d.snapshots {
+		if !seen[name] {
+			delete(d.snapshots, name)
+		}
+	}
+
+	return events, nil
+}
+
+// Start checks for drift every interval until Stop is called
+func (d *DriftDetector) Start() {
+	d.runner.Start()
+}
+
+// Stop ends the background loop, cancelling a check in progress
+func (d *DriftDetector) Stop(ctx context.Context) error {
+	return d.runner.Stop(ctx)
+}
+
+func (d *DriftDetector) runCheck(ctx context.Context) {
+	if _, err := d.Check(ctx); err != nil && ctx.Err() == nil {
+		d.logger.Warn("Drift detection failed", "error", err)
+	}
+}
@@ -0,0 +1,72 @@
+package usecases
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"reflect"
+	"testing"
+
+	domain "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/app/domain"
+	"github.com/dokku-mcp/dokku-mcp/internal/shared/process"
+)
+
+// snapshotRepository serves the applications and deployed commits a drift check reads
+type snapshotRepository struct {
+	domain.ApplicationRepository
+	apps []*domain.Application
+	sha  string
+}
+
+func (r *snapshotRepository) GetAll(ctx context.Context) ([]*domain.Application, error) {
+	return r.apps, nil
+}
+
+func (r *snapshotRepository) GetGitInfo(ctx context.Context, name *domain.ApplicationName) (*domain.GitInfo, error) {
+	return &domain.GitInfo{AppName: name.Value(), Deployed: true, SHA: r.sha}, nil
+}
+
+func TestDriftDetectorReportsScaleChangedBetweenSnapshots(t *testing.T) {
+	repo := &snapshotRepository{
+		apps: []*domain.Application{newAppWithFormation(t, map[process.ProcessType]int{process.ProcessTypeWeb: 1})},
+		sha:  "4f2a9c1",
+	}
+	published := []*domain.ApplicationDriftDetectedEvent{}
+	detector := NewDriftDetector(repo, func(event *domain.ApplicationDriftDetectedEvent) {
+		published = append(published, event)
+	}, slog.New(slog.NewTextHandler(io.Discard, nil)), 0)
+
+	events, err := detector.Check(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(events) != 0 {
+		t.Fatalf("expected the first snapshot to be a baseline, got %d events", len(events))
+	}
+
+	// Scaled out of band, e.g. with dokku ps:scale on the host
+	repo.apps = []*domain.Application{newAppWithFormation(t, map[process.ProcessType]int{process.ProcessTypeWeb: 3})}
+
+	events, err = detector.Check(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(events) != 1 || len(published) != 1 {
+		t.Fatalf("expected one drift event to be returned and published, got %d and %d", len(events), len(published))
+	}
+	if events[0].AggregateID() != "my-app" || events[0].EventType() != "application.drift.detected" {
+		t.Fatalf("unexpected event: %s for %s", events[0].EventType(), events[0].AggregateID())
+	}
+	want := []domain.DriftChange{{Field: "scale.web", Before: "1", After: "3"}}
+	if !reflect.DeepEqual(events[0].Changes(), want) {
+		t.Fatalf("unexpected changes: %+v", events[0].Changes())
+	}
+
+	events, err = detector.Check(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(events) != 0 {
+		t.Fatalf("expected no drift once the new scale is the baseline, got %+v", events[0].Changes())
+	}
+}
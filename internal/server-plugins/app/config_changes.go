@@ -0,0 +1,69 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/server"
+	appdomain "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/app/domain"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// configChange is one audited config:set or config:unset of an application; values are never kept
+type configChange struct {
+	Time     time.Time `json:"time"`
+	Action   string    `json:"action"`
+	Keys     []string  `json:"keys"`
+	UserID   string    `json:"user_id,omitempty"`
+	TenantID string    `json:"tenant_id,omitempty"`
+}
+
+func (p *AppsServerPlugin) buildGetAppConfigChangesTool() mcp.Tool {
+	return mcp.NewTool(
+		"get_app_config_changes",
+		mcp.WithDescription("Get the chronological history of an application's configuration changes (config:set and config:unset made through this server): when, by whom and which keys. Values are never returned. Requires auditing (multi_tenant.observability.audit_enabled); the history is kept in memory and starts over when the server restarts"),
+		mcp.WithString("app_name",
+			mcp.Required(),
+			mcp.Description("Name of the application"),
+		),
+	)
+}
+
+func (p *AppsServerPlugin) handleGetAppConfigChanges(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	appName, err := req.RequireString("app_name")
+	if err != nil {
+		return server.Error("Application name is required"), nil
+	}
+
+	changes := []configChange{}
+	data := map[string]any{"app_name": appName, "auditing_enabled": p.auditLog != nil, "changes": changes}
+	if p.auditLog == nil {
+		return server.OK(fmt.Sprintf("No configuration changes recorded for '%s'", appName), data,
+			"Auditing is disabled, so configuration changes are not recorded; enable multi_tenant.observability.audit_enabled to track them"), nil
+	}
+
+	events, err := p.auditLog.Events(ctx)
+	if err != nil {
+		return server.Error(fmt.Sprintf("Failed to read the audit log: %v", err)), nil
+	}
+
+	for _, event := range events {
+		if event.Resource != appName || (event.Action != appdomain.CommandConfigSet.String() && event.Action != appdomain.CommandConfigUnset.String()) {
+			continue
+		}
+		change := configChange{Time: event.Timestamp, Action: event.Action, Keys: []string{}, UserID: event.UserID, TenantID: event.TenantID}
+		if raw, ok := event.Parameters["keys"].GetJSON(); ok {
+			if err := json.Unmarshal(raw, &change.Keys); err != nil {
+				p.logger.Warn("Ignoring malformed audited config keys", "app_name", appName, "error", err)
+			}
+		}
+		changes = append(changes, change)
+	}
+	slices.SortStableFunc(changes, func(a, b configChange) int { return a.Time.Compare(b.Time) })
+	data["changes"] = changes
+
+	return server.OK(fmt.Sprintf("%d configuration changes of '%s'", len(changes), appName), data), nil
+}
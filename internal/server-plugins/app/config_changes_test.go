@@ -0,0 +1,67 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/shared/audit"
+)
+
+func TestGetAppConfigChanges(t *testing.T) {
+	auditLog := audit.NewMemorySink(0)
+	start := time.Date(2025, 12, 13, 10, 0, 0, 0, time.UTC)
+	fabricate := func(offset time.Duration, action, resource, keys string) {
+		if err := auditLog.Record(context.Background(), audit.Event{
+			Timestamp:  start.Add(offset),
+			UserID:     "alice",
+			Action:     action,
+			Resource:   resource,
+			Parameters: map[string]audit.AuditParameter{"keys": audit.NewJSONParameter(json.RawMessage(keys))},
+		}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	fabricate(2*time.Minute, "config:unset", "my-app", `["DEBUG"]`)
+	fabricate(time.Minute, "config:set", "my-app", `["DATABASE_URL","DEBUG"]`)
+	fabricate(3*time.Minute, "config:set", "other-app", `["PORT"]`)
+	fabricate(4*time.Minute, "ps:scale", "my-app", `[]`)
+
+	plugin := newTestPlugin(&fakeApplicationRepository{}, false)
+	plugin.auditLog = auditLog
+
+	result, err := plugin.handleGetAppConfigChanges(context.Background(), newToolRequest(map[string]any{"app_name": "my-app"}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var data struct {
+		Enabled bool           `json:"auditing_enabled"`
+		Changes []configChange `json:"changes"`
+	}
+	resultData(t, result, &data)
+	if !data.Enabled || len(data.Changes) != 2 {
+		t.Fatalf("expected the two config changes of my-app, got %+v", data)
+	}
+	first, second := data.Changes[0], data.Changes[1]
+	if first.Action != "config:set" || strings.Join(first.Keys, ",") != "DATABASE_URL,DEBUG" || first.UserID != "alice" {
+		t.Fatalf("unexpected first change: %+v", first)
+	}
+	if second.Action != "config:unset" || strings.Join(second.Keys, ",") != "DEBUG" || !second.Time.After(first.Time) {
+		t.Fatalf("unexpected second change: %+v", second)
+	}
+
+	plugin.auditLog = nil
+	result, err = plugin.handleGetAppConfigChanges(context.Background(), newToolRequest(map[string]any{"app_name": "my-app"}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.IsError || !strings.Contains(resultText(t, result), "Auditing is disabled") {
+		t.Fatalf("expected an empty history with a note, got %q", resultText(t, result))
+	}
+	resultData(t, result, &data)
+	if data.Enabled || len(data.Changes) != 0 {
+		t.Fatalf("expected no changes while auditing is disabled, got %+v", data)
+	}
+}
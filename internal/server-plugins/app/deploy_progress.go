@@ -0,0 +1,50 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/server"
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
+	"github.com/mark3labs/mcp-go/mcp"
+	mcpserver "github.com/mark3labs/mcp-go/server"
+)
+
+// notificationSender sends a notification to the client of the current request
+type notificationSender func(ctx context.Context, method string, params map[string]any) error
+
+// withDeployProgress returns a context under which the output of the deploy's git:sync
+// and ps:rebuild is sent to the client as progress notifications, when the transport
+// streams and the client asked for progress. Otherwise the client only gets the final result.
+func (p *AppsServerPlugin) withDeployProgress(ctx context.Context, req mcp.CallToolRequest) context.Context {
+	if !p.streamDeployProgress || req.Params.Meta == nil || req.Params.Meta.ProgressToken == nil {
+		return ctx
+	}
+	mcpServer := mcpserver.ServerFromContext(ctx)
+	if mcpServer == nil {
+		return ctx
+	}
+	return shared.WithDeployOutput(ctx, deployProgressSink(ctx, req.Params.Meta.ProgressToken, mcpServer.SendNotificationToClient, p.logger))
+}
+
+// deployProgressSink turns each line of deploy output into a progress notification;
+// the progress is the number of lines received so far, as the total is unknown.
+// The sink is safe for concurrent use and sends the notifications in order.
+func deployProgressSink(ctx context.Context, token mcp.ProgressToken, send notificationSender, logger *slog.Logger) func(chunk []byte) {
+	var mu sync.Mutex
+	progress := 0
+	return func(chunk []byte) {
+		mu.Lock()
+		defer mu.Unlock()
+		progress++
+		err := send(ctx, "notifications/progress", map[string]any{
+			"progressToken": token,
+			"progress":      progress,
+			"message":       server.SanitizeLogLines([]string{string(chunk)})[0],
+		})
+		if err != nil {
+			logger.Debug("Failed to send deploy progress", "error", err)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Builder is a Dokku builder turning an application's source into an image, selected with builder:set
+type Builder string
+
+const (
+	BuilderHerokuish  Builder = "herokuish"
+	BuilderPack       Builder = "pack"
+	BuilderDockerfile Builder = "dockerfile"
+	BuilderNixpacks   Builder = "nixpacks"
+)
+
+// ErrInvalidBuilder is returned for builders Dokku does not provide
+var ErrInvalidBuilder = errors.New("invalid builder")
+
+// GetSupportedBuilders returns the builders an application can select
+func GetSupportedBuilders() []Builder {
+	return []Builder{BuilderHerokuish, BuilderPack, BuilderDockerfile, BuilderNixpacks}
+}
+
+// ParseBuilder validates a builder name, case-insensitively
+func ParseBuilder(value string) (Builder, error) {
+	builder := Builder(strings.ToLower(strings.TrimSpace(value)))
+	for _, supported := range GetSupportedBuilders() {
+		if builder == supported {
+			return builder, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %q (supported: %v)", ErrInvalidBuilder, value, GetSupportedBuilders())
+}
+
+// BuilderSettings describes the builder of an application
+type BuilderSettings struct {
+	AppName string `json:"app_name"`
+	// Selected is the builder set for the app; empty when Dokku detects it from the source
+	Selected string `json:"selected"`
+	// Computed is the builder used by the next build, falling back to the global setting
+	Computed string `json:"computed"`
+}
+
+// NewBuilderSettings builds the builder settings from builder:report values
+func NewBuilderSettings(appName, selected, computed string) *BuilderSettings {
+	return &BuilderSettings{
+		AppName:  appName,
+		Selected: strings.TrimSpace(selected),
+		Computed: strings.TrimSpace(computed),
+	}
+}
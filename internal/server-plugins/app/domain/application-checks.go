@@ -0,0 +1,73 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ChecksTimeoutVar is the app config variable holding the timeout, in seconds,
+// of each zero-downtime check request. It is read at deploy time.
+const ChecksTimeoutVar = "DOKKU_CHECKS_TIMEOUT"
+
+// ErrInvalidChecksDuration is returned for check durations that are not a positive number of seconds
+var ErrInvalidChecksDuration = errors.New("invalid checks duration")
+
+// ChecksSettings summarizes the zero-downtime checks configuration of an application
+type ChecksSettings struct {
+	AppName             string   `json:"app_name"`
+	DisabledProcesses   []string `json:"disabled_processes"`
+	SkippedProcesses    []string `json:"skipped_processes"`
+	WaitToRetireSeconds int      `json:"wait_to_retire_seconds"`
+	// TimeoutSeconds is 0 when DOKKU_CHECKS_TIMEOUT is not set and Dokku's default applies
+	TimeoutSeconds int `json:"timeout_seconds"`
+}
+
+// ParseChecksDuration converts a duration given as seconds ("60") or as a Go
+// duration ("90s", "2m") to a positive number of whole seconds
+func ParseChecksDuration(value string) (int, error) {
+	value = strings.TrimSpace(value)
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds <= 0 {
+			return 0, fmt.Errorf("%w: %q must be positive", ErrInvalidChecksDuration, value)
+		}
+		return seconds, nil
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q is neither seconds nor a duration like 90s or 2m", ErrInvalidChecksDuration, value)
+	}
+	if duration <= 0 {
+		return 0, fmt.Errorf("%w: %q must be positive", ErrInvalidChecksDuration, value)
+	}
+	if duration%time.Second != 0 {
+		return 0, fmt.Errorf("%w: %q must be a whole number of seconds", ErrInvalidChecksDuration, value)
+	}
+	return int(duration / time.Second), nil
+}
+
+// parseProcessList splits a checks:report process list; "none" means no process and "_all_" is kept as is
+func parseProcessList(value string) []string {
+	processes := []string{}
+	for _, process := range strings.Split(value, ",") {
+		if process = strings.TrimSpace(process); process != "" && process != "none" {
+			processes = append(processes, process)
+		}
+	}
+	return processes
+}
+
+// NewChecksSettings builds the checks settings from checks:report values and the app config
+func NewChecksSettings(appName, disabledList, skippedList, waitToRetire string, config map[string]string) *ChecksSettings {
+	settings := &ChecksSettings{
+		AppName:           appName,
+		DisabledProcesses: parseProcessList(disabledList),
+		SkippedProcesses:  parseProcessList(skippedList),
+	}
+	settings.WaitToRetireSeconds, _ = strconv.Atoi(strings.TrimSpace(waitToRetire))
+	settings.TimeoutSeconds, _ = strconv.Atoi(strings.TrimSpace(config[ChecksTimeoutVar]))
+	return settings
+}
@@ -0,0 +1,41 @@
+package app_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	app "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/app/domain"
+)
+
+var _ = Describe("Checks settings", func() {
+	DescribeTable("ParseChecksDuration",
+		func(value string, expected int, valid bool) {
+			seconds, err := app.ParseChecksDuration(value)
+			if !valid {
+				Expect(errors.Is(err, app.ErrInvalidChecksDuration)).To(BeTrue())
+				return
+			}
+			Expect(err).NotTo(HaveOccurred())
+			Expect(seconds).To(Equal(expected))
+		},
+		Entry("plain seconds", "60", 60, true),
+		Entry("seconds with unit", "90s", 90, true),
+		Entry("minutes", "2m", 120, true),
+		Entry("zero", "0", 0, false),
+		Entry("negative", "-5", 0, false),
+		Entry("negative duration", "-1m", 0, false),
+		Entry("fractional seconds", "1500ms", 0, false),
+		Entry("garbage", "soon", 0, false),
+	)
+
+	It("should build settings from checks:report values and the app config", func() {
+		settings := app.NewChecksSettings("my-app", "worker,release", "none", "60", map[string]string{app.ChecksTimeoutVar: "30"})
+
+		Expect(settings.DisabledProcesses).To(Equal([]string{"worker", "release"}))
+		Expect(settings.SkippedProcesses).To(BeEmpty())
+		Expect(settings.WaitToRetireSeconds).To(Equal(60))
+		Expect(settings.TimeoutSeconds).To(Equal(30))
+	})
+})
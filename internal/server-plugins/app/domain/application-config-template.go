@@ -0,0 +1,80 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ErrUnresolvedPlaceholders is returned when a config template still references unknown variables
+var ErrUnresolvedPlaceholders = errors.New("unresolved placeholders")
+
+// placeholderPattern matches ${VAR} placeholders
+var placeholderPattern = regexp.MustCompile(`\$\{([a-zA-Z_][a-zA-Z0-9_]*)\}`)
+
+// RenderConfigTemplate substitutes ${VAR} placeholders in each template value.
+// Placeholders resolve from values first, then from other rendered template
+// entries, so related variables can be built from each other
+// (e.g. DATABASE_URL from DB_USER and DB_HOST).
+func RenderConfigTemplate(template map[string]string, values map[string]string) (map[string]string, error) {
+	rendered := make(map[string]string, len(template))
+	for key, value := range template {
+		rendered[key] = value
+	}
+
+	lookup := func(name string) (string, bool) {
+		if value, ok := values[name]; ok {
+			return value, true
+		}
+		if value, ok := rendered[name]; ok && !placeholderPattern.MatchString(value) {
+			return value, true
+		}
+		return "", false
+	}
+
+	// Each pass resolves at least one level of references between entries
+	for pass := 0; pass <= len(template); pass++ {
+		changed := false
+		for key, value := range rendered {
+			resolved := placeholderPattern.ReplaceAllStringFunc(value, func(match string) string {
+				name := placeholderPattern.FindStringSubmatch(match)[1]
+				if replacement, ok := lookup(name); ok {
+					return replacement
+				}
+				return match
+			})
+			if resolved != value {
+				rendered[key] = resolved
+				changed = true
+			}
+		}
+		if !changed {
+			break
+		}
+	}
+
+	if unresolved := unresolvedPlaceholders(rendered); len(unresolved) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrUnresolvedPlaceholders, strings.Join(unresolved, ", "))
+	}
+
+	return rendered, nil
+}
+
+// unresolvedPlaceholders lists the distinct placeholder names left in the rendered values
+func unresolvedPlaceholders(rendered map[string]string) []string {
+	seen := make(map[string]bool)
+	for _, value := range rendered {
+		for _, match := range placeholderPattern.FindAllStringSubmatch(value, -1) {
+			seen[match[1]] = true
+		}
+	}
+
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
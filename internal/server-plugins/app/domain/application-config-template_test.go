@@ -0,0 +1,54 @@
+package app_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	app "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/app/domain"
+)
+
+var _ = Describe("RenderConfigTemplate", func() {
+	It("should resolve placeholders from values", func() {
+		rendered, err := app.RenderConfigTemplate(
+			map[string]string{"DATABASE_URL": "postgres://${DB_USER}@${DB_HOST}:5432/app"},
+			map[string]string{"DB_USER": "app", "DB_HOST": "db.internal"},
+		)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rendered).To(Equal(map[string]string{"DATABASE_URL": "postgres://app@db.internal:5432/app"}))
+	})
+
+	It("should resolve placeholders from other template entries", func() {
+		rendered, err := app.RenderConfigTemplate(
+			map[string]string{
+				"BASE_URL":     "https://${DOMAIN}",
+				"CALLBACK_URL": "${BASE_URL}/auth/callback",
+			},
+			map[string]string{"DOMAIN": "example.com"},
+		)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rendered["BASE_URL"]).To(Equal("https://example.com"))
+		Expect(rendered["CALLBACK_URL"]).To(Equal("https://example.com/auth/callback"))
+	})
+
+	It("should report unresolved placeholders", func() {
+		_, err := app.RenderConfigTemplate(
+			map[string]string{"DATABASE_URL": "postgres://${DB_USER}:${DB_PASSWORD}@db/app"},
+			map[string]string{"DB_USER": "app"},
+		)
+
+		Expect(err).To(MatchError(app.ErrUnresolvedPlaceholders))
+		Expect(err.Error()).To(ContainSubstring("DB_PASSWORD"))
+		Expect(err.Error()).NotTo(ContainSubstring("DB_USER"))
+	})
+
+	It("should not resolve circular references", func() {
+		_, err := app.RenderConfigTemplate(
+			map[string]string{"A": "${B}", "B": "${A}"},
+			nil,
+		)
+
+		Expect(err).To(MatchError(app.ErrUnresolvedPlaceholders))
+	})
+})
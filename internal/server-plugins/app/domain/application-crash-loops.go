@@ -0,0 +1,71 @@
+package app
+
+import "time"
+
+const (
+	// DefaultCrashLoopThreshold is the number of restarts from which a container is considered crash looping
+	DefaultCrashLoopThreshold = 3
+	// DefaultCrashLoopWindow is how recent the last restart must be for a container to still be crash looping
+	DefaultCrashLoopWindow = 15 * time.Minute
+)
+
+// CrashLoop is a container restarting above the crash loop threshold
+type CrashLoop struct {
+	Type         string     `json:"type"`
+	Index        int        `json:"index"`
+	Status       string     `json:"status"`
+	RestartCount int        `json:"restart_count"`
+	LastExitCode *int       `json:"last_exit_code,omitempty"`
+	StartedAt    *time.Time `json:"started_at,omitempty"`
+}
+
+// CrashLoopReport lists the containers of an application that are crash looping
+type CrashLoopReport struct {
+	AppName      string      `json:"app_name"`
+	Threshold    int         `json:"threshold"`
+	Window       string      `json:"window"`
+	CrashLooping bool        `json:"crash_looping"`
+	ProcessTypes []string    `json:"process_types"`
+	Processes    []CrashLoop `json:"processes"`
+}
+
+// DetectCrashLoops flags the containers with at least threshold restarts whose last
+// (re)start happened within window of now. Docker only keeps a cumulative restart
+// count, so the window tells a container still restarting from one that crashed
+// long ago and has been stable since. Containers without a known start time are
+// judged on their restart count alone; a zero window disables the recency check.
+func DetectCrashLoops(report *ProcessReport, threshold int, window time.Duration, now time.Time) *CrashLoopReport {
+	result := &CrashLoopReport{
+		AppName:      report.AppName,
+		Threshold:    threshold,
+		Window:       window.String(),
+		ProcessTypes: []string{},
+		Processes:    []CrashLoop{},
+	}
+
+	seen := make(map[string]bool)
+	for _, instance := range report.Processes {
+		if instance.RestartCount == nil || *instance.RestartCount < threshold {
+			continue
+		}
+		if window > 0 && instance.StartedAt != nil && instance.StartedAt.Before(now.Add(-window)) {
+			continue
+		}
+
+		result.Processes = append(result.Processes, CrashLoop{
+			Type:         instance.Type,
+			Index:        instance.Index,
+			Status:       instance.Status,
+			RestartCount: *instance.RestartCount,
+			LastExitCode: instance.LastExitCode,
+			StartedAt:    instance.StartedAt,
+		})
+		if !seen[instance.Type] {
+			seen[instance.Type] = true
+			result.ProcessTypes = append(result.ProcessTypes, instance.Type)
+		}
+	}
+
+	result.CrashLooping = len(result.Processes) > 0
+	return result
+}
@@ -0,0 +1,63 @@
+package app_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	app "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/app/domain"
+)
+
+var _ = Describe("DetectCrashLoops", func() {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	newReport := func() *app.ProcessReport {
+		report := app.ParseProcessReport("my-app", map[string]string{
+			"Deployed":        "true",
+			"Status web 1":    "running (CID: 03ea8977f37)",
+			"Status web 2":    "running (CID: 5d6e7f8a9b0)",
+			"Status worker 1": "restarting (CID: 9b1c2d3e4f5)",
+		})
+		report.SetContainerStates(map[string]app.ContainerState{
+			"03ea8977f37e1d2c3b4a": {RestartCount: 0, StartedAt: now.Add(-48 * time.Hour)},
+			"5d6e7f8a9b0c1d2e3f4a": {RestartCount: 5, ExitCode: 137, StartedAt: now.Add(-24 * time.Hour)},
+			"9b1c2d3e4f5a6b7c8d9e": {RestartCount: 12, ExitCode: 1, StartedAt: now.Add(-30 * time.Second)},
+		})
+		return report
+	}
+
+	It("should flag a process restarting above the threshold", func() {
+		result := app.DetectCrashLoops(newReport(), 3, 15*time.Minute, now)
+
+		Expect(result.CrashLooping).To(BeTrue())
+		Expect(result.ProcessTypes).To(Equal([]string{"worker"}))
+		Expect(result.Processes).To(HaveLen(1))
+		Expect(result.Processes[0].Type).To(Equal("worker"))
+		Expect(result.Processes[0].RestartCount).To(Equal(12))
+		Expect(result.Processes[0].LastExitCode).NotTo(BeNil())
+		Expect(*result.Processes[0].LastExitCode).To(Equal(1))
+	})
+
+	It("should judge restart counts alone with a zero window", func() {
+		result := app.DetectCrashLoops(newReport(), 3, 0, now)
+
+		Expect(result.ProcessTypes).To(Equal([]string{"web", "worker"}))
+		Expect(result.Processes[0].RestartCount).To(Equal(5))
+	})
+
+	It("should not flag processes below the threshold", func() {
+		result := app.DetectCrashLoops(newReport(), 20, 15*time.Minute, now)
+
+		Expect(result.CrashLooping).To(BeFalse())
+		Expect(result.Processes).To(BeEmpty())
+	})
+
+	It("should only keep exit codes of containers that exited", func() {
+		report := newReport()
+
+		Expect(report.Processes[0].RestartCount).NotTo(BeNil())
+		Expect(report.Processes[0].LastExitCode).To(BeNil())
+		Expect(report.Processes[2].StartedAt).NotTo(BeNil())
+	})
+})
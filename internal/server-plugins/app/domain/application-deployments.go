@@ -0,0 +1,46 @@
+package app
+
+import (
+	"time"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
+)
+
+// DeploymentRecord is one past deployment of an application
+type DeploymentRecord struct {
+	ID        string    `json:"id"`
+	GitRef    string    `json:"git_ref"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+	// Duration is a Go duration string, e.g. 1m30s
+	Duration string `json:"duration"`
+}
+
+// DeploymentHistory lists the deployments of an application, most recent first
+type DeploymentHistory struct {
+	AppName     string             `json:"app_name"`
+	Deployments []DeploymentRecord `json:"deployments"`
+}
+
+// NewDeploymentHistory keeps the limit most recent deployments; a limit of 0
+// keeps them all. An app that was never deployed has an empty history.
+func NewDeploymentHistory(appName string, summaries []shared.DeploymentSummary, limit int) *DeploymentHistory {
+	if limit > 0 && len(summaries) > limit {
+		summaries = summaries[:limit]
+	}
+
+	history := &DeploymentHistory{
+		AppName:     appName,
+		Deployments: make([]DeploymentRecord, 0, len(summaries)),
+	}
+	for _, summary := range summaries {
+		history.Deployments = append(history.Deployments, DeploymentRecord{
+			ID:        summary.ID,
+			GitRef:    summary.GitRef,
+			Status:    string(summary.Status),
+			CreatedAt: summary.CreatedAt,
+			Duration:  summary.Duration.Round(time.Second).String(),
+		})
+	}
+	return history
+}
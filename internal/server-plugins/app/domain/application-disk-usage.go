@@ -0,0 +1,141 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DiskUsageScheduler is the only scheduler whose images are kept on the Dokku host
+const DiskUsageScheduler = "docker-local"
+
+// containerSizesNote explains why only images are measured: ps:inspect runs docker
+// inspect without --size and Dokku has no docker ps --size equivalent
+const containerSizesNote = "container writable layers are not measured: Dokku does not report container sizes"
+
+// DiskUsage breaks down the disk space used by an application
+type DiskUsage struct {
+	AppName   string `json:"app_name"`
+	Scheduler string `json:"scheduler"`
+	Supported bool   `json:"supported"`
+	Note      string `json:"note,omitempty"`
+	// ImageBytes sums the sizes docker reports for the app's images (tags:list). Layers
+	// shared between images are counted in each of them, so this is an upper bound.
+	ImageBytes int64            `json:"image_bytes"`
+	Images     []ImageDiskUsage `json:"images"`
+	// StorageMounts are persistent storage bind mounts; they live on the host and are not sized
+	StorageMounts []string `json:"storage_mounts"`
+}
+
+// ImageDiskUsage is the size of one of an application's images
+type ImageDiskUsage struct {
+	ID    string   `json:"id"`
+	Tags  []string `json:"tags"`
+	Bytes int64    `json:"bytes"`
+}
+
+// SystemDiskUsage aggregates the disk usage of all applications
+type SystemDiskUsage struct {
+	ImageBytes int64        `json:"image_bytes"`
+	Apps       []*DiskUsage `json:"apps"`
+	// Unsupported lists apps whose scheduler or state did not allow measuring them
+	Unsupported []string `json:"unsupported,omitempty"`
+}
+
+// dockerSizeUnits are the decimal units docker prints image sizes with
+var dockerSizeUnits = []struct {
+	suffix     string
+	multiplier float64
+}{
+	{"TB", 1e12}, {"GB", 1e9}, {"MB", 1e6}, {"kB", 1e3}, {"KB", 1e3}, {"B", 1},
+}
+
+// ParseDockerSize converts a size printed by docker (e.g. "512MB", "1.2GB", "0B") to bytes
+func ParseDockerSize(value string) (int64, error) {
+	value = strings.TrimSpace(value)
+	for _, unit := range dockerSizeUnits {
+		number, found := strings.CutSuffix(value, unit.suffix)
+		if !found {
+			continue
+		}
+		n, err := strconv.ParseFloat(strings.TrimSpace(number), 64)
+		if err != nil || n < 0 {
+			break
+		}
+		return int64(n * unit.multiplier), nil
+	}
+	return 0, fmt.Errorf("invalid docker size %q", value)
+}
+
+// ParseStorageMounts extracts host paths from a storage:report deploy mounts value ("-v host:container ...")
+func ParseStorageMounts(value string) []string {
+	mounts := []string{}
+	for _, field := range strings.Fields(value) {
+		if field == "-v" || field == "--volume" {
+			continue
+		}
+		mounts = append(mounts, field)
+	}
+	return mounts
+}
+
+// NewDiskUsage measures an application from its images, as listed by ParseAppImages
+func NewDiskUsage(appName, scheduler string, images []AppImage, mounts []string) *DiskUsage {
+	usage := &DiskUsage{
+		AppName:       appName,
+		Scheduler:     scheduler,
+		Supported:     true,
+		Note:          containerSizesNote,
+		Images:        make([]ImageDiskUsage, 0, len(images)),
+		StorageMounts: mounts,
+	}
+
+	var unsized []string
+	for _, image := range images {
+		bytes, err := ParseDockerSize(image.Size)
+		if err != nil {
+			unsized = append(unsized, image.ID)
+		}
+		usage.ImageBytes += bytes
+		usage.Images = append(usage.Images, ImageDiskUsage{ID: image.ID, Tags: image.Tags, Bytes: bytes})
+	}
+
+	if len(unsized) > 0 {
+		usage.Note += fmt.Sprintf("; the size of images %s could not be read", strings.Join(unsized, ", "))
+	}
+	return usage
+}
+
+// NewUnsupportedDiskUsage describes an application that cannot be measured
+func NewUnsupportedDiskUsage(appName, scheduler, note string) *DiskUsage {
+	return &DiskUsage{
+		AppName:       appName,
+		Scheduler:     scheduler,
+		Note:          note,
+		Images:        []ImageDiskUsage{},
+		StorageMounts: []string{},
+	}
+}
+
+// NewSystemDiskUsage sums the usage of every measured application
+func NewSystemDiskUsage(apps []*DiskUsage) *SystemDiskUsage {
+	system := &SystemDiskUsage{Apps: []*DiskUsage{}}
+	for _, usage := range apps {
+		if usage == nil {
+			continue
+		}
+		system.Apps = append(system.Apps, usage)
+		if !usage.Supported {
+			system.Unsupported = append(system.Unsupported, usage.AppName)
+			continue
+		}
+		system.ImageBytes += usage.ImageBytes
+	}
+
+	sort.Slice(system.Apps, func(i, j int) bool {
+		return system.Apps[i].ImageBytes > system.Apps[j].ImageBytes
+	})
+	sort.Strings(system.Unsupported)
+	return system
+}
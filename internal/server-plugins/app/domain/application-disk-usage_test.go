@@ -0,0 +1,25 @@
+package app_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	app "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/app/domain"
+)
+
+var _ = Describe("ParseDockerSize", func() {
+	DescribeTable("should read the decimal sizes docker prints",
+		func(value string, want int64) {
+			Expect(app.ParseDockerSize(value)).To(Equal(want))
+		},
+		Entry("bytes", "0B", int64(0)),
+		Entry("kilobytes", "23.5kB", int64(23_500)),
+		Entry("megabytes", "512MB", int64(512_000_000)),
+		Entry("gigabytes", "1.2GB", int64(1_200_000_000)),
+	)
+
+	It("should reject a value without a unit", func() {
+		_, err := app.ParseDockerSize("512")
+		Expect(err).To(HaveOccurred())
+	})
+})
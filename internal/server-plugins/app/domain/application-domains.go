@@ -0,0 +1,61 @@
+package app
+
+import "strings"
+
+// DomainsReport is the structured form of domains:report for an application.
+// Vhosts listed while their scope is disabled are kept but not routed by the proxy.
+type DomainsReport struct {
+	AppEnabled    bool     `json:"app_enabled"`
+	AppVhosts     []string `json:"app_vhosts"`
+	GlobalEnabled bool     `json:"global_enabled"`
+	GlobalVhosts  []string `json:"global_vhosts"`
+}
+
+// ParseDomainsReport builds a DomainsReport from domains:report key/value pairs
+func ParseDomainsReport(info map[string]string) *DomainsReport {
+	return &DomainsReport{
+		AppEnabled:    info["Domains app enabled"] == "true",
+		AppVhosts:     parseVhosts(info["Domains app vhosts"]),
+		GlobalEnabled: info["Domains global enabled"] == "true",
+		GlobalVhosts:  parseVhosts(info["Domains global vhosts"]),
+	}
+}
+
+// parseVhosts splits a space separated vhost list, which Dokku may print as "none"
+func parseVhosts(value string) []string {
+	if strings.TrimSpace(value) == "none" {
+		return []string{}
+	}
+	return strings.Fields(value)
+}
+
+// AppURLs are the addresses an application can be browsed at
+type AppURLs struct {
+	AppName string `json:"app_name"`
+	// Scheme is "https" when the app has a certificate, "http" otherwise
+	Scheme string   `json:"scheme"`
+	URLs   []string `json:"urls"`
+	// VhostsEnabled is false when the proxy does not route the app's domains
+	VhostsEnabled bool `json:"vhosts_enabled"`
+}
+
+// NewAppURLs composes the URLs of the vhosts the proxy routes for an application,
+// served over https when the app has a certificate
+func NewAppURLs(appName string, report *DomainsReport, https *HTTPSStatus) *AppURLs {
+	urls := &AppURLs{
+		AppName:       appName,
+		Scheme:        "http",
+		URLs:          []string{},
+		VhostsEnabled: report.AppEnabled,
+	}
+	if https != nil && https.SSLEnabled {
+		urls.Scheme = "https"
+	}
+	if !report.AppEnabled {
+		return urls
+	}
+	for _, vhost := range report.AppVhosts {
+		urls.URLs = append(urls.URLs, urls.Scheme+"://"+vhost)
+	}
+	return urls
+}
@@ -0,0 +1,78 @@
+package app
+
+import (
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AppSnapshot is the state of an application that drift detection compares
+// between two runs: the process scale, the domains and the deployed commit
+type AppSnapshot struct {
+	AppName     string         `json:"app_name"`
+	Formation   map[string]int `json:"formation"`
+	Domains     []string       `json:"domains"`
+	DeployedSHA string         `json:"deployed_sha"`
+	TakenAt     time.Time      `json:"taken_at"`
+}
+
+// DriftChange is one setting that differs between two snapshots of an app
+type DriftChange struct {
+	// Field is "scale.<process type>", "domains" or "deployed_sha"
+	Field  string `json:"field"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+// NewAppSnapshot captures the scale and domains of application and the commit it runs
+func NewAppSnapshot(application *Application, deployedSHA string, takenAt time.Time) *AppSnapshot {
+	domains := application.GetDomains()
+	slices.Sort(domains)
+	return &AppSnapshot{
+		AppName:     application.Name().Value(),
+		Formation:   application.Formation(),
+		Domains:     domains,
+		DeployedSHA: deployedSHA,
+		TakenAt:     takenAt,
+	}
+}
+
+// Diff lists what changed from s to next; an empty list means no drift.
+// Process types missing from one side are compared as scaled to 0.
+func (s *AppSnapshot) Diff(next *AppSnapshot) []DriftChange {
+	changes := []DriftChange{}
+
+	processTypes := slices.Collect(maps.Keys(s.Formation))
+	for processType := range next.Formation {
+		if _, ok := s.Formation[processType]; !ok {
+			processTypes = append(processTypes, processType)
+		}
+	}
+	slices.Sort(processTypes)
+	for _, processType := range processTypes {
+		before, after := s.Formation[processType], next.Formation[processType]
+		if before != after {
+			changes = append(changes, DriftChange{
+				Field:  "scale." + processType,
+				Before: strconv.Itoa(before),
+				After:  strconv.Itoa(after),
+			})
+		}
+	}
+
+	if !slices.Equal(s.Domains, next.Domains) {
+		changes = append(changes, DriftChange{
+			Field:  "domains",
+			Before: strings.Join(s.Domains, ","),
+			After:  strings.Join(next.Domains, ","),
+		})
+	}
+
+	if s.DeployedSHA != next.DeployedSHA {
+		changes = append(changes, DriftChange{Field: "deployed_sha", Before: s.DeployedSHA, After: next.DeployedSHA})
+	}
+
+	return changes
+}
@@ -0,0 +1,41 @@
+package app
+
+import "sort"
+
+// ConfigSource tells where an effective environment variable comes from
+type ConfigSource string
+
+const (
+	ConfigSourceGlobal ConfigSource = "global"
+	ConfigSourceApp    ConfigSource = "app"
+)
+
+// EffectiveConfigEntry is one variable of the environment an application runs with
+type EffectiveConfigEntry struct {
+	Key    string       `json:"key"`
+	Value  string       `json:"value"`
+	Source ConfigSource `json:"source"`
+	// OverridesGlobal is set when the app value replaces a global one
+	OverridesGlobal bool `json:"overrides_global,omitempty"`
+}
+
+// MergeEffectiveConfig merges the global environment with the app's one, app
+// values taking precedence as they do at runtime. Entries are sorted by key.
+func MergeEffectiveConfig(global map[string]string, appConfig map[string]string) []EffectiveConfigEntry {
+	entries := make([]EffectiveConfigEntry, 0, len(global)+len(appConfig))
+	for key, value := range global {
+		if _, overridden := appConfig[key]; overridden {
+			continue
+		}
+		entries = append(entries, EffectiveConfigEntry{Key: key, Value: value, Source: ConfigSourceGlobal})
+	}
+	for key, value := range appConfig {
+		_, overrides := global[key]
+		entries = append(entries, EffectiveConfigEntry{Key: key, Value: value, Source: ConfigSourceApp, OverridesGlobal: overrides})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Key < entries[j].Key
+	})
+	return entries
+}
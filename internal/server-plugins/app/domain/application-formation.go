@@ -0,0 +1,96 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/shared/process"
+)
+
+// FormationChange describes the scale change of one process type
+type FormationChange struct {
+	ProcessType string `json:"process_type"`
+	From        int    `json:"from"`
+	To          int    `json:"to"`
+}
+
+// FormationReconciliation reports the outcome of reconciling a formation;
+// InSync is true when the formation already matched and nothing was scaled
+type FormationReconciliation struct {
+	AppName string            `json:"app_name"`
+	Changed []FormationChange `json:"changed"`
+	InSync  bool              `json:"in_sync"`
+}
+
+// DiffFormation lists the process types whose current scale differs from the
+// desired one, sorted by process type. Process types absent from desired are
+// left as they are.
+func (a *Application) DiffFormation(desired map[process.ProcessType]int) []FormationChange {
+	changes := make([]FormationChange, 0)
+	for processType, scale := range desired {
+		if current := a.GetProcessScale(processType); current != scale {
+			changes = append(changes, FormationChange{
+				ProcessType: processType.String(),
+				From:        current,
+				To:          scale,
+			})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].ProcessType < changes[j].ProcessType })
+	return changes
+}
+
+// ScaleFormation sets the scale of every given process type and records them
+// in a single FormationScaledEvent. It returns one change per process type,
+// sorted by process type, including those already at the requested scale.
+func (a *Application) ScaleFormation(scales map[process.ProcessType]int) ([]FormationChange, error) {
+	changes := make([]FormationChange, 0, len(scales))
+	applied := make(map[string]int, len(scales))
+	for processType, scale := range scales {
+		if _, err := process.NewProcessScale(scale); err != nil {
+			return nil, fmt.Errorf("unable to scale %s to %d: %w", processType, scale, err)
+		}
+		changes = append(changes, FormationChange{
+			ProcessType: processType.String(),
+			From:        a.GetProcessScale(processType),
+			To:          scale,
+		})
+		applied[processType.String()] = scale
+	}
+	if len(changes) == 0 {
+		return changes, nil
+	}
+
+	// Scales were validated above, so the formation is never left half updated
+	for processType, scale := range scales {
+		if proc, exists := a.configuration.processes[processType]; exists {
+			if err := proc.SetScale(scale); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		proc, err := process.NewProcessForScaling(processType, scale)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create process: %w", err)
+		}
+		a.configuration.processes[processType] = proc
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].ProcessType < changes[j].ProcessType })
+
+	a.updatedAt = time.Now()
+	a.addEvent(NewFormationScaledEvent(a.name.Value(), applied, time.Now()))
+	return changes, nil
+}
+
+// ReconcileFormation scales only the process types that differ from desired
+// and returns the applied changes; nothing is recorded when they already match
+func (a *Application) ReconcileFormation(desired map[process.ProcessType]int) ([]FormationChange, error) {
+	changes := a.DiffFormation(desired)
+	for _, change := range changes {
+		if err := a.Scale(process.ProcessType(change.ProcessType), change.To); err != nil {
+			return nil, fmt.Errorf("unable to scale %s to %d: %w", change.ProcessType, change.To, err)
+		}
+	}
+	return changes, nil
+}
@@ -0,0 +1,66 @@
+package app
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// gitSyncDeploySource is the apps:report deploy source of apps deployed with git:sync
+const gitSyncDeploySource = "git-sync"
+
+// shortSHALength matches the abbreviated commit hashes printed by git
+const shortSHALength = 7
+
+// GitInfo describes what an application runs from git: the deploy branch,
+// the remote it was last synced from and the deployed commit
+type GitInfo struct {
+	AppName      string `json:"app_name"`
+	Deployed     bool   `json:"deployed"`
+	DeployBranch string `json:"deploy_branch"`
+	// Remote and Ref are only known for apps deployed with git:sync
+	Remote        string     `json:"remote,omitempty"`
+	Ref           string     `json:"ref,omitempty"`
+	SHA           string     `json:"sha,omitempty"`
+	ShortSHA      string     `json:"short_sha,omitempty"`
+	SourceImage   string     `json:"source_image,omitempty"`
+	LastUpdatedAt *time.Time `json:"last_updated_at,omitempty"`
+}
+
+// ParseGitInfo builds a GitInfo from git:report key/value pairs, completed with
+// the deploy source recorded in apps:report (nil when unavailable). The app's
+// deploy branch falls back to the global one when it is not set.
+func ParseGitInfo(appName string, gitReport map[string]string, appsReport *ApplicationInfo) *GitInfo {
+	info := &GitInfo{
+		AppName:      appName,
+		DeployBranch: gitReport["Git deploy branch"],
+		SHA:          gitReport["Git sha"],
+		SourceImage:  gitReport["Git source image"],
+	}
+	if info.DeployBranch == "" {
+		info.DeployBranch = gitReport["Git global deploy branch"]
+	}
+
+	info.Deployed = info.SHA != "" || info.SourceImage != ""
+	info.ShortSHA = info.SHA
+	if len(info.ShortSHA) > shortSHALength {
+		info.ShortSHA = info.ShortSHA[:shortSHALength]
+	}
+
+	if seconds, err := strconv.ParseInt(gitReport["Git last updated at"], 10, 64); err == nil && seconds > 0 {
+		updatedAt := time.Unix(seconds, 0).UTC()
+		info.LastUpdatedAt = &updatedAt
+	}
+
+	// git:sync records "<remote>#<ref>" as the deploy source metadata
+	if appsReport != nil && appsReport.DeploySource == gitSyncDeploySource {
+		metadata := appsReport.DeploySourceMetadata
+		if remote, ref, found := strings.Cut(metadata, "#"); found {
+			info.Remote, info.Ref = remote, ref
+		} else {
+			info.Remote = metadata
+		}
+	}
+
+	return info
+}
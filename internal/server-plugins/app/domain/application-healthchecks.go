@@ -0,0 +1,253 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// healthchecksKey is the app.json section holding the healthchecks of each process type
+const healthchecksKey = "healthchecks"
+
+// healthcheckTypes lists the check types Dokku runs; an empty type is a startup check
+var healthcheckTypes = []string{"startup", "liveness", "readiness"}
+
+// ErrInvalidHealthchecks is returned for a healthchecks section Dokku would reject or misread
+var ErrInvalidHealthchecks = errors.New("invalid healthchecks")
+
+// Healthcheck is one check of the app.json healthchecks section. A check probes
+// an HTTP path, runs a command, or waits for the container to stay up.
+type Healthcheck struct {
+	Type        string              `json:"type,omitempty"`
+	Name        string              `json:"name,omitempty"`
+	Description string              `json:"description,omitempty"`
+	Path        string              `json:"path,omitempty"`
+	Scheme      string              `json:"scheme,omitempty"`
+	Port        int                 `json:"port,omitempty"`
+	HTTPHeaders []HealthcheckHeader `json:"httpHeaders,omitempty"`
+	Content     string              `json:"content,omitempty"`
+	Command     []string            `json:"command,omitempty"`
+	Uptime      int                 `json:"uptime,omitempty"`
+	Listening   bool                `json:"listening,omitempty"`
+	// Attempts is the number of tries before the check fails
+	Attempts     int             `json:"attempts,omitempty"`
+	Timeout      int             `json:"timeout,omitempty"`
+	Wait         int             `json:"wait,omitempty"`
+	InitialDelay int             `json:"initialDelay,omitempty"`
+	Warn         bool            `json:"warn,omitempty"`
+	OnFailure    json.RawMessage `json:"onFailure,omitempty"`
+}
+
+// HealthcheckHeader is an HTTP header sent with a path check
+type HealthcheckHeader struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// AppHealthchecks maps process types to their checks
+type AppHealthchecks map[string][]Healthcheck
+
+// HealthchecksReport combines the healthchecks declared in app.json with the
+// zero-downtime checks settings deciding whether Dokku runs them
+type HealthchecksReport struct {
+	AppName           string          `json:"app_name"`
+	Healthchecks      AppHealthchecks `json:"healthchecks"`
+	DisabledProcesses []string        `json:"disabled_processes"`
+	SkippedProcesses  []string        `json:"skipped_processes"`
+	// Inactive lists the process types with declared checks that Dokku disables or skips
+	Inactive []string `json:"inactive,omitempty"`
+}
+
+// NewHealthchecksReport matches the declared checks against the checks settings of the app
+func NewHealthchecksReport(settings *ChecksSettings, checks AppHealthchecks) *HealthchecksReport {
+	report := &HealthchecksReport{
+		AppName:           settings.AppName,
+		Healthchecks:      checks,
+		DisabledProcesses: settings.DisabledProcesses,
+		SkippedProcesses:  settings.SkippedProcesses,
+	}
+
+	off := append(slices.Clone(settings.DisabledProcesses), settings.SkippedProcesses...)
+	for processType := range checks {
+		if slices.Contains(off, processType) || slices.Contains(off, "_all_") {
+			report.Inactive = append(report.Inactive, processType)
+		}
+	}
+	sort.Strings(report.Inactive)
+	return report
+}
+
+// ParseHealthchecks reads and validates the healthchecks section of app.json
+// content. An app.json without the section has no healthchecks.
+func ParseHealthchecks(appJSON string) (AppHealthchecks, error) {
+	var manifest map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(appJSON), &manifest); err != nil {
+		return nil, fmt.Errorf("%w: app.json is not valid JSON: %v", ErrInvalidHealthchecks, err)
+	}
+
+	raw, ok := manifest[healthchecksKey]
+	if !ok {
+		return AppHealthchecks{}, nil
+	}
+	return DecodeHealthchecks(raw)
+}
+
+// DecodeHealthchecks decodes and validates a healthchecks section. Unknown
+// check fields are rejected so a misspelled setting does not go unnoticed.
+func DecodeHealthchecks(raw json.RawMessage) (AppHealthchecks, error) {
+	checks, err := decodeHealthchecks(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%w: healthchecks must map process types to a list of checks: %v", ErrInvalidHealthchecks, err)
+	}
+
+	if problems := checks.Validate(); len(problems) > 0 {
+		messages := make([]string, 0, len(problems))
+		for _, problem := range problems {
+			messages = append(messages, fmt.Sprintf("%s: %s", problem.Field, problem.Message))
+		}
+		return nil, fmt.Errorf("%w: %s", ErrInvalidHealthchecks, strings.Join(messages, "; "))
+	}
+	return checks, nil
+}
+
+// decodeHealthchecks decodes a healthchecks section, rejecting unknown check fields
+func decodeHealthchecks(raw json.RawMessage) (AppHealthchecks, error) {
+	checks := AppHealthchecks{}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&checks); err != nil {
+		return nil, err
+	}
+	return checks, nil
+}
+
+// Validate returns the problems of the checks, sorted by process type
+func (h AppHealthchecks) Validate() []ValidationError {
+	problems := []ValidationError{}
+	add := func(field, message, code string) {
+		problems = append(problems, ValidationError{Field: field, Message: message, Code: code})
+	}
+
+	types := make([]string, 0, len(h))
+	for processType := range h {
+		types = append(types, processType)
+	}
+	sort.Strings(types)
+
+	for _, processType := range types {
+		if !procfileTypePattern.MatchString(processType) {
+			add("app.json:healthchecks."+processType, fmt.Sprintf("Process type '%s' must match %s", processType, procfileTypePattern.String()), "INVALID_PROCESS_TYPE")
+			continue
+		}
+
+		for i, check := range h[processType] {
+			field := fmt.Sprintf("app.json:healthchecks.%s[%d]", processType, i)
+			if check.Type != "" && !slices.Contains(healthcheckTypes, check.Type) {
+				add(field, fmt.Sprintf("Type '%s' must be one of %s", check.Type, strings.Join(healthcheckTypes, ", ")), "INVALID_HEALTHCHECK_TYPE")
+			}
+			if check.Path == "" && len(check.Command) == 0 && check.Uptime == 0 && !check.Listening {
+				add(field, "A check needs a path, a command, an uptime or listening", "EMPTY_HEALTHCHECK")
+			}
+			if check.Path != "" && !strings.HasPrefix(check.Path, "/") {
+				add(field, fmt.Sprintf("Path '%s' must start with /", check.Path), "INVALID_HEALTHCHECK_PATH")
+			}
+			if check.Port < 0 || check.Port > 65535 {
+				add(field, fmt.Sprintf("Port %d must be between 1 and 65535", check.Port), "INVALID_HEALTHCHECK_PORT")
+			}
+			if check.Attempts < 0 || check.Timeout < 0 || check.Wait < 0 || check.InitialDelay < 0 || check.Uptime < 0 {
+				add(field, "attempts, timeout, wait, initialDelay and uptime cannot be negative", "INVALID_HEALTHCHECK_DURATION")
+			}
+		}
+	}
+	return problems
+}
+
+// SetHealthchecks returns appJSON with its healthchecks section replaced by checks,
+// or removed when checks is empty. The other keys are kept in their original order.
+func SetHealthchecks(appJSON string, checks AppHealthchecks) (string, error) {
+	if problems := checks.Validate(); len(problems) > 0 {
+		return "", fmt.Errorf("%w: %s: %s", ErrInvalidHealthchecks, problems[0].Field, problems[0].Message)
+	}
+	if strings.TrimSpace(appJSON) == "" {
+		appJSON = "{}"
+	}
+
+	keys, err := objectKeys(appJSON)
+	if err != nil {
+		return "", fmt.Errorf("%w: app.json is not valid JSON: %v", ErrInvalidHealthchecks, err)
+	}
+	var manifest map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(appJSON), &manifest); err != nil {
+		return "", fmt.Errorf("%w: app.json is not valid JSON: %v", ErrInvalidHealthchecks, err)
+	}
+
+	if len(checks) == 0 {
+		delete(manifest, healthchecksKey)
+	} else {
+		section, err := json.Marshal(checks)
+		if err != nil {
+			return "", fmt.Errorf("failed to encode healthchecks: %w", err)
+		}
+		if _, ok := manifest[healthchecksKey]; !ok {
+			keys = append(keys, healthchecksKey)
+		}
+		manifest[healthchecksKey] = section
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("{")
+	written := 0
+	for _, key := range keys {
+		value, ok := manifest[key]
+		if !ok {
+			continue
+		}
+		if written > 0 {
+			buf.WriteString(",")
+		}
+		name, _ := json.Marshal(key)
+		buf.Write(name)
+		buf.WriteString(":")
+		buf.Write(value)
+		written++
+	}
+	buf.WriteString("}")
+
+	var compact, out bytes.Buffer
+	if err := json.Compact(&compact, buf.Bytes()); err != nil {
+		return "", fmt.Errorf("failed to format app.json: %w", err)
+	}
+	if err := json.Indent(&out, compact.Bytes(), "", "  "); err != nil {
+		return "", fmt.Errorf("failed to format app.json: %w", err)
+	}
+	return out.String() + "\n", nil
+}
+
+// objectKeys returns the top-level keys of a JSON object in document order
+func objectKeys(document string) ([]string, error) {
+	decoder := json.NewDecoder(strings.NewReader(document))
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return nil, fmt.Errorf("expected a JSON object")
+	}
+
+	keys := []string{}
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, _ := token.(string)
+		if !slices.Contains(keys, key) {
+			keys = append(keys, key)
+		}
+		var skip json.RawMessage
+		if err := decoder.Decode(&skip); err != nil {
+			return nil, err
+		}
+	}
+	return keys, nil
+}
@@ -0,0 +1,115 @@
+package app_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	app "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/app/domain"
+)
+
+var _ = Describe("Healthchecks", func() {
+	Describe("ParseHealthchecks", func() {
+		It("should read the checks of each process type", func() {
+			checks, err := app.ParseHealthchecks(`{
+				"name": "my-app",
+				"healthchecks": {
+					"web": [{"type": "startup", "name": "web check", "path": "/health", "timeout": 5, "attempts": 3}],
+					"worker": [{"type": "liveness", "command": ["/app/check.sh"]}]
+				}
+			}`)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(checks).To(HaveLen(2))
+			Expect(checks["web"][0].Path).To(Equal("/health"))
+			Expect(checks["web"][0].Timeout).To(Equal(5))
+			Expect(checks["web"][0].Attempts).To(Equal(3))
+			Expect(checks["worker"][0].Command).To(Equal([]string{"/app/check.sh"}))
+		})
+
+		It("should return no checks when app.json has no healthchecks section", func() {
+			checks, err := app.ParseHealthchecks(`{"name": "my-app"}`)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(checks).To(BeEmpty())
+		})
+
+		DescribeTable("should reject malformed definitions",
+			func(appJSON, message string) {
+				_, err := app.ParseHealthchecks(appJSON)
+
+				Expect(err).To(MatchError(app.ErrInvalidHealthchecks))
+				Expect(err.Error()).To(ContainSubstring(message))
+			},
+			Entry("invalid JSON", `{"healthchecks": `, "not valid JSON"),
+			Entry("checks not in a list", `{"healthchecks": {"web": {"path": "/health"}}}`, "list of checks"),
+			Entry("misspelled field", `{"healthchecks": {"web": [{"path": "/health", "retries": 3}]}}`, `unknown field "retries"`),
+			Entry("unknown type", `{"healthchecks": {"web": [{"type": "warmup", "path": "/health"}]}}`, "Type 'warmup'"),
+			Entry("nothing to probe", `{"healthchecks": {"web": [{"type": "startup", "timeout": 5}]}}`, "needs a path"),
+			Entry("relative path", `{"healthchecks": {"web": [{"path": "health"}]}}`, "must start with /"),
+			Entry("negative timeout", `{"healthchecks": {"web": [{"path": "/health", "timeout": -5}]}}`, "cannot be negative"),
+			Entry("invalid process type", `{"healthchecks": {"Web": [{"path": "/health"}]}}`, "Process type 'Web'"),
+		)
+	})
+
+	Describe("SetHealthchecks", func() {
+		checks := app.AppHealthchecks{"web": {{Type: "startup", Path: "/ready", Timeout: 10, Attempts: 5}}}
+
+		It("should replace the section and keep the other keys in order", func() {
+			updated, err := app.SetHealthchecks(`{"name": "my-app", "healthchecks": {"web": [{"path": "/health"}]}, "scripts": {}}`, checks)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(updated).To(Equal(`{
+  "name": "my-app",
+  "healthchecks": {
+    "web": [
+      {
+        "type": "startup",
+        "path": "/ready",
+        "attempts": 5,
+        "timeout": 10
+      }
+    ]
+  },
+  "scripts": {}
+}
+`))
+		})
+
+		It("should add the section to an empty app.json", func() {
+			updated, err := app.SetHealthchecks("", checks)
+
+			Expect(err).NotTo(HaveOccurred())
+			parsed, err := app.ParseHealthchecks(updated)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed).To(Equal(checks))
+		})
+
+		It("should remove the section when there are no checks", func() {
+			updated, err := app.SetHealthchecks(`{"name": "my-app", "healthchecks": {"web": [{"path": "/health"}]}}`, app.AppHealthchecks{})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(updated).To(Equal("{\n  \"name\": \"my-app\"\n}\n"))
+		})
+
+		It("should refuse invalid checks", func() {
+			_, err := app.SetHealthchecks(`{}`, app.AppHealthchecks{"web": {{Path: "health"}}})
+
+			Expect(err).To(MatchError(app.ErrInvalidHealthchecks))
+		})
+	})
+
+	Describe("NewHealthchecksReport", func() {
+		It("should flag the checks Dokku skips or disables", func() {
+			settings := &app.ChecksSettings{AppName: "my-app", DisabledProcesses: []string{"worker"}, SkippedProcesses: []string{}}
+			checks := app.AppHealthchecks{
+				"web":    {{Path: "/health"}},
+				"worker": {{Uptime: 10}},
+			}
+
+			report := app.NewHealthchecksReport(settings, checks)
+
+			Expect(report.AppName).To(Equal("my-app"))
+			Expect(report.Inactive).To(Equal([]string{"worker"}))
+		})
+	})
+})
@@ -0,0 +1,121 @@
+package app
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// CurrentImageTag is the tag Dokku moves to the image of the running release on each deploy
+const CurrentImageTag = "latest"
+
+// AppImage is a docker image built for an application, with every tag pointing at it
+type AppImage struct {
+	ID      string   `json:"id"`
+	Tags    []string `json:"tags"`
+	Created string   `json:"created"`
+	Size    string   `json:"size"`
+	Current bool     `json:"current"`
+}
+
+// AppImages lists the images of an application, newest first
+type AppImages struct {
+	AppName   string     `json:"app_name"`
+	Scheduler string     `json:"scheduler"`
+	Supported bool       `json:"supported"`
+	Note      string     `json:"note,omitempty"`
+	Images    []AppImage `json:"images"`
+}
+
+// ImagePruneResult reports which images of an application were kept and which were removed
+type ImagePruneResult struct {
+	AppName      string     `json:"app_name"`
+	Scheduler    string     `json:"scheduler"`
+	Supported    bool       `json:"supported"`
+	Note         string     `json:"note,omitempty"`
+	KeepPrevious int        `json:"keep_previous"`
+	Kept         []AppImage `json:"kept"`
+	Removed      []AppImage `json:"removed"`
+	// Failed maps the tags that could not be removed to the reason
+	Failed map[string]string `json:"failed,omitempty"`
+	// NotRemovable lists the untagged images due for removal: tags:destroy needs a
+	// tag, so they stay on disk until a docker image prune on the host
+	NotRemovable []AppImage `json:"not_removable,omitempty"`
+}
+
+// imageColumnSeparator splits the columns of a docker images table, whose values may contain single spaces
+var imageColumnSeparator = regexp.MustCompile(`\s{2,}`)
+
+// ParseAppImages reads the docker images table printed by tags:list
+// (REPOSITORY, TAG, IMAGE ID, CREATED, SIZE). Docker lists images newest first;
+// rows sharing an image ID are merged into one image carrying every tag.
+// The image tagged latest is the current one, or the newest when none is.
+func ParseAppImages(output string) []AppImage {
+	images := []AppImage{}
+	index := make(map[string]int)
+
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "=====>") || strings.HasPrefix(line, "----->") ||
+			strings.HasPrefix(line, "REPOSITORY") {
+			continue
+		}
+
+		columns := imageColumnSeparator.Split(line, -1)
+		if len(columns) < 5 {
+			continue
+		}
+		tag, id := columns[1], columns[2]
+
+		i, seen := index[id]
+		if !seen {
+			i = len(images)
+			index[id] = i
+			images = append(images, AppImage{ID: id, Tags: []string{}, Created: columns[3], Size: columns[4]})
+		}
+		if tag != "<none>" {
+			images[i].Tags = append(images[i].Tags, tag)
+		}
+	}
+
+	current := 0
+	for i, image := range images {
+		if slices.Contains(image.Tags, CurrentImageTag) {
+			current = i
+			break
+		}
+	}
+	if len(images) > 0 {
+		images[current].Current = true
+	}
+	return images
+}
+
+// PlanImagePrune splits images between the ones to keep, the current image and the
+// keepPrevious newest other ones, and the ones to remove
+func PlanImagePrune(images []AppImage, keepPrevious int) (kept, removed []AppImage) {
+	kept, removed = []AppImage{}, []AppImage{}
+	previous := 0
+	for _, image := range images {
+		switch {
+		case image.Current:
+			kept = append(kept, image)
+		case previous < keepPrevious:
+			previous++
+			kept = append(kept, image)
+		default:
+			removed = append(removed, image)
+		}
+	}
+	return kept, removed
+}
+
+// NewUnsupportedAppImages describes an application whose images cannot be listed
+func NewUnsupportedAppImages(appName, scheduler, note string) *AppImages {
+	return &AppImages{
+		AppName:   appName,
+		Scheduler: scheduler,
+		Note:      note,
+		Images:    []AppImage{},
+	}
+}
@@ -0,0 +1,61 @@
+package app_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	app "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/app/domain"
+)
+
+var _ = Describe("ParseAppImages", func() {
+	It("should merge the tags of an image and flag the latest one as current", func() {
+		images := app.ParseAppImages(`=====> Image tags for dokku/my-app
+REPOSITORY    TAG       IMAGE ID       CREATED              SIZE
+dokku/my-app  v2        9f9f9f9f9f9f   About a minute ago   512MB
+dokku/my-app  latest    1a1a1a1a1a1a   2 hours ago          510MB
+dokku/my-app  v1        1a1a1a1a1a1a   2 hours ago          510MB
+dokku/my-app  <none>    2b2b2b2b2b2b   3 days ago           505MB
+`)
+
+		Expect(images).To(HaveLen(3))
+		Expect(images[0].Current).To(BeFalse())
+		Expect(images[1].ID).To(Equal("1a1a1a1a1a1a"))
+		Expect(images[1].Tags).To(Equal([]string{"latest", "v1"}))
+		Expect(images[1].Created).To(Equal("2 hours ago"))
+		Expect(images[1].Current).To(BeTrue())
+		Expect(images[2].Tags).To(BeEmpty())
+	})
+
+	It("should treat the newest image as current when none is tagged latest", func() {
+		images := app.ParseAppImages("dokku/my-app  v2  9f9f9f9f9f9f  1 day ago  512MB\ndokku/my-app  v1  1a1a1a1a1a1a  2 days ago  510MB\n")
+
+		Expect(images).To(HaveLen(2))
+		Expect(images[0].Current).To(BeTrue())
+		Expect(images[1].Current).To(BeFalse())
+	})
+})
+
+var _ = Describe("PlanImagePrune", func() {
+	images := []app.AppImage{
+		{ID: "new", Tags: []string{"v3"}},
+		{ID: "current", Tags: []string{"latest"}, Current: true},
+		{ID: "old", Tags: []string{"v1"}},
+	}
+
+	It("should always keep the current image", func() {
+		kept, removed := app.PlanImagePrune(images, 0)
+
+		Expect(kept).To(HaveLen(1))
+		Expect(kept[0].ID).To(Equal("current"))
+		Expect(removed).To(HaveLen(2))
+	})
+
+	It("should keep the newest previous images", func() {
+		kept, removed := app.PlanImagePrune(images, 1)
+
+		Expect(kept).To(HaveLen(2))
+		Expect(kept[0].ID).To(Equal("new"))
+		Expect(removed).To(HaveLen(1))
+		Expect(removed[0].ID).To(Equal("old"))
+	})
+})
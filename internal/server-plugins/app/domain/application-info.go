@@ -0,0 +1,15 @@
+package app
+
+import "time"
+
+// ApplicationInfo is the typed form of apps:report for an application. The report
+// does not tell whether the app is deployed, its restart policy or its git sha:
+// those come from ps:report (ProcessReport) and git:report (GitInfo).
+type ApplicationInfo struct {
+	AppName              string     `json:"app_name"`
+	CreatedAt            *time.Time `json:"created_at,omitempty"`
+	DeploySource         string     `json:"deploy_source,omitempty"`
+	DeploySourceMetadata string     `json:"deploy_source_metadata,omitempty"`
+	Dir                  string     `json:"dir,omitempty"`
+	Locked               bool       `json:"locked"`
+}
@@ -0,0 +1,75 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrInvalidLogTime is returned when a since or until bound is neither RFC3339 nor a duration
+var ErrInvalidLogTime = errors.New("invalid log time")
+
+// RuntimeLogs are the lines of an application's logs kept by a time window
+type RuntimeLogs struct {
+	Logs string
+	// Truncated is set when the window may reach back before the oldest retrieved
+	// line: dokku logs only returns the last lines, so older matches are missing
+	Truncated bool
+}
+
+// LogTimeWindow bounds log lines by their timestamp; a zero bound leaves that side open
+type LogTimeWindow struct {
+	Since time.Time
+	Until time.Time
+}
+
+// NewLogTimeWindow parses since and until bounds. Each is either an RFC3339 timestamp
+// or a duration relative to now (e.g. "1h" means one hour ago); empty values are open.
+func NewLogTimeWindow(since, until string, now time.Time) (LogTimeWindow, error) {
+	var window LogTimeWindow
+	var err error
+	if window.Since, err = parseLogTime("since", since, now); err != nil {
+		return LogTimeWindow{}, err
+	}
+	if window.Until, err = parseLogTime("until", until, now); err != nil {
+		return LogTimeWindow{}, err
+	}
+	if !window.Since.IsZero() && !window.Until.IsZero() && window.Since.After(window.Until) {
+		return LogTimeWindow{}, fmt.Errorf("%w: since (%s) is after until (%s)", ErrInvalidLogTime,
+			window.Since.Format(time.RFC3339), window.Until.Format(time.RFC3339))
+	}
+	return window, nil
+}
+
+// parseLogTime reads one bound as an RFC3339 timestamp or a non-negative duration before now
+func parseLogTime(field, value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return time.Time{}, fmt.Errorf("%w: %s '%s' must be an RFC3339 timestamp or a duration such as 30m or 2h", ErrInvalidLogTime, field, value)
+	}
+	return now.Add(-d), nil
+}
+
+// IsZero reports whether the window has no bound
+func (w LogTimeWindow) IsZero() bool {
+	return w.Since.IsZero() && w.Until.IsZero()
+}
+
+// Contains reports whether a timestamp falls within the window, bounds included
+func (w LogTimeWindow) Contains(t time.Time) bool {
+	if !w.Since.IsZero() && t.Before(w.Since) {
+		return false
+	}
+	if !w.Until.IsZero() && t.After(w.Until) {
+		return false
+	}
+	return true
+}
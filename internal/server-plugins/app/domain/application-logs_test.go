@@ -0,0 +1,54 @@
+package app_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	app "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/app/domain"
+)
+
+var _ = Describe("LogTimeWindow", func() {
+	now := time.Date(2025, 12, 13, 12, 0, 0, 0, time.UTC)
+
+	It("should resolve relative bounds against now", func() {
+		window, err := app.NewLogTimeWindow("1h", "15m", now)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(window.Since).To(Equal(now.Add(-time.Hour)))
+		Expect(window.Until).To(Equal(now.Add(-15 * time.Minute)))
+		Expect(window.Contains(now.Add(-30 * time.Minute))).To(BeTrue())
+		Expect(window.Contains(now.Add(-2 * time.Hour))).To(BeFalse())
+		Expect(window.Contains(now.Add(-5 * time.Minute))).To(BeFalse())
+	})
+
+	It("should read absolute RFC3339 bounds", func() {
+		window, err := app.NewLogTimeWindow("2025-12-13T10:00:00Z", "2025-12-13T11:00:00+01:00", now)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(window.Since).To(BeTemporally("==", time.Date(2025, 12, 13, 10, 0, 0, 0, time.UTC)))
+		Expect(window.Until).To(BeTemporally("==", time.Date(2025, 12, 13, 10, 0, 0, 0, time.UTC)))
+		Expect(window.Contains(window.Since)).To(BeTrue())
+	})
+
+	It("should leave empty bounds open", func() {
+		window, err := app.NewLogTimeWindow("", "", now)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(window.IsZero()).To(BeTrue())
+		Expect(window.Contains(time.Time{})).To(BeTrue())
+	})
+
+	DescribeTable("should reject invalid bounds",
+		func(since, until string) {
+			_, err := app.NewLogTimeWindow(since, until, now)
+
+			Expect(err).To(MatchError(app.ErrInvalidLogTime))
+		},
+		Entry("unparseable since", "yesterday", ""),
+		Entry("negative duration", "-1h", ""),
+		Entry("date without time", "", "2025-12-13"),
+		Entry("since after until", "10m", "1h"),
+	)
+})
@@ -0,0 +1,238 @@
+package app
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
+)
+
+// AppsBackupVersion is the format version written in backups
+const AppsBackupVersion = 1
+
+// Sensitive value handling in backups
+const (
+	SensitiveValuesMasked    = "masked"
+	SensitiveValuesEncrypted = "encrypted"
+)
+
+// encryptedValuePrefix marks a sealed value in a backup
+const encryptedValuePrefix = "enc:v1:"
+
+// backupKeyIterations is the PBKDF2 work factor used to derive the backup key from the passphrase
+const backupKeyIterations = 600_000
+
+var (
+	// ErrInvalidBackup is returned when a backup cannot be read or restored
+	ErrInvalidBackup = errors.New("invalid backup")
+	// ErrIncompleteBackup is returned when an application could not be read entirely during an export
+	ErrIncompleteBackup = errors.New("applications could not be exported")
+	// ErrEncryptionKeyRequired is returned when encrypted values are written or read without a passphrase
+	ErrEncryptionKeyRequired = errors.New("encryption key required")
+)
+
+// AppManifest is the restorable configuration of one application
+type AppManifest struct {
+	Name        string            `json:"name"`
+	Environment map[string]string `json:"environment"`
+	Formation   map[string]int    `json:"formation"`
+	// Nginx only lists the properties overriding Dokku's defaults
+	Nginx map[string]string `json:"nginx,omitempty"`
+}
+
+// NewAppManifest captures an application's configuration, with the environment
+// as read from config:show. Nginx settings are optional since apps behind another
+// proxy have none.
+func NewAppManifest(app *Application, environment map[string]string, nginx map[NginxProperty]string) AppManifest {
+	manifest := AppManifest{
+		Name:        app.Name().Value(),
+		Environment: environment,
+		Formation:   app.Formation(),
+	}
+	for property, value := range nginx {
+		if value == "" {
+			continue
+		}
+		if manifest.Nginx == nil {
+			manifest.Nginx = make(map[string]string)
+		}
+		manifest.Nginx[string(property)] = value
+	}
+	return manifest
+}
+
+// BackupEncryption holds the parameters needed to derive the key of an encrypted backup
+type BackupEncryption struct {
+	Salt       string `json:"salt"`
+	Iterations int    `json:"iterations"`
+}
+
+// AppsBackup combines the manifests of several applications
+type AppsBackup struct {
+	Version         int               `json:"version"`
+	CreatedAt       time.Time         `json:"created_at"`
+	SensitiveValues string            `json:"sensitive_values"`
+	Encryption      *BackupEncryption `json:"encryption,omitempty"`
+	Apps            []AppManifest     `json:"apps"`
+}
+
+// NewAppsBackup combines manifests, sorted by app name, masking sensitive values
+// or encrypting them with a key derived from passphrase when encrypt is set
+func NewAppsBackup(manifests []AppManifest, encrypt bool, passphrase string) (*AppsBackup, error) {
+	backup := &AppsBackup{
+		Version:         AppsBackupVersion,
+		CreatedAt:       time.Now().UTC(),
+		SensitiveValues: SensitiveValuesMasked,
+		Apps:            make([]AppManifest, 0, len(manifests)),
+	}
+
+	var seal func(string) (string, error)
+	if encrypt {
+		if passphrase == "" {
+			return nil, ErrEncryptionKeyRequired
+		}
+		salt := make([]byte, 16)
+		if _, err := rand.Read(salt); err != nil {
+			return nil, fmt.Errorf("unable to generate salt: %w", err)
+		}
+		backup.SensitiveValues = SensitiveValuesEncrypted
+		backup.Encryption = &BackupEncryption{
+			Salt:       base64.StdEncoding.EncodeToString(salt),
+			Iterations: backupKeyIterations,
+		}
+		aead, err := backupCipher(passphrase, backup.Encryption)
+		if err != nil {
+			return nil, err
+		}
+		seal = func(value string) (string, error) { return sealValue(aead, value) }
+	}
+
+	for _, manifest := range manifests {
+		environment := make(map[string]string, len(manifest.Environment))
+		for key, value := range manifest.Environment {
+			if shared.IsSensitiveKey(key) {
+				if seal == nil {
+					value = shared.MaskedValue
+				} else {
+					sealed, err := seal(value)
+					if err != nil {
+						return nil, err
+					}
+					value = sealed
+				}
+			}
+			environment[key] = value
+		}
+		manifest.Environment = environment
+		backup.Apps = append(backup.Apps, manifest)
+	}
+
+	sort.Slice(backup.Apps, func(i, j int) bool { return backup.Apps[i].Name < backup.Apps[j].Name })
+	return backup, nil
+}
+
+// RestorableManifests returns the manifests with encrypted values decrypted.
+// Masked values cannot be restored: they are left out of the environment and
+// listed per app so the caller can report them.
+func (b *AppsBackup) RestorableManifests(passphrase string) ([]AppManifest, map[string][]string, error) {
+	if b.Version != AppsBackupVersion {
+		return nil, nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidBackup, b.Version)
+	}
+
+	var aead cipher.AEAD
+	if b.SensitiveValues == SensitiveValuesEncrypted {
+		if passphrase == "" {
+			return nil, nil, ErrEncryptionKeyRequired
+		}
+		if b.Encryption == nil {
+			return nil, nil, fmt.Errorf("%w: missing encryption parameters", ErrInvalidBackup)
+		}
+		var err error
+		if aead, err = backupCipher(passphrase, b.Encryption); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	manifests := make([]AppManifest, 0, len(b.Apps))
+	skipped := make(map[string][]string)
+	for _, manifest := range b.Apps {
+		environment := make(map[string]string, len(manifest.Environment))
+		for key, value := range manifest.Environment {
+			switch {
+			case value == shared.MaskedValue:
+				skipped[manifest.Name] = append(skipped[manifest.Name], key)
+				continue
+			case aead != nil && strings.HasPrefix(value, encryptedValuePrefix):
+				opened, err := openValue(aead, value)
+				if err != nil {
+					return nil, nil, fmt.Errorf("%w: cannot decrypt %s of %s (wrong encryption key?)", ErrInvalidBackup, key, manifest.Name)
+				}
+				value = opened
+			}
+			environment[key] = value
+		}
+		sort.Strings(skipped[manifest.Name])
+		manifest.Environment = environment
+		manifests = append(manifests, manifest)
+	}
+	return manifests, skipped, nil
+}
+
+// backupCipher derives the AES-256-GCM cipher of a backup from its passphrase
+func backupCipher(passphrase string, params *BackupEncryption) (cipher.AEAD, error) {
+	salt, err := base64.StdEncoding.DecodeString(params.Salt)
+	if err != nil || params.Iterations <= 0 {
+		return nil, fmt.Errorf("%w: bad encryption parameters", ErrInvalidBackup)
+	}
+	key, err := pbkdf2.Key(sha256.New, passphrase, salt, params.Iterations, 32)
+	if err != nil {
+		return nil, fmt.Errorf("unable to derive backup key: %w", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func sealValue(aead cipher.AEAD, value string) (string, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("unable to generate nonce: %w", err)
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(value), nil)
+	return encryptedValuePrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func openValue(aead cipher.AEAD, value string) (string, error) {
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, encryptedValuePrefix))
+	if err != nil || len(raw) < aead.NonceSize() {
+		return "", fmt.Errorf("malformed encrypted value")
+	}
+	nonce, ciphertext := raw[:aead.NonceSize()], raw[aead.NonceSize():]
+	plain, err := aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", err
+	}
+	return string(plain), nil
+}
+
+// AppRestoreResult reports how one application of a backup was restored
+type AppRestoreResult struct {
+	Name      string `json:"name"`
+	Created   bool   `json:"created"`
+	Variables int    `json:"variables"`
+	// SkippedMasked lists the variables exported masked, which must be set again by hand
+	SkippedMasked    []string          `json:"skipped_masked,omitempty"`
+	FormationChanges []FormationChange `json:"formation_changes,omitempty"`
+	Error            string            `json:"error,omitempty"`
+}
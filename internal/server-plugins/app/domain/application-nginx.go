@@ -0,0 +1,71 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+)
+
+// NginxProperty is an app-level nginx setting managed through nginx:set
+type NginxProperty string
+
+const (
+	NginxClientMaxBodySize NginxProperty = "client-max-body-size"
+	NginxProxyReadTimeout  NginxProperty = "proxy-read-timeout"
+	NginxProxySendTimeout  NginxProperty = "proxy-send-timeout"
+	NginxHSTS              NginxProperty = "hsts"
+)
+
+var (
+	ErrUnsupportedNginxProperty = errors.New("unsupported nginx property")
+	ErrInvalidNginxValue        = errors.New("invalid nginx property value")
+)
+
+var (
+	// nginxSizePattern matches nginx sizes such as 1024, 512k or 50m
+	nginxSizePattern = regexp.MustCompile(`^[0-9]+[kKmMgG]?$`)
+	// nginxTimePattern matches nginx times such as 60, 60s, 500ms or 5m
+	nginxTimePattern = regexp.MustCompile(`^[0-9]+(ms|s|m|h)?$`)
+)
+
+// nginxPropertyValidators lists the supported properties and how their values are checked
+var nginxPropertyValidators = map[NginxProperty]func(string) bool{
+	NginxClientMaxBodySize: nginxSizePattern.MatchString,
+	NginxProxyReadTimeout:  nginxTimePattern.MatchString,
+	NginxProxySendTimeout:  nginxTimePattern.MatchString,
+	NginxHSTS: func(value string) bool {
+		return value == "true" || value == "false"
+	},
+}
+
+// GetSupportedNginxProperties returns the nginx properties that can be read and set, sorted
+func GetSupportedNginxProperties() []NginxProperty {
+	properties := make([]NginxProperty, 0, len(nginxPropertyValidators))
+	for property := range nginxPropertyValidators {
+		properties = append(properties, property)
+	}
+	sort.Slice(properties, func(i, j int) bool { return properties[i] < properties[j] })
+	return properties
+}
+
+// ValidateNginxProperty checks that the property is supported and the value has the expected format.
+// An empty value is accepted and resets the property to Dokku's default.
+func ValidateNginxProperty(property NginxProperty, value string) error {
+	valid, ok := nginxPropertyValidators[property]
+	if !ok {
+		return fmt.Errorf("%w: %s (supported: %v)", ErrUnsupportedNginxProperty, property, GetSupportedNginxProperties())
+	}
+	if value != "" && !valid(value) {
+		return fmt.Errorf("%w for %s: %q", ErrInvalidNginxValue, property, value)
+	}
+	return nil
+}
+
+// NginxLogs holds the end of an app's nginx access and error logs
+type NginxLogs struct {
+	AppName   string `json:"app_name"`
+	Lines     int    `json:"lines"`
+	AccessLog string `json:"access_log"`
+	ErrorLog  string `json:"error_log"`
+}
@@ -0,0 +1,158 @@
+package app
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// processStatusPattern matches ps:report status values such as "running (CID: 03ea8977f37)"
+var processStatusPattern = regexp.MustCompile(`^(\S+)(?:\s+\(CID:\s*([0-9a-fA-F]+)\))?`)
+
+// ProcessReport is the structured form of ps:report for an application
+type ProcessReport struct {
+	AppName       string            `json:"app_name"`
+	Deployed      bool              `json:"deployed"`
+	Running       bool              `json:"running"`
+	RestartPolicy string            `json:"restart_policy,omitempty"`
+	Scale         map[string]int    `json:"scale"`
+	Processes     []ProcessInstance `json:"processes"`
+}
+
+// ProcessInstance is a single container of a process type
+type ProcessInstance struct {
+	Type        string `json:"type"`
+	Index       int    `json:"index"`
+	Status      string `json:"status"`
+	ContainerID string `json:"container_id,omitempty"`
+	// RestartCount, LastExitCode and StartedAt are only known when the container could be inspected
+	RestartCount *int       `json:"restart_count,omitempty"`
+	LastExitCode *int       `json:"last_exit_code,omitempty"`
+	StartedAt    *time.Time `json:"started_at,omitempty"`
+}
+
+// ContainerState is the part of a container's inspect output describing its restarts
+type ContainerState struct {
+	RestartCount int
+	ExitCode     int
+	StartedAt    time.Time
+}
+
+// ParseProcessReport builds a ProcessReport from ps:report key/value pairs,
+// reading one instance per "Status <type> <index>" entry
+func ParseProcessReport(appName string, info map[string]string) *ProcessReport {
+	report := &ProcessReport{
+		AppName:       appName,
+		Deployed:      info["Deployed"] == "true",
+		Running:       info["Running"] == "true",
+		RestartPolicy: info["Ps restart policy"],
+		Scale:         make(map[string]int),
+		Processes:     []ProcessInstance{},
+	}
+
+	for key, value := range info {
+		fields := strings.Fields(key)
+		if len(fields) != 3 || fields[0] != "Status" {
+			continue
+		}
+		index, err := strconv.Atoi(fields[2])
+		if err != nil {
+			continue
+		}
+
+		instance := ProcessInstance{Type: fields[1], Index: index, Status: value}
+		if matches := processStatusPattern.FindStringSubmatch(value); matches != nil {
+			instance.Status = matches[1]
+			instance.ContainerID = matches[2]
+		}
+
+		report.Scale[instance.Type]++
+		report.Processes = append(report.Processes, instance)
+	}
+
+	sort.Slice(report.Processes, func(i, j int) bool {
+		if report.Processes[i].Type != report.Processes[j].Type {
+			return report.Processes[i].Type < report.Processes[j].Type
+		}
+		return report.Processes[i].Index < report.Processes[j].Index
+	})
+
+	return report
+}
+
+// IsHealthy reports whether the app has processes and all of them are running
+func (r *ProcessReport) IsHealthy() bool {
+	if len(r.Processes) == 0 {
+		return false
+	}
+	for _, instance := range r.Processes {
+		if instance.Status != "running" {
+			return false
+		}
+	}
+	return true
+}
+
+// State maps the container statuses to an application state. A deployed app
+// whose containers restart, died or only partly run is in error even though it
+// is scaled up, which the scale alone cannot tell.
+func (r *ProcessReport) State() StateValue {
+	if !r.Deployed {
+		return StateExists
+	}
+	if len(r.Processes) == 0 {
+		return StateStopped
+	}
+
+	running, stopped := 0, 0
+	for _, instance := range r.Processes {
+		switch instance.Status {
+		case "running":
+			running++
+		case "exited", "created", "paused":
+			stopped++
+		default:
+			// restarting, dead or a container Docker no longer knows
+			return StateError
+		}
+	}
+
+	switch {
+	case running == len(r.Processes):
+		return StateRunning
+	case stopped == len(r.Processes):
+		return StateStopped
+	default:
+		return StateError
+	}
+}
+
+// SetContainerStates attaches inspected container states, keyed by full or short container ID.
+// The exit code is only kept for containers that restarted or are not running, since a
+// running container that never exited reports 0.
+func (r *ProcessReport) SetContainerStates(states map[string]ContainerState) {
+	for i := range r.Processes {
+		instance := &r.Processes[i]
+		if instance.ContainerID == "" {
+			continue
+		}
+		for id, state := range states {
+			if !strings.HasPrefix(id, instance.ContainerID) {
+				continue
+			}
+			restartCount := state.RestartCount
+			instance.RestartCount = &restartCount
+			if state.RestartCount > 0 || instance.Status != "running" {
+				exitCode := state.ExitCode
+				instance.LastExitCode = &exitCode
+			}
+			if !state.StartedAt.IsZero() {
+				startedAt := state.StartedAt
+				instance.StartedAt = &startedAt
+			}
+			break
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package app_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	app "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/app/domain"
+)
+
+var _ = Describe("ProcessReport", func() {
+	DescribeTable("State",
+		func(deployed bool, statuses []string, expected app.StateValue) {
+			report := &app.ProcessReport{Deployed: deployed}
+			for i, status := range statuses {
+				report.Processes = append(report.Processes, app.ProcessInstance{Type: "web", Index: i + 1, Status: status})
+			}
+
+			Expect(report.State()).To(Equal(expected))
+		},
+		Entry("not deployed", false, []string{}, app.StateExists),
+		Entry("scaled to zero", true, []string{}, app.StateStopped),
+		Entry("all running", true, []string{"running", "running"}, app.StateRunning),
+		Entry("all exited", true, []string{"exited", "exited"}, app.StateStopped),
+		Entry("crash loop", true, []string{"running", "restarting"}, app.StateError),
+		Entry("dead container", true, []string{"dead"}, app.StateError),
+		Entry("partly exited", true, []string{"running", "exited"}, app.StateError),
+	)
+})
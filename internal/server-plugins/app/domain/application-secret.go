@@ -0,0 +1,20 @@
+package app
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+)
+
+// secretValueBytes is the entropy of a generated secret (64 hex characters)
+const secretValueBytes = 32
+
+// GenerateSecretValue returns a new cryptographically random value suitable
+// for API keys, session secrets and similar credentials
+func GenerateSecretValue() (string, error) {
+	buf := make([]byte, secretValueBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("unable to generate secret value: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
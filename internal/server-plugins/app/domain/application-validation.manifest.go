@@ -0,0 +1,254 @@
+package app
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/shared/process"
+)
+
+// procfileTypePattern is the process type format accepted by Dokku in a Procfile or app.json formation
+var procfileTypePattern = regexp.MustCompile(`^[a-z][a-z0-9_-]*$`)
+
+// knownAppJSONKeys lists the top-level app.json keys; Dokku only acts on some of
+// them, the others are kept for compatibility with the Heroku format
+var knownAppJSONKeys = map[string]bool{
+	"name":         true,
+	"description":  true,
+	"keywords":     true,
+	"website":      true,
+	"repository":   true,
+	"logo":         true,
+	"success_url":  true,
+	"scripts":      true,
+	"env":          true,
+	"formation":    true,
+	"addons":       true,
+	"buildpacks":   true,
+	"environments": true,
+	"stack":        true,
+	"image":        true,
+	"cron":         true,
+	"healthchecks": true,
+}
+
+// ValidateDeployManifest checks an app.json and/or a Procfile before they are
+// deployed: structure, process types and commands. Either may be empty.
+func (s *ValidationService) ValidateDeployManifest(ctx context.Context, appJSON string, procfile string) *ValidationResult {
+	result := &ValidationResult{
+		IsValid:  true,
+		Errors:   make([]ValidationError, 0),
+		Warnings: make([]ValidationWarning, 0),
+	}
+
+	if strings.TrimSpace(appJSON) == "" && strings.TrimSpace(procfile) == "" {
+		result.IsValid = false
+		result.Errors = append(result.Errors, ValidationError{
+			Field:   "manifest",
+			Message: "Provide app.json or Procfile content to validate",
+			Code:    "MANIFEST_REQUIRED",
+		})
+		return result
+	}
+
+	var procfileTypes map[string]bool
+	if strings.TrimSpace(procfile) != "" {
+		procfileTypes = s.validateProcfile(procfile, result)
+	}
+
+	if strings.TrimSpace(appJSON) != "" {
+		s.validateAppJSON(appJSON, procfileTypes, result)
+	}
+
+	return result
+}
+
+// validateProcfile checks every "type: command" line and returns the declared process types
+func (s *ValidationService) validateProcfile(procfile string, result *ValidationResult) map[string]bool {
+	types := make(map[string]bool)
+
+	scanner := bufio.NewScanner(strings.NewReader(procfile))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		field := fmt.Sprintf("procfile:%d", lineNumber)
+
+		name, command, found := strings.Cut(line, ":")
+		if !found {
+			s.addError(result, field, fmt.Sprintf("Line '%s' is not in the 'type: command' format", line), "INVALID_PROCFILE_LINE")
+			continue
+		}
+
+		name = strings.TrimSpace(name)
+		if !procfileTypePattern.MatchString(name) {
+			s.addError(result, field, fmt.Sprintf("Process type '%s' must match %s", name, procfileTypePattern.String()), "INVALID_PROCESS_TYPE")
+		}
+		if _, err := process.NewProcessCommand(command); err != nil {
+			s.addError(result, field, fmt.Sprintf("Process type '%s' has an empty command", name), "EMPTY_PROCESS_COMMAND")
+		}
+		if types[name] {
+			s.addError(result, field, fmt.Sprintf("Process type '%s' is declared more than once", name), "DUPLICATE_PROCESS_TYPE")
+		}
+		types[name] = true
+	}
+
+	if len(types) > 0 && !types[string(process.ProcessTypeWeb)] {
+		result.Warnings = append(result.Warnings, ValidationWarning{
+			Field:   "procfile",
+			Message: "No web process type, the app will not receive HTTP traffic",
+			Code:    "NO_WEB_PROCESS",
+		})
+	}
+
+	return types
+}
+
+// validateAppJSON checks the app.json keys Dokku acts on. Formation entries are
+// compared with the Procfile process types when a Procfile was given.
+func (s *ValidationService) validateAppJSON(appJSON string, procfileTypes map[string]bool, result *ValidationResult) {
+	var manifest map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(appJSON), &manifest); err != nil {
+		s.addError(result, "app.json", fmt.Sprintf("Invalid JSON: %v", err), "INVALID_JSON")
+		return
+	}
+
+	keys := make([]string, 0, len(manifest))
+	for key := range manifest {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if !knownAppJSONKeys[key] {
+			result.Warnings = append(result.Warnings, ValidationWarning{
+				Field:   "app.json:" + key,
+				Message: fmt.Sprintf("Unknown key '%s' is ignored by Dokku", key),
+				Code:    "UNKNOWN_APP_JSON_KEY",
+			})
+		}
+	}
+
+	if raw, ok := manifest["scripts"]; ok {
+		s.validateAppJSONScripts(raw, result)
+	}
+	if raw, ok := manifest["formation"]; ok {
+		s.validateAppJSONFormation(raw, procfileTypes, result)
+	}
+	if raw, ok := manifest["cron"]; ok {
+		s.validateAppJSONCron(raw, result)
+	}
+	if raw, ok := manifest[healthchecksKey]; ok {
+		s.validateAppJSONHealthchecks(raw, result)
+	}
+}
+
+// validateAppJSONScripts checks the deploy hooks, including the dokku-specific ones
+func (s *ValidationService) validateAppJSONScripts(raw json.RawMessage, result *ValidationResult) {
+	var scripts struct {
+		Dokku struct {
+			Predeploy  *string `json:"predeploy"`
+			Postdeploy *string `json:"postdeploy"`
+		} `json:"dokku"`
+		Postdeploy *string `json:"postdeploy"`
+	}
+	if err := json.Unmarshal(raw, &scripts); err != nil {
+		s.addError(result, "app.json:scripts", fmt.Sprintf("scripts must be an object of commands: %v", err), "INVALID_APP_JSON_SCRIPTS")
+		return
+	}
+
+	hooks := []struct {
+		field   string
+		command *string
+	}{
+		{field: "scripts.dokku.predeploy", command: scripts.Dokku.Predeploy},
+		{field: "scripts.dokku.postdeploy", command: scripts.Dokku.Postdeploy},
+		{field: "scripts.postdeploy", command: scripts.Postdeploy},
+	}
+	for _, hook := range hooks {
+		if hook.command != nil && strings.TrimSpace(*hook.command) == "" {
+			s.addError(result, "app.json:"+hook.field, "Deploy hook command cannot be empty", "EMPTY_SCRIPT_COMMAND")
+		}
+	}
+}
+
+// validateAppJSONFormation checks process types and quantities of the formation
+func (s *ValidationService) validateAppJSONFormation(raw json.RawMessage, procfileTypes map[string]bool, result *ValidationResult) {
+	var formation map[string]struct {
+		Quantity *int `json:"quantity"`
+	}
+	if err := json.Unmarshal(raw, &formation); err != nil {
+		s.addError(result, "app.json:formation", fmt.Sprintf("formation must map process types to {\"quantity\": n}: %v", err), "INVALID_APP_JSON_FORMATION")
+		return
+	}
+
+	types := make([]string, 0, len(formation))
+	for processType := range formation {
+		types = append(types, processType)
+	}
+	sort.Strings(types)
+
+	for _, processType := range types {
+		field := "app.json:formation." + processType
+		if !procfileTypePattern.MatchString(processType) {
+			s.addError(result, field, fmt.Sprintf("Process type '%s' must match %s", processType, procfileTypePattern.String()), "INVALID_PROCESS_TYPE")
+			continue
+		}
+		if quantity := formation[processType].Quantity; quantity != nil && *quantity < 0 {
+			s.addError(result, field, "Number of instances cannot be negative", "INVALID_SCALE")
+		}
+		if procfileTypes != nil && !procfileTypes[processType] {
+			result.Warnings = append(result.Warnings, ValidationWarning{
+				Field:   field,
+				Message: fmt.Sprintf("Process type '%s' is not declared in the Procfile", processType),
+				Code:    "PROCESS_NOT_CONFIGURED",
+			})
+		}
+	}
+}
+
+// validateAppJSONCron checks that every cron task has a command and a schedule
+func (s *ValidationService) validateAppJSONCron(raw json.RawMessage, result *ValidationResult) {
+	var tasks []struct {
+		Command  string `json:"command"`
+		Schedule string `json:"schedule"`
+	}
+	if err := json.Unmarshal(raw, &tasks); err != nil {
+		s.addError(result, "app.json:cron", fmt.Sprintf("cron must be a list of {\"command\", \"schedule\"} tasks: %v", err), "INVALID_APP_JSON_CRON")
+		return
+	}
+
+	for i, task := range tasks {
+		field := fmt.Sprintf("app.json:cron[%d]", i)
+		if strings.TrimSpace(task.Command) == "" {
+			s.addError(result, field, "Cron task command cannot be empty", "EMPTY_PROCESS_COMMAND")
+		}
+		if strings.TrimSpace(task.Schedule) == "" {
+			s.addError(result, field, "Cron task schedule cannot be empty", "EMPTY_CRON_SCHEDULE")
+		}
+	}
+}
+
+// validateAppJSONHealthchecks checks the structure and values of every healthcheck
+func (s *ValidationService) validateAppJSONHealthchecks(raw json.RawMessage, result *ValidationResult) {
+	checks, err := decodeHealthchecks(raw)
+	if err != nil {
+		s.addError(result, "app.json:healthchecks", fmt.Sprintf("healthchecks must map process types to a list of checks: %v", err), "INVALID_APP_JSON_HEALTHCHECKS")
+		return
+	}
+
+	for _, problem := range checks.Validate() {
+		s.addError(result, problem.Field, problem.Message, problem.Code)
+	}
+}
+
+// addError records a validation error and marks the result invalid
+func (s *ValidationService) addError(result *ValidationResult, field, message, code string) {
+	result.IsValid = false
+	result.Errors = append(result.Errors, ValidationError{Field: field, Message: message, Code: code})
+}
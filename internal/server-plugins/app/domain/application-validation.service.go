@@ -213,22 +213,32 @@ func (s *ValidationService) validateApplicationState(app *Application, result *V
 // validateDomains validates the list of domains
 func (s *ValidationService) validateDomains(domains []string, result *ValidationResult) {
 	for _, domain := range domains {
-		// Basic validation of domain format
-		if !strings.Contains(domain, ".") {
+		domainVO, err := shared.NewDomainName(domain)
+		if err != nil {
 			result.Warnings = append(result.Warnings, ValidationWarning{
 				Field:   "domains",
-				Message: fmt.Sprintf("Domain '%s' does not appear to be a valid FQDN", domain),
+				Message: fmt.Sprintf("Domain '%s' is not valid: %v", domain, err),
 				Code:    "INVALID_DOMAIN_FORMAT",
 			})
+			continue
 		}
 
 		// Check for localhost domains
-		if strings.Contains(domain, "localhost") || strings.Contains(domain, "127.0.0.1") {
+		if domainVO.IsLocal() {
 			result.Warnings = append(result.Warnings, ValidationWarning{
 				Field:   "domains",
 				Message: fmt.Sprintf("Domain '%s' is a local domain", domain),
 				Code:    "LOCAL_DOMAIN_WARNING",
 			})
+			continue
+		}
+
+		if !domainVO.IsIP() && !domainVO.IsFQDN() {
+			result.Warnings = append(result.Warnings, ValidationWarning{
+				Field:   "domains",
+				Message: fmt.Sprintf("Domain '%s' does not appear to be a valid FQDN", domain),
+				Code:    "INVALID_DOMAIN_FORMAT",
+			})
 		}
 	}
 }
@@ -217,4 +217,58 @@ var _ = Describe("ValidationService", func() {
 			})
 		})
 	})
+
+	Describe("ValidateDeployManifest", func() {
+		errorCodes := func(result *ValidationResult) []string {
+			codes := make([]string, len(result.Errors))
+			for i, err := range result.Errors {
+				codes[i] = err.Code
+			}
+			return codes
+		}
+
+		It("should accept a matching app.json and Procfile", func() {
+			appJSON := `{"formation": {"web": {"quantity": 2}, "worker": {"quantity": 1}}, "scripts": {"dokku": {"predeploy": "rake db:migrate"}}}`
+			procfile := "# processes\nweb: bundle exec puma\nworker: bundle exec sidekiq\n"
+
+			result := service.ValidateDeployManifest(ctx, appJSON, procfile)
+
+			Expect(result.IsValid).To(BeTrue())
+			Expect(result.Errors).To(BeEmpty())
+			Expect(result.Warnings).To(BeEmpty())
+		})
+
+		It("should reject malformed JSON", func() {
+			result := service.ValidateDeployManifest(ctx, `{"formation": {"web": }`, "")
+
+			Expect(result.IsValid).To(BeFalse())
+			Expect(errorCodes(result)).To(ConsistOf("INVALID_JSON"))
+			Expect(result.Errors[0].Field).To(Equal("app.json"))
+		})
+
+		It("should reject an invalid process type", func() {
+			result := service.ValidateDeployManifest(ctx, "", "web: npm start\nWeb_Worker: node worker.js\n")
+
+			Expect(result.IsValid).To(BeFalse())
+			Expect(errorCodes(result)).To(ConsistOf("INVALID_PROCESS_TYPE"))
+			Expect(result.Errors[0].Field).To(Equal("procfile:2"))
+		})
+
+		It("should reject empty commands and warn about unknown keys", func() {
+			result := service.ValidateDeployManifest(ctx, `{"formation": {"clock": {"quantity": 1}}, "deploy": true}`, "web:\n")
+
+			Expect(result.IsValid).To(BeFalse())
+			Expect(errorCodes(result)).To(ConsistOf("EMPTY_PROCESS_COMMAND"))
+			Expect(result.Warnings).To(HaveLen(2))
+			Expect([]string{result.Warnings[0].Code, result.Warnings[1].Code}).To(ConsistOf("UNKNOWN_APP_JSON_KEY", "PROCESS_NOT_CONFIGURED"))
+		})
+
+		It("should reject malformed healthchecks", func() {
+			result := service.ValidateDeployManifest(ctx, `{"healthchecks": {"web": [{"type": "startup", "path": "health", "timeout": -1}]}}`, "")
+
+			Expect(result.IsValid).To(BeFalse())
+			Expect(errorCodes(result)).To(ConsistOf("INVALID_HEALTHCHECK_PATH", "INVALID_HEALTHCHECK_DURATION"))
+			Expect(result.Errors[0].Field).To(Equal("app.json:healthchecks.web[0]"))
+		})
+	})
 })
@@ -13,12 +13,48 @@ const (
 	CommandAppsReport  ApplicationCommand = "apps:report"
 
 	// Configuration commands
-	CommandConfigShow ApplicationCommand = "config:show"
-	CommandConfigSet  ApplicationCommand = "config:set"
+	CommandConfigShow  ApplicationCommand = "config:show"
+	CommandConfigSet   ApplicationCommand = "config:set"
+	CommandConfigUnset ApplicationCommand = "config:unset"
 
 	// Process management commands
-	CommandPsScale  ApplicationCommand = "ps:scale"
-	CommandPsReport ApplicationCommand = "ps:report"
+	CommandPsScale   ApplicationCommand = "ps:scale"
+	CommandPsReport  ApplicationCommand = "ps:report"
+	CommandPsInspect ApplicationCommand = "ps:inspect"
+	CommandPsRestart ApplicationCommand = "ps:restart"
+
+	// Scheduler and storage commands
+	CommandSchedulerReport ApplicationCommand = "scheduler:report"
+	CommandStorageReport   ApplicationCommand = "storage:report"
+
+	// Proxy commands
+	CommandProxyReport      ApplicationCommand = "proxy:report"
+	CommandProxyBuildConfig ApplicationCommand = "proxy:build-config"
+	CommandNginxReport      ApplicationCommand = "nginx:report"
+	CommandNginxSet         ApplicationCommand = "nginx:set"
+	CommandNginxAccessLogs  ApplicationCommand = "nginx:access-logs"
+	CommandNginxErrorLogs   ApplicationCommand = "nginx:error-logs"
+	CommandCertsReport      ApplicationCommand = "certs:report"
+
+	// Domain commands
+	CommandDomainsReport ApplicationCommand = "domains:report"
+	CommandDomainsAdd    ApplicationCommand = "domains:add"
+	CommandDomainsRemove ApplicationCommand = "domains:remove"
+
+	// Zero-downtime checks commands
+	CommandChecksReport ApplicationCommand = "checks:report"
+	CommandChecksSet    ApplicationCommand = "checks:set"
+
+	// Builder commands
+	CommandBuilderReport ApplicationCommand = "builder:report"
+	CommandBuilderSet    ApplicationCommand = "builder:set"
+
+	// Image tag commands
+	CommandTagsList    ApplicationCommand = "tags:list"
+	CommandTagsDestroy ApplicationCommand = "tags:destroy"
+
+	// Git commands
+	CommandGitReport ApplicationCommand = "git:report"
 
 	// Logging commands
 	CommandLogs ApplicationCommand = "logs"
@@ -28,8 +64,11 @@ const (
 func (c ApplicationCommand) IsValid() bool {
 	switch c {
 	case CommandAppsList, CommandAppsInfo, CommandAppsCreate, CommandAppsDestroy,
-		CommandAppsExists, CommandAppsReport, CommandConfigShow, CommandConfigSet,
-		CommandPsScale, CommandPsReport, CommandLogs:
+		CommandAppsExists, CommandAppsReport, CommandConfigShow, CommandConfigSet, CommandConfigUnset,
+		CommandPsScale, CommandPsReport, CommandPsInspect, CommandPsRestart, CommandSchedulerReport, CommandStorageReport,
+		CommandProxyReport, CommandProxyBuildConfig, CommandNginxReport, CommandNginxSet, CommandNginxAccessLogs, CommandNginxErrorLogs,
+		CommandCertsReport, CommandDomainsReport, CommandDomainsAdd, CommandDomainsRemove, CommandChecksReport, CommandChecksSet,
+		CommandBuilderReport, CommandBuilderSet, CommandTagsList, CommandTagsDestroy, CommandGitReport, CommandLogs:
 		return true
 	default:
 		return false
@@ -52,8 +91,30 @@ func GetAllowedCommands() []ApplicationCommand {
 		CommandAppsReport,
 		CommandConfigShow,
 		CommandConfigSet,
+		CommandConfigUnset,
 		CommandPsScale,
 		CommandPsReport,
+		CommandPsInspect,
+		CommandPsRestart,
+		CommandSchedulerReport,
+		CommandStorageReport,
+		CommandProxyReport,
+		CommandProxyBuildConfig,
+		CommandNginxReport,
+		CommandNginxSet,
+		CommandNginxAccessLogs,
+		CommandNginxErrorLogs,
+		CommandCertsReport,
+		CommandDomainsReport,
+		CommandDomainsAdd,
+		CommandDomainsRemove,
+		CommandChecksReport,
+		CommandChecksSet,
+		CommandBuilderReport,
+		CommandBuilderSet,
+		CommandTagsList,
+		CommandTagsDestroy,
+		CommandGitReport,
 		CommandLogs,
 	}
 }
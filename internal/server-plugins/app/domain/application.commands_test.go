@@ -22,6 +22,11 @@ var _ = Describe("ApplicationCommand", func() {
 					app.CommandConfigSet,
 					app.CommandPsScale,
 					app.CommandPsReport,
+					app.CommandProxyReport,
+					app.CommandProxyBuildConfig,
+					app.CommandNginxReport,
+					app.CommandNginxSet,
+					app.CommandCertsReport,
 					app.CommandLogs,
 				}
 
@@ -38,7 +43,7 @@ var _ = Describe("ApplicationCommand", func() {
 					"apps:delete",
 					"sudo reboot",
 					"git:push",
-					"domains:add",
+					"domains:clear",
 				}
 
 				for _, cmd := range invalidCommands {
@@ -58,7 +63,7 @@ var _ = Describe("ApplicationCommand", func() {
 	Describe("GetAllowedCommands", func() {
 		It("should return all allowed commands", func() {
 			commands := app.GetAllowedCommands()
-			Expect(commands).To(HaveLen(11))
+			Expect(commands).To(HaveLen(33))
 			Expect(commands).To(ContainElements(
 				app.CommandAppsList,
 				app.CommandAppsInfo,
@@ -68,8 +73,20 @@ var _ = Describe("ApplicationCommand", func() {
 				app.CommandAppsReport,
 				app.CommandConfigShow,
 				app.CommandConfigSet,
+				app.CommandConfigUnset,
 				app.CommandPsScale,
 				app.CommandPsReport,
+				app.CommandPsInspect,
+				app.CommandProxyReport,
+				app.CommandProxyBuildConfig,
+				app.CommandNginxReport,
+				app.CommandNginxSet,
+				app.CommandNginxAccessLogs,
+				app.CommandNginxErrorLogs,
+				app.CommandCertsReport,
+				app.CommandDomainsReport,
+				app.CommandDomainsAdd,
+				app.CommandDomainsRemove,
 				app.CommandLogs,
 			))
 		})
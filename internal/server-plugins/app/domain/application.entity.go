@@ -2,6 +2,8 @@ package app
 
 import (
 	"fmt"
+	"sort"
+	"strconv"
 	"time"
 
 	"github.com/dokku-mcp/dokku-mcp/internal/shared"
@@ -25,6 +27,8 @@ type Application struct {
 type ApplicationConfiguration struct {
 	buildpack       *shared.BuildpackName
 	domains         []*shared.DomainName
+	vhostsEnabled   bool
+	globalDomains   []string
 	environmentVars map[shared.EnvVarKey]*shared.EnvVarValue
 	processes       map[process.ProcessType]*process.Process
 }
@@ -68,6 +72,8 @@ func NewApplicationWithState(name string, state StateValue) (*Application, error
 		updatedAt: time.Now(),
 		configuration: &ApplicationConfiguration{
 			domains:         make([]*shared.DomainName, 0),
+			vhostsEnabled:   true,
+			globalDomains:   make([]string, 0),
 			environmentVars: make(map[shared.EnvVarKey]*shared.EnvVarValue),
 			processes:       make(map[process.ProcessType]*process.Process),
 		},
@@ -95,34 +101,49 @@ func (a *Application) Configuration() *ApplicationConfiguration {
 	return a.copyConfiguration()
 }
 
+// Deploy starts a deployment of gitRef: the application stays in the deploying
+// state until CompleteDeployment or FailDeployment, and refuses another deploy meanwhile
 func (a *Application) Deploy(gitRef *shared.GitRef, buildOpts *DeploymentOptions) error {
 	if gitRef == nil {
 		return fmt.Errorf("git reference cannot be null")
 	}
+	if a.state.IsDeploying() {
+		return fmt.Errorf("%w: %s", ErrDeploymentInProgress, a.name.Value())
+	}
+	if err := a.setState(StateDeploying); err != nil {
+		return err
+	}
 
 	a.deploymentInfo.currentGitRef = gitRef
-	now := time.Now()
-	a.deploymentInfo.lastDeployedAt = &now
-	a.deploymentInfo.deploymentCount++
-
 	if buildOpts != nil {
 		a.deploymentInfo.buildImage = buildOpts.BuildImage
 		a.deploymentInfo.runImage = buildOpts.RunImage
 	}
 
-	a.updatedAt = time.Now()
-	a.addEvent(NewApplicationDeployedEvent(a.name.Value(), gitRef.Value(), time.Now()))
-
 	return nil
 }
 
-// CompleteDeployment just sets state to running
+// CompleteDeployment ends the deployment in progress: the application is running the deployed ref
 func (a *Application) CompleteDeployment() error {
-	return a.setState(StateRunning)
+	if !a.state.IsDeploying() {
+		return fmt.Errorf("%w: no deployment in progress for %s", ErrInvalidState, a.name.Value())
+	}
+	if err := a.setState(StateRunning); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	a.deploymentInfo.lastDeployedAt = &now
+	a.deploymentInfo.deploymentCount++
+	a.addEvent(NewApplicationDeployedEvent(a.name.Value(), a.deploymentInfo.currentGitRef.Value(), now))
+	return nil
 }
 
-// FailDeployment sets state to error
+// FailDeployment ends the deployment in progress in the error state
 func (a *Application) FailDeployment(reason string) error {
+	if !a.state.IsDeploying() {
+		return fmt.Errorf("%w: no deployment in progress for %s", ErrInvalidState, a.name.Value())
+	}
 	a.addEvent(NewApplicationDeploymentFailedEvent(a.name.Value(), reason, time.Now()))
 	return a.setState(StateError)
 }
@@ -155,12 +176,12 @@ func (a *Application) Scale(processType process.ProcessType, instances int) erro
 func (a *Application) AddDomain(domainName string) error {
 	domainVO, err := shared.NewDomainName(domainName)
 	if err != nil {
-		return fmt.Errorf("invalid domain: %w", err)
+		return fmt.Errorf("%w: %w", ErrInvalidDomain, err)
 	}
 
 	for _, existingDomain := range a.configuration.domains {
 		if existingDomain.Equal(domainVO) {
-			return fmt.Errorf("the domain %s already exists", domainName)
+			return fmt.Errorf("%w: %s", ErrDomainAlreadyExists, domainName)
 		}
 	}
 
@@ -174,7 +195,7 @@ func (a *Application) AddDomain(domainName string) error {
 func (a *Application) RemoveDomain(domainName string) error {
 	domainVO, err := shared.NewDomainName(domainName)
 	if err != nil {
-		return fmt.Errorf("invalid domain: %w", err)
+		return fmt.Errorf("%w: %w", ErrInvalidDomain, err)
 	}
 
 	for i, existingDomain := range a.configuration.domains {
@@ -187,7 +208,24 @@ func (a *Application) RemoveDomain(domainName string) error {
 		}
 	}
 
-	return fmt.Errorf("the domain %s doesn't exist", domainName)
+	return fmt.Errorf("%w: %s", ErrDomainNotFound, domainName)
+}
+
+// ApplyDomainsReport replaces the domains with the ones Dokku reports for the app.
+// It reflects existing state, so no event is recorded.
+func (a *Application) ApplyDomainsReport(report *DomainsReport) {
+	domains := make([]*shared.DomainName, 0, len(report.AppVhosts))
+	for _, vhost := range report.AppVhosts {
+		domainVO, err := shared.NewDomainName(vhost)
+		if err != nil {
+			continue
+		}
+		domains = append(domains, domainVO)
+	}
+
+	a.configuration.domains = domains
+	a.configuration.vhostsEnabled = report.AppEnabled
+	a.configuration.globalDomains = append([]string{}, report.GlobalVhosts...)
 }
 
 func (a *Application) SetBuildpack(buildpackName string) error {
@@ -216,6 +254,96 @@ func (a *Application) SetEnvironmentVariable(key, value string) error {
 	return nil
 }
 
+// ConfigureEnvironment sets several environment variables at once and records
+// them as a single change. With noRestart the app keeps running with its
+// previous environment until it is restarted or redeployed.
+func (a *Application) ConfigureEnvironment(variables map[string]string, noRestart bool) error {
+	applied := make(map[string]string, len(variables))
+	for key, value := range variables {
+		if err := a.SetEnvironmentVariable(key, value); err != nil {
+			return fmt.Errorf("unable to set variable %s: %w", key, err)
+		}
+		applied[key] = value
+	}
+
+	a.addEvent(NewEnvironmentConfiguredEvent(a.name.Value(), applied, noRestart, time.Now()))
+	return nil
+}
+
+// UnsetEnvironment removes environment variables and records the removal as a
+// single change. Keys that are not set are still forwarded to Dokku, which ignores them.
+func (a *Application) UnsetEnvironment(keys []string, noRestart bool) error {
+	if len(keys) == 0 {
+		return ErrNoConfigKeys
+	}
+
+	removed := make([]string, 0, len(keys))
+	for _, key := range keys {
+		envKey, err := shared.NewEnvVarKey(key)
+		if err != nil {
+			return fmt.Errorf("unable to unset variable %s: %w", key, err)
+		}
+		delete(a.configuration.environmentVars, *envKey)
+		removed = append(removed, envKey.Value())
+	}
+
+	a.updatedAt = time.Now()
+	a.addEvent(NewEnvironmentUnsetEvent(a.name.Value(), removed, noRestart, time.Now()))
+	return nil
+}
+
+// Restart requests a restart of every process, applying configuration that was
+// stored with noRestart
+func (a *Application) Restart() {
+	a.addEvent(NewApplicationRestartRequestedEvent(a.name.Value(), time.Now()))
+}
+
+// EnvironmentVariables returns a copy of the app's environment variables
+func (a *Application) EnvironmentVariables() map[string]string {
+	vars := make(map[string]string, len(a.configuration.environmentVars))
+	for key, value := range a.configuration.environmentVars {
+		vars[key.Value()] = value.Value()
+	}
+	return vars
+}
+
+// EnvironmentSnapshot captures the value of each key in current, the app's config as
+// read from Dokku, so a change can be reverted: previous holds the keys that exist,
+// added the keys that do not
+func EnvironmentSnapshot(current map[string]string, keys []string) (previous map[string]string, added []string) {
+	previous = make(map[string]string)
+	for _, key := range keys {
+		if _, err := shared.NewEnvVarKey(key); err != nil {
+			continue
+		}
+		if value, exists := current[key]; exists {
+			previous[key] = value
+		} else {
+			added = append(added, key)
+		}
+	}
+	sort.Strings(added)
+	return previous, added
+}
+
+// RestoreEnvironment reverts variables to a snapshot taken with EnvironmentSnapshot
+func (a *Application) RestoreEnvironment(previous map[string]string, added []string) error {
+	for key, value := range previous {
+		if err := a.SetEnvironmentVariable(key, value); err != nil {
+			return fmt.Errorf("unable to restore variable %s: %w", key, err)
+		}
+	}
+	for _, key := range added {
+		if envKey, err := shared.NewEnvVarKey(key); err == nil {
+			delete(a.configuration.environmentVars, *envKey)
+		}
+	}
+
+	a.updatedAt = time.Now()
+	a.addEvent(NewEnvironmentRestoredEvent(a.name.Value(), previous, added, time.Now()))
+	return nil
+}
+
 func (a *Application) AddProcess(processType process.ProcessType, command string, scale int) error {
 	proc, err := process.NewProcess(processType, command, scale)
 	if err != nil {
@@ -271,6 +399,71 @@ func (a *Application) GetProcessScale(processType process.ProcessType) int {
 	return 0
 }
 
+// Formation returns the scale of each process type
+func (a *Application) Formation() map[string]int {
+	formation := make(map[string]int, len(a.configuration.processes))
+	for processType, proc := range a.configuration.processes {
+		formation[processType.String()] = proc.Scale()
+	}
+	return formation
+}
+
+// GetProcessTypes returns the process types of the formation, sorted by name
+func (a *Application) GetProcessTypes() []string {
+	types := make([]string, 0, len(a.configuration.processes))
+	for processType := range a.configuration.processes {
+		types = append(types, processType.String())
+	}
+	sort.Strings(types)
+	return types
+}
+
+// SetForceHTTPS enables or disables HTTPS enforcement at the proxy
+func (a *Application) SetForceHTTPS(enabled bool) {
+	a.updatedAt = time.Now()
+	a.addEvent(NewForceHTTPSChangedEvent(a.name.Value(), enabled, time.Now()))
+}
+
+// SetNginxProperty changes an app-level nginx setting; an empty value resets it to the default
+func (a *Application) SetNginxProperty(property NginxProperty, value string) error {
+	if err := ValidateNginxProperty(property, value); err != nil {
+		return err
+	}
+	a.updatedAt = time.Now()
+	a.addEvent(NewNginxPropertyChangedEvent(a.name.Value(), property, value, time.Now()))
+	return nil
+}
+
+// SetChecksWaitToRetire changes how long the previous containers keep running after a deploy before being retired
+func (a *Application) SetChecksWaitToRetire(seconds int) error {
+	if seconds <= 0 {
+		return fmt.Errorf("%w: wait-to-retire must be positive, got %d", ErrInvalidChecksDuration, seconds)
+	}
+	a.updatedAt = time.Now()
+	a.addEvent(NewChecksWaitToRetireChangedEvent(a.name.Value(), seconds, time.Now()))
+	return nil
+}
+
+// SetChecksTimeout changes the timeout of each check request. Checks only run
+// on deploy, so the app is not restarted and the value applies from the next deploy.
+func (a *Application) SetChecksTimeout(seconds int) error {
+	if seconds <= 0 {
+		return fmt.Errorf("%w: timeout must be positive, got %d", ErrInvalidChecksDuration, seconds)
+	}
+	return a.ConfigureEnvironment(map[string]string{ChecksTimeoutVar: strconv.Itoa(seconds)}, true)
+}
+
+// SetBuilder selects the builder of the next builds; the running containers keep
+// the image they were built with until the app is rebuilt
+func (a *Application) SetBuilder(builder Builder) error {
+	if _, err := ParseBuilder(string(builder)); err != nil {
+		return err
+	}
+	a.updatedAt = time.Now()
+	a.addEvent(NewBuilderChangedEvent(a.name.Value(), builder, time.Now()))
+	return nil
+}
+
 func (a *Application) GetDomains() []string {
 	domains := make([]string, len(a.configuration.domains))
 	for i, domainVO := range a.configuration.domains {
@@ -279,6 +472,16 @@ func (a *Application) GetDomains() []string {
 	return domains
 }
 
+// VhostsEnabled reports whether the proxy routes the app's domains
+func (a *Application) VhostsEnabled() bool {
+	return a.configuration.vhostsEnabled
+}
+
+// GetGlobalDomains returns the global vhosts Dokku derives app domains from
+func (a *Application) GetGlobalDomains() []string {
+	return append([]string{}, a.configuration.globalDomains...)
+}
+
 func (a *Application) GetEvents() []DomainEvent {
 	return a.events
 }
@@ -289,12 +492,15 @@ func (a *Application) ClearEvents() {
 
 // Private methods
 
-// setState replaces the complex changeState logic with simple state setting
+// setState moves the application to newState when the transition is allowed
 func (a *Application) setState(newState StateValue) error {
 	newStateObj, err := NewApplicationState(newState)
 	if err != nil {
 		return fmt.Errorf("invalid state transition to %s: %w", newState, err)
 	}
+	if !a.state.CanTransitionTo(newState) {
+		return fmt.Errorf("%w: cannot go from %s to %s", ErrInvalidState, a.state.Value(), newState)
+	}
 
 	a.state = newStateObj
 	a.updatedAt = time.Now()
@@ -322,6 +528,8 @@ func (a *Application) copyConfiguration() *ApplicationConfiguration {
 	return &ApplicationConfiguration{
 		buildpack:       a.configuration.buildpack,
 		domains:         domains,
+		vhostsEnabled:   a.configuration.vhostsEnabled,
+		globalDomains:   append([]string{}, a.configuration.globalDomains...),
 		environmentVars: envVars,
 		processes:       processes,
 	}
@@ -334,8 +542,8 @@ type DeploymentOptions struct {
 	NoCache    bool
 }
 
-// ApplicationInfo represents application info for JSON serialization
-type ApplicationInfo struct {
+// ApplicationListItem represents an application of the list resource for JSON serialization
+type ApplicationListItem struct {
 	Name       string    `json:"name"`
 	State      string    `json:"state"`
 	IsRunning  bool      `json:"is_running"`
@@ -346,19 +554,50 @@ type ApplicationInfo struct {
 
 // ApplicationStatus represents detailed application status for JSON serialization
 type ApplicationStatus struct {
-	Name       string    `json:"name"`
-	State      string    `json:"state"`
-	CreatedAt  time.Time `json:"created_at"`
-	UpdatedAt  time.Time `json:"updated_at"`
-	IsRunning  bool      `json:"is_running"`
-	IsDeployed bool      `json:"is_deployed"`
-	Domains    []string  `json:"domains"`
+	Name       string       `json:"name"`
+	State      string       `json:"state"`
+	CreatedAt  time.Time    `json:"created_at"`
+	UpdatedAt  time.Time    `json:"updated_at"`
+	IsRunning  bool         `json:"is_running"`
+	IsDeployed bool         `json:"is_deployed"`
+	Domains    []string     `json:"domains"`
+	HTTPS      *HTTPSStatus `json:"https,omitempty"`
+	// LastDeployStatus is the outcome of the most recent deploy (succeeded, failed or running)
+	LastDeployStatus string `json:"last_deploy_status,omitempty"`
+	// VhostsEnabled is false when the domains are kept but not routed by the proxy
+	VhostsEnabled bool     `json:"vhosts_enabled"`
+	GlobalDomains []string `json:"global_domains,omitempty"`
+	// Builder is the builder used by the next build (builder:report)
+	Builder string `json:"builder,omitempty"`
+	// Healthchecks are the checks declared in the app.json given with the request
+	Healthchecks AppHealthchecks `json:"healthchecks,omitempty"`
+}
+
+// HTTPSStatus describes how an application is served over HTTPS
+type HTTPSStatus struct {
+	ProxyType  string `json:"proxy_type"`
+	SSLEnabled bool   `json:"ssl_enabled"`
+	ForceHTTPS bool   `json:"force_https"`
+}
+
+// SupportsForceHTTPS reports whether HTTPS enforcement can be managed for the app's proxy
+func (s *HTTPSStatus) SupportsForceHTTPS() bool {
+	return s.UsesNginx()
+}
+
+// UsesNginx reports whether the app is served by the nginx proxy
+func (s *HTTPSStatus) UsesNginx() bool {
+	return s.ProxyType == "nginx"
 }
 
 // ApplicationListData represents the application list resource data
 type ApplicationListData struct {
-	Applications []ApplicationInfo `json:"applications"`
-	Count        int               `json:"count"`
+	Applications []ApplicationListItem `json:"applications"`
+	// Count is the number of applications in this page, Total the number of all applications
+	Count  int `json:"count"`
+	Total  int `json:"total"`
+	Offset int `json:"offset"`
+	Limit  int `json:"limit"`
 }
 
 // ApplicationSummaryData represents the application summary resource data
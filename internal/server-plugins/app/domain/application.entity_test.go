@@ -0,0 +1,96 @@
+package app_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	app "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/app/domain"
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
+)
+
+var _ = Describe("Application deployment lifecycle", func() {
+	var (
+		application *app.Application
+		gitRef      *shared.GitRef
+	)
+
+	BeforeEach(func() {
+		var err error
+		application, err = app.NewApplication("my-app")
+		Expect(err).NotTo(HaveOccurred())
+		gitRef, err = shared.NewGitRef("main")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	deployedEvents := func() []app.DomainEvent {
+		events := []app.DomainEvent{}
+		for _, event := range application.GetEvents() {
+			if event.EventType() == "application.deployed" {
+				events = append(events, event)
+			}
+		}
+		return events
+	}
+
+	It("should enter the deploying state on Deploy", func() {
+		Expect(application.Deploy(gitRef, nil)).To(Succeed())
+
+		Expect(application.State().Value()).To(Equal(app.StateDeploying))
+		Expect(application.IsDeployed()).To(BeFalse())
+		Expect(deployedEvents()).To(BeEmpty())
+	})
+
+	It("should be running once the deployment completes", func() {
+		Expect(application.Deploy(gitRef, nil)).To(Succeed())
+		Expect(application.CompleteDeployment()).To(Succeed())
+
+		Expect(application.State().Value()).To(Equal(app.StateRunning))
+		Expect(application.IsDeployed()).To(BeTrue())
+		Expect(deployedEvents()).To(HaveLen(1))
+	})
+
+	It("should be in error once the deployment fails", func() {
+		Expect(application.Deploy(gitRef, nil)).To(Succeed())
+		Expect(application.FailDeployment("build failed")).To(Succeed())
+
+		Expect(application.State().IsError()).To(BeTrue())
+	})
+
+	It("should refuse a second deploy while one is in progress", func() {
+		Expect(application.Deploy(gitRef, nil)).To(Succeed())
+
+		Expect(application.Deploy(gitRef, nil)).To(MatchError(app.ErrDeploymentInProgress))
+		Expect(application.State().Value()).To(Equal(app.StateDeploying))
+	})
+
+	It("should allow redeploying after a failure", func() {
+		Expect(application.Deploy(gitRef, nil)).To(Succeed())
+		Expect(application.FailDeployment("build failed")).To(Succeed())
+
+		Expect(application.Deploy(gitRef, nil)).To(Succeed())
+		Expect(application.CompleteDeployment()).To(Succeed())
+		Expect(application.State().IsRunning()).To(BeTrue())
+	})
+
+	It("should refuse to end a deployment that was not started", func() {
+		Expect(application.CompleteDeployment()).To(MatchError(app.ErrInvalidState))
+		Expect(application.FailDeployment("build failed")).To(MatchError(app.ErrInvalidState))
+		Expect(application.State().Value()).To(Equal(app.StateExists))
+	})
+})
+
+var _ = Describe("ApplicationState transitions", func() {
+	DescribeTable("CanTransitionTo",
+		func(from, to app.StateValue, allowed bool) {
+			Expect(app.MustNewApplicationState(from).CanTransitionTo(to)).To(Equal(allowed))
+		},
+		Entry("exists to deploying", app.StateExists, app.StateDeploying, true),
+		Entry("exists to running", app.StateExists, app.StateRunning, false),
+		Entry("deploying to running", app.StateDeploying, app.StateRunning, true),
+		Entry("deploying to error", app.StateDeploying, app.StateError, true),
+		Entry("deploying to stopped", app.StateDeploying, app.StateStopped, false),
+		Entry("deploying to deploying", app.StateDeploying, app.StateDeploying, false),
+		Entry("running to deploying", app.StateRunning, app.StateDeploying, true),
+		Entry("error to deploying", app.StateError, app.StateDeploying, true),
+	)
+})
@@ -1,6 +1,10 @@
 package app
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
+)
 
 // Application domain specific errors
 var (
@@ -11,4 +15,11 @@ var (
 	ErrApplicationNotDeployed   = errors.New("application not deployed")
 	ErrDeploymentInProgress     = errors.New("deployment already in progress")
 	ErrInvalidState             = errors.New("invalid application state")
+	ErrProxyNotSupported        = errors.New("operation not supported by the app's proxy")
+	ErrHealthCheckFailed        = errors.New("application did not become healthy")
+	ErrNoConfigKeys             = errors.New("at least one configuration key is required")
+	ErrInvalidDomain            = errors.New("invalid domain")
+	ErrDomainAlreadyExists      = errors.New("domain already exists")
+	ErrDomainNotFound           = errors.New("domain not found")
+	ErrApplicationOutOfScope    = shared.ErrAppOutOfScope
 )
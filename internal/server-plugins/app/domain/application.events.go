@@ -1,6 +1,8 @@
 package app
 
 import (
+	"maps"
+	"slices"
 	"time"
 )
 
@@ -83,6 +85,26 @@ func (e *ApplicationScaledEvent) ProcessType() string   { return e.processType }
 func (e *ApplicationScaledEvent) OldScale() int         { return e.oldScale }
 func (e *ApplicationScaledEvent) NewScale() int         { return e.newScale }
 
+// FormationScaledEvent records several process types scaled together, applied by a single ps:scale
+type FormationScaledEvent struct {
+	aggregateID string
+	scales      map[string]int
+	occurredAt  time.Time
+}
+
+func NewFormationScaledEvent(aggregateID string, scales map[string]int, occurredAt time.Time) *FormationScaledEvent {
+	return &FormationScaledEvent{
+		aggregateID: aggregateID,
+		scales:      maps.Clone(scales),
+		occurredAt:  occurredAt,
+	}
+}
+
+func (e *FormationScaledEvent) OccurredAt() time.Time  { return e.occurredAt }
+func (e *FormationScaledEvent) EventType() string      { return "application.formation.scaled" }
+func (e *FormationScaledEvent) AggregateID() string    { return e.aggregateID }
+func (e *FormationScaledEvent) Scales() map[string]int { return maps.Clone(e.scales) }
+
 type ApplicationStateChangedEvent struct {
 	aggregateID string
 	oldState    string
@@ -161,3 +183,192 @@ func (e *BuildpackChangedEvent) OccurredAt() time.Time { return e.occurredAt }
 func (e *BuildpackChangedEvent) EventType() string     { return "application.buildpack.changed" }
 func (e *BuildpackChangedEvent) AggregateID() string   { return e.aggregateID }
 func (e *BuildpackChangedEvent) Buildpack() string     { return e.buildpack }
+
+type EnvironmentConfiguredEvent struct {
+	aggregateID string
+	variables   map[string]string
+	noRestart   bool
+	occurredAt  time.Time
+}
+
+func NewEnvironmentConfiguredEvent(aggregateID string, variables map[string]string, noRestart bool, occurredAt time.Time) *EnvironmentConfiguredEvent {
+	return &EnvironmentConfiguredEvent{
+		aggregateID: aggregateID,
+		variables:   variables,
+		noRestart:   noRestart,
+		occurredAt:  occurredAt,
+	}
+}
+
+func (e *EnvironmentConfiguredEvent) OccurredAt() time.Time        { return e.occurredAt }
+func (e *EnvironmentConfiguredEvent) EventType() string            { return "application.environment.configured" }
+func (e *EnvironmentConfiguredEvent) AggregateID() string          { return e.aggregateID }
+func (e *EnvironmentConfiguredEvent) Variables() map[string]string { return e.variables }
+func (e *EnvironmentConfiguredEvent) NoRestart() bool              { return e.noRestart }
+
+// EnvironmentRestoredEvent puts back a previous environment: variables are set
+// to their previous values and variables that did not exist are removed
+type EnvironmentRestoredEvent struct {
+	aggregateID string
+	previous    map[string]string
+	removed     []string
+	occurredAt  time.Time
+}
+
+func NewEnvironmentRestoredEvent(aggregateID string, previous map[string]string, removed []string, occurredAt time.Time) *EnvironmentRestoredEvent {
+	return &EnvironmentRestoredEvent{
+		aggregateID: aggregateID,
+		previous:    previous,
+		removed:     removed,
+		occurredAt:  occurredAt,
+	}
+}
+
+func (e *EnvironmentRestoredEvent) OccurredAt() time.Time       { return e.occurredAt }
+func (e *EnvironmentRestoredEvent) EventType() string           { return "application.environment.restored" }
+func (e *EnvironmentRestoredEvent) AggregateID() string         { return e.aggregateID }
+func (e *EnvironmentRestoredEvent) Previous() map[string]string { return e.previous }
+func (e *EnvironmentRestoredEvent) Removed() []string           { return e.removed }
+
+// EnvironmentUnsetEvent removes environment variables from the app
+type EnvironmentUnsetEvent struct {
+	aggregateID string
+	keys        []string
+	noRestart   bool
+	occurredAt  time.Time
+}
+
+func NewEnvironmentUnsetEvent(aggregateID string, keys []string, noRestart bool, occurredAt time.Time) *EnvironmentUnsetEvent {
+	return &EnvironmentUnsetEvent{
+		aggregateID: aggregateID,
+		keys:        keys,
+		noRestart:   noRestart,
+		occurredAt:  occurredAt,
+	}
+}
+
+func (e *EnvironmentUnsetEvent) OccurredAt() time.Time { return e.occurredAt }
+func (e *EnvironmentUnsetEvent) EventType() string     { return "application.environment.unset" }
+func (e *EnvironmentUnsetEvent) AggregateID() string   { return e.aggregateID }
+func (e *EnvironmentUnsetEvent) Keys() []string        { return e.keys }
+func (e *EnvironmentUnsetEvent) NoRestart() bool       { return e.noRestart }
+
+// ApplicationRestartRequestedEvent restarts every process of the app, e.g. to
+// pick up configuration stored with --no-restart
+type ApplicationRestartRequestedEvent struct {
+	aggregateID string
+	occurredAt  time.Time
+}
+
+func NewApplicationRestartRequestedEvent(aggregateID string, occurredAt time.Time) *ApplicationRestartRequestedEvent {
+	return &ApplicationRestartRequestedEvent{
+		aggregateID: aggregateID,
+		occurredAt:  occurredAt,
+	}
+}
+
+func (e *ApplicationRestartRequestedEvent) OccurredAt() time.Time { return e.occurredAt }
+func (e *ApplicationRestartRequestedEvent) EventType() string     { return "application.restart.requested" }
+func (e *ApplicationRestartRequestedEvent) AggregateID() string   { return e.aggregateID }
+
+type ForceHTTPSChangedEvent struct {
+	aggregateID string
+	enabled     bool
+	occurredAt  time.Time
+}
+
+func NewForceHTTPSChangedEvent(aggregateID string, enabled bool, occurredAt time.Time) *ForceHTTPSChangedEvent {
+	return &ForceHTTPSChangedEvent{
+		aggregateID: aggregateID,
+		enabled:     enabled,
+		occurredAt:  occurredAt,
+	}
+}
+
+func (e *ForceHTTPSChangedEvent) OccurredAt() time.Time { return e.occurredAt }
+func (e *ForceHTTPSChangedEvent) EventType() string     { return "application.force_https.changed" }
+func (e *ForceHTTPSChangedEvent) AggregateID() string   { return e.aggregateID }
+func (e *ForceHTTPSChangedEvent) Enabled() bool         { return e.enabled }
+
+type NginxPropertyChangedEvent struct {
+	aggregateID string
+	property    NginxProperty
+	value       string
+	occurredAt  time.Time
+}
+
+func NewNginxPropertyChangedEvent(aggregateID string, property NginxProperty, value string, occurredAt time.Time) *NginxPropertyChangedEvent {
+	return &NginxPropertyChangedEvent{
+		aggregateID: aggregateID,
+		property:    property,
+		value:       value,
+		occurredAt:  occurredAt,
+	}
+}
+
+func (e *NginxPropertyChangedEvent) OccurredAt() time.Time   { return e.occurredAt }
+func (e *NginxPropertyChangedEvent) EventType() string       { return "application.nginx_property.changed" }
+func (e *NginxPropertyChangedEvent) AggregateID() string     { return e.aggregateID }
+func (e *NginxPropertyChangedEvent) Property() NginxProperty { return e.property }
+func (e *NginxPropertyChangedEvent) Value() string           { return e.value }
+
+type ChecksWaitToRetireChangedEvent struct {
+	aggregateID string
+	seconds     int
+	occurredAt  time.Time
+}
+
+func NewChecksWaitToRetireChangedEvent(aggregateID string, seconds int, occurredAt time.Time) *ChecksWaitToRetireChangedEvent {
+	return &ChecksWaitToRetireChangedEvent{
+		aggregateID: aggregateID,
+		seconds:     seconds,
+		occurredAt:  occurredAt,
+	}
+}
+
+func (e *ChecksWaitToRetireChangedEvent) OccurredAt() time.Time { return e.occurredAt }
+func (e *ChecksWaitToRetireChangedEvent) EventType() string {
+	return "application.checks_wait_to_retire.changed"
+}
+func (e *ChecksWaitToRetireChangedEvent) AggregateID() string { return e.aggregateID }
+func (e *ChecksWaitToRetireChangedEvent) Seconds() int        { return e.seconds }
+
+type BuilderChangedEvent struct {
+	aggregateID string
+	builder     Builder
+	occurredAt  time.Time
+}
+
+func NewBuilderChangedEvent(aggregateID string, builder Builder, occurredAt time.Time) *BuilderChangedEvent {
+	return &BuilderChangedEvent{
+		aggregateID: aggregateID,
+		builder:     builder,
+		occurredAt:  occurredAt,
+	}
+}
+
+func (e *BuilderChangedEvent) OccurredAt() time.Time { return e.occurredAt }
+func (e *BuilderChangedEvent) EventType() string     { return "application.builder.changed" }
+func (e *BuilderChangedEvent) AggregateID() string   { return e.aggregateID }
+func (e *BuilderChangedEvent) Builder() Builder      { return e.builder }
+
+// ApplicationDriftDetectedEvent reports an app whose scale, domains or deployed
+// commit changed between two drift detection snapshots
+type ApplicationDriftDetectedEvent struct {
+	aggregateID string
+	changes     []DriftChange
+	occurredAt  time.Time
+}
+
+func NewApplicationDriftDetectedEvent(aggregateID string, changes []DriftChange, occurredAt time.Time) *ApplicationDriftDetectedEvent {
+	return &ApplicationDriftDetectedEvent{
+		aggregateID: aggregateID,
+		changes:     slices.Clone(changes),
+		occurredAt:  occurredAt,
+	}
+}
+
+func (e *ApplicationDriftDetectedEvent) OccurredAt() time.Time  { return e.occurredAt }
+func (e *ApplicationDriftDetectedEvent) EventType() string      { return "application.drift.detected" }
+func (e *ApplicationDriftDetectedEvent) AggregateID() string    { return e.aggregateID }
+func (e *ApplicationDriftDetectedEvent) Changes() []DriftChange { return slices.Clone(e.changes) }
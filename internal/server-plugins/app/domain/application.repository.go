@@ -8,6 +8,7 @@ type ApplicationRepository interface {
 	Save(ctx context.Context, app *Application) error
 	GetByName(ctx context.Context, name *ApplicationName) (*Application, error)
 	GetAll(ctx context.Context) ([]*Application, error)
+	GetNames(ctx context.Context) ([]*ApplicationName, error)
 	GetByState(ctx context.Context, state *ApplicationState) ([]*Application, error)
 	Delete(ctx context.Context, name *ApplicationName) error
 	Exists(ctx context.Context, name *ApplicationName) (bool, error)
@@ -18,6 +19,20 @@ type ApplicationRepository interface {
 	GetRecentlyDeployed(ctx context.Context, limit int) ([]*Application, error)
 	CountByState(ctx context.Context) (map[StateValue]int, error)
 	GetApplicationMetrics(ctx context.Context) (*ApplicationMetrics, error)
+	GetHTTPSStatus(ctx context.Context, name *ApplicationName) (*HTTPSStatus, error)
+	GetNginxConfig(ctx context.Context, name *ApplicationName) (map[NginxProperty]string, error)
+	GetNginxLogs(ctx context.Context, name *ApplicationName, lines int) (*NginxLogs, error)
+	GetChecksSettings(ctx context.Context, name *ApplicationName) (*ChecksSettings, error)
+	GetBuilderSettings(ctx context.Context, name *ApplicationName) (*BuilderSettings, error)
+	GetConfig(ctx context.Context, name *ApplicationName) (map[string]string, error)
+	GetGlobalConfig(ctx context.Context) (map[string]string, error)
+	GetDomainsReport(ctx context.Context, name *ApplicationName) (*DomainsReport, error)
+	GetProcessReport(ctx context.Context, name *ApplicationName) (*ProcessReport, error)
+	GetDiskUsage(ctx context.Context, name *ApplicationName) (*DiskUsage, error)
+	GetImages(ctx context.Context, name *ApplicationName) (*AppImages, error)
+	RemoveImageTag(ctx context.Context, name *ApplicationName, tag string) error
+	GetGitInfo(ctx context.Context, name *ApplicationName) (*GitInfo, error)
+	GetLogs(ctx context.Context, name *ApplicationName, processType string, lines int, window LogTimeWindow) (*RuntimeLogs, error)
 }
 
 type ApplicationMetrics struct {
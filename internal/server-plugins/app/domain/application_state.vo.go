@@ -9,12 +9,23 @@ import (
 type StateValue string
 
 const (
-	StateExists  StateValue = "exists"  // Application exists in Dokku but status unknown
-	StateRunning StateValue = "running" // Application is running (has active processes)
-	StateStopped StateValue = "stopped" // Application exists but is not running
-	StateError   StateValue = "error"   // Application is in an error state
+	StateExists    StateValue = "exists"    // Application exists in Dokku but status unknown
+	StateDeploying StateValue = "deploying" // A deployment of the application is in progress
+	StateRunning   StateValue = "running"   // Application is running (has active processes)
+	StateStopped   StateValue = "stopped"   // Application exists but is not running
+	StateError     StateValue = "error"     // Application is in an error state
 )
 
+// stateTransitions lists the states each state may move to. A deployment always
+// goes through deploying, and only ends in running or error.
+var stateTransitions = map[StateValue][]StateValue{
+	StateExists:    {StateDeploying, StateError},
+	StateDeploying: {StateRunning, StateError},
+	StateRunning:   {StateDeploying, StateStopped, StateError},
+	StateStopped:   {StateDeploying, StateRunning, StateError},
+	StateError:     {StateDeploying, StateRunning, StateStopped},
+}
+
 // ApplicationState represents the state of an application
 type ApplicationState struct {
 	value StateValue
@@ -61,6 +72,16 @@ func (as *ApplicationState) IsRunning() bool {
 	return as.value == StateRunning
 }
 
+// IsDeploying checks if a deployment is in progress
+func (as *ApplicationState) IsDeploying() bool {
+	return as.value == StateDeploying
+}
+
+// CanTransitionTo reports whether the state may move to target
+func (as *ApplicationState) CanTransitionTo(target StateValue) bool {
+	return slices.Contains(stateTransitions[as.value], target)
+}
+
 // IsDeployed checks if the application is deployed (running or stopped, but not just exists)
 func (as *ApplicationState) IsDeployed() bool {
 	return as.value == StateRunning ||
@@ -75,10 +96,11 @@ func (as *ApplicationState) IsError() bool {
 // Description returns a human-readable description of the state
 func (as *ApplicationState) Description() string {
 	descriptions := map[StateValue]string{
-		StateExists:  "Application exists in Dokku",
-		StateRunning: "Application is running",
-		StateStopped: "Application is stopped",
-		StateError:   "Application is in error state",
+		StateExists:    "Application exists in Dokku",
+		StateDeploying: "Application is being deployed",
+		StateRunning:   "Application is running",
+		StateStopped:   "Application is stopped",
+		StateError:     "Application is in error state",
 	}
 
 	if desc, exists := descriptions[as.value]; exists {
@@ -90,7 +112,7 @@ func (as *ApplicationState) Description() string {
 // isValidState checks if a state value is valid
 func isValidState(state StateValue) bool {
 	validStates := []StateValue{
-		StateExists, StateRunning, StateStopped, StateError,
+		StateExists, StateDeploying, StateRunning, StateStopped, StateError,
 	}
 
 	return slices.Contains(validStates, state)
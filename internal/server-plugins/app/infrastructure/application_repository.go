@@ -2,13 +2,21 @@ package infrastructure
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	dokkuApi "github.com/dokku-mcp/dokku-mcp/internal/dokku-api"
 	app "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/app/domain"
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
+	"github.com/dokku-mcp/dokku-mcp/internal/shared/audit"
 	"github.com/dokku-mcp/dokku-mcp/internal/shared/process"
 )
 
@@ -16,15 +24,21 @@ import (
 type DokkuApplicationRepository struct {
 	client dokkuApi.DokkuClient
 	dokku  *DokkuApplicationAdapter
-	logger *slog.Logger
+	// scope limits the applications that can be read or changed; nil allows all of them
+	scope *shared.AppScope
+	// auditLog records the config keys set or unset by Save; nil when auditing is disabled
+	auditLog audit.EventSink
+	logger   *slog.Logger
 }
 
-// NewDokkuApplicationRepository creates a new application repository
-func NewDokkuApplicationRepository(client dokkuApi.DokkuClient, logger *slog.Logger) app.ApplicationRepository {
+// NewDokkuApplicationRepository creates a new application repository restricted to scope
+func NewDokkuApplicationRepository(client dokkuApi.DokkuClient, scope *shared.AppScope, auditLog audit.EventSink, logger *slog.Logger) app.ApplicationRepository {
 	return &DokkuApplicationRepository{
-		client: client,
-		dokku:  NewDokkuApplicationAdapter(client, logger),
-		logger: logger,
+		client:   client,
+		dokku:    NewDokkuApplicationAdapter(client, logger),
+		scope:    scope,
+		auditLog: auditLog,
+		logger:   logger,
 	}
 }
 
@@ -40,6 +54,10 @@ func (r *DokkuApplicationRepository) GetAll(ctx context.Context) ([]*app.Applica
 	applications := make([]*app.Application, 0, len(appNames))
 
 	for _, appName := range appNames {
+		if !r.scope.Allows(appName) {
+			continue
+		}
+
 		appNameVO, err := app.NewApplicationName(appName)
 		if err != nil {
 			r.logger.Warn("Invalid application name, skipped",
@@ -63,8 +81,33 @@ func (r *DokkuApplicationRepository) GetAll(ctx context.Context) ([]*app.Applica
 	return applications, nil
 }
 
+// GetNames lists the names of the applications in scope without loading them
+func (r *DokkuApplicationRepository) GetNames(ctx context.Context) ([]*app.ApplicationName, error) {
+	appNames, err := r.dokku.GetApplications(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve application names: %w", err)
+	}
+
+	names := make([]*app.ApplicationName, 0, len(appNames))
+	for _, appName := range appNames {
+		if !r.scope.Allows(appName) {
+			continue
+		}
+		name, err := app.NewApplicationName(appName)
+		if err != nil {
+			return nil, fmt.Errorf("invalid application name %q: %w", appName, err)
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
 // GetByName retrieves an application by its name
 func (r *DokkuApplicationRepository) GetByName(ctx context.Context, name *app.ApplicationName) (*app.Application, error) {
+	if err := r.scope.Check(name.Value()); err != nil {
+		return nil, err
+	}
+
 	r.logger.Debug("Retrieving application by name",
 		"app_name", name.Value())
 
@@ -80,23 +123,10 @@ func (r *DokkuApplicationRepository) GetByName(ctx context.Context, name *app.Ap
 		return nil, app.ErrApplicationNotFound
 	}
 
-	// Get ps:report information for proper state detection
-	info, err := r.tryGetPsReportInfo(ctx, name.Value())
-	if err != nil {
-		r.logger.Warn("Failed to retrieve ps:report - using basic info",
-			"error", err,
-			"app_name", name.Value())
-
-		// Try to get basic information via apps:report as fallback
-		if reportInfo, reportErr := r.tryGetBasicApplicationInfo(ctx, name.Value()); reportErr == nil {
-			info = reportInfo
-		} else {
-			info = make(map[string]string)
-		}
-	}
+	reports := r.fetchApplicationReports(ctx, name.Value())
 
-	// Determine state from Dokku output
-	state := r.determineStateFromInfo(info)
+	// Determine state from the container statuses, or from the report heuristics without them
+	state := r.determineState(reports)
 
 	// Create application entity with the determined state
 	appInstance, err := app.NewApplicationWithState(name.Value(), state)
@@ -104,22 +134,21 @@ func (r *DokkuApplicationRepository) GetByName(ctx context.Context, name *app.Ap
 		return nil, fmt.Errorf("failed to create application entity: %w", err)
 	}
 
-	// Get configuration
-	config, err := r.dokku.GetApplicationConfig(ctx, name.Value())
-	if err != nil {
-		r.logger.Warn("Failed to retrieve configuration - using empty configuration",
-			"error", err,
-			"app_name", name.Value())
-		config = make(map[string]string)
-	}
-
 	// Update application with retrieved information
-	if err := r.updateApplicationFromInfo(appInstance, info, config); err != nil {
+	if err := r.updateApplicationFromInfo(appInstance, reports.info, reports.config); err != nil {
 		r.logger.Warn("Failed to update application from Dokku information",
 			"error", err,
 			"app_name", name.Value())
 	}
 
+	// domains:report is the canonical source of domains and tells whether vhosts are enabled
+	if reports.domains != nil {
+		appInstance.ApplyDomainsReport(reports.domains)
+	}
+
+	// The entity mirrors what Dokku reports; hydrating it is not a change to save
+	appInstance.ClearEvents()
+
 	r.logger.Debug("Application retrieved successfully",
 		"app_name", name.Value(),
 		"state", state)
@@ -128,6 +157,10 @@ func (r *DokkuApplicationRepository) GetByName(ctx context.Context, name *app.Ap
 
 // Save saves an application
 func (r *DokkuApplicationRepository) Save(ctx context.Context, application *app.Application) error {
+	if err := r.scope.Check(application.Name().Value()); err != nil {
+		return err
+	}
+
 	r.logger.Debug("Saving application",
 		"app_name", application.Name().Value())
 
@@ -139,8 +172,14 @@ func (r *DokkuApplicationRepository) Save(ctx context.Context, application *app.
 	if !exists {
 		_, err := r.dokku.ExecuteCommand(ctx, app.CommandAppsCreate, []string{application.Name().Value()})
 		if err != nil {
+			if isInvalidNameOutput(err) {
+				return fmt.Errorf("failed to create application: %w: %w", app.ErrInvalidApplicationName, err)
+			}
 			return fmt.Errorf("failed to create application: %w", err)
 		}
+		// The cached apps:exists failure and apps:list output predate the app
+		r.client.InvalidateByApp(application.Name().Value())
+		r.client.InvalidateByCommand(app.CommandAppsList.String())
 	}
 
 	for _, event := range application.GetEvents() {
@@ -151,27 +190,479 @@ func (r *DokkuApplicationRepository) Save(ctx context.Context, application *app.
 				return fmt.Errorf("failed to scale application during save: %w", err)
 			}
 			r.logger.Debug("Applied scaling event", "app", e.AggregateID(), "process", e.ProcessType(), "scale", e.NewScale())
-		}
-	}
-	application.ClearEvents()
-
-	// Update configuration if it exists
-	if config := application.Configuration(); config != nil {
-		configMap := r.extractEnvironmentVars(config)
-		if len(configMap) > 0 {
-			if err := r.dokku.SetApplicationConfig(ctx, application.Name().Value(), configMap); err != nil {
+		case *app.FormationScaledEvent:
+			if err := r.dokku.ScaleApplicationProcesses(ctx, e.AggregateID(), e.Scales()); err != nil {
+				r.logger.Error("Failed to apply formation scaling event", "error", err)
+				return fmt.Errorf("failed to scale application during save: %w", err)
+			}
+			r.logger.Debug("Applied formation scaling event", "app", e.AggregateID(), "scales", e.Scales())
+		case *app.ForceHTTPSChangedEvent:
+			if err := r.dokku.SetNginxProperty(ctx, e.AggregateID(), "hsts", strconv.FormatBool(e.Enabled())); err != nil {
+				r.logger.Error("Failed to apply force-https event", "error", err)
+				return fmt.Errorf("failed to update HTTPS enforcement: %w", err)
+			}
+			r.logger.Debug("Applied force-https event", "app", e.AggregateID(), "enabled", e.Enabled())
+		case *app.NginxPropertyChangedEvent:
+			if err := r.dokku.SetNginxProperty(ctx, e.AggregateID(), string(e.Property()), e.Value()); err != nil {
+				r.logger.Error("Failed to apply nginx property event", "error", err)
+				return fmt.Errorf("failed to update nginx %s: %w", e.Property(), err)
+			}
+			r.logger.Debug("Applied nginx property event", "app", e.AggregateID(), "property", e.Property())
+		case *app.ChecksWaitToRetireChangedEvent:
+			if err := r.dokku.SetChecksProperty(ctx, e.AggregateID(), "wait-to-retire", strconv.Itoa(e.Seconds())); err != nil {
+				r.logger.Error("Failed to apply checks wait-to-retire event", "error", err)
+				return fmt.Errorf("failed to update checks wait-to-retire: %w", err)
+			}
+			r.logger.Debug("Applied checks wait-to-retire event", "app", e.AggregateID(), "seconds", e.Seconds())
+		case *app.BuilderChangedEvent:
+			if err := r.dokku.SetBuilderProperty(ctx, e.AggregateID(), "selected", string(e.Builder())); err != nil {
+				r.logger.Error("Failed to apply builder event", "error", err)
+				return fmt.Errorf("failed to update builder: %w", err)
+			}
+			r.logger.Debug("Applied builder event", "app", e.AggregateID(), "builder", e.Builder())
+		case *app.EnvironmentRestoredEvent:
+			// Previous values first: they matter most, and are kept even if removing the
+			// added variables fails (config:unset is blocked when security.blacklist contains "unset", as in config.yaml.example)
+			if len(e.Previous()) > 0 {
+				if err := r.dokku.SetApplicationConfig(ctx, e.AggregateID(), e.Previous(), false); err != nil {
+					r.logger.Error("Failed to restore previous variables", "error", err)
+					return fmt.Errorf("failed to restore configuration: %w", err)
+				}
+				r.recordConfigChange(ctx, e.AggregateID(), app.CommandConfigSet, slices.Sorted(maps.Keys(e.Previous())))
+			}
+			if len(e.Removed()) > 0 {
+				if err := r.dokku.UnsetApplicationConfig(ctx, e.AggregateID(), e.Removed(), false); err != nil {
+					r.logger.Error("Failed to remove variables while restoring configuration", "error", err)
+					return fmt.Errorf("failed to remove added variables %v: %w", e.Removed(), err)
+				}
+				r.recordConfigChange(ctx, e.AggregateID(), app.CommandConfigUnset, e.Removed())
+			}
+			r.logger.Debug("Applied configuration restore event", "app", e.AggregateID(), "restored", len(e.Previous()), "removed", len(e.Removed()))
+		case *app.EnvironmentConfiguredEvent:
+			if err := r.dokku.SetApplicationConfig(ctx, e.AggregateID(), e.Variables(), e.NoRestart()); err != nil {
+				r.logger.Error("Failed to apply configuration event", "error", err)
 				return fmt.Errorf("failed to update configuration: %w", err)
 			}
+			r.recordConfigChange(ctx, e.AggregateID(), app.CommandConfigSet, slices.Sorted(maps.Keys(e.Variables())))
+			r.logger.Debug("Applied configuration event", "app", e.AggregateID(), "nb_vars", len(e.Variables()), "no_restart", e.NoRestart())
+		case *app.EnvironmentUnsetEvent:
+			if err := r.dokku.UnsetApplicationConfig(ctx, e.AggregateID(), e.Keys(), e.NoRestart()); err != nil {
+				r.logger.Error("Failed to apply unset configuration event", "error", err)
+				return fmt.Errorf("failed to unset configuration: %w", err)
+			}
+			r.recordConfigChange(ctx, e.AggregateID(), app.CommandConfigUnset, e.Keys())
+			r.logger.Debug("Applied unset configuration event", "app", e.AggregateID(), "nb_keys", len(e.Keys()), "no_restart", e.NoRestart())
+		case *app.DomainAddedEvent:
+			if err := r.dokku.AddApplicationDomain(ctx, e.AggregateID(), e.Domain()); err != nil {
+				r.logger.Error("Failed to apply domain added event", "error", err)
+				return fmt.Errorf("failed to add domain: %w", err)
+			}
+			r.logger.Debug("Applied domain added event", "app", e.AggregateID(), "domain", e.Domain())
+		case *app.DomainRemovedEvent:
+			if err := r.dokku.RemoveApplicationDomain(ctx, e.AggregateID(), e.Domain()); err != nil {
+				r.logger.Error("Failed to apply domain removed event", "error", err)
+				return fmt.Errorf("failed to remove domain: %w", err)
+			}
+			r.logger.Debug("Applied domain removed event", "app", e.AggregateID(), "domain", e.Domain())
+		case *app.ApplicationRestartRequestedEvent:
+			if err := r.dokku.RestartApplication(ctx, e.AggregateID()); err != nil {
+				r.logger.Error("Failed to apply restart event", "error", err)
+				return fmt.Errorf("failed to restart application: %w", err)
+			}
+			r.logger.Debug("Applied restart event", "app", e.AggregateID())
 		}
 	}
+	application.ClearEvents()
 
 	r.logger.Debug("Application saved successfully",
 		"app_name", application.Name().Value())
 	return nil
 }
 
+// recordConfigChange audits the keys set or unset on an application, with the
+// caller's identity when the request is authenticated. Values are never recorded.
+func (r *DokkuApplicationRepository) recordConfigChange(ctx context.Context, appName string, action app.ApplicationCommand, keys []string) {
+	if r.auditLog == nil {
+		return
+	}
+
+	rawKeys, err := json.Marshal(keys)
+	if err != nil {
+		r.logger.Warn("Failed to encode audited config keys", "app_name", appName, "error", err)
+		return
+	}
+
+	event := audit.Event{
+		Timestamp:  time.Now(),
+		Action:     action.String(),
+		Resource:   appName,
+		Parameters: map[string]audit.AuditParameter{"keys": audit.NewJSONParameter(rawKeys)},
+		Result:     "success",
+	}
+	if tenant, ok := shared.GetTenantContext(ctx); ok {
+		event.TenantID = tenant.TenantID
+		event.UserID = tenant.UserID
+	}
+
+	if err := r.auditLog.Record(ctx, event); err != nil {
+		r.logger.Warn("Failed to audit config change", "app_name", appName, "action", action, "error", err)
+	}
+}
+
+// GetHTTPSStatus reads the proxy type, certificate and HTTPS enforcement state of an application
+func (r *DokkuApplicationRepository) GetHTTPSStatus(ctx context.Context, name *app.ApplicationName) (*app.HTTPSStatus, error) {
+	if err := r.scope.Check(name.Value()); err != nil {
+		return nil, err
+	}
+
+	proxyType, err := r.dokku.GetReportProperty(ctx, app.CommandProxyReport, name.Value(), "--proxy-type")
+	if err != nil {
+		return nil, err
+	}
+
+	status := &app.HTTPSStatus{ProxyType: proxyType}
+
+	sslEnabled, err := r.dokku.GetReportProperty(ctx, app.CommandCertsReport, name.Value(), "--ssl-enabled")
+	if err != nil {
+		r.logger.Debug("Failed to read certificate status", "app_name", name.Value(), "error", err)
+	}
+	status.SSLEnabled = sslEnabled == "true"
+
+	if status.SupportsForceHTTPS() {
+		hsts, err := r.dokku.GetReportProperty(ctx, app.CommandNginxReport, name.Value(), "--nginx-computed-hsts")
+		if err != nil {
+			r.logger.Debug("Failed to read nginx hsts setting", "app_name", name.Value(), "error", err)
+		}
+		status.ForceHTTPS = hsts == "true"
+	}
+
+	return status, nil
+}
+
+// GetNginxConfig reads the supported app-level nginx properties; an empty value means Dokku's default
+func (r *DokkuApplicationRepository) GetNginxConfig(ctx context.Context, name *app.ApplicationName) (map[app.NginxProperty]string, error) {
+	if err := r.scope.Check(name.Value()); err != nil {
+		return nil, err
+	}
+
+	config := make(map[app.NginxProperty]string)
+	for _, property := range app.GetSupportedNginxProperties() {
+		value, err := r.dokku.GetReportProperty(ctx, app.CommandNginxReport, name.Value(), "--nginx-"+string(property))
+		if err != nil {
+			return nil, err
+		}
+		config[property] = value
+	}
+	return config, nil
+}
+
+// GetNginxLogs reads the end of an application's nginx access and error logs.
+// Dokku prints a fixed tail of each log, which is cut down to the requested lines.
+func (r *DokkuApplicationRepository) GetNginxLogs(ctx context.Context, name *app.ApplicationName, lines int) (*app.NginxLogs, error) {
+	if err := r.scope.Check(name.Value()); err != nil {
+		return nil, err
+	}
+
+	accessLog, err := r.dokku.ExecuteCommand(ctx, app.CommandNginxAccessLogs, []string{name.Value()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read nginx access logs of %s: %w", name.Value(), err)
+	}
+	errorLog, err := r.dokku.ExecuteCommand(ctx, app.CommandNginxErrorLogs, []string{name.Value()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read nginx error logs of %s: %w", name.Value(), err)
+	}
+
+	return &app.NginxLogs{
+		AppName:   name.Value(),
+		Lines:     lines,
+		AccessLog: tailLines(string(accessLog), lines),
+		ErrorLog:  tailLines(string(errorLog), lines),
+	}, nil
+}
+
+// tailLines keeps the last n lines of a command output
+func tailLines(output string, n int) string {
+	output = strings.TrimRight(output, "\n")
+	if output == "" || n <= 0 {
+		return ""
+	}
+	lines := strings.Split(output, "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// GetConfig reads the environment variables of an application
+func (r *DokkuApplicationRepository) GetConfig(ctx context.Context, name *app.ApplicationName) (map[string]string, error) {
+	if err := r.scope.Check(name.Value()); err != nil {
+		return nil, err
+	}
+	return r.dokku.ShowApplicationConfig(ctx, name.Value())
+}
+
+// GetDomainsReport reads the app and global vhosts of an application from domains:report
+func (r *DokkuApplicationRepository) GetDomainsReport(ctx context.Context, name *app.ApplicationName) (*app.DomainsReport, error) {
+	if err := r.scope.Check(name.Value()); err != nil {
+		return nil, err
+	}
+	return r.tryGetDomainsReport(ctx, name.Value())
+}
+
+// GetGlobalConfig reads the global environment variables inherited by every application
+func (r *DokkuApplicationRepository) GetGlobalConfig(ctx context.Context) (map[string]string, error) {
+	return r.dokku.ShowGlobalConfig(ctx)
+}
+
+// GetChecksSettings reads the zero-downtime checks settings from checks:report and the app config
+func (r *DokkuApplicationRepository) GetChecksSettings(ctx context.Context, name *app.ApplicationName) (*app.ChecksSettings, error) {
+	if err := r.scope.Check(name.Value()); err != nil {
+		return nil, err
+	}
+
+	disabled, err := r.dokku.GetReportProperty(ctx, app.CommandChecksReport, name.Value(), "--checks-disabled-list")
+	if err != nil {
+		return nil, err
+	}
+	skipped, err := r.dokku.GetReportProperty(ctx, app.CommandChecksReport, name.Value(), "--checks-skipped-list")
+	if err != nil {
+		return nil, err
+	}
+	waitToRetire, err := r.dokku.GetReportProperty(ctx, app.CommandChecksReport, name.Value(), "--checks-computed-wait-to-retire")
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := r.dokku.GetApplicationConfig(ctx, name.Value())
+	if err != nil {
+		r.logger.Debug("Failed to read checks timeout", "app_name", name.Value(), "error", err)
+		config = map[string]string{}
+	}
+
+	return app.NewChecksSettings(name.Value(), disabled, skipped, waitToRetire, config), nil
+}
+
+// GetBuilderSettings reads the selected and computed builder from builder:report
+func (r *DokkuApplicationRepository) GetBuilderSettings(ctx context.Context, name *app.ApplicationName) (*app.BuilderSettings, error) {
+	if err := r.scope.Check(name.Value()); err != nil {
+		return nil, err
+	}
+
+	selected, err := r.dokku.GetReportProperty(ctx, app.CommandBuilderReport, name.Value(), "--builder-selected")
+	if err != nil {
+		return nil, err
+	}
+	computed, err := r.dokku.GetReportProperty(ctx, app.CommandBuilderReport, name.Value(), "--builder-computed-selected")
+	if err != nil {
+		return nil, err
+	}
+
+	return app.NewBuilderSettings(name.Value(), selected, computed), nil
+}
+
+// GetProcessReport reads the full ps:report of an application, with container
+// restart counts from ps:inspect when the containers can be inspected
+func (r *DokkuApplicationRepository) GetProcessReport(ctx context.Context, name *app.ApplicationName) (*app.ProcessReport, error) {
+	if err := r.scope.Check(name.Value()); err != nil {
+		return nil, err
+	}
+
+	info, err := r.tryGetPsReportInfo(ctx, name.Value())
+	if err != nil {
+		return nil, err
+	}
+
+	report := app.ParseProcessReport(name.Value(), info)
+	if len(report.Processes) == 0 {
+		return report, nil
+	}
+
+	output, err := r.dokku.ExecuteCommand(ctx, app.CommandPsInspect, []string{name.Value()})
+	if err != nil {
+		r.logger.Debug("Failed to inspect containers, restart counts unavailable", "app_name", name.Value(), "error", err)
+		return report, nil
+	}
+
+	var containers []struct {
+		ID           string `json:"Id"`
+		RestartCount int    `json:"RestartCount"`
+		State        struct {
+			ExitCode  int       `json:"ExitCode"`
+			StartedAt time.Time `json:"StartedAt"`
+		} `json:"State"`
+	}
+	if err := json.Unmarshal(output, &containers); err != nil {
+		r.logger.Debug("Failed to parse ps:inspect output", "app_name", name.Value(), "error", err)
+		return report, nil
+	}
+
+	states := make(map[string]app.ContainerState, len(containers))
+	for _, container := range containers {
+		states[container.ID] = app.ContainerState{
+			RestartCount: container.RestartCount,
+			ExitCode:     container.State.ExitCode,
+			StartedAt:    container.State.StartedAt,
+		}
+	}
+	report.SetContainerStates(states)
+
+	return report, nil
+}
+
+// GetGitInfo reads the deploy branch and deployed commit from git:report, and the
+// git:sync remote from apps:report when the app was deployed with git:sync
+func (r *DokkuApplicationRepository) GetGitInfo(ctx context.Context, name *app.ApplicationName) (*app.GitInfo, error) {
+	if err := r.scope.Check(name.Value()); err != nil {
+		return nil, err
+	}
+
+	output, err := r.dokku.ExecuteCommand(ctx, app.CommandGitReport, []string{name.Value()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute git:report: %w", err)
+	}
+
+	appsReport, err := r.tryGetBasicApplicationInfo(ctx, name.Value())
+	if err != nil {
+		r.logger.Debug("Failed to read deploy source, git remote unavailable", "app_name", name.Value(), "error", err)
+	}
+
+	return app.ParseGitInfo(name.Value(), parseReportOutput(output), appsReport), nil
+}
+
+// GetLogs retrieves the last lines of an application's logs. An application that was
+// never deployed has no containers to read from, so its logs are empty rather than an error.
+// dokku logs has no time flags, so the lines are filtered by their timestamp here.
+func (r *DokkuApplicationRepository) GetLogs(ctx context.Context, name *app.ApplicationName, processType string, lines int, window app.LogTimeWindow) (*app.RuntimeLogs, error) {
+	if err := r.scope.Check(name.Value()); err != nil {
+		return nil, err
+	}
+
+	logs, err := r.dokku.GetApplicationLogs(ctx, name.Value(), processType, lines)
+	if err != nil {
+		if errors.Is(err, app.ErrApplicationNotDeployed) {
+			return &app.RuntimeLogs{}, nil
+		}
+		return nil, err
+	}
+	return filterLogsByTime(logs, lines, window), nil
+}
+
+// filterLogsByTime keeps the log lines whose timestamp falls within the window.
+// Lines without a timestamp belong to the message above them and follow its fate.
+// When all the requested lines came back and the oldest is still after the start
+// of the window, earlier lines of the window were cut off and the logs are truncated.
+func filterLogsByTime(logs string, lines int, window app.LogTimeWindow) *app.RuntimeLogs {
+	if window.IsZero() || logs == "" {
+		return &app.RuntimeLogs{Logs: logs}
+	}
+
+	var kept []string
+	var oldest time.Time
+	retrieved := 0
+	keep := false
+	for line := range strings.SplitSeq(strings.TrimRight(logs, "\n"), "\n") {
+		retrieved++
+		if timestamp, ok := dokkuApi.ParseLogTimestamp(line); ok {
+			if oldest.IsZero() {
+				oldest = timestamp
+			}
+			keep = window.Contains(timestamp)
+		}
+		if keep {
+			kept = append(kept, line)
+		}
+	}
+
+	result := &app.RuntimeLogs{
+		Truncated: retrieved >= lines && !oldest.IsZero() && (window.Since.IsZero() || oldest.After(window.Since)),
+	}
+	if len(kept) > 0 {
+		result.Logs = strings.Join(kept, "\n") + "\n"
+	}
+	return result
+}
+
+// GetDiskUsage measures an application's images from tags:list and lists its storage mounts.
+// Only the docker-local scheduler keeps images on the host; other schedulers are reported as unsupported.
+func (r *DokkuApplicationRepository) GetDiskUsage(ctx context.Context, name *app.ApplicationName) (*app.DiskUsage, error) {
+	if err := r.scope.Check(name.Value()); err != nil {
+		return nil, err
+	}
+
+	scheduler, err := r.dokku.GetReportProperty(ctx, app.CommandSchedulerReport, name.Value(), "--scheduler-selected")
+	if err != nil {
+		return nil, err
+	}
+	if scheduler != app.DiskUsageScheduler {
+		return app.NewUnsupportedDiskUsage(name.Value(), scheduler,
+			fmt.Sprintf("disk usage can only be measured for the %s scheduler", app.DiskUsageScheduler)), nil
+	}
+
+	output, err := r.dokku.ExecuteCommand(ctx, app.CommandTagsList, []string{name.Value()})
+	if err != nil {
+		if errors.Is(err, app.ErrApplicationNotDeployed) {
+			return app.NewUnsupportedDiskUsage(name.Value(), scheduler, "application has not been deployed"), nil
+		}
+		return nil, fmt.Errorf("failed to list images: %w", err)
+	}
+
+	mounts := []string{}
+	deployMounts, err := r.dokku.GetReportProperty(ctx, app.CommandStorageReport, name.Value(), "--storage-deploy-mounts")
+	if err != nil {
+		r.logger.Debug("Failed to read storage mounts", "app_name", name.Value(), "error", err)
+	} else {
+		mounts = app.ParseStorageMounts(deployMounts)
+	}
+
+	return app.NewDiskUsage(name.Value(), scheduler, app.ParseAppImages(string(output)), mounts), nil
+}
+
+// GetImages lists an application's images from tags:list. Only the docker-local scheduler
+// keeps the images on the Dokku host; other schedulers are reported as unsupported.
+func (r *DokkuApplicationRepository) GetImages(ctx context.Context, name *app.ApplicationName) (*app.AppImages, error) {
+	if err := r.scope.Check(name.Value()); err != nil {
+		return nil, err
+	}
+
+	scheduler, err := r.dokku.GetReportProperty(ctx, app.CommandSchedulerReport, name.Value(), "--scheduler-selected")
+	if err != nil {
+		return nil, err
+	}
+	if scheduler != app.DiskUsageScheduler {
+		return app.NewUnsupportedAppImages(name.Value(), scheduler,
+			fmt.Sprintf("images can only be listed for the %s scheduler", app.DiskUsageScheduler)), nil
+	}
+
+	output, err := r.dokku.ExecuteCommand(ctx, app.CommandTagsList, []string{name.Value()})
+	if err != nil {
+		if errors.Is(err, app.ErrApplicationNotDeployed) {
+			return app.NewUnsupportedAppImages(name.Value(), scheduler, "application has not been deployed"), nil
+		}
+		return nil, fmt.Errorf("failed to list images: %w", err)
+	}
+
+	return &app.AppImages{
+		AppName:   name.Value(),
+		Scheduler: scheduler,
+		Supported: true,
+		Images:    app.ParseAppImages(string(output)),
+	}, nil
+}
+
+// RemoveImageTag removes a tag from one of an application's images
+func (r *DokkuApplicationRepository) RemoveImageTag(ctx context.Context, name *app.ApplicationName, tag string) error {
+	if err := r.scope.Check(name.Value()); err != nil {
+		return err
+	}
+	return r.dokku.RemoveImageTag(ctx, name.Value(), tag)
+}
+
 // Delete deletes an application
 func (r *DokkuApplicationRepository) Delete(ctx context.Context, name *app.ApplicationName) error {
+	if err := r.scope.Check(name.Value()); err != nil {
+		return err
+	}
+
 	r.logger.Debug("Deleting application",
 		"app_name", name.Value())
 
@@ -179,6 +670,9 @@ func (r *DokkuApplicationRepository) Delete(ctx context.Context, name *app.Appli
 	if err != nil {
 		return fmt.Errorf("failed to delete application: %w", err)
 	}
+	// Only the destroyed app's entries are dropped, other apps stay cached
+	r.client.InvalidateByApp(name.Value())
+	r.client.InvalidateByCommand(app.CommandAppsList.String())
 
 	r.logger.Debug("Application deleted successfully",
 		"app_name", name.Value())
@@ -187,6 +681,10 @@ func (r *DokkuApplicationRepository) Delete(ctx context.Context, name *app.Appli
 
 // Exists checks if an application exists
 func (r *DokkuApplicationRepository) Exists(ctx context.Context, name *app.ApplicationName) (bool, error) {
+	if err := r.scope.Check(name.Value()); err != nil {
+		return false, err
+	}
+
 	r.logger.Debug("Checking application existence",
 		"app_name", name.Value())
 
@@ -211,8 +709,9 @@ func (r *DokkuApplicationRepository) List(ctx context.Context, offset, limit int
 
 	total := len(allApps)
 
-	start := min(offset, total)
-	end := min(start+limit, total)
+	// The limit is bounded by the remaining apps before adding, so a huge limit cannot overflow
+	start := min(max(offset, 0), total)
+	end := start + min(max(limit, 0), total-start)
 
 	pagedApps := allApps[start:end]
 
@@ -398,6 +897,8 @@ func (r *DokkuApplicationRepository) updateApplicationFromInfo(app *app.Applicat
 	// Process processes if present in information
 	if processesStr, ok := info["ps.scale"]; ok && processesStr != "" {
 		r.parseProcesses(app, processesStr)
+	} else {
+		r.parseProcessStatuses(app, info)
 	}
 
 	// Process domains if present
@@ -452,58 +953,167 @@ func (r *DokkuApplicationRepository) parseProcesses(application *app.Application
 	}
 }
 
+// parseProcessStatuses derives the formation from ps:report "Status <type> <n>" entries
+func (r *DokkuApplicationRepository) parseProcessStatuses(application *app.Application, info map[string]string) {
+	report := app.ParseProcessReport(application.Name().Value(), info)
+
+	for processType, scale := range report.Scale {
+		processTypeVO, err := process.NewProcessType(processType)
+		if err != nil {
+			r.logger.Debug("Ignoring unsupported process type from ps:report",
+				"type", processType,
+				"error", err)
+			continue
+		}
+
+		if err := application.AddProcessForScaling(processTypeVO, scale); err != nil {
+			r.logger.Warn("Failed to add process for scaling",
+				"type", processType,
+				"error", err)
+		}
+	}
+}
+
 // tryGetPsReportInfo tries to retrieve ps:report information for proper state detection
 func (r *DokkuApplicationRepository) tryGetPsReportInfo(ctx context.Context, appName string) (map[string]string, error) {
-	output, err := r.dokku.ExecuteCommand(ctx, app.CommandPsReport, []string{appName})
+	result, err := r.client.ExecuteWithAutoFormat(ctx, app.CommandPsReport.String(), []string{appName})
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute ps:report: %w", err)
 	}
 
+	if len(result.JSONData) > 0 {
+		info, err := psReportFieldsFromJSON(result.JSONData)
+		if err == nil {
+			return info, nil
+		}
+		r.logger.Debug("ps:report JSON output is not an object, parsing as text",
+			"app_name", appName,
+			"error", err)
+	}
+
 	// Parse ps:report output to extract deployment and running state
-	info := make(map[string]string)
-	lines := strings.Split(string(output), "\n")
-	for _, line := range lines {
-		if strings.Contains(line, ":") {
-			parts := strings.SplitN(line, ":", 2)
-			if len(parts) == 2 {
-				key := strings.TrimSpace(parts[0])
-				value := strings.TrimSpace(parts[1])
-				info[key] = value
+	return parseReportOutput(result.RawOutput), nil
+}
+
+// applicationReports holds what GetByName reads from Dokku to hydrate an application
+type applicationReports struct {
+	info map[string]string
+	// processes is nil when ps:report failed and info comes from apps:report
+	processes *app.ProcessReport
+	config    map[string]string
+	domains   *app.DomainsReport
+}
+
+// fetchApplicationReports reads the process state, configuration and domains of an
+// application concurrently: Dokku has no report combining them, so running the SSH
+// calls side by side saves round-trips. A failed fetch falls back to what the others
+// provide instead of failing the whole read.
+func (r *DokkuApplicationRepository) fetchApplicationReports(ctx context.Context, appName string) applicationReports {
+	var reports applicationReports
+	fetches := []func(){
+		func() {
+			// Get ps:report information for proper state detection
+			info, err := r.tryGetPsReportInfo(ctx, appName)
+			if err == nil {
+				reports.processes = app.ParseProcessReport(appName, info)
+			} else {
+				r.logger.Warn("Failed to retrieve ps:report - using basic info",
+					"error", err,
+					"app_name", appName)
+
+				// Try to get basic information via apps:report as fallback
+				if reportInfo, reportErr := r.tryGetBasicApplicationInfo(ctx, appName); reportErr == nil {
+					info = stateFieldsFromApplicationInfo(reportInfo)
+				} else {
+					info = make(map[string]string)
+				}
 			}
-		}
+			reports.info = info
+		},
+		func() {
+			config, err := r.dokku.GetApplicationConfig(ctx, appName)
+			if err != nil {
+				r.logger.Warn("Failed to retrieve configuration - using empty configuration",
+					"error", err,
+					"app_name", appName)
+				config = make(map[string]string)
+			}
+			reports.config = config
+		},
+		func() {
+			report, err := r.tryGetDomainsReport(ctx, appName)
+			if err != nil {
+				r.logger.Debug("Failed to retrieve domains:report - keeping domains from the app report",
+					"error", err,
+					"app_name", appName)
+				return
+			}
+			reports.domains = report
+		},
 	}
 
-	return info, nil
+	var wg sync.WaitGroup
+	for _, fetch := range fetches {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			fetch()
+		}()
+	}
+	wg.Wait()
+	return reports
 }
 
 // tryGetBasicApplicationInfo tries to retrieve basic information
-func (r *DokkuApplicationRepository) tryGetBasicApplicationInfo(ctx context.Context, appName string) (map[string]string, error) {
-	output, err := r.dokku.ExecuteCommand(ctx, app.CommandAppsReport, []string{appName})
+func (r *DokkuApplicationRepository) tryGetBasicApplicationInfo(ctx context.Context, appName string) (*app.ApplicationInfo, error) {
+	info, err := r.dokku.GetApplicationReport(ctx, appName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute apps:report: %w", err)
 	}
+	return info, nil
+}
+
+// stateFieldsFromApplicationInfo exposes apps:report under the keys determineStateFromInfo
+// reads; apps:report has no deployment state, so "Deployed" is left out
+func stateFieldsFromApplicationInfo(info *app.ApplicationInfo) map[string]string {
+	return map[string]string{
+		"App locked":        strconv.FormatBool(info.Locked),
+		"App deploy source": info.DeploySource,
+	}
+}
+
+// tryGetDomainsReport reads the app and global vhosts from domains:report
+func (r *DokkuApplicationRepository) tryGetDomainsReport(ctx context.Context, appName string) (*app.DomainsReport, error) {
+	output, err := r.dokku.ExecuteCommand(ctx, app.CommandDomainsReport, []string{appName})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute domains:report: %w", err)
+	}
+
+	return app.ParseDomainsReport(parseReportOutput(output)), nil
+}
 
-	// Parse apps:report output to extract basic information
+// parseReportOutput reads the "Key: value" lines of a Dokku *:report output.
+// Values keep everything after the first colon, so URLs survive intact.
+func parseReportOutput(output []byte) map[string]string {
 	info := make(map[string]string)
-	lines := strings.Split(string(output), "\n")
-	for _, line := range lines {
-		if strings.Contains(line, ":") {
-			parts := strings.SplitN(line, ":", 2)
-			if len(parts) == 2 {
-				key := strings.TrimSpace(parts[0])
-				value := strings.TrimSpace(parts[1])
-				info[key] = value
-			}
+	for _, line := range strings.Split(string(output), "\n") {
+		key, value, found := strings.Cut(line, ":")
+		if !found {
+			continue
 		}
+		info[strings.TrimSpace(key)] = strings.TrimSpace(value)
 	}
-
-	return info, nil
+	return info
 }
 
-// extractEnvironmentVars extracts environment variables from configuration
-func (r *DokkuApplicationRepository) extractEnvironmentVars(config *app.ApplicationConfiguration) map[string]string {
-	// For now, return empty map - implement when ApplicationConfiguration interface is defined
-	return make(map[string]string)
+// determineState reads the state from the status of each container reported by
+// ps:report, so a crash-looping app is in error even though it is scaled up. The
+// heuristics of determineStateFromInfo are only used when ps:report failed.
+func (r *DokkuApplicationRepository) determineState(reports applicationReports) app.StateValue {
+	if reports.processes != nil {
+		return reports.processes.State()
+	}
+	return r.determineStateFromInfo(reports.info)
 }
 
 // determineStateFromInfo determines the application state from Dokku output
@@ -572,3 +1182,9 @@ func (r *DokkuApplicationRepository) hasRunningProcesses(processesStr string) bo
 	}
 	return false
 }
+
+// isInvalidNameOutput reports whether Dokku rejected an app name our own validation accepted
+func isInvalidNameOutput(err error) bool {
+	output, ok := dokkuApi.CommandOutput(err)
+	return ok && strings.Contains(strings.ToLower(output), "name must")
+}
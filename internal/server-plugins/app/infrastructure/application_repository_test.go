@@ -1,9 +1,18 @@
 package infrastructure
 
 import (
+	"context"
+	"errors"
+	"math"
+	"slices"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	dokkuApi "github.com/dokku-mcp/dokku-mcp/internal/dokku-api"
 	app "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/app/domain"
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
 )
 
 func TestDetermineStateFromInfo(t *testing.T) {
@@ -37,3 +46,509 @@ func TestDetermineStateFromInfo(t *testing.T) {
 		}
 	})
 }
+
+func TestGetProcessReportShowsRestartingProcess(t *testing.T) {
+	client := &recordingClient{outputs: map[string][]byte{
+		app.CommandPsReport.String(): []byte(`=====> my-app ps information
+       Deployed:                      true
+       Processes:                     2
+       Ps restart policy:             on-failure:10
+       Running:                       true
+       Status web 1:                  running (CID: 03ea8977f37)
+       Status worker 1:               restarting (CID: 9b1c2d3e4f5)`),
+		app.CommandPsInspect.String(): []byte(`[
+  {"Id": "03ea8977f37e1d2c3b4a", "RestartCount": 0, "State": {"ExitCode": 0, "StartedAt": "2025-01-01T00:00:00.123456789Z"}},
+  {"Id": "9b1c2d3e4f5a6b7c8d9e", "RestartCount": 7, "State": {"ExitCode": 137, "StartedAt": "2025-01-01T10:30:00Z"}}
+]`),
+	}}
+	repo := NewDokkuApplicationRepository(client, nil, nil, newTestLogger())
+
+	name, err := app.NewApplicationName("my-app")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	report, err := repo.GetProcessReport(context.Background(), name)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !report.Deployed || report.RestartPolicy != "on-failure:10" {
+		t.Fatalf("unexpected report header: %+v", report)
+	}
+	if len(report.Processes) != 2 || report.Scale["web"] != 1 || report.Scale["worker"] != 1 {
+		t.Fatalf("unexpected processes: %+v", report.Processes)
+	}
+
+	worker := report.Processes[1]
+	if worker.Type != "worker" || worker.Status != "restarting" || worker.ContainerID != "9b1c2d3e4f5" {
+		t.Fatalf("unexpected worker instance: %+v", worker)
+	}
+	if worker.RestartCount == nil || *worker.RestartCount != 7 {
+		t.Fatalf("expected worker restart count 7, got %v", worker.RestartCount)
+	}
+	if worker.LastExitCode == nil || *worker.LastExitCode != 137 {
+		t.Fatalf("expected worker last exit code 137, got %v", worker.LastExitCode)
+	}
+	if worker.StartedAt == nil || !worker.StartedAt.Equal(time.Date(2025, 1, 1, 10, 30, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected worker start time: %v", worker.StartedAt)
+	}
+	if report.Processes[0].LastExitCode != nil {
+		t.Fatalf("expected no exit code for a running container that never restarted, got %v", *report.Processes[0].LastExitCode)
+	}
+}
+
+func TestGetDiskUsageMeasuresImages(t *testing.T) {
+	client := &recordingClient{outputs: map[string][]byte{
+		app.CommandSchedulerReport.String(): []byte("docker-local\n"),
+		app.CommandTagsList.String(): []byte(`=====> Image tags for dokku/my-app
+REPOSITORY     TAG       IMAGE ID       CREATED        SIZE
+dokku/my-app   latest    7e2f4c1a9b3d   2 hours ago    512MB
+dokku/my-app   v2        7e2f4c1a9b3d   2 hours ago    512MB
+dokku/my-app   v1        1c5d8e0f2a6b   3 days ago     1.2GB
+`),
+		app.CommandStorageReport.String(): []byte("-v /var/lib/dokku/data/storage/my-app:/app/storage\n"),
+	}}
+	repo := NewDokkuApplicationRepository(client, nil, nil, newTestLogger())
+
+	name, err := app.NewApplicationName("my-app")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	usage, err := repo.GetDiskUsage(context.Background(), name)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The image tagged twice must only be counted once
+	if !usage.Supported || len(usage.Images) != 2 {
+		t.Fatalf("unexpected usage: %+v", usage)
+	}
+	if usage.ImageBytes != 1_712_000_000 {
+		t.Fatalf("expected image bytes 1712000000, got %d", usage.ImageBytes)
+	}
+	if !strings.Contains(usage.Note, "container writable layers are not measured") {
+		t.Fatalf("expected container sizes to be reported as unmeasured, got %q", usage.Note)
+	}
+	if len(usage.StorageMounts) != 1 || usage.StorageMounts[0] != "/var/lib/dokku/data/storage/my-app:/app/storage" {
+		t.Fatalf("unexpected storage mounts: %v", usage.StorageMounts)
+	}
+}
+
+func TestGetDiskUsageSkipsOtherSchedulers(t *testing.T) {
+	client := &recordingClient{outputs: map[string][]byte{
+		app.CommandSchedulerReport.String(): []byte("k3s\n"),
+	}}
+	repo := NewDokkuApplicationRepository(client, nil, nil, newTestLogger())
+
+	name, err := app.NewApplicationName("my-app")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	usage, err := repo.GetDiskUsage(context.Background(), name)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if usage.Supported || usage.Scheduler != "k3s" || usage.Note == "" {
+		t.Fatalf("expected an unsupported report for k3s, got %+v", usage)
+	}
+	if _, listed := client.find(app.CommandTagsList.String()); listed {
+		t.Fatalf("images must not be listed for non docker-local schedulers")
+	}
+}
+
+func TestGetGitInfoParsesGitReport(t *testing.T) {
+	client := &recordingClient{outputs: map[string][]byte{
+		app.CommandGitReport.String(): []byte(`=====> my-app git information
+       Git deploy branch:             main
+       Git global deploy branch:      master
+       Git keep git dir:              false
+       Git rev env var:               GIT_REV
+       Git sha:                       5f3c9a1e8b7d6c5b4a3928171605f4e3d2c1b0a9
+       Git source image:
+       Git last updated at:           1735689600`),
+		app.CommandAppsReport.String(): []byte(`=====> my-app app information
+       App created at:                1735600000
+       App deploy source:             git-sync
+       App deploy source metadata:    https://github.com/example/my-app.git#main
+       App dir:                       /home/dokku/my-app
+       App locked:                    false`),
+	}}
+	repo := NewDokkuApplicationRepository(client, nil, nil, newTestLogger())
+
+	name, err := app.NewApplicationName("my-app")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	info, err := repo.GetGitInfo(context.Background(), name)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !info.Deployed || info.DeployBranch != "main" {
+		t.Fatalf("unexpected deploy state: %+v", info)
+	}
+	if info.SHA != "5f3c9a1e8b7d6c5b4a3928171605f4e3d2c1b0a9" || info.ShortSHA != "5f3c9a1" {
+		t.Fatalf("unexpected deployed commit: %q (%q)", info.SHA, info.ShortSHA)
+	}
+	if info.Remote != "https://github.com/example/my-app.git" || info.Ref != "main" {
+		t.Fatalf("unexpected remote: %q#%q", info.Remote, info.Ref)
+	}
+	if info.LastUpdatedAt == nil || !info.LastUpdatedAt.Equal(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected last update: %v", info.LastUpdatedAt)
+	}
+}
+
+func TestGetByNameReadsDomainsReport(t *testing.T) {
+	client := &recordingClient{outputs: map[string][]byte{
+		app.CommandDomainsReport.String(): []byte(`=====> my-app domains information
+       Domains app enabled:           false
+       Domains app vhosts:            my-app.example.com www.example.com
+       Domains global enabled:        true
+       Domains global vhosts:         dokku.example.com`),
+	}}
+	repo := NewDokkuApplicationRepository(client, nil, nil, newTestLogger())
+
+	name, err := app.NewApplicationName("my-app")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	application, err := repo.GetByName(context.Background(), name)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	domains := application.GetDomains()
+	if len(domains) != 2 || domains[0] != "my-app.example.com" || domains[1] != "www.example.com" {
+		t.Fatalf("unexpected domains: %v", domains)
+	}
+	if application.VhostsEnabled() {
+		t.Fatal("expected vhosts to be reported as disabled")
+	}
+	if global := application.GetGlobalDomains(); len(global) != 1 || global[0] != "dokku.example.com" {
+		t.Fatalf("unexpected global domains: %v", global)
+	}
+	if events := application.GetEvents(); len(events) != 0 {
+		t.Fatalf("expected no pending events after hydration, got %d", len(events))
+	}
+}
+
+func TestRepositoryRejectsOutOfScopeApps(t *testing.T) {
+	client := &recordingClient{outputs: map[string][]byte{
+		app.CommandAppsList.String(): []byte("=====> My Apps\nbilling\nstaging-api\nstaging-web\n"),
+	}}
+	scope := shared.NewAppScope([]string{"staging-*", "billing"}, []string{"billing", "staging-web"})
+	repo := NewDokkuApplicationRepository(client, scope, nil, newTestLogger())
+
+	denied, err := app.NewApplicationName("billing")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	t.Run("read", func(t *testing.T) {
+		client.commands = nil
+		if _, err := repo.GetByName(context.Background(), denied); !errors.Is(err, app.ErrApplicationOutOfScope) {
+			t.Fatalf("expected ErrApplicationOutOfScope from GetByName, got %v", err)
+		}
+		if _, err := repo.GetConfig(context.Background(), denied); !errors.Is(err, app.ErrApplicationOutOfScope) {
+			t.Fatalf("expected ErrApplicationOutOfScope from GetConfig, got %v", err)
+		}
+		if len(client.commands) != 0 {
+			t.Fatalf("expected no command to run, got %+v", client.commands)
+		}
+	})
+
+	t.Run("write", func(t *testing.T) {
+		client.commands = nil
+		application, err := app.NewApplication("billing")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := application.ConfigureEnvironment(map[string]string{"MODE": "maintenance"}, false); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := repo.Save(context.Background(), application); !errors.Is(err, app.ErrApplicationOutOfScope) {
+			t.Fatalf("expected ErrApplicationOutOfScope from Save, got %v", err)
+		}
+		if err := repo.Delete(context.Background(), denied); !errors.Is(err, app.ErrApplicationOutOfScope) {
+			t.Fatalf("expected ErrApplicationOutOfScope from Delete, got %v", err)
+		}
+		if len(client.commands) != 0 {
+			t.Fatalf("expected no command to run, got %+v", client.commands)
+		}
+	})
+
+	t.Run("list", func(t *testing.T) {
+		apps, err := repo.GetAll(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(apps) != 1 || apps[0].Name().Value() != "staging-api" {
+			t.Fatalf("expected only staging-api, got %d apps", len(apps))
+		}
+	})
+}
+
+func TestListClampsAHugeLimit(t *testing.T) {
+	client := &recordingClient{outputs: map[string][]byte{
+		app.CommandAppsList.String(): []byte("=====> My Apps\napi\nweb\nworker\n"),
+	}}
+	repo := NewDokkuApplicationRepository(client, nil, nil, newTestLogger())
+
+	apps, total, err := repo.List(context.Background(), 1, math.MaxInt)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if total != 3 || len(apps) != 2 || apps[0].Name().Value() != "web" || apps[1].Name().Value() != "worker" {
+		t.Fatalf("expected web and worker out of 3 apps, got %d of %d", len(apps), total)
+	}
+}
+
+// overlapClient holds ps:report and config:show until both are running, so a
+// sequential caller waits out the timeout and is reported as not overlapping
+type overlapClient struct {
+	*recordingClient
+	mu       sync.Mutex
+	started  map[string]bool
+	bothRun  chan struct{}
+	overlaps int
+}
+
+func (c *overlapClient) ExecuteCommand(ctx context.Context, command string, args []string) ([]byte, error) {
+	if command == app.CommandPsReport.String() || command == app.CommandConfigShow.String() {
+		c.mu.Lock()
+		c.started[command] = true
+		if len(c.started) == 2 {
+			close(c.bothRun)
+		}
+		c.mu.Unlock()
+
+		select {
+		case <-c.bothRun:
+			c.mu.Lock()
+			c.overlaps++
+			c.mu.Unlock()
+		case <-time.After(time.Second):
+		}
+	}
+	return c.recordingClient.ExecuteCommand(ctx, command, args)
+}
+
+func (c *overlapClient) ExecuteWithAutoFormat(ctx context.Context, command string, args []string) (*dokkuApi.CommandResult, error) {
+	output, err := c.ExecuteCommand(ctx, command, args)
+	if err != nil {
+		return nil, err
+	}
+	return &dokkuApi.CommandResult{RawOutput: output}, nil
+}
+
+func TestGetByNameFetchesReportsConcurrently(t *testing.T) {
+	client := &overlapClient{
+		recordingClient: &recordingClient{outputs: map[string][]byte{
+			app.CommandPsReport.String(): []byte(`=====> my-app ps information
+       Deployed:                      true
+       Processes:                     1
+       Running:                       true
+       Status web 1:                  running (CID: 03ea8977f37)`),
+			app.CommandConfigShow.String(): []byte("=====> my-app env vars\nPORT:  5000\n"),
+		}},
+		started: map[string]bool{},
+		bothRun: make(chan struct{}),
+	}
+	repo := NewDokkuApplicationRepository(client, nil, nil, newTestLogger())
+
+	name, err := app.NewApplicationName("my-app")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	application, err := repo.GetByName(context.Background(), name)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if client.overlaps != 2 {
+		t.Fatalf("expected ps:report and config:show to run concurrently, %d of them saw the other in flight", client.overlaps)
+	}
+	if !application.State().IsRunning() {
+		t.Fatalf("expected the state from ps:report, got %s", application.State().Value())
+	}
+}
+
+func TestGetByNameDetectsCrashLoopFromPsReport(t *testing.T) {
+	// Scaled up and reported as running, but the worker container keeps restarting
+	client := &recordingClient{outputs: map[string][]byte{
+		app.CommandPsReport.String(): []byte(`{
+  "deployed": "true",
+  "processes": "2",
+  "ps-can-scale": "true",
+  "ps-restart-policy": "on-failure:10",
+  "running": "true",
+  "status-web-1": "running (CID: 03ea8977f37)",
+  "status-worker-1": "restarting (CID: 9b1c2d3e4f5)"
+}`),
+	}}
+	repo := NewDokkuApplicationRepository(client, nil, nil, newTestLogger())
+
+	name, err := app.NewApplicationName("my-app")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	application, err := repo.GetByName(context.Background(), name)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := application.State().Value(); got != app.StateError {
+		t.Fatalf("expected state error for a crash-looping worker, got %s", got)
+	}
+}
+
+func TestGetLogsFiltersByTimeWindow(t *testing.T) {
+	client := &recordingClient{outputs: map[string][]byte{
+		app.CommandLogs.String(): []byte(`2025-12-13T09:00:00.000000000Z app[web.1]: booting
+2025-12-13T10:30:00.000000000Z app[web.1]: panic: nil map
+	goroutine 1 [running]:
+2025-12-13T11:45:00.000000000Z app[web.1]: listening on :5000
+`),
+	}}
+	repo := NewDokkuApplicationRepository(client, nil, nil, newTestLogger())
+
+	name, err := app.NewApplicationName("my-app")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	now := time.Date(2025, 12, 13, 12, 0, 0, 0, time.UTC)
+
+	t.Run("relative since", func(t *testing.T) {
+		window, err := app.NewLogTimeWindow("2h", "", now)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		logs, err := repo.GetLogs(context.Background(), name, "", 100, window)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := "2025-12-13T10:30:00.000000000Z app[web.1]: panic: nil map\n\tgoroutine 1 [running]:\n2025-12-13T11:45:00.000000000Z app[web.1]: listening on :5000\n"
+		if logs.Logs != want || logs.Truncated {
+			t.Fatalf("unexpected logs:\n%s", logs.Logs)
+		}
+	})
+
+	t.Run("absolute until", func(t *testing.T) {
+		window, err := app.NewLogTimeWindow("", "2025-12-13T10:00:00Z", now)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		logs, err := repo.GetLogs(context.Background(), name, "", 100, window)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if logs.Logs != "2025-12-13T09:00:00.000000000Z app[web.1]: booting\n" {
+			t.Fatalf("unexpected logs:\n%s", logs.Logs)
+		}
+	})
+
+	t.Run("no line in range", func(t *testing.T) {
+		window, err := app.NewLogTimeWindow("10m", "", now)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		logs, err := repo.GetLogs(context.Background(), name, "", 100, window)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if logs.Logs != "" {
+			t.Fatalf("expected no logs, got:\n%s", logs.Logs)
+		}
+	})
+
+	t.Run("window reaching before the retrieved lines", func(t *testing.T) {
+		window, err := app.NewLogTimeWindow("4h", "", now)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		logs, err := repo.GetLogs(context.Background(), name, "", 4, window)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !logs.Truncated {
+			t.Fatalf("expected the logs to be flagged truncated")
+		}
+
+		window, err = app.NewLogTimeWindow("2h", "", now)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if logs, _ = repo.GetLogs(context.Background(), name, "", 4, window); logs.Truncated {
+			t.Fatalf("expected a window starting after the oldest line not to be truncated")
+		}
+	})
+}
+
+func TestGetNginxLogsKeepsTheLastLines(t *testing.T) {
+	client := &recordingClient{outputs: map[string][]byte{
+		app.CommandNginxAccessLogs.String(): []byte(`10.0.0.1 - - [13/Dec/2025:10:00:00 +0000] "GET / HTTP/1.1" 200 612
+10.0.0.1 - - [13/Dec/2025:10:00:01 +0000] "GET /health HTTP/1.1" 200 2
+10.0.0.2 - - [13/Dec/2025:10:00:02 +0000] "POST /api HTTP/1.1" 502 166
+`),
+		app.CommandNginxErrorLogs.String(): []byte("2025/12/13 10:00:02 [error] 31#31: *7 connect() failed (111: Connection refused) while connecting to upstream\n"),
+	}}
+	repo := NewDokkuApplicationRepository(client, nil, nil, newTestLogger())
+
+	name, err := app.NewApplicationName("my-app")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	logs, err := repo.GetNginxLogs(context.Background(), name, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	wantAccess := `10.0.0.1 - - [13/Dec/2025:10:00:01 +0000] "GET /health HTTP/1.1" 200 2
+10.0.0.2 - - [13/Dec/2025:10:00:02 +0000] "POST /api HTTP/1.1" 502 166
+`
+	if logs.AccessLog != wantAccess {
+		t.Fatalf("unexpected access log:\n%s", logs.AccessLog)
+	}
+	if logs.ErrorLog != string(client.outputs[app.CommandNginxErrorLogs.String()]) {
+		t.Fatalf("unexpected error log:\n%s", logs.ErrorLog)
+	}
+	for _, command := range client.commands {
+		if (command.command == app.CommandNginxAccessLogs.String() || command.command == app.CommandNginxErrorLogs.String()) &&
+			!slices.Equal(command.args, []string{"my-app"}) {
+			t.Fatalf("unexpected arguments for %s: %v", command.command, command.args)
+		}
+	}
+}
+
+func TestSaveMapsDokkuNameRejectionToInvalidName(t *testing.T) {
+	client := &recordingClient{failures: map[string]error{
+		app.CommandAppsExists.String(): errors.New("exit status 20"),
+		app.CommandAppsCreate.String(): &dokkuApi.CommandError{
+			Command: app.CommandAppsCreate.String(),
+			Output:  []byte(" !     Name must be lowercase alphanumeric and may only contain dashes\n"),
+			Err:     errors.New("exit status 1"),
+		},
+	}}
+	repo := NewDokkuApplicationRepository(client, nil, nil, newTestLogger())
+
+	application, err := app.NewApplication("my-app")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err = repo.Save(context.Background(), application)
+	if !errors.Is(err, app.ErrInvalidApplicationName) {
+		t.Fatalf("expected ErrInvalidApplicationName, got %v", err)
+	}
+	if output, ok := dokkuApi.CommandOutput(err); !ok || !strings.Contains(output, "Name must be") {
+		t.Fatalf("expected Dokku's output to be kept, got %q", output)
+	}
+}
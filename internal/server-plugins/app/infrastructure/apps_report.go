@@ -0,0 +1,65 @@
+package infrastructure
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	app "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/app/domain"
+)
+
+// parseAppsReportJSON maps the output of apps:report <app> --format json,
+// whose keys are the report flags without dashes (e.g. "app-deploy-source")
+func parseAppsReportJSON(appName string, data []byte) (*app.ApplicationInfo, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var raw map[string]any
+	if err := decoder.Decode(&raw); err != nil {
+		return nil, fmt.Errorf("invalid apps:report JSON: %w", err)
+	}
+
+	fields := make(map[string]string, len(raw))
+	for key, value := range raw {
+		if value == nil {
+			continue
+		}
+		if text, ok := value.(string); ok {
+			fields[key] = text
+			continue
+		}
+		fields[key] = fmt.Sprint(value)
+	}
+	return applicationInfoFromFields(appName, fields), nil
+}
+
+// parseAppsReportText is the line-based fallback for Dokku versions without
+// JSON output; "App deploy source metadata:" becomes "app-deploy-source-metadata"
+func parseAppsReportText(appName string, output []byte) *app.ApplicationInfo {
+	fields := make(map[string]string)
+	for key, value := range parseReportOutput(output) {
+		fields[strings.ToLower(strings.Join(strings.Fields(key), "-"))] = value
+	}
+	return applicationInfoFromFields(appName, fields)
+}
+
+// applicationInfoFromFields builds an ApplicationInfo from report fields keyed by flag name
+func applicationInfoFromFields(appName string, fields map[string]string) *app.ApplicationInfo {
+	info := &app.ApplicationInfo{
+		AppName:              appName,
+		DeploySource:         fields["app-deploy-source"],
+		DeploySourceMetadata: fields["app-deploy-source-metadata"],
+		Dir:                  fields["app-dir"],
+		Locked:               fields["app-locked"] == "true",
+	}
+
+	if seconds, err := strconv.ParseInt(fields["app-created-at"], 10, 64); err == nil && seconds > 0 {
+		createdAt := time.Unix(seconds, 0).UTC()
+		info.CreatedAt = &createdAt
+	}
+
+	return info
+}
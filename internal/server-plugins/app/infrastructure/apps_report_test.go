@@ -0,0 +1,103 @@
+package infrastructure
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	app "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/app/domain"
+)
+
+func TestGetApplicationReport(t *testing.T) {
+	createdAt := time.Date(2024, 12, 30, 23, 6, 40, 0, time.UTC)
+
+	// Samples of apps:report <app> as printed by Dokku, with and without --format json
+	cases := []struct {
+		name   string
+		output string
+		want   app.ApplicationInfo
+	}{
+		{
+			name:   "json",
+			output: `{"app-created-at":"1735600000","app-deploy-source":"git-sync","app-deploy-source-metadata":"git@github.com:example/my-app.git#main","app-dir":"/home/dokku/my-app","app-locked":"false"}`,
+			want: app.ApplicationInfo{
+				AppName:              "my-app",
+				CreatedAt:            &createdAt,
+				DeploySource:         "git-sync",
+				DeploySourceMetadata: "git@github.com:example/my-app.git#main",
+				Dir:                  "/home/dokku/my-app",
+			},
+		},
+		{
+			name: "text",
+			output: `=====> my-app app information
+       App created at:                1735600000
+       App deploy source:             git-sync
+       App deploy source metadata:    https://github.com/example/my-app.git#v1.2.0
+       App dir:                       /home/dokku/my-app
+       App locked:                    true`,
+			want: app.ApplicationInfo{
+				AppName:              "my-app",
+				CreatedAt:            &createdAt,
+				DeploySource:         "git-sync",
+				DeploySourceMetadata: "https://github.com/example/my-app.git#v1.2.0",
+				Dir:                  "/home/dokku/my-app",
+				Locked:               true,
+			},
+		},
+		{
+			name: "text deployed from an image",
+			output: `=====> my-app app information
+       App created at:                1735600000
+       App deploy source:             docker-image
+       App deploy source metadata:    registry.example.com:5000/example/my-app:1.2.0
+       App dir:                       /home/dokku/my-app
+       App locked:                    false`,
+			want: app.ApplicationInfo{
+				AppName:              "my-app",
+				CreatedAt:            &createdAt,
+				DeploySource:         "docker-image",
+				DeploySourceMetadata: "registry.example.com:5000/example/my-app:1.2.0",
+				Dir:                  "/home/dokku/my-app",
+			},
+		},
+		{
+			name: "text without deploy",
+			output: `=====> my-app app information
+       App created at:                1735600000
+       App deploy source:
+       App deploy source metadata:
+       App dir:                       /home/dokku/my-app
+       App locked:                    false`,
+			want: app.ApplicationInfo{
+				AppName:   "my-app",
+				CreatedAt: &createdAt,
+				Dir:       "/home/dokku/my-app",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &recordingClient{outputs: map[string][]byte{
+				app.CommandAppsReport.String(): []byte(tc.output),
+			}}
+			adapter := NewDokkuApplicationAdapter(client, newTestLogger())
+
+			report, err := adapter.GetApplicationReport(context.Background(), "my-app")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if (report.CreatedAt == nil) != (tc.want.CreatedAt == nil) ||
+				(report.CreatedAt != nil && !report.CreatedAt.Equal(*tc.want.CreatedAt)) {
+				t.Fatalf("created at = %v, want %v", report.CreatedAt, tc.want.CreatedAt)
+			}
+			got, want := *report, tc.want
+			got.CreatedAt, want.CreatedAt = nil, nil
+			if got != want {
+				t.Fatalf("report = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
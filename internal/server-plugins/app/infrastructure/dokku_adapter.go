@@ -2,8 +2,12 @@ package infrastructure
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log/slog"
+	"maps"
+	"slices"
+	"sort"
 	"strings"
 
 	dokkuApi "github.com/dokku-mcp/dokku-mcp/internal/dokku-api"
@@ -32,7 +36,11 @@ func (a *DokkuApplicationAdapter) ExecuteCommand(ctx context.Context, command ap
 		return nil, fmt.Errorf("invalid application command: %s", command)
 	}
 
-	return a.client.ExecuteCommand(ctx, command.String(), args)
+	output, err := a.client.ExecuteCommand(ctx, command.String(), args)
+	if dokkuApi.IsNotDeployedError(err) {
+		return output, fmt.Errorf("%w: %w", app.ErrApplicationNotDeployed, err)
+	}
+	return output, err
 }
 
 // GetApplications retrieves list of all applications
@@ -71,6 +79,26 @@ func (a *DokkuApplicationAdapter) GetApplicationInfo(ctx context.Context, appNam
 	return info, nil
 }
 
+// GetApplicationReport reads apps:report for an application, as JSON when
+// supported and from the "Key: value" text output otherwise
+func (a *DokkuApplicationAdapter) GetApplicationReport(ctx context.Context, appName string) (*app.ApplicationInfo, error) {
+	result, err := a.client.ExecuteWithAutoFormat(ctx, app.CommandAppsReport.String(), []string{appName})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get application report %s: %w", appName, err)
+	}
+
+	if len(result.JSONData) > 0 {
+		info, err := parseAppsReportJSON(appName, result.JSONData)
+		if err == nil {
+			return info, nil
+		}
+		a.logger.Debug("apps:report JSON output is not an object, parsing as text",
+			"app_name", appName,
+			"error", err)
+	}
+	return parseAppsReportText(appName, result.RawOutput), nil
+}
+
 // GetApplicationConfig retrieves application configuration
 func (a *DokkuApplicationAdapter) GetApplicationConfig(ctx context.Context, appName string) (map[string]string, error) {
 	output, err := a.ExecuteCommand(ctx, app.CommandConfigShow, []string{appName})
@@ -82,13 +110,64 @@ func (a *DokkuApplicationAdapter) GetApplicationConfig(ctx context.Context, appN
 	return config, nil
 }
 
+// ShowApplicationConfig reads the full environment of an application, as JSON
+// when config:show supports --format json and as KEY=value text otherwise
+func (a *DokkuApplicationAdapter) ShowApplicationConfig(ctx context.Context, appName string) (map[string]string, error) {
+	config, err := a.showConfig(ctx, appName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to show application config %s: %w", appName, err)
+	}
+	return config, nil
+}
+
+// ShowGlobalConfig reads the global environment every application inherits
+func (a *DokkuApplicationAdapter) ShowGlobalConfig(ctx context.Context) (map[string]string, error) {
+	config, err := a.showConfig(ctx, "--global")
+	if err != nil {
+		return nil, fmt.Errorf("failed to show global config: %w", err)
+	}
+	return config, nil
+}
+
+// showConfig runs config:show for an app name or --global
+func (a *DokkuApplicationAdapter) showConfig(ctx context.Context, target string) (map[string]string, error) {
+	result, err := a.client.ExecuteWithAutoFormat(ctx, app.CommandConfigShow.String(), []string{target})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(result.JSONData) > 0 {
+		var config map[string]string
+		if err := json.Unmarshal(result.JSONData, &config); err == nil {
+			return config, nil
+		}
+		a.logger.Debug("config:show JSON output is not a key/value object, parsing as text",
+			"target", target)
+	}
+	if result.KeyValueData != nil {
+		return result.KeyValueData, nil
+	}
+	return dokkuApi.ParseKeyValueOutput(string(result.RawOutput), "="), nil
+}
+
 // SetApplicationConfig sets application configuration
-func (a *DokkuApplicationAdapter) SetApplicationConfig(ctx context.Context, appName string, config map[string]string) error {
+// With noRestart, Dokku stores the values without restarting the app, so they
+// only take effect on the next restart or deploy.
+func (a *DokkuApplicationAdapter) SetApplicationConfig(ctx context.Context, appName string, config map[string]string, noRestart bool) error {
 	var args []string
+	if noRestart {
+		args = append(args, "--no-restart")
+	}
 	args = append(args, appName)
 
-	for key, value := range config {
-		args = append(args, fmt.Sprintf("%s=%s", key, value))
+	keys := make([]string, 0, len(config))
+	for key := range config {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		args = append(args, fmt.Sprintf("%s=%s", key, config[key]))
 	}
 
 	_, err := a.ExecuteCommand(ctx, app.CommandConfigSet, args)
@@ -110,11 +189,128 @@ func (a *DokkuApplicationAdapter) ScaleApplication(ctx context.Context, appName
 	return nil
 }
 
-// GetApplicationLogs retrieves application logs
-func (a *DokkuApplicationAdapter) GetApplicationLogs(ctx context.Context, appName string, lines int) (string, error) {
+// ScaleApplicationProcesses scales several process types with a single ps:scale
+func (a *DokkuApplicationAdapter) ScaleApplicationProcesses(ctx context.Context, appName string, scales map[string]int) error {
+	args := []string{appName}
+	for _, processType := range slices.Sorted(maps.Keys(scales)) {
+		args = append(args, fmt.Sprintf("%s=%d", processType, scales[processType]))
+	}
+
+	if _, err := a.ExecuteCommand(ctx, app.CommandPsScale, args); err != nil {
+		return fmt.Errorf("failed to scale application %s: %w", appName, err)
+	}
+
+	return nil
+}
+
+// RestartApplication restarts every process of an application
+func (a *DokkuApplicationAdapter) RestartApplication(ctx context.Context, appName string) error {
+	if _, err := a.ExecuteCommand(ctx, app.CommandPsRestart, []string{appName}); err != nil {
+		return fmt.Errorf("failed to restart application %s: %w", appName, err)
+	}
+
+	return nil
+}
+
+// UnsetApplicationConfig removes environment variables from an application
+func (a *DokkuApplicationAdapter) UnsetApplicationConfig(ctx context.Context, appName string, keys []string, noRestart bool) error {
+	args := []string{}
+	if noRestart {
+		args = append(args, "--no-restart")
+	}
+	args = append(args, appName)
+	args = append(args, keys...)
+
+	if _, err := a.ExecuteCommand(ctx, app.CommandConfigUnset, args); err != nil {
+		return fmt.Errorf("failed to unset application config %s: %w", appName, err)
+	}
+	// Cached config:show output would still list the removed variables
+	a.client.InvalidateByApp(appName)
+
+	return nil
+}
+
+// AddApplicationDomain adds a vhost to an application
+func (a *DokkuApplicationAdapter) AddApplicationDomain(ctx context.Context, appName, domainName string) error {
+	if _, err := a.ExecuteCommand(ctx, app.CommandDomainsAdd, []string{appName, domainName}); err != nil {
+		return fmt.Errorf("failed to add domain %s to %s: %w", domainName, appName, err)
+	}
+	// Cached domains:report output would miss the new domain
+	a.client.InvalidateByApp(appName)
+	return nil
+}
+
+// RemoveApplicationDomain removes a vhost from an application
+func (a *DokkuApplicationAdapter) RemoveApplicationDomain(ctx context.Context, appName, domainName string) error {
+	if _, err := a.ExecuteCommand(ctx, app.CommandDomainsRemove, []string{appName, domainName}); err != nil {
+		return fmt.Errorf("failed to remove domain %s from %s: %w", domainName, appName, err)
+	}
+	a.client.InvalidateByApp(appName)
+	return nil
+}
+
+// RemoveImageTag removes a tag from an application image; docker deletes the image with its last tag
+func (a *DokkuApplicationAdapter) RemoveImageTag(ctx context.Context, appName, tag string) error {
+	if _, err := a.ExecuteCommand(ctx, app.CommandTagsDestroy, []string{appName, tag}); err != nil {
+		return fmt.Errorf("failed to remove image tag %s from %s: %w", tag, appName, err)
+	}
+	// Cached tags:list output would still list the removed image
+	a.client.InvalidateByApp(appName)
+	return nil
+}
+
+// GetReportProperty reads a single property from a report command (e.g. proxy:report app --proxy-type)
+func (a *DokkuApplicationAdapter) GetReportProperty(ctx context.Context, command app.ApplicationCommand, appName string, flag string) (string, error) {
+	output, err := a.ExecuteCommand(ctx, command, []string{appName, flag})
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s %s for %s: %w", command, flag, appName, err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// SetNginxProperty sets an nginx property and rebuilds the proxy config so it takes effect.
+// An empty value resets the property to Dokku's default.
+func (a *DokkuApplicationAdapter) SetNginxProperty(ctx context.Context, appName, property, value string) error {
+	args := []string{appName, property}
+	if value != "" {
+		args = append(args, value)
+	}
+
+	if _, err := a.ExecuteCommand(ctx, app.CommandNginxSet, args); err != nil {
+		return fmt.Errorf("failed to set nginx %s for %s: %w", property, appName, err)
+	}
+
+	if _, err := a.ExecuteCommand(ctx, app.CommandProxyBuildConfig, []string{appName}); err != nil {
+		return fmt.Errorf("failed to rebuild proxy config for %s: %w", appName, err)
+	}
+
+	return nil
+}
+
+// SetChecksProperty sets a zero-downtime checks property (e.g. wait-to-retire)
+func (a *DokkuApplicationAdapter) SetChecksProperty(ctx context.Context, appName, property, value string) error {
+	if _, err := a.ExecuteCommand(ctx, app.CommandChecksSet, []string{appName, property, value}); err != nil {
+		return fmt.Errorf("failed to set checks %s for %s: %w", property, appName, err)
+	}
+	return nil
+}
+
+// SetBuilderProperty sets a builder property (e.g. selected)
+func (a *DokkuApplicationAdapter) SetBuilderProperty(ctx context.Context, appName, property, value string) error {
+	if _, err := a.ExecuteCommand(ctx, app.CommandBuilderSet, []string{appName, property, value}); err != nil {
+		return fmt.Errorf("failed to set builder %s for %s: %w", property, appName, err)
+	}
+	return nil
+}
+
+// GetApplicationLogs retrieves the last lines of application logs, optionally for a single process type
+func (a *DokkuApplicationAdapter) GetApplicationLogs(ctx context.Context, appName, processType string, lines int) (string, error) {
 	args := []string{appName}
 	if lines > 0 {
-		args = append(args, "--tail", fmt.Sprintf("%d", lines))
+		args = append(args, "--num", fmt.Sprintf("%d", lines))
+	}
+	if processType != "" {
+		args = append(args, "--ps", processType)
 	}
 
 	output, err := a.ExecuteCommand(ctx, app.CommandLogs, args)
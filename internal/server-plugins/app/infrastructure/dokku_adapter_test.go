@@ -0,0 +1,287 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"reflect"
+	"sync"
+	"testing"
+
+	dokkuApi "github.com/dokku-mcp/dokku-mcp/internal/dokku-api"
+	app "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/app/domain"
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
+	"github.com/dokku-mcp/dokku-mcp/internal/shared/audit"
+	"github.com/dokku-mcp/dokku-mcp/internal/shared/process"
+)
+
+type executedCommand struct {
+	command string
+	args    []string
+}
+
+// recordingClient records executed commands; unimplemented DokkuClient methods panic if called
+type recordingClient struct {
+	dokkuApi.DokkuClient
+	mu              sync.Mutex
+	outputs         map[string][]byte
+	failures        map[string]error
+	commands        []executedCommand
+	invalidations   int
+	invalidatedApps []string
+}
+
+func (c *recordingClient) ExecuteCommand(ctx context.Context, command string, args []string) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.commands = append(c.commands, executedCommand{command: command, args: args})
+	return c.outputs[command], c.failures[command]
+}
+
+// ExecuteWithAutoFormat hands valid JSON outputs back as JSON and the others as text
+func (c *recordingClient) ExecuteWithAutoFormat(ctx context.Context, command string, args []string) (*dokkuApi.CommandResult, error) {
+	output, err := c.ExecuteCommand(ctx, command, args)
+	if err != nil {
+		return nil, err
+	}
+	result := &dokkuApi.CommandResult{RawOutput: output}
+	if json.Valid(output) {
+		result.JSONData = output
+	}
+	return result, nil
+}
+
+func (c *recordingClient) InvalidateCache() {
+	c.invalidations++
+}
+
+func (c *recordingClient) InvalidateByApp(appName string) {
+	c.invalidatedApps = append(c.invalidatedApps, appName)
+}
+
+func (c *recordingClient) InvalidateByCommand(prefix string) {}
+
+func (c *recordingClient) find(command string) (executedCommand, bool) {
+	for _, cmd := range c.commands {
+		if cmd.command == command {
+			return cmd, true
+		}
+	}
+	return executedCommand{}, false
+}
+
+func newTestLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+func TestSaveForwardsNoRestart(t *testing.T) {
+	cases := []struct {
+		name      string
+		noRestart bool
+		wantArgs  []string
+	}{
+		{name: "restart by default", noRestart: false, wantArgs: []string{"my-app", "A=1", "B=2"}},
+		{name: "no restart", noRestart: true, wantArgs: []string{"--no-restart", "my-app", "A=1", "B=2"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &recordingClient{}
+			repo := NewDokkuApplicationRepository(client, nil, nil, newTestLogger())
+
+			application, err := app.NewApplication("my-app")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err := application.ConfigureEnvironment(map[string]string{"B": "2", "A": "1"}, tc.noRestart); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if err := repo.Save(context.Background(), application); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			cmd, ok := client.find(app.CommandConfigSet.String())
+			if !ok {
+				t.Fatalf("expected config:set to be executed, got %+v", client.commands)
+			}
+			if !reflect.DeepEqual(cmd.args, tc.wantArgs) {
+				t.Fatalf("config:set args = %v, want %v", cmd.args, tc.wantArgs)
+			}
+		})
+	}
+}
+
+func TestSaveUnsetsConfigAndInvalidatesCache(t *testing.T) {
+	client := &recordingClient{}
+	repo := NewDokkuApplicationRepository(client, nil, nil, newTestLogger())
+
+	application, err := app.NewApplication("my-app")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := application.UnsetEnvironment([]string{"OLD_TOKEN", "LEGACY_URL"}, false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := repo.Save(context.Background(), application); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cmd, ok := client.find(app.CommandConfigUnset.String())
+	if !ok {
+		t.Fatalf("expected config:unset to be executed, got %+v", client.commands)
+	}
+	if want := []string{"my-app", "OLD_TOKEN", "LEGACY_URL"}; !reflect.DeepEqual(cmd.args, want) {
+		t.Fatalf("config:unset args = %v, want %v", cmd.args, want)
+	}
+	if !reflect.DeepEqual(client.invalidatedApps, []string{"my-app"}) || client.invalidations != 0 {
+		t.Fatalf("expected only my-app's cache entries to be invalidated, got %v (%d full invalidations)", client.invalidatedApps, client.invalidations)
+	}
+}
+
+func TestSaveAuditsConfigChanges(t *testing.T) {
+	auditLog := audit.NewMemorySink(0)
+	repo := NewDokkuApplicationRepository(&recordingClient{}, nil, auditLog, newTestLogger())
+
+	application, err := app.NewApplication("my-app")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := application.ConfigureEnvironment(map[string]string{"TOKEN": "s3cret", "DEBUG": "true"}, false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := application.UnsetEnvironment([]string{"LEGACY_URL"}, false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := application.RestoreEnvironment(map[string]string{"TOKEN": "old"}, []string{"DEBUG"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx := shared.WithTenantContext(context.Background(), &shared.TenantContext{TenantID: "acme", UserID: "bob"})
+	if err := repo.Save(ctx, application); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	events, _ := auditLog.Events(context.Background())
+	want := []struct{ action, keys string }{
+		{"config:set", `["DEBUG","TOKEN"]`},
+		{"config:unset", `["LEGACY_URL"]`},
+		{"config:set", `["TOKEN"]`},
+		{"config:unset", `["DEBUG"]`},
+	}
+	if len(events) != len(want) {
+		t.Fatalf("expected %d audited config changes, got %+v", len(want), events)
+	}
+	for i, event := range events {
+		raw, _ := event.Parameters["keys"].GetJSON()
+		if event.Action != want[i].action || string(raw) != want[i].keys || event.Resource != "my-app" || event.UserID != "bob" || event.TenantID != "acme" {
+			t.Fatalf("event %d = %s %s by %s, want %s %s by bob", i, event.Action, raw, event.UserID, want[i].action, want[i].keys)
+		}
+	}
+}
+
+// autoFormatClient returns a fixed result from ExecuteWithAutoFormat
+type autoFormatClient struct {
+	dokkuApi.DokkuClient
+	result *dokkuApi.CommandResult
+}
+
+func (c *autoFormatClient) ExecuteWithAutoFormat(ctx context.Context, command string, args []string) (*dokkuApi.CommandResult, error) {
+	return c.result, nil
+}
+
+func TestShowApplicationConfig(t *testing.T) {
+	cases := []struct {
+		name   string
+		result *dokkuApi.CommandResult
+	}{
+		{
+			name:   "json",
+			result: &dokkuApi.CommandResult{JSONData: []byte(`{"PORT":"5000","DATABASE_URL":"postgres://db"}`)},
+		},
+		{
+			name: "text fallback",
+			result: &dokkuApi.CommandResult{
+				RawOutput:    []byte("PORT=5000\nDATABASE_URL=postgres://db\n"),
+				KeyValueData: map[string]string{"PORT": "5000", "DATABASE_URL": "postgres://db"},
+			},
+		},
+		{
+			name:   "unparsed text",
+			result: &dokkuApi.CommandResult{RawOutput: []byte("PORT=5000\nDATABASE_URL=postgres://db\n")},
+		},
+	}
+
+	want := map[string]string{"PORT": "5000", "DATABASE_URL": "postgres://db"}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			adapter := NewDokkuApplicationAdapter(&autoFormatClient{result: tc.result}, newTestLogger())
+			config, err := adapter.ShowApplicationConfig(context.Background(), "my-app")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(config, want) {
+				t.Fatalf("config = %v, want %v", config, want)
+			}
+		})
+	}
+}
+
+func TestSaveAppliesDomainEvents(t *testing.T) {
+	client := &recordingClient{}
+	repo := NewDokkuApplicationRepository(client, nil, nil, newTestLogger())
+
+	application, err := app.NewApplication("my-app")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	application.ApplyDomainsReport(&app.DomainsReport{AppEnabled: true, AppVhosts: []string{"old.example.com"}})
+	if err := application.AddDomain("www.example.com"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := application.RemoveDomain("old.example.com"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := repo.Save(context.Background(), application); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	added, ok := client.find(app.CommandDomainsAdd.String())
+	if !ok || !reflect.DeepEqual(added.args, []string{"my-app", "www.example.com"}) {
+		t.Fatalf("expected domains:add my-app www.example.com, got %+v", client.commands)
+	}
+	removed, ok := client.find(app.CommandDomainsRemove.String())
+	if !ok || !reflect.DeepEqual(removed.args, []string{"my-app", "old.example.com"}) {
+		t.Fatalf("expected domains:remove my-app old.example.com, got %+v", client.commands)
+	}
+}
+
+func TestSaveScalesFormationWithOneCommand(t *testing.T) {
+	client := &recordingClient{}
+	repo := NewDokkuApplicationRepository(client, nil, nil, newTestLogger())
+
+	application, err := app.NewApplication("my-app")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := application.ScaleFormation(map[process.ProcessType]int{process.ProcessTypeWorker: 3, process.ProcessTypeWeb: 2}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := repo.Save(context.Background(), application); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var scales []executedCommand
+	for _, command := range client.commands {
+		if command.command == app.CommandPsScale.String() {
+			scales = append(scales, command)
+		}
+	}
+	if len(scales) != 1 || !reflect.DeepEqual(scales[0].args, []string{"my-app", "web=2", "worker=3"}) {
+		t.Fatalf("expected a single ps:scale my-app web=2 worker=3, got %+v", scales)
+	}
+}
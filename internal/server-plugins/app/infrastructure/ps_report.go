@@ -0,0 +1,51 @@
+package infrastructure
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// psReportFieldsFromJSON maps the output of ps:report <app> --format json, keyed by
+// report flags (e.g. "deployed", "status-web-1"), to the "Key: value" names of the
+// text report that ParseProcessReport and determineStateFromInfo read
+func psReportFieldsFromJSON(data []byte) (map[string]string, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var raw map[string]any
+	if err := decoder.Decode(&raw); err != nil {
+		return nil, fmt.Errorf("invalid ps:report JSON: %w", err)
+	}
+
+	info := make(map[string]string, len(raw))
+	for key, value := range raw {
+		if value == nil {
+			continue
+		}
+		text, ok := value.(string)
+		if !ok {
+			text = fmt.Sprint(value)
+		}
+		info[psReportTextKey(key)] = text
+	}
+	return info, nil
+}
+
+// psReportTextKey turns a report flag into its text report name: "ps-restart-policy"
+// becomes "Ps restart policy" and "status-web-1" becomes "Status web 1". The process
+// type of a status flag keeps its dashes so it stays a single word.
+func psReportTextKey(flag string) string {
+	if rest, ok := strings.CutPrefix(flag, "status-"); ok {
+		if i := strings.LastIndex(rest, "-"); i > 0 {
+			return "Status " + rest[:i] + " " + rest[i+1:]
+		}
+	}
+
+	words := strings.Split(flag, "-")
+	if len(words) > 0 && words[0] != "" {
+		words[0] = strings.ToUpper(words[0][:1]) + words[0][1:]
+	}
+	return strings.Join(words, " ")
+}
@@ -6,25 +6,46 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
+	"net/url"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	dokkuApi "github.com/dokku-mcp/dokku-mcp/internal/dokku-api"
+	"github.com/dokku-mcp/dokku-mcp/internal/server"
 	"github.com/dokku-mcp/dokku-mcp/internal/server-plugin/domain"
 	appusecases "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/app/application"
 	appdomain "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/app/domain"
 	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/app/infrastructure"
 	"github.com/dokku-mcp/dokku-mcp/internal/shared"
+	"github.com/dokku-mcp/dokku-mcp/internal/shared/audit"
 	"github.com/dokku-mcp/dokku-mcp/pkg/config"
 	"github.com/mark3labs/mcp-go/mcp"
 	"go.uber.org/fx"
 )
 
+// defaultDeploymentHistoryLimit is how many deployments get_deployment_history returns by default
+const defaultDeploymentHistoryLimit = 20
+
+// defaultHealthTimeoutSeconds is how long configure_app waits for the app to become healthy before rolling back
+const defaultHealthTimeoutSeconds = 60
+
 // AppsServerPlugin implements the unified ServerPlugin interface for Dokku applications
 // This replaces the legacy AppsPlugin and demonstrates the new architecture
 type AppsServerPlugin struct {
-	applicationUseCase *appusecases.ApplicationUseCase
-	logger             *slog.Logger
-	logsConfig         config.LogsConfig
+	applicationUseCase  *appusecases.ApplicationUseCase
+	logger              *slog.Logger
+	logsConfig          config.LogsConfig
+	imagesConfig        config.ImagesConfig
+	appListConfig       config.AppListConfig
+	auditLog            audit.EventLog
+	exposeCommandOutput bool
+	// streamDeployProgress is set under the SSE transport, which can notify progress while a deploy runs
+	streamDeployProgress bool
+	// allowConfigReveal lets the config resource return sensitive values unmasked (security.allow_config_reveal)
+	allowConfigReveal bool
 }
 
 // NewAppsServerPlugin creates a new unified apps server plugin
@@ -33,11 +54,23 @@ func NewAppsServerPlugin(
 	deploymentSvc shared.DeploymentService,
 	logger *slog.Logger,
 	logsConfig config.LogsConfig,
+	imagesConfig config.ImagesConfig,
+	appListConfig config.AppListConfig,
+	auditLog audit.EventLog,
+	exposeCommandOutput bool,
+	streamDeployProgress bool,
+	allowConfigReveal bool,
 ) domain.ServerPlugin {
 	return &AppsServerPlugin{
-		applicationUseCase: appusecases.NewApplicationUseCase(applicationRepo, deploymentSvc, logger),
-		logger:             logger,
-		logsConfig:         logsConfig,
+		applicationUseCase:   appusecases.NewApplicationUseCase(applicationRepo, deploymentSvc, logger),
+		logger:               logger,
+		logsConfig:           logsConfig,
+		imagesConfig:         imagesConfig,
+		appListConfig:        appListConfig,
+		auditLog:             auditLog,
+		exposeCommandOutput:  exposeCommandOutput,
+		streamDeployProgress: streamDeployProgress,
+		allowConfigReveal:    allowConfigReveal,
 	}
 }
 
@@ -66,9 +99,17 @@ func (p *AppsServerPlugin) GetResources(ctx context.Context) ([]domain.Resource,
 		{
 			URI:         "dokku://apps/list",
 			Name:        "Application List",
-			Description: "Complete list of all Dokku applications with status",
+			Description: fmt.Sprintf("List of Dokku applications with status, the first %d by default", p.appListConfig.DefaultLimit),
+			MIMEType:    "application/json",
+			Handler:     p.handleApplicationListResource,
+		},
+		{
+			URI:         "dokku://apps/list{?offset,limit}",
+			Name:        "Application List Page",
+			Description: "A page of the Dokku applications, with the total count to page through the rest",
 			MIMEType:    "application/json",
 			Handler:     p.handleApplicationListResource,
+			Template:    true,
 		},
 	}
 
@@ -81,6 +122,29 @@ func (p *AppsServerPlugin) GetResources(ctx context.Context) ([]domain.Resource,
 			MIMEType:    "application/json",
 			Handler:     p.handleRuntimeLogsResource,
 		})
+		resources = append(resources, domain.Resource{
+			URI:         fmt.Sprintf("dokku://apps/%s/config", app.Name().Value()),
+			Name:        fmt.Sprintf("Config: %s", app.Name().Value()),
+			Description: fmt.Sprintf("Environment variables of %s, with sensitive values masked", app.Name().Value()),
+			MIMEType:    "application/json",
+			Handler:     p.handleApplicationConfigResource,
+		})
+		if p.allowConfigReveal {
+			resources = append(resources, domain.Resource{
+				URI:         fmt.Sprintf("dokku://apps/%s/config?reveal=true", app.Name().Value()),
+				Name:        fmt.Sprintf("Config (revealed): %s", app.Name().Value()),
+				Description: fmt.Sprintf("Environment variables of %s including secret values in clear text", app.Name().Value()),
+				MIMEType:    "application/json",
+				Handler:     p.handleApplicationConfigResource,
+			})
+		}
+		resources = append(resources, domain.Resource{
+			URI:         fmt.Sprintf("dokku://apps/%s/deployments", app.Name().Value()),
+			Name:        fmt.Sprintf("Deployments: %s", app.Name().Value()),
+			Description: fmt.Sprintf("Deployment history of %s, most recent first", app.Name().Value()),
+			MIMEType:    "application/json",
+			Handler:     p.handleDeploymentHistoryResource,
+		})
 	}
 
 	return resources, nil
@@ -107,24 +171,228 @@ func (p *AppsServerPlugin) GetTools(ctx context.Context) ([]domain.Tool, error)
 			Builder:     p.buildScaleAppTool,
 			Handler:     p.handleScaleApp,
 		},
+		{
+			Name:        "scale_app_bulk",
+			Description: "Scale several process types of an application in one ps:scale",
+			Builder:     p.buildScaleAppBulkTool,
+			Handler:     p.handleScaleAppBulk,
+		},
+		{
+			Name:        "reconcile_app_formation",
+			Description: "Scale an application to a desired formation, changing only the process types that differ",
+			Builder:     p.buildReconcileAppFormationTool,
+			Handler:     p.handleReconcileAppFormation,
+		},
 		{
 			Name:        "configure_app",
 			Description: "Set environment variables with validation",
 			Builder:     p.buildConfigureAppTool,
 			Handler:     p.handleConfigureApp,
 		},
+		{
+			Name:        "unset_app_config",
+			Description: "Remove environment variables from an application",
+			Builder:     p.buildUnsetAppConfigTool,
+			Handler:     p.handleUnsetAppConfig,
+		},
+		{
+			Name:        "get_app_config_changes",
+			Description: "Get the audited configuration changes of an application",
+			Builder:     p.buildGetAppConfigChangesTool,
+			Handler:     p.handleGetAppConfigChanges,
+		},
+		{
+			Name:        "get_app_effective_config",
+			Description: "Show the environment an application runs with, global and app variables merged",
+			Builder:     p.buildGetAppEffectiveConfigTool,
+			Handler:     p.handleGetAppEffectiveConfig,
+		},
+		{
+			Name:        "enable_app_force_https",
+			Description: "Enforce HTTPS for an application at the nginx proxy",
+			Builder:     p.buildEnableAppForceHTTPSTool,
+			Handler:     p.handleEnableAppForceHTTPS,
+		},
+		{
+			Name:        "disable_app_force_https",
+			Description: "Stop enforcing HTTPS for an application at the nginx proxy",
+			Builder:     p.buildDisableAppForceHTTPSTool,
+			Handler:     p.handleDisableAppForceHTTPS,
+		},
+		{
+			Name:        "list_app_domains",
+			Description: "List the domains of an application and whether its vhosts are enabled",
+			Builder:     p.buildListAppDomainsTool,
+			Handler:     p.handleListAppDomains,
+		},
+		{
+			Name:        "get_app_urls",
+			Description: "Get the public URLs of an application",
+			Builder:     p.buildGetAppURLsTool,
+			Handler:     p.handleGetAppURLs,
+		},
+		{
+			Name:        "add_app_domain",
+			Description: "Add a domain to an application",
+			Builder:     p.buildAddAppDomainTool,
+			Handler:     p.handleAddAppDomain,
+		},
+		{
+			Name:        "remove_app_domain",
+			Description: "Remove a domain from an application",
+			Builder:     p.buildRemoveAppDomainTool,
+			Handler:     p.handleRemoveAppDomain,
+		},
+		{
+			Name:        "render_app_config_template",
+			Description: "Render environment variables from a ${VAR} template and optionally apply them",
+			Builder:     p.buildRenderAppConfigTemplateTool,
+			Handler:     p.handleRenderAppConfigTemplate,
+		},
+		{
+			Name:        "validate_app_manifest",
+			Description: "Validate app.json and Procfile content before deploying",
+			Builder:     p.buildValidateAppManifestTool,
+			Handler:     p.handleValidateAppManifest,
+		},
+		{
+			Name:        "rotate_app_secret",
+			Description: "Replace an environment variable with a new random secret and restart the app once",
+			Builder:     p.buildRotateAppSecretTool,
+			Handler:     p.handleRotateAppSecret,
+		},
+		{
+			Name:        "export_all_apps",
+			Description: "Export the configuration of every application as a single backup",
+			Builder:     p.buildExportAllAppsTool,
+			Handler:     p.handleExportAllApps,
+		},
+		{
+			Name:        "import_all_apps",
+			Description: "Restore applications from a backup produced by export_all_apps",
+			Builder:     p.buildImportAllAppsTool,
+			Handler:     p.handleImportAllApps,
+		},
+		{
+			Name:        "get_deployment_history",
+			Description: "List the past deployments of an application",
+			Builder:     p.buildGetDeploymentHistoryTool,
+			Handler:     p.handleGetDeploymentHistory,
+		},
 		{
 			Name:        "get_app_status",
 			Description: "Get comprehensive application status",
 			Builder:     p.buildGetAppStatusTool,
 			Handler:     p.handleGetAppStatus,
 		},
+		{
+			Name:        "get_app_processes",
+			Description: "Get the per-process status of an application from ps:report",
+			Builder:     p.buildGetAppProcessesTool,
+			Handler:     p.handleGetAppProcesses,
+		},
+		{
+			Name:        "detect_crash_loops",
+			Description: "Flag the processes of an application that keep restarting",
+			Builder:     p.buildDetectCrashLoopsTool,
+			Handler:     p.handleDetectCrashLoops,
+		},
+		{
+			Name:        "get_app_git_info",
+			Description: "Get the deploy branch, git remote and deployed commit of an application",
+			Builder:     p.buildGetAppGitInfoTool,
+			Handler:     p.handleGetAppGitInfo,
+		},
+		{
+			Name:        "get_app_disk_usage",
+			Description: "Get the disk space used by an application's images",
+			Builder:     p.buildGetAppDiskUsageTool,
+			Handler:     p.handleGetAppDiskUsage,
+		},
+		{
+			Name:        "get_system_disk_usage",
+			Description: "Get the disk space used by all applications, largest first",
+			Builder:     p.buildGetSystemDiskUsageTool,
+			Handler:     p.handleGetSystemDiskUsage,
+		},
+		{
+			Name:        "list_app_images",
+			Description: "List the docker images built for an application, newest first",
+			Builder:     p.buildListAppImagesTool,
+			Handler:     p.handleListAppImages,
+		},
+		{
+			Name:        "prune_app_images",
+			Description: "Remove an application's old images, keeping the current one and the most recent previous ones",
+			Builder:     p.buildPruneAppImagesTool,
+			Handler:     p.handlePruneAppImages,
+		},
+		{
+			Name:        "get_app_nginx_config",
+			Description: "Get the app-level nginx settings (body size, proxy timeouts, HSTS)",
+			Builder:     p.buildGetAppNginxConfigTool,
+			Handler:     p.handleGetAppNginxConfig,
+		},
+		{
+			Name:        "set_app_nginx_config",
+			Description: "Set an app-level nginx setting (body size, proxy timeouts, HSTS)",
+			Builder:     p.buildSetAppNginxConfigTool,
+			Handler:     p.handleSetAppNginxConfig,
+		},
+		{
+			Name:        "get_app_checks",
+			Description: "Get the zero-downtime checks settings of an application (wait-to-retire, timeout, disabled processes)",
+			Builder:     p.buildGetAppChecksTool,
+			Handler:     p.handleGetAppChecks,
+		},
+		{
+			Name:        "set_app_checks",
+			Description: "Set the zero-downtime checks wait-to-retire and timeout of an application",
+			Builder:     p.buildSetAppChecksTool,
+			Handler:     p.handleSetAppChecks,
+		},
+		{
+			Name:        "get_app_builder",
+			Description: "Get the builder of an application (herokuish, pack, dockerfile or nixpacks)",
+			Builder:     p.buildGetAppBuilderTool,
+			Handler:     p.handleGetAppBuilder,
+		},
+		{
+			Name:        "set_app_builder",
+			Description: "Select the builder of an application's next builds",
+			Builder:     p.buildSetAppBuilderTool,
+			Handler:     p.handleSetAppBuilder,
+		},
+		{
+			Name:        "get_app_healthchecks",
+			Description: "Get the healthchecks declared in an app.json and whether Dokku runs them for the app",
+			Builder:     p.buildGetAppHealthchecksTool,
+			Handler:     p.handleGetAppHealthchecks,
+		},
+		{
+			Name:        "set_app_healthchecks",
+			Description: "Replace the healthchecks section of an app.json",
+			Builder:     p.buildSetAppHealthchecksTool,
+			Handler:     p.handleSetAppHealthchecks,
+		},
 		{
 			Name:        "get_runtime_logs",
 			Description: "Retrieve runtime logs from a Dokku application",
 			Builder:     p.buildGetRuntimeLogsTool,
 			Handler:     p.handleGetRuntimeLogs,
 		},
+		{
+			Name:        "get_app_logs",
+			Description: "Get the last lines of an application's logs, optionally for a single process type",
+			Builder:     p.buildGetAppLogsTool,
+			Handler:     p.handleGetAppLogs,
+		},
+		{
+			Name:        "get_app_nginx_logs",
+			Description: "Get the end of an application's nginx access and error logs",
+			Builder:     p.buildGetAppNginxLogsTool,
+			Handler:     p.handleGetAppNginxLogs,
+		},
 	}, nil
 }
 
@@ -141,15 +409,32 @@ func (p *AppsServerPlugin) GetPrompts(ctx context.Context) ([]domain.Prompt, err
 }
 
 // Resource handlers
+
+// Application list resource handler: dokku://apps/list, with optional offset and limit
+// query parameters to page through the applications
 func (p *AppsServerPlugin) handleApplicationListResource(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
-	applications, err := p.applicationUseCase.GetAllApplications(ctx)
+	uri, err := url.Parse(req.Params.URI)
+	if err != nil || uri.Scheme != "dokku" || uri.Host != "apps" || uri.Path != "/list" {
+		return nil, fmt.Errorf("invalid application list resource URI: %s", req.Params.URI)
+	}
+
+	offset, err := queryInt(uri.Query(), "offset", 0)
+	if err != nil {
+		return nil, err
+	}
+	limit, err := queryInt(uri.Query(), "limit", p.appListConfig.DefaultLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	applications, total, err := p.applicationUseCase.ListApplications(ctx, offset, limit)
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve applications: %w", err)
 	}
 
-	apps := make([]appdomain.ApplicationInfo, len(applications))
+	apps := make([]appdomain.ApplicationListItem, len(applications))
 	for i, app := range applications {
-		apps[i] = appdomain.ApplicationInfo{
+		apps[i] = appdomain.ApplicationListItem{
 			Name:       app.Name().Value(),
 			State:      string(app.State().Value()),
 			IsRunning:  app.IsRunning(),
@@ -162,9 +447,12 @@ func (p *AppsServerPlugin) handleApplicationListResource(ctx context.Context, re
 	data := appdomain.ApplicationListData{
 		Applications: apps,
 		Count:        len(apps),
+		Total:        total,
+		Offset:       offset,
+		Limit:        limit,
 	}
 
-	jsonData, err := json.MarshalIndent(data, "", "  ")
+	jsonData, err := shared.MarshalOutput(data)
 	if err != nil {
 		return nil, fmt.Errorf("failed to serialize applications: %w", err)
 	}
@@ -178,6 +466,19 @@ func (p *AppsServerPlugin) handleApplicationListResource(ctx context.Context, re
 	}, nil
 }
 
+// queryInt reads an integer query parameter, returning fallback when it is absent
+func queryInt(query url.Values, name string, fallback int) (int, error) {
+	value := query.Get(name)
+	if value == "" {
+		return fallback, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s query parameter: %q is not a number", name, value)
+	}
+	return n, nil
+}
+
 // Tool builders
 func (p *AppsServerPlugin) buildCreateAppTool() mcp.Tool {
 	return mcp.NewTool(
@@ -194,13 +495,14 @@ func (p *AppsServerPlugin) buildCreateAppTool() mcp.Tool {
 		mcp.WithBoolean("no_vhost",
 			mcp.Description("Disable default vhost creation"),
 		),
+		withDebugFlag(),
 	)
 }
 
 func (p *AppsServerPlugin) buildDeployAppTool() mcp.Tool {
 	return mcp.NewTool(
 		"deploy_app",
-		mcp.WithDescription("Deploy application from Git repository"),
+		mcp.WithDescription("Deploy application from Git repository and wait for the build to finish"),
 		mcp.WithString("app_name",
 			mcp.Required(),
 			mcp.Description("Name of the application to deploy"),
@@ -212,9 +514,13 @@ func (p *AppsServerPlugin) buildDeployAppTool() mcp.Tool {
 		mcp.WithString("git_ref",
 			mcp.Description("Git reference to deploy (branch, tag, or commit)"),
 		),
+		mcp.WithString("build_dir",
+			mcp.Description("Repository subdirectory to build from (monorepos), relative and without '..'. Stored with git:set build-dir, so it also applies to later deploys"),
+		),
 		mcp.WithBoolean("force",
 			mcp.Description("Force deployment even if no changes detected"),
 		),
+		withDebugFlag(),
 	)
 }
 
@@ -233,6 +539,49 @@ func (p *AppsServerPlugin) buildScaleAppTool() mcp.Tool {
 			mcp.Required(),
 			mcp.Description("Number of instances to scale to"),
 		),
+		withDebugFlag(),
+	)
+}
+
+func (p *AppsServerPlugin) buildScaleAppBulkTool() mcp.Tool {
+	return mcp.NewTool(
+		"scale_app_bulk",
+		mcp.WithDescription("Scale several process types of an application with a single ps:scale (e.g. ps:scale my-app web=2 worker=3), so the app is restarted once. Process types not listed are left unchanged"),
+		mcp.WithString("app_name",
+			mcp.Required(),
+			mcp.Description("Name of the application to scale"),
+		),
+		mcp.WithObject("scales",
+			mcp.Required(),
+			mcp.Description("Number of instances per process type (e.g. {\"web\": 2, \"worker\": 3})"),
+			mcp.Properties(map[string]interface{}{ // NOTE: This is a valid exception
+				"additionalProperties": map[string]interface{}{ // NOTE: This is a valid exception
+					"type": "integer",
+				},
+			}),
+		),
+		withDebugFlag(),
+	)
+}
+
+func (p *AppsServerPlugin) buildReconcileAppFormationTool() mcp.Tool {
+	return mcp.NewTool(
+		"reconcile_app_formation",
+		mcp.WithDescription("Compare a desired formation with the app's current one and run ps:scale only for the process types that differ. Process types not listed are left unchanged; nothing is done when the formation already matches"),
+		mcp.WithString("app_name",
+			mcp.Required(),
+			mcp.Description("Name of the application to reconcile"),
+		),
+		mcp.WithObject("formation",
+			mcp.Required(),
+			mcp.Description("Desired number of instances per process type (e.g. {\"web\": 2, \"worker\": 1})"),
+			mcp.Properties(map[string]interface{}{ // NOTE: This is a valid exception
+				"additionalProperties": map[string]interface{}{ // NOTE: This is a valid exception
+					"type": "integer",
+				},
+			}),
+		),
+		withDebugFlag(),
 	)
 }
 
@@ -253,191 +602,1403 @@ func (p *AppsServerPlugin) buildConfigureAppTool() mcp.Tool {
 				},
 			}),
 		),
+		mcp.WithBoolean("no_restart",
+			mcp.Description("Store the variables without restarting the app. Changes only take effect after the next restart or deploy, which lets several changes be batched into a single restart"),
+		),
+		mcp.WithBoolean("rollback_on_failure",
+			mcp.Description("Snapshot the current values and restore them (with a restart) if the app's processes are not all running within health_timeout_seconds after the change. Cannot be combined with no_restart"),
+		),
+		mcp.WithNumber("health_timeout_seconds",
+			mcp.Description("How long to wait for the app to become healthy when rollback_on_failure is set (default 60)"),
+		),
+		withDebugFlag(),
 	)
 }
 
-func (p *AppsServerPlugin) buildGetAppStatusTool() mcp.Tool {
+func (p *AppsServerPlugin) buildUnsetAppConfigTool() mcp.Tool {
 	return mcp.NewTool(
-		"get_app_status",
-		mcp.WithDescription("Get comprehensive status information for an application"),
+		"unset_app_config",
+		mcp.WithDescription("Remove environment variables from an application (config:unset), e.g. to delete a leaked secret. config:unset is blocked when security.blacklist contains \"unset\" (as in config.yaml.example)"),
 		mcp.WithString("app_name",
 			mcp.Required(),
 			mcp.Description("Name of the application"),
 		),
+		mcp.WithArray("keys",
+			mcp.Required(),
+			mcp.WithStringItems(),
+			mcp.Description("Environment variables to remove (e.g. [\"OLD_API_TOKEN\"])"),
+		),
+		withDebugFlag(),
 	)
 }
 
-// Tool handlers
-func (p *AppsServerPlugin) handleCreateApp(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	name, err := req.RequireString("name")
-	if err != nil {
-		return mcp.NewToolResultError("Application name is required"), nil
-	}
+func (p *AppsServerPlugin) buildGetAppEffectiveConfigTool() mcp.Tool {
+	return mcp.NewTool(
+		"get_app_effective_config",
+		mcp.WithDescription("Show the environment an application actually runs with: global variables (config:show --global) merged with the app's own ones, app values overriding global ones. Each entry reports its source (global or app) and whether it overrides a global value. Sensitive values are masked"),
+		mcp.WithString("app_name",
+			mcp.Required(),
+			mcp.Description("Name of the application"),
+		),
+		withDebugFlag(),
+	)
+}
 
-	cmd := appusecases.CreateApplicationCommand{Name: name}
-	if err := p.applicationUseCase.CreateApplication(ctx, cmd); err != nil {
-		if errors.Is(err, appdomain.ErrApplicationAlreadyExists) {
-			return mcp.NewToolResultError(fmt.Sprintf("Application '%s' already exists", name)), nil
-		}
-		if errors.Is(err, appdomain.ErrInvalidApplicationName) {
-			return mcp.NewToolResultError(fmt.Sprintf("Invalid application name '%s'", name)), nil
-		}
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to create application: %v", err)), nil
-	}
+func (p *AppsServerPlugin) buildEnableAppForceHTTPSTool() mcp.Tool {
+	return mcp.NewTool(
+		"enable_app_force_https",
+		mcp.WithDescription("Enforce HTTPS for an application by enabling HSTS on its nginx proxy (nginx:set <app> hsts true). Nginx already redirects HTTP to HTTPS once a certificate is installed; HSTS makes browsers stick to HTTPS. Requires the nginx proxy; warns when no certificate is installed (see letsencrypt)"),
+		mcp.WithString("app_name",
+			mcp.Required(),
+			mcp.Description("Name of the application"),
+		),
+		withDebugFlag(),
+	)
+}
 
-	return mcp.NewToolResultText(fmt.Sprintf("Application '%s' created successfully", name)), nil
+func (p *AppsServerPlugin) buildDisableAppForceHTTPSTool() mcp.Tool {
+	return mcp.NewTool(
+		"disable_app_force_https",
+		mcp.WithDescription("Stop enforcing HTTPS for an application by disabling HSTS on its nginx proxy (nginx:set <app> hsts false). Requires the nginx proxy"),
+		mcp.WithString("app_name",
+			mcp.Required(),
+			mcp.Description("Name of the application"),
+		),
+		withDebugFlag(),
+	)
 }
 
-func (p *AppsServerPlugin) handleDeployApp(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	appName, err := req.RequireString("app_name")
-	if err != nil {
-		return mcp.NewToolResultError("Application name is required"), nil
-	}
+func (p *AppsServerPlugin) buildValidateAppManifestTool() mcp.Tool {
+	return mcp.NewTool(
+		"validate_app_manifest",
+		mcp.WithDescription("Validate app.json and/or Procfile content without deploying: JSON syntax, known app.json keys, deploy scripts, formation and cron entries, process types (lowercase, e.g. web, worker) and non-empty commands. Returns JSON with valid, errors and warnings"),
+		mcp.WithString("app_json",
+			mcp.Description("Content of the app.json file"),
+		),
+		mcp.WithString("procfile",
+			mcp.Description("Content of the Procfile, one 'type: command' per line"),
+		),
+	)
+}
 
-	repoURL, err := req.RequireString("repo_url")
-	if err != nil {
-		return mcp.NewToolResultError("Repository URL is required"), nil
-	}
+func (p *AppsServerPlugin) buildListAppDomainsTool() mcp.Tool {
+	return mcp.NewTool(
+		"list_app_domains",
+		mcp.WithDescription("List the domains of an application from domains:report: the app vhosts, whether they are enabled (routed by the proxy) and the global vhosts"),
+		mcp.WithString("app_name",
+			mcp.Required(),
+			mcp.Description("Name of the application"),
+		),
+	)
+}
 
-	gitRef := "main"
-	if gitRefParam, ok := req.GetArguments()["git_ref"]; ok {
-		if gitRefStr, ok := gitRefParam.(string); ok && gitRefStr != "" {
-			gitRef = gitRefStr
-		}
-	}
+func (p *AppsServerPlugin) buildAddAppDomainTool() mcp.Tool {
+	return mcp.NewTool(
+		"add_app_domain",
+		mcp.WithDescription("Add a domain to an application (domains:add). The domain must resolve to the Dokku host to be reachable"),
+		mcp.WithString("app_name",
+			mcp.Required(),
+			mcp.Description("Name of the application"),
+		),
+		mcp.WithString("domain",
+			mcp.Required(),
+			mcp.Description("Domain to add (e.g. www.example.com)"),
+		),
+		withDebugFlag(),
+	)
+}
 
-	cmd := appusecases.DeployApplicationCommand{
-		Name:    appName,
-		RepoURL: repoURL,
-		GitRef:  gitRef,
-	}
+func (p *AppsServerPlugin) buildRemoveAppDomainTool() mcp.Tool {
+	return mcp.NewTool(
+		"remove_app_domain",
+		mcp.WithDescription("Remove a domain from an application (domains:remove). domains:remove is blocked when security.blacklist contains \"remove\" (as in config.yaml.example)"),
+		mcp.WithString("app_name",
+			mcp.Required(),
+			mcp.Description("Name of the application"),
+		),
+		mcp.WithString("domain",
+			mcp.Required(),
+			mcp.Description("Domain to remove"),
+		),
+		withDebugFlag(),
+	)
+}
 
-	if err := p.applicationUseCase.DeployApplication(ctx, cmd); err != nil {
-		if errors.Is(err, appdomain.ErrApplicationNotFound) {
-			return mcp.NewToolResultError(fmt.Sprintf("Application '%s' not found", appName)), nil
-		}
-		if errors.Is(err, appdomain.ErrDeploymentInProgress) {
-			return mcp.NewToolResultError(fmt.Sprintf("Deployment already in progress for '%s'", appName)), nil
-		}
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to deploy application: %v", err)), nil
-	}
+func (p *AppsServerPlugin) buildRenderAppConfigTemplateTool() mcp.Tool {
+	stringMap := mcp.Properties(map[string]interface{}{ // NOTE: This is a valid exception
+		"additionalProperties": map[string]interface{}{ // NOTE: This is a valid exception
+			"type": "string",
+		},
+	})
 
-	return mcp.NewToolResultText(fmt.Sprintf("Application '%s' deployed successfully from '%s'", appName, gitRef)), nil
+	return mcp.NewTool(
+		"render_app_config_template",
+		mcp.WithDescription("Render environment variables whose values contain ${VAR} placeholders. Placeholders resolve from 'values' first, then from other rendered template entries. Rendering fails if any placeholder is left unresolved. Set apply to true to set the rendered variables on the app"),
+		mcp.WithString("app_name",
+			mcp.Required(),
+			mcp.Description("Name of the application"),
+		),
+		mcp.WithObject("template",
+			mcp.Required(),
+			mcp.Description("Environment variables as key-value pairs, values may contain ${VAR} placeholders (e.g., {\"DATABASE_URL\": \"postgres://${DB_USER}@${DB_HOST}/app\"})"),
+			stringMap,
+		),
+		mcp.WithObject("values",
+			mcp.Description("Values for the placeholders as key-value pairs"),
+			stringMap,
+		),
+		mcp.WithBoolean("apply",
+			mcp.Description("Set the rendered variables on the application (default: false, only render)"),
+		),
+		mcp.WithBoolean("no_restart",
+			mcp.Description("When applying, store the variables without restarting the app"),
+		),
+		withDebugFlag(),
+	)
 }
 
-func (p *AppsServerPlugin) handleScaleApp(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	appName, err := req.RequireString("app_name")
-	if err != nil {
-		return mcp.NewToolResultError("Application name is required"), nil
-	}
-
-	processType := "web"
-	if processTypeParam, ok := req.GetArguments()["process_type"]; ok {
-		if processTypeStr, ok := processTypeParam.(string); ok && processTypeStr != "" {
-			processType = processTypeStr
-		}
-	}
+func (p *AppsServerPlugin) buildRotateAppSecretTool() mcp.Tool {
+	return mcp.NewTool(
+		"rotate_app_secret",
+		mcp.WithDescription("Generate a new cryptographically random value for an environment variable, store it without restart, then restart the app once. The new value is never returned"),
+		mcp.WithString("app_name",
+			mcp.Required(),
+			mcp.Description("Name of the application"),
+		),
+		mcp.WithString("key",
+			mcp.Required(),
+			mcp.Description("Environment variable holding the secret (e.g. SECRET_KEY_BASE)"),
+		),
+	)
+}
 
-	instancesParam, ok := req.GetArguments()["instances"]
-	if !ok {
-		return mcp.NewToolResultError("Number of instances is required"), nil
-	}
+func (p *AppsServerPlugin) buildExportAllAppsTool() mcp.Tool {
+	return mcp.NewTool(
+		"export_all_apps",
+		mcp.WithDescription("Export every application's environment variables, process formation and nginx settings as one JSON backup. Sensitive values are masked unless encrypt is set, in which case they are encrypted with encryption_key and can be restored by import_all_apps. The export fails, naming the apps concerned, if any app cannot be read entirely"),
+		mcp.WithBoolean("encrypt",
+			mcp.Description("Encrypt sensitive values (AES-256-GCM, key derived from encryption_key) instead of masking them"),
+		),
+		mcp.WithString("encryption_key",
+			mcp.Description("Passphrase used to encrypt sensitive values; required with encrypt and needed again to import"),
+		),
+		withDebugFlag(),
+	)
+}
 
-	var instances int
-	switch v := instancesParam.(type) {
-	case float64:
+func (p *AppsServerPlugin) buildImportAllAppsTool() mcp.Tool {
+	return mcp.NewTool(
+		"import_all_apps",
+		mcp.WithDescription("Restore applications from an export_all_apps backup: missing apps are created, then environment variables, formation and nginx settings are applied. Masked values are skipped and reported"),
+		mcp.WithString("backup",
+			mcp.Required(),
+			mcp.Description("JSON backup produced by export_all_apps"),
+		),
+		mcp.WithString("encryption_key",
+			mcp.Description("Passphrase the backup was encrypted with, when it holds encrypted values"),
+		),
+		withDebugFlag(),
+	)
+}
+
+func (p *AppsServerPlugin) buildGetAppStatusTool() mcp.Tool {
+	return mcp.NewTool(
+		"get_app_status",
+		mcp.WithDescription("Get comprehensive status information for an application"),
+		mcp.WithString("app_name",
+			mcp.Required(),
+			mcp.Description("Name of the application"),
+		),
+		mcp.WithString("app_json",
+			mcp.Description("Content of the app.json deployed with the application, to include its healthchecks in the status"),
+		),
+	)
+}
+
+func (p *AppsServerPlugin) buildGetDeploymentHistoryTool() mcp.Tool {
+	return mcp.NewTool(
+		"get_deployment_history",
+		mcp.WithDescription("List the past deployments of an application, most recent first: id, git ref, status, creation time and duration. An application that was never deployed has an empty history"),
+		mcp.WithString("app_name",
+			mcp.Required(),
+			mcp.Description("Name of the application"),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description(fmt.Sprintf("Maximum number of deployments to return (default: %d)", defaultDeploymentHistoryLimit)),
+		),
+	)
+}
+
+func (p *AppsServerPlugin) buildGetAppProcessesTool() mcp.Tool {
+	return mcp.NewTool(
+		"get_app_processes",
+		mcp.WithDescription("Get the full process report of an application as JSON: every container with its process type, status (running, restarting, exited...), container ID and restart count, plus the scale and restart policy. Useful to debug crash loops"),
+		mcp.WithString("app_name",
+			mcp.Required(),
+			mcp.Description("Name of the application"),
+		),
+		withDebugFlag(),
+	)
+}
+
+func (p *AppsServerPlugin) buildDetectCrashLoopsTool() mcp.Tool {
+	return mcp.NewTool(
+		"detect_crash_loops",
+		mcp.WithDescription("Detect crash loops in an application as JSON: the process types whose containers restarted at least `threshold` times with the last restart within `window`, with their restart counts and last exit codes. Use get_app_logs on a flagged process type to find out why it crashes"),
+		mcp.WithString("app_name",
+			mcp.Required(),
+			mcp.Description("Name of the application"),
+		),
+		mcp.WithNumber("threshold",
+			mcp.Description(fmt.Sprintf("Restarts from which a container is flagged (default: %d)", appdomain.DefaultCrashLoopThreshold)),
+		),
+		mcp.WithString("window",
+			mcp.Description(fmt.Sprintf("How recent the last restart must be, as a duration like 15m or 1h; 0 ignores it (default: %s)", appdomain.DefaultCrashLoopWindow)),
+		),
+		withDebugFlag(),
+	)
+}
+
+func (p *AppsServerPlugin) buildGetAppGitInfoTool() mcp.Tool {
+	return mcp.NewTool(
+		"get_app_git_info",
+		mcp.WithDescription("Get what an application actually runs from git as JSON: the deploy branch, the remote and ref of the last git:sync, and the deployed commit SHA with its update time. Dokku does not keep commit messages, so confirm them against the remote"),
+		mcp.WithString("app_name",
+			mcp.Required(),
+			mcp.Description("Name of the application"),
+		),
+		withDebugFlag(),
+	)
+}
+
+func (p *AppsServerPlugin) buildGetAppDiskUsageTool() mcp.Tool {
+	return mcp.NewTool(
+		"get_app_disk_usage",
+		mcp.WithDescription("Get the disk usage of an application as JSON: the size of its images (tags:list) and its persistent storage mounts. Dokku does not report container writable layers, so they are not measured. Only the docker-local scheduler can be measured"),
+		mcp.WithString("app_name",
+			mcp.Required(),
+			mcp.Description("Name of the application"),
+		),
+		withDebugFlag(),
+	)
+}
+
+func (p *AppsServerPlugin) buildGetSystemDiskUsageTool() mcp.Tool {
+	return mcp.NewTool(
+		"get_system_disk_usage",
+		mcp.WithDescription("Get the disk usage of every application as JSON, sorted from the largest, with totals. Apps that cannot be measured are listed as unsupported"),
+	)
+}
+
+func (p *AppsServerPlugin) buildListAppImagesTool() mcp.Tool {
+	return mcp.NewTool(
+		"list_app_images",
+		mcp.WithDescription("List the docker images of an application as JSON (tags:list), newest first, with their tags, age and size. The current image is flagged. Only the docker-local scheduler keeps images on the host"),
+		mcp.WithString("app_name",
+			mcp.Required(),
+			mcp.Description("Name of the application"),
+		),
+		withDebugFlag(),
+	)
+}
+
+func (p *AppsServerPlugin) buildPruneAppImagesTool() mcp.Tool {
+	return mcp.NewTool(
+		"prune_app_images",
+		mcp.WithDescription("Reclaim disk by removing an application's old images (tags:destroy), keeping the current image and the most recent previous ones as rollback targets. tags:destroy is blocked when security.blacklist contains \"destroy\" (as in config.yaml.example)"),
+		mcp.WithString("app_name",
+			mcp.Required(),
+			mcp.Description("Name of the application"),
+		),
+		mcp.WithString("confirm_name",
+			mcp.Required(),
+			mcp.Description("Repeat the application name to confirm the removal"),
+		),
+		mcp.WithNumber("keep_previous",
+			mcp.Description(fmt.Sprintf("Images to keep besides the current one (default: %d)", p.imagesConfig.KeepPrevious)),
+		),
+		withDebugFlag(),
+	)
+}
+
+func (p *AppsServerPlugin) buildGetAppNginxConfigTool() mcp.Tool {
+	return mcp.NewTool(
+		"get_app_nginx_config",
+		mcp.WithDescription("Get the app-level nginx settings managed by set_app_nginx_config (nginx:report). An empty value means Dokku's default applies"),
+		mcp.WithString("app_name",
+			mcp.Required(),
+			mcp.Description("Name of the application"),
+		),
+	)
+}
+
+func (p *AppsServerPlugin) buildSetAppNginxConfigTool() mcp.Tool {
+	return mcp.NewTool(
+		"set_app_nginx_config",
+		mcp.WithDescription("Set an app-level nginx setting (nginx:set) and rebuild the proxy config. Supported properties: client-max-body-size (e.g. '50m'), proxy-read-timeout and proxy-send-timeout (e.g. '60s'), hsts ('true' or 'false')"),
+		mcp.WithString("app_name",
+			mcp.Required(),
+			mcp.Description("Name of the application"),
+		),
+		mcp.WithString("property",
+			mcp.Required(),
+			mcp.Description("Nginx property to set: client-max-body-size, proxy-read-timeout, proxy-send-timeout or hsts"),
+		),
+		mcp.WithString("value",
+			mcp.Description("New value; leave empty to reset the property to Dokku's default"),
+		),
+		withDebugFlag(),
+	)
+}
+
+func (p *AppsServerPlugin) buildGetAppChecksTool() mcp.Tool {
+	return mcp.NewTool(
+		"get_app_checks",
+		mcp.WithDescription("Get the zero-downtime checks settings of an application (checks:report): processes with checks disabled or skipped, how long old containers keep running after a deploy (wait-to-retire) and the timeout of each check request (DOKKU_CHECKS_TIMEOUT, 0 means Dokku's default)"),
+		mcp.WithString("app_name",
+			mcp.Required(),
+			mcp.Description("Name of the application"),
+		),
+	)
+}
+
+func (p *AppsServerPlugin) buildSetAppChecksTool() mcp.Tool {
+	return mcp.NewTool(
+		"set_app_checks",
+		mcp.WithDescription("Tune the deploy health checks of an application, e.g. for slow-starting apps. wait_to_retire is set with checks:set and applies immediately; timeout is stored in DOKKU_CHECKS_TIMEOUT without restarting and applies from the next deploy. Durations are positive seconds ('60') or durations ('90s', '2m')"),
+		mcp.WithString("app_name",
+			mcp.Required(),
+			mcp.Description("Name of the application"),
+		),
+		mcp.WithString("wait_to_retire",
+			mcp.Description("How long the previous containers keep running after a deploy before being retired"),
+		),
+		mcp.WithString("timeout",
+			mcp.Description("Timeout of each check request, stored in DOKKU_CHECKS_TIMEOUT"),
+		),
+		withDebugFlag(),
+	)
+}
+
+func (p *AppsServerPlugin) buildGetAppBuilderTool() mcp.Tool {
+	return mcp.NewTool(
+		"get_app_builder",
+		mcp.WithDescription("Get the builder of an application (builder:report): the builder selected for the app, empty when Dokku detects it from the source, and the builder the next build will use"),
+		mcp.WithString("app_name",
+			mcp.Required(),
+			mcp.Description("Name of the application"),
+		),
+	)
+}
+
+func (p *AppsServerPlugin) buildSetAppBuilderTool() mcp.Tool {
+	return mcp.NewTool(
+		"set_app_builder",
+		mcp.WithDescription("Select the builder of an application with builder:set, e.g. dockerfile to build from the app's Dockerfile instead of buildpacks. The running containers keep their image: rebuild or redeploy the app for the new builder to take effect"),
+		mcp.WithString("app_name",
+			mcp.Required(),
+			mcp.Description("Name of the application"),
+		),
+		mcp.WithString("builder",
+			mcp.Required(),
+			mcp.Description("Builder to use: herokuish, pack, dockerfile or nixpacks"),
+		),
+		withDebugFlag(),
+	)
+}
+
+func (p *AppsServerPlugin) buildGetAppHealthchecksTool() mcp.Tool {
+	return mcp.NewTool(
+		"get_app_healthchecks",
+		mcp.WithDescription("Read the healthchecks section of an app's app.json as JSON: the checks of each process type (path, timeout, attempts...), the processes whose checks are disabled or skipped (checks:report) and the declared checks Dokku will therefore not run. Dokku reads app.json from the deployed source and cannot return it, so pass the file content"),
+		mcp.WithString("app_name",
+			mcp.Required(),
+			mcp.Description("Name of the application"),
+		),
+		mcp.WithString("app_json",
+			mcp.Required(),
+			mcp.Description("Content of the app.json deployed with the application"),
+		),
+		withDebugFlag(),
+	)
+}
+
+func (p *AppsServerPlugin) buildSetAppHealthchecksTool() mcp.Tool {
+	return mcp.NewTool(
+		"set_app_healthchecks",
+		mcp.WithDescription("Replace the healthchecks section of an app.json and return the updated file, other keys unchanged. Checks are validated first: known fields only, type startup, liveness or readiness, a path starting with / (or a command, uptime or listening) and non-negative attempts, timeout, wait and initialDelay. Commit the file and redeploy for Dokku to use the new checks"),
+		mcp.WithString("app_json",
+			mcp.Description("Current content of app.json (an empty file when omitted)"),
+		),
+		mcp.WithString("healthchecks",
+			mcp.Required(),
+			mcp.Description(`Healthchecks section as JSON, mapping process types to checks, e.g. {"web": [{"type": "startup", "path": "/health", "timeout": 5, "attempts": 3}]}; {} removes the section`),
+		),
+	)
+}
+
+// Tool handlers
+func (p *AppsServerPlugin) handleCreateApp(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	name, err := req.RequireString("name")
+	if err != nil {
+		return server.Error("Application name is required"), nil
+	}
+
+	cmd := appusecases.CreateApplicationCommand{Name: name}
+	validation, err := p.applicationUseCase.CreateApplication(ctx, cmd)
+	if err != nil {
+		if errors.Is(err, appdomain.ErrApplicationAlreadyExists) {
+			return server.Error(fmt.Sprintf("Application '%s' already exists", name)), nil
+		}
+		if errors.Is(err, appdomain.ErrInvalidApplicationName) {
+			return server.Error(fmt.Sprintf("Invalid application name '%s'", name)), nil
+		}
+		return p.withValidation(p.toolFailure(req, fmt.Sprintf("Failed to create application: %v", err), err), validation), nil
+	}
+
+	return p.withValidation(server.ToolResult{Success: true, Message: fmt.Sprintf("Application '%s' created successfully", name), Data: map[string]any{"app_name": name}}, validation), nil
+}
+
+func (p *AppsServerPlugin) handleDeployApp(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	appName, err := req.RequireString("app_name")
+	if err != nil {
+		return server.Error("Application name is required"), nil
+	}
+
+	repoURL, err := req.RequireString("repo_url")
+	if err != nil {
+		return server.Error("Repository URL is required"), nil
+	}
+
+	gitRef := "main"
+	if gitRefParam, ok := req.GetArguments()["git_ref"]; ok {
+		if gitRefStr, ok := gitRefParam.(string); ok && gitRefStr != "" {
+			gitRef = gitRefStr
+		}
+	}
+
+	cmd := appusecases.DeployApplicationCommand{
+		Name:     appName,
+		RepoURL:  repoURL,
+		GitRef:   gitRef,
+		BuildDir: req.GetString("build_dir", ""),
+	}
+
+	validation, err := p.applicationUseCase.DeployApplication(p.withDeployProgress(ctx, req), cmd)
+	if err != nil {
+		if errors.Is(err, appdomain.ErrApplicationNotFound) {
+			return server.Error(fmt.Sprintf("Application '%s' not found", appName)), nil
+		}
+		if errors.Is(err, appdomain.ErrDeploymentInProgress) {
+			return server.Error(fmt.Sprintf("Deployment already in progress for '%s'", appName)), nil
+		}
+		if message, ok := gitSyncFailureMessage(err, repoURL, gitRef); ok {
+			return p.withValidation(p.toolFailure(req, message, err), validation), nil
+		}
+		return p.withValidation(p.toolFailure(req, fmt.Sprintf("Failed to deploy application: %v", err), err), validation), nil
+	}
+
+	return p.withValidation(server.ToolResult{Success: true, Message: fmt.Sprintf("Application '%s' deployed successfully from '%s'", appName, gitRef), Data: map[string]any{"app_name": appName, "git_ref": gitRef}}, validation), nil
+}
+
+func (p *AppsServerPlugin) handleScaleApp(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	appName, err := req.RequireString("app_name")
+	if err != nil {
+		return server.Error("Application name is required"), nil
+	}
+
+	processType := "web"
+	if processTypeParam, ok := req.GetArguments()["process_type"]; ok {
+		if processTypeStr, ok := processTypeParam.(string); ok && processTypeStr != "" {
+			processType = processTypeStr
+		}
+	}
+
+	instancesParam, ok := req.GetArguments()["instances"]
+	if !ok {
+		return server.Error("Number of instances is required"), nil
+	}
+
+	var instances int
+	switch v := instancesParam.(type) {
+	case float64:
 		instances = int(v)
 	case int:
 		instances = v
 	default:
-		return mcp.NewToolResultError("Invalid instances value - must be a number"), nil
+		return server.Error("Invalid instances value - must be a number"), nil
+	}
+
+	cmd := appusecases.ScaleApplicationCommand{
+		Name:        appName,
+		ProcessType: processType,
+		Scale:       instances,
+	}
+
+	validation, err := p.applicationUseCase.ScaleApplication(ctx, cmd)
+	if err != nil {
+		if errors.Is(err, appdomain.ErrApplicationNotFound) {
+			return server.Error(fmt.Sprintf("Application '%s' not found", appName)), nil
+		}
+		if errors.Is(err, appdomain.ErrApplicationNotDeployed) {
+			return notDeployedResult(appName), nil
+		}
+		return p.withValidation(p.toolFailure(req, fmt.Sprintf("Failed to scale application: %v", err), err), validation), nil
+	}
+
+	return p.withValidation(server.ToolResult{Success: true, Message: fmt.Sprintf("Application '%s' scaled to %d instances for process type '%s'", appName, instances, processType), Data: map[string]any{"app_name": appName, "process_type": processType, "instances": instances}}, validation), nil
+}
+
+func (p *AppsServerPlugin) handleScaleAppBulk(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	appName, err := req.RequireString("app_name")
+	if err != nil {
+		return server.Error("Application name is required"), nil
+	}
+
+	scales, err := intMapArgument(req, "scales")
+	if err != nil {
+		return server.Error(err.Error()), nil
+	}
+	if len(scales) == 0 {
+		return server.Error("At least one process type is required in scales"), nil
+	}
+
+	changes, validation, err := p.applicationUseCase.ScaleApplicationBulk(ctx, appusecases.ScaleApplicationBulkCommand{
+		Name:   appName,
+		Scales: scales,
+	})
+	if err != nil {
+		if errors.Is(err, appdomain.ErrApplicationNotFound) {
+			return server.Error(fmt.Sprintf("Application '%s' not found", appName)), nil
+		}
+		if errors.Is(err, appdomain.ErrApplicationNotDeployed) {
+			return notDeployedResult(appName), nil
+		}
+		return p.withValidation(p.toolFailure(req, fmt.Sprintf("Failed to scale application: %v", err), err), validation), nil
+	}
+
+	applied := make([]string, 0, len(changes))
+	for _, change := range changes {
+		applied = append(applied, fmt.Sprintf("%s=%d (was %d)", change.ProcessType, change.To, change.From))
+	}
+	return p.withValidation(server.ToolResult{
+		Success: true,
+		Message: fmt.Sprintf("Application '%s' scaled: %s", appName, strings.Join(applied, ", ")),
+		Data:    map[string]any{"app_name": appName, "changed": changes},
+	}, validation), nil
+}
+
+func (p *AppsServerPlugin) handleReconcileAppFormation(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	appName, err := req.RequireString("app_name")
+	if err != nil {
+		return server.Error("Application name is required"), nil
+	}
+
+	formation, err := intMapArgument(req, "formation")
+	if err != nil {
+		return server.Error(err.Error()), nil
+	}
+	if len(formation) == 0 {
+		return server.Error("At least one process type is required in the formation"), nil
+	}
+
+	changes, err := p.applicationUseCase.ReconcileFormation(ctx, appusecases.ReconcileFormationCommand{
+		Name:      appName,
+		Formation: formation,
+	})
+	if err != nil {
+		if errors.Is(err, appdomain.ErrApplicationNotFound) {
+			return server.Error(fmt.Sprintf("Application '%s' not found", appName)), nil
+		}
+		if errors.Is(err, appdomain.ErrApplicationNotDeployed) {
+			return notDeployedResult(appName), nil
+		}
+		return p.toolError(req, fmt.Sprintf("Failed to reconcile formation: %v", err), err), nil
+	}
+
+	message := fmt.Sprintf("Formation of '%s' reconciled: %d process types scaled", appName, len(changes))
+	if len(changes) == 0 {
+		message = fmt.Sprintf("Formation of '%s' already matches", appName)
+	}
+	return server.OK(message, appdomain.FormationReconciliation{
+		AppName: appName,
+		Changed: changes,
+		InSync:  len(changes) == 0,
+	}), nil
+}
+
+func (p *AppsServerPlugin) handleConfigureApp(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	appName, err := req.RequireString("app_name")
+	if err != nil {
+		return server.Error("Application name is required"), nil
+	}
+
+	configVars := stringMapArgument(req, "config")
+
+	if len(configVars) == 0 {
+		return server.Error("At least one configuration variable is required"), nil
+	}
+
+	noRestart := req.GetBool("no_restart", false)
+
+	cmd := appusecases.SetConfigCommand{
+		Name:              appName,
+		Config:            configVars,
+		NoRestart:         noRestart,
+		RollbackOnFailure: req.GetBool("rollback_on_failure", false),
+		HealthTimeout:     time.Duration(req.GetInt("health_timeout_seconds", defaultHealthTimeoutSeconds)) * time.Second,
+	}
+
+	if err := p.applicationUseCase.SetApplicationConfig(ctx, cmd); err != nil {
+		if errors.Is(err, appdomain.ErrApplicationNotFound) {
+			return server.Error(fmt.Sprintf("Application '%s' not found", appName)), nil
+		}
+		if errors.Is(err, appdomain.ErrHealthCheckFailed) {
+			return p.toolError(req, fmt.Sprintf("Configuration of '%s' was not kept: %v", appName, err), err), nil
+		}
+		return p.toolError(req, fmt.Sprintf("Failed to configure application: %v", err), err), nil
+	}
+
+	// Values may be secrets; only the keys are returned
+	keys := slices.Sorted(maps.Keys(configVars))
+	configured := map[string]any{"app_name": appName, "keys": keys}
+	if noRestart {
+		return server.OK(fmt.Sprintf("Application '%s' configured with %d variables without restart; changes take effect after the next restart or deploy", appName, len(configVars)), configured), nil
+	}
+
+	return server.OK(fmt.Sprintf("Application '%s' configured successfully with %d variables", appName, len(configVars)), configured), nil
+}
+
+func (p *AppsServerPlugin) handleUnsetAppConfig(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	appName, err := req.RequireString("app_name")
+	if err != nil {
+		return server.Error("Application name is required"), nil
+	}
+
+	keys := req.GetStringSlice("keys", nil)
+
+	if err := p.applicationUseCase.UnsetApplicationConfig(ctx, appusecases.UnsetConfigCommand{Name: appName, Keys: keys}); err != nil {
+		if errors.Is(err, appdomain.ErrNoConfigKeys) {
+			return server.Error("At least one configuration key is required"), nil
+		}
+		if errors.Is(err, appdomain.ErrApplicationNotFound) {
+			return server.Error(fmt.Sprintf("Application '%s' not found", appName)), nil
+		}
+		return p.toolError(req, fmt.Sprintf("Failed to remove configuration: %v", err), err), nil
+	}
+
+	return server.OK(fmt.Sprintf("Removed %s from application '%s'", strings.Join(keys, ", "), appName), map[string]any{"app_name": appName, "keys": keys}), nil
+}
+
+func (p *AppsServerPlugin) handleGetAppEffectiveConfig(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	appName, err := req.RequireString("app_name")
+	if err != nil {
+		return server.Error("Application name is required"), nil
+	}
+
+	entries, err := p.applicationUseCase.GetEffectiveConfig(ctx, appName)
+	if err != nil {
+		if errors.Is(err, appdomain.ErrApplicationNotFound) {
+			return server.Error(fmt.Sprintf("Application '%s' not found", appName)), nil
+		}
+		return p.toolError(req, fmt.Sprintf("Failed to get effective configuration: %v", err), err), nil
+	}
+
+	for i := range entries {
+		if shared.IsSensitiveKey(entries[i].Key) {
+			entries[i].Value = shared.MaskedValue
+		}
+	}
+
+	return server.OK(fmt.Sprintf("Effective configuration of '%s', sensitive values masked", appName), entries), nil
+}
+
+func (p *AppsServerPlugin) handleEnableAppForceHTTPS(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return p.setForceHTTPS(ctx, req, true)
+}
+
+func (p *AppsServerPlugin) handleDisableAppForceHTTPS(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return p.setForceHTTPS(ctx, req, false)
+}
+
+func (p *AppsServerPlugin) setForceHTTPS(ctx context.Context, req mcp.CallToolRequest, enabled bool) (*mcp.CallToolResult, error) {
+	appName, err := req.RequireString("app_name")
+	if err != nil {
+		return server.Error("Application name is required"), nil
+	}
+
+	cmd := appusecases.SetForceHTTPSCommand{
+		Name:    appName,
+		Enabled: enabled,
+	}
+
+	status, err := p.applicationUseCase.SetForceHTTPS(ctx, cmd)
+	if err != nil {
+		if errors.Is(err, appdomain.ErrApplicationNotFound) {
+			return server.Error(fmt.Sprintf("Application '%s' not found", appName)), nil
+		}
+		if errors.Is(err, appdomain.ErrProxyNotSupported) {
+			return server.Error(fmt.Sprintf("Cannot change HTTPS enforcement for '%s': %v", appName, err)), nil
+		}
+		return p.toolError(req, fmt.Sprintf("Failed to update HTTPS enforcement: %v", err), err), nil
+	}
+
+	if !enabled {
+		return server.OK(fmt.Sprintf("HTTPS enforcement disabled for '%s'", appName), status), nil
+	}
+
+	message := fmt.Sprintf("HTTPS enforcement enabled for '%s'", appName)
+	if !status.SSLEnabled {
+		return server.OK(message, status, "No certificate is installed for this app, so HTTPS is not served yet. Add one (e.g. with letsencrypt) before relying on HTTPS enforcement"), nil
+	}
+	return server.OK(message, status), nil
+}
+
+func (p *AppsServerPlugin) handleValidateAppManifest(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	appJSON := req.GetString("app_json", "")
+	procfile := req.GetString("procfile", "")
+	if strings.TrimSpace(appJSON) == "" && strings.TrimSpace(procfile) == "" {
+		return server.Error("Either app_json or procfile is required"), nil
+	}
+
+	report := newValidationReport(p.applicationUseCase.ValidateDeployManifest(ctx, appJSON, procfile))
+	message := "The manifest is valid"
+	if !report.Valid {
+		message = fmt.Sprintf("The manifest has %d errors", len(report.Errors))
+	}
+	return server.OK(message, report), nil
+}
+
+func (p *AppsServerPlugin) handleListAppDomains(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	appName, err := req.RequireString("app_name")
+	if err != nil {
+		return server.Error("Application name is required"), nil
+	}
+
+	report, err := p.applicationUseCase.GetApplicationDomains(ctx, appName)
+	if err != nil {
+		if errors.Is(err, appdomain.ErrApplicationNotFound) {
+			return server.Error(fmt.Sprintf("Application '%s' not found", appName)), nil
+		}
+		return p.toolError(req, fmt.Sprintf("Failed to list domains: %v", err), err), nil
+	}
+
+	return server.OK(fmt.Sprintf("Domains of '%s'", appName), report), nil
+}
+
+func (p *AppsServerPlugin) handleGetAppURLs(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	appName, err := req.RequireString("app_name")
+	if err != nil {
+		return server.Error("Application name is required"), nil
+	}
+
+	urls, https, err := p.applicationUseCase.GetApplicationURLs(ctx, appName)
+	if err != nil {
+		if errors.Is(err, appdomain.ErrApplicationNotFound) {
+			return server.Error(fmt.Sprintf("Application '%s' not found", appName)), nil
+		}
+		return p.toolError(req, fmt.Sprintf("Failed to get application URLs: %v", err), err), nil
+	}
+
+	var warnings []string
+	switch {
+	case !urls.VhostsEnabled:
+		warnings = append(warnings, "Vhosts are disabled, so the proxy does not route any domain to the app")
+	case len(urls.URLs) == 0:
+		warnings = append(warnings, "The app has no domain; add one with add_app_domain")
+	}
+	if https.ForceHTTPS && !https.SSLEnabled {
+		warnings = append(warnings, "HTTPS is enforced but the app has no certificate, so browsers cannot reach it over https")
+	}
+
+	return server.OK(fmt.Sprintf("URLs of '%s'", appName), urls, warnings...), nil
+}
+
+func (p *AppsServerPlugin) handleAddAppDomain(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return p.changeAppDomain(ctx, req, true)
+}
+
+func (p *AppsServerPlugin) handleRemoveAppDomain(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return p.changeAppDomain(ctx, req, false)
+}
+
+func (p *AppsServerPlugin) changeAppDomain(ctx context.Context, req mcp.CallToolRequest, add bool) (*mcp.CallToolResult, error) {
+	appName, err := req.RequireString("app_name")
+	if err != nil {
+		return server.Error("Application name is required"), nil
+	}
+	domainName, err := req.RequireString("domain")
+	if err != nil {
+		return server.Error("Domain is required"), nil
+	}
+
+	cmd := appusecases.DomainCommand{Name: appName, Domain: domainName}
+	if add {
+		err = p.applicationUseCase.AddApplicationDomain(ctx, cmd)
+	} else {
+		err = p.applicationUseCase.RemoveApplicationDomain(ctx, cmd)
+	}
+	if err != nil {
+		switch {
+		case errors.Is(err, appdomain.ErrInvalidDomain):
+			validation := &appdomain.ValidationResult{
+				IsValid: false,
+				Errors: []appdomain.ValidationError{{
+					Field:   "domain",
+					Message: err.Error(),
+					Code:    "INVALID_DOMAIN",
+				}},
+			}
+			return p.withValidation(server.ToolResult{Message: fmt.Sprintf("Invalid domain '%s'", domainName)}, validation), nil
+		case errors.Is(err, appdomain.ErrApplicationNotFound):
+			return server.Error(fmt.Sprintf("Application '%s' not found", appName)), nil
+		case errors.Is(err, appdomain.ErrDomainAlreadyExists):
+			return server.Error(fmt.Sprintf("Domain '%s' is already set on application '%s'", domainName, appName)), nil
+		case errors.Is(err, appdomain.ErrDomainNotFound):
+			return server.Error(fmt.Sprintf("Domain '%s' is not set on application '%s'", domainName, appName)), nil
+		}
+		if add {
+			return p.toolError(req, fmt.Sprintf("Failed to add domain: %v", err), err), nil
+		}
+		return p.toolError(req, fmt.Sprintf("Failed to remove domain: %v", err), err), nil
+	}
+
+	if add {
+		return server.OK(fmt.Sprintf("Domain '%s' added to application '%s'", domainName, appName), map[string]string{"app_name": appName, "domain": domainName}), nil
+	}
+	return server.OK(fmt.Sprintf("Domain '%s' removed from application '%s'", domainName, appName), map[string]string{"app_name": appName, "domain": domainName}), nil
+}
+
+func (p *AppsServerPlugin) handleRenderAppConfigTemplate(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	appName, err := req.RequireString("app_name")
+	if err != nil {
+		return server.Error("Application name is required"), nil
+	}
+
+	template := stringMapArgument(req, "template")
+	if len(template) == 0 {
+		return server.Error("At least one template variable is required"), nil
+	}
+
+	rendered, err := appdomain.RenderConfigTemplate(template, stringMapArgument(req, "values"))
+	if err != nil {
+		return server.Error(fmt.Sprintf("Failed to render config template: %v", err)), nil
+	}
+
+	// Secrets were provided by the caller; never echo them back
+	masked := shared.MaskSensitiveValues(rendered)
+
+	if !req.GetBool("apply", false) {
+		return server.OK(fmt.Sprintf("Rendered config for '%s' (not applied)", appName), masked), nil
+	}
+
+	cmd := appusecases.SetConfigCommand{
+		Name:      appName,
+		Config:    rendered,
+		NoRestart: req.GetBool("no_restart", false),
+	}
+
+	if err := p.applicationUseCase.SetApplicationConfig(ctx, cmd); err != nil {
+		if errors.Is(err, appdomain.ErrApplicationNotFound) {
+			return server.Error(fmt.Sprintf("Application '%s' not found", appName)), nil
+		}
+		return p.toolError(req, fmt.Sprintf("Failed to apply rendered config: %v", err), err), nil
+	}
+
+	return server.OK(fmt.Sprintf("Rendered config applied to '%s'", appName), masked), nil
+}
+
+func (p *AppsServerPlugin) handleRotateAppSecret(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	appName, err := req.RequireString("app_name")
+	if err != nil {
+		return server.Error("Application name is required"), nil
+	}
+
+	key, err := req.RequireString("key")
+	if err != nil {
+		return server.Error("Secret key name is required"), nil
+	}
+
+	// Dokku echoes the values it sets, so command output is never attached here
+	if err := p.applicationUseCase.RotateSecret(ctx, appusecases.RotateSecretCommand{Name: appName, Key: key}); err != nil {
+		if errors.Is(err, appdomain.ErrApplicationNotFound) {
+			return server.Error(fmt.Sprintf("Application '%s' not found", appName)), nil
+		}
+		return server.Error(fmt.Sprintf("Failed to rotate secret %s: %v", key, err)), nil
+	}
+
+	return server.OK(fmt.Sprintf("Secret %s of application '%s' rotated and the app restarted", key, appName), map[string]string{"app_name": appName, "key": key}), nil
+}
+
+func (p *AppsServerPlugin) handleExportAllApps(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	backup, err := p.applicationUseCase.ExportAllApps(ctx, appusecases.ExportAppsCommand{
+		Encrypt:       req.GetBool("encrypt", false),
+		EncryptionKey: req.GetString("encryption_key", ""),
+	})
+	if err != nil {
+		if errors.Is(err, appdomain.ErrEncryptionKeyRequired) {
+			return server.Error("An encryption_key is required to encrypt sensitive values"), nil
+		}
+		return p.toolError(req, fmt.Sprintf("Failed to export applications: %v", err), err), nil
+	}
+
+	return server.OK(fmt.Sprintf("Exported %d applications", len(backup.Apps)), backup), nil
+}
+
+func (p *AppsServerPlugin) handleImportAllApps(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	rawBackup, err := req.RequireString("backup")
+	if err != nil {
+		return server.Error("A backup is required"), nil
+	}
+
+	var backup appdomain.AppsBackup
+	if err := json.Unmarshal([]byte(rawBackup), &backup); err != nil {
+		return server.Error(fmt.Sprintf("Invalid backup JSON: %v", err)), nil
+	}
+
+	results, err := p.applicationUseCase.ImportAllApps(ctx, appusecases.ImportAppsCommand{
+		Backup:        &backup,
+		EncryptionKey: req.GetString("encryption_key", ""),
+	})
+	if err != nil {
+		if errors.Is(err, appdomain.ErrEncryptionKeyRequired) {
+			return server.Error("This backup holds encrypted values: the encryption_key used to export it is required"), nil
+		}
+		return p.toolError(req, fmt.Sprintf("Failed to import applications: %v", err), err), nil
+	}
+
+	return server.OK(fmt.Sprintf("Imported %d applications", len(results)), results), nil
+}
+
+func (p *AppsServerPlugin) handleGetAppStatus(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	appName, err := req.RequireString("app_name")
+	if err != nil {
+		return server.Error("Application name is required"), nil
+	}
+
+	app, err := p.applicationUseCase.GetApplicationByName(ctx, appName)
+	if err != nil {
+		if errors.Is(err, appdomain.ErrApplicationNotFound) {
+			return server.Error(fmt.Sprintf("Application '%s' not found", appName)), nil
+		}
+		return server.Error(fmt.Sprintf("Failed to get application status: %v", err)), nil
+	}
+
+	status := appdomain.ApplicationStatus{
+		Name:          app.Name().Value(),
+		State:         string(app.State().Value()),
+		CreatedAt:     app.CreatedAt(),
+		UpdatedAt:     app.UpdatedAt(),
+		IsRunning:     app.IsRunning(),
+		IsDeployed:    app.IsDeployed(),
+		Domains:       app.GetDomains(),
+		VhostsEnabled: app.VhostsEnabled(),
+		GlobalDomains: app.GetGlobalDomains(),
+	}
+
+	if appJSON := req.GetString("app_json", ""); appJSON != "" {
+		healthchecks, err := appdomain.ParseHealthchecks(appJSON)
+		if err != nil {
+			return server.Error(err.Error()), nil
+		}
+		status.Healthchecks = healthchecks
+	}
+
+	if https, err := p.applicationUseCase.GetHTTPSStatus(ctx, app); err == nil {
+		status.HTTPS = https
+	} else {
+		p.logger.Debug("HTTPS status unavailable", "app_name", appName, "error", err)
+	}
+
+	if builder, err := p.applicationUseCase.GetBuilderSettings(ctx, appName); err == nil {
+		status.Builder = builder.Computed
+	} else {
+		p.logger.Debug("Builder unavailable", "app_name", appName, "error", err)
+	}
+
+	if lastDeploy, err := p.applicationUseCase.GetLastDeployStatus(ctx, app); err == nil {
+		status.LastDeployStatus = string(lastDeploy)
+	} else {
+		p.logger.Debug("Last deploy status unavailable", "app_name", appName, "error", err)
+	}
+
+	return server.OK(fmt.Sprintf("Application '%s' is %s", appName, status.State), status), nil
+}
+
+func (p *AppsServerPlugin) handleGetDeploymentHistory(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	appName, err := req.RequireString("app_name")
+	if err != nil {
+		return server.Error("Application name is required"), nil
+	}
+
+	limit := req.GetInt("limit", defaultDeploymentHistoryLimit)
+	if limit < 1 {
+		return server.Error("Limit must be at least 1"), nil
+	}
+
+	history, err := p.applicationUseCase.GetDeploymentHistory(ctx, appName, limit)
+	if err != nil {
+		if errors.Is(err, appdomain.ErrApplicationNotFound) {
+			return server.Error(fmt.Sprintf("Application '%s' not found", appName)), nil
+		}
+		return p.toolError(req, fmt.Sprintf("Failed to get deployment history: %v", err), err), nil
+	}
+
+	return server.OK(fmt.Sprintf("%d deployments of '%s'", len(history.Deployments), appName), history), nil
+}
+
+func (p *AppsServerPlugin) handleGetAppProcesses(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	appName, err := req.RequireString("app_name")
+	if err != nil {
+		return server.Error("Application name is required"), nil
+	}
+
+	report, err := p.applicationUseCase.GetProcessReport(ctx, appName)
+	if err != nil {
+		if errors.Is(err, appdomain.ErrApplicationNotFound) {
+			return server.Error(fmt.Sprintf("Application '%s' not found", appName)), nil
+		}
+		return p.toolError(req, fmt.Sprintf("Failed to get process report: %v", err), err), nil
+	}
+
+	return server.OK(fmt.Sprintf("Processes of '%s'", appName), report), nil
+}
+
+func (p *AppsServerPlugin) handleDetectCrashLoops(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	appName, err := req.RequireString("app_name")
+	if err != nil {
+		return server.Error("Application name is required"), nil
+	}
+
+	threshold := req.GetInt("threshold", appdomain.DefaultCrashLoopThreshold)
+	if threshold < 1 {
+		return server.Error("Threshold must be at least 1"), nil
+	}
+
+	window := appdomain.DefaultCrashLoopWindow
+	if value := req.GetString("window", ""); value != "" {
+		window, err = time.ParseDuration(value)
+		if err != nil || window < 0 {
+			return server.Error(fmt.Sprintf("Invalid window '%s': expected a duration like 15m or 1h", value)), nil
+		}
+	}
+
+	report, err := p.applicationUseCase.DetectCrashLoops(ctx, appName, threshold, window)
+	if err != nil {
+		if errors.Is(err, appdomain.ErrApplicationNotFound) {
+			return server.Error(fmt.Sprintf("Application '%s' not found", appName)), nil
+		}
+		return p.toolError(req, fmt.Sprintf("Failed to detect crash loops: %v", err), err), nil
+	}
+
+	message := fmt.Sprintf("No crash loop detected for '%s'", appName)
+	if report.CrashLooping {
+		message = fmt.Sprintf("Crash loop detected for '%s': %s", appName, strings.Join(report.ProcessTypes, ", "))
+	}
+	return server.OK(message, report), nil
+}
+
+func (p *AppsServerPlugin) handleGetAppGitInfo(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	appName, err := req.RequireString("app_name")
+	if err != nil {
+		return server.Error("Application name is required"), nil
+	}
+
+	info, err := p.applicationUseCase.GetGitInfo(ctx, appName)
+	if err != nil {
+		if errors.Is(err, appdomain.ErrApplicationNotFound) {
+			return server.Error(fmt.Sprintf("Application '%s' not found", appName)), nil
+		}
+		return p.toolError(req, fmt.Sprintf("Failed to get git info: %v", err), err), nil
+	}
+
+	return server.OK(fmt.Sprintf("Git settings of '%s'", appName), info), nil
+}
+
+func (p *AppsServerPlugin) handleGetAppDiskUsage(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	appName, err := req.RequireString("app_name")
+	if err != nil {
+		return server.Error("Application name is required"), nil
+	}
+
+	usage, err := p.applicationUseCase.GetDiskUsage(ctx, appName)
+	if err != nil {
+		if errors.Is(err, appdomain.ErrApplicationNotFound) {
+			return server.Error(fmt.Sprintf("Application '%s' not found", appName)), nil
+		}
+		return p.toolError(req, fmt.Sprintf("Failed to get disk usage: %v", err), err), nil
+	}
+
+	return server.OK(fmt.Sprintf("Disk usage of '%s'", appName), usage), nil
+}
+
+func (p *AppsServerPlugin) handleListAppImages(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	appName, err := req.RequireString("app_name")
+	if err != nil {
+		return server.Error("Application name is required"), nil
 	}
 
-	cmd := appusecases.ScaleApplicationCommand{
-		Name:        appName,
-		ProcessType: processType,
-		Scale:       instances,
+	images, err := p.applicationUseCase.GetImages(ctx, appName)
+	if err != nil {
+		if errors.Is(err, appdomain.ErrApplicationNotFound) {
+			return server.Error(fmt.Sprintf("Application '%s' not found", appName)), nil
+		}
+		return p.toolError(req, fmt.Sprintf("Failed to list images: %v", err), err), nil
+	}
+
+	return server.OK(fmt.Sprintf("Docker images of '%s'", appName), images), nil
+}
+
+func (p *AppsServerPlugin) handlePruneAppImages(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	appName, err := req.RequireString("app_name")
+	if err != nil {
+		return server.Error("Application name is required"), nil
+	}
+
+	confirmation, err := req.RequireString("confirm_name")
+	if err != nil || confirmation != appName {
+		return server.Error("Confirmation is required: repeat the application name in confirm_name"), nil
 	}
 
-	if err := p.applicationUseCase.ScaleApplication(ctx, cmd); err != nil {
+	keepPrevious := req.GetInt("keep_previous", p.imagesConfig.KeepPrevious)
+	if keepPrevious < 0 {
+		return server.Error("keep_previous cannot be negative"), nil
+	}
+
+	result, err := p.applicationUseCase.PruneImages(ctx, appName, keepPrevious)
+	if err != nil {
 		if errors.Is(err, appdomain.ErrApplicationNotFound) {
-			return mcp.NewToolResultError(fmt.Sprintf("Application '%s' not found", appName)), nil
+			return server.Error(fmt.Sprintf("Application '%s' not found", appName)), nil
 		}
-		if errors.Is(err, appdomain.ErrApplicationNotDeployed) {
-			return mcp.NewToolResultError(fmt.Sprintf("Application '%s' is not deployed", appName)), nil
+		return p.toolError(req, fmt.Sprintf("Failed to prune images: %v", err), err), nil
+	}
+
+	message := fmt.Sprintf("Removed %d images of '%s', kept %d", len(result.Removed), appName, len(result.Kept))
+	if len(result.NotRemovable) > 0 {
+		return server.OK(message, result, fmt.Sprintf("%d untagged images cannot be removed through Dokku; run docker image prune on the host to reclaim them", len(result.NotRemovable))), nil
+	}
+	return server.OK(message, result), nil
+}
+
+func (p *AppsServerPlugin) handleGetSystemDiskUsage(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	usage, err := p.applicationUseCase.GetSystemDiskUsage(ctx)
+	if err != nil {
+		return server.Error(fmt.Sprintf("Failed to get system disk usage: %v", err)), nil
+	}
+
+	return server.OK("Docker disk usage of the Dokku host", usage), nil
+}
+
+func (p *AppsServerPlugin) handleGetAppNginxConfig(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	appName, err := req.RequireString("app_name")
+	if err != nil {
+		return server.Error("Application name is required"), nil
+	}
+
+	nginxConfig, err := p.applicationUseCase.GetNginxConfig(ctx, appName)
+	if err != nil {
+		if errors.Is(err, appdomain.ErrApplicationNotFound) {
+			return server.Error(fmt.Sprintf("Application '%s' not found", appName)), nil
 		}
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to scale application: %v", err)), nil
+		return p.toolError(req, fmt.Sprintf("Failed to get nginx config: %v", err), err), nil
 	}
 
-	return mcp.NewToolResultText(fmt.Sprintf("Application '%s' scaled to %d instances for process type '%s'", appName, instances, processType)), nil
+	return server.OK(fmt.Sprintf("Nginx config of '%s'", appName), nginxConfig), nil
 }
 
-func (p *AppsServerPlugin) handleConfigureApp(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+func (p *AppsServerPlugin) handleSetAppNginxConfig(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	appName, err := req.RequireString("app_name")
 	if err != nil {
-		return mcp.NewToolResultError("Application name is required"), nil
+		return server.Error("Application name is required"), nil
 	}
 
-	configVars := make(map[string]string)
-	if configParam, ok := req.GetArguments()["config"]; ok {
-		if configMap, ok := configParam.(map[string]interface{}); ok { // NOTE: This is a valid exception
-			for key, value := range configMap {
-				if valueStr, ok := value.(string); ok {
-					configVars[key] = valueStr
-				}
-			}
+	property, err := req.RequireString("property")
+	if err != nil {
+		return server.Error("Nginx property is required"), nil
+	}
+
+	cmd := appusecases.SetNginxPropertyCommand{
+		Name:     appName,
+		Property: property,
+		Value:    req.GetString("value", ""),
+	}
+
+	if err := p.applicationUseCase.SetNginxProperty(ctx, cmd); err != nil {
+		if errors.Is(err, appdomain.ErrApplicationNotFound) {
+			return server.Error(fmt.Sprintf("Application '%s' not found", appName)), nil
 		}
+		if errors.Is(err, appdomain.ErrUnsupportedNginxProperty) || errors.Is(err, appdomain.ErrInvalidNginxValue) {
+			return server.Error(err.Error()), nil
+		}
+		return p.toolError(req, fmt.Sprintf("Failed to set nginx config: %v", err), err), nil
 	}
 
-	if len(configVars) == 0 {
-		return mcp.NewToolResultError("At least one configuration variable is required"), nil
+	if cmd.Value == "" {
+		return server.OK(fmt.Sprintf("Nginx %s reset to the default for '%s'", property, appName), map[string]string{"app_name": appName, "property": property, "value": ""}), nil
 	}
+	return server.OK(fmt.Sprintf("Nginx %s set to '%s' for '%s'", property, cmd.Value, appName), map[string]string{"app_name": appName, "property": property, "value": cmd.Value}), nil
+}
 
-	cmd := appusecases.SetConfigCommand{
-		Name:   appName,
-		Config: configVars,
+func (p *AppsServerPlugin) handleGetAppChecks(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	appName, err := req.RequireString("app_name")
+	if err != nil {
+		return server.Error("Application name is required"), nil
 	}
 
-	if err := p.applicationUseCase.SetApplicationConfig(ctx, cmd); err != nil {
+	settings, err := p.applicationUseCase.GetChecksSettings(ctx, appName)
+	if err != nil {
 		if errors.Is(err, appdomain.ErrApplicationNotFound) {
-			return mcp.NewToolResultError(fmt.Sprintf("Application '%s' not found", appName)), nil
+			return server.Error(fmt.Sprintf("Application '%s' not found", appName)), nil
 		}
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to configure application: %v", err)), nil
+		return p.toolError(req, fmt.Sprintf("Failed to get checks settings: %v", err), err), nil
 	}
 
-	return mcp.NewToolResultText(fmt.Sprintf("Application '%s' configured successfully with %d variables", appName, len(configVars))), nil
+	return server.OK(fmt.Sprintf("Zero-downtime checks settings of '%s'", appName), settings), nil
 }
 
-func (p *AppsServerPlugin) handleGetAppStatus(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+func (p *AppsServerPlugin) handleSetAppChecks(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	appName, err := req.RequireString("app_name")
 	if err != nil {
-		return mcp.NewToolResultError("Application name is required"), nil
+		return server.Error("Application name is required"), nil
 	}
 
-	app, err := p.applicationUseCase.GetApplicationByName(ctx, appName)
+	cmd := appusecases.SetChecksSettingsCommand{
+		Name:         appName,
+		WaitToRetire: req.GetString("wait_to_retire", ""),
+		Timeout:      req.GetString("timeout", ""),
+	}
+
+	if err := p.applicationUseCase.SetChecksSettings(ctx, cmd); err != nil {
+		if errors.Is(err, appdomain.ErrApplicationNotFound) {
+			return server.Error(fmt.Sprintf("Application '%s' not found", appName)), nil
+		}
+		if errors.Is(err, appdomain.ErrInvalidChecksDuration) {
+			return server.Error(err.Error()), nil
+		}
+		return p.toolError(req, fmt.Sprintf("Failed to set checks settings: %v", err), err), nil
+	}
+
+	var changes []string
+	if cmd.WaitToRetire != "" {
+		changes = append(changes, fmt.Sprintf("wait-to-retire %s", cmd.WaitToRetire))
+	}
+	if cmd.Timeout != "" {
+		changes = append(changes, fmt.Sprintf("timeout %s (applies from the next deploy)", cmd.Timeout))
+	}
+	return server.OK(fmt.Sprintf("Checks for '%s' updated: %s", appName, strings.Join(changes, ", ")), map[string]string{"app_name": appName, "wait_to_retire": cmd.WaitToRetire, "timeout": cmd.Timeout}), nil
+}
+
+func (p *AppsServerPlugin) handleGetAppBuilder(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	appName, err := req.RequireString("app_name")
+	if err != nil {
+		return server.Error("Application name is required"), nil
+	}
+
+	settings, err := p.applicationUseCase.GetBuilderSettings(ctx, appName)
 	if err != nil {
 		if errors.Is(err, appdomain.ErrApplicationNotFound) {
-			return mcp.NewToolResultError(fmt.Sprintf("Application '%s' not found", appName)), nil
+			return server.Error(fmt.Sprintf("Application '%s' not found", appName)), nil
 		}
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to get application status: %v", err)), nil
+		return p.toolError(req, fmt.Sprintf("Failed to get builder: %v", err), err), nil
 	}
 
-	status := appdomain.ApplicationStatus{
-		Name:       app.Name().Value(),
-		State:      string(app.State().Value()),
-		CreatedAt:  app.CreatedAt(),
-		UpdatedAt:  app.UpdatedAt(),
-		IsRunning:  app.IsRunning(),
-		IsDeployed: app.IsDeployed(),
-		Domains:    app.GetDomains(),
+	return server.OK(fmt.Sprintf("Builder of '%s'", appName), settings), nil
+}
+
+func (p *AppsServerPlugin) handleSetAppBuilder(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	appName, err := req.RequireString("app_name")
+	if err != nil {
+		return server.Error("Application name is required"), nil
+	}
+	builder, err := req.RequireString("builder")
+	if err != nil {
+		return server.Error("A builder is required"), nil
+	}
+
+	selected, err := p.applicationUseCase.SetBuilder(ctx, appName, builder)
+	if err != nil {
+		if errors.Is(err, appdomain.ErrApplicationNotFound) {
+			return server.Error(fmt.Sprintf("Application '%s' not found", appName)), nil
+		}
+		if errors.Is(err, appdomain.ErrInvalidBuilder) {
+			return server.Error(err.Error()), nil
+		}
+		return p.toolError(req, fmt.Sprintf("Failed to set builder: %v", err), err), nil
+	}
+
+	return server.OK(fmt.Sprintf("Builder of '%s' set to '%s'", appName, selected),
+		map[string]string{"app_name": appName, "builder": string(selected)},
+		fmt.Sprintf("The running containers were built with the previous builder: rebuild or redeploy '%s' to use %s", appName, selected)), nil
+}
+
+func (p *AppsServerPlugin) handleGetAppHealthchecks(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	appName, err := req.RequireString("app_name")
+	if err != nil {
+		return server.Error("Application name is required"), nil
+	}
+	appJSON, err := req.RequireString("app_json")
+	if err != nil {
+		return server.Error("app_json is required"), nil
+	}
+
+	report, err := p.applicationUseCase.GetHealthchecks(ctx, appName, appJSON)
+	if err != nil {
+		if errors.Is(err, appdomain.ErrApplicationNotFound) {
+			return server.Error(fmt.Sprintf("Application '%s' not found", appName)), nil
+		}
+		if errors.Is(err, appdomain.ErrInvalidHealthchecks) {
+			return server.Error(err.Error()), nil
+		}
+		return p.toolError(req, fmt.Sprintf("Failed to get healthchecks: %v", err), err), nil
+	}
+
+	return server.OK(fmt.Sprintf("Healthchecks of '%s'", appName), report), nil
+}
+
+func (p *AppsServerPlugin) handleSetAppHealthchecks(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	healthchecks, err := req.RequireString("healthchecks")
+	if err != nil {
+		return server.Error("healthchecks is required"), nil
 	}
 
-	statusJSON, err := json.MarshalIndent(status, "", "  ")
+	appJSON, err := p.applicationUseCase.SetHealthchecks(req.GetString("app_json", ""), healthchecks)
 	if err != nil {
-		return mcp.NewToolResultError("Failed to serialize status"), nil
+		return server.Error(err.Error()), nil
+	}
+
+	return server.OK("Updated app.json, commit it and redeploy to apply the healthchecks", map[string]string{"app_json": appJSON}), nil
+}
+
+// stringMapArgument extracts an object argument of string values, ignoring non-string entries
+func stringMapArgument(req mcp.CallToolRequest, name string) map[string]string {
+	result := make(map[string]string)
+	if param, ok := req.GetArguments()[name]; ok {
+		if paramMap, ok := param.(map[string]interface{}); ok { // NOTE: This is a valid exception
+			for key, value := range paramMap {
+				if valueStr, ok := value.(string); ok {
+					result[key] = valueStr
+				}
+			}
+		}
 	}
+	return result
+}
 
-	return mcp.NewToolResultText(fmt.Sprintf("Application Status for '%s':\n%s", appName, string(statusJSON))), nil
+// intMapArgument reads an object argument whose values must be whole numbers
+func intMapArgument(req mcp.CallToolRequest, name string) (map[string]int, error) {
+	result := make(map[string]int)
+	param, ok := req.GetArguments()[name].(map[string]interface{}) // NOTE: This is a valid exception
+	if !ok {
+		return result, nil
+	}
+	for key, value := range param {
+		number, ok := value.(float64)
+		if !ok || number != float64(int(number)) {
+			return nil, fmt.Errorf("invalid value for %s: must be a whole number", key)
+		}
+		result[key] = int(number)
+	}
+	return result, nil
 }
 
 // Prompt implementations
@@ -492,41 +2053,24 @@ func (p *AppsServerPlugin) handleRuntimeLogsResource(ctx context.Context, req mc
 
 	appName := parts[0]
 
-	// Get Dokku client from application use case
-	// We need to access the Dokku client to get logs
-	// For now, we'll use a default lines value
-	lines := p.logsConfig.Runtime.DefaultLines
-	if lines > p.logsConfig.Runtime.MaxLines {
-		lines = p.logsConfig.Runtime.MaxLines
-	}
-
-	// Validate that the application exists
-	_, validationErr := p.applicationUseCase.GetApplicationByName(ctx, appName)
-	if validationErr != nil {
-		p.logger.Error("application not found for logs request", "app_name", appName, "error", validationErr)
-		return nil, fmt.Errorf("application not found")
-	}
+	lines := p.clampLogLines(p.logsConfig.Runtime.DefaultLines)
 
-	// Define typed struct for logs response
-	type RuntimeLogsResponse struct {
-		AppName string `json:"app_name"`
-		Lines   int    `json:"lines"`
-		Logs    string `json:"logs"`
-		Note    string `json:"note"`
+	logs, err := p.applicationUseCase.GetLogs(ctx, appName, "", lines, appdomain.LogTimeWindow{})
+	if err != nil {
+		if errors.Is(err, appdomain.ErrApplicationNotFound) {
+			p.logger.Error("application not found for logs request", "app_name", appName, "error", err)
+			return nil, fmt.Errorf("application not found")
+		}
+		return nil, fmt.Errorf("failed to get logs: %w", err)
 	}
 
-	// Get logs from Dokku
-	// Note: This is a simplified implementation - in a real scenario,
-	// we would need to access the Dokku client through the use case
-	// For now, we'll return a placeholder response
-	response := RuntimeLogsResponse{
+	response := runtimeLogsResponse{
 		AppName: appName,
 		Lines:   lines,
-		Logs:    "Runtime logs would be retrieved from Dokku here",
-		Note:    "This is a placeholder - actual Dokku client integration needed",
+		Logs:    logs.Logs,
 	}
 
-	jsonData, err := json.MarshalIndent(response, "", "  ")
+	jsonData, err := shared.MarshalOutput(response)
 	if err != nil {
 		p.logger.Error("failed to serialize logs response", "app_name", appName, "error", err)
 		return nil, fmt.Errorf("failed to serialize logs response")
@@ -560,66 +2104,264 @@ func (p *AppsServerPlugin) buildGetRuntimeLogsTool() mcp.Tool {
 func (p *AppsServerPlugin) handleGetRuntimeLogs(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	appName, err := req.RequireString("app_name")
 	if err != nil {
-		return mcp.NewToolResultError("Application name is required"), nil
+		return server.Error("Application name is required"), nil
+	}
+
+	lines := p.clampLogLines(req.GetInt("lines", p.logsConfig.Runtime.DefaultLines))
+
+	logs, err := p.applicationUseCase.GetLogs(ctx, appName, "", lines, appdomain.LogTimeWindow{})
+	if err != nil {
+		if errors.Is(err, appdomain.ErrApplicationNotFound) {
+			return server.Error("Application not found"), nil
+		}
+		return p.toolError(req, fmt.Sprintf("Failed to get logs: %v", err), err), nil
+	}
+
+	return server.OK(fmt.Sprintf("Runtime logs of '%s'", appName), runtimeLogsResponse{
+		AppName: appName,
+		Lines:   lines,
+		Logs:    logs.Logs,
+	}), nil
+}
+
+// appConfigResponse is the JSON shape of the application config resource
+type appConfigResponse struct {
+	AppName  string            `json:"app_name"`
+	Config   map[string]string `json:"config"`
+	Revealed bool              `json:"revealed"`
+}
+
+// Application config resource handler: dokku://apps/{name}/config, with a reveal
+// query parameter to return sensitive values unmasked when security.allow_config_reveal is set
+func (p *AppsServerPlugin) handleApplicationConfigResource(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	uri, err := url.Parse(req.Params.URI)
+	if err != nil || uri.Scheme != "dokku" || uri.Host != "apps" {
+		return nil, fmt.Errorf("invalid application config resource URI: %s", req.Params.URI)
+	}
+
+	parts := strings.Split(strings.TrimPrefix(uri.Path, "/"), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] != "config" {
+		return nil, fmt.Errorf("invalid application config resource URI format: %s", req.Params.URI)
+	}
+	appName := parts[0]
+	_, reveal := uri.Query()["reveal"]
+	if reveal && !p.allowConfigReveal {
+		return nil, fmt.Errorf("revealing config values is disabled, set security.allow_config_reveal to enable it")
+	}
+
+	vars, err := p.applicationUseCase.GetApplicationConfig(ctx, appName)
+	if err != nil {
+		if errors.Is(err, appdomain.ErrApplicationNotFound) {
+			return nil, fmt.Errorf("application not found")
+		}
+		return nil, fmt.Errorf("failed to get application config: %w", err)
+	}
+	if !reveal {
+		vars = shared.MaskSensitiveValues(vars)
+	}
+
+	jsonData, err := shared.MarshalOutput(appConfigResponse{
+		AppName:  appName,
+		Config:   vars,
+		Revealed: reveal,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize application config: %w", err)
+	}
+
+	return []mcp.ResourceContents{
+		mcp.TextResourceContents{
+			URI:      req.Params.URI,
+			MIMEType: "application/json",
+			Text:     string(jsonData),
+		},
+	}, nil
+}
+
+// Deployment history resource handler: dokku://apps/{name}/deployments
+func (p *AppsServerPlugin) handleDeploymentHistoryResource(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	uri, err := url.Parse(req.Params.URI)
+	if err != nil || uri.Scheme != "dokku" || uri.Host != "apps" {
+		return nil, fmt.Errorf("invalid deployment history resource URI: %s", req.Params.URI)
+	}
+
+	parts := strings.Split(strings.TrimPrefix(uri.Path, "/"), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] != "deployments" {
+		return nil, fmt.Errorf("invalid deployment history resource URI format: %s", req.Params.URI)
 	}
 
-	// Get lines parameter
-	lines := p.logsConfig.Runtime.DefaultLines
-	if linesParam, ok := req.GetArguments()["lines"]; ok {
-		if linesFloat, ok := linesParam.(float64); ok {
-			lines = int(linesFloat)
+	history, err := p.applicationUseCase.GetDeploymentHistory(ctx, parts[0], 0)
+	if err != nil {
+		if errors.Is(err, appdomain.ErrApplicationNotFound) {
+			return nil, fmt.Errorf("application not found")
 		}
+		return nil, err
+	}
+
+	jsonData, err := shared.MarshalOutput(history)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize deployment history: %w", err)
 	}
 
-	// Validate lines
+	return []mcp.ResourceContents{
+		mcp.TextResourceContents{
+			URI:      req.Params.URI,
+			MIMEType: "application/json",
+			Text:     string(jsonData),
+		},
+	}, nil
+}
+
+// runtimeLogsResponse is the JSON shape of the runtime logs resource and tools
+type runtimeLogsResponse struct {
+	AppName     string `json:"app_name"`
+	ProcessType string `json:"process_type,omitempty"`
+	Lines       int    `json:"lines"`
+	Since       string `json:"since,omitempty"`
+	Until       string `json:"until,omitempty"`
+	Logs        string `json:"logs"`
+	// Truncated is set when since/until reach back before the oldest retrieved line
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// clampLogLines keeps a requested number of log lines between 1 and the configured maximum
+func (p *AppsServerPlugin) clampLogLines(lines int) int {
 	if lines < 1 {
-		lines = 1
+		return 1
+	}
+	return min(lines, p.logsConfig.Runtime.MaxLines)
+}
+
+func (p *AppsServerPlugin) buildGetAppLogsTool() mcp.Tool {
+	return mcp.NewTool(
+		"get_app_logs",
+		mcp.WithDescription("Get the last lines of an application's logs (dokku logs). An application that was never deployed has empty logs"),
+		mcp.WithString("app_name",
+			mcp.Required(),
+			mcp.Description("Name of the application"),
+		),
+		mcp.WithString("process_type",
+			mcp.Description("Only return logs of this process type (e.g. web, worker); all process types by default"),
+		),
+		mcp.WithNumber("lines",
+			mcp.Description(fmt.Sprintf("Number of log lines to retrieve (default: %d, max: %d)", p.logsConfig.Runtime.DefaultLines, p.logsConfig.Runtime.MaxLines)),
+		),
+		mcp.WithString("since",
+			mcp.Description("Only keep lines logged at or after this time: an RFC3339 timestamp or a duration ago such as 30m or 2h. dokku logs has no time flags, so the last lines retrieved are filtered by their timestamp and the result is flagged truncated when the window reaches further back"),
+		),
+		mcp.WithString("until",
+			mcp.Description("Only keep lines logged at or before this time: an RFC3339 timestamp or a duration ago such as 10m"),
+		),
+		mcp.WithBoolean("tail",
+			mcp.Description("Follow the logs. Not supported: a tool call returns a single result, so poll with lines instead"),
+		),
+	)
+}
+
+func (p *AppsServerPlugin) handleGetAppLogs(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	appName, err := req.RequireString("app_name")
+	if err != nil {
+		return server.Error("Application name is required"), nil
 	}
-	if lines > p.logsConfig.Runtime.MaxLines {
-		lines = p.logsConfig.Runtime.MaxLines
+
+	if req.GetBool("tail", false) {
+		return server.Error("Streaming logs is not available over a single tool call; call get_app_logs again with lines to poll for new output"), nil
+	}
+
+	processType := req.GetString("process_type", "")
+	lines := p.clampLogLines(req.GetInt("lines", p.logsConfig.Runtime.DefaultLines))
+
+	window, err := appdomain.NewLogTimeWindow(req.GetString("since", ""), req.GetString("until", ""), time.Now())
+	if err != nil {
+		return server.Error(err.Error()), nil
 	}
 
-	// Validate that the application exists
-	_, validationErr := p.applicationUseCase.GetApplicationByName(ctx, appName)
-	if validationErr != nil {
-		p.logger.Error("application not found for logs tool", "app_name", appName, "error", validationErr)
-		return mcp.NewToolResultError("Application not found"), nil
+	logs, err := p.applicationUseCase.GetLogs(ctx, appName, processType, lines, window)
+	if err != nil {
+		if errors.Is(err, appdomain.ErrApplicationNotFound) {
+			return server.Error(fmt.Sprintf("Application '%s' not found", appName)), nil
+		}
+		return p.toolError(req, fmt.Sprintf("Failed to get logs: %v", err), err), nil
 	}
 
-	// Define typed struct for logs response
-	type RuntimeLogsResponse struct {
-		AppName string `json:"app_name"`
-		Lines   int    `json:"lines"`
-		Logs    string `json:"logs"`
-		Note    string `json:"note"`
+	response := runtimeLogsResponse{
+		AppName:     appName,
+		ProcessType: processType,
+		Lines:       lines,
+		Since:       formatLogBound(window.Since),
+		Until:       formatLogBound(window.Until),
+		Logs:        logs.Logs,
+		Truncated:   logs.Truncated,
+	}
+	if logs.Truncated {
+		return server.OK(fmt.Sprintf("Logs of '%s'", appName), response,
+			fmt.Sprintf("Only the last %d lines were searched and the time window reaches further back; raise lines (max %d) to see earlier logs", lines, p.logsConfig.Runtime.MaxLines)), nil
 	}
+	return server.OK(fmt.Sprintf("Logs of '%s'", appName), response), nil
+}
 
-	// Get logs from Dokku
-	// Note: This is a simplified implementation - in a real scenario,
-	// we would need to access the Dokku client through the use case
-	// For now, we'll return a placeholder response
-	response := RuntimeLogsResponse{
-		AppName: appName,
-		Lines:   lines,
-		Logs:    "Runtime logs would be retrieved from Dokku here",
-		Note:    "This is a placeholder - actual Dokku client integration needed",
+func (p *AppsServerPlugin) buildGetAppURLsTool() mcp.Tool {
+	return mcp.NewTool(
+		"get_app_urls",
+		mcp.WithDescription("Get the full URLs an application can be browsed at, built from the domains routed by the proxy (domains:report). The scheme is https when the app has a TLS certificate (certs:report, e.g. from Let's Encrypt) and http otherwise. Use it after a deploy to get a link to the app"),
+		mcp.WithString("app_name",
+			mcp.Required(),
+			mcp.Description("Name of the application"),
+		),
+		withDebugFlag(),
+	)
+}
+
+func (p *AppsServerPlugin) buildGetAppNginxLogsTool() mcp.Tool {
+	return mcp.NewTool(
+		"get_app_nginx_logs",
+		mcp.WithDescription("Get the end of an application's nginx access and error logs (nginx:access-logs, nginx:error-logs) to debug the web tier: status codes, upstream errors, timeouts. Only for apps served by the nginx proxy. Dokku prints a fixed tail of each log, so fewer lines than requested may come back"),
+		mcp.WithString("app_name",
+			mcp.Required(),
+			mcp.Description("Name of the application"),
+		),
+		mcp.WithNumber("lines",
+			mcp.Description(fmt.Sprintf("Maximum number of lines of each log (default: %d, max: %d)", p.logsConfig.Runtime.DefaultLines, p.logsConfig.Runtime.MaxLines)),
+		),
+	)
+}
+
+func (p *AppsServerPlugin) handleGetAppNginxLogs(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	appName, err := req.RequireString("app_name")
+	if err != nil {
+		return server.Error("Application name is required"), nil
 	}
 
-	jsonData, err := json.MarshalIndent(response, "", "  ")
+	lines := p.clampLogLines(req.GetInt("lines", p.logsConfig.Runtime.DefaultLines))
+
+	logs, err := p.applicationUseCase.GetNginxLogs(ctx, appName, lines)
 	if err != nil {
-		p.logger.Error("failed to serialize logs response for tool", "app_name", appName, "error", err)
-		return mcp.NewToolResultError("Failed to serialize logs response"), nil
+		if errors.Is(err, appdomain.ErrApplicationNotFound) {
+			return server.Error(fmt.Sprintf("Application '%s' not found", appName)), nil
+		}
+		if errors.Is(err, appdomain.ErrProxyNotSupported) {
+			return server.Error(fmt.Sprintf("Cannot read nginx logs of '%s': %v", appName, err)), nil
+		}
+		return p.toolError(req, fmt.Sprintf("Failed to get nginx logs: %v", err), err), nil
 	}
 
-	return mcp.NewToolResultText(fmt.Sprintf("Runtime logs for '%s':\n%s", appName, string(jsonData))), nil
+	return server.OK(fmt.Sprintf("Nginx logs of '%s'", appName), logs), nil
+}
+
+// formatLogBound renders a resolved time bound, empty when the bound is open
+func formatLogBound(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
 }
 
 var Module = fx.Module("app",
 	fx.Provide(
 		// Provide the infrastructure layer dependencies
 		fx.Annotate(
-			func(client dokkuApi.DokkuClient, logger *slog.Logger) appdomain.ApplicationRepository {
-				return infrastructure.NewDokkuApplicationRepository(client, logger)
+			func(client dokkuApi.DokkuClient, scope *shared.AppScope, auditLog audit.EventLog, logger *slog.Logger) appdomain.ApplicationRepository {
+				return infrastructure.NewDokkuApplicationRepository(client, scope, auditLog, logger)
 			},
 		),
 		// Provide the main plugin - deployment service will be injected from deployment plugin
@@ -629,16 +2371,63 @@ var Module = fx.Module("app",
 				deploymentSvc shared.DeploymentService,
 				logger *slog.Logger,
 				config *config.ServerConfig,
+				auditLog audit.EventLog,
 			) domain.ServerPlugin {
 				return NewAppsServerPlugin(
 					applicationRepo,
 					deploymentSvc,
 					logger,
 					config.Logs,
+					config.Images,
+					config.AppList,
+					auditLog,
+					config.ExposeCommandOutput,
+					config.Transport.Type == "sse",
+					config.Security.AllowConfigReveal,
 				)
 			},
 			fx.As(new(domain.ServerPlugin)),
 			fx.ResultTags(`group:"server_plugins"`),
 		),
 	),
+	fx.Invoke(registerDriftDetection),
 )
+
+// registerDriftDetection runs the drift detector while the server is up, when enabled
+func registerDriftDetection(
+	lc fx.Lifecycle,
+	cfg *config.ServerConfig,
+	applicationRepo appdomain.ApplicationRepository,
+	notifier *server.ClientNotifier,
+	logger *slog.Logger,
+) {
+	if !cfg.DriftDetection.Enabled {
+		return
+	}
+
+	detector := appusecases.NewDriftDetector(applicationRepo, notifyDrift(notifier), logger, cfg.DriftDetection.Interval)
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			logger.Info("Starting drift detection", "interval", cfg.DriftDetection.Interval)
+			detector.Start()
+			return nil
+		},
+		OnStop: detector.Stop,
+	})
+}
+
+// notifyDrift sends drift events to the clients allowed to receive notifications, as warning log notifications
+func notifyDrift(notifier *server.ClientNotifier) appusecases.DriftPublisher {
+	return func(event *appdomain.ApplicationDriftDetectedEvent) {
+		notifier.Notify("notifications/message", map[string]any{
+			"level":  mcp.LoggingLevelWarning,
+			"logger": "drift_detection",
+			"data": map[string]any{
+				"event":       event.EventType(),
+				"app_name":    event.AggregateID(),
+				"changes":     event.Changes(),
+				"detected_at": event.OccurredAt(),
+			},
+		})
+	}
+}
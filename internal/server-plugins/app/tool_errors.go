@@ -0,0 +1,78 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+
+	dokkuApi "github.com/dokku-mcp/dokku-mcp/internal/dokku-api"
+	"github.com/dokku-mcp/dokku-mcp/internal/server"
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// toolError builds an error result and, when raw output exposure is enabled
+// (server config or per-call debug flag), adds what Dokku actually printed to its data.
+// The output is redacted and size-capped before being returned to the client.
+func (p *AppsServerPlugin) toolError(req mcp.CallToolRequest, message string, err error) *mcp.CallToolResult {
+	return server.NewResult(p.toolFailure(req, message, err))
+}
+
+// toolFailure is the envelope of toolError; its data, when set, is a map so
+// withValidation can add the validation report next to the Dokku output.
+// A classified git:sync failure keeps its message: the git error is more
+// precise than any cause recognized in the Dokku output.
+func (p *AppsServerPlugin) toolFailure(req mcp.CallToolRequest, message string, err error) server.ToolResult {
+	var gitErr *shared.GitSyncError
+	if precise, ok := server.DokkuFailureMessage(err); ok && !errors.As(err, &gitErr) {
+		message = precise
+	}
+	failure := server.ToolResult{Message: message}
+	if !p.exposeCommandOutput && !req.GetBool("debug", false) {
+		return failure
+	}
+
+	output, ok := dokkuApi.CommandOutput(err)
+	if !ok {
+		return failure
+	}
+
+	failure.Data = map[string]any{"dokku_output": server.FormatRawOutput(output)}
+	return failure
+}
+
+// withDebugFlag adds the per-call debug option to a tool definition
+func withDebugFlag() mcp.ToolOption {
+	return mcp.WithBoolean("debug",
+		mcp.Description("Include the redacted raw Dokku output in the data of error results"),
+	)
+}
+
+// notDeployedResult is the uniform error for operations that need a deployed release
+func notDeployedResult(appName string) *mcp.CallToolResult {
+	return server.Error(fmt.Sprintf("Application '%s' has not been deployed yet; deploy it first with deploy_app", appName))
+}
+
+// gitSyncFailureMessage explains a deployment that failed on a git error retrying cannot fix
+func gitSyncFailureMessage(err error, repoURL, gitRef string) (string, bool) {
+	var gitErr *shared.GitSyncError
+	if !errors.As(err, &gitErr) {
+		return "", false
+	}
+
+	var message string
+	switch {
+	case errors.Is(err, shared.ErrGitAuthenticationFailed):
+		message = fmt.Sprintf("Dokku could not authenticate to '%s'; give the Dokku host access to the repository (deploy key from git:public-key, or credentials for HTTPS remotes)", repoURL)
+	case errors.Is(err, shared.ErrGitRepositoryNotFound):
+		message = fmt.Sprintf("Repository '%s' was not found; check the URL, or give the Dokku host access if it is private", repoURL)
+	case errors.Is(err, shared.ErrGitRefNotFound):
+		message = fmt.Sprintf("Git ref '%s' was not found in '%s'; check the branch, tag or commit name", gitRef, repoURL)
+	default:
+		return "", false
+	}
+	if gitErr.Detail != "" {
+		// The detail may quote a remote URL carrying credentials
+		message += fmt.Sprintf("\n\nGit said: %s", server.SanitizeLogLines([]string{gitErr.Detail})[0])
+	}
+	return message, true
+}
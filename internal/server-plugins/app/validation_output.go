@@ -0,0 +1,57 @@
+package app
+
+import (
+	"github.com/dokku-mcp/dokku-mcp/internal/server"
+	appdomain "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/app/domain"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// validationIssue is the JSON form of a validation error or warning
+type validationIssue struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// validationReport is the JSON form of a ValidationResult exposed to clients
+type validationReport struct {
+	Valid    bool              `json:"valid"`
+	Errors   []validationIssue `json:"errors"`
+	Warnings []validationIssue `json:"warnings"`
+}
+
+// newValidationReport converts a ValidationResult to its JSON form
+func newValidationReport(result *appdomain.ValidationResult) validationReport {
+	report := validationReport{
+		Valid:    result.IsValid,
+		Errors:   make([]validationIssue, 0, len(result.Errors)),
+		Warnings: make([]validationIssue, 0, len(result.Warnings)),
+	}
+	for _, e := range result.Errors {
+		report.Errors = append(report.Errors, validationIssue{Field: e.Field, Code: e.Code, Message: e.Message})
+	}
+	for _, w := range result.Warnings {
+		report.Warnings = append(report.Warnings, validationIssue{Field: w.Field, Code: w.Code, Message: w.Message})
+	}
+	return report
+}
+
+// withValidation renders result with the validation report under the "validation"
+// key of its data when it reports errors or warnings, so clients can parse it
+// independently; validation warnings are also listed in the result warnings
+func (p *AppsServerPlugin) withValidation(result server.ToolResult, validation *appdomain.ValidationResult) *mcp.CallToolResult {
+	if validation == nil || (len(validation.Errors) == 0 && len(validation.Warnings) == 0) {
+		return server.NewResultWithLogger(result, p.logger)
+	}
+
+	data, ok := result.Data.(map[string]any)
+	if !ok {
+		data = map[string]any{}
+	}
+	data["validation"] = newValidationReport(validation)
+	result.Data = data
+	for _, w := range validation.Warnings {
+		result.Warnings = append(result.Warnings, w.Message)
+	}
+	return server.NewResultWithLogger(result, p.logger)
+}
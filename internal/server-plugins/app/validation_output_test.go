@@ -0,0 +1,86 @@
+package app
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/server"
+	appdomain "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/app/domain"
+)
+
+func TestWithValidationAddsReportToData(t *testing.T) {
+	plugin := newTestPlugin(&fakeApplicationRepository{}, false)
+	validation := &appdomain.ValidationResult{
+		IsValid: false,
+		Errors: []appdomain.ValidationError{
+			{Field: "scale", Message: "Number of instances cannot be negative", Code: "INVALID_SCALE"},
+		},
+		Warnings: []appdomain.ValidationWarning{
+			{Field: "process_type", Message: "Process type worker is not yet configured", Code: "PROCESS_NOT_CONFIGURED"},
+		},
+	}
+
+	result := plugin.withValidation(server.ToolResult{Message: "Failed to scale application"}, validation)
+	if !result.IsError {
+		t.Fatal("expected an error result")
+	}
+
+	var envelope struct {
+		Data struct {
+			Validation map[string]any `json:"validation"`
+		} `json:"data"`
+		Warnings []string `json:"warnings"`
+	}
+	if err := json.Unmarshal([]byte(resultText(t, result)), &envelope); err != nil {
+		t.Fatalf("result is not a JSON envelope: %v", err)
+	}
+	if len(envelope.Warnings) != 1 || envelope.Warnings[0] != "Process type worker is not yet configured" {
+		t.Fatalf("expected the validation warning in the result warnings, got %v", envelope.Warnings)
+	}
+
+	report := envelope.Data.Validation
+	if report["valid"] != false {
+		t.Fatalf("expected valid=false, got %v", report["valid"])
+	}
+
+	assertIssues := func(key string, want map[string]any) {
+		t.Helper()
+		issues, ok := report[key].([]any)
+		if !ok || len(issues) != 1 {
+			t.Fatalf("expected one %s entry, got %v", key, report[key])
+		}
+		issue, ok := issues[0].(map[string]any)
+		if !ok {
+			t.Fatalf("expected %s entry to be an object, got %T", key, issues[0])
+		}
+		if len(issue) != len(want) {
+			t.Fatalf("unexpected %s fields: %v", key, issue)
+		}
+		for field, value := range want {
+			if issue[field] != value {
+				t.Fatalf("%s.%s = %v, want %v", key, field, issue[field], value)
+			}
+		}
+	}
+
+	assertIssues("errors", map[string]any{
+		"field":   "scale",
+		"code":    "INVALID_SCALE",
+		"message": "Number of instances cannot be negative",
+	})
+	assertIssues("warnings", map[string]any{
+		"field":   "process_type",
+		"code":    "PROCESS_NOT_CONFIGURED",
+		"message": "Process type worker is not yet configured",
+	})
+}
+
+func TestWithValidationSkipsEmptyResult(t *testing.T) {
+	plugin := newTestPlugin(&fakeApplicationRepository{}, false)
+
+	result := plugin.withValidation(server.ToolResult{Success: true, Message: "ok", Data: map[string]any{"app_name": "my-app"}}, &appdomain.ValidationResult{IsValid: true})
+	if text := resultText(t, result); strings.Contains(text, "validation") {
+		t.Fatalf("expected no validation report, got %s", text)
+	}
+}
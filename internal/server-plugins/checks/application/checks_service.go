@@ -0,0 +1,64 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/checks/domain"
+)
+
+// ChecksService provides application-level orchestration for zero-downtime checks
+type ChecksService struct {
+	checksRepo domain.ChecksRepository
+	logger     *slog.Logger
+}
+
+// NewChecksService creates a new checks application service
+func NewChecksService(checksRepo domain.ChecksRepository, logger *slog.Logger) *ChecksService {
+	return &ChecksService{
+		checksRepo: checksRepo,
+		logger:     logger,
+	}
+}
+
+// GetStatus reads the checks state of an application
+func (s *ChecksService) GetStatus(ctx context.Context, appName string) (*domain.ChecksStatus, error) {
+	if appName == "" {
+		return nil, fmt.Errorf("app name cannot be empty")
+	}
+	return s.checksRepo.GetStatus(ctx, appName)
+}
+
+// Enable turns the checks back on for a comma-separated list of process types, or all of them
+func (s *ChecksService) Enable(ctx context.Context, appName, processTypes string) error {
+	return s.toggle(ctx, "Enabling checks", appName, processTypes, s.checksRepo.Enable)
+}
+
+// Disable turns the checks off: the process types are deployed without checks and with downtime
+func (s *ChecksService) Disable(ctx context.Context, appName, processTypes string) error {
+	return s.toggle(ctx, "Disabling checks", appName, processTypes, s.checksRepo.Disable)
+}
+
+// Skip deploys the process types without checks but keeps the zero-downtime switch
+func (s *ChecksService) Skip(ctx context.Context, appName, processTypes string) error {
+	return s.toggle(ctx, "Skipping checks", appName, processTypes, s.checksRepo.Skip)
+}
+
+// toggle validates the process types before changing the checks of an application
+func (s *ChecksService) toggle(
+	ctx context.Context,
+	message, appName, processTypes string,
+	change func(ctx context.Context, appName string, processTypes []string) error,
+) error {
+	if appName == "" {
+		return fmt.Errorf("app name cannot be empty")
+	}
+	types, err := domain.ParseProcessTypes(processTypes)
+	if err != nil {
+		return err
+	}
+
+	s.logger.Info(message, "app_name", appName, "process_types", types)
+	return change(ctx, appName, types)
+}
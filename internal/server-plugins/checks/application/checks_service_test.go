@@ -0,0 +1,39 @@
+package application
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/server-plugin/plugintest"
+	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/checks/domain"
+)
+
+// fakeChecksRepository records the process types checks are disabled for
+type fakeChecksRepository struct {
+	domain.ChecksRepository
+	disabled plugintest.Calls[[]string]
+}
+
+func (f *fakeChecksRepository) Disable(ctx context.Context, appName string, processTypes []string) error {
+	f.disabled.Record(processTypes)
+	return nil
+}
+
+func TestDisablePassesProcessTypes(t *testing.T) {
+	repo := &fakeChecksRepository{}
+
+	if err := NewChecksService(repo, plugintest.Logger()).Disable(context.Background(), "my-app", "web, worker"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := (plugintest.Calls[[]string]{{"web", "worker"}}); !reflect.DeepEqual(repo.disabled, want) {
+		t.Fatalf("expected checks:disable for %v, got %v", want, repo.disabled)
+	}
+}
+
+func TestDisableRejectsInvalidProcessTypeBeforeExecuting(t *testing.T) {
+	repo := &fakeChecksRepository{}
+
+	err := NewChecksService(repo, plugintest.Logger()).Disable(context.Background(), "my-app", "web;worker")
+	plugintest.ExpectRejectedBeforeExecuting(t, err, domain.ErrInvalidProcessType, "checks:disable", repo.disabled)
+}
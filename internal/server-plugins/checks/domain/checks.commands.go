@@ -0,0 +1,36 @@
+package domain
+
+// ChecksCommand represents allowed Dokku commands for the checks plugin
+type ChecksCommand string
+
+const (
+	CommandChecksReport  ChecksCommand = "checks:report"
+	CommandChecksEnable  ChecksCommand = "checks:enable"
+	CommandChecksDisable ChecksCommand = "checks:disable"
+	CommandChecksSkip    ChecksCommand = "checks:skip"
+)
+
+// IsValid checks if the command is a valid checks command
+func (c ChecksCommand) IsValid() bool {
+	switch c {
+	case CommandChecksReport, CommandChecksEnable, CommandChecksDisable, CommandChecksSkip:
+		return true
+	default:
+		return false
+	}
+}
+
+// String returns the string representation of the command
+func (c ChecksCommand) String() string {
+	return string(c)
+}
+
+// GetAllowedCommands returns all allowed checks commands
+func GetAllowedCommands() []ChecksCommand {
+	return []ChecksCommand{
+		CommandChecksReport,
+		CommandChecksEnable,
+		CommandChecksDisable,
+		CommandChecksSkip,
+	}
+}
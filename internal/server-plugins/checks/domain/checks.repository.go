@@ -0,0 +1,13 @@
+package domain
+
+import (
+	"context"
+)
+
+// ChecksRepository defines methods for reading and toggling the zero-downtime checks of applications
+type ChecksRepository interface {
+	GetStatus(ctx context.Context, appName string) (*ChecksStatus, error)
+	Enable(ctx context.Context, appName string, processTypes []string) error
+	Disable(ctx context.Context, appName string, processTypes []string) error
+	Skip(ctx context.Context, appName string, processTypes []string) error
+}
@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ErrInvalidProcessType is returned for a process type Dokku would not recognize
+var ErrInvalidProcessType = errors.New("invalid process type")
+
+var processTypePattern = regexp.MustCompile(`^[a-z][a-z0-9_-]*$`)
+
+// ChecksStatus is the zero-downtime checks state of an application as reported by checks:report.
+// A process type listed as disabled is deployed without checks and with downtime; a skipped
+// one is deployed without checks but keeps the zero-downtime switch.
+type ChecksStatus struct {
+	App                 string   `json:"app"`
+	DisabledProcesses   []string `json:"disabled_processes"`
+	SkippedProcesses    []string `json:"skipped_processes"`
+	WaitToRetireSeconds int      `json:"wait_to_retire_seconds"`
+}
+
+// ParseProcessTypes splits a comma-separated list of process types. An empty list
+// targets every process type of the application.
+func ParseProcessTypes(value string) ([]string, error) {
+	processTypes := []string{}
+	for _, processType := range strings.Split(value, ",") {
+		processType = strings.TrimSpace(processType)
+		if processType == "" {
+			continue
+		}
+		if !processTypePattern.MatchString(processType) {
+			return nil, fmt.Errorf("%w: %q must match %s", ErrInvalidProcessType, processType, processTypePattern.String())
+		}
+		processTypes = append(processTypes, processType)
+	}
+	return processTypes, nil
+}
@@ -0,0 +1,23 @@
+package domain_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/checks/domain"
+)
+
+var _ = Describe("ParseProcessTypes", func() {
+	It("splits a comma-separated list", func() {
+		Expect(domain.ParseProcessTypes("web, worker")).To(Equal([]string{"web", "worker"}))
+	})
+
+	It("targets every process type when empty", func() {
+		Expect(domain.ParseProcessTypes("")).To(BeEmpty())
+	})
+
+	It("rejects names that are not process types", func() {
+		_, err := domain.ParseProcessTypes("web,--all")
+		Expect(err).To(MatchError(domain.ErrInvalidProcessType))
+	})
+})
@@ -0,0 +1,114 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	dokkuApi "github.com/dokku-mcp/dokku-mcp/internal/dokku-api"
+	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/checks/domain"
+)
+
+// DokkuChecksAdapter implements the checks repository using Dokku CLI
+type DokkuChecksAdapter struct {
+	client dokkuApi.DokkuClient
+	logger *slog.Logger
+}
+
+// NewDokkuChecksAdapter creates a new checks adapter
+func NewDokkuChecksAdapter(client dokkuApi.DokkuClient, logger *slog.Logger) domain.ChecksRepository {
+	return &DokkuChecksAdapter{
+		client: client,
+		logger: logger,
+	}
+}
+
+// executeCommand wraps the client's ExecuteCommand with checks-specific context and validation
+func (a *DokkuChecksAdapter) executeCommand(ctx context.Context, command domain.ChecksCommand, args []string) ([]byte, error) {
+	if !command.IsValid() {
+		return nil, fmt.Errorf("invalid checks command: %s", command)
+	}
+	return a.client.ExecuteCommand(ctx, command.String(), args)
+}
+
+// GetStatus retrieves the checks state of an application from checks:report
+func (a *DokkuChecksAdapter) GetStatus(ctx context.Context, appName string) (*domain.ChecksStatus, error) {
+	output, err := a.executeCommand(ctx, domain.CommandChecksReport, []string{appName})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the checks report of %s: %w", appName, err)
+	}
+	return parseChecksReport(appName, string(output)), nil
+}
+
+// Enable turns the checks of an application back on
+func (a *DokkuChecksAdapter) Enable(ctx context.Context, appName string, processTypes []string) error {
+	return a.toggle(ctx, domain.CommandChecksEnable, appName, processTypes)
+}
+
+// Disable turns the checks of an application off
+func (a *DokkuChecksAdapter) Disable(ctx context.Context, appName string, processTypes []string) error {
+	return a.toggle(ctx, domain.CommandChecksDisable, appName, processTypes)
+}
+
+// Skip skips the checks of an application while keeping zero-downtime deploys
+func (a *DokkuChecksAdapter) Skip(ctx context.Context, appName string, processTypes []string) error {
+	return a.toggle(ctx, domain.CommandChecksSkip, appName, processTypes)
+}
+
+// toggle runs checks:enable, checks:disable or checks:skip for the given process
+// types, passed as one comma-separated argument, or for all of them when empty
+func (a *DokkuChecksAdapter) toggle(ctx context.Context, command domain.ChecksCommand, appName string, processTypes []string) error {
+	args := []string{appName}
+	if len(processTypes) > 0 {
+		args = append(args, strings.Join(processTypes, ","))
+	}
+	if _, err := a.executeCommand(ctx, command, args); err != nil {
+		return fmt.Errorf("failed to run %s for %s: %w", command, appName, err)
+	}
+	a.client.InvalidateByApp(appName)
+	return nil
+}
+
+// parseChecksReport reads the checks:report section of an application:
+//
+//	=====> my-app checks information
+//	       Checks disabled list:          none
+//	       Checks skipped list:           worker
+//	       Checks computed wait to retire: 60
+func parseChecksReport(appName, output string) *domain.ChecksStatus {
+	output, _ = dokkuApi.StripWarningLines(output)
+
+	status := &domain.ChecksStatus{
+		App:               appName,
+		DisabledProcesses: []string{},
+		SkippedProcesses:  []string{},
+	}
+	for _, line := range strings.Split(output, "\n") {
+		key, value, ok := dokkuApi.ParseColonKeyValueLine(strings.TrimSpace(line))
+		if !ok {
+			continue
+		}
+		switch strings.ToLower(key) {
+		case "checks disabled list":
+			status.DisabledProcesses = parseProcessList(value)
+		case "checks skipped list":
+			status.SkippedProcesses = parseProcessList(value)
+		case "checks computed wait to retire":
+			status.WaitToRetireSeconds, _ = strconv.Atoi(value)
+		}
+	}
+	return status
+}
+
+// parseProcessList splits a checks:report process list; "none" means no process and "_all_" is kept as is
+func parseProcessList(value string) []string {
+	processes := []string{}
+	for _, process := range strings.Split(value, ",") {
+		if process = strings.TrimSpace(process); process != "" && process != "none" {
+			processes = append(processes, process)
+		}
+	}
+	return processes
+}
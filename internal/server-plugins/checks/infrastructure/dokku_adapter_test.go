@@ -0,0 +1,28 @@
+package infrastructure
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/checks/domain"
+)
+
+func TestParseChecksReport(t *testing.T) {
+	status := parseChecksReport("my-app", `=====> my-app checks information
+       Checks disabled list:          none
+       Checks skipped list:           worker,clock
+       Checks computed wait to retire: 60
+       Checks global wait to retire:  60
+       Checks wait to retire:
+`)
+
+	want := &domain.ChecksStatus{
+		App:                 "my-app",
+		DisabledProcesses:   []string{},
+		SkippedProcesses:    []string{"worker", "clock"},
+		WaitToRetireSeconds: 60,
+	}
+	if !reflect.DeepEqual(status, want) {
+		t.Fatalf("expected %+v, got %+v", want, status)
+	}
+}
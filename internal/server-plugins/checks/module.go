@@ -0,0 +1,16 @@
+package checks
+
+import (
+	serverDomain "github.com/dokku-mcp/dokku-mcp/internal/server-plugin/domain"
+	"go.uber.org/fx"
+)
+
+var Module = fx.Module("checks",
+	fx.Provide(
+		fx.Annotate(
+			NewChecksServerPlugin,
+			fx.As(new(serverDomain.ServerPlugin)),
+			fx.ResultTags(`group:"server_plugins"`),
+		),
+	),
+)
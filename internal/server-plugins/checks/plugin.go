@@ -0,0 +1,154 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	dokkuApi "github.com/dokku-mcp/dokku-mcp/internal/dokku-api"
+	serverDomain "github.com/dokku-mcp/dokku-mcp/internal/server-plugin/domain"
+	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/checks/application"
+	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/checks/domain"
+	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/checks/infrastructure"
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// ChecksServerPlugin turns the zero-downtime deploy checks of applications on and off
+type ChecksServerPlugin struct {
+	checksService *application.ChecksService
+	logger        *slog.Logger
+}
+
+// NewChecksServerPlugin creates a new checks server plugin
+func NewChecksServerPlugin(client dokkuApi.DokkuClient, logger *slog.Logger) serverDomain.ServerPlugin {
+	adapter := infrastructure.NewDokkuChecksAdapter(client, logger)
+	checksService := application.NewChecksService(adapter, logger)
+	return &ChecksServerPlugin{
+		checksService: checksService,
+		logger:        logger,
+	}
+}
+
+func (p *ChecksServerPlugin) ID() string   { return "checks" }
+func (p *ChecksServerPlugin) Name() string { return "Dokku Checks" }
+func (p *ChecksServerPlugin) Description() string {
+	return "Enables, disables or skips the zero-downtime deploy checks of applications"
+}
+func (p *ChecksServerPlugin) Version() string         { return "0.1.0" }
+func (p *ChecksServerPlugin) DokkuPluginName() string { return "checks" }
+
+// ResourceProvider implementation
+func (p *ChecksServerPlugin) GetResources(ctx context.Context) ([]serverDomain.Resource, error) {
+	return []serverDomain.Resource{
+		{
+			URI:         "dokku://checks/{app_name}",
+			Name:        "get_checks_status",
+			Description: "Process types of an application whose deploy checks are disabled or skipped, and its wait to retire (checks:report <app>)",
+			MIMEType:    "application/json",
+			Handler:     p.handleChecksStatusResource,
+			Template:    true,
+		},
+	}, nil
+}
+
+// ToolProvider implementation
+func (p *ChecksServerPlugin) GetTools(ctx context.Context) ([]serverDomain.Tool, error) {
+	return []serverDomain.Tool{
+		{
+			Name:        "enable_checks",
+			Description: "Enable the zero-downtime deploy checks of an application",
+			Builder:     p.buildEnableChecksTool,
+			Handler:     p.handleEnableChecks,
+		},
+		{
+			Name:        "disable_checks",
+			Description: "Disable the zero-downtime deploy checks of an application",
+			Builder:     p.buildDisableChecksTool,
+			Handler:     p.handleDisableChecks,
+		},
+		{
+			Name:        "skip_checks",
+			Description: "Skip the deploy checks of an application while keeping zero-downtime deploys",
+			Builder:     p.buildSkipChecksTool,
+			Handler:     p.handleSkipChecks,
+		},
+	}, nil
+}
+
+func (p *ChecksServerPlugin) handleChecksStatusResource(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	appName, found := strings.CutPrefix(req.Params.URI, "dokku://checks/")
+	if !found || appName == "" || strings.Contains(appName, "/") {
+		return nil, fmt.Errorf("invalid checks status resource URI: %s", req.Params.URI)
+	}
+
+	status, err := p.checksService.GetStatus(ctx, appName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get checks status: %w", err)
+	}
+	jsonData, err := shared.MarshalOutput(status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize checks status: %w", err)
+	}
+	return []mcp.ResourceContents{
+		mcp.TextResourceContents{
+			URI:      req.Params.URI,
+			MIMEType: "application/json",
+			Text:     string(jsonData),
+		},
+	}, nil
+}
+
+// newChecksTool builds a checks tool taking an application and optional process types
+func newChecksTool(name, description string) mcp.Tool {
+	return mcp.NewTool(
+		name,
+		mcp.WithDescription(description),
+		mcp.WithString("app_name",
+			mcp.Required(),
+			mcp.Description("Name of the application"),
+		),
+		mcp.WithString("process_types",
+			mcp.Description("Comma-separated process types (e.g. 'web,worker'); all process types when omitted"),
+		),
+	)
+}
+
+func (p *ChecksServerPlugin) buildEnableChecksTool() mcp.Tool {
+	return newChecksTool("enable_checks", "Enable the zero-downtime deploy checks of an application (checks:enable): new containers must pass their checks before traffic switches to them")
+}
+
+func (p *ChecksServerPlugin) handleEnableChecks(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return p.handleToggle(ctx, req, p.checksService.Enable, "enable", "✅ Checks enabled for '%s'")
+}
+
+func (p *ChecksServerPlugin) buildDisableChecksTool() mcp.Tool {
+	return newChecksTool("disable_checks", "Disable the deploy checks of an application (checks:disable). Old containers are stopped before new ones start, so deploys cause downtime; prefer skip_checks to only skip the checks")
+}
+
+func (p *ChecksServerPlugin) handleDisableChecks(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return p.handleToggle(ctx, req, p.checksService.Disable, "disable",
+		"✅ Checks disabled for '%[1]s'\n⚠️ Deploys of '%[1]s' lose their zero-downtime guarantee: old containers are stopped before the new ones start and a broken release is no longer caught. Use skip_checks to only skip the checks, and enable_checks to restore them")
+}
+
+func (p *ChecksServerPlugin) buildSkipChecksTool() mcp.Tool {
+	return newChecksTool("skip_checks", "Skip the deploy checks of an application (checks:skip): traffic switches to new containers without checking them, but old containers keep running until then")
+}
+
+func (p *ChecksServerPlugin) handleSkipChecks(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return p.handleToggle(ctx, req, p.checksService.Skip, "skip", "✅ Checks skipped for '%s'")
+}
+
+// handleToggle reads the process types parameter and runs a checks change
+func (p *ChecksServerPlugin) handleToggle(
+	ctx context.Context,
+	req mcp.CallToolRequest,
+	change func(ctx context.Context, appName, processTypes string) error,
+	action string,
+	successFormat string,
+) (*mcp.CallToolResult, error) {
+	return serverDomain.HandleAppChange(ctx, req, action+" checks", func(ctx context.Context, appName string) (string, error) {
+		return fmt.Sprintf(successFormat, appName), change(ctx, appName, req.GetString("process_types", ""))
+	}, domain.ErrInvalidProcessType)
+}
@@ -0,0 +1,70 @@
+package checks
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/server-plugin/plugintest"
+	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/checks/application"
+	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/checks/domain"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// fakeChecksRepository reports the checks of my-app and records the apps checks are disabled for
+type fakeChecksRepository struct {
+	domain.ChecksRepository
+	disabled plugintest.Calls[string]
+}
+
+func (f *fakeChecksRepository) GetStatus(ctx context.Context, appName string) (*domain.ChecksStatus, error) {
+	return &domain.ChecksStatus{App: appName, DisabledProcesses: []string{"worker"}, SkippedProcesses: []string{}}, nil
+}
+
+func (f *fakeChecksRepository) Disable(ctx context.Context, appName string, processTypes []string) error {
+	f.disabled.Record(appName)
+	return nil
+}
+
+func newTestPlugin(repo domain.ChecksRepository) *ChecksServerPlugin {
+	logger := plugintest.Logger()
+	return &ChecksServerPlugin{checksService: application.NewChecksService(repo, logger), logger: logger}
+}
+
+func TestDisableChecksWarnsAboutDowntime(t *testing.T) {
+	repo := &fakeChecksRepository{}
+
+	result, text := plugintest.CallTool(t, newTestPlugin(repo).handleDisableChecks, map[string]any{"app_name": "my-app"})
+	if result.IsError || len(repo.disabled) != 1 {
+		t.Fatalf("expected checks:disable to run, got %s", text)
+	}
+	for _, want := range []string{"⚠️", "lose their zero-downtime guarantee", "skip_checks"} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("expected the disable result to contain %q, got %s", want, text)
+		}
+	}
+
+	result, text = plugintest.CallTool(t, newTestPlugin(repo).handleDisableChecks, map[string]any{"app_name": "my-app", "process_types": "web;worker"})
+	if !result.IsError || !strings.Contains(text, "invalid process type") || len(repo.disabled) != 1 {
+		t.Fatalf("expected an invalid process type to be refused, got %s", text)
+	}
+}
+
+func TestChecksStatusResourceReadsOneApp(t *testing.T) {
+	plugin := newTestPlugin(&fakeChecksRepository{})
+
+	req := mcp.ReadResourceRequest{}
+	req.Params.URI = "dokku://checks/my-app"
+	contents, err := plugin.handleChecksStatusResource(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if text := contents[0].(mcp.TextResourceContents).Text; !strings.Contains(text, `"app": "my-app"`) || !strings.Contains(text, "worker") {
+		t.Fatalf("expected the checks of my-app, got %s", text)
+	}
+
+	req.Params.URI = "dokku://checks/"
+	if _, err := plugin.handleChecksStatusResource(context.Background(), req); err == nil {
+		t.Fatalf("expected a URI without an app to be refused")
+	}
+}
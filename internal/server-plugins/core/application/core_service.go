@@ -4,18 +4,37 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"regexp"
+	"sync"
+	"time"
 
 	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/core/domain"
 )
 
+// System log bounds for GetSystemLogs
+const (
+	defaultSystemLogLines = 100
+	maxSystemLogLines     = 1000
+)
+
+// gitHostPattern matches a plain hostname accepted by git:allow-host
+var gitHostPattern = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9-]*[A-Za-z0-9])?(\.[A-Za-z0-9]([A-Za-z0-9-]*[A-Za-z0-9])?)*$`)
+
 // CoreService provides application-level orchestration for core Dokku functionality
 type CoreService struct {
 	systemRepo   domain.SystemRepository
 	pluginRepo   domain.PluginRepository
 	sshKeyRepo   domain.SSHKeyRepository
+	gitRepo      domain.GitAccessRepository
 	registryRepo domain.RegistryRepository
 	configRepo   domain.ConfigurationRepository
 	logger       *slog.Logger
+
+	// System overview cache, kept for overviewTTL
+	overviewTTL time.Duration
+	overviewMu  sync.Mutex
+	overview    *domain.ServerInfo
+	overviewAt  time.Time
 }
 
 // NewCoreService creates a new core application service
@@ -23,6 +42,7 @@ func NewCoreService(
 	systemRepo domain.SystemRepository,
 	pluginRepo domain.PluginRepository,
 	sshKeyRepo domain.SSHKeyRepository,
+	gitRepo domain.GitAccessRepository,
 	registryRepo domain.RegistryRepository,
 	configRepo domain.ConfigurationRepository,
 	logger *slog.Logger,
@@ -31,6 +51,7 @@ func NewCoreService(
 		systemRepo:   systemRepo,
 		pluginRepo:   pluginRepo,
 		sshKeyRepo:   sshKeyRepo,
+		gitRepo:      gitRepo,
 		registryRepo: registryRepo,
 		configRepo:   configRepo,
 		logger:       logger,
@@ -48,6 +69,34 @@ func (s *CoreService) GetServerInfo(ctx context.Context) (*domain.ServerInfo, er
 	return s.systemRepo.GetServerInfo(ctx)
 }
 
+// SetOverviewTTL sets how long GetSystemOverview reuses a fetched overview; zero disables the cache
+func (s *CoreService) SetOverviewTTL(ttl time.Duration) {
+	s.overviewMu.Lock()
+	defer s.overviewMu.Unlock()
+	s.overviewTTL = ttl
+	s.overview = nil
+}
+
+// GetSystemOverview returns the complete server information, cached for the overview TTL.
+// Concurrent callers wait for a single fetch instead of each querying Dokku.
+func (s *CoreService) GetSystemOverview(ctx context.Context) (*domain.ServerInfo, error) {
+	s.overviewMu.Lock()
+	defer s.overviewMu.Unlock()
+
+	if s.overview != nil && time.Since(s.overviewAt) < s.overviewTTL {
+		return s.overview, nil
+	}
+
+	info, err := s.GetServerInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if s.overviewTTL > 0 {
+		s.overview, s.overviewAt = info, time.Now()
+	}
+	return info, nil
+}
+
 func (s *CoreService) GetResourceUsage(ctx context.Context) (*domain.ResourceUsage, error) {
 	s.logger.Debug("Getting resource usage")
 	return s.systemRepo.GetResourceUsage(ctx)
@@ -118,14 +167,24 @@ func (s *CoreService) TogglePlugin(ctx context.Context, name string, enable bool
 	return s.pluginRepo.DisablePlugin(ctx, name)
 }
 
-func (s *CoreService) UpdatePlugin(ctx context.Context, name string, version string) error {
+// UpdatePlugin updates an installed plugin to version, or to its latest version
+// when empty, and returns the plugin as plugin:list reports it afterwards
+func (s *CoreService) UpdatePlugin(ctx context.Context, name string, version string) (*domain.DokkuPlugin, error) {
 	s.logger.Info("Updating plugin", "plugin", name, "version", version)
 
 	if name == "" {
-		return fmt.Errorf("plugin name cannot be empty")
+		return nil, fmt.Errorf("plugin name cannot be empty")
+	}
+
+	// plugin:update would try to fetch a plugin that is not installed
+	if _, err := s.pluginRepo.GetPlugin(ctx, name); err != nil {
+		return nil, err
 	}
 
-	return s.pluginRepo.UpdatePlugin(ctx, name, version)
+	if err := s.pluginRepo.UpdatePlugin(ctx, name, version); err != nil {
+		return nil, err
+	}
+	return s.pluginRepo.GetPlugin(ctx, name)
 }
 
 // SSH Key Management Operations
@@ -163,6 +222,39 @@ func (s *CoreService) RemoveSSHKey(ctx context.Context, name string) error {
 	return s.sshKeyRepo.RemoveSSHKey(ctx, name)
 }
 
+// GetSystemLogs returns the last lines of the Dokku platform log, bounded to maxSystemLogLines
+func (s *CoreService) GetSystemLogs(ctx context.Context, lines int) ([]string, error) {
+	if lines <= 0 {
+		lines = defaultSystemLogLines
+	}
+	if lines > maxSystemLogLines {
+		lines = maxSystemLogLines
+	}
+
+	s.logger.Debug("Getting system logs", "lines", lines)
+	return s.systemRepo.GetSystemLogs(ctx, lines)
+}
+
+// Git Access Operations
+func (s *CoreService) GetDeployKey(ctx context.Context) (*domain.DeployKey, error) {
+	s.logger.Debug("Getting deploy public key")
+	return s.gitRepo.GetDeployKey(ctx)
+}
+
+func (s *CoreService) AllowGitHost(ctx context.Context, host string) error {
+	s.logger.Info("Allowing git host", "host", host)
+
+	if host == "" {
+		return fmt.Errorf("git host cannot be empty")
+	}
+
+	if !gitHostPattern.MatchString(host) {
+		return fmt.Errorf("invalid git host '%s', expected a hostname such as github.com", host)
+	}
+
+	return s.gitRepo.AllowHost(ctx, host)
+}
+
 // Registry Management Operations
 func (s *CoreService) ListRegistries(ctx context.Context) ([]domain.RegistryCredential, error) {
 	s.logger.Debug("Listing registries")
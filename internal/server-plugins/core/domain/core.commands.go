@@ -16,9 +16,14 @@ const (
 	CommandSchedulerReport CoreCommand = "scheduler:report"
 	CommandSchedulerSet    CoreCommand = "scheduler:set"
 
+	// Domains commands
+	CommandDomainsReport CoreCommand = "domains:report"
+
 	// Git commands
-	CommandGitReport CoreCommand = "git:report"
-	CommandGitSet    CoreCommand = "git:set"
+	CommandGitReport    CoreCommand = "git:report"
+	CommandGitSet       CoreCommand = "git:set"
+	CommandGitPublicKey CoreCommand = "git:public-key"
+	CommandGitAllowHost CoreCommand = "git:allow-host"
 
 	// Plugin management commands
 	CommandPluginList      CoreCommand = "plugin:list"
@@ -30,9 +35,11 @@ const (
 
 	// SSH key commands
 	CommandSSHKeysList   CoreCommand = "ssh-keys:list"
+	CommandSSHKeysAdd    CoreCommand = "ssh-keys:add"
 	CommandSSHKeysRemove CoreCommand = "ssh-keys:remove"
 
 	// Registry commands
+	CommandRegistryLogin  CoreCommand = "registry:login"
 	CommandRegistryLogout CoreCommand = "registry:logout"
 
 	// Logs commands
@@ -45,11 +52,12 @@ func (c CoreCommand) IsValid() bool {
 	case CommandVersion, CommandEvents,
 		CommandProxyReport, CommandProxySet,
 		CommandSchedulerReport, CommandSchedulerSet,
-		CommandGitReport, CommandGitSet,
+		CommandDomainsReport,
+		CommandGitReport, CommandGitSet, CommandGitPublicKey, CommandGitAllowHost,
 		CommandPluginList, CommandPluginInstall, CommandPluginUninstall,
 		CommandPluginEnable, CommandPluginDisable, CommandPluginUpdate,
-		CommandSSHKeysList, CommandSSHKeysRemove,
-		CommandRegistryLogout,
+		CommandSSHKeysList, CommandSSHKeysAdd, CommandSSHKeysRemove,
+		CommandRegistryLogin, CommandRegistryLogout,
 		CommandLogsSet:
 		return true
 	default:
@@ -71,8 +79,11 @@ func GetAllowedCoreCommands() []CoreCommand {
 		CommandProxySet,
 		CommandSchedulerReport,
 		CommandSchedulerSet,
+		CommandDomainsReport,
 		CommandGitReport,
 		CommandGitSet,
+		CommandGitPublicKey,
+		CommandGitAllowHost,
 		CommandPluginList,
 		CommandPluginInstall,
 		CommandPluginUninstall,
@@ -80,7 +91,9 @@ func GetAllowedCoreCommands() []CoreCommand {
 		CommandPluginDisable,
 		CommandPluginUpdate,
 		CommandSSHKeysList,
+		CommandSSHKeysAdd,
 		CommandSSHKeysRemove,
+		CommandRegistryLogin,
 		CommandRegistryLogout,
 		CommandLogsSet,
 	}
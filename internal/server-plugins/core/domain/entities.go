@@ -36,6 +36,13 @@ type SSHKey struct {
 	AddedAt     time.Time `json:"added_at"`
 }
 
+// DeployKey represents the public key Dokku uses to fetch remote git repositories
+type DeployKey struct {
+	PublicKey string `json:"public_key"`
+	KeyType   string `json:"key_type"`
+	Comment   string `json:"comment"`
+}
+
 // RegistryCredential represents Docker registry credentials
 type RegistryCredential struct {
 	Registry string    `json:"registry"`
@@ -63,6 +70,8 @@ type ServerInfo struct {
 	Registries    []RegistryCredential `json:"registries"`
 	Configuration GlobalConfiguration  `json:"configuration"`
 	ResourceUsage ResourceUsage        `json:"resource_usage"`
+	// Warnings lists the sub-reports that could not be fetched
+	Warnings []string `json:"warnings,omitempty"`
 }
 
 // ResourceUsage represents system resource usage
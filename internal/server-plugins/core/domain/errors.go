@@ -0,0 +1,6 @@
+package domain
+
+import "errors"
+
+// ErrPluginNotFound is returned when no installed Dokku plugin has the requested name
+var ErrPluginNotFound = errors.New("plugin not found")
@@ -9,6 +9,7 @@ type SystemRepository interface {
 	GetSystemStatus(ctx context.Context) (*SystemStatus, error)
 	GetServerInfo(ctx context.Context) (*ServerInfo, error)
 	GetResourceUsage(ctx context.Context) (*ResourceUsage, error)
+	GetSystemLogs(ctx context.Context, lines int) ([]string, error)
 }
 
 // PluginRepository defines methods for managing Dokku plugins
@@ -30,6 +31,12 @@ type SSHKeyRepository interface {
 	GetSSHKey(ctx context.Context, name string) (*SSHKey, error)
 }
 
+// GitAccessRepository defines methods for managing git access to remote repositories
+type GitAccessRepository interface {
+	GetDeployKey(ctx context.Context) (*DeployKey, error)
+	AllowHost(ctx context.Context, host string) error
+}
+
 // RegistryRepository defines methods for managing Docker registry credentials
 type RegistryRepository interface {
 	ListRegistries(ctx context.Context) ([]RegistryCredential, error)
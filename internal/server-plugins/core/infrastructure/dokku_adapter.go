@@ -2,9 +2,12 @@ package infrastructure
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	dokkuApi "github.com/dokku-mcp/dokku-mcp/internal/dokku-api"
@@ -72,41 +75,97 @@ func (a *DokkuCoreAdapter) GetSystemStatus(ctx context.Context) (*domain.SystemS
 	return status, nil
 }
 
+// maxServerInfoConcurrency bounds how many sub-reports of the server info are fetched at once
+const maxServerInfoConcurrency = 3
+
+// GetServerInfo fetches the server sub-reports concurrently. Only the system
+// status is required; the other failures are logged and listed as warnings.
 func (a *DokkuCoreAdapter) GetServerInfo(ctx context.Context) (*domain.ServerInfo, error) {
-	systemStatus, err := a.GetSystemStatus(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get system status: %w", err)
-	}
+	var (
+		systemStatus  *domain.SystemStatus
+		statusErr     error
+		domains       = []string{}
+		plugins       = []domain.DokkuPlugin{}
+		sshKeys       = []domain.SSHKey{}
+		registries    = []domain.RegistryCredential{}
+		config        = &domain.GlobalConfiguration{}
+		resourceUsage = &domain.ResourceUsage{LastUpdated: time.Now()}
 
-	plugins, err := a.ListPlugins(ctx)
-	if err != nil {
-		a.logger.Warn("Failed to get plugins", "error", err)
-		plugins = []domain.DokkuPlugin{}
-	}
+		mu       sync.Mutex
+		warnings []string
+	)
 
-	sshKeys, err := a.ListSSHKeys(ctx)
-	if err != nil {
-		a.logger.Warn("Failed to get SSH keys", "error", err)
-		sshKeys = []domain.SSHKey{}
+	warn := func(subject string, err error) {
+		a.logger.Warn("Failed to get "+subject, "error", err)
+		mu.Lock()
+		defer mu.Unlock()
+		warnings = append(warnings, fmt.Sprintf("%s unavailable: %v", subject, err))
 	}
 
-	registries, err := a.ListRegistries(ctx)
-	if err != nil {
-		a.logger.Warn("Failed to get registries", "error", err)
-		registries = []domain.RegistryCredential{}
+	fetches := []func(){
+		func() { systemStatus, statusErr = a.GetSystemStatus(ctx) },
+		func() {
+			if result, err := a.GetGlobalDomains(ctx); err != nil {
+				warn("global domains", err)
+			} else {
+				domains = result
+			}
+		},
+		func() {
+			if result, err := a.ListPlugins(ctx); err != nil {
+				warn("plugins", err)
+			} else {
+				plugins = result
+			}
+		},
+		func() {
+			if result, err := a.ListSSHKeys(ctx); err != nil {
+				warn("SSH keys", err)
+			} else {
+				sshKeys = result
+			}
+		},
+		func() {
+			if result, err := a.ListRegistries(ctx); err != nil {
+				warn("registries", err)
+			} else {
+				registries = result
+			}
+		},
+		func() {
+			if result, err := a.GetGlobalConfiguration(ctx); err != nil {
+				warn("global configuration", err)
+			} else {
+				config = result
+			}
+		},
+		func() {
+			if result, err := a.GetResourceUsage(ctx); err != nil {
+				warn("resource usage", err)
+			} else {
+				resourceUsage = result
+			}
+		},
 	}
 
-	config, err := a.GetGlobalConfiguration(ctx)
-	if err != nil {
-		a.logger.Warn("Failed to get global configuration", "error", err)
-		config = &domain.GlobalConfiguration{}
+	semaphore := make(chan struct{}, maxServerInfoConcurrency)
+	var wg sync.WaitGroup
+	for _, fetch := range fetches {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+			fetch()
+		}()
 	}
+	wg.Wait()
 
-	resourceUsage, err := a.GetResourceUsage(ctx)
-	if err != nil {
-		a.logger.Warn("Failed to get resource usage", "error", err)
-		resourceUsage = &domain.ResourceUsage{LastUpdated: time.Now()}
+	if statusErr != nil {
+		return nil, fmt.Errorf("failed to get system status: %w", statusErr)
 	}
+	systemStatus.GlobalDomains = domains
+	sort.Strings(warnings)
 
 	return &domain.ServerInfo{
 		SystemStatus:  *systemStatus,
@@ -115,9 +174,24 @@ func (a *DokkuCoreAdapter) GetServerInfo(ctx context.Context) (*domain.ServerInf
 		Registries:    registries,
 		Configuration: *config,
 		ResourceUsage: *resourceUsage,
+		Warnings:      warnings,
 	}, nil
 }
 
+// GetGlobalDomains lists the global vhosts applied to every app
+func (a *DokkuCoreAdapter) GetGlobalDomains(ctx context.Context) ([]string, error) {
+	output, err := a.executeCommand(ctx, domain.CommandDomainsReport, []string{"--global", "--domains-global-vhosts"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get global domains: %w", err)
+	}
+
+	value := strings.TrimSpace(string(output))
+	if value == "" || value == "none" {
+		return []string{}, nil
+	}
+	return strings.Fields(value), nil
+}
+
 func (a *DokkuCoreAdapter) GetResourceUsage(ctx context.Context) (*domain.ResourceUsage, error) {
 	// This would typically involve getting system metrics
 	// For now, returning basic placeholder data
@@ -131,6 +205,23 @@ func (a *DokkuCoreAdapter) GetResourceUsage(ctx context.Context) (*domain.Resour
 	}, nil
 }
 
+// GetSystemLogs returns the last lines of Dokku's platform event log
+func (a *DokkuCoreAdapter) GetSystemLogs(ctx context.Context, lines int) ([]string, error) {
+	output, err := a.executeCommand(ctx, domain.CommandEvents, []string{})
+	if err != nil {
+		if errors.Is(err, dokkuApi.ErrPermissionDenied) {
+			return nil, fmt.Errorf("reading the Dokku event log requires root on the Dokku host: %w", err)
+		}
+		return nil, fmt.Errorf("failed to read system logs: %w", err)
+	}
+
+	logLines := dokkuApi.ParseTrimmedLines(string(output), true)
+	if len(logLines) > lines {
+		logLines = logLines[len(logLines)-lines:]
+	}
+	return logLines, nil
+}
+
 // PluginRepository implementation
 func (a *DokkuCoreAdapter) ListPlugins(ctx context.Context) ([]domain.DokkuPlugin, error) {
 	output, err := a.executeCommand(ctx, domain.CommandPluginList, []string{})
@@ -153,7 +244,7 @@ func (a *DokkuCoreAdapter) GetPlugin(ctx context.Context, name string) (*domain.
 		}
 	}
 
-	return nil, fmt.Errorf("plugin %s not found", name)
+	return nil, fmt.Errorf("%w: %s", domain.ErrPluginNotFound, name)
 }
 
 func (a *DokkuCoreAdapter) InstallPlugin(ctx context.Context, source string, options map[string]string) error {
@@ -215,6 +306,7 @@ func (a *DokkuCoreAdapter) UpdatePlugin(ctx context.Context, name string, versio
 	if err != nil {
 		return fmt.Errorf("failed to update plugin %s: %w", name, err)
 	}
+	a.client.InvalidateByCommand(domain.CommandPluginList.String())
 	return nil
 }
 
@@ -228,11 +320,21 @@ func (a *DokkuCoreAdapter) ListSSHKeys(ctx context.Context) ([]domain.SSHKey, er
 	return a.parseSSHKeys(string(output)), nil
 }
 
+// AddSSHKey adds a public key with ssh-keys:add. The key is fed to Dokku through
+// stdin so it never shows up on the command line or in the command logs.
 func (a *DokkuCoreAdapter) AddSSHKey(ctx context.Context, name string, keyContent string) error {
-	// Note: Dokku SSH key addition typically requires piping input
-	// For now, we'll return an error suggesting the user add keys manually
-	// This could be enhanced by writing to a temporary file and using that file path
-	return fmt.Errorf("SSH key addition via MCP not yet supported - please add keys manually via 'dokku ssh-keys:add %s'", name)
+	if !domain.CommandSSHKeysAdd.IsValid() {
+		return fmt.Errorf("invalid core command: %s", domain.CommandSSHKeysAdd)
+	}
+
+	// ssh-keys:add reads a single key line from stdin
+	key := []byte(strings.TrimSpace(keyContent) + "\n")
+	if _, err := a.client.ExecuteCommandWithInput(ctx, domain.CommandSSHKeysAdd.String(), []string{name}, key); err != nil {
+		return fmt.Errorf("failed to add SSH key %s: %w", name, err)
+	}
+	// Cached ssh-keys:list output would miss the new key
+	a.client.InvalidateByCommand(domain.CommandSSHKeysList.String())
+	return nil
 }
 
 func (a *DokkuCoreAdapter) RemoveSSHKey(ctx context.Context, name string) error {
@@ -258,6 +360,45 @@ func (a *DokkuCoreAdapter) GetSSHKey(ctx context.Context, name string) (*domain.
 	return nil, fmt.Errorf("SSH key %s not found", name)
 }
 
+// GitAccessRepository implementation
+func (a *DokkuCoreAdapter) GetDeployKey(ctx context.Context) (*domain.DeployKey, error) {
+	output, err := a.executeCommand(ctx, domain.CommandGitPublicKey, []string{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deploy key: %w", err)
+	}
+
+	// Never pass through anything that looks like private key material
+	if strings.Contains(string(output), "PRIVATE KEY") {
+		return nil, fmt.Errorf("unexpected private key material in git:public-key output")
+	}
+
+	for _, line := range dokkuApi.ParseTrimmedLines(string(output), true) {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || !isPublicKeyType(fields[0]) {
+			continue
+		}
+
+		key := &domain.DeployKey{
+			PublicKey: strings.Join(fields, " "),
+			KeyType:   fields[0],
+		}
+		if len(fields) > 2 {
+			key.Comment = strings.Join(fields[2:], " ")
+		}
+		return key, nil
+	}
+
+	return nil, fmt.Errorf("no public deploy key found - generate one on the Dokku host first")
+}
+
+func (a *DokkuCoreAdapter) AllowHost(ctx context.Context, host string) error {
+	_, err := a.executeCommand(ctx, domain.CommandGitAllowHost, []string{host})
+	if err != nil {
+		return fmt.Errorf("failed to allow git host %s: %w", host, err)
+	}
+	return nil
+}
+
 // RegistryRepository implementation
 func (a *DokkuCoreAdapter) ListRegistries(ctx context.Context) ([]domain.RegistryCredential, error) {
 	// Dokku doesn't have a direct command to list registries
@@ -266,11 +407,18 @@ func (a *DokkuCoreAdapter) ListRegistries(ctx context.Context) ([]domain.Registr
 	return []domain.RegistryCredential{}, nil
 }
 
+// LoginRegistry logs Dokku into a registry with registry:login --password-stdin.
+// The password goes through stdin, so it is neither part of the command line nor logged.
 func (a *DokkuCoreAdapter) LoginRegistry(ctx context.Context, registry, username, password string) error {
-	// Note: Registry login typically requires password input
-	// For now, we'll return an error suggesting manual login
-	// This could be enhanced by using environment variables or credential files
-	return fmt.Errorf("registry login via MCP not yet supported - please login manually via 'dokku registry:login %s %s'", registry, username)
+	if !domain.CommandRegistryLogin.IsValid() {
+		return fmt.Errorf("invalid core command: %s", domain.CommandRegistryLogin)
+	}
+
+	args := []string{"--password-stdin", registry, username}
+	if _, err := a.client.ExecuteCommandWithInput(ctx, domain.CommandRegistryLogin.String(), args, []byte(password)); err != nil {
+		return fmt.Errorf("failed to login to registry %s: %w", registry, err)
+	}
+	return nil
 }
 
 func (a *DokkuCoreAdapter) LogoutRegistry(ctx context.Context, registry string) error {
@@ -405,3 +553,9 @@ func (a *DokkuCoreAdapter) parseSSHKeys(output string) []domain.SSHKey {
 
 	return keys
 }
+
+func isPublicKeyType(keyType string) bool {
+	return strings.HasPrefix(keyType, "ssh-") ||
+		strings.HasPrefix(keyType, "ecdsa-sha2-") ||
+		strings.HasPrefix(keyType, "sk-")
+}
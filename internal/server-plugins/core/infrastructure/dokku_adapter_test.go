@@ -0,0 +1,75 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"slices"
+	"testing"
+
+	dokkuApi "github.com/dokku-mcp/dokku-mcp/internal/dokku-api"
+)
+
+// stdinClient records the commands run with stdin instead of executing them
+type stdinClient struct {
+	dokkuApi.DokkuClient
+	command string
+	args    []string
+	stdin   []byte
+	err     error
+}
+
+func (c *stdinClient) ExecuteCommandWithInput(ctx context.Context, command string, args []string, stdin []byte) ([]byte, error) {
+	c.command, c.args, c.stdin = command, args, stdin
+	return nil, c.err
+}
+
+func (c *stdinClient) InvalidateByCommand(prefix string) {}
+
+func TestAddSSHKeyPipesKeyThroughStdin(t *testing.T) {
+	key := "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIGx5 alice@example.com"
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	t.Run("key is sent on stdin", func(t *testing.T) {
+		client := &stdinClient{}
+		adapter := NewDokkuCoreAdapter(client, logger)
+
+		if err := adapter.AddSSHKey(context.Background(), "alice", key+"\n\n"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if client.command != "ssh-keys:add" || len(client.args) != 1 || client.args[0] != "alice" {
+			t.Fatalf("unexpected command: %s %v", client.command, client.args)
+		}
+		if string(client.stdin) != key+"\n" {
+			t.Fatalf("expected the key on stdin, got %q", client.stdin)
+		}
+	})
+
+	t.Run("command failure is reported", func(t *testing.T) {
+		client := &stdinClient{err: errors.New("exit status 1")}
+		adapter := NewDokkuCoreAdapter(client, logger)
+
+		if err := adapter.AddSSHKey(context.Background(), "alice", key); err == nil {
+			t.Fatal("expected an error")
+		}
+	})
+}
+
+func TestLoginRegistryPipesPasswordThroughStdin(t *testing.T) {
+	client := &stdinClient{}
+	adapter := NewDokkuCoreAdapter(client, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	if err := adapter.LoginRegistry(context.Background(), "ghcr.io", "deployer", "s3cr3t;|$(whoami)"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if client.command != "registry:login" {
+		t.Fatalf("unexpected command: %s", client.command)
+	}
+	if want := []string{"--password-stdin", "ghcr.io", "deployer"}; !slices.Equal(client.args, want) {
+		t.Fatalf("expected args %v, got %v", want, client.args)
+	}
+	if string(client.stdin) != "s3cr3t;|$(whoami)" {
+		t.Fatalf("expected the password on stdin, got %q", client.stdin)
+	}
+}
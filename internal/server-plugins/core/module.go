@@ -3,7 +3,11 @@ package core
 import (
 	"log/slog"
 
+	dokkuApi "github.com/dokku-mcp/dokku-mcp/internal/dokku-api"
+	"github.com/dokku-mcp/dokku-mcp/internal/server"
 	serverDomain "github.com/dokku-mcp/dokku-mcp/internal/server-plugin/domain"
+	"github.com/dokku-mcp/dokku-mcp/internal/shared/audit"
+	"github.com/dokku-mcp/dokku-mcp/pkg/config"
 	"go.uber.org/fx"
 )
 
@@ -16,7 +20,9 @@ type PluginRegistry interface {
 var CoreModule = fx.Module("core",
 	fx.Provide(
 		fx.Annotate(
-			NewCoreServerPlugin,
+			func(client dokkuApi.DokkuClient, logger *slog.Logger, cfg *config.ServerConfig, reloader *server.ConfigReloader, readiness *server.ReadinessProbe, auditLog audit.EventLog) serverDomain.ServerPlugin {
+				return NewCoreServerPlugin(client, logger, cfg, reloader, readiness, auditLog)
+			},
 			fx.As(new(serverDomain.ServerPlugin)),
 			fx.ResultTags(`group:"server_plugins"`),
 		),
@@ -3,14 +3,20 @@ package core
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"strings"
+	"time"
 
 	dokkuApi "github.com/dokku-mcp/dokku-mcp/internal/dokku-api"
+	"github.com/dokku-mcp/dokku-mcp/internal/server"
 	serverDomain "github.com/dokku-mcp/dokku-mcp/internal/server-plugin/domain"
 	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/core/application"
+	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/core/domain"
 	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/core/infrastructure"
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
+	"github.com/dokku-mcp/dokku-mcp/internal/shared/audit"
 	"github.com/dokku-mcp/dokku-mcp/pkg/config"
 	"github.com/dokku-mcp/dokku-mcp/pkg/logger"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -18,13 +24,29 @@ import (
 
 // CoreServerPlugin provides core Dokku functionality and global configuration
 type CoreServerPlugin struct {
-	coreService *application.CoreService
-	logger      *slog.Logger
-	cfg         *config.ServerConfig
+	coreService  *application.CoreService
+	capabilities dokkuApi.CapabilityManager
+	breakGlass   dokkuApi.BreakGlassExecutor
+	logger       *slog.Logger
+	cfg          *config.ServerConfig
+	reloader     ConfigReloader
+	readiness    ReadinessChecker
+	// auditLog records break-glass commands; nil when auditing is disabled
+	auditLog audit.EventLog
+}
+
+// ConfigReloader re-reads the configuration file and applies the settings that can change live
+type ConfigReloader interface {
+	Reload() (*server.ConfigReloadResult, error)
+}
+
+// ReadinessChecker reports whether the server's subsystems are healthy
+type ReadinessChecker interface {
+	Ready() *server.ReadinessReport
 }
 
 // NewCoreServerPlugin creates a new core functionality server plugin
-func NewCoreServerPlugin(client dokkuApi.DokkuClient, logger *slog.Logger, cfg *config.ServerConfig) serverDomain.ServerPlugin {
+func NewCoreServerPlugin(client dokkuApi.DokkuClient, logger *slog.Logger, cfg *config.ServerConfig, reloader ConfigReloader, readiness ReadinessChecker, auditLog audit.EventLog) serverDomain.ServerPlugin {
 	// Create infrastructure adapter
 	adapter := infrastructure.NewDokkuCoreAdapter(client, logger)
 
@@ -33,15 +55,25 @@ func NewCoreServerPlugin(client dokkuApi.DokkuClient, logger *slog.Logger, cfg *
 		adapter, // SystemRepository
 		adapter, // PluginRepository
 		adapter, // SSHKeyRepository
+		adapter, // GitAccessRepository
 		adapter, // RegistryRepository
 		adapter, // ConfigurationRepository
 		logger,
 	)
 
+	if cfg != nil && cfg.CacheEnabled {
+		coreService.SetOverviewTTL(cfg.CacheTTL)
+	}
+
 	return &CoreServerPlugin{
-		coreService: coreService,
-		logger:      logger,
-		cfg:         cfg,
+		coreService:  coreService,
+		capabilities: client,
+		breakGlass:   client,
+		logger:       logger,
+		cfg:          cfg,
+		reloader:     reloader,
+		readiness:    readiness,
+		auditLog:     auditLog,
 	}
 }
 
@@ -80,6 +112,15 @@ func (p *CoreServerPlugin) GetResources(ctx context.Context) ([]serverDomain.Res
 			Handler:     p.handleServerInfoResource,
 		},
 
+		// System Overview Resource (server info cached for the cache TTL)
+		{
+			URI:         "dokku://system/overview",
+			Name:        "System Overview",
+			Description: "Dokku version, system status, global configuration, global domains and plugins in one document, cached for the cache TTL. Sub-reports that fail are listed under warnings",
+			MIMEType:    "application/json",
+			Handler:     p.handleSystemOverviewResource,
+		},
+
 		// Plugin List Resource
 		{
 			URI:         "dokku://core/plugins",
@@ -88,6 +129,15 @@ func (p *CoreServerPlugin) GetResources(ctx context.Context) ([]serverDomain.Res
 			MIMEType:    "application/json",
 			Handler:     p.handlePluginsResource,
 		},
+
+		// Effective server configuration, secrets redacted
+		{
+			URI:         "dokku://core/server/config",
+			Name:        "Server Configuration",
+			Description: "Configuration dokku-mcp is running with after merging defaults, config file and environment, with secrets redacted",
+			MIMEType:    "application/json",
+			Handler:     p.handleServerConfigResource,
+		},
 	}
 
 	p.logger.Debug("Core plugin: Generated resources", "count", len(resources))
@@ -101,7 +151,7 @@ func (p *CoreServerPlugin) handleServerInfoResource(ctx context.Context, req mcp
 		return nil, fmt.Errorf("failed to get server info: %w", err)
 	}
 
-	jsonData, err := json.MarshalIndent(info, "", "  ")
+	jsonData, err := shared.MarshalOutput(info)
 	if err != nil {
 		return nil, fmt.Errorf("failed to serialize server info: %w", err)
 	}
@@ -115,13 +165,33 @@ func (p *CoreServerPlugin) handleServerInfoResource(ctx context.Context, req mcp
 	}, nil
 }
 
+func (p *CoreServerPlugin) handleSystemOverviewResource(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	info, err := p.coreService.GetSystemOverview(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get system overview: %w", err)
+	}
+
+	jsonData, err := shared.MarshalOutput(info)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize system overview: %w", err)
+	}
+
+	return []mcp.ResourceContents{
+		mcp.TextResourceContents{
+			URI:      req.Params.URI,
+			MIMEType: "application/json",
+			Text:     string(jsonData),
+		},
+	}, nil
+}
+
 func (p *CoreServerPlugin) handlePluginsResource(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
 	plugins, err := p.coreService.ListPlugins(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list plugins: %w", err)
 	}
 
-	jsonData, err := json.MarshalIndent(plugins, "", "  ")
+	jsonData, err := shared.MarshalOutput(plugins)
 	if err != nil {
 		return nil, fmt.Errorf("failed to serialize plugins: %w", err)
 	}
@@ -135,11 +205,92 @@ func (p *CoreServerPlugin) handlePluginsResource(ctx context.Context, req mcp.Re
 	}, nil
 }
 
+func (p *CoreServerPlugin) handleServerConfigResource(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	jsonData, err := p.serverConfigJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	return []mcp.ResourceContents{
+		mcp.TextResourceContents{
+			URI:      req.Params.URI,
+			MIMEType: "application/json",
+			Text:     string(jsonData),
+		},
+	}, nil
+}
+
+// serverConfigJSON serializes the effective server configuration with its secrets redacted
+func (p *CoreServerPlugin) serverConfigJSON() ([]byte, error) {
+	if p.cfg == nil {
+		return nil, fmt.Errorf("server configuration is not available")
+	}
+
+	jsonData, err := shared.MarshalOutput(p.cfg.Redacted())
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize server configuration: %w", err)
+	}
+	return jsonData, nil
+}
+
 // ToolProvider implementation
 func (p *CoreServerPlugin) GetTools(ctx context.Context) ([]serverDomain.Tool, error) {
 	p.logger.Debug("Core plugin: Getting MCP tools")
 
-	tools := []serverDomain.Tool{}
+	tools := []serverDomain.Tool{
+		{
+			Name:        "get_app_deploy_key",
+			Description: "Get the public deploy key Dokku uses to fetch private git repositories",
+			Builder:     p.buildGetDeployKeyTool,
+			Handler:     p.handleGetDeployKeyTool,
+		},
+		{
+			Name:        "git_allow_host",
+			Description: "Trust a git host's SSH fingerprint for git:sync deployments",
+			Builder:     p.buildGitAllowHostTool,
+			Handler:     p.handleGitAllowHostTool,
+		},
+		{
+			Name:        "check_feature",
+			Description: "Check whether a Dokku plugin or feature is available before using it",
+			Builder:     p.buildCheckFeatureTool,
+			Handler:     p.handleCheckFeatureTool,
+		},
+		{
+			Name:        "get_system_logs",
+			Description: "Get recent Dokku platform logs (event log) for troubleshooting the host",
+			Builder:     p.buildGetSystemLogsTool,
+			Handler:     p.handleGetSystemLogsTool,
+		},
+		{
+			Name:        "update_dokku_plugin",
+			Description: "Update an installed Dokku plugin and report its new version",
+			Builder:     p.buildUpdateDokkuPluginTool,
+			Handler:     p.handleUpdateDokkuPluginTool,
+		},
+		{
+			Name:        "get_server_config",
+			Description: "Get the configuration dokku-mcp is running with, secrets redacted",
+			Builder:     p.buildGetServerConfigTool,
+			Handler:     p.handleGetServerConfigTool,
+		},
+	}
+	if p.reloader != nil {
+		tools = append(tools, serverDomain.Tool{
+			Name:        "reload_server_config",
+			Description: "Re-read the configuration file and apply the blacklist, log level and cache TTL without a restart",
+			Builder:     p.buildReloadServerConfigTool,
+			Handler:     p.handleReloadServerConfigTool,
+		})
+	}
+	if p.readiness != nil {
+		tools = append(tools, serverDomain.Tool{
+			Name:        "server_ready",
+			Description: "Check whether dokku-mcp is ready: SSH reachability, capability discovery and plugin synchronization",
+			Builder:     p.buildServerReadyTool,
+			Handler:     p.handleServerReadyTool,
+		})
+	}
 	if p.cfg != nil && p.cfg.ExposeServerLogs {
 		tools = append(tools, serverDomain.Tool{
 			Name:        "get_server_logs",
@@ -149,6 +300,15 @@ func (p *CoreServerPlugin) GetTools(ctx context.Context) ([]serverDomain.Tool, e
 		})
 	}
 
+	if p.cfg != nil && p.cfg.Security.BreakGlass {
+		tools = append(tools, serverDomain.Tool{
+			Name:        "run_break_glass_command",
+			Description: "Run a single blacklisted Dokku command with an audited reason (break-glass mode)",
+			Builder:     p.buildRunBreakGlassCommandTool,
+			Handler:     p.handleRunBreakGlassCommandTool,
+		})
+	}
+
 	p.logger.Debug("Core plugin: Generated tools", "count", len(tools))
 	return tools, nil
 }
@@ -156,6 +316,81 @@ func (p *CoreServerPlugin) GetTools(ctx context.Context) ([]serverDomain.Tool, e
 // Tool builders
 // no builders for system status or plugin list tools; they are resources only
 
+func (p *CoreServerPlugin) buildGetDeployKeyTool() mcp.Tool {
+	return mcp.NewTool(
+		"get_app_deploy_key",
+		mcp.WithDescription("Get the public deploy key Dokku uses for git:sync. The key is shared by all apps on the server; add it as a read-only deploy key on the git host to deploy private repositories over SSH"),
+	)
+}
+
+func (p *CoreServerPlugin) buildGitAllowHostTool() mcp.Tool {
+	return mcp.NewTool(
+		"git_allow_host",
+		mcp.WithDescription("Add a git host to Dokku's known hosts so SSH deployments from it are trusted"),
+		mcp.WithString("host",
+			mcp.Required(),
+			mcp.Description("Hostname of the git server (e.g. github.com)"),
+		),
+	)
+}
+
+func (p *CoreServerPlugin) buildUpdateDokkuPluginTool() mcp.Tool {
+	return mcp.NewTool(
+		"update_dokku_plugin",
+		mcp.WithDescription("Update an installed Dokku plugin (plugin:update) to a git committish, or to the latest version of its repository, then report the version plugin:list shows. Core plugins are updated with Dokku itself"),
+		mcp.WithString("plugin_name",
+			mcp.Required(),
+			mcp.Description("Name of the installed plugin (e.g. postgres)"),
+		),
+		mcp.WithString("version",
+			mcp.Description("Git tag, branch or commit to update to; the latest version when omitted"),
+		),
+	)
+}
+
+func (p *CoreServerPlugin) buildCheckFeatureTool() mcp.Tool {
+	return mcp.NewTool(
+		"check_feature",
+		mcp.WithDescription("Check whether a Dokku feature is available on the server, from the discovered plugins and Dokku version. Returns JSON with available, the providing plugin, whether it ships with Dokku and the minimum Dokku version for core features. Call it before a tool that needs an optional plugin (e.g. letsencrypt)"),
+		mcp.WithString("feature",
+			mcp.Required(),
+			mcp.Description("Feature name (e.g. letsencrypt, maintenance, cron, network, storage)"),
+			mcp.Enum(dokkuApi.KnownFeatures()...),
+		),
+	)
+}
+
+func (p *CoreServerPlugin) buildGetSystemLogsTool() mcp.Tool {
+	return mcp.NewTool(
+		"get_system_logs",
+		mcp.WithDescription("Get the last lines of Dokku's platform event log (read-only). Useful to troubleshoot Dokku itself rather than an app; use get_runtime_logs for app logs"),
+		mcp.WithNumber("lines",
+			mcp.Description("Number of lines to return (default 100, max 1000)"),
+		),
+	)
+}
+
+func (p *CoreServerPlugin) buildGetServerConfigTool() mcp.Tool {
+	return mcp.NewTool(
+		"get_server_config",
+		mcp.WithDescription("Get the configuration dokku-mcp is running with, as resolved from defaults, the config file and DOKKU_MCP_* environment variables. Secrets (JWT secret, SSH key path, sensitive keys) are redacted. Useful to check which value of a setting actually won"),
+	)
+}
+
+func (p *CoreServerPlugin) buildReloadServerConfigTool() mcp.Tool {
+	return mcp.NewTool(
+		"reload_server_config",
+		mcp.WithDescription("Re-read the dokku-mcp configuration file and environment, as on SIGHUP. The command blacklist, log level and cache TTL take effect immediately; other changed settings (e.g. transport or SSH host) are reported as ignored and need a restart"),
+	)
+}
+
+func (p *CoreServerPlugin) buildServerReadyTool() mcp.Tool {
+	return mcp.NewTool(
+		"server_ready",
+		mcp.WithDescription("Report whether dokku-mcp is ready to serve requests, with the health of each subsystem: whether the last command reached the Dokku host over SSH, whether the Dokku capabilities were discovered and whether the background plugin synchronization is running. The /healthz endpoint of the SSE transport only reports the status of each subsystem, without these details"),
+	)
+}
+
 func (p *CoreServerPlugin) buildGetServerLogsTool() mcp.Tool {
 	return mcp.NewTool(
 		"get_server_logs",
@@ -172,9 +407,222 @@ func (p *CoreServerPlugin) buildGetServerLogsTool() mcp.Tool {
 	)
 }
 
+func (p *CoreServerPlugin) buildRunBreakGlassCommandTool() mcp.Tool {
+	return mcp.NewTool(
+		"run_break_glass_command",
+		mcp.WithDescription("Run ONE Dokku command that the security blacklist blocks (e.g. apps:destroy), under operator supervision. Only blacklisted commands are accepted; the blacklist keeps applying to every other call. The command, its arguments (secrets redacted) and the reason are written to the server log, and to the audit log when auditing is enabled. Only use it when an operator explicitly asked for this command"),
+		mcp.WithString("command",
+			mcp.Required(),
+			mcp.Description("Blacklisted Dokku command to run (e.g. apps:destroy)"),
+		),
+		mcp.WithArray("args",
+			mcp.WithStringItems(),
+			mcp.Description("Command arguments (e.g. [\"my-app\", \"--force\"])"),
+		),
+		mcp.WithString("reason",
+			mcp.Required(),
+			mcp.Description("Why the command must run despite the blacklist, and who approved it; recorded with the command"),
+		),
+	)
+}
+
 // Tool handlers
 // no handlers for system status or plugin list tools; they are resources only
 
+// toolError reports a failed tool call as "Failed to <action>: <error>", or with
+// the explanation of a Dokku failure whose cause the client recognized
+func toolError(action string, err error) *mcp.CallToolResult {
+	if message, ok := server.DokkuFailureMessage(err); ok {
+		return server.Error(message)
+	}
+	return server.Error(fmt.Sprintf("Failed to %s: %v", action, err))
+}
+
+func (p *CoreServerPlugin) handleGetDeployKeyTool(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	key, err := p.coreService.GetDeployKey(ctx)
+	if err != nil {
+		return toolError("get deploy key", err), nil
+	}
+
+	return server.OK("Public deploy key of the Dokku host; add it to the git provider for private repositories", key), nil
+}
+
+func (p *CoreServerPlugin) handleGitAllowHostTool(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	host, err := req.RequireString("host")
+	if err != nil {
+		return server.Error("Git host is required"), nil
+	}
+
+	if err := p.coreService.AllowGitHost(ctx, host); err != nil {
+		return toolError("allow git host", err), nil
+	}
+
+	return server.OK(fmt.Sprintf("Git host '%s' added to known hosts", host), map[string]string{"host": host}), nil
+}
+
+func (p *CoreServerPlugin) handleUpdateDokkuPluginTool(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	name, err := req.RequireString("plugin_name")
+	if err != nil {
+		return server.Error("Plugin name is required"), nil
+	}
+	version := req.GetString("version", "")
+
+	plugin, err := p.coreService.UpdatePlugin(ctx, name, version)
+	if err != nil {
+		if errors.Is(err, domain.ErrPluginNotFound) {
+			return server.Error(fmt.Sprintf("Plugin '%s' is not installed; install it first", name)), nil
+		}
+		return toolError("update plugin", err), nil
+	}
+
+	return server.OK(fmt.Sprintf("Plugin '%s' updated, now at version %s (%s)", plugin.Name, plugin.Version, plugin.Status), plugin), nil
+}
+
+func (p *CoreServerPlugin) handleCheckFeatureTool(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	feature, err := req.RequireString("feature")
+	if err != nil {
+		return server.Error("Feature name is required"), nil
+	}
+
+	availability, err := p.capabilities.GetCapabilities().CheckFeature(feature)
+	if err != nil {
+		return server.Error(err.Error()), nil
+	}
+
+	message := fmt.Sprintf("Feature '%s' is available", feature)
+	if !availability.Available {
+		message = fmt.Sprintf("Feature '%s' is not available", feature)
+	}
+	return server.OK(message, availability), nil
+}
+
+func (p *CoreServerPlugin) handleGetSystemLogsTool(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	lines, err := p.coreService.GetSystemLogs(ctx, req.GetInt("lines", 0))
+	if err != nil {
+		if errors.Is(err, dokkuApi.ErrPermissionDenied) {
+			return server.Error("Permission denied reading Dokku system logs: the Dokku user cannot read the host log. Run `dokku events` as root on the host, or grant read access to /var/log/dokku"), nil
+		}
+		return toolError("get system logs", err), nil
+	}
+
+	if len(lines) == 0 {
+		return server.OK("No system log entries (enable the event log with `dokku events:on`)", map[string][]string{"lines": {}}), nil
+	}
+
+	return server.OK(fmt.Sprintf("%d system log entries", len(lines)), map[string][]string{"lines": lines}), nil
+}
+
+func (p *CoreServerPlugin) handleRunBreakGlassCommandTool(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	command, err := req.RequireString("command")
+	if err != nil {
+		return server.Error("Command is required"), nil
+	}
+	reason, err := req.RequireString("reason")
+	if err != nil || strings.TrimSpace(reason) == "" {
+		return server.Error("A reason is required to run a break-glass command"), nil
+	}
+
+	args := req.GetStringSlice("args", nil)
+	started := time.Now()
+	output, err := p.breakGlass.ExecuteBreakGlassCommand(ctx, command, args, reason)
+	p.recordBreakGlassCommand(ctx, command, args, reason, time.Since(started), err)
+	if err != nil {
+		if out, ok := dokkuApi.CommandOutput(err); ok {
+			return server.NewResult(server.ToolResult{
+				Message: fmt.Sprintf("Break-glass command failed: %v", err),
+				Data:    map[string]string{"output": server.FormatRawOutput(out)},
+			}), nil
+		}
+		return server.Error(fmt.Sprintf("Break-glass command failed: %v", err)), nil
+	}
+
+	return server.OK(fmt.Sprintf("Break-glass command %s completed", command), map[string]string{"output": server.FormatRawOutput(string(output))}), nil
+}
+
+// recordBreakGlassCommand adds a break-glass attempt, refused or run, to the audit
+// log with its reason; argument secrets are redacted
+func (p *CoreServerPlugin) recordBreakGlassCommand(ctx context.Context, command string, args []string, reason string, duration time.Duration, runErr error) {
+	if p.auditLog == nil {
+		return
+	}
+
+	rawArgs, err := json.Marshal(server.SanitizeLogLines(args))
+	if err != nil {
+		p.logger.Warn("Failed to encode audited break-glass arguments", "command", command, "error", err)
+		return
+	}
+
+	event := audit.Event{
+		Timestamp: time.Now(),
+		Action:    command,
+		Parameters: map[string]audit.AuditParameter{
+			"args":   audit.NewJSONParameter(rawArgs),
+			"reason": audit.NewStringParameter(strings.TrimSpace(reason)),
+		},
+		Result:   "success",
+		Duration: duration,
+		Metadata: map[string]string{"break_glass": "true"},
+	}
+	if len(args) > 0 {
+		event.Resource = args[0]
+	}
+	if runErr != nil {
+		event.Result = "failure"
+		event.ErrorMessage = runErr.Error()
+	}
+	if tenant, ok := shared.GetTenantContext(ctx); ok {
+		event.TenantID = tenant.TenantID
+		event.UserID = tenant.UserID
+	}
+
+	if err := p.auditLog.Record(ctx, event); err != nil {
+		p.logger.Warn("Failed to audit break-glass command", "command", command, "error", err)
+	}
+}
+
+func (p *CoreServerPlugin) handleGetServerConfigTool(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if p.cfg == nil {
+		return server.Error("server configuration is not available"), nil
+	}
+	return server.OK("Effective server configuration, secrets masked", p.cfg.Redacted()), nil
+}
+
+func (p *CoreServerPlugin) handleReloadServerConfigTool(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	result, err := p.reloader.Reload()
+	if err != nil {
+		return server.Error(err.Error()), nil
+	}
+
+	var warnings []string
+	for _, key := range result.Ignored {
+		warnings = append(warnings, fmt.Sprintf("%s changed but needs a restart to take effect", key))
+	}
+	message := "Configuration reloaded, no reloadable setting changed"
+	if len(result.Applied) > 0 {
+		message = fmt.Sprintf("Configuration reloaded, applied %s", strings.Join(result.Applied, ", "))
+	}
+	return server.OK(message, result, warnings...), nil
+}
+
+func (p *CoreServerPlugin) handleServerReadyTool(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	report := p.readiness.Ready()
+	if report.Ready {
+		return server.OK("dokku-mcp is ready", report), nil
+	}
+
+	var unhealthy, warnings []string
+	for _, subsystem := range []struct {
+		name   string
+		status server.SubsystemStatus
+	}{{"ssh", report.SSH}, {"capabilities", report.Capabilities}, {"plugin_sync", report.PluginSync}} {
+		if !subsystem.status.Healthy {
+			unhealthy = append(unhealthy, subsystem.name)
+			warnings = append(warnings, fmt.Sprintf("%s: %s", subsystem.name, subsystem.status.Detail))
+		}
+	}
+	return server.OK(fmt.Sprintf("dokku-mcp is not ready, unhealthy: %s", strings.Join(unhealthy, ", ")), report, warnings...), nil
+}
+
 func (p *CoreServerPlugin) handleGetServerLogsTool(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	// Extract arguments
 	last := 200
@@ -212,10 +660,5 @@ func (p *CoreServerPlugin) handleGetServerLogsTool(ctx context.Context, req mcp.
 		out = append(out, line)
 	}
 
-	// Return as single text block
-	return &mcp.CallToolResult{
-		Content: []mcp.Content{
-			mcp.TextContent{Type: "text", Text: strings.Join(out, "\n")},
-		},
-	}, nil
+	return server.OK(fmt.Sprintf("%d server log lines", len(out)), map[string][]string{"lines": out}), nil
 }
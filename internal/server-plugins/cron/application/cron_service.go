@@ -0,0 +1,66 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/cron/domain"
+)
+
+// CronService provides application-level orchestration for scheduled tasks
+type CronService struct {
+	cronRepo domain.CronRepository
+	logger   *slog.Logger
+}
+
+// NewCronService creates a new cron application service
+func NewCronService(cronRepo domain.CronRepository, logger *slog.Logger) *CronService {
+	return &CronService{
+		cronRepo: cronRepo,
+		logger:   logger,
+	}
+}
+
+// ListTasks lists the scheduled tasks of an application
+func (s *CronService) ListTasks(ctx context.Context, appName string) ([]domain.CronTask, error) {
+	if appName == "" {
+		return nil, fmt.Errorf("app name cannot be empty")
+	}
+	return s.cronRepo.ListTasks(ctx, appName)
+}
+
+// ListAllTasks lists the scheduled tasks of every application, keyed by app name
+func (s *CronService) ListAllTasks(ctx context.Context) (map[string][]domain.CronTask, error) {
+	apps, err := s.cronRepo.ListApps(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := make(map[string][]domain.CronTask, len(apps))
+	for _, appName := range apps {
+		appTasks, err := s.cronRepo.ListTasks(ctx, appName)
+		if err != nil {
+			s.logger.Warn("Failed to list cron tasks", "app_name", appName, "error", err)
+			continue
+		}
+		tasks[appName] = appTasks
+	}
+	return tasks, nil
+}
+
+// RunTask runs a task of the application now, outside its schedule. The task must be
+// one cron:list reports, i.e. declared in the app.json of the deployed release.
+func (s *CronService) RunTask(ctx context.Context, appName, taskID string, detach bool) (string, error) {
+	tasks, err := s.ListTasks(ctx, appName)
+	if err != nil {
+		return "", err
+	}
+	if !slices.ContainsFunc(tasks, func(task domain.CronTask) bool { return task.ID == taskID }) {
+		return "", fmt.Errorf("%w: %q is not scheduled for %s, see cron:list", domain.ErrCronTaskNotFound, taskID, appName)
+	}
+
+	s.logger.Info("Running cron task", "app_name", appName, "task_id", taskID, "detach", detach)
+	return s.cronRepo.RunTask(ctx, appName, taskID, detach)
+}
@@ -0,0 +1,34 @@
+package domain
+
+// CronCommand represents allowed Dokku commands for the cron plugin
+type CronCommand string
+
+const (
+	CommandAppsList CronCommand = "apps:list"
+	CommandCronList CronCommand = "cron:list"
+	CommandCronRun  CronCommand = "cron:run"
+)
+
+// IsValid checks if the command is a valid cron command
+func (c CronCommand) IsValid() bool {
+	switch c {
+	case CommandAppsList, CommandCronList, CommandCronRun:
+		return true
+	default:
+		return false
+	}
+}
+
+// String returns the string representation of the command
+func (c CronCommand) String() string {
+	return string(c)
+}
+
+// GetAllowedCommands returns all allowed cron commands
+func GetAllowedCommands() []CronCommand {
+	return []CronCommand{
+		CommandAppsList,
+		CommandCronList,
+		CommandCronRun,
+	}
+}
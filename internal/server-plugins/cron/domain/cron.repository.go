@@ -0,0 +1,12 @@
+package domain
+
+import (
+	"context"
+)
+
+// CronRepository defines methods for reading and running scheduled tasks
+type CronRepository interface {
+	ListApps(ctx context.Context) ([]string, error)
+	ListTasks(ctx context.Context, appName string) ([]CronTask, error)
+	RunTask(ctx context.Context, appName, taskID string, detach bool) (string, error)
+}
@@ -0,0 +1,148 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrInvalidCronSchedule is returned when a cron expression cannot be scheduled by Dokku
+	ErrInvalidCronSchedule = errors.New("invalid cron schedule")
+	// ErrCronTaskNotFound is returned when cron:list does not report the requested task
+	ErrCronTaskNotFound = errors.New("cron task not found")
+)
+
+// CronTask is a scheduled command of an application, as reported by cron:list.
+// Dokku derives the ID from the app.json entry; it changes when the entry does.
+type CronTask struct {
+	ID       string `json:"id"`
+	App      string `json:"app"`
+	Schedule string `json:"schedule"`
+	Command  string `json:"command"`
+}
+
+// CronEntry is a task of the app.json "cron" section. Dokku only schedules the
+// tasks declared there: they are added or removed by deploying a new app.json.
+type CronEntry struct {
+	Command  string `json:"command"`
+	Schedule string `json:"schedule"`
+}
+
+// NewCronEntry validates a schedule and a command for the app.json "cron" section
+func NewCronEntry(schedule, command string) (CronEntry, error) {
+	if err := ValidateCronSchedule(schedule); err != nil {
+		return CronEntry{}, err
+	}
+	command = strings.TrimSpace(command)
+	if command == "" {
+		return CronEntry{}, fmt.Errorf("task command cannot be empty")
+	}
+	return CronEntry{Command: command, Schedule: strings.TrimSpace(schedule)}, nil
+}
+
+// cronField describes the accepted range of one cron expression field
+type cronField struct {
+	name  string
+	min   int
+	max   int
+	names map[string]int
+}
+
+var cronFields = []cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}},
+	{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}},
+}
+
+// cronMacros are the shorthand schedules accepted by Dokku's scheduler
+var cronMacros = map[string]bool{
+	"@yearly": true, "@annually": true, "@monthly": true, "@weekly": true,
+	"@daily": true, "@midnight": true, "@hourly": true,
+}
+
+// ValidateCronSchedule checks a five-field cron expression or a shorthand macro
+func ValidateCronSchedule(schedule string) error {
+	schedule = strings.TrimSpace(schedule)
+	if schedule == "" {
+		return fmt.Errorf("%w: schedule cannot be empty", ErrInvalidCronSchedule)
+	}
+
+	if strings.HasPrefix(schedule, "@") {
+		if !cronMacros[strings.ToLower(schedule)] {
+			return fmt.Errorf("%w: unsupported macro %q", ErrInvalidCronSchedule, schedule)
+		}
+		return nil
+	}
+
+	parts := strings.Fields(schedule)
+	if len(parts) != len(cronFields) {
+		return fmt.Errorf("%w: expected %d fields, got %d", ErrInvalidCronSchedule, len(cronFields), len(parts))
+	}
+
+	for i, part := range parts {
+		if err := cronFields[i].validate(part); err != nil {
+			return fmt.Errorf("%w: %s: %v", ErrInvalidCronSchedule, cronFields[i].name, err)
+		}
+	}
+	return nil
+}
+
+// validate checks a comma-separated list of values, ranges and steps
+func (f cronField) validate(expr string) error {
+	for _, item := range strings.Split(expr, ",") {
+		rangePart, step, hasStep := strings.Cut(item, "/")
+		if hasStep {
+			n, err := strconv.Atoi(step)
+			if err != nil || n <= 0 {
+				return fmt.Errorf("invalid step %q", step)
+			}
+		}
+
+		if rangePart == "*" {
+			continue
+		}
+
+		low, high, isRange := strings.Cut(rangePart, "-")
+		lowValue, err := f.value(low)
+		if err != nil {
+			return err
+		}
+		if !isRange {
+			continue
+		}
+
+		highValue, err := f.value(high)
+		if err != nil {
+			return err
+		}
+		if lowValue > highValue {
+			return fmt.Errorf("range %q is reversed", rangePart)
+		}
+	}
+	return nil
+}
+
+// value parses a single number or name within the field range
+func (f cronField) value(token string) (int, error) {
+	if n, ok := f.names[strings.ToLower(token)]; ok {
+		return n, nil
+	}
+
+	n, err := strconv.Atoi(token)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", token)
+	}
+	if n < f.min || n > f.max {
+		return 0, fmt.Errorf("value %d out of range %d-%d", n, f.min, f.max)
+	}
+	return n, nil
+}
@@ -0,0 +1,54 @@
+package domain_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/cron/domain"
+)
+
+var _ = Describe("ValidateCronSchedule", func() {
+	DescribeTable("accepts schedules Dokku can run",
+		func(schedule string) {
+			Expect(domain.ValidateCronSchedule(schedule)).To(Succeed())
+		},
+		Entry("every fifteen minutes", "*/15 * * * *"),
+		Entry("fixed time", "30 2 * * *"),
+		Entry("ranges and lists", "0 9-17 * * 1,3,5"),
+		Entry("month and weekday names", "0 0 1 jan mon-fri"),
+		Entry("stepped range", "0-30/10 * * * *"),
+		Entry("macro", "@daily"),
+	)
+
+	DescribeTable("rejects invalid schedules",
+		func(schedule string) {
+			err := domain.ValidateCronSchedule(schedule)
+			Expect(err).To(MatchError(domain.ErrInvalidCronSchedule))
+		},
+		Entry("empty", ""),
+		Entry("too few fields", "* * * *"),
+		Entry("minute out of range", "60 * * * *"),
+		Entry("reversed range", "0 17-9 * * *"),
+		Entry("zero step", "*/0 * * * *"),
+		Entry("unknown name", "0 0 * foo *"),
+		Entry("unsupported macro", "@reboot"),
+	)
+})
+
+var _ = Describe("NewCronEntry", func() {
+	It("returns the app.json entry with a trimmed command and schedule", func() {
+		entry, err := domain.NewCronEntry(" @daily ", " python manage.py clearsessions ")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entry).To(Equal(domain.CronEntry{Command: "python manage.py clearsessions", Schedule: "@daily"}))
+	})
+
+	It("rejects an invalid schedule", func() {
+		_, err := domain.NewCronEntry("61 * * * *", "echo hello")
+		Expect(err).To(MatchError(domain.ErrInvalidCronSchedule))
+	})
+
+	It("rejects an empty command", func() {
+		_, err := domain.NewCronEntry("@hourly", "  ")
+		Expect(err).To(MatchError(ContainSubstring("command cannot be empty")))
+	})
+})
@@ -0,0 +1,100 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	dokkuApi "github.com/dokku-mcp/dokku-mcp/internal/dokku-api"
+	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/cron/domain"
+)
+
+// cronRunTimeout bounds a task run attached to its output, like a long maintenance script
+const cronRunTimeout = 30 * time.Minute
+
+// DokkuCronAdapter implements the cron repository using Dokku CLI
+type DokkuCronAdapter struct {
+	client dokkuApi.DokkuClient
+	logger *slog.Logger
+}
+
+// NewDokkuCronAdapter creates a new cron adapter
+func NewDokkuCronAdapter(client dokkuApi.DokkuClient, logger *slog.Logger) domain.CronRepository {
+	return &DokkuCronAdapter{
+		client: client,
+		logger: logger,
+	}
+}
+
+// executeCommand wraps the client's ExecuteCommand with cron-specific context and validation
+func (a *DokkuCronAdapter) executeCommand(ctx context.Context, command domain.CronCommand, args []string) ([]byte, error) {
+	if !command.IsValid() {
+		return nil, fmt.Errorf("invalid cron command: %s", command)
+	}
+	return a.client.ExecuteCommand(ctx, command.String(), args)
+}
+
+// ListApps retrieves the names of all applications
+func (a *DokkuCronAdapter) ListApps(ctx context.Context) ([]string, error) {
+	output, err := a.executeCommand(ctx, domain.CommandAppsList, []string{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list applications: %w", err)
+	}
+	return dokkuApi.ParseLinesSkipHeaders(string(output)), nil
+}
+
+// ListTasks retrieves the scheduled tasks of an application from cron:list
+func (a *DokkuCronAdapter) ListTasks(ctx context.Context, appName string) ([]domain.CronTask, error) {
+	output, err := a.executeCommand(ctx, domain.CommandCronList, []string{appName, "--format", "json"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cron tasks for %s: %w", appName, err)
+	}
+	return parseCronList(appName, string(output))
+}
+
+// RunTask runs a task in a one-off container through cron:run. Detached, it returns
+// as soon as the container starts; otherwise it returns the task output.
+func (a *DokkuCronAdapter) RunTask(ctx context.Context, appName, taskID string, detach bool) (string, error) {
+	if !domain.CommandCronRun.IsValid() {
+		return "", fmt.Errorf("invalid cron command: %s", domain.CommandCronRun)
+	}
+
+	args := []string{appName, taskID}
+	if detach {
+		args = append([]string{"--detach"}, args...)
+	}
+	result, err := a.client.ExecuteStructured(ctx, dokkuApi.CommandSpec{
+		Command:      domain.CommandCronRun.String(),
+		Args:         args,
+		OutputFormat: dokkuApi.OutputFormatRaw,
+		Timeout:      cronRunTimeout,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to run cron task %s for %s: %w", taskID, appName, err)
+	}
+	return strings.TrimSpace(string(result.RawOutput)), nil
+}
+
+// parseCronList decodes the JSON output of cron:list
+func parseCronList(appName, output string) ([]domain.CronTask, error) {
+	output, _ = dokkuApi.StripWarningLines(output)
+	output = strings.TrimSpace(output)
+	if output == "" {
+		return []domain.CronTask{}, nil
+	}
+
+	var tasks []domain.CronTask
+	if err := json.Unmarshal([]byte(output), &tasks); err != nil {
+		return nil, fmt.Errorf("failed to parse cron:list output: %w", err)
+	}
+
+	for i := range tasks {
+		if tasks[i].App == "" {
+			tasks[i].App = appName
+		}
+	}
+	return tasks, nil
+}
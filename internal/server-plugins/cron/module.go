@@ -0,0 +1,16 @@
+package cron
+
+import (
+	serverDomain "github.com/dokku-mcp/dokku-mcp/internal/server-plugin/domain"
+	"go.uber.org/fx"
+)
+
+var Module = fx.Module("cron",
+	fx.Provide(
+		fx.Annotate(
+			NewCronServerPlugin,
+			fx.As(new(serverDomain.ServerPlugin)),
+			fx.ResultTags(`group:"server_plugins"`),
+		),
+	),
+)
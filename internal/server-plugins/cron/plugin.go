@@ -0,0 +1,208 @@
+package cron
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	dokkuApi "github.com/dokku-mcp/dokku-mcp/internal/dokku-api"
+	serverDomain "github.com/dokku-mcp/dokku-mcp/internal/server-plugin/domain"
+	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/cron/application"
+	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/cron/domain"
+	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/cron/infrastructure"
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// cronMinDokkuVersion is the first Dokku release with the cron plugin and cron:list
+const cronMinDokkuVersion = "0.23.0"
+
+// cronRunMinDokkuVersion is the first Dokku release with cron:run
+const cronRunMinDokkuVersion = "0.32.0"
+
+// CronServerPlugin provides scheduled task management
+type CronServerPlugin struct {
+	cronService *application.CronService
+	logger      *slog.Logger
+}
+
+// NewCronServerPlugin creates a new cron server plugin
+func NewCronServerPlugin(client dokkuApi.DokkuClient, logger *slog.Logger) serverDomain.ServerPlugin {
+	adapter := infrastructure.NewDokkuCronAdapter(client, logger)
+	cronService := application.NewCronService(adapter, logger)
+	return &CronServerPlugin{
+		cronService: cronService,
+		logger:      logger,
+	}
+}
+
+func (p *CronServerPlugin) ID() string   { return "cron" }
+func (p *CronServerPlugin) Name() string { return "Dokku Cron" }
+func (p *CronServerPlugin) Description() string {
+	return "Lists and runs the scheduled tasks of applications"
+}
+func (p *CronServerPlugin) Version() string         { return "0.1.0" }
+func (p *CronServerPlugin) DokkuPluginName() string { return "cron" }
+
+// ResourceProvider implementation
+func (p *CronServerPlugin) GetResources(ctx context.Context) ([]serverDomain.Resource, error) {
+	return []serverDomain.Resource{
+		{
+			URI:         "dokku://cron/tasks",
+			Name:        "Cron Tasks",
+			Description: "Scheduled tasks configured for each application",
+			MIMEType:    "application/json",
+			Handler:     p.handleCronTasksResource,
+		},
+	}, nil
+}
+
+// ToolProvider implementation
+func (p *CronServerPlugin) GetTools(ctx context.Context) ([]serverDomain.Tool, error) {
+	return []serverDomain.Tool{
+		{
+			Name:            "list_app_cron_tasks",
+			Description:     "List the scheduled tasks of an application",
+			Builder:         p.buildListAppCronTasksTool,
+			Handler:         p.handleListAppCronTasks,
+			MinDokkuVersion: cronMinDokkuVersion,
+		},
+		{
+			Name:            "run_app_cron_task",
+			Description:     "Run a scheduled task of an application now",
+			Builder:         p.buildRunAppCronTaskTool,
+			Handler:         p.handleRunAppCronTask,
+			MinDokkuVersion: cronRunMinDokkuVersion,
+		},
+		{
+			Name:        "prepare_app_cron_entry",
+			Description: "Validate a cron task and return its app.json entry",
+			Builder:     p.buildPrepareAppCronEntryTool,
+			Handler:     p.handlePrepareAppCronEntry,
+		},
+	}, nil
+}
+
+func (p *CronServerPlugin) handleCronTasksResource(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	tasks, err := p.cronService.ListAllTasks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cron tasks: %w", err)
+	}
+	jsonData, err := shared.MarshalOutput(tasks)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize cron tasks: %w", err)
+	}
+	return []mcp.ResourceContents{
+		mcp.TextResourceContents{
+			URI:      req.Params.URI,
+			MIMEType: "application/json",
+			Text:     string(jsonData),
+		},
+	}, nil
+}
+
+func (p *CronServerPlugin) buildListAppCronTasksTool() mcp.Tool {
+	return mcp.NewTool(
+		"list_app_cron_tasks",
+		mcp.WithDescription("List the scheduled tasks of an application (cron:list)"),
+		mcp.WithString("app_name",
+			mcp.Required(),
+			mcp.Description("Name of the application"),
+		),
+	)
+}
+
+func (p *CronServerPlugin) handleListAppCronTasks(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	appName, err := req.RequireString("app_name")
+	if err != nil {
+		return mcp.NewToolResultError("Application name is required"), nil
+	}
+
+	tasks, err := p.cronService.ListTasks(ctx, appName)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to list cron tasks: %v", err)), nil
+	}
+
+	jsonData, err := shared.MarshalOutput(tasks)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to encode cron tasks: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(jsonData)), nil
+}
+
+func (p *CronServerPlugin) buildRunAppCronTaskTool() mcp.Tool {
+	return mcp.NewTool(
+		"run_app_cron_task",
+		mcp.WithDescription("Run a scheduled task of an application now, in a one-off container (cron:run). The task ID comes from list_app_cron_tasks"),
+		mcp.WithString("app_name",
+			mcp.Required(),
+			mcp.Description("Name of the application"),
+		),
+		mcp.WithString("task_id",
+			mcp.Required(),
+			mcp.Description("ID of the task as reported by list_app_cron_tasks"),
+		),
+		mcp.WithBoolean("detach",
+			mcp.Description("Return once the container has started instead of waiting for the task output (default: false)"),
+		),
+	)
+}
+
+func (p *CronServerPlugin) handleRunAppCronTask(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	appName, err := req.RequireString("app_name")
+	if err != nil {
+		return mcp.NewToolResultError("Application name is required"), nil
+	}
+	taskID, err := req.RequireString("task_id")
+	if err != nil {
+		return mcp.NewToolResultError("Task ID is required"), nil
+	}
+	detach := req.GetBool("detach", false)
+
+	output, err := p.cronService.RunTask(ctx, appName, taskID, detach)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to run cron task: %v", err)), nil
+	}
+
+	if detach || output == "" {
+		return mcp.NewToolResultText(fmt.Sprintf("✅ Cron task '%s' started for '%s'", taskID, appName)), nil
+	}
+	return mcp.NewToolResultText(fmt.Sprintf("✅ Cron task '%s' ran for '%s':\n%s", taskID, appName, output)), nil
+}
+
+func (p *CronServerPlugin) buildPrepareAppCronEntryTool() mcp.Tool {
+	return mcp.NewTool(
+		"prepare_app_cron_entry",
+		mcp.WithDescription("Validate a cron task and return the entry to add to the \"cron\" section of app.json. Dokku schedules only the tasks of the deployed app.json: add or remove an entry there and redeploy the application"),
+		mcp.WithString("schedule",
+			mcp.Required(),
+			mcp.Description("Five-field cron expression (e.g. '*/15 * * * *') or a macro such as @daily"),
+		),
+		mcp.WithString("command",
+			mcp.Required(),
+			mcp.Description("Command to run in a one-off container of the application"),
+		),
+	)
+}
+
+func (p *CronServerPlugin) handlePrepareAppCronEntry(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	schedule, err := req.RequireString("schedule")
+	if err != nil {
+		return mcp.NewToolResultError("Schedule is required"), nil
+	}
+	command, err := req.RequireString("command")
+	if err != nil {
+		return mcp.NewToolResultError("Command is required"), nil
+	}
+
+	entry, err := domain.NewCronEntry(schedule, command)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid cron task: %v", err)), nil
+	}
+
+	jsonData, err := shared.MarshalOutput(entry)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to encode cron entry: %v", err)), nil
+	}
+	return mcp.NewToolResultText(fmt.Sprintf("Add this entry to the \"cron\" list of app.json, then redeploy the application:\n%s", jsonData)), nil
+}
@@ -11,21 +11,29 @@ import (
 // This allows other plugins to use deployment functionality without direct coupling
 type DeploymentServiceAdapter struct {
 	deploymentService deployment_domain.DeploymentService
+	// scope refuses deployments, rollbacks and history of apps the server may not manage
+	scope *shared.AppScope
 }
 
-// NewDeploymentServiceAdapter creates a new adapter instance
-func NewDeploymentServiceAdapter(deploymentService deployment_domain.DeploymentService) shared.DeploymentService {
+// NewDeploymentServiceAdapter creates a new adapter instance restricted to scope
+func NewDeploymentServiceAdapter(deploymentService deployment_domain.DeploymentService, scope *shared.AppScope) shared.DeploymentService {
 	return &DeploymentServiceAdapter{
 		deploymentService: deploymentService,
+		scope:             scope,
 	}
 }
 
 // Deploy implements the shared DeploymentService interface
 func (a *DeploymentServiceAdapter) Deploy(ctx context.Context, appName string, options shared.DeployOptions) (*shared.DeploymentResult, error) {
+	if err := a.scope.Check(appName); err != nil {
+		return nil, err
+	}
+
 	pluginOptions := deployment_domain.DeployOptions{
 		RepoURL:   options.RepoURL,
 		GitRef:    options.GitRef,
 		BuildPack: options.Buildpack,
+		BuildDir:  options.BuildDir,
 	}
 
 	// Call the plugin's deployment service
@@ -34,25 +42,23 @@ func (a *DeploymentServiceAdapter) Deploy(ctx context.Context, appName string, o
 		return nil, err
 	}
 
-	// Convert plugin result to shared result
-	return &shared.DeploymentResult{
-		ID:          deployment.ID(),
-		AppName:     deployment.AppName(),
-		GitRef:      deployment.GitRef(),
-		Status:      convertStatus(deployment.Status()),
-		CreatedAt:   deployment.CreatedAt(),
-		CompletedAt: deployment.CompletedAt(),
-		ErrorMsg:    deployment.ErrorMsg(),
-	}, nil
+	return toDeploymentResult(deployment), nil
 }
 
 // Rollback implements the shared DeploymentService interface
 func (a *DeploymentServiceAdapter) Rollback(ctx context.Context, appName string, version string) error {
+	if err := a.scope.Check(appName); err != nil {
+		return err
+	}
 	return a.deploymentService.Rollback(ctx, appName, version)
 }
 
 // GetHistory implements the shared DeploymentService interface
 func (a *DeploymentServiceAdapter) GetHistory(ctx context.Context, appName string) ([]shared.DeploymentSummary, error) {
+	if err := a.scope.Check(appName); err != nil {
+		return nil, err
+	}
+
 	deployments, err := a.deploymentService.GetHistory(ctx, appName)
 	if err != nil {
 		return nil, err
@@ -79,7 +85,65 @@ func (a *DeploymentServiceAdapter) GetStatus(ctx context.Context, deploymentID s
 	if err != nil {
 		return nil, err
 	}
+	if err := a.scope.Check(deployment.AppName()); err != nil {
+		return nil, err
+	}
 
+	return toDeploymentResult(deployment), nil
+}
+
+// GetActiveDeployment implements the shared DeploymentService interface
+func (a *DeploymentServiceAdapter) GetActiveDeployment(ctx context.Context, appName string) (*shared.DeploymentResult, error) {
+	if err := a.scope.Check(appName); err != nil {
+		return nil, err
+	}
+
+	deployment, err := a.deploymentService.GetActiveDeployment(ctx, appName)
+	if err != nil || deployment == nil {
+		return nil, err
+	}
+	return toDeploymentResult(deployment), nil
+}
+
+// WaitForCompletion implements the shared DeploymentService interface
+func (a *DeploymentServiceAdapter) WaitForCompletion(ctx context.Context, deploymentID string) (*shared.DeploymentResult, error) {
+	if _, err := a.GetStatus(ctx, deploymentID); err != nil {
+		return nil, err
+	}
+
+	deployment, err := a.deploymentService.WaitForCompletion(ctx, deploymentID)
+	if err != nil {
+		return nil, err
+	}
+	return toDeploymentResult(deployment), nil
+}
+
+// GetLastDeployStatus implements the shared DeploymentService interface
+func (a *DeploymentServiceAdapter) GetLastDeployStatus(ctx context.Context, appName string) (shared.DeploymentStatus, error) {
+	if err := a.scope.Check(appName); err != nil {
+		return "", err
+	}
+	status, err := a.deploymentService.GetLastDeployStatus(ctx, appName)
+	if err != nil || status == "" {
+		return "", err
+	}
+	return convertStatus(status), nil
+}
+
+// Cancel implements the shared DeploymentService interface
+func (a *DeploymentServiceAdapter) Cancel(ctx context.Context, deploymentID string) error {
+	deployment, err := a.deploymentService.GetByID(ctx, deploymentID)
+	if err != nil {
+		return err
+	}
+	if err := a.scope.Check(deployment.AppName()); err != nil {
+		return err
+	}
+	return a.deploymentService.Cancel(ctx, deploymentID)
+}
+
+// toDeploymentResult converts a plugin deployment to the shared result
+func toDeploymentResult(deployment *deployment_domain.Deployment) *shared.DeploymentResult {
 	return &shared.DeploymentResult{
 		ID:          deployment.ID(),
 		AppName:     deployment.AppName(),
@@ -88,12 +152,8 @@ func (a *DeploymentServiceAdapter) GetStatus(ctx context.Context, deploymentID s
 		CreatedAt:   deployment.CreatedAt(),
 		CompletedAt: deployment.CompletedAt(),
 		ErrorMsg:    deployment.ErrorMsg(),
-	}, nil
-}
-
-// Cancel implements the shared DeploymentService interface
-func (a *DeploymentServiceAdapter) Cancel(ctx context.Context, deploymentID string) error {
-	return a.deploymentService.Cancel(ctx, deploymentID)
+		Warnings:    deployment.Warnings(),
+	}
 }
 
 // convertStatus converts plugin-specific status to shared status
@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"context"
+	"sync"
+)
+
+// DeployQueue serializes the deployments of each application: a deployment
+// waits for the previous one of the same app to finish, while different apps
+// deploy concurrently
+type DeployQueue struct {
+	mu    sync.Mutex
+	slots map[string]chan struct{}
+}
+
+// NewDeployQueue creates an empty per-app deploy queue
+func NewDeployQueue() *DeployQueue {
+	return &DeployQueue{
+		slots: make(map[string]chan struct{}),
+	}
+}
+
+// Run waits for the app's turn, then runs fn. It gives up with the context
+// error when ctx is done before the turn comes.
+func (q *DeployQueue) Run(ctx context.Context, appName string, fn func() error) error {
+	release, err := q.Acquire(ctx, appName)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	return fn()
+}
+
+// Acquire waits for the app's turn and returns the function ending it, for
+// deployments that outlive the call starting them. It gives up with the
+// context error when ctx is done before the turn comes.
+func (q *DeployQueue) Acquire(ctx context.Context, appName string) (release func(), err error) {
+	slot := q.slot(appName)
+
+	select {
+	case slot <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	var once sync.Once
+	return func() { once.Do(func() { <-slot }) }, nil
+}
+
+// slot returns the single-entry channel guarding an app's deployments
+func (q *DeployQueue) slot(appName string) chan struct{} {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	slot, exists := q.slots[appName]
+	if !exists {
+		slot = make(chan struct{}, 1)
+		q.slots[appName] = slot
+	}
+	return slot
+}
@@ -0,0 +1,349 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
+)
+
+var (
+	ErrScheduledDeployNotFound   = errors.New("scheduled deploy not found")
+	ErrScheduledDeployNotPending = errors.New("scheduled deploy is no longer pending")
+	ErrScheduleInPast            = errors.New("scheduled time must be in the future")
+)
+
+// ScheduledDeployStatus is the state of a deploy scheduled for later
+type ScheduledDeployStatus string
+
+const (
+	ScheduledDeployPending   ScheduledDeployStatus = "pending"
+	ScheduledDeployRunning   ScheduledDeployStatus = "running"
+	ScheduledDeployCompleted ScheduledDeployStatus = "completed"
+	ScheduledDeployFailed    ScheduledDeployStatus = "failed"
+	ScheduledDeployCancelled ScheduledDeployStatus = "cancelled"
+)
+
+// finishedScheduleRetention is how long executed and cancelled schedules stay listed
+const finishedScheduleRetention = 24 * time.Hour
+
+// ScheduledDeploy is a deploy request to run once DueAt is reached
+type ScheduledDeploy struct {
+	ID           string                `json:"id"`
+	AppName      string                `json:"app_name"`
+	RepoURL      string                `json:"repo_url"`
+	GitRef       string                `json:"git_ref"`
+	DueAt        time.Time             `json:"due_at"`
+	CreatedAt    time.Time             `json:"created_at"`
+	Status       ScheduledDeployStatus `json:"status"`
+	FinishedAt   *time.Time            `json:"finished_at,omitempty"`
+	DeploymentID string                `json:"deployment_id,omitempty"`
+	Error        string                `json:"error,omitempty"`
+}
+
+// ScheduledDeployStore persists pending schedules so they survive a restart
+type ScheduledDeployStore interface {
+	Load() ([]*ScheduledDeploy, error)
+	Save(schedules []*ScheduledDeploy) error
+}
+
+// DeployScheduler runs scheduled deploys when they are due. Deploys go through
+// the deployment service, and therefore wait behind any running deploy of the same app.
+// A schedule stays running until its deployment reaches a final status.
+type DeployScheduler struct {
+	service   DeploymentService
+	store     ScheduledDeployStore
+	logger    *slog.Logger
+	now       func() time.Time
+	schedules map[string]*ScheduledDeploy
+	mu        sync.Mutex
+	sequence  int
+	runner    *shared.PeriodicRunner
+}
+
+// NewDeployScheduler creates a scheduler checking for due deploys every interval,
+// starting from the pending schedules found in store
+func NewDeployScheduler(service DeploymentService, store ScheduledDeployStore, logger *slog.Logger, interval time.Duration) (*DeployScheduler, error) {
+	scheduler := &DeployScheduler{
+		service:   service,
+		store:     store,
+		logger:    logger,
+		now:       time.Now,
+		schedules: make(map[string]*ScheduledDeploy),
+	}
+	scheduler.runner = shared.NewPeriodicRunner(interval, false, func(ctx context.Context) {
+		scheduler.RunDue(ctx)
+	})
+
+	if store != nil {
+		schedules, err := store.Load()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load scheduled deploys: %w", err)
+		}
+		for _, schedule := range schedules {
+			if schedule.Status == ScheduledDeployPending {
+				scheduler.schedules[schedule.ID] = schedule
+			}
+		}
+		if len(scheduler.schedules) > 0 {
+			logger.Info("Restored scheduled deploys", "count", len(scheduler.schedules))
+		}
+	}
+
+	return scheduler, nil
+}
+
+// SetClock replaces the clock used to decide which deploys are due (for tests)
+func (s *DeployScheduler) SetClock(now func() time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.now = now
+}
+
+// Schedule records a deploy of gitRef from repoURL to run at dueAt
+func (s *DeployScheduler) Schedule(appName, repoURL, gitRef string, dueAt time.Time) (*ScheduledDeploy, error) {
+	appName = strings.TrimSpace(appName)
+	repoURL = strings.TrimSpace(repoURL)
+	if appName == "" {
+		return nil, fmt.Errorf("application name cannot be empty")
+	}
+	if repoURL == "" {
+		return nil, fmt.Errorf("repository URL cannot be empty")
+	}
+	if gitRef == "" {
+		gitRef = "main"
+	}
+	if _, err := shared.NewGitRef(gitRef); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if !dueAt.After(now) {
+		return nil, ErrScheduleInPast
+	}
+
+	s.sequence++
+	schedule := &ScheduledDeploy{
+		ID:        fmt.Sprintf("sched_%d_%d", now.UnixNano(), s.sequence),
+		AppName:   appName,
+		RepoURL:   repoURL,
+		GitRef:    gitRef,
+		DueAt:     dueAt,
+		CreatedAt: now,
+		Status:    ScheduledDeployPending,
+	}
+	s.schedules[schedule.ID] = schedule
+
+	if err := s.persistLocked(); err != nil {
+		delete(s.schedules, schedule.ID)
+		return nil, err
+	}
+
+	s.logger.Info("Deploy scheduled",
+		"schedule_id", schedule.ID,
+		"app_name", appName,
+		"git_ref", gitRef,
+		"due_at", dueAt)
+
+	copied := *schedule
+	return &copied, nil
+}
+
+// List returns the scheduled deploys sorted by due time, for one app when appName is set
+func (s *DeployScheduler) List(appName string) []ScheduledDeploy {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	schedules := make([]ScheduledDeploy, 0, len(s.schedules))
+	for _, schedule := range s.schedules {
+		if appName == "" || schedule.AppName == appName {
+			schedules = append(schedules, *schedule)
+		}
+	}
+	sort.Slice(schedules, func(i, j int) bool {
+		if schedules[i].DueAt.Equal(schedules[j].DueAt) {
+			return schedules[i].ID < schedules[j].ID
+		}
+		return schedules[i].DueAt.Before(schedules[j].DueAt)
+	})
+	return schedules
+}
+
+// Cancel drops a pending scheduled deploy
+func (s *DeployScheduler) Cancel(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	schedule, exists := s.schedules[id]
+	if !exists {
+		return ErrScheduledDeployNotFound
+	}
+	if schedule.Status != ScheduledDeployPending {
+		return fmt.Errorf("%w: %s", ErrScheduledDeployNotPending, schedule.Status)
+	}
+
+	now := s.now()
+	schedule.Status = ScheduledDeployCancelled
+	schedule.FinishedAt = &now
+
+	if err := s.persistLocked(); err != nil {
+		schedule.Status = ScheduledDeployPending
+		schedule.FinishedAt = nil
+		return err
+	}
+
+	s.logger.Info("Scheduled deploy cancelled", "schedule_id", id, "app_name", schedule.AppName)
+	return nil
+}
+
+// RunDue starts every pending deploy whose time has come and waits for their
+// deployments to reach a final status.
+// It returns the number of deploys started.
+func (s *DeployScheduler) RunDue(ctx context.Context) int {
+	s.mu.Lock()
+	now := s.now()
+	s.pruneLocked(now)
+
+	var due []*ScheduledDeploy
+	for _, schedule := range s.schedules {
+		if schedule.Status == ScheduledDeployPending && !schedule.DueAt.After(now) {
+			schedule.Status = ScheduledDeployRunning
+			due = append(due, schedule)
+		}
+	}
+	if len(due) > 0 {
+		if err := s.persistLocked(); err != nil {
+			s.logger.Warn("Failed to persist scheduled deploys", "error", err)
+		}
+	}
+	s.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for _, schedule := range due {
+		wg.Add(1)
+		go func(schedule *ScheduledDeploy) {
+			defer wg.Done()
+			s.execute(ctx, schedule)
+		}(schedule)
+	}
+	wg.Wait()
+
+	return len(due)
+}
+
+// execute deploys a due schedule, waits for the deployment to finish and records the outcome
+func (s *DeployScheduler) execute(ctx context.Context, schedule *ScheduledDeploy) {
+	s.logger.Info("Running scheduled deploy",
+		"schedule_id", schedule.ID,
+		"app_name", schedule.AppName,
+		"git_ref", schedule.GitRef)
+
+	var deployment *Deployment
+	gitRef, err := shared.NewGitRef(schedule.GitRef)
+	if err == nil {
+		deployment, err = s.service.Deploy(ctx, schedule.AppName, DeployOptions{
+			RepoURL: schedule.RepoURL,
+			GitRef:  gitRef,
+		})
+	}
+
+	if deployment != nil {
+		s.mu.Lock()
+		schedule.DeploymentID = deployment.ID()
+		s.mu.Unlock()
+	}
+	if err == nil {
+		err = s.waitForDeployment(ctx, deployment.ID())
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	finishedAt := s.now()
+	schedule.FinishedAt = &finishedAt
+	if err != nil {
+		schedule.Status = ScheduledDeployFailed
+		schedule.Error = err.Error()
+		s.logger.Error("Scheduled deploy failed",
+			"schedule_id", schedule.ID,
+			"app_name", schedule.AppName,
+			"deployment_id", schedule.DeploymentID,
+			"error", err)
+	} else {
+		schedule.Status = ScheduledDeployCompleted
+		s.logger.Info("Scheduled deploy succeeded",
+			"schedule_id", schedule.ID,
+			"app_name", schedule.AppName,
+			"deployment_id", schedule.DeploymentID)
+	}
+
+	if err := s.persistLocked(); err != nil {
+		s.logger.Warn("Failed to persist scheduled deploys", "error", err)
+	}
+}
+
+// waitForDeployment waits for the final status of a started deployment and
+// returns an error unless it succeeded
+func (s *DeployScheduler) waitForDeployment(ctx context.Context, deploymentID string) error {
+	deployment, err := s.service.WaitForCompletion(ctx, deploymentID)
+	if err != nil {
+		return fmt.Errorf("deployment %s started but its outcome is unknown: %w", deploymentID, err)
+	}
+	if !deployment.IsSuccessful() {
+		if deployment.ErrorMsg() != "" {
+			return fmt.Errorf("deployment %s %s: %s", deploymentID, deployment.Status(), deployment.ErrorMsg())
+		}
+		return fmt.Errorf("deployment %s %s", deploymentID, deployment.Status())
+	}
+	return nil
+}
+
+// Start checks for due deploys every interval until Stop is called
+func (s *DeployScheduler) Start() {
+	s.runner.Start()
+}
+
+// Stop ends the background loop, cancelling the deploys it is running
+func (s *DeployScheduler) Stop(ctx context.Context) error {
+	return s.runner.Stop(ctx)
+}
+
+// pruneLocked forgets finished schedules older than the retention period
+func (s *DeployScheduler) pruneLocked(now time.Time) {
+	for id, schedule := range s.schedules {
+		if schedule.FinishedAt != nil && now.Sub(*schedule.FinishedAt) > finishedScheduleRetention {
+			delete(s.schedules, id)
+		}
+	}
+}
+
+// persistLocked saves the pending schedules; a schedule running when the server
+// stops is not restored, since the deploy may already have happened
+func (s *DeployScheduler) persistLocked() error {
+	if s.store == nil {
+		return nil
+	}
+
+	pending := make([]*ScheduledDeploy, 0, len(s.schedules))
+	for _, schedule := range s.schedules {
+		if schedule.Status == ScheduledDeployPending {
+			copied := *schedule
+			pending = append(pending, &copied)
+		}
+	}
+	sort.Slice(pending, func(i, j int) bool { return pending[i].ID < pending[j].ID })
+
+	if err := s.store.Save(pending); err != nil {
+		return fmt.Errorf("failed to persist scheduled deploys: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,223 @@
+package domain_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/deployment/domain"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// fakeDeploymentService records the deploys started by the scheduler; they
+// succeed unless failure is set
+type fakeDeploymentService struct {
+	domain.DeploymentService
+	mu       sync.Mutex
+	deployed []string
+	failure  string
+}
+
+func (f *fakeDeploymentService) Deploy(ctx context.Context, appName string, options domain.DeployOptions) (*domain.Deployment, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.deployed = append(f.deployed, appName+"@"+options.GitRef.Value())
+	return domain.NewDeploymentWithID("deploy_1", appName, options.GitRef.Value())
+}
+
+func (f *fakeDeploymentService) WaitForCompletion(ctx context.Context, deploymentID string) (*domain.Deployment, error) {
+	deployment, err := domain.NewDeploymentWithID(deploymentID, "my-app", "main")
+	if err != nil {
+		return nil, err
+	}
+	if f.failure != "" {
+		deployment.Fail(f.failure)
+	} else {
+		deployment.Complete()
+	}
+	return deployment, nil
+}
+
+// memoryScheduleStore keeps the last saved schedules
+type memoryScheduleStore struct {
+	saved []*domain.ScheduledDeploy
+}
+
+func (m *memoryScheduleStore) Load() ([]*domain.ScheduledDeploy, error) { return m.saved, nil }
+
+func (m *memoryScheduleStore) Save(schedules []*domain.ScheduledDeploy) error {
+	m.saved = schedules
+	return nil
+}
+
+var _ = Describe("DeployScheduler", func() {
+	var (
+		service   *fakeDeploymentService
+		store     *memoryScheduleStore
+		scheduler *domain.DeployScheduler
+		now       time.Time
+		logger    *slog.Logger
+	)
+
+	BeforeEach(func() {
+		service = &fakeDeploymentService{}
+		store = &memoryScheduleStore{}
+		logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+		now = time.Date(2026, 3, 1, 1, 0, 0, 0, time.UTC)
+
+		var err error
+		scheduler, err = domain.NewDeployScheduler(service, store, logger, time.Minute)
+		Expect(err).NotTo(HaveOccurred())
+		scheduler.SetClock(func() time.Time { return now })
+	})
+
+	It("should record and persist a pending deploy", func() {
+		schedule, err := scheduler.Schedule("my-app", "https://github.com/acme/app.git", "v1.2.0", now.Add(time.Hour))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(schedule.Status).To(Equal(domain.ScheduledDeployPending))
+
+		Expect(scheduler.List("my-app")).To(HaveLen(1))
+		Expect(scheduler.List("other-app")).To(BeEmpty())
+		Expect(store.saved).To(HaveLen(1))
+		Expect(store.saved[0].GitRef).To(Equal("v1.2.0"))
+	})
+
+	It("should refuse a time that is not in the future", func() {
+		_, err := scheduler.Schedule("my-app", "https://github.com/acme/app.git", "main", now)
+		Expect(err).To(MatchError(domain.ErrScheduleInPast))
+	})
+
+	It("should only deploy once the due time is reached", func() {
+		schedule, err := scheduler.Schedule("my-app", "https://github.com/acme/app.git", "main", now.Add(time.Hour))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(scheduler.RunDue(context.Background())).To(Equal(0))
+		Expect(service.deployed).To(BeEmpty())
+
+		now = now.Add(time.Hour)
+		Expect(scheduler.RunDue(context.Background())).To(Equal(1))
+		Expect(service.deployed).To(Equal([]string{"my-app@main"}))
+
+		schedules := scheduler.List("")
+		Expect(schedules).To(HaveLen(1))
+		Expect(schedules[0].ID).To(Equal(schedule.ID))
+		Expect(schedules[0].Status).To(Equal(domain.ScheduledDeployCompleted))
+		Expect(schedules[0].DeploymentID).To(Equal("deploy_1"))
+		Expect(store.saved).To(BeEmpty())
+
+		Expect(scheduler.RunDue(context.Background())).To(Equal(0))
+		Expect(service.deployed).To(HaveLen(1))
+	})
+
+	It("should record a deploy whose build failed as failed", func() {
+		service.failure = "remote: ! Build failed"
+		_, err := scheduler.Schedule("my-app", "https://github.com/acme/app.git", "main", now.Add(time.Hour))
+		Expect(err).NotTo(HaveOccurred())
+
+		now = now.Add(time.Hour)
+		Expect(scheduler.RunDue(context.Background())).To(Equal(1))
+
+		schedule := scheduler.List("my-app")[0]
+		Expect(schedule.Status).To(Equal(domain.ScheduledDeployFailed))
+		Expect(schedule.DeploymentID).To(Equal("deploy_1"))
+		Expect(schedule.Error).To(ContainSubstring("Build failed"))
+	})
+
+	It("should not run a cancelled deploy", func() {
+		schedule, err := scheduler.Schedule("my-app", "https://github.com/acme/app.git", "main", now.Add(time.Hour))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(scheduler.Cancel(schedule.ID)).To(Succeed())
+		Expect(store.saved).To(BeEmpty())
+		Expect(scheduler.Cancel(schedule.ID)).To(MatchError(domain.ErrScheduledDeployNotPending))
+		Expect(scheduler.Cancel("sched_unknown")).To(MatchError(domain.ErrScheduledDeployNotFound))
+
+		now = now.Add(2 * time.Hour)
+		Expect(scheduler.RunDue(context.Background())).To(Equal(0))
+		Expect(service.deployed).To(BeEmpty())
+		Expect(scheduler.List("my-app")[0].Status).To(Equal(domain.ScheduledDeployCancelled))
+	})
+
+	It("should restore pending deploys after a restart", func() {
+		_, err := scheduler.Schedule("my-app", "https://github.com/acme/app.git", "main", now.Add(time.Hour))
+		Expect(err).NotTo(HaveOccurred())
+
+		restarted, err := domain.NewDeployScheduler(service, store, logger, time.Minute)
+		Expect(err).NotTo(HaveOccurred())
+		restarted.SetClock(func() time.Time { return now.Add(2 * time.Hour) })
+
+		Expect(restarted.List("my-app")).To(HaveLen(1))
+		Expect(restarted.RunDue(context.Background())).To(Equal(1))
+		Expect(service.deployed).To(Equal([]string{"my-app@main"}))
+	})
+})
+
+var _ = Describe("DeployQueue", func() {
+	It("should run the deploys of one app one at a time", func() {
+		queue := domain.NewDeployQueue()
+
+		var running, maxRunning int32
+		var wg sync.WaitGroup
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_ = queue.Run(context.Background(), "my-app", func() error {
+					current := atomic.AddInt32(&running, 1)
+					for {
+						previous := atomic.LoadInt32(&maxRunning)
+						if current <= previous || atomic.CompareAndSwapInt32(&maxRunning, previous, current) {
+							break
+						}
+					}
+					time.Sleep(5 * time.Millisecond)
+					atomic.AddInt32(&running, -1)
+					return nil
+				})
+			}()
+		}
+		wg.Wait()
+
+		Expect(atomic.LoadInt32(&maxRunning)).To(Equal(int32(1)))
+	})
+
+	It("should keep the app reserved until an acquired turn is released", func() {
+		queue := domain.NewDeployQueue()
+		release, err := queue.Acquire(context.Background(), "my-app")
+		Expect(err).NotTo(HaveOccurred())
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		Expect(queue.Run(ctx, "my-app", func() error { return nil })).To(MatchError(context.DeadlineExceeded))
+
+		release()
+		release()
+		Expect(queue.Run(context.Background(), "my-app", func() error { return nil })).To(Succeed())
+	})
+
+	It("should stop waiting when the context is done", func() {
+		queue := domain.NewDeployQueue()
+		release := make(chan struct{})
+		started := make(chan struct{})
+		go func() {
+			_ = queue.Run(context.Background(), "my-app", func() error {
+				close(started)
+				<-release
+				return nil
+			})
+		}()
+		<-started
+		defer close(release)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		err := queue.Run(ctx, "my-app", func() error { return nil })
+		Expect(err).To(MatchError(context.DeadlineExceeded))
+
+		Expect(queue.Run(context.Background(), "other-app", func() error { return nil })).To(Succeed())
+	})
+})
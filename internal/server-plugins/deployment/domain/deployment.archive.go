@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// SourceArchiveURL retourne l'URL de l'archive tar.gz d'une référence git,
+// telle que publiée par GitHub, GitLab et Bitbucket. Un rollback reconstruit
+// l'application depuis cette archive (git:from-archive) sans resynchroniser le dépôt.
+// Le booléen est faux pour les dépôts hébergés ailleurs.
+func SourceArchiveURL(repoURL, gitRef string) (string, bool) {
+	host, repoPath, ok := splitRepositoryURL(repoURL)
+	if !ok || gitRef == "" {
+		return "", false
+	}
+
+	ref := url.PathEscape(gitRef)
+	switch host {
+	case "github.com":
+		return fmt.Sprintf("https://github.com/%s/archive/%s.tar.gz", repoPath, ref), true
+	case "gitlab.com":
+		return fmt.Sprintf("https://gitlab.com/%s/-/archive/%s/%s-%s.tar.gz", repoPath, ref, path.Base(repoPath), ref), true
+	case "bitbucket.org":
+		return fmt.Sprintf("https://bitbucket.org/%s/get/%s.tar.gz", repoPath, ref), true
+	default:
+		return "", false
+	}
+}
+
+// splitRepositoryURL extrait l'hôte et le chemin "propriétaire/dépôt" d'une URL
+// git HTTPS ou SSH (git@hôte:propriétaire/dépôt.git)
+func splitRepositoryURL(repoURL string) (host, repoPath string, ok bool) {
+	repoURL = strings.TrimSpace(repoURL)
+	if rest, found := strings.CutPrefix(repoURL, "git@"); found {
+		host, repoPath, ok = strings.Cut(rest, ":")
+	} else if parsed, err := url.Parse(repoURL); err == nil && parsed.Host != "" {
+		host, repoPath, ok = parsed.Hostname(), parsed.Path, true
+	}
+	if !ok {
+		return "", "", false
+	}
+
+	repoPath = strings.TrimSuffix(strings.Trim(repoPath, "/"), ".git")
+	if strings.Count(repoPath, "/") < 1 {
+		return "", "", false
+	}
+	return strings.ToLower(host), repoPath, true
+}
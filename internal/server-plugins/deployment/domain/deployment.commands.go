@@ -4,14 +4,21 @@ package domain
 type DeploymentCommand string
 
 const (
+	// Application commands
+	CommandAppsExists DeploymentCommand = "apps:exists"
+	CommandAppsReport DeploymentCommand = "apps:report"
+
 	// Buildpack commands
 	CommandBuildpacksSet DeploymentCommand = "buildpacks:set"
 
 	// Git commands
-	CommandGitSync DeploymentCommand = "git:sync"
+	CommandGitSync        DeploymentCommand = "git:sync"
+	CommandGitSet         DeploymentCommand = "git:set"
+	CommandGitFromArchive DeploymentCommand = "git:from-archive"
 
 	// Process commands
 	CommandPsRebuild DeploymentCommand = "ps:rebuild"
+	CommandPsReport  DeploymentCommand = "ps:report"
 
 	// Event commands
 	CommandEvents DeploymentCommand = "events"
@@ -20,8 +27,8 @@ const (
 // IsValid checks if the command is a valid deployment command
 func (c DeploymentCommand) IsValid() bool {
 	switch c {
-	case CommandBuildpacksSet,
-		CommandGitSync, CommandPsRebuild, CommandEvents:
+	case CommandAppsExists, CommandAppsReport, CommandBuildpacksSet,
+		CommandGitSync, CommandGitSet, CommandGitFromArchive, CommandPsRebuild, CommandPsReport, CommandEvents:
 		return true
 	default:
 		return false
@@ -36,9 +43,14 @@ func (c DeploymentCommand) String() string {
 // GetAllowedCommands returns all allowed deployment commands
 func GetAllowedDeploymentCommands() []DeploymentCommand {
 	return []DeploymentCommand{
+		CommandAppsExists,
+		CommandAppsReport,
 		CommandBuildpacksSet,
 		CommandGitSync,
+		CommandGitSet,
+		CommandGitFromArchive,
 		CommandPsRebuild,
+		CommandPsReport,
 		CommandEvents,
 	}
 }
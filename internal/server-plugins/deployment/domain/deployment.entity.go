@@ -7,15 +7,20 @@ import (
 
 // Deployment représente un déploiement d'application
 type Deployment struct {
-	id          string
-	appName     string
-	gitRef      string
+	id      string
+	appName string
+	gitRef  string
+	// repoURL est le dépôt git synchronisé, connu uniquement pour les déploiements lancés par ce serveur
+	repoURL     string
 	status      DeploymentStatus
 	createdAt   time.Time
 	startedAt   *time.Time
 	completedAt *time.Time
 	errorMsg    string
 	buildLogs   string
+	// buildLogsTruncated indique que le début des logs a été supprimé pour respecter la limite de taille
+	buildLogsTruncated bool
+	warnings           []string
 }
 
 // DeploymentStatus état d'un déploiement
@@ -80,6 +85,16 @@ func (d *Deployment) GitRef() string {
 	return d.gitRef
 }
 
+// RepoURL retourne le dépôt git synchronisé (vide si inconnu)
+func (d *Deployment) RepoURL() string {
+	return d.repoURL
+}
+
+// SetRepoURL enregistre le dépôt git synchronisé
+func (d *Deployment) SetRepoURL(repoURL string) {
+	d.repoURL = repoURL
+}
+
 // Status retourne le statut du déploiement
 func (d *Deployment) Status() DeploymentStatus {
 	return d.status
@@ -110,26 +125,43 @@ func (d *Deployment) BuildLogs() string {
 	return d.buildLogs
 }
 
+// Warnings retourne les avertissements relevés pendant le déploiement
+func (d *Deployment) Warnings() []string {
+	return d.warnings
+}
+
 // Start démarre le déploiement
 func (d *Deployment) Start() {
+	d.StartAt(time.Now())
+}
+
+// StartAt démarre le déploiement à la date donnée (déploiement relu d'un historique)
+func (d *Deployment) StartAt(at time.Time) {
 	d.status = DeploymentStatusRunning
-	now := time.Now()
-	d.startedAt = &now
+	d.startedAt = &at
 }
 
 // Complete marque le déploiement comme terminé avec succès
 func (d *Deployment) Complete() {
+	d.CompleteAt(time.Now())
+}
+
+// CompleteAt marque le déploiement comme terminé avec succès à la date donnée
+func (d *Deployment) CompleteAt(at time.Time) {
 	d.status = DeploymentStatusSucceeded
-	now := time.Now()
-	d.completedAt = &now
+	d.completedAt = &at
 }
 
 // Fail marque le déploiement comme échoué
 func (d *Deployment) Fail(errorMsg string) {
+	d.FailAt(errorMsg, time.Now())
+}
+
+// FailAt marque le déploiement comme échoué à la date donnée
+func (d *Deployment) FailAt(errorMsg string, at time.Time) {
 	d.status = DeploymentStatusFailed
 	d.errorMsg = errorMsg
-	now := time.Now()
-	d.completedAt = &now
+	d.completedAt = &at
 }
 
 // Rollback marque le déploiement comme annulé
@@ -144,6 +176,27 @@ func (d *Deployment) AddBuildLogs(logs string) {
 	d.buildLogs += logs
 }
 
+// AddBuildLogsCapped ajoute des logs de construction en ne gardant que les
+// maxBytes derniers octets (0 = sans limite) : la fin du build est celle qui
+// explique un échec
+func (d *Deployment) AddBuildLogsCapped(logs string, maxBytes int) {
+	d.buildLogs += logs
+	if maxBytes > 0 && len(d.buildLogs) > maxBytes {
+		d.buildLogs = d.buildLogs[len(d.buildLogs)-maxBytes:]
+		d.buildLogsTruncated = true
+	}
+}
+
+// BuildLogsTruncated indique si le début des logs de construction a été supprimé
+func (d *Deployment) BuildLogsTruncated() bool {
+	return d.buildLogsTruncated
+}
+
+// AddWarning ajoute un avertissement non bloquant au déploiement
+func (d *Deployment) AddWarning(warning string) {
+	d.warnings = append(d.warnings, warning)
+}
+
 // IsRunning vérifie si le déploiement est en cours
 func (d *Deployment) IsRunning() bool {
 	return d.status == DeploymentStatusRunning
@@ -186,6 +239,26 @@ func generateDeploymentID() string {
 	return fmt.Sprintf("deploy_%d", time.Now().UnixNano())
 }
 
+// NewDeploymentFromHistory recrée un déploiement lu dans le journal d'événements
+// Dokku, avec un identifiant stable et sa date d'origine. La référence git reste
+// vide quand le journal ne l'indique pas.
+func NewDeploymentFromHistory(id, appName, gitRef string, createdAt time.Time) (*Deployment, error) {
+	if appName == "" {
+		return nil, fmt.Errorf("le nom de l'application ne peut pas être vide")
+	}
+	if id == "" {
+		return nil, fmt.Errorf("deployment ID cannot be empty")
+	}
+
+	return &Deployment{
+		id:        id,
+		appName:   appName,
+		gitRef:    gitRef,
+		status:    DeploymentStatusPending,
+		createdAt: createdAt,
+	}, nil
+}
+
 // NewDeploymentWithID creates a deployment with a specific ID (for testing)
 func NewDeploymentWithID(id, appName, gitRef string) (*Deployment, error) {
 	if appName == "" {
@@ -1,6 +1,9 @@
 package domain
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
 	ErrDeploymentNotFound       = errors.New("deployment not found")
@@ -8,4 +11,28 @@ var (
 	ErrDeploymentAlreadyRunning = errors.New("deployment is already running")
 	ErrInvalidDeploymentStatus  = errors.New("invalid deployment status")
 	ErrDeploymentAlreadyExists  = errors.New("deployment already exists")
+	ErrInvalidBuildDir          = errors.New("invalid build directory")
+	ErrNoFailedDeployment       = errors.New("no failed deployment recorded")
+	// ErrTransientSyncFailure marque un échec de git:sync dû au réseau, qui peut réussir en réessayant
+	ErrTransientSyncFailure = errors.New("transient git sync failure")
+	// ErrApplicationNotFound est renvoyée quand l'application ciblée n'existe pas dans Dokku
+	ErrApplicationNotFound = errors.New("application not found")
+	// ErrRollbackSourceUnknown est renvoyée quand aucun dépôt git connu ne permet de reconstruire la version ciblée
+	ErrRollbackSourceUnknown = errors.New("rollback source repository unknown")
 )
+
+// RollbackTargetNotFoundError indique que la version demandée pour un rollback
+// ne figure pas (ou pas comme déploiement réussi) dans l'historique de l'application
+type RollbackTargetNotFoundError struct {
+	AppName string
+	Version string
+}
+
+func (e *RollbackTargetNotFoundError) Error() string {
+	return fmt.Sprintf("no successful deployment %q in the history of %s", e.Version, e.AppName)
+}
+
+// Is permet errors.Is(err, ErrDeploymentNotFound)
+func (e *RollbackTargetNotFoundError) Is(target error) bool {
+	return target == ErrDeploymentNotFound
+}
@@ -0,0 +1,41 @@
+package domain
+
+import "time"
+
+// DeploymentEventKind is the stage of a deploy reported by the Dokku event log
+type DeploymentEventKind string
+
+const (
+	DeploymentEventStarted   DeploymentEventKind = "started"
+	DeploymentEventSucceeded DeploymentEventKind = "succeeded"
+	DeploymentEventFailed    DeploymentEventKind = "failed"
+)
+
+// DeploymentEvent reports a deploy that started, succeeded or failed on the Dokku
+// host, whether it was run by this server or directly with git push or dokku
+type DeploymentEvent struct {
+	aggregateID string
+	gitRef      string
+	kind        DeploymentEventKind
+	logLine     string
+	occurredAt  time.Time
+}
+
+func NewDeploymentEvent(aggregateID, gitRef string, kind DeploymentEventKind, logLine string, occurredAt time.Time) *DeploymentEvent {
+	return &DeploymentEvent{
+		aggregateID: aggregateID,
+		gitRef:      gitRef,
+		kind:        kind,
+		logLine:     logLine,
+		occurredAt:  occurredAt,
+	}
+}
+
+func (e *DeploymentEvent) OccurredAt() time.Time     { return e.occurredAt }
+func (e *DeploymentEvent) EventType() string         { return "deployment." + string(e.kind) }
+func (e *DeploymentEvent) AggregateID() string       { return e.aggregateID }
+func (e *DeploymentEvent) GitRef() string            { return e.gitRef }
+func (e *DeploymentEvent) Kind() DeploymentEventKind { return e.kind }
+
+// LogLine is the Dokku event log line the event was read from
+func (e *DeploymentEvent) LogLine() string { return e.logLine }
@@ -2,9 +2,11 @@ package domain
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"sort"
+	"time"
 
 	"github.com/dokku-mcp/dokku-mcp/internal/shared"
 )
@@ -15,14 +17,23 @@ type DeploymentService interface {
 	Rollback(ctx context.Context, appName string, version string) error
 	GetHistory(ctx context.Context, appName string) ([]*Deployment, error)
 	GetByID(ctx context.Context, deploymentID string) (*Deployment, error)
+	WaitForCompletion(ctx context.Context, deploymentID string) (*Deployment, error)
+	GetActiveDeployment(ctx context.Context, appName string) (*Deployment, error)
+	GetLastDeployStatus(ctx context.Context, appName string) (DeploymentStatus, error)
 	Cancel(ctx context.Context, deploymentID string) error
 }
 
 // DeploymentInfrastructure simplified interface for infrastructure operations
 type DeploymentInfrastructure interface {
+	ApplicationExists(ctx context.Context, appName string) (bool, error)
 	SetBuildpack(ctx context.Context, appName string, buildpack string) error
+	SetBuildDir(ctx context.Context, appName string, buildDir string) error
 	PerformGitDeploy(ctx context.Context, deploymentID, appName, repoURL, gitRef string) error
+	PerformArchiveDeploy(ctx context.Context, deploymentID, appName, archiveURL string) error
+	DeploySourceRepository(ctx context.Context, appName string) (string, error)
 	ParseDeploymentHistory(ctx context.Context, appName string) ([]*Deployment, error)
+	GetLastDeployStatus(ctx context.Context, appName string) (DeploymentStatus, error)
+	DeploymentEvents(ctx context.Context) ([]*DeploymentEvent, error)
 }
 
 // DeployOptions simplified options for deployment
@@ -30,6 +41,8 @@ type DeployOptions struct {
 	RepoURL   string
 	GitRef    *shared.GitRef
 	BuildPack *shared.BuildpackName
+	// BuildDir est persisté par Dokku (git:set build-dir) et s'applique aux déploiements suivants
+	BuildDir string
 }
 
 // ApplicationDeploymentService implémentation du service de déploiement
@@ -38,6 +51,10 @@ type ApplicationDeploymentService struct {
 	infrastructure DeploymentInfrastructure
 	tracker        *DeploymentTracker
 	logger         *slog.Logger
+	// queue sérialise les déploiements et rollbacks d'une même application
+	queue *DeployQueue
+	// syncRetryDelay délai avant de réessayer un git:sync en échec transitoire (0 = pas de nouvel essai)
+	syncRetryDelay time.Duration
 }
 
 // NewApplicationDeploymentService crée une nouvelle instance du service
@@ -52,20 +69,86 @@ func NewApplicationDeploymentService(
 		infrastructure: infrastructure,
 		tracker:        tracker,
 		logger:         logger,
+		queue:          NewDeployQueue(),
 	}
 }
 
-// Deploy lance un déploiement d'application
+// SetSyncRetryDelay configure le délai de grâce avant de réessayer une fois un
+// git:sync en échec transitoire ; 0 désactive le nouvel essai
+func (s *ApplicationDeploymentService) SetSyncRetryDelay(delay time.Duration) {
+	s.syncRetryDelay = delay
+}
+
+// Deploy lance un déploiement d'application, après la fin du déploiement en
+// cours de la même application le cas échéant. Le build se poursuit après le
+// retour : l'application reste réservée jusqu'à ce que le déploiement suivi
+// atteigne son statut final, qui est alors enregistré dans l'historique avec sa
+// référence git et son dépôt (cibles du rollback).
 func (s *ApplicationDeploymentService) Deploy(ctx context.Context, appName string, options DeployOptions) (*Deployment, error) {
+	release, err := s.queue.Acquire(ctx, appName)
+	if err != nil {
+		return nil, err
+	}
+
+	deployment, err := s.deploy(ctx, appName, options)
+	if err != nil && deployment != nil {
+		s.recordDeployment(deployment)
+	}
+	if err != nil || deployment == nil || s.tracker == nil {
+		release()
+		return deployment, err
+	}
+
+	go func() {
+		defer release()
+		finished, err := s.tracker.WaitForCompletion(context.Background(), deployment.ID())
+		if err != nil {
+			s.logger.Warn("Deployment no longer tracked, releasing the application",
+				"nom_app", appName, "deployment_id", deployment.ID(), "erreur", err)
+			return
+		}
+		s.recordDeployment(finished)
+	}()
+	return deployment, nil
+}
+
+// recordDeployment enregistre un déploiement terminé dans l'historique
+func (s *ApplicationDeploymentService) recordDeployment(deployment *Deployment) {
+	if err := s.deploymentRepo.Save(context.Background(), deployment); err != nil {
+		s.logger.Warn("Échec d'enregistrement du déploiement",
+			"nom_app", deployment.AppName(), "deployment_id", deployment.ID(), "erreur", err)
+	}
+}
+
+// WaitForCompletion attend qu'un déploiement suivi atteigne son statut final ;
+// un déploiement qui n'est plus suivi est renvoyé tel qu'enregistré
+func (s *ApplicationDeploymentService) WaitForCompletion(ctx context.Context, deploymentID string) (*Deployment, error) {
+	if s.tracker != nil {
+		deployment, err := s.tracker.WaitForCompletion(ctx, deploymentID)
+		if !errors.Is(err, ErrDeploymentNotFound) {
+			return deployment, err
+		}
+	}
+	return s.GetByID(ctx, deploymentID)
+}
+
+func (s *ApplicationDeploymentService) deploy(ctx context.Context, appName string, options DeployOptions) (*Deployment, error) {
 	s.logger.Info("Démarrage du déploiement d'application",
 		"nom_app", appName,
 		"git_ref", options.GitRef.Value())
 
+	if options.BuildDir != "" {
+		if err := ValidateBuildDir(options.BuildDir); err != nil {
+			return nil, err
+		}
+	}
+
 	deployment, err := NewDeployment(appName, options.GitRef.Value())
 	if err != nil {
 		return nil, fmt.Errorf("échec de création du déploiement: %w", err)
 	}
 
+	deployment.SetRepoURL(options.RepoURL)
 	deployment.Start()
 
 	// Track the deployment
@@ -81,8 +164,19 @@ func (s *ApplicationDeploymentService) Deploy(ctx context.Context, appName strin
 		}
 	}
 
+	// Le répertoire de build doit être défini avant le git:sync qui déclenche le build
+	if options.BuildDir != "" {
+		if err := s.infrastructure.SetBuildDir(ctx, appName, options.BuildDir); err != nil {
+			deployment.Fail(fmt.Sprintf("Échec de définition du répertoire de build: %v", err))
+			if s.tracker != nil {
+				_ = s.tracker.UpdateStatus(deployment.ID(), DeploymentStatusFailed, err.Error())
+			}
+			return deployment, fmt.Errorf("échec de définition du répertoire de build: %w", err)
+		}
+	}
+
 	// Start async deployment - infrastructure will handle tracking via poller
-	if err := s.infrastructure.PerformGitDeploy(ctx, deployment.ID(), appName, options.RepoURL, options.GitRef.Value()); err != nil {
+	if err := s.performGitDeployWithRetry(ctx, deployment.ID(), appName, options.RepoURL, options.GitRef.Value()); err != nil {
 		deployment.Fail(fmt.Sprintf("Échec du déploiement depuis git: %v", err))
 		s.logger.Error("Git deployment failed", "app_name", appName, "error", err)
 
@@ -103,70 +197,197 @@ func (s *ApplicationDeploymentService) Deploy(ctx context.Context, appName strin
 	return deployment, nil
 }
 
-// Rollback effectue un rollback vers une version précédente
+// performGitDeployWithRetry réessaie une seule fois, après le délai de grâce, un
+// git:sync en échec transitoire. Les autres échecs (dont ceux du build) sont
+// renvoyés immédiatement.
+func (s *ApplicationDeploymentService) performGitDeployWithRetry(ctx context.Context, deploymentID, appName, repoURL, gitRef string) error {
+	err := s.infrastructure.PerformGitDeploy(ctx, deploymentID, appName, repoURL, gitRef)
+	if err == nil || s.syncRetryDelay <= 0 || !errors.Is(err, ErrTransientSyncFailure) {
+		return err
+	}
+
+	s.logger.Warn("Échec transitoire du git:sync, nouvel essai",
+		"nom_app", appName,
+		"deployment_id", deploymentID,
+		"délai", s.syncRetryDelay,
+		"erreur", err)
+
+	select {
+	case <-ctx.Done():
+		return err
+	case <-time.After(s.syncRetryDelay):
+	}
+
+	return s.infrastructure.PerformGitDeploy(ctx, deploymentID, appName, repoURL, gitRef)
+}
+
+// Rollback redéploie une version précédente. La version est un identifiant ou
+// une référence git d'un déploiement réussi de l'historique ; son code est
+// importé depuis l'archive publiée par l'hébergeur du dépôt (git:from-archive),
+// ce qui reconstruit et redéploie l'application sans resynchroniser le dépôt.
+// Le rollback attend la fin du build et est enregistré dans l'historique avec
+// son résultat réel ; il passe par la file de déploiement de l'application.
 func (s *ApplicationDeploymentService) Rollback(ctx context.Context, appName string, version string) error {
+	return s.queue.Run(ctx, appName, func() error {
+		return s.rollback(ctx, appName, version)
+	})
+}
+
+func (s *ApplicationDeploymentService) rollback(ctx context.Context, appName string, version string) error {
 	s.logger.Info("Démarrage du rollback d'application",
 		"nom_app", appName,
 		"version", version)
 
-	deployments, err := s.deploymentRepo.FindByAppName(ctx, appName)
-	if err != nil {
+	if err := s.checkApplicationExists(ctx, appName); err != nil {
 		return err
 	}
 
-	var targetDeployment *Deployment
-	for _, d := range deployments {
-		if d.ID() == version {
-			targetDeployment = d
-			break
-		}
+	history, err := s.GetHistory(ctx, appName)
+	if err != nil {
+		return err
 	}
 
-	if targetDeployment == nil {
-		return ErrDeploymentNotFound
+	target := findRollbackTarget(history, version)
+	if target == nil {
+		return &RollbackTargetNotFoundError{AppName: appName, Version: version}
 	}
 
-	if !targetDeployment.IsCompleted() {
-		return fmt.Errorf("cannot rollback to incomplete deployment: %s", version)
+	repoURL := s.sourceRepository(ctx, appName, target)
+	if repoURL == "" {
+		return fmt.Errorf("%w: %s has no known git:sync remote, deploy %s again with deploy_app",
+			ErrRollbackSourceUnknown, appName, target.GitRef())
+	}
+	archiveURL, ok := SourceArchiveURL(repoURL, target.GitRef())
+	if !ok {
+		return fmt.Errorf("%w: no source archive is published for %s, deploy %s again with deploy_app",
+			ErrRollbackSourceUnknown, repoURL, target.GitRef())
 	}
 
-	rollbackDeploy, err := NewDeployment(appName, targetDeployment.GitRef())
+	rollbackDeploy, err := NewDeployment(appName, target.GitRef())
 	if err != nil {
 		return err
 	}
+	rollbackDeploy.SetRepoURL(repoURL)
+	rollbackDeploy.Start()
 
-	rollbackDeploy.Rollback()
+	if s.tracker != nil {
+		if err := s.tracker.Track(rollbackDeploy); err != nil {
+			s.logger.Warn("Failed to track rollback", "error", err)
+		}
+	}
 
-	// Perform the actual rollback
-	if err := s.infrastructure.PerformGitDeploy(ctx, rollbackDeploy.ID(), appName, "", targetDeployment.GitRef()); err != nil {
+	// git:from-archive rend la main une fois le build et le déploiement terminés
+	if err := s.infrastructure.PerformArchiveDeploy(ctx, rollbackDeploy.ID(), appName, archiveURL); err != nil {
 		rollbackDeploy.Fail(fmt.Sprintf("Échec du rollback: %v", err))
-		_ = s.deploymentRepo.Save(ctx, rollbackDeploy)
+		if s.tracker != nil {
+			_ = s.tracker.UpdateStatus(rollbackDeploy.ID(), DeploymentStatusFailed, err.Error())
+		}
+		if saveErr := s.deploymentRepo.Save(ctx, rollbackDeploy); saveErr != nil {
+			s.logger.Warn("Failed to record failed rollback", "error", saveErr)
+		}
 		return fmt.Errorf("échec du rollback: %w", err)
 	}
 
 	rollbackDeploy.Complete()
+	if s.tracker != nil {
+		_ = s.tracker.UpdateStatus(rollbackDeploy.ID(), DeploymentStatusSucceeded, "")
+	}
 
 	s.logger.Info("Rollback terminé avec succès",
 		"nom_app", appName,
-		"version", version)
+		"version", version,
+		"git_ref", target.GitRef())
 
 	return s.deploymentRepo.Save(ctx, rollbackDeploy)
 }
 
-// GetHistory récupère l'historique des déploiements
+// checkApplicationExists vérifie que l'application existe dans Dokku
+func (s *ApplicationDeploymentService) checkApplicationExists(ctx context.Context, appName string) error {
+	exists, err := s.infrastructure.ApplicationExists(ctx, appName)
+	if err != nil {
+		return fmt.Errorf("échec de vérification de l'application: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrApplicationNotFound, appName)
+	}
+	return nil
+}
+
+// findRollbackTarget cherche, du plus récent au plus ancien, le déploiement
+// réussi dont l'identifiant ou la référence git correspond à la version
+func findRollbackTarget(history []*Deployment, version string) *Deployment {
+	for _, d := range history {
+		if (d.ID() == version || d.GitRef() == version) && d.IsSuccessful() {
+			return d
+		}
+	}
+	return nil
+}
+
+// sourceRepository retrouve le dépôt git de la version ciblée : le sien s'il est
+// connu, sinon celui du dernier déploiement de l'application lancé par ce serveur,
+// sinon le dernier dépôt git:sync enregistré par Dokku, qui survit aux redémarrages
+func (s *ApplicationDeploymentService) sourceRepository(ctx context.Context, appName string, target *Deployment) string {
+	if target.RepoURL() != "" {
+		return target.RepoURL()
+	}
+
+	if s.tracker != nil {
+		var latest *Deployment
+		for _, d := range s.tracker.GetAll() {
+			if d.AppName() != appName || d.RepoURL() == "" {
+				continue
+			}
+			if latest == nil || d.CreatedAt().After(latest.CreatedAt()) {
+				latest = d
+			}
+		}
+		if latest != nil {
+			return latest.RepoURL()
+		}
+	}
+
+	repoURL, err := s.infrastructure.DeploySourceRepository(ctx, appName)
+	if err != nil {
+		s.logger.Warn("Échec de lecture de la source de déploiement",
+			"erreur", err, "nom_app", appName)
+		return ""
+	}
+	return repoURL
+}
+
+// GetHistory récupère l'historique des déploiements : le journal d'événements
+// Dokku complété des déploiements enregistrés par ce serveur (rollbacks). Les
+// événements produits par un déploiement enregistré ne sont pas comptés deux fois.
+// Une source illisible est ignorée tant que l'autre répond.
 func (s *ApplicationDeploymentService) GetHistory(ctx context.Context, appName string) ([]*Deployment, error) {
 	s.logger.Debug("Récupération de l'historique des déploiements", "nom_app", appName)
 
-	deployments, err := s.infrastructure.ParseDeploymentHistory(ctx, appName)
-	if err != nil {
+	recorded, recordedErr := s.deploymentRepo.FindByAppName(ctx, appName)
+	if recordedErr != nil {
+		s.logger.Warn("Échec de récupération des déploiements enregistrés",
+			"erreur", recordedErr, "nom_app", appName)
+		recorded = nil
+	}
+
+	events, eventsErr := s.infrastructure.ParseDeploymentHistory(ctx, appName)
+	if eventsErr != nil {
 		s.logger.Warn("Échec de récupération de l'historique depuis l'infrastructure",
-			"erreur", err, "nom_app", appName)
+			"erreur", eventsErr, "nom_app", appName)
+		events = nil
+	}
 
-		deployments, err = s.deploymentRepo.FindByAppName(ctx, appName)
-		if err != nil {
-			return nil, fmt.Errorf("échec de récupération de l'historique: %w", err)
+	if recordedErr != nil && eventsErr != nil {
+		return nil, fmt.Errorf("échec de récupération de l'historique: %w", errors.Join(recordedErr, eventsErr))
+	}
+
+	deployments := make([]*Deployment, 0, len(events)+len(recorded))
+	for _, event := range events {
+		if !withinRecordedDeployment(event, recorded) {
+			deployments = append(deployments, event)
 		}
 	}
+	deployments = append(deployments, recorded...)
 
 	// Sort deployments by timestamp (most recent first)
 	sort.Slice(deployments, func(i, j int) bool {
@@ -179,6 +400,27 @@ func (s *ApplicationDeploymentService) GetHistory(ctx context.Context, appName s
 	return deployments, nil
 }
 
+// withinRecordedDeployment indique si un déploiement du journal d'événements a
+// eu lieu pendant un déploiement enregistré, dont il n'est alors qu'une trace
+func withinRecordedDeployment(event *Deployment, recorded []*Deployment) bool {
+	at := event.CreatedAt()
+	for _, d := range recorded {
+		start := d.CreatedAt()
+		if d.StartedAt() != nil {
+			start = *d.StartedAt()
+		}
+		end := time.Now()
+		if d.CompletedAt() != nil {
+			end = *d.CompletedAt()
+		}
+		// Le journal Dokku est à la seconde près
+		if !at.Before(start.Truncate(time.Second)) && !at.After(end) {
+			return true
+		}
+	}
+	return false
+}
+
 // GetByID récupère un déploiement par son ID
 func (s *ApplicationDeploymentService) GetByID(ctx context.Context, deploymentID string) (*Deployment, error) {
 	s.logger.Debug("Récupération du déploiement par ID", "deployment_id", deploymentID)
@@ -200,6 +442,38 @@ func (s *ApplicationDeploymentService) GetByID(ctx context.Context, deploymentID
 	return nil, ErrDeploymentNotFound
 }
 
+// GetActiveDeployment renvoie le déploiement suivi en cours de l'application,
+// ou nil s'il n'y en a pas
+func (s *ApplicationDeploymentService) GetActiveDeployment(ctx context.Context, appName string) (*Deployment, error) {
+	if s.tracker == nil {
+		return nil, nil
+	}
+	for _, d := range s.tracker.GetActive() {
+		if d.AppName() == appName {
+			return d, nil
+		}
+	}
+	return nil, nil
+}
+
+// GetLastDeployStatus récupère le résultat de la dernière tentative de déploiement
+// (statut vide si l'application n'a jamais été déployée)
+func (s *ApplicationDeploymentService) GetLastDeployStatus(ctx context.Context, appName string) (DeploymentStatus, error) {
+	s.logger.Debug("Récupération du statut du dernier déploiement", "nom_app", appName)
+
+	// Un déploiement suivi en cours fait foi, même si le journal Dokku ne l'a pas encore vu
+	if active, _ := s.GetActiveDeployment(ctx, appName); active != nil {
+		return DeploymentStatusRunning, nil
+	}
+
+	status, err := s.infrastructure.GetLastDeployStatus(ctx, appName)
+	if err != nil {
+		return "", fmt.Errorf("échec de récupération du dernier déploiement: %w", err)
+	}
+
+	return status, nil
+}
+
 // Cancel annule un déploiement en cours
 func (s *ApplicationDeploymentService) Cancel(ctx context.Context, deploymentID string) error {
 	s.logger.Info("Annulation du déploiement", "deployment_id", deploymentID)
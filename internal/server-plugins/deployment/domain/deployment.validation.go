@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// buildDirPattern limite le répertoire de build aux caractères sûrs d'un chemin
+var buildDirPattern = regexp.MustCompile(`^[A-Za-z0-9._/-]+$`)
+
+// ProcessFormation décrit les processus obtenus après le build, tels que
+// rapportés par Dokku (type de processus -> nombre d'instances)
+type ProcessFormation map[string]int
+
+// PostDeploymentValidation vérifie la formation résultant du build et
+// retourne les avertissements à remonter à l'utilisateur. Un Procfile mal
+// configuré ne se voit qu'après le build : on signale donc l'absence de
+// processus web sans faire échouer le déploiement.
+func PostDeploymentValidation(appName string, formation ProcessFormation) []string {
+	var warnings []string
+
+	if len(formation) == 0 {
+		warnings = append(warnings, fmt.Sprintf("no processes found for %s after build; check the Procfile", appName))
+		return warnings
+	}
+
+	if _, ok := formation["web"]; !ok {
+		warnings = append(warnings, fmt.Sprintf("no web process found for %s after build; the app will not receive HTTP traffic (check the Procfile)", appName))
+	}
+
+	return warnings
+}
+
+// ValidateBuildDir vérifie que le répertoire de build est un chemin relatif
+// sûr dans le dépôt (monorepo) : pas de chemin absolu ni de remontée "..".
+func ValidateBuildDir(buildDir string) error {
+	if buildDir == "" {
+		return fmt.Errorf("%w: cannot be empty", ErrInvalidBuildDir)
+	}
+	if !buildDirPattern.MatchString(buildDir) {
+		return fmt.Errorf("%w: %q contains unsupported characters", ErrInvalidBuildDir, buildDir)
+	}
+	if strings.HasPrefix(buildDir, "/") || strings.HasPrefix(buildDir, "-") {
+		return fmt.Errorf("%w: %q must be a path relative to the repository root", ErrInvalidBuildDir, buildDir)
+	}
+	for _, segment := range strings.Split(buildDir, "/") {
+		if segment == ".." {
+			return fmt.Errorf("%w: %q must not contain \"..\"", ErrInvalidBuildDir, buildDir)
+		}
+	}
+	if path.Clean(buildDir) == "." {
+		return fmt.Errorf("%w: %q points to the repository root", ErrInvalidBuildDir, buildDir)
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
+)
+
+// DeploymentEventPublisher receives the deployment events found by a DeploymentEventWatcher
+type DeploymentEventPublisher func(event *DeploymentEvent)
+
+// DeploymentEventWatcher periodically reads the Dokku event log and reports the
+// deploys that started, succeeded or failed since the previous read, including
+// the ones run on the host without going through this server
+type DeploymentEventWatcher struct {
+	infrastructure DeploymentInfrastructure
+	publish        DeploymentEventPublisher
+	logger         *slog.Logger
+	seen           map[string]bool
+	running        map[string]bool
+	primed         bool
+	mu             sync.Mutex
+	runner         *shared.PeriodicRunner
+}
+
+// NewDeploymentEventWatcher creates a watcher reading the event log every interval and handing new events to publish
+func NewDeploymentEventWatcher(infrastructure DeploymentInfrastructure, publish DeploymentEventPublisher, logger *slog.Logger, interval time.Duration) *DeploymentEventWatcher {
+	watcher := &DeploymentEventWatcher{
+		infrastructure: infrastructure,
+		publish:        publish,
+		logger:         logger,
+		seen:           make(map[string]bool),
+		running:        make(map[string]bool),
+	}
+	watcher.runner = shared.NewPeriodicRunner(interval, true, watcher.runCheck)
+	return watcher
+}
+
+// Check reads the event log and returns the deployment events added since the
+// previous check. The first check only records the events already logged, so
+// past deploys are not reported again when the server starts. A deploy logs
+// several start triggers; only the first one is reported.
+func (w *DeploymentEventWatcher) Check(ctx context.Context) ([]*DeploymentEvent, error) {
+	events, err := w.infrastructure.DeploymentEvents(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the Dokku event log: %w", err)
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	found := []*DeploymentEvent{}
+	current := make(map[string]bool, len(events))
+	for _, event := range events {
+		current[event.LogLine()] = true
+		if w.seen[event.LogLine()] {
+			continue
+		}
+
+		appName := event.AggregateID()
+		alreadyRunning := w.running[appName]
+		w.running[appName] = event.Kind() == DeploymentEventStarted
+		if !w.primed || (alreadyRunning && event.Kind() == DeploymentEventStarted) {
+			continue
+		}
+
+		w.logger.Info("Deployment event", "app_name", appName, "event", event.EventType(), "git_ref", event.GitRef())
+		if w.publish != nil {
+			w.publish(event)
+		}
+		found = append(found, event)
+	}
+	w.seen = current
+	w.primed = true
+
+	return found, nil
+}
+
+// Start checks the event log every interval until Stop is called
+func (w *DeploymentEventWatcher) Start() {
+	w.runner.Start()
+}
+
+// Stop ends the background loop, cancelling a check in progress
+func (w *DeploymentEventWatcher) Stop(ctx context.Context) error {
+	return w.runner.Stop(ctx)
+}
+
+func (w *DeploymentEventWatcher) runCheck(ctx context.Context) {
+	if _, err := w.Check(ctx); err != nil && ctx.Err() == nil {
+		w.logger.Warn("Deployment event watch failed", "error", err)
+	}
+}
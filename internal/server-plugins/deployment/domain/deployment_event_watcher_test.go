@@ -0,0 +1,139 @@
+package domain_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/deployment/domain"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// eventLogInfrastructure returns the deployment events of a scripted event log
+type eventLogInfrastructure struct {
+	domain.DeploymentInfrastructure
+	mu     sync.Mutex
+	events []*domain.DeploymentEvent
+	err    error
+	reads  int
+}
+
+func (f *eventLogInfrastructure) DeploymentEvents(ctx context.Context) ([]*domain.DeploymentEvent, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.reads++
+	return f.events, f.err
+}
+
+func (f *eventLogInfrastructure) readCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.reads
+}
+
+func (f *eventLogInfrastructure) log(appName string, kind domain.DeploymentEventKind, line string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.events = append(f.events, domain.NewDeploymentEvent(appName, "main", kind, line, time.Now()))
+}
+
+var _ = Describe("DeploymentEventWatcher", func() {
+	var (
+		infrastructure *eventLogInfrastructure
+		published      chan *domain.DeploymentEvent
+		watcher        *domain.DeploymentEventWatcher
+	)
+
+	BeforeEach(func() {
+		infrastructure = &eventLogInfrastructure{}
+		infrastructure.log("my-app", domain.DeploymentEventSucceeded, "dokku[1]: INVOKED: post-deploy( my-app 5000 )")
+		published = make(chan *domain.DeploymentEvent, 10)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+		watcher = domain.NewDeploymentEventWatcher(infrastructure, func(event *domain.DeploymentEvent) {
+			published <- event
+		}, logger, time.Minute)
+	})
+
+	It("should not report the deploys logged before the first check", func() {
+		events, err := watcher.Check(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(events).To(BeEmpty())
+		Expect(published).To(BeEmpty())
+	})
+
+	It("should report each new deploy event once", func() {
+		_, err := watcher.Check(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+
+		infrastructure.log("my-app", domain.DeploymentEventStarted, "dokku[2]: INVOKED: receive-app( my-app main )")
+		infrastructure.log("my-app", domain.DeploymentEventFailed, "dokku[2]: INVOKED: deploy-failed( my-app )")
+
+		events, err := watcher.Check(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(events).To(HaveLen(2))
+		Expect(events[0].EventType()).To(Equal("deployment.started"))
+		Expect(events[1].EventType()).To(Equal("deployment.failed"))
+		Expect(events[1].AggregateID()).To(Equal("my-app"))
+		Expect(published).To(HaveLen(2))
+
+		events, err = watcher.Check(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(events).To(BeEmpty())
+		Expect(published).To(HaveLen(2))
+	})
+
+	It("should report a deploy start once across its build triggers", func() {
+		_, err := watcher.Check(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+
+		infrastructure.log("my-app", domain.DeploymentEventStarted, "dokku[2]: INVOKED: receive-app( my-app main )")
+		infrastructure.log("my-app", domain.DeploymentEventStarted, "dokku[2]: INVOKED: pre-build( my-app herokuish )")
+		infrastructure.log("my-app", domain.DeploymentEventSucceeded, "dokku[2]: INVOKED: post-deploy( my-app 5000 )")
+		infrastructure.log("my-app", domain.DeploymentEventStarted, "dokku[3]: INVOKED: pre-build( my-app herokuish )")
+
+		events, err := watcher.Check(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(events).To(HaveLen(3))
+		Expect(events[0].Kind()).To(Equal(domain.DeploymentEventStarted))
+		Expect(events[1].Kind()).To(Equal(domain.DeploymentEventSucceeded))
+		Expect(events[2].Kind()).To(Equal(domain.DeploymentEventStarted))
+	})
+
+	It("should keep what it has seen when the event log cannot be read", func() {
+		_, err := watcher.Check(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+
+		infrastructure.err = errors.New("ssh unreachable")
+		_, err = watcher.Check(context.Background())
+		Expect(err).To(MatchError(ContainSubstring("ssh unreachable")))
+
+		infrastructure.err = nil
+		infrastructure.log("my-app", domain.DeploymentEventStarted, "dokku[2]: INVOKED: receive-app( my-app main )")
+		events, err := watcher.Check(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(events).To(HaveLen(1))
+		Expect(events[0].Kind()).To(Equal(domain.DeploymentEventStarted))
+	})
+
+	It("should watch in the background until stopped", func() {
+		watcher = domain.NewDeploymentEventWatcher(infrastructure, func(event *domain.DeploymentEvent) {
+			published <- event
+		}, slog.New(slog.NewTextHandler(io.Discard, nil)), 10*time.Millisecond)
+
+		watcher.Start()
+		Eventually(infrastructure.readCount).Should(BeNumerically(">=", 1))
+		infrastructure.log("my-app", domain.DeploymentEventStarted, "dokku[2]: INVOKED: receive-app( my-app main )")
+
+		var event *domain.DeploymentEvent
+		Eventually(published).Should(Receive(&event))
+		Expect(event.Kind()).To(Equal(domain.DeploymentEventStarted))
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		Expect(watcher.Stop(ctx)).To(Succeed())
+	})
+})
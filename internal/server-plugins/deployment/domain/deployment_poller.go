@@ -24,6 +24,8 @@ type DeploymentPoller struct {
 	stopChan      chan struct{}
 	activePolls   map[string]context.CancelFunc
 	pollMutex     sync.RWMutex
+	// postDeploymentCheck runs once a deployment succeeded, before it is marked so
+	postDeploymentCheck func(ctx context.Context, deploymentID, appName string)
 }
 
 // NewDeploymentPoller creates a new deployment poller
@@ -45,6 +47,15 @@ func NewDeploymentPoller(
 	}
 }
 
+// SetPostDeploymentCheck registers a check run when a deployment succeeds, before
+// its status is updated: warnings it records are visible to whoever waits for the
+// deployment to finish
+func (dp *DeploymentPoller) SetPostDeploymentCheck(check func(ctx context.Context, deploymentID, appName string)) {
+	dp.pollMutex.Lock()
+	defer dp.pollMutex.Unlock()
+	dp.postDeploymentCheck = check
+}
+
 // StartPolling begins polling for a deployment's status
 func (dp *DeploymentPoller) StartPolling(ctx context.Context, deploymentID, appName string) {
 	dp.logger.Info("Starting deployment polling",
@@ -137,6 +148,15 @@ func (dp *DeploymentPoller) pollDeploymentStatus(ctx context.Context, deployment
 
 			previousStatus := deployment.Status()
 
+			if status == DeploymentStatusSucceeded && previousStatus != DeploymentStatusSucceeded {
+				dp.pollMutex.RLock()
+				check := dp.postDeploymentCheck
+				dp.pollMutex.RUnlock()
+				if check != nil {
+					check(ctx, deploymentID, appName)
+				}
+			}
+
 			// Update status in tracker
 			if err := dp.tracker.UpdateStatus(deploymentID, status, errorMsg); err != nil {
 				dp.logger.Warn("Failed to update deployment status",
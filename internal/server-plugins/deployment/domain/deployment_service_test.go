@@ -0,0 +1,516 @@
+package domain_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/deployment/domain"
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type fakeInfrastructure struct {
+	domain.DeploymentInfrastructure
+	calls    []string
+	buildDir string
+	// tracker and deployOutput simulate a failed build: the output is recorded and the deploy fails
+	tracker      *domain.DeploymentTracker
+	deployOutput string
+	// deployErrors are returned by successive PerformGitDeploy calls
+	deployErrors []error
+	// missingApp makes ApplicationExists report the app as absent
+	missingApp bool
+	// history is returned by ParseDeploymentHistory; synced records the repo and ref of each git deploy
+	history []*domain.Deployment
+	synced  []string
+	// archives records the archive of each archive deploy; sourceRepo is the remote recorded by Dokku
+	archives   []string
+	sourceRepo string
+}
+
+func (f *fakeInfrastructure) PerformArchiveDeploy(ctx context.Context, deploymentID, appName, archiveURL string) error {
+	f.calls = append(f.calls, "archive-deploy")
+	f.archives = append(f.archives, archiveURL)
+	if len(f.deployErrors) > 0 {
+		err := f.deployErrors[0]
+		f.deployErrors = f.deployErrors[1:]
+		return err
+	}
+	return nil
+}
+
+func (f *fakeInfrastructure) DeploySourceRepository(ctx context.Context, appName string) (string, error) {
+	return f.sourceRepo, nil
+}
+
+func (f *fakeInfrastructure) ApplicationExists(ctx context.Context, appName string) (bool, error) {
+	return !f.missingApp, nil
+}
+
+func (f *fakeInfrastructure) ParseDeploymentHistory(ctx context.Context, appName string) ([]*domain.Deployment, error) {
+	return f.history, nil
+}
+
+func (f *fakeInfrastructure) SetBuildDir(ctx context.Context, appName string, buildDir string) error {
+	f.calls = append(f.calls, "set-build-dir")
+	f.buildDir = buildDir
+	return nil
+}
+
+func (f *fakeInfrastructure) PerformGitDeploy(ctx context.Context, deploymentID, appName, repoURL, gitRef string) error {
+	f.calls = append(f.calls, "git-deploy")
+	f.synced = append(f.synced, repoURL+"@"+gitRef)
+	if len(f.deployErrors) > 0 {
+		err := f.deployErrors[0]
+		f.deployErrors = f.deployErrors[1:]
+		return err
+	}
+	if f.deployOutput != "" {
+		_ = f.tracker.AddLogs(deploymentID, f.deployOutput)
+		return errors.New("exit status 1")
+	}
+	return nil
+}
+
+var _ = Describe("ApplicationDeploymentService", func() {
+	var (
+		infra   *fakeInfrastructure
+		service *domain.ApplicationDeploymentService
+		gitRef  *shared.GitRef
+	)
+
+	BeforeEach(func() {
+		infra = &fakeInfrastructure{}
+		service = domain.NewApplicationDeploymentService(&memoryDeploymentRepository{}, infra, nil, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+		var err error
+		gitRef, err = shared.NewGitRef("main")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Describe("Deploy with a build directory", func() {
+		It("should set the build directory before syncing the code", func() {
+			_, err := service.Deploy(context.Background(), "my-app", domain.DeployOptions{
+				RepoURL:  "https://github.com/example/monorepo.git",
+				GitRef:   gitRef,
+				BuildDir: "services/api",
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(infra.buildDir).To(Equal("services/api"))
+			Expect(infra.calls).To(Equal([]string{"set-build-dir", "git-deploy"}))
+		})
+
+		It("should leave the build directory untouched when not provided", func() {
+			_, err := service.Deploy(context.Background(), "my-app", domain.DeployOptions{
+				RepoURL: "https://github.com/example/app.git",
+				GitRef:  gitRef,
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(infra.calls).To(Equal([]string{"git-deploy"}))
+		})
+
+		It("should reject a build directory escaping the repository", func() {
+			_, err := service.Deploy(context.Background(), "my-app", domain.DeployOptions{
+				RepoURL:  "https://github.com/example/monorepo.git",
+				GitRef:   gitRef,
+				BuildDir: "services/../../etc",
+			})
+
+			Expect(err).To(MatchError(domain.ErrInvalidBuildDir))
+			Expect(infra.calls).To(BeEmpty())
+		})
+	})
+})
+
+var _ = Describe("Deploy retries", func() {
+	var (
+		infra   *fakeInfrastructure
+		service *domain.ApplicationDeploymentService
+		gitRef  *shared.GitRef
+	)
+
+	BeforeEach(func() {
+		infra = &fakeInfrastructure{}
+		service = domain.NewApplicationDeploymentService(&memoryDeploymentRepository{}, infra, nil, slog.New(slog.NewTextHandler(io.Discard, nil)))
+		service.SetSyncRetryDelay(time.Millisecond)
+
+		var err error
+		gitRef, err = shared.NewGitRef("main")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	deploy := func() error {
+		_, err := service.Deploy(context.Background(), "my-app", domain.DeployOptions{
+			RepoURL: "https://github.com/example/app.git",
+			GitRef:  gitRef,
+		})
+		return err
+	}
+
+	It("should retry a transient git sync failure once", func() {
+		infra.deployErrors = []error{fmt.Errorf("git sync failed: %w", domain.ErrTransientSyncFailure)}
+
+		Expect(deploy()).To(Succeed())
+		Expect(infra.calls).To(Equal([]string{"git-deploy", "git-deploy"}))
+	})
+
+	It("should fail after a second transient failure", func() {
+		transient := fmt.Errorf("git sync failed: %w", domain.ErrTransientSyncFailure)
+		infra.deployErrors = []error{transient, transient}
+
+		Expect(deploy()).To(MatchError(domain.ErrTransientSyncFailure))
+		Expect(infra.calls).To(HaveLen(2))
+	})
+
+	It("should not retry a build failure", func() {
+		infra.deployErrors = []error{errors.New("remote: ! Build failed: exit status 1")}
+
+		Expect(deploy()).To(HaveOccurred())
+		Expect(infra.calls).To(Equal([]string{"git-deploy"}))
+	})
+
+	It("should return a missing git ref as a typed error without retrying", func() {
+		infra.deployErrors = []error{fmt.Errorf("git sync failed: %w", &shared.GitSyncError{
+			Kind:   shared.ErrGitRefNotFound,
+			Detail: "fatal: couldn't find remote ref main",
+			Err:    errors.New("exit status 128"),
+		})}
+
+		err := deploy()
+		Expect(err).To(MatchError(shared.ErrGitRefNotFound))
+		Expect(err.Error()).To(ContainSubstring("couldn't find remote ref main"))
+		Expect(infra.calls).To(Equal([]string{"git-deploy"}))
+	})
+})
+
+var _ = Describe("Deploy queue slot", func() {
+	It("should keep the app reserved until the tracked deployment finishes", func() {
+		tracker := domain.NewDeploymentTracker()
+		infra := &fakeInfrastructure{}
+		service := domain.NewApplicationDeploymentService(&memoryDeploymentRepository{}, infra, tracker, slog.New(slog.NewTextHandler(io.Discard, nil)))
+		gitRef, err := shared.NewGitRef("main")
+		Expect(err).NotTo(HaveOccurred())
+		options := domain.DeployOptions{RepoURL: "https://github.com/example/app.git", GitRef: gitRef}
+
+		first, err := service.Deploy(context.Background(), "my-app", options)
+		Expect(err).NotTo(HaveOccurred())
+		active, err := service.GetActiveDeployment(context.Background(), "my-app")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(active.ID()).To(Equal(first.ID()))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		_, err = service.Deploy(ctx, "my-app", options)
+		Expect(err).To(MatchError(context.DeadlineExceeded))
+		Expect(infra.calls).To(HaveLen(1))
+
+		Expect(tracker.UpdateStatus(first.ID(), domain.DeploymentStatusSucceeded, "")).To(Succeed())
+		Eventually(func() error {
+			ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+			defer cancel()
+			_, err := service.Deploy(ctx, "my-app", options)
+			return err
+		}).Should(Succeed())
+
+		finished, err := service.WaitForCompletion(context.Background(), first.ID())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(finished.Status()).To(Equal(domain.DeploymentStatusSucceeded))
+	})
+})
+
+var _ = Describe("Deploy history", func() {
+	It("should record a finished deploy with its ref, repository and final status", func() {
+		tracker := domain.NewDeploymentTracker()
+		repo := &memoryDeploymentRepository{}
+		service := domain.NewApplicationDeploymentService(repo, &fakeInfrastructure{}, tracker, slog.New(slog.NewTextHandler(io.Discard, nil)))
+		gitRef, err := shared.NewGitRef("release-2")
+		Expect(err).NotTo(HaveOccurred())
+
+		deployment, err := service.Deploy(context.Background(), "my-app", domain.DeployOptions{
+			RepoURL: "https://github.com/example/app.git",
+			GitRef:  gitRef,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(repo.recorded()).To(BeEmpty())
+
+		Expect(tracker.UpdateStatus(deployment.ID(), domain.DeploymentStatusFailed, "build failed")).To(Succeed())
+		Eventually(repo.recorded).Should(HaveLen(1))
+
+		recorded := repo.recorded()[0]
+		Expect(recorded.ID()).To(Equal(deployment.ID()))
+		Expect(recorded.GitRef()).To(Equal("release-2"))
+		Expect(recorded.RepoURL()).To(Equal("https://github.com/example/app.git"))
+		Expect(recorded.Status()).To(Equal(domain.DeploymentStatusFailed))
+	})
+})
+
+var _ = Describe("Failed deployment logs", func() {
+	var (
+		tracker *domain.DeploymentTracker
+		infra   *fakeInfrastructure
+		service *domain.ApplicationDeploymentService
+		gitRef  *shared.GitRef
+	)
+
+	BeforeEach(func() {
+		tracker = domain.NewDeploymentTrackerWithLogLimit(64)
+		infra = &fakeInfrastructure{tracker: tracker}
+		service = domain.NewApplicationDeploymentService(&memoryDeploymentRepository{}, infra, tracker, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+		var err error
+		gitRef, err = shared.NewGitRef("main")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should retain the build output and reason of a failed deploy", func() {
+		infra.deployOutput = "-----> Building my-app\nnpm ERR! missing script: build\n"
+
+		deployment, err := service.Deploy(context.Background(), "my-app", domain.DeployOptions{
+			RepoURL: "https://github.com/example/app.git",
+			GitRef:  gitRef,
+		})
+		Expect(err).To(HaveOccurred())
+
+		failure, err := tracker.LastFailure("my-app")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(failure.DeploymentID).To(Equal(deployment.ID()))
+		Expect(failure.Reason).To(ContainSubstring("exit status 1"))
+		Expect(failure.Logs).To(ContainSubstring("npm ERR! missing script: build"))
+		Expect(failure.Truncated).To(BeFalse())
+	})
+
+	It("should keep the end of the output beyond the size limit", func() {
+		infra.deployOutput = strings.Repeat("-----> step\n", 20) + "FAILED: exit 1\n"
+
+		_, err := service.Deploy(context.Background(), "my-app", domain.DeployOptions{
+			RepoURL: "https://github.com/example/app.git",
+			GitRef:  gitRef,
+		})
+		Expect(err).To(HaveOccurred())
+
+		failure, err := tracker.LastFailure("my-app")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(failure.Logs).To(HaveLen(64))
+		Expect(failure.Logs).To(HaveSuffix("FAILED: exit 1\n"))
+		Expect(failure.Truncated).To(BeTrue())
+	})
+
+	It("should not record successful deploys", func() {
+		_, err := service.Deploy(context.Background(), "my-app", domain.DeployOptions{
+			RepoURL: "https://github.com/example/app.git",
+			GitRef:  gitRef,
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = tracker.LastFailure("my-app")
+		Expect(err).To(MatchError(domain.ErrNoFailedDeployment))
+	})
+})
+
+// memoryDeploymentRepository keeps the deployments recorded by the service
+type memoryDeploymentRepository struct {
+	domain.DeploymentRepository
+	mu      sync.Mutex
+	saved   []*domain.Deployment
+	findErr error
+}
+
+func (r *memoryDeploymentRepository) Save(ctx context.Context, deployment *domain.Deployment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.saved = append(r.saved, deployment)
+	return nil
+}
+
+func (r *memoryDeploymentRepository) FindByAppName(ctx context.Context, appName string) ([]*domain.Deployment, error) {
+	if r.findErr != nil {
+		return nil, r.findErr
+	}
+	var deployments []*domain.Deployment
+	for _, d := range r.recorded() {
+		if d.AppName() == appName {
+			deployments = append(deployments, d)
+		}
+	}
+	return deployments, nil
+}
+
+// recorded returns the deployments saved so far, finished deploys being saved asynchronously
+func (r *memoryDeploymentRepository) recorded() []*domain.Deployment {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.saved)
+}
+
+var _ = Describe("Rollback", func() {
+	var (
+		infra   *fakeInfrastructure
+		repo    *memoryDeploymentRepository
+		tracker *domain.DeploymentTracker
+		service *domain.ApplicationDeploymentService
+	)
+
+	pastDeployment := func(gitRef string, age time.Duration) *domain.Deployment {
+		d, err := domain.NewDeploymentWithTimestamp("my-app", gitRef, time.Now().Add(-age))
+		Expect(err).NotTo(HaveOccurred())
+		d.Complete()
+		return d
+	}
+
+	BeforeEach(func() {
+		infra = &fakeInfrastructure{
+			history: []*domain.Deployment{pastDeployment("v2", time.Hour), pastDeployment("v1", 2*time.Hour)},
+		}
+		repo = &memoryDeploymentRepository{}
+		tracker = domain.NewDeploymentTracker()
+		service = domain.NewApplicationDeploymentService(repo, infra, tracker, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+		gitRef, err := shared.NewGitRef("v2")
+		Expect(err).NotTo(HaveOccurred())
+		_, err = service.Deploy(context.Background(), "my-app", domain.DeployOptions{
+			RepoURL: "https://github.com/example/app.git",
+			GitRef:  gitRef,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		for _, d := range tracker.GetActive() {
+			Expect(tracker.UpdateStatus(d.ID(), domain.DeploymentStatusSucceeded, "")).To(Succeed())
+		}
+		Eventually(repo.recorded).Should(HaveLen(1))
+		infra.calls, infra.synced = nil, nil
+	})
+
+	AfterEach(func() {
+		_ = tracker.Shutdown(context.Background())
+	})
+
+	It("should rebuild the target git ref from its source archive and record it in the history", func() {
+		Expect(service.Rollback(context.Background(), "my-app", "v1")).To(Succeed())
+
+		Expect(infra.archives).To(Equal([]string{"https://github.com/example/app/archive/v1.tar.gz"}))
+		Expect(infra.synced).To(BeEmpty())
+
+		history, err := service.GetHistory(context.Background(), "my-app")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(history).To(HaveLen(4))
+		Expect(history[0].GitRef()).To(Equal("v1"))
+		Expect(history[0].Status()).To(Equal(domain.DeploymentStatusSucceeded))
+	})
+
+	It("should not list the events of a recorded rollback twice", func() {
+		Expect(service.Rollback(context.Background(), "my-app", "v1")).To(Succeed())
+		recorded := repo.recorded()
+		build, err := domain.NewDeploymentWithTimestamp("my-app", "main", *recorded[len(recorded)-1].StartedAt())
+		Expect(err).NotTo(HaveOccurred())
+		build.Complete()
+		infra.history = append([]*domain.Deployment{build}, infra.history...)
+
+		history, err := service.GetHistory(context.Background(), "my-app")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(history).To(HaveLen(4))
+	})
+
+	It("should read the source repository recorded by Dokku once the server forgot it", func() {
+		tracker = domain.NewDeploymentTracker()
+		service = domain.NewApplicationDeploymentService(repo, infra, tracker, slog.New(slog.NewTextHandler(io.Discard, nil)))
+		infra.sourceRepo = "git@gitlab.com:example/app.git"
+
+		Expect(service.Rollback(context.Background(), "my-app", "v1")).To(Succeed())
+
+		Expect(infra.archives).To(Equal([]string{"https://gitlab.com/example/app/-/archive/v1/app-v1.tar.gz"}))
+	})
+
+	It("should roll back to a recorded deploy from its own repository once the tracker forgot it", func() {
+		tracker = domain.NewDeploymentTracker()
+		service = domain.NewApplicationDeploymentService(repo, infra, tracker, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+		Expect(service.Rollback(context.Background(), "my-app", "v2")).To(Succeed())
+
+		Expect(infra.archives).To(Equal([]string{"https://github.com/example/app/archive/v2.tar.gz"}))
+	})
+
+	It("should refuse a repository without source archives", func() {
+		tracker = domain.NewDeploymentTracker()
+		service = domain.NewApplicationDeploymentService(repo, infra, tracker, slog.New(slog.NewTextHandler(io.Discard, nil)))
+		infra.sourceRepo = "https://git.example.com/app.git"
+
+		Expect(service.Rollback(context.Background(), "my-app", "v1")).To(MatchError(domain.ErrRollbackSourceUnknown))
+		Expect(infra.calls).To(BeEmpty())
+	})
+
+	It("should record a failed rollback", func() {
+		infra.deployErrors = []error{errors.New("exit status 1")}
+
+		Expect(service.Rollback(context.Background(), "my-app", "v1")).To(MatchError(ContainSubstring("exit status 1")))
+
+		recorded := repo.recorded()
+		Expect(recorded).To(HaveLen(2))
+		Expect(recorded[1].Status()).To(Equal(domain.DeploymentStatusFailed))
+	})
+
+	It("should list the event log when the recorded deployments cannot be read", func() {
+		repo.findErr = errors.New("store unavailable")
+
+		history, err := service.GetHistory(context.Background(), "my-app")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(history).To(HaveLen(2))
+	})
+
+	It("should return a typed error when the version is not in the history", func() {
+		err := service.Rollback(context.Background(), "my-app", "v0")
+
+		var notFound *domain.RollbackTargetNotFoundError
+		Expect(errors.As(err, &notFound)).To(BeTrue())
+		Expect(notFound.Version).To(Equal("v0"))
+		Expect(err).To(MatchError(domain.ErrDeploymentNotFound))
+		Expect(infra.calls).To(BeEmpty())
+	})
+
+	It("should refuse to roll back a missing application", func() {
+		infra.missingApp = true
+
+		Expect(service.Rollback(context.Background(), "my-app", "v1")).To(MatchError(domain.ErrApplicationNotFound))
+		Expect(infra.calls).To(BeEmpty())
+	})
+})
+
+var _ = DescribeTable("ValidateBuildDir",
+	func(buildDir string, valid bool) {
+		err := domain.ValidateBuildDir(buildDir)
+		if valid {
+			Expect(err).NotTo(HaveOccurred())
+		} else {
+			Expect(err).To(MatchError(domain.ErrInvalidBuildDir))
+		}
+	},
+	Entry("nested directory", "apps/web", true),
+	Entry("trailing slash", "apps/web/", true),
+	Entry("absolute path", "/apps/web", false),
+	Entry("parent directory", "..", false),
+	Entry("parent directory in the middle", "apps/../secrets", false),
+	Entry("repository root", ".", false),
+	Entry("space", "apps/my web", false),
+)
+
+var _ = DescribeTable("SourceArchiveURL",
+	func(repoURL, gitRef, want string) {
+		archiveURL, ok := domain.SourceArchiveURL(repoURL, gitRef)
+		Expect(ok).To(Equal(want != ""))
+		Expect(archiveURL).To(Equal(want))
+	},
+	Entry("GitHub over HTTPS", "https://github.com/acme/app.git", "v1", "https://github.com/acme/app/archive/v1.tar.gz"),
+	Entry("GitHub over SSH", "git@github.com:acme/app.git", "abc123", "https://github.com/acme/app/archive/abc123.tar.gz"),
+	Entry("GitLab subgroup", "https://gitlab.com/acme/team/app", "main", "https://gitlab.com/acme/team/app/-/archive/main/app-main.tar.gz"),
+	Entry("Bitbucket", "https://bitbucket.org/acme/app.git", "v1", "https://bitbucket.org/acme/app/get/v1.tar.gz"),
+	Entry("self-hosted forge", "https://git.example.com/acme/app.git", "v1", ""),
+	Entry("missing owner", "https://github.com/app.git", "v1", ""),
+)
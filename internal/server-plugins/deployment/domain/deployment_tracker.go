@@ -10,8 +10,11 @@ import (
 // DeploymentTracker manages in-memory state of active deployments
 type DeploymentTracker struct {
 	deployments map[string]*TrackedDeployment
-	mu          sync.RWMutex
-	cleanupTTL  time.Duration
+	// failures keeps the latest failed deployment of each app, past the cleanup TTL
+	failures        map[string]*TrackedDeployment
+	mu              sync.RWMutex
+	cleanupTTL      time.Duration
+	maxBuildLogSize int
 }
 
 // TrackedDeployment represents a deployment being tracked
@@ -20,13 +23,43 @@ type TrackedDeployment struct {
 	StartedAt   time.Time
 	LastChecked time.Time
 	mu          sync.RWMutex
+	// done is closed once the deployment reaches a final status
+	done     chan struct{}
+	doneOnce sync.Once
+}
+
+// markDone closes done when the deployment is completed; tracked.mu must be held
+func (tracked *TrackedDeployment) markDone() {
+	if tracked.Deployment.IsCompleted() {
+		tracked.doneOnce.Do(func() { close(tracked.done) })
+	}
 }
 
-// NewDeploymentTracker creates a new deployment tracker
+// FailedDeployLogs is the build output retained for an app's last failed deployment
+type FailedDeployLogs struct {
+	DeploymentID string     `json:"deployment_id"`
+	AppName      string     `json:"app_name"`
+	GitRef       string     `json:"git_ref"`
+	Reason       string     `json:"reason"`
+	FailedAt     *time.Time `json:"failed_at,omitempty"`
+	Logs         string     `json:"logs"`
+	// Truncated is true when the start of the logs was dropped to respect the size limit
+	Truncated bool `json:"truncated"`
+}
+
+// NewDeploymentTracker creates a new deployment tracker with no build log size limit
 func NewDeploymentTracker() *DeploymentTracker {
+	return NewDeploymentTrackerWithLogLimit(0)
+}
+
+// NewDeploymentTrackerWithLogLimit creates a deployment tracker keeping at most
+// maxBuildLogSize bytes of build logs per deployment (0 means no limit)
+func NewDeploymentTrackerWithLogLimit(maxBuildLogSize int) *DeploymentTracker {
 	tracker := &DeploymentTracker{
-		deployments: make(map[string]*TrackedDeployment),
-		cleanupTTL:  5 * time.Minute, // Clean up completed deployments after 5 minutes
+		deployments:     make(map[string]*TrackedDeployment),
+		failures:        make(map[string]*TrackedDeployment),
+		cleanupTTL:      5 * time.Minute, // Clean up completed deployments after 5 minutes
+		maxBuildLogSize: maxBuildLogSize,
 	}
 
 	// Start cleanup goroutine
@@ -45,7 +78,9 @@ func (dt *DeploymentTracker) Track(deployment *Deployment) error {
 		Deployment:  deployment,
 		StartedAt:   time.Now(),
 		LastChecked: time.Now(),
+		done:        make(chan struct{}),
 	}
+	tracked.markDone()
 
 	dt.mu.Lock()
 	dt.deployments[deployment.ID()] = tracked
@@ -81,8 +116,6 @@ func (dt *DeploymentTracker) UpdateStatus(deploymentID string, status Deployment
 	}
 
 	tracked.mu.Lock()
-	defer tracked.mu.Unlock()
-
 	tracked.LastChecked = time.Now()
 
 	switch status {
@@ -95,10 +128,68 @@ func (dt *DeploymentTracker) UpdateStatus(deploymentID string, status Deployment
 	case DeploymentStatusFailed:
 		tracked.Deployment.Fail(errorMsg)
 	}
+	tracked.markDone()
+	appName := tracked.Deployment.AppName()
+	tracked.mu.Unlock()
+
+	// Recorded outside tracked.mu: other methods lock dt.mu before tracked.mu
+	if status == DeploymentStatusFailed {
+		dt.mu.Lock()
+		dt.failures[appName] = tracked
+		dt.mu.Unlock()
+	}
 
 	return nil
 }
 
+// WaitForCompletion waits until a tracked deployment reaches a final status
+// (succeeded or failed) and returns it, or gives up with the error of ctx
+func (dt *DeploymentTracker) WaitForCompletion(ctx context.Context, deploymentID string) (*Deployment, error) {
+	dt.mu.RLock()
+	tracked, exists := dt.deployments[deploymentID]
+	dt.mu.RUnlock()
+
+	if !exists {
+		return nil, ErrDeploymentNotFound
+	}
+
+	select {
+	case <-tracked.done:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	tracked.mu.RLock()
+	defer tracked.mu.RUnlock()
+	return tracked.Deployment, nil
+}
+
+// LastFailure returns the build output of the app's most recent failed deployment.
+// Logs added after the failure (e.g. fetched by the poller) are included.
+func (dt *DeploymentTracker) LastFailure(appName string) (*FailedDeployLogs, error) {
+	dt.mu.RLock()
+	tracked, exists := dt.failures[appName]
+	dt.mu.RUnlock()
+
+	if !exists {
+		return nil, ErrNoFailedDeployment
+	}
+
+	tracked.mu.RLock()
+	defer tracked.mu.RUnlock()
+
+	deployment := tracked.Deployment
+	return &FailedDeployLogs{
+		DeploymentID: deployment.ID(),
+		AppName:      deployment.AppName(),
+		GitRef:       deployment.GitRef(),
+		Reason:       deployment.ErrorMsg(),
+		FailedAt:     deployment.CompletedAt(),
+		Logs:         deployment.BuildLogs(),
+		Truncated:    deployment.BuildLogsTruncated(),
+	}, nil
+}
+
 // AddLogs appends logs to a tracked deployment
 func (dt *DeploymentTracker) AddLogs(deploymentID string, logs string) error {
 	dt.mu.RLock()
@@ -112,7 +203,24 @@ func (dt *DeploymentTracker) AddLogs(deploymentID string, logs string) error {
 	tracked.mu.Lock()
 	defer tracked.mu.Unlock()
 
-	tracked.Deployment.AddBuildLogs(logs)
+	tracked.Deployment.AddBuildLogsCapped(logs, dt.maxBuildLogSize)
+	return nil
+}
+
+// AddWarning records a non-fatal warning on a tracked deployment
+func (dt *DeploymentTracker) AddWarning(deploymentID string, warning string) error {
+	dt.mu.RLock()
+	tracked, exists := dt.deployments[deploymentID]
+	dt.mu.RUnlock()
+
+	if !exists {
+		return ErrDeploymentNotFound
+	}
+
+	tracked.mu.Lock()
+	defer tracked.mu.Unlock()
+
+	tracked.Deployment.AddWarning(warning)
 	return nil
 }
 
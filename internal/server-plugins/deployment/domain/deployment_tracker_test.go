@@ -1,6 +1,7 @@
 package domain_test
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -112,6 +113,29 @@ var _ = Describe("DeploymentTracker", func() {
 		})
 	})
 
+	Describe("WaitForCompletion", func() {
+		It("should return once the deployment reaches a final status", func() {
+			deployment, _ := domain.NewDeployment("test-app", "main")
+			deployment.Start()
+			_ = tracker.Track(deployment)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			defer cancel()
+			_, err := tracker.WaitForCompletion(ctx, deployment.ID())
+			Expect(err).To(MatchError(context.DeadlineExceeded))
+
+			go func() { _ = tracker.UpdateStatus(deployment.ID(), domain.DeploymentStatusFailed, "build failed") }()
+			finished, err := tracker.WaitForCompletion(context.Background(), deployment.ID())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(finished.Status()).To(Equal(domain.DeploymentStatusFailed))
+		})
+
+		It("should return error for non-existent deployment", func() {
+			_, err := tracker.WaitForCompletion(context.Background(), "non-existent")
+			Expect(err).To(Equal(domain.ErrDeploymentNotFound))
+		})
+	})
+
 	Describe("AddLogs", func() {
 		It("should append logs to deployment", func() {
 			deployment, _ := domain.NewDeployment("test-app", "main")
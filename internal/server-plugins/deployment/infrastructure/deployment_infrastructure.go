@@ -2,6 +2,7 @@ package dokku
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"sort"
@@ -11,6 +12,7 @@ import (
 
 	dokku_client "github.com/dokku-mcp/dokku-mcp/internal/dokku-api"
 	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/deployment/domain"
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
 )
 
 // deploymentInfrastructure implements the simplified DeploymentInfrastructure interface
@@ -35,15 +37,26 @@ func NewDeploymentInfrastructure(
 	tracker *domain.DeploymentTracker,
 	poller *domain.DeploymentPoller,
 ) domain.DeploymentInfrastructure {
-	return &deploymentInfrastructure{
+	infra := &deploymentInfrastructure{
 		client:            client,
 		logger:            logger,
 		tracker:           tracker,
 		poller:            poller,
 		activeDeployments: make(map[string]bool),
 	}
+	// The Procfile formation is only known once the rebuild has finished
+	if poller != nil {
+		poller.SetPostDeploymentCheck(infra.validateFormation)
+	}
+	return infra
 }
 
+// gitSyncTimeout bounds a git:sync, which clones or fetches the whole repository
+const gitSyncTimeout = 5 * time.Minute
+
+// archiveDeployTimeout bounds a git:from-archive, which downloads the archive then builds and deploys it
+const archiveDeployTimeout = 30 * time.Minute
+
 // executeCommand wraps the client's ExecuteCommand with deployment-specific context and validation
 func (s *deploymentInfrastructure) executeCommand(ctx context.Context, command domain.DeploymentCommand, args []string) ([]byte, error) {
 	if !command.IsValid() {
@@ -53,6 +66,17 @@ func (s *deploymentInfrastructure) executeCommand(ctx context.Context, command d
 	return s.client.ExecuteCommand(ctx, command.String(), args)
 }
 
+// ApplicationExists checks that the application exists in Dokku - INFRASTRUCTURE ONLY
+func (s *deploymentInfrastructure) ApplicationExists(ctx context.Context, appName string) (bool, error) {
+	if _, err := s.executeCommand(ctx, domain.CommandAppsExists, []string{appName}); err != nil {
+		if errors.Is(err, dokku_client.ErrSSHUnreachable) {
+			return false, err
+		}
+		return false, nil
+	}
+	return true, nil
+}
+
 // SetBuildpack sets buildpack for application in Dokku - INFRASTRUCTURE ONLY
 func (s *deploymentInfrastructure) SetBuildpack(ctx context.Context, appName string, buildpack string) error {
 	_, err := s.executeCommand(ctx, domain.CommandBuildpacksSet, []string{appName, buildpack})
@@ -62,6 +86,15 @@ func (s *deploymentInfrastructure) SetBuildpack(ctx context.Context, appName str
 	return nil
 }
 
+// SetBuildDir sets the repository subdirectory Dokku builds from - INFRASTRUCTURE ONLY
+func (s *deploymentInfrastructure) SetBuildDir(ctx context.Context, appName string, buildDir string) error {
+	_, err := s.executeCommand(ctx, domain.CommandGitSet, []string{appName, "build-dir", buildDir})
+	if err != nil {
+		return fmt.Errorf("failed to set build directory in Dokku: %w", err)
+	}
+	return nil
+}
+
 // PerformGitDeploy executes git deployment in Dokku - INFRASTRUCTURE ONLY
 func (s *deploymentInfrastructure) PerformGitDeploy(ctx context.Context, deploymentID, appName, repoURL, gitRef string) error {
 	s.logger.Debug("Performing git deployment",
@@ -70,49 +103,168 @@ func (s *deploymentInfrastructure) PerformGitDeploy(ctx context.Context, deploym
 		"repo_url", repoURL,
 		"git_ref", gitRef)
 
-	// Check for concurrent deployment
+	release, err := s.lockDeployment(deploymentID, appName)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	// Only the build commands stream to the caller's sink, never the other commands
+	// run under ctx
+	sink := shared.DeployOutputFromContext(ctx)
+	syncCtx := ctx
+	if sink != nil {
+		syncCtx = dokku_client.WithOutputSink(ctx, sink)
+	}
+
+	// Perform git sync. Fetching a large repository over a slow network easily
+	// outlasts the default client timeout, so the command asks for a longer one.
+	_, err = s.client.ExecuteStructured(syncCtx, dokku_client.CommandSpec{
+		Command:      domain.CommandGitSync.String(),
+		Args:         []string{appName, repoURL, gitRef},
+		OutputFormat: dokku_client.OutputFormatRaw,
+		Timeout:      gitSyncTimeout,
+	})
+	if err != nil {
+		s.recordFailureOutput(deploymentID, err)
+		// Git errors are checked first: an authentication failure also ends with
+		// "the remote end hung up unexpectedly" and must not be retried
+		if gitErr := classifyGitSyncFailure(err); gitErr != nil {
+			return fmt.Errorf("git sync failed: %w", gitErr)
+		}
+		if isTransientSyncFailure(err) {
+			return fmt.Errorf("git sync failed: %w: %w", domain.ErrTransientSyncFailure, err)
+		}
+		return fmt.Errorf("git sync failed: %w", err)
+	}
+
+	s.logger.Debug("Git sync completed, triggering async rebuild",
+		"app_name", appName,
+		"deployment_id", deploymentID)
+
+	// Trigger async rebuild with tracking
+	s.performAsyncRebuild(deploymentID, appName, gitRef, sink)
+
+	return nil
+}
+
+// lockDeployment prevents concurrent deployments of the same app; the returned
+// function releases the lock
+func (s *deploymentInfrastructure) lockDeployment(deploymentID, appName string) (func(), error) {
 	s.deploymentMutex.Lock()
+	defer s.deploymentMutex.Unlock()
 	if s.activeDeployments[appName] {
-		s.deploymentMutex.Unlock()
-		return fmt.Errorf("deployment already in progress for application %s", appName)
+		return nil, fmt.Errorf("deployment already in progress for application %s", appName)
 	}
 	s.activeDeployments[appName] = true
-	s.deploymentMutex.Unlock()
 
-	// Ensure cleanup on exit
-	defer func() {
+	return func() {
 		s.deploymentMutex.Lock()
 		delete(s.activeDeployments, appName)
 		s.deploymentMutex.Unlock()
 		s.logger.Debug("Deployment lock released", "app_name", appName, "deployment_id", deploymentID)
-	}()
+	}, nil
+}
 
-	// Perform git sync. Some environments may need a slightly longer timeout
-	// than the default client timeout due to network and repository size.
-	gitSyncCtx := ctx
-	if _, ok := ctx.Deadline(); !ok {
-		// Provide a modest 2 minute timeout for git:sync when caller didn't set one
-		var cancel context.CancelFunc
-		gitSyncCtx, cancel = context.WithTimeout(ctx, 2*time.Minute)
-		defer cancel()
-	}
-	_, err := s.executeCommand(gitSyncCtx, domain.CommandGitSync, []string{appName, repoURL, gitRef})
+// PerformArchiveDeploy imports the app's code from a source archive with git:from-archive,
+// which builds and deploys it, and returns once the deploy is over - INFRASTRUCTURE ONLY
+func (s *deploymentInfrastructure) PerformArchiveDeploy(ctx context.Context, deploymentID, appName, archiveURL string) error {
+	s.logger.Debug("Performing archive deployment",
+		"deployment_id", deploymentID,
+		"app_name", appName,
+		"archive_url", archiveURL)
+
+	release, err := s.lockDeployment(deploymentID, appName)
 	if err != nil {
-		return fmt.Errorf("git sync failed: %w", err)
+		return err
 	}
+	defer release()
 
-	s.logger.Debug("Git sync completed, triggering async rebuild",
-		"app_name", appName,
-		"deployment_id", deploymentID)
+	deployCtx := ctx
+	if sink := shared.DeployOutputFromContext(ctx); sink != nil {
+		deployCtx = dokku_client.WithOutputSink(ctx, sink)
+	}
 
-	// Trigger async rebuild with tracking
-	s.performAsyncRebuild(deploymentID, appName, gitRef)
+	_, err = s.client.ExecuteStructured(deployCtx, dokku_client.CommandSpec{
+		Command:      domain.CommandGitFromArchive.String(),
+		Args:         []string{"--archive-type=tar.gz", appName, archiveURL},
+		OutputFormat: dokku_client.OutputFormatRaw,
+		Timeout:      archiveDeployTimeout,
+	})
+	if err != nil {
+		s.recordFailureOutput(deploymentID, err)
+		return fmt.Errorf("archive deploy failed: %w", err)
+	}
 
+	s.validateFormation(ctx, deploymentID, appName)
 	return nil
 }
 
-// performAsyncRebuild performs the rebuild operation with proper tracking
-func (s *deploymentInfrastructure) performAsyncRebuild(deploymentID, appName, gitRef string) {
+// DeploySourceRepository returns the remote of the app's last git:sync as recorded by
+// Dokku in apps:report, or "" when the app was deployed another way - INFRASTRUCTURE ONLY
+func (s *deploymentInfrastructure) DeploySourceRepository(ctx context.Context, appName string) (string, error) {
+	output, err := s.executeCommand(ctx, domain.CommandAppsReport, []string{appName})
+	if err != nil {
+		return "", fmt.Errorf("failed to read apps report: %w", err)
+	}
+	return parseGitSyncRemote(string(output)), nil
+}
+
+// parseGitSyncRemote extracts the remote from the "<remote>#<ref>" deploy source
+// metadata that git:sync records in apps:report - INFRASTRUCTURE PARSING
+func parseGitSyncRemote(report string) string {
+	fields := dokku_client.ParseKeyValueOutput(report, ":")
+	if fields["App deploy source"] != "git-sync" {
+		return ""
+	}
+	remote, _, _ := strings.Cut(fields["App deploy source metadata"], "#")
+	return remote
+}
+
+// validateFormation reads ps:report and records post-deployment warnings on the tracked deployment.
+// Failures to read the report never fail the deployment.
+func (s *deploymentInfrastructure) validateFormation(ctx context.Context, deploymentID, appName string) {
+	output, err := s.executeCommand(ctx, domain.CommandPsReport, []string{appName})
+	if err != nil {
+		s.logger.Debug("Skipping post-deployment formation check",
+			"deployment_id", deploymentID,
+			"app_name", appName,
+			"error", err)
+		return
+	}
+
+	formation := parseProcessFormation(string(output))
+	for _, warning := range domain.PostDeploymentValidation(appName, formation) {
+		s.logger.Warn("Post-deployment validation warning",
+			"deployment_id", deploymentID,
+			"app_name", appName,
+			"warning", warning)
+		if s.tracker != nil {
+			_ = s.tracker.AddWarning(deploymentID, warning)
+		}
+	}
+}
+
+// parseProcessFormation extracts process types from ps:report "Status <type> <n>:" lines - INFRASTRUCTURE PARSING
+func parseProcessFormation(report string) domain.ProcessFormation {
+	formation := make(domain.ProcessFormation)
+	for _, line := range strings.Split(report, "\n") {
+		key, _, found := strings.Cut(strings.TrimSpace(line), ":")
+		if !found {
+			continue
+		}
+		fields := strings.Fields(key)
+		if len(fields) != 3 || fields[0] != "Status" {
+			continue
+		}
+		formation[fields[1]]++
+	}
+	return formation
+}
+
+// performAsyncRebuild performs the rebuild operation with proper tracking; the build
+// output goes to sink when it is not nil
+func (s *deploymentInfrastructure) performAsyncRebuild(deploymentID, appName, gitRef string, sink func(line []byte)) {
 	s.logger.Info("Starting tracked async rebuild",
 		"deployment_id", deploymentID,
 		"app_name", appName,
@@ -127,6 +279,9 @@ func (s *deploymentInfrastructure) performAsyncRebuild(deploymentID, appName, gi
 	go func() {
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
 		defer cancel()
+		if sink != nil {
+			ctx = dokku_client.WithOutputSink(ctx, sink)
+		}
 
 		s.logger.Debug("Executing ps:rebuild command", "deployment_id", deploymentID, "app_name", appName)
 
@@ -134,7 +289,14 @@ func (s *deploymentInfrastructure) performAsyncRebuild(deploymentID, appName, gi
 
 		// SSH timeout is expected - the poller will track actual status
 		if err != nil {
-			if dokku_client.IsNotFoundError(err) {
+			if dokku_client.IsNotDeployedError(err) {
+				s.logger.Warn("Rebuild command skipped (app never deployed)",
+					"deployment_id", deploymentID,
+					"app_name", appName)
+				if s.tracker != nil {
+					_ = s.tracker.UpdateStatus(deploymentID, domain.DeploymentStatusFailed, "application has not been deployed yet; deploy it from a git source first")
+				}
+			} else if dokku_client.IsNotFoundError(err) {
 				s.logger.Warn("Rebuild command skipped (app missing)",
 					"deployment_id", deploymentID,
 					"app_name", appName)
@@ -166,6 +328,7 @@ func (s *deploymentInfrastructure) performAsyncRebuild(deploymentID, appName, gi
 						"error", err)
 
 					// Update tracker with error
+					s.recordFailureOutput(deploymentID, err)
 					if s.tracker != nil {
 						_ = s.tracker.UpdateStatus(deploymentID, domain.DeploymentStatusFailed, err.Error())
 					}
@@ -175,6 +338,90 @@ func (s *deploymentInfrastructure) performAsyncRebuild(deploymentID, appName, gi
 	}()
 }
 
+// transientSyncMarkers are git and network messages of a failed fetch that usually succeeds when retried
+var transientSyncMarkers = []string{
+	"could not resolve host",
+	"connection reset",
+	"connection timed out",
+	"operation timed out",
+	"early eof",
+	"the remote end hung up unexpectedly",
+	"rpc failed",
+	"temporary failure in name resolution",
+	"tls handshake timeout",
+}
+
+// isTransientSyncFailure classifies a git:sync failure caused by the network
+// (SSH to Dokku or Dokku to the git remote), as opposed to a bad repository or ref
+func isTransientSyncFailure(err error) bool {
+	if errors.Is(err, dokku_client.ErrSSHUnreachable) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	output, ok := dokku_client.CommandOutput(err)
+	if !ok {
+		return false
+	}
+	lower := strings.ToLower(output)
+	for _, marker := range transientSyncMarkers {
+		if strings.Contains(lower, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// gitSyncFailureMarkers maps git messages of a failed git:sync to the failure they reveal
+var gitSyncFailureMarkers = []struct {
+	marker string
+	kind   error
+}{
+	{"authentication failed", shared.ErrGitAuthenticationFailed},
+	{"could not read username", shared.ErrGitAuthenticationFailed},
+	{"could not read password", shared.ErrGitAuthenticationFailed},
+	{"terminal prompts disabled", shared.ErrGitAuthenticationFailed},
+	{"permission denied (publickey", shared.ErrGitAuthenticationFailed},
+	{"host key verification failed", shared.ErrGitAuthenticationFailed},
+	{"the requested url returned error: 401", shared.ErrGitAuthenticationFailed},
+	{"the requested url returned error: 403", shared.ErrGitAuthenticationFailed},
+	{"repository not found", shared.ErrGitRepositoryNotFound},
+	{"does not appear to be a git repository", shared.ErrGitRepositoryNotFound},
+	{"the requested url returned error: 404", shared.ErrGitRepositoryNotFound},
+	{"couldn't find remote ref", shared.ErrGitRefNotFound},
+	{"not found in upstream origin", shared.ErrGitRefNotFound},
+	{"did not match any file(s) known to git", shared.ErrGitRefNotFound},
+	{"unknown revision", shared.ErrGitRefNotFound},
+	{"invalid reference", shared.ErrGitRefNotFound},
+}
+
+// classifyGitSyncFailure recognizes the git failures of a git:sync that retrying
+// cannot fix (authentication, missing repository, missing ref) from its output.
+// It returns nil for any other failure.
+func classifyGitSyncFailure(err error) *shared.GitSyncError {
+	output, ok := dokku_client.CommandOutput(err)
+	if !ok {
+		return nil
+	}
+	lines := strings.Split(output, "\n")
+	for _, failure := range gitSyncFailureMarkers {
+		for _, line := range lines {
+			if strings.Contains(strings.ToLower(line), failure.marker) {
+				return &shared.GitSyncError{Kind: failure.kind, Detail: strings.TrimSpace(line), Err: err}
+			}
+		}
+	}
+	return nil
+}
+
+// recordFailureOutput keeps the output of a failed Dokku command as the deployment's build logs
+func (s *deploymentInfrastructure) recordFailureOutput(deploymentID string, err error) {
+	if s.tracker == nil {
+		return
+	}
+	if output, ok := dokku_client.CommandOutput(err); ok {
+		_ = s.tracker.AddLogs(deploymentID, output)
+	}
+}
+
 // ParseDeploymentHistory retrieves deployment history from Dokku - INFRASTRUCTURE ONLY
 func (s *deploymentInfrastructure) ParseDeploymentHistory(ctx context.Context, appName string) ([]*domain.Deployment, error) {
 	// Get events from Dokku
@@ -194,49 +441,261 @@ func (s *deploymentInfrastructure) ParseDeploymentHistory(ctx context.Context, a
 	return deployments, nil
 }
 
-// parseEventsOutput parses Dokku events output to extract deployments - INFRASTRUCTURE PARSING
-func (s *deploymentInfrastructure) parseEventsOutput(eventsOutput, appName string) []*domain.Deployment {
-	lines := strings.Split(eventsOutput, "\n")
-	var deployments []*domain.Deployment
+// deployStartTriggers mark the start of a deploy attempt in the Dokku event log
+var deployStartTriggers = []string{"receive-app", "pre-build"}
+
+// GetLastDeployStatus reports the outcome of the most recent deploy attempt - INFRASTRUCTURE ONLY
+// It prefers the Dokku event log and falls back to ps:report when no deploy events are recorded.
+// An empty status means the app has never been deployed.
+func (s *deploymentInfrastructure) GetLastDeployStatus(ctx context.Context, appName string) (domain.DeploymentStatus, error) {
+	s.deploymentMutex.Lock()
+	active := s.activeDeployments[appName]
+	s.deploymentMutex.Unlock()
+	if active {
+		return domain.DeploymentStatusRunning, nil
+	}
+
+	eventsOutput, err := s.executeCommand(ctx, domain.CommandEvents, []string{appName})
+	if err == nil {
+		if status, ok := parseLastDeployStatus(string(eventsOutput), appName); ok {
+			return status, nil
+		}
+	} else {
+		s.logger.Debug("Dokku events unavailable, falling back to ps:report", "app_name", appName, "error", err)
+	}
+
+	psOutput, err := s.executeCommand(ctx, domain.CommandPsReport, []string{appName})
+	if err != nil {
+		return "", fmt.Errorf("failed to read process report: %w", err)
+	}
+	if deployed, ok := dokku_client.ParseKeyValueOutput(string(psOutput), ":")["Deployed"]; ok && deployed == "true" {
+		return domain.DeploymentStatusSucceeded, nil
+	}
+	return "", nil
+}
 
-	for _, line := range lines {
+// DeploymentEvents reads the deploy starts, successes and failures of every app from the Dokku event log - INFRASTRUCTURE ONLY
+func (s *deploymentInfrastructure) DeploymentEvents(ctx context.Context) ([]*domain.DeploymentEvent, error) {
+	eventsOutput, err := s.executeCommand(ctx, domain.CommandEvents, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get events from Dokku: %w", err)
+	}
+	return s.parseDeploymentEvents(string(eventsOutput)), nil
+}
+
+// parseDeploymentEvents turns the deploy trigger lines of the event log into deployment events, oldest first
+func (s *deploymentInfrastructure) parseDeploymentEvents(eventsOutput string) []*domain.DeploymentEvent {
+	var events []*domain.DeploymentEvent
+
+	for _, line := range strings.Split(eventsOutput, "\n") {
 		line = strings.TrimSpace(line)
-		if line == "" || !strings.Contains(line, "deploy") {
+		kind, ok := deployEventKind(strings.ToLower(line))
+		if !ok {
+			continue
+		}
+		appName := eventAppName(line)
+		if appName == "" {
 			continue
 		}
 
-		deployment := s.parseEventLine(line, appName)
-		if deployment != nil {
-			deployments = append(deployments, deployment)
+		occurredAt, ok := parseEventTime(line, time.Now())
+		if !ok {
+			occurredAt = time.Now()
 		}
+		events = append(events, domain.NewDeploymentEvent(appName, eventGitRef(line), kind, line, occurredAt))
 	}
 
-	return deployments
+	return events
+}
+
+// deployEventKind tells which stage of a deploy an event log line reports, the
+// same way parseLastDeployStatus reads it
+func deployEventKind(lowerLine string) (domain.DeploymentEventKind, bool) {
+	switch {
+	case strings.Contains(lowerLine, "post-deploy"):
+		return domain.DeploymentEventSucceeded, true
+	case strings.Contains(lowerLine, "fail"):
+		return domain.DeploymentEventFailed, true
+	case containsAny(lowerLine, deployStartTriggers):
+		return domain.DeploymentEventStarted, true
+	default:
+		return "", false
+	}
+}
+
+// parseLastDeployStatus scans the event log for the app's latest deploy attempt. An attempt
+// starts with a build trigger and succeeds with post-deploy; a failure line or a missing
+// post-deploy (while events keep flowing) leaves it failed or still running.
+func parseLastDeployStatus(eventsOutput, appName string) (domain.DeploymentStatus, bool) {
+	var status domain.DeploymentStatus
+	found := false
+
+	for _, line := range strings.Split(eventsOutput, "\n") {
+		lower := strings.ToLower(strings.TrimSpace(line))
+		if lower == "" || !eventMentionsApp(lower, strings.ToLower(appName)) {
+			continue
+		}
+
+		switch {
+		case strings.Contains(lower, "post-deploy"):
+			status, found = domain.DeploymentStatusSucceeded, true
+		case strings.Contains(lower, "fail"):
+			if found && status == domain.DeploymentStatusRunning {
+				status = domain.DeploymentStatusFailed
+			}
+		case containsAny(lower, deployStartTriggers):
+			status, found = domain.DeploymentStatusRunning, true
+		}
+	}
+
+	return status, found
 }
 
-// parseEventLine parses a single event line to create deployment - INFRASTRUCTURE PARSING
-func (s *deploymentInfrastructure) parseEventLine(line, appName string) *domain.Deployment {
-	// Extract git ref if available, otherwise use "main"
-	gitRef := "main"
-	parts := strings.Fields(line)
-	for _, part := range parts {
-		if strings.Contains(part, ":") && strings.Contains(part, "git") {
-			gitParts := strings.Split(part, ":")
-			if len(gitParts) > 1 {
-				gitRef = gitParts[1]
+// eventMentionsApp reports whether an event line targets the app. Triggers log as
+// "trigger( app args... )", so the app must be the first argument inside the
+// parentheses: "api" does not match events of "api-staging". Lines without that
+// format fall back to a whole-word match.
+func eventMentionsApp(lowerLine, lowerApp string) bool {
+	if strings.Contains(lowerLine, "(") {
+		return eventAppName(lowerLine) == lowerApp
+	}
+
+	for _, field := range strings.Fields(lowerLine) {
+		if field == lowerApp {
+			return true
+		}
+	}
+	return false
+}
+
+// eventAppName returns the app a trigger line is about: the first argument of "trigger( app args... )"
+func eventAppName(line string) string {
+	if args := eventArgs(line); len(args) > 0 {
+		return args[0]
+	}
+	return ""
+}
+
+// eventArgs returns the arguments logged between the parentheses of a trigger line
+func eventArgs(line string) []string {
+	open := strings.Index(line, "(")
+	if open < 0 {
+		return nil
+	}
+	args := line[open+1:]
+	if end := strings.LastIndex(args, ")"); end >= 0 {
+		args = args[:end]
+	}
+	return strings.Fields(args)
+}
+
+func containsAny(s string, substrings []string) bool {
+	for _, sub := range substrings {
+		if strings.Contains(s, sub) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseEventsOutput groups the app's event log lines into deploy attempts, read the
+// same way as parseLastDeployStatus: an attempt starts with a build trigger and ends
+// with post-deploy (succeeded) or a failure line (failed). An attempt superseded by
+// the next one without an outcome is reported failed; the last one may still be
+// running. - INFRASTRUCTURE PARSING
+func (s *deploymentInfrastructure) parseEventsOutput(eventsOutput, appName string) []*domain.Deployment {
+	lowerApp := strings.ToLower(appName)
+	now := time.Now()
+	var deployments []*domain.Deployment
+	var current *domain.Deployment
+	built := false
+
+	for _, line := range strings.Split(eventsOutput, "\n") {
+		line = strings.TrimSpace(line)
+		lower := strings.ToLower(line)
+		if line == "" || !eventMentionsApp(lower, lowerApp) {
+			continue
+		}
+		kind, ok := deployEventKind(lower)
+		if !ok {
+			continue
+		}
+		at, ok := parseEventTime(line, now)
+		if !ok {
+			at = now
+		}
+
+		// receive-app always opens an attempt, pre-build only when it is not the build of the open one
+		isReceive := strings.Contains(lower, "receive-app")
+		if current != nil && kind == domain.DeploymentEventStarted && (isReceive || built) {
+			current.FailAt("no deploy outcome in the Dokku event log", at)
+			current = nil
+		}
+		if current == nil {
+			current = s.newEventDeployment(appName, eventGitRef(line), at)
+			if current == nil {
+				continue
 			}
+			deployments = append(deployments, current)
+			built = false
+		}
+
+		switch kind {
+		case domain.DeploymentEventStarted:
+			built = built || !isReceive
+		case domain.DeploymentEventSucceeded:
+			current.CompleteAt(at)
+			current = nil
+		case domain.DeploymentEventFailed:
+			current.FailAt(line, at)
+			current = nil
 		}
 	}
 
-	// Create deployment entity
-	deployment, err := domain.NewDeployment(appName, gitRef)
+	return deployments
+}
+
+// newEventDeployment creates the deployment of an attempt read from the event log.
+// Its ID derives from the app and start time so it stays the same across reads
+// and can be passed to rollback_app. - INFRASTRUCTURE PARSING
+func (s *deploymentInfrastructure) newEventDeployment(appName, gitRef string, startedAt time.Time) *domain.Deployment {
+	id := fmt.Sprintf("event_%s_%d", appName, startedAt.Unix())
+	deployment, err := domain.NewDeploymentFromHistory(id, appName, gitRef, startedAt)
 	if err != nil {
 		s.logger.Warn("Failed to create deployment from event", "error", err)
 		return nil
 	}
+	deployment.StartAt(startedAt)
+	return deployment
+}
 
-	// Set complete status
-	deployment.Complete()
+// eventGitRef returns the revision of a "receive-app( app <rev> )" line, the commit
+// Dokku builds; other triggers do not log it. - INFRASTRUCTURE PARSING
+func eventGitRef(line string) string {
+	if !strings.Contains(strings.ToLower(line), "receive-app") {
+		return ""
+	}
+	if args := eventArgs(line); len(args) > 1 {
+		return args[1]
+	}
+	return ""
+}
 
-	return deployment
+// parseEventTime reads the syslog timestamp ("Jul  3 16:09:48") starting an event
+// line. Syslog omits the year: it is the one of now, or the previous one for
+// dates that would be in the future. - INFRASTRUCTURE PARSING
+func parseEventTime(line string, now time.Time) (time.Time, bool) {
+	if len(line) < len(time.Stamp) {
+		return time.Time{}, false
+	}
+	parsed, err := time.ParseInLocation(time.Stamp, line[:len(time.Stamp)], now.Location())
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	at := time.Date(now.Year(), parsed.Month(), parsed.Day(), parsed.Hour(), parsed.Minute(), parsed.Second(), 0, now.Location())
+	if at.After(now.Add(24 * time.Hour)) {
+		at = at.AddDate(-1, 0, 0)
+	}
+	return at, true
 }
@@ -0,0 +1,528 @@
+package dokku
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	dokku_client "github.com/dokku-mcp/dokku-mcp/internal/dokku-api"
+	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/deployment/domain"
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
+)
+
+// scriptedClient returns canned output per command
+type scriptedClient struct {
+	dokku_client.DokkuClient
+	mu      sync.Mutex
+	outputs map[string]string
+	specs   []dokku_client.CommandSpec
+}
+
+func (c *scriptedClient) ExecuteCommand(ctx context.Context, command string, args []string) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return []byte(c.outputs[command]), nil
+}
+
+func (c *scriptedClient) ExecuteStructured(ctx context.Context, spec dokku_client.CommandSpec) (*dokku_client.CommandResult, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.specs = append(c.specs, spec)
+	return &dokku_client.CommandResult{RawOutput: []byte(c.outputs[spec.Command])}, nil
+}
+
+// succeededStatusChecker reports every deployment as succeeded
+type succeededStatusChecker struct{}
+
+func (succeededStatusChecker) CheckStatus(ctx context.Context, appName string) (domain.DeploymentStatus, string, error) {
+	return domain.DeploymentStatusSucceeded, "", nil
+}
+
+func (succeededStatusChecker) GetLogs(ctx context.Context, appName string, lines int) (string, error) {
+	return "", nil
+}
+
+func TestDeployWarnsWhenNoWebProcessOnceRebuilt(t *testing.T) {
+	client := &scriptedClient{outputs: map[string]string{
+		"ps:report": `=====> worker-only ps information
+       Deployed:                      true
+       Processes:                     1
+       Ps computed procfile path:     Procfile
+       Running:                       true
+       Status worker 1:               running (CID: 03ea8977f37)`,
+	}}
+	tracker := domain.NewDeploymentTracker()
+	defer func() { _ = tracker.Shutdown(context.Background()) }()
+
+	deployment, err := domain.NewDeployment("worker-only", "main")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	deployment.Start()
+	if err := tracker.Track(deployment); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	poller := domain.NewDeploymentPoller(tracker, succeededStatusChecker{}, logger, 5*time.Millisecond, time.Second)
+	defer poller.Shutdown()
+	infra := NewDeploymentInfrastructure(client, logger, tracker, poller)
+
+	if err := infra.PerformGitDeploy(context.Background(), deployment.ID(), "worker-only", "https://github.com/acme/app.git", "main"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(client.specs) != 1 || client.specs[0].Command != "git:sync" || client.specs[0].Timeout != gitSyncTimeout {
+		t.Fatalf("expected git:sync to run with its own timeout, got %+v", client.specs)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	finished, err := tracker.WaitForCompletion(ctx, deployment.ID())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	warnings := finished.Warnings()
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "no web process") {
+		t.Fatalf("expected a no web process warning once the deploy succeeded, got %v", warnings)
+	}
+}
+
+func TestParseProcessFormation(t *testing.T) {
+	formation := parseProcessFormation(`       Processes:                     3
+       Status web 1:                  running (CID: a)
+       Status web 2:                  running (CID: b)
+       Status worker 1:               running (CID: c)`)
+
+	if formation["web"] != 2 || formation["worker"] != 1 || len(formation) != 2 {
+		t.Fatalf("unexpected formation: %v", formation)
+	}
+}
+
+func TestGetLastDeployStatusLatestFailed(t *testing.T) {
+	client := &scriptedClient{outputs: map[string]string{
+		"events": `Jul  3 16:09:48 dokku dokku[1201]: INVOKED: receive-app( my-app main )
+Jul  3 16:09:49 dokku dokku[1201]: INVOKED: pre-build( my-app herokuish )
+Jul  3 16:11:02 dokku dokku[1201]: INVOKED: post-deploy( my-app 5000 172.17.0.4 )
+Jul  3 16:09:49 dokku dokku[1388]: INVOKED: receive-app( other-app main )
+Jul  4 09:12:20 dokku dokku[2405]: INVOKED: receive-app( my-app main )
+Jul  4 09:12:21 dokku dokku[2405]: INVOKED: pre-build( my-app herokuish )
+Jul  4 09:13:40 dokku dokku[2405]: INVOKED: deploy-failed( my-app )`,
+		"ps:report": `=====> my-app ps information
+       Deployed:                      true`,
+	}}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	infra := NewDeploymentInfrastructure(client, logger, nil, nil)
+
+	status, err := infra.GetLastDeployStatus(context.Background(), "my-app")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if status != domain.DeploymentStatusFailed {
+		t.Fatalf("expected the latest deploy to be failed, got %q", status)
+	}
+}
+
+func TestParseLastDeployStatus(t *testing.T) {
+	cases := []struct {
+		name   string
+		events string
+		want   domain.DeploymentStatus
+		found  bool
+	}{
+		{name: "succeeded", events: "dokku[1]: INVOKED: pre-build( my-app )\ndokku[1]: INVOKED: post-deploy( my-app 5000 )", want: domain.DeploymentStatusSucceeded, found: true},
+		{name: "in progress", events: "dokku[1]: INVOKED: post-deploy( my-app 5000 )\ndokku[2]: INVOKED: receive-app( my-app main )", want: domain.DeploymentStatusRunning, found: true},
+		{name: "other app only", events: "dokku[1]: INVOKED: post-deploy( my-app-2 5000 )"},
+		{name: "app name as a later argument", events: "dokku[1]: INVOKED: post-deploy( other-app my-app )"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			status, found := parseLastDeployStatus(tc.events, "my-app")
+			if status != tc.want || found != tc.found {
+				t.Fatalf("expected (%q, %v), got (%q, %v)", tc.want, tc.found, status, found)
+			}
+		})
+	}
+}
+
+func TestParseEventsOutputIgnoresAppsSharingAPrefix(t *testing.T) {
+	events := `Jul  3 16:09:48 dokku dokku[1201]: INVOKED: pre-deploy( api )
+Jul  3 16:09:50 dokku dokku[1201]: INVOKED: post-deploy( api 5000 172.17.0.4 )
+Jul  3 16:10:12 dokku dokku[1388]: INVOKED: pre-deploy( api-staging )
+Jul  3 16:10:15 dokku dokku[1388]: INVOKED: post-deploy( api-staging 5000 172.17.0.5 )
+Jul  3 16:10:15 dokku dokku[1388]: INVOKED: post-deploy( api-staging 5000 172.17.0.6 )`
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	infra := &deploymentInfrastructure{logger: logger}
+
+	if got := len(infra.parseEventsOutput(events, "api")); got != 1 {
+		t.Fatalf("expected 1 deployment for api, got %d", got)
+	}
+	if got := len(infra.parseEventsOutput(events, "api-staging")); got != 2 {
+		t.Fatalf("expected 2 deployments for api-staging, got %d", got)
+	}
+}
+
+const deployHistoryEvents = `Jul  3 16:09:48 dokku dokku[1201]: INVOKED: receive-app( my-app 3f2a9c1 )
+Jul  3 16:09:49 dokku dokku[1201]: INVOKED: pre-build( my-app herokuish )
+Jul  3 16:11:02 dokku dokku[1201]: INVOKED: post-deploy( my-app 5000 172.17.0.4 )
+Jul  3 16:09:49 dokku dokku[1388]: INVOKED: receive-app( other-app 77aa001 )
+Jul  4 09:12:20 dokku dokku[2405]: INVOKED: receive-app( my-app 8b7d4e0 )
+Jul  4 09:12:21 dokku dokku[2405]: INVOKED: pre-build( my-app herokuish )
+Jul  4 09:13:40 dokku dokku[2405]: INVOKED: deploy-failed( my-app )`
+
+func TestParseDeploymentHistory(t *testing.T) {
+	client := &scriptedClient{outputs: map[string]string{"events": deployHistoryEvents}}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	infra := NewDeploymentInfrastructure(client, logger, nil, nil)
+
+	history, err := infra.ParseDeploymentHistory(context.Background(), "my-app")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(history) != 2 {
+		t.Fatalf("expected 2 deploy attempts, got %d", len(history))
+	}
+
+	failed, succeeded := history[0], history[1]
+	if failed.GitRef() != "8b7d4e0" || failed.Status() != domain.DeploymentStatusFailed {
+		t.Fatalf("expected the latest attempt to be the failed 8b7d4e0, got %s %s", failed.GitRef(), failed.Status())
+	}
+	if succeeded.GitRef() != "3f2a9c1" || succeeded.Status() != domain.DeploymentStatusSucceeded {
+		t.Fatalf("expected the first attempt to be the succeeded 3f2a9c1, got %s %s", succeeded.GitRef(), succeeded.Status())
+	}
+	if got := succeeded.Duration(); got != 74*time.Second {
+		t.Fatalf("expected the duration between receive-app and post-deploy, got %s", got)
+	}
+
+	again, err := infra.ParseDeploymentHistory(context.Background(), "my-app")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if again[0].ID() != failed.ID() || again[1].ID() != succeeded.ID() {
+		t.Fatalf("expected stable IDs across reads, got %s %s then %s %s", failed.ID(), succeeded.ID(), again[0].ID(), again[1].ID())
+	}
+}
+
+func TestRollbackToACommitOfTheEventLog(t *testing.T) {
+	client := &scriptedClient{outputs: map[string]string{
+		"events": deployHistoryEvents,
+		"apps:report": `=====> my-app app information
+       App deploy source:             git-sync
+       App deploy source metadata:    https://github.com/example/app.git#8b7d4e0`,
+	}}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	infra := NewDeploymentInfrastructure(client, logger, nil, nil)
+	service := domain.NewApplicationDeploymentService(NewDeploymentRepository(logger), infra, nil, logger)
+
+	var notFound *domain.RollbackTargetNotFoundError
+	if err := service.Rollback(context.Background(), "my-app", "8b7d4e0"); !errors.As(err, &notFound) {
+		t.Fatalf("expected the failed commit to be refused as a rollback target, got %v", err)
+	}
+
+	if err := service.Rollback(context.Background(), "my-app", "3f2a9c1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var archives []string
+	for _, spec := range client.specs {
+		if spec.Command == domain.CommandGitFromArchive.String() {
+			archives = append(archives, spec.Args[len(spec.Args)-1])
+		}
+	}
+	if len(archives) != 1 || archives[0] != "https://github.com/example/app/archive/3f2a9c1.tar.gz" {
+		t.Fatalf("expected the archive of 3f2a9c1 to be deployed, got %v", archives)
+	}
+}
+
+func TestDeploymentEvents(t *testing.T) {
+	client := &scriptedClient{outputs: map[string]string{
+		"events": `Jul  4 09:12:20 dokku dokku[2405]: INVOKED: receive-app( my-app main )
+Jul  4 09:12:21 dokku dokku[2405]: INVOKED: pre-build( my-app herokuish )
+Jul  4 09:12:30 dokku dokku[2405]: INVOKED: docker-args-process-build( my-app herokuish )
+Jul  4 09:13:40 dokku dokku[2405]: INVOKED: deploy-failed( my-app )
+Jul  4 09:14:02 dokku dokku[2511]: INVOKED: pre-build( api-staging dockerfile )
+Jul  4 09:15:12 dokku dokku[2511]: INVOKED: post-deploy( api-staging 5000 172.17.0.5 )
+Jul  4 09:15:13 dokku sshd[2512]: Connection closed by 10.0.0.1 port 50022`,
+	}}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	infra := NewDeploymentInfrastructure(client, logger, nil, nil)
+
+	events, err := infra.DeploymentEvents(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{
+		"my-app deployment.started",
+		"my-app deployment.started",
+		"my-app deployment.failed",
+		"api-staging deployment.started",
+		"api-staging deployment.succeeded",
+	}
+	if len(events) != len(want) {
+		t.Fatalf("expected %d deployment events, got %d", len(want), len(events))
+	}
+	for i, event := range events {
+		if got := event.AggregateID() + " " + event.EventType(); got != want[i] {
+			t.Fatalf("expected event %d to be %q, got %q", i, want[i], got)
+		}
+		if !strings.Contains(event.LogLine(), "INVOKED") {
+			t.Fatalf("expected event %d to keep its log line, got %q", i, event.LogLine())
+		}
+	}
+}
+
+func TestIsTransientSyncFailure(t *testing.T) {
+	syncError := func(output string) error {
+		return &dokku_client.CommandError{Command: "git:sync", Output: []byte(output), Err: errors.New("exit status 128")}
+	}
+
+	cases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "host unreachable", err: dokku_client.ErrSSHUnreachable, want: true},
+		{name: "dns failure", err: syncError("fatal: unable to access 'https://github.com/example/app.git/': Could not resolve host: github.com"), want: true},
+		{name: "interrupted fetch", err: syncError("error: RPC failed; curl 56 GnuTLS recv error\nfatal: early EOF"), want: true},
+		{name: "unknown ref", err: syncError("fatal: couldn't find remote ref feature/missing"), want: false},
+		{name: "access denied", err: syncError("fatal: unable to access 'https://github.com/example/private.git/': The requested URL returned error: 403"), want: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := isTransientSyncFailure(tc.err); got != tc.want {
+				t.Fatalf("isTransientSyncFailure() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestClassifyGitSyncFailure(t *testing.T) {
+	syncError := func(output string) error {
+		return &dokku_client.CommandError{Command: "git:sync", Output: []byte(output), Err: errors.New("exit status 128")}
+	}
+
+	cases := []struct {
+		name   string
+		output string
+		want   error
+		detail string
+	}{
+		{
+			name:   "https credentials rejected",
+			output: "-----> Syncing https://github.com/acme/private.git:main\nremote: Invalid username or password.\nfatal: Authentication failed for 'https://github.com/acme/private.git/'",
+			want:   shared.ErrGitAuthenticationFailed,
+			detail: "fatal: Authentication failed for 'https://github.com/acme/private.git/'",
+		},
+		{
+			name:   "https credentials missing",
+			output: "fatal: could not read Username for 'https://gitlab.com': No such device or address",
+			want:   shared.ErrGitAuthenticationFailed,
+		},
+		{
+			name:   "ssh deploy key missing",
+			output: "git@github.com: Permission denied (publickey).\nfatal: Could not read from remote repository.\n\nPlease make sure you have the correct access rights\nand the repository exists.",
+			want:   shared.ErrGitAuthenticationFailed,
+			detail: "git@github.com: Permission denied (publickey).",
+		},
+		{
+			name:   "unknown host key",
+			output: "Host key verification failed.\nfatal: Could not read from remote repository.",
+			want:   shared.ErrGitAuthenticationFailed,
+		},
+		{
+			name:   "missing repository",
+			output: "remote: Repository not found.\nfatal: repository 'https://github.com/acme/missing.git/' not found",
+			want:   shared.ErrGitRepositoryNotFound,
+			detail: "remote: Repository not found.",
+		},
+		{
+			name:   "not a repository",
+			output: "fatal: 'acme/app' does not appear to be a git repository\nfatal: Could not read from remote repository.",
+			want:   shared.ErrGitRepositoryNotFound,
+		},
+		{
+			name:   "missing branch",
+			output: "-----> Syncing https://github.com/acme/app.git:feature/missing\nfatal: couldn't find remote ref feature/missing",
+			want:   shared.ErrGitRefNotFound,
+			detail: "fatal: couldn't find remote ref feature/missing",
+		},
+		{
+			name:   "missing branch on clone",
+			output: "Cloning into '/tmp/dokku-sync'...\nwarning: Could not find remote branch v9.9.9 to clone.\nfatal: Remote branch v9.9.9 not found in upstream origin",
+			want:   shared.ErrGitRefNotFound,
+		},
+		{
+			name:   "missing commit",
+			output: "error: pathspec '0123456789abcdef' did not match any file(s) known to git",
+			want:   shared.ErrGitRefNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			gitErr := classifyGitSyncFailure(syncError(tc.output))
+			if gitErr == nil {
+				t.Fatalf("expected a git failure to be recognized")
+			}
+			if !errors.Is(gitErr, tc.want) {
+				t.Fatalf("expected %v, got %v", tc.want, gitErr.Kind)
+			}
+			if tc.detail != "" && gitErr.Detail != tc.detail {
+				t.Fatalf("expected detail %q, got %q", tc.detail, gitErr.Detail)
+			}
+		})
+	}
+
+	t.Run("build failures are not git failures", func(t *testing.T) {
+		if gitErr := classifyGitSyncFailure(syncError("-----> Building app...\nnpm ERR! code ELIFECYCLE\n !     Failure during app build")); gitErr != nil {
+			t.Fatalf("expected no git failure, got %v", gitErr)
+		}
+	})
+}
+
+// failingSyncClient fails git:sync with the given output
+type failingSyncClient struct {
+	dokku_client.DokkuClient
+	output string
+}
+
+func (c *failingSyncClient) ExecuteCommand(ctx context.Context, command string, args []string) ([]byte, error) {
+	return nil, &dokku_client.CommandError{Command: command, Output: []byte(c.output), Err: errors.New("exit status 1")}
+}
+
+func (c *failingSyncClient) ExecuteStructured(ctx context.Context, spec dokku_client.CommandSpec) (*dokku_client.CommandResult, error) {
+	_, err := c.ExecuteCommand(ctx, spec.Command, spec.Args)
+	return nil, err
+}
+
+func TestPerformGitDeployReturnsTypedGitErrors(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	// The hang-up line would classify the failure as transient on its own
+	client := &failingSyncClient{output: "fatal: Authentication failed for 'https://github.com/acme/app.git/'\nfatal: the remote end hung up unexpectedly"}
+	infra := NewDeploymentInfrastructure(client, logger, nil, nil)
+
+	err := infra.PerformGitDeploy(context.Background(), "deploy-1", "api", "https://github.com/acme/app.git", "main")
+	if !errors.Is(err, shared.ErrGitAuthenticationFailed) {
+		t.Fatalf("expected an authentication failure, got %v", err)
+	}
+	if errors.Is(err, domain.ErrTransientSyncFailure) {
+		t.Fatalf("an authentication failure must not be retried, got %v", err)
+	}
+	if output, ok := dokku_client.CommandOutput(err); !ok || !strings.Contains(output, "Authentication failed") {
+		t.Fatalf("expected the command output to stay reachable, got %q", output)
+	}
+}
+
+// sinkRecordingClient reports each command run with an output sink on its context
+type sinkRecordingClient struct {
+	dokku_client.DokkuClient
+	streamed chan string
+}
+
+func (c *sinkRecordingClient) ExecuteCommand(ctx context.Context, command string, args []string) ([]byte, error) {
+	if sink := dokku_client.OutputSinkFromContext(ctx); sink != nil {
+		sink([]byte(command + " output"))
+		c.streamed <- command
+	}
+	return nil, nil
+}
+
+func (c *sinkRecordingClient) ExecuteStructured(ctx context.Context, spec dokku_client.CommandSpec) (*dokku_client.CommandResult, error) {
+	output, err := c.ExecuteCommand(ctx, spec.Command, spec.Args)
+	return &dokku_client.CommandResult{RawOutput: output}, err
+}
+
+func TestPerformGitDeployStreamsOnlyBuildCommands(t *testing.T) {
+	client := &sinkRecordingClient{streamed: make(chan string, 8)}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	infra := NewDeploymentInfrastructure(client, logger, nil, nil)
+
+	var mu sync.Mutex
+	var lines []string
+	ctx := shared.WithDeployOutput(context.Background(), func(line []byte) {
+		mu.Lock()
+		defer mu.Unlock()
+		lines = append(lines, string(line))
+	})
+	if err := infra.PerformGitDeploy(ctx, "deploy-1", "my-app", "https://github.com/acme/app.git", "main"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// ps:rebuild runs in the background once git:sync returned
+	var streamed []string
+	for len(streamed) < 2 {
+		select {
+		case command := <-client.streamed:
+			streamed = append(streamed, command)
+		case <-time.After(2 * time.Second):
+			t.Fatalf("expected git:sync and ps:rebuild to stream, got %v", streamed)
+		}
+	}
+	if streamed[0] != "git:sync" || streamed[1] != "ps:rebuild" {
+		t.Fatalf("expected only git:sync and ps:rebuild to stream, got %v", streamed)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if want := []string{"git:sync output", "ps:rebuild output"}; !slices.Equal(lines, want) {
+		t.Fatalf("expected the build output %q, got %q", want, lines)
+	}
+}
+
+func TestPerformArchiveDeployImportsTheArchive(t *testing.T) {
+	client := &scriptedClient{outputs: map[string]string{}}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	infra := NewDeploymentInfrastructure(client, logger, nil, nil)
+
+	if err := infra.PerformArchiveDeploy(context.Background(), "deploy-1", "my-app", "https://github.com/acme/app/archive/v1.tar.gz"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{"--archive-type=tar.gz", "my-app", "https://github.com/acme/app/archive/v1.tar.gz"}
+	if len(client.specs) != 1 || client.specs[0].Command != "git:from-archive" || !slices.Equal(client.specs[0].Args, want) ||
+		client.specs[0].Timeout != archiveDeployTimeout {
+		t.Fatalf("expected a single git:from-archive with its own timeout, got %+v", client.specs)
+	}
+}
+
+func TestParseGitSyncRemote(t *testing.T) {
+	report := `=====> my-app app information
+       App deploy source:             git-sync
+       App deploy source metadata:    https://github.com/acme/app.git#v2`
+	if remote := parseGitSyncRemote(report); remote != "https://github.com/acme/app.git" {
+		t.Fatalf("expected the git:sync remote, got %q", remote)
+	}
+
+	pushed := `=====> my-app app information
+       App deploy source:             git-hook
+       App deploy source metadata:    5d9a1c2`
+	if remote := parseGitSyncRemote(pushed); remote != "" {
+		t.Fatalf("expected no remote for a pushed app, got %q", remote)
+	}
+}
+
+func TestParseEventTime(t *testing.T) {
+	now := time.Date(2026, time.January, 2, 10, 0, 0, 0, time.UTC)
+
+	at, ok := parseEventTime("Jan  2 09:12:20 dokku dokku[2405]: INVOKED: receive-app( my-app main )", now)
+	if !ok || !at.Equal(time.Date(2026, time.January, 2, 9, 12, 20, 0, time.UTC)) {
+		t.Fatalf("unexpected event time %v", at)
+	}
+
+	at, ok = parseEventTime("Dec 31 23:59:59 dokku dokku[2405]: INVOKED: post-deploy( my-app )", now)
+	if !ok || at.Year() != 2025 {
+		t.Fatalf("expected last year's event, got %v", at)
+	}
+
+	if _, ok := parseEventTime("dokku[1]: INVOKED: post-deploy( my-app 5000 )", now); ok {
+		t.Fatalf("expected a line without timestamp to be rejected")
+	}
+}
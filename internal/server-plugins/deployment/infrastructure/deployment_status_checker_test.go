@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	dokku_client "github.com/dokku-mcp/dokku-mcp/internal/dokku-api"
 	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/deployment/domain"
@@ -16,6 +17,12 @@ func (f *fakeClient) ExecuteCommand(ctx context.Context, command string, args []
 }
 
 // satisfy interfaces used by status checker but not needed for this test
+func (f *fakeClient) ExecuteCommandWithInput(ctx context.Context, command string, args []string, stdin []byte) ([]byte, error) {
+	return nil, nil
+}
+func (f *fakeClient) ExecuteCommandStreaming(ctx context.Context, command string, args []string) (<-chan []byte, error) {
+	return nil, nil
+}
 func (f *fakeClient) GetKeyValueOutput(ctx context.Context, command string, args []string, separator string) (map[string]string, error) {
 	return nil, nil
 }
@@ -38,6 +45,16 @@ func (f *fakeClient) GetCapabilities() *dokku_client.DokkuCapabilities {
 func (f *fakeClient) GetSSHConnectionManager() *dokku_client.SSHConnectionManager { return nil }
 func (f *fakeClient) SetBlacklist(commands []string)                              {}
 func (f *fakeClient) ValidateCommand(command string, args []string) error         { return nil }
+func (f *fakeClient) ConnectionStatus() dokku_client.ConnectionStatus {
+	return dokku_client.ConnectionStatus{}
+}
+func (f *fakeClient) ExecuteBreakGlassCommand(ctx context.Context, command string, args []string, reason string) ([]byte, error) {
+	return nil, nil
+}
+func (f *fakeClient) InvalidateCache()                  {}
+func (f *fakeClient) InvalidateByApp(appName string)    {}
+func (f *fakeClient) InvalidateByCommand(prefix string) {}
+func (f *fakeClient) SetCacheTTL(ttl time.Duration)     {}
 
 func TestStatusCheckerNotFoundReturnsFailed(t *testing.T) {
 	dsc := NewDeploymentStatusChecker(&fakeClient{})
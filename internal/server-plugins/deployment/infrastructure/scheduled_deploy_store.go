@@ -0,0 +1,90 @@
+package dokku
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	deployment "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/deployment/domain"
+)
+
+// DefaultScheduledDeploysFile returns where pending scheduled deploys are kept
+// when no file is configured: dokku-mcp/scheduled-deploys.json in the user config directory
+func DefaultScheduledDeploysFile() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate the user config directory: %w", err)
+	}
+	return filepath.Join(dir, "dokku-mcp", "scheduled-deploys.json"), nil
+}
+
+// fileScheduledDeployStore keeps scheduled deploys in a JSON file
+type fileScheduledDeployStore struct {
+	path  string
+	mutex sync.Mutex
+}
+
+// NewFileScheduledDeployStore creates a store persisting scheduled deploys to path
+func NewFileScheduledDeployStore(path string) deployment.ScheduledDeployStore {
+	return &fileScheduledDeployStore{path: path}
+}
+
+// Load reads the saved schedules; a missing file means there are none
+func (s *fileScheduledDeployStore) Load() ([]*deployment.ScheduledDeploy, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", s.path, err)
+	}
+
+	var schedules []*deployment.ScheduledDeploy
+	if err := json.Unmarshal(data, &schedules); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", s.path, err)
+	}
+	return schedules, nil
+}
+
+// Save replaces the file atomically so a crash never leaves it half written
+func (s *fileScheduledDeployStore) Save(schedules []*deployment.ScheduledDeploy) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if schedules == nil {
+		schedules = []*deployment.ScheduledDeploy{}
+	}
+	data, err := json.MarshalIndent(schedules, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to serialize scheduled deploys: %w", err)
+	}
+
+	dir := filepath.Dir(s.path)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+
+	tmp, err := os.CreateTemp(dir, ".scheduled-deploys-*.json")
+	if err != nil {
+		return fmt.Errorf("failed to write scheduled deploys: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write scheduled deploys: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write scheduled deploys: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("failed to write scheduled deploys: %w", err)
+	}
+	return nil
+}
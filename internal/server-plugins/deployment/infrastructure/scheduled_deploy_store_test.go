@@ -0,0 +1,48 @@
+package dokku
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	deployment "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/deployment/domain"
+)
+
+func TestFileScheduledDeployStoreRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", "scheduled-deploys.json")
+	store := NewFileScheduledDeployStore(path)
+
+	schedules, err := store.Load()
+	if err != nil || len(schedules) != 0 {
+		t.Fatalf("expected no schedules before the first save, got %v (%v)", schedules, err)
+	}
+
+	dueAt := time.Date(2026, 3, 1, 2, 0, 0, 0, time.UTC)
+	if err := store.Save([]*deployment.ScheduledDeploy{{
+		ID:      "sched_1",
+		AppName: "my-app",
+		RepoURL: "https://github.com/acme/app.git",
+		GitRef:  "main",
+		DueAt:   dueAt,
+		Status:  deployment.ScheduledDeployPending,
+	}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("expected the file to be written: %v", err)
+	}
+	if info.Mode().Perm()&0o077 != 0 {
+		t.Fatalf("expected the file to be private, got %v", info.Mode().Perm())
+	}
+
+	schedules, err = NewFileScheduledDeployStore(path).Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(schedules) != 1 || schedules[0].AppName != "my-app" || !schedules[0].DueAt.Equal(dueAt) {
+		t.Fatalf("unexpected schedules %+v", schedules)
+	}
+}
@@ -1,15 +1,19 @@
 package deployment
 
 import (
+	"context"
 	"log/slog"
 	"time"
 
 	dokkuApi "github.com/dokku-mcp/dokku-mcp/internal/dokku-api"
+	"github.com/dokku-mcp/dokku-mcp/internal/server"
 	serverPluginDomain "github.com/dokku-mcp/dokku-mcp/internal/server-plugin/domain"
 	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/deployment/adapter"
 	deploymentDomain "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/deployment/domain"
 	deploymentInfrastructure "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/deployment/infrastructure"
 	"github.com/dokku-mcp/dokku-mcp/internal/shared"
+	"github.com/dokku-mcp/dokku-mcp/pkg/config"
+	"github.com/mark3labs/mcp-go/mcp"
 	"go.uber.org/fx"
 )
 
@@ -23,7 +27,9 @@ var Module = fx.Module("deployment",
 		),
 		// Deployment tracker
 		fx.Annotate(
-			deploymentDomain.NewDeploymentTracker,
+			func(cfg *config.ServerConfig) *deploymentDomain.DeploymentTracker {
+				return deploymentDomain.NewDeploymentTrackerWithLogLimit(cfg.Logs.Build.MaxSizeMB * 1024 * 1024)
+			},
 		),
 		// Deployment status checker
 		fx.Annotate(
@@ -53,9 +59,56 @@ var Module = fx.Module("deployment",
 		),
 		// Deployment service
 		fx.Annotate(
-			deploymentDomain.NewApplicationDeploymentService,
+			func(
+				deploymentRepo deploymentDomain.DeploymentRepository,
+				infrastructure deploymentDomain.DeploymentInfrastructure,
+				tracker *deploymentDomain.DeploymentTracker,
+				logger *slog.Logger,
+				cfg *config.ServerConfig,
+			) *deploymentDomain.ApplicationDeploymentService {
+				service := deploymentDomain.NewApplicationDeploymentService(deploymentRepo, infrastructure, tracker, logger)
+				service.SetSyncRetryDelay(cfg.DeployRetryDelay)
+				return service
+			},
 			fx.As(new(deploymentDomain.DeploymentService)),
 		),
+		// Deploy scheduler, running due deploys in the background
+		fx.Annotate(
+			func(
+				service deploymentDomain.DeploymentService,
+				cfg *config.ServerConfig,
+				logger *slog.Logger,
+				lc fx.Lifecycle,
+			) (*deploymentDomain.DeployScheduler, error) {
+				path := cfg.ScheduledDeploys.File
+				if path == "" {
+					defaultPath, err := deploymentInfrastructure.DefaultScheduledDeploysFile()
+					if err != nil {
+						return nil, err
+					}
+					path = defaultPath
+				}
+
+				scheduler, err := deploymentDomain.NewDeployScheduler(
+					service,
+					deploymentInfrastructure.NewFileScheduledDeployStore(path),
+					logger,
+					cfg.ScheduledDeploys.CheckInterval,
+				)
+				if err != nil {
+					return nil, err
+				}
+
+				lc.Append(fx.Hook{
+					OnStart: func(ctx context.Context) error {
+						scheduler.Start()
+						return nil
+					},
+					OnStop: scheduler.Stop,
+				})
+				return scheduler, nil
+			},
+		),
 		// Deployment adapter
 		fx.Annotate(
 			adapter.NewDeploymentServiceAdapter,
@@ -68,4 +121,55 @@ var Module = fx.Module("deployment",
 			fx.ResultTags(`group:"server_plugins"`),
 		),
 	),
+	fx.Invoke(registerDeploymentEventWatcher),
 )
+
+// registerDeploymentEventWatcher watches the Dokku event log while the server is up,
+// at the plugin discovery sync interval; it is off when plugin discovery is disabled
+func registerDeploymentEventWatcher(
+	lc fx.Lifecycle,
+	cfg *config.ServerConfig,
+	infrastructure deploymentDomain.DeploymentInfrastructure,
+	notifier *server.ClientNotifier,
+	scope *shared.AppScope,
+	logger *slog.Logger,
+) {
+	interval := cfg.PluginDiscovery.SyncInterval
+	if !cfg.PluginDiscovery.Enabled || interval <= 0 {
+		return
+	}
+
+	watcher := deploymentDomain.NewDeploymentEventWatcher(infrastructure, notifyDeploymentEvent(notifier.Notify, scope), logger, interval)
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			logger.Info("Starting deployment event watcher", "interval", interval)
+			watcher.Start()
+			return nil
+		},
+		OnStop: watcher.Stop,
+	})
+}
+
+// notifyDeploymentEvent sends deployment events of in-scope apps to the clients allowed
+// to receive notifications, as log notifications at warning level for failed deploys
+func notifyDeploymentEvent(notify func(method string, params map[string]any), scope *shared.AppScope) deploymentDomain.DeploymentEventPublisher {
+	return func(event *deploymentDomain.DeploymentEvent) {
+		if !scope.Allows(event.AggregateID()) {
+			return
+		}
+		level := mcp.LoggingLevelInfo
+		if event.Kind() == deploymentDomain.DeploymentEventFailed {
+			level = mcp.LoggingLevelWarning
+		}
+		notify("notifications/message", map[string]any{
+			"level":  level,
+			"logger": "deployment_events",
+			"data": map[string]any{
+				"event":       event.EventType(),
+				"app_name":    event.AggregateID(),
+				"git_ref":     event.GitRef(),
+				"detected_at": event.OccurredAt(),
+			},
+		})
+	}
+}
@@ -2,31 +2,40 @@ package deployment
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/dokku-mcp/dokku-mcp/internal/server-plugin/domain"
 	deployment_domain "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/deployment/domain"
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
 // DeploymentServerPlugin implements the ServerPlugin interface for deployment functionality
 type DeploymentServerPlugin struct {
-	tracker *deployment_domain.DeploymentTracker
-	logger  *slog.Logger
+	tracker   *deployment_domain.DeploymentTracker
+	scheduler *deployment_domain.DeployScheduler
+	// scope hides the deployments and schedules of apps the server may not manage
+	scope  *shared.AppScope
+	logger *slog.Logger
 }
 
 // NewDeploymentServerPlugin creates a new deployment server plugin
 func NewDeploymentServerPlugin(
 	tracker *deployment_domain.DeploymentTracker,
+	scheduler *deployment_domain.DeployScheduler,
+	scope *shared.AppScope,
 	logger *slog.Logger,
 ) domain.ServerPlugin {
 	return &DeploymentServerPlugin{
-		tracker: tracker,
-		logger:  logger,
+		tracker:   tracker,
+		scheduler: scheduler,
+		scope:     scope,
+		logger:    logger,
 	}
 }
 
@@ -61,9 +70,33 @@ func (p *DeploymentServerPlugin) GetResources(ctx context.Context) ([]domain.Res
 	return resources, nil
 }
 
+// scopedDeployments returns the tracked deployments of in-scope apps
+func (p *DeploymentServerPlugin) scopedDeployments() []*deployment_domain.Deployment {
+	deployments := p.tracker.GetAll()
+	scoped := make([]*deployment_domain.Deployment, 0, len(deployments))
+	for _, deployment := range deployments {
+		if p.scope.Allows(deployment.AppName()) {
+			scoped = append(scoped, deployment)
+		}
+	}
+	return scoped
+}
+
+// scopedDeployment returns a tracked deployment, hiding those of out-of-scope apps
+func (p *DeploymentServerPlugin) scopedDeployment(deploymentID string) (*deployment_domain.Deployment, error) {
+	deployment, err := p.tracker.GetByID(deploymentID)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.scope.Check(deployment.AppName()); err != nil {
+		return nil, err
+	}
+	return deployment, nil
+}
+
 // Get deployment resources (existing deployments)
 func (p *DeploymentServerPlugin) getDeploymentResources() ([]domain.Resource, error) {
-	deployments := p.tracker.GetAll()
+	deployments := p.scopedDeployments()
 
 	resources := make([]domain.Resource, 0, len(deployments))
 
@@ -82,7 +115,7 @@ func (p *DeploymentServerPlugin) getDeploymentResources() ([]domain.Resource, er
 
 // Get build log resources
 func (p *DeploymentServerPlugin) getBuildLogResources() ([]domain.Resource, error) {
-	deployments := p.tracker.GetAll()
+	deployments := p.scopedDeployments()
 
 	resources := make([]domain.Resource, 0, len(deployments))
 
@@ -103,9 +136,220 @@ func (p *DeploymentServerPlugin) getBuildLogResources() ([]domain.Resource, erro
 }
 
 // ToolProvider implementation
+// Deploying now is handled via the apps plugin; these tools read tracked
+// deployments and manage deploys scheduled for later
 func (p *DeploymentServerPlugin) GetTools(ctx context.Context) ([]domain.Tool, error) {
-	// No tools for now - deployment is handled via the apps plugin
-	return []domain.Tool{}, nil
+	return []domain.Tool{
+		{
+			Name:        "get_last_failed_deploy_logs",
+			Description: "Get the build output and failure reason of an application's last failed deployment",
+			Builder:     p.buildGetLastFailedDeployLogsTool,
+			Handler:     p.handleGetLastFailedDeployLogs,
+		},
+		{
+			Name:        "schedule_app_deploy",
+			Description: "Schedule a deployment from Git at a future time",
+			Builder:     p.buildScheduleAppDeployTool,
+			Handler:     p.handleScheduleAppDeploy,
+		},
+		{
+			Name:        "list_scheduled_deploys",
+			Description: "List scheduled deployments and their outcome",
+			Builder:     p.buildListScheduledDeploysTool,
+			Handler:     p.handleListScheduledDeploys,
+		},
+		{
+			Name:        "cancel_scheduled_deploy",
+			Description: "Cancel a pending scheduled deployment",
+			Builder:     p.buildCancelScheduledDeployTool,
+			Handler:     p.handleCancelScheduledDeploy,
+		},
+	}, nil
+}
+
+func (p *DeploymentServerPlugin) buildGetLastFailedDeployLogsTool() mcp.Tool {
+	return mcp.NewTool(
+		"get_last_failed_deploy_logs",
+		mcp.WithDescription("Get the build output retained from an application's most recent failed deployment, with the failure reason. Only deployments started by this server are recorded; the output keeps the end of the build when it exceeds logs.build.max_size_mb"),
+		mcp.WithString("app_name",
+			mcp.Required(),
+			mcp.Description("Name of the application"),
+		),
+	)
+}
+
+func (p *DeploymentServerPlugin) handleGetLastFailedDeployLogs(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	appName, err := req.RequireString("app_name")
+	if err != nil {
+		return mcp.NewToolResultError("Application name is required"), nil
+	}
+	if err := p.scope.Check(appName); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	failure, err := p.tracker.LastFailure(appName)
+	if err != nil {
+		if errors.Is(err, deployment_domain.ErrNoFailedDeployment) {
+			return mcp.NewToolResultText(fmt.Sprintf("No failed deployment recorded for application '%s'", appName)), nil
+		}
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to read failed deployment logs: %v", err)), nil
+	}
+
+	jsonData, err := shared.MarshalOutput(failure)
+	if err != nil {
+		return mcp.NewToolResultError("Failed to serialize failed deployment logs"), nil
+	}
+
+	return mcp.NewToolResultText(string(jsonData)), nil
+}
+
+func (p *DeploymentServerPlugin) buildScheduleAppDeployTool() mcp.Tool {
+	return mcp.NewTool(
+		"schedule_app_deploy",
+		mcp.WithDescription("Schedule a deployment from Git for later, e.g. during a maintenance window. The deploy runs once the time is reached (queued behind any deploy of the same app in progress); pending schedules survive a server restart, and schedules missed while the server was down run as soon as it is back"),
+		mcp.WithString("app_name",
+			mcp.Required(),
+			mcp.Description("Name of the application to deploy"),
+		),
+		mcp.WithString("repo_url",
+			mcp.Required(),
+			mcp.Description("URL of the Git repository to deploy from"),
+		),
+		mcp.WithString("git_ref",
+			mcp.Description("Git reference to deploy (branch, tag, or commit; default: main)"),
+		),
+		mcp.WithString("at",
+			mcp.Description("When to deploy, as an RFC 3339 time (e.g. 2026-03-01T02:00:00Z). Either at or in is required"),
+		),
+		mcp.WithString("in",
+			mcp.Description("Delay before deploying, as a duration (e.g. 90m, 2h). Either at or in is required"),
+		),
+	)
+}
+
+func (p *DeploymentServerPlugin) buildListScheduledDeploysTool() mcp.Tool {
+	return mcp.NewTool(
+		"list_scheduled_deploys",
+		mcp.WithDescription("List pending scheduled deployments, and those run or cancelled in the last 24 hours with their outcome"),
+		mcp.WithString("app_name",
+			mcp.Description("Only list the schedules of this application"),
+		),
+	)
+}
+
+func (p *DeploymentServerPlugin) buildCancelScheduledDeployTool() mcp.Tool {
+	return mcp.NewTool(
+		"cancel_scheduled_deploy",
+		mcp.WithDescription("Cancel a scheduled deployment that has not started yet"),
+		mcp.WithString("schedule_id",
+			mcp.Required(),
+			mcp.Description("ID returned by schedule_app_deploy or list_scheduled_deploys"),
+		),
+	)
+}
+
+func (p *DeploymentServerPlugin) handleScheduleAppDeploy(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	appName, err := req.RequireString("app_name")
+	if err != nil {
+		return mcp.NewToolResultError("Application name is required"), nil
+	}
+	if err := p.scope.Check(appName); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	repoURL, err := req.RequireString("repo_url")
+	if err != nil {
+		return mcp.NewToolResultError("Repository URL is required"), nil
+	}
+
+	dueAt, err := scheduledTime(req.GetString("at", ""), req.GetString("in", ""), time.Now())
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	schedule, err := p.scheduler.Schedule(appName, repoURL, req.GetString("git_ref", "main"), dueAt)
+	if err != nil {
+		if errors.Is(err, deployment_domain.ErrScheduleInPast) {
+			return mcp.NewToolResultError(fmt.Sprintf("Cannot schedule a deploy at %s: the time must be in the future", dueAt.Format(time.RFC3339))), nil
+		}
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to schedule deploy: %v", err)), nil
+	}
+
+	jsonData, err := shared.MarshalOutput(schedule)
+	if err != nil {
+		return mcp.NewToolResultError("Failed to serialize scheduled deploy"), nil
+	}
+
+	return mcp.NewToolResultText(string(jsonData)), nil
+}
+
+func (p *DeploymentServerPlugin) handleListScheduledDeploys(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	schedules := p.scopedSchedules(req.GetString("app_name", ""))
+
+	jsonData, err := shared.MarshalOutput(schedules)
+	if err != nil {
+		return mcp.NewToolResultError("Failed to serialize scheduled deploys"), nil
+	}
+
+	return mcp.NewToolResultText(string(jsonData)), nil
+}
+
+func (p *DeploymentServerPlugin) handleCancelScheduledDeploy(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	scheduleID, err := req.RequireString("schedule_id")
+	if err != nil {
+		return mcp.NewToolResultError("Schedule ID is required"), nil
+	}
+	if !slices.ContainsFunc(p.scopedSchedules(""), func(schedule deployment_domain.ScheduledDeploy) bool {
+		return schedule.ID == scheduleID
+	}) {
+		return mcp.NewToolResultError(fmt.Sprintf("Scheduled deploy '%s' not found", scheduleID)), nil
+	}
+
+	if err := p.scheduler.Cancel(scheduleID); err != nil {
+		if errors.Is(err, deployment_domain.ErrScheduledDeployNotFound) {
+			return mcp.NewToolResultError(fmt.Sprintf("Scheduled deploy '%s' not found", scheduleID)), nil
+		}
+		if errors.Is(err, deployment_domain.ErrScheduledDeployNotPending) {
+			return mcp.NewToolResultError(fmt.Sprintf("Scheduled deploy '%s' cannot be cancelled: %v", scheduleID, err)), nil
+		}
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to cancel scheduled deploy: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Scheduled deploy '%s' cancelled", scheduleID)), nil
+}
+
+// scopedSchedules lists the scheduled deploys of in-scope apps, optionally for a single app
+func (p *DeploymentServerPlugin) scopedSchedules(appName string) []deployment_domain.ScheduledDeploy {
+	schedules := p.scheduler.List(appName)
+	scoped := schedules[:0]
+	for _, schedule := range schedules {
+		if p.scope.Allows(schedule.AppName) {
+			scoped = append(scoped, schedule)
+		}
+	}
+	return scoped
+}
+
+// scheduledTime resolves the at (RFC 3339 time) or in (duration from now) argument
+func scheduledTime(at, in string, now time.Time) (time.Time, error) {
+	switch {
+	case at != "" && in != "":
+		return time.Time{}, fmt.Errorf("set either at or in, not both")
+	case at != "":
+		dueAt, err := time.Parse(time.RFC3339, at)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid at %q: expected an RFC 3339 time such as 2026-03-01T02:00:00Z", at)
+		}
+		return dueAt, nil
+	case in != "":
+		delay, err := time.ParseDuration(in)
+		if err != nil || delay <= 0 {
+			return time.Time{}, fmt.Errorf("invalid in %q: expected a positive duration such as 90m or 2h", in)
+		}
+		return now.Add(delay), nil
+	default:
+		return time.Time{}, fmt.Errorf("a deploy time is required: set at or in")
+	}
 }
 
 // PromptProvider implementation
@@ -132,7 +376,7 @@ func (p *DeploymentServerPlugin) handleDeploymentResource(ctx context.Context, r
 	}
 
 	// Get deployment from tracker
-	deployment, err := p.tracker.GetByID(deploymentID)
+	deployment, err := p.scopedDeployment(deploymentID)
 	if err != nil {
 		p.logger.Error("deployment not found", "deployment_id", deploymentID, "error", err)
 		return nil, fmt.Errorf("deployment not found")
@@ -151,6 +395,7 @@ func (p *DeploymentServerPlugin) handleDeploymentResource(ctx context.Context, r
 		Duration     string     `json:"duration"`
 		HasBuildLogs bool       `json:"has_build_logs"`
 		BuildLogsURI string     `json:"build_logs_uri,omitempty"`
+		Warnings     []string   `json:"warnings,omitempty"`
 	}
 
 	// Create typed deployment response
@@ -165,6 +410,7 @@ func (p *DeploymentServerPlugin) handleDeploymentResource(ctx context.Context, r
 		ErrorMsg:     deployment.ErrorMsg(),
 		Duration:     deployment.Duration().String(),
 		HasBuildLogs: deployment.BuildLogs() != "",
+		Warnings:     deployment.Warnings(),
 	}
 
 	if deployment.BuildLogs() != "" {
@@ -172,7 +418,7 @@ func (p *DeploymentServerPlugin) handleDeploymentResource(ctx context.Context, r
 	}
 
 	// Serialize to JSON
-	jsonData, err := json.MarshalIndent(response, "", "  ")
+	jsonData, err := shared.MarshalOutput(response)
 	if err != nil {
 		p.logger.Error("failed to serialize deployment response", "error", err)
 		return nil, fmt.Errorf("failed to serialize deployment info")
@@ -204,7 +450,7 @@ func (p *DeploymentServerPlugin) handleBuildLogsResource(ctx context.Context, re
 	deploymentID := parts[0]
 
 	// Get deployment from tracker
-	deployment, err := p.tracker.GetByID(deploymentID)
+	deployment, err := p.scopedDeployment(deploymentID)
 	if err != nil {
 		return nil, fmt.Errorf("deployment not found: %w", err)
 	}
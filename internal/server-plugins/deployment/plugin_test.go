@@ -0,0 +1,115 @@
+package deployment
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	deployment_domain "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/deployment/domain"
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// newScopedTestPlugin returns a deployment plugin limited to "staging-*" apps, tracking a
+// deployment and a schedule for both staging-api and billing
+func newScopedTestPlugin(t *testing.T) (*DeploymentServerPlugin, *deployment_domain.DeployScheduler) {
+	t.Helper()
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	tracker := deployment_domain.NewDeploymentTracker()
+	scheduler, err := deployment_domain.NewDeployScheduler(nil, nil, logger, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, appName := range []string{"staging-api", "billing"} {
+		deployment, err := deployment_domain.NewDeploymentWithID("deploy_"+appName, appName, "main")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := tracker.Track(deployment); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := scheduler.Schedule(appName, "https://example.com/repo.git", "main", time.Now().Add(time.Hour)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	scope := shared.NewAppScope([]string{"staging-*"}, nil)
+	return NewDeploymentServerPlugin(tracker, scheduler, scope, logger).(*DeploymentServerPlugin), scheduler
+}
+
+func callTool(handler func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error), args map[string]any) (*mcp.CallToolResult, string) {
+	req := mcp.CallToolRequest{}
+	req.Params.Arguments = args
+	result, _ := handler(context.Background(), req)
+	return result, result.Content[0].(mcp.TextContent).Text
+}
+
+func TestDeploymentPluginHidesOutOfScopeApps(t *testing.T) {
+	plugin, scheduler := newScopedTestPlugin(t)
+
+	resources, err := plugin.GetResources(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, resource := range resources {
+		if strings.Contains(resource.Name, "billing") {
+			t.Fatalf("expected billing deployments to be hidden, got %s", resource.Name)
+		}
+	}
+
+	req := mcp.ReadResourceRequest{}
+	req.Params.URI = "dokku://deployment/deploy_billing"
+	if _, err := plugin.handleDeploymentResource(context.Background(), req); err == nil {
+		t.Fatalf("expected an out-of-scope deployment to be refused")
+	}
+
+	_, text := callTool(plugin.handleListScheduledDeploys, map[string]any{})
+	if strings.Contains(text, "billing") || !strings.Contains(text, "staging-api") {
+		t.Fatalf("expected only in-scope schedules, got %s", text)
+	}
+
+	billing := scheduler.List("billing")[0]
+	if result, _ := callTool(plugin.handleCancelScheduledDeploy, map[string]any{"schedule_id": billing.ID}); !result.IsError {
+		t.Fatalf("expected cancelling an out-of-scope schedule to be refused")
+	}
+	if scheduler.List("billing")[0].Status != deployment_domain.ScheduledDeployPending {
+		t.Fatalf("expected the billing schedule to stay pending")
+	}
+}
+
+func TestDeploymentPluginRefusesOutOfScopeTools(t *testing.T) {
+	plugin, _ := newScopedTestPlugin(t)
+
+	result, text := callTool(plugin.handleScheduleAppDeploy, map[string]any{
+		"app_name": "billing",
+		"repo_url": "https://example.com/repo.git",
+		"in":       "1h",
+	})
+	if !result.IsError || !strings.Contains(text, "outside the apps") {
+		t.Fatalf("expected scheduling an out-of-scope deploy to be refused, got %s", text)
+	}
+
+	result, text = callTool(plugin.handleGetLastFailedDeployLogs, map[string]any{"app_name": "billing"})
+	if !result.IsError || !strings.Contains(text, "outside the apps") {
+		t.Fatalf("expected out-of-scope failure logs to be refused, got %s", text)
+	}
+}
+
+func TestNotifyDeploymentEventSkipsOutOfScopeApps(t *testing.T) {
+	var notified []string
+	publish := notifyDeploymentEvent(func(method string, params map[string]any) {
+		notified = append(notified, params["data"].(map[string]any)["app_name"].(string))
+	}, shared.NewAppScope(nil, []string{"billing"}))
+
+	publish(deployment_domain.NewDeploymentEvent("billing", "main", deployment_domain.DeploymentEventSucceeded, "", time.Now()))
+	publish(deployment_domain.NewDeploymentEvent("staging-api", "main", deployment_domain.DeploymentEventFailed, "", time.Now()))
+
+	if len(notified) != 1 || notified[0] != "staging-api" {
+		t.Fatalf("expected only the in-scope event to be sent, got %v", notified)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"log/slog"
 
 	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/domain/domain"
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
 )
 
 // DomainService provides application-level orchestration for domain functionality
@@ -73,11 +74,8 @@ func (s *DomainService) GetDomainsReport(ctx context.Context) (*domain.DomainsRe
 }
 
 func (s *DomainService) validateDomainName(domain string) error {
-	if domain == "" {
-		return fmt.Errorf("domain name cannot be empty")
-	}
-	if len(domain) > 253 {
-		return fmt.Errorf("domain name too long (max 253 characters)")
+	if _, err := shared.NewDomainName(domain); err != nil {
+		return err
 	}
 	return nil
 }
@@ -2,7 +2,6 @@ package domain
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"log/slog"
 
@@ -11,6 +10,7 @@ import (
 	serverDomain "github.com/dokku-mcp/dokku-mcp/internal/server-plugin/domain"
 	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/domain/application"
 	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/domain/infrastructure"
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
@@ -74,7 +74,7 @@ func (p *DomainServerPlugin) handleDomainsReportResource(ctx context.Context, re
 	if err != nil {
 		return nil, fmt.Errorf("failed to get domains report: %w", err)
 	}
-	jsonData, err := json.MarshalIndent(report, "", "  ")
+	jsonData, err := shared.MarshalOutput(report)
 	if err != nil {
 		return nil, fmt.Errorf("failed to serialize domains report: %w", err)
 	}
@@ -97,18 +97,10 @@ func (p *DomainServerPlugin) buildListGlobalDomainsTool() mcp.Tool {
 func (p *DomainServerPlugin) handleListGlobalDomains(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	domains, err := p.domainService.ListGlobalDomains(ctx)
 	if err != nil {
-		env := server.ToolResponse{Status: server.ToolStatusError, Code: "DOMAINS_LIST_FAILED", Message: fmt.Sprintf("Failed to list global domains: %v", err)}
-		b, _ := json.MarshalIndent(env, "", "  ")
-		return mcp.NewToolResultText(string(b)), nil
-	}
-	payload, err := json.Marshal(domains)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to encode domains: %v", err)), nil
+		return server.Error(fmt.Sprintf("Failed to list global domains: %v", err)), nil
 	}
 
-	env := server.ToolResponse{Status: server.ToolStatusOK, Code: "DOMAINS_OK", Data: server.ToolResponseData{"domains": payload}}
-	b, _ := json.MarshalIndent(env, "", "  ")
-	return mcp.NewToolResultText(string(b)), nil
+	return server.OK(fmt.Sprintf("%d global domains", len(domains)), map[string]any{"domains": domains}), nil
 }
 
 func (p *DomainServerPlugin) buildAddGlobalDomainTool() mcp.Tool {
@@ -0,0 +1,67 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/letsencrypt/domain"
+)
+
+// LetsEncryptService provides application-level orchestration for Let's Encrypt certificates
+type LetsEncryptService struct {
+	letsEncryptRepo domain.LetsEncryptRepository
+	logger          *slog.Logger
+}
+
+// NewLetsEncryptService creates a new letsencrypt application service
+func NewLetsEncryptService(letsEncryptRepo domain.LetsEncryptRepository, logger *slog.Logger) *LetsEncryptService {
+	return &LetsEncryptService{
+		letsEncryptRepo: letsEncryptRepo,
+		logger:          logger,
+	}
+}
+
+// ListCertificates lists the Let's Encrypt certificates of all applications
+func (s *LetsEncryptService) ListCertificates(ctx context.Context) ([]domain.Certificate, error) {
+	return s.letsEncryptRepo.ListCertificates(ctx)
+}
+
+// Enable requests a certificate for an application once it has a domain Let's Encrypt can validate.
+// It returns the domains the certificate will cover.
+func (s *LetsEncryptService) Enable(ctx context.Context, appName string) ([]string, error) {
+	if appName == "" {
+		return nil, fmt.Errorf("app name cannot be empty")
+	}
+
+	domains, err := s.letsEncryptRepo.GetAppDomains(ctx, appName)
+	if err != nil {
+		return nil, err
+	}
+	certifiable := domain.CertifiableDomains(domains)
+	if len(certifiable) == 0 {
+		configured := "none"
+		if len(domains) > 0 {
+			configured = strings.Join(domains, ", ")
+		}
+		return nil, fmt.Errorf("%w: %s has no public domain (configured: %s); add one with domains:add pointing to this server first",
+			domain.ErrNoCertifiableDomain, appName, configured)
+	}
+
+	s.logger.Info("Enabling letsencrypt", "app_name", appName, "domains", certifiable)
+	if err := s.letsEncryptRepo.Enable(ctx, appName); err != nil {
+		return nil, err
+	}
+	return certifiable, nil
+}
+
+// Disable removes the certificate of an application
+func (s *LetsEncryptService) Disable(ctx context.Context, appName string) error {
+	if appName == "" {
+		return fmt.Errorf("app name cannot be empty")
+	}
+
+	s.logger.Info("Disabling letsencrypt", "app_name", appName)
+	return s.letsEncryptRepo.Disable(ctx, appName)
+}
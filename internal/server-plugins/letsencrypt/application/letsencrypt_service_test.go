@@ -0,0 +1,51 @@
+package application
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/server-plugin/plugintest"
+	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/letsencrypt/domain"
+)
+
+// fakeLetsEncryptRepository returns fixed domains and records enable calls
+type fakeLetsEncryptRepository struct {
+	domain.LetsEncryptRepository
+	domains []string
+	enabled plugintest.Calls[string]
+}
+
+func (f *fakeLetsEncryptRepository) GetAppDomains(ctx context.Context, appName string) ([]string, error) {
+	return f.domains, nil
+}
+
+func (f *fakeLetsEncryptRepository) Enable(ctx context.Context, appName string) error {
+	f.enabled.Record(appName)
+	return nil
+}
+
+func TestEnableRefusesWithoutCertifiableDomain(t *testing.T) {
+	repo := &fakeLetsEncryptRepository{domains: []string{"my-app.localhost", "192.168.1.10"}}
+
+	_, err := NewLetsEncryptService(repo, plugintest.Logger()).Enable(context.Background(), "my-app")
+	plugintest.ExpectRejectedBeforeExecuting(t, err, domain.ErrNoCertifiableDomain, "letsencrypt:enable", repo.enabled)
+	if !strings.Contains(err.Error(), "my-app.localhost, 192.168.1.10") {
+		t.Fatalf("expected the configured domains in the error, got %v", err)
+	}
+}
+
+func TestEnableRequestsCertificateForPublicDomains(t *testing.T) {
+	repo := &fakeLetsEncryptRepository{domains: []string{"my-app.localhost", "app.example.com"}}
+
+	domains, err := NewLetsEncryptService(repo, plugintest.Logger()).Enable(context.Background(), "my-app")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(domains) != 1 || domains[0] != "app.example.com" {
+		t.Fatalf("unexpected certified domains: %v", domains)
+	}
+	if len(repo.enabled) != 1 || repo.enabled[0] != "my-app" {
+		t.Fatalf("expected letsencrypt:enable for my-app, got %v", repo.enabled)
+	}
+}
@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
+)
+
+// ErrNoCertifiableDomain is returned when an application has no domain Let's Encrypt can validate
+var ErrNoCertifiableDomain = errors.New("no domain eligible for a Let's Encrypt certificate")
+
+// Certificate is the Let's Encrypt certificate of an application as listed by letsencrypt:list
+type Certificate struct {
+	App       string     `json:"app"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	// ExpiresIn and RenewsIn are Dokku's human readable countdowns (e.g. "59d, 23h, 10m, 5s")
+	ExpiresIn string `json:"expires_in,omitempty"`
+	RenewsIn  string `json:"renews_in,omitempty"`
+}
+
+// CertifiableDomains returns the domains Let's Encrypt can issue a certificate for:
+// fully qualified, not local and not wildcards (the HTTP challenge cannot validate them)
+func CertifiableDomains(domains []string) []string {
+	certifiable := []string{}
+	for _, domain := range domains {
+		domainVO, err := shared.NewDomainName(domain)
+		if err != nil {
+			continue
+		}
+		if domainVO.IsLocal() || !domainVO.IsFQDN() || domainVO.IsWildcard() {
+			continue
+		}
+		certifiable = append(certifiable, domainVO.Value())
+	}
+	return certifiable
+}
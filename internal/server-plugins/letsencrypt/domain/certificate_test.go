@@ -0,0 +1,26 @@
+package domain_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/letsencrypt/domain"
+)
+
+var _ = Describe("CertifiableDomains", func() {
+	It("keeps public domains only", func() {
+		Expect(domain.CertifiableDomains([]string{
+			"app.example.com",
+			"localhost",
+			"127.0.0.1",
+			"203.0.113.10",
+			"my-app",
+			"*.example.com",
+			"WWW.Example.com",
+		})).To(Equal([]string{"app.example.com", "www.example.com"}))
+	})
+
+	It("returns an empty list when nothing is eligible", func() {
+		Expect(domain.CertifiableDomains([]string{"my-app.localhost"})).To(BeEmpty())
+	})
+})
@@ -0,0 +1,36 @@
+package domain
+
+// LetsEncryptCommand represents allowed Dokku commands for the letsencrypt plugin
+type LetsEncryptCommand string
+
+const (
+	CommandDomainsReport      LetsEncryptCommand = "domains:report"
+	CommandLetsEncryptEnable  LetsEncryptCommand = "letsencrypt:enable"
+	CommandLetsEncryptDisable LetsEncryptCommand = "letsencrypt:disable"
+	CommandLetsEncryptList    LetsEncryptCommand = "letsencrypt:list"
+)
+
+// IsValid checks if the command is a valid letsencrypt command
+func (c LetsEncryptCommand) IsValid() bool {
+	switch c {
+	case CommandDomainsReport, CommandLetsEncryptEnable, CommandLetsEncryptDisable, CommandLetsEncryptList:
+		return true
+	default:
+		return false
+	}
+}
+
+// String returns the string representation of the command
+func (c LetsEncryptCommand) String() string {
+	return string(c)
+}
+
+// GetAllowedCommands returns all allowed letsencrypt commands
+func GetAllowedCommands() []LetsEncryptCommand {
+	return []LetsEncryptCommand{
+		CommandDomainsReport,
+		CommandLetsEncryptEnable,
+		CommandLetsEncryptDisable,
+		CommandLetsEncryptList,
+	}
+}
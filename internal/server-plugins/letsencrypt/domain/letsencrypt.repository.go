@@ -0,0 +1,13 @@
+package domain
+
+import (
+	"context"
+)
+
+// LetsEncryptRepository defines methods for managing Let's Encrypt certificates
+type LetsEncryptRepository interface {
+	GetAppDomains(ctx context.Context, appName string) ([]string, error)
+	ListCertificates(ctx context.Context) ([]Certificate, error)
+	Enable(ctx context.Context, appName string) error
+	Disable(ctx context.Context, appName string) error
+}
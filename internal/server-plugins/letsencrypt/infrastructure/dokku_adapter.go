@@ -0,0 +1,106 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+	"time"
+
+	dokkuApi "github.com/dokku-mcp/dokku-mcp/internal/dokku-api"
+	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/letsencrypt/domain"
+)
+
+// certificateExpiryLayout is the format of the expiry column of letsencrypt:list
+const certificateExpiryLayout = "2006-01-02 15:04:05"
+
+// columnSeparator splits letsencrypt:list columns, which are padded with at least two spaces
+var columnSeparator = regexp.MustCompile(`\s{2,}`)
+
+// DokkuLetsEncryptAdapter implements the letsencrypt repository using Dokku CLI
+type DokkuLetsEncryptAdapter struct {
+	client dokkuApi.DokkuClient
+	logger *slog.Logger
+}
+
+// NewDokkuLetsEncryptAdapter creates a new letsencrypt adapter
+func NewDokkuLetsEncryptAdapter(client dokkuApi.DokkuClient, logger *slog.Logger) domain.LetsEncryptRepository {
+	return &DokkuLetsEncryptAdapter{
+		client: client,
+		logger: logger,
+	}
+}
+
+// executeCommand wraps the client's ExecuteCommand with letsencrypt-specific context and validation
+func (a *DokkuLetsEncryptAdapter) executeCommand(ctx context.Context, command domain.LetsEncryptCommand, args []string) ([]byte, error) {
+	if !command.IsValid() {
+		return nil, fmt.Errorf("invalid letsencrypt command: %s", command)
+	}
+	return a.client.ExecuteCommand(ctx, command.String(), args)
+}
+
+// GetAppDomains retrieves the domains of an application from domains:report
+func (a *DokkuLetsEncryptAdapter) GetAppDomains(ctx context.Context, appName string) ([]string, error) {
+	output, err := a.executeCommand(ctx, domain.CommandDomainsReport, []string{appName, "--domains-app-vhosts"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get domains of %s: %w", appName, err)
+	}
+	value, _ := dokkuApi.StripWarningLines(string(output))
+	return strings.Fields(value), nil
+}
+
+// ListCertificates retrieves the Let's Encrypt certificates from letsencrypt:list
+func (a *DokkuLetsEncryptAdapter) ListCertificates(ctx context.Context) ([]domain.Certificate, error) {
+	output, err := a.executeCommand(ctx, domain.CommandLetsEncryptList, []string{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list certificates: %w", err)
+	}
+	return parseCertificateList(string(output)), nil
+}
+
+// Enable requests a certificate for an application and enables HTTPS
+func (a *DokkuLetsEncryptAdapter) Enable(ctx context.Context, appName string) error {
+	if _, err := a.executeCommand(ctx, domain.CommandLetsEncryptEnable, []string{appName}); err != nil {
+		return fmt.Errorf("failed to enable letsencrypt for %s: %w", appName, err)
+	}
+	return nil
+}
+
+// Disable removes the certificate of an application
+func (a *DokkuLetsEncryptAdapter) Disable(ctx context.Context, appName string) error {
+	if _, err := a.executeCommand(ctx, domain.CommandLetsEncryptDisable, []string{appName}); err != nil {
+		return fmt.Errorf("failed to disable letsencrypt for %s: %w", appName, err)
+	}
+	return nil
+}
+
+// parseCertificateList reads the letsencrypt:list table:
+// App name, Certificate Expiry, Time before expiry, Time before renewal
+func parseCertificateList(output string) []domain.Certificate {
+	output, _ = dokkuApi.StripWarningLines(output)
+
+	certificates := []domain.Certificate{}
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "----->") || strings.HasPrefix(line, "=====>") {
+			continue
+		}
+
+		columns := columnSeparator.Split(line, -1)
+		certificate := domain.Certificate{App: columns[0]}
+		if len(columns) > 1 {
+			if expiresAt, err := time.Parse(certificateExpiryLayout, columns[1]); err == nil {
+				certificate.ExpiresAt = &expiresAt
+			}
+		}
+		if len(columns) > 2 {
+			certificate.ExpiresIn = columns[2]
+		}
+		if len(columns) > 3 {
+			certificate.RenewsIn = columns[3]
+		}
+		certificates = append(certificates, certificate)
+	}
+	return certificates
+}
@@ -0,0 +1,34 @@
+package infrastructure
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCertificateList(t *testing.T) {
+	certificates := parseCertificateList(`-----> App name           Certificate Expiry        Time before expiry        Time before renewal
+my-app                    2025-03-01 12:00:00       59d, 23h, 10m, 5s         29d, 23h, 10m, 5s
+other-app                 2025-01-15 08:30:00       14d, 19h, 40m, 5s         0d, 0h, 0m, 0s`)
+
+	if len(certificates) != 2 {
+		t.Fatalf("expected two certificates, got %+v", certificates)
+	}
+
+	first := certificates[0]
+	if first.App != "my-app" || first.ExpiresIn != "59d, 23h, 10m, 5s" || first.RenewsIn != "29d, 23h, 10m, 5s" {
+		t.Fatalf("unexpected certificate: %+v", first)
+	}
+	if first.ExpiresAt == nil || !first.ExpiresAt.Equal(time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected expiry: %v", first.ExpiresAt)
+	}
+	if certificates[1].App != "other-app" {
+		t.Fatalf("unexpected second certificate: %+v", certificates[1])
+	}
+}
+
+func TestParseCertificateListWithoutCertificates(t *testing.T) {
+	certificates := parseCertificateList("-----> App name           Certificate Expiry        Time before expiry        Time before renewal\n")
+	if len(certificates) != 0 {
+		t.Fatalf("expected no certificates, got %+v", certificates)
+	}
+}
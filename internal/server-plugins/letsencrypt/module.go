@@ -0,0 +1,16 @@
+package letsencrypt
+
+import (
+	serverDomain "github.com/dokku-mcp/dokku-mcp/internal/server-plugin/domain"
+	"go.uber.org/fx"
+)
+
+var Module = fx.Module("letsencrypt",
+	fx.Provide(
+		fx.Annotate(
+			NewLetsEncryptServerPlugin,
+			fx.As(new(serverDomain.ServerPlugin)),
+			fx.ResultTags(`group:"server_plugins"`),
+		),
+	),
+)
@@ -0,0 +1,118 @@
+package letsencrypt
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	dokkuApi "github.com/dokku-mcp/dokku-mcp/internal/dokku-api"
+	serverDomain "github.com/dokku-mcp/dokku-mcp/internal/server-plugin/domain"
+	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/letsencrypt/application"
+	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/letsencrypt/infrastructure"
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// LetsEncryptServerPlugin provides TLS certificate management through the dokku-letsencrypt plugin
+type LetsEncryptServerPlugin struct {
+	letsEncryptService *application.LetsEncryptService
+	logger             *slog.Logger
+}
+
+// NewLetsEncryptServerPlugin creates a new letsencrypt server plugin
+func NewLetsEncryptServerPlugin(client dokkuApi.DokkuClient, logger *slog.Logger) serverDomain.ServerPlugin {
+	adapter := infrastructure.NewDokkuLetsEncryptAdapter(client, logger)
+	letsEncryptService := application.NewLetsEncryptService(adapter, logger)
+	return &LetsEncryptServerPlugin{
+		letsEncryptService: letsEncryptService,
+		logger:             logger,
+	}
+}
+
+func (p *LetsEncryptServerPlugin) ID() string   { return "letsencrypt" }
+func (p *LetsEncryptServerPlugin) Name() string { return "Dokku Let's Encrypt" }
+func (p *LetsEncryptServerPlugin) Description() string {
+	return "Enables HTTPS for applications with Let's Encrypt certificates"
+}
+func (p *LetsEncryptServerPlugin) Version() string         { return "0.1.0" }
+func (p *LetsEncryptServerPlugin) DokkuPluginName() string { return "letsencrypt" }
+
+// ToolProvider implementation
+func (p *LetsEncryptServerPlugin) GetTools(ctx context.Context) ([]serverDomain.Tool, error) {
+	return []serverDomain.Tool{
+		{
+			Name:        "enable_letsencrypt",
+			Description: "Enable HTTPS for an application with a Let's Encrypt certificate",
+			Builder:     p.buildEnableLetsEncryptTool,
+			Handler:     p.handleEnableLetsEncrypt,
+		},
+		{
+			Name:        "disable_letsencrypt",
+			Description: "Remove the Let's Encrypt certificate of an application",
+			Builder:     p.buildDisableLetsEncryptTool,
+			Handler:     p.handleDisableLetsEncrypt,
+		},
+		{
+			Name:        "get_certificate_status",
+			Description: "List the Let's Encrypt certificates with their expiry dates",
+			Builder:     p.buildGetCertificateStatusTool,
+			Handler:     p.handleGetCertificateStatus,
+		},
+	}, nil
+}
+
+func (p *LetsEncryptServerPlugin) buildEnableLetsEncryptTool() mcp.Tool {
+	return mcp.NewTool(
+		"enable_letsencrypt",
+		mcp.WithDescription("Request a Let's Encrypt certificate for an application and serve it over HTTPS (letsencrypt:enable). The application needs at least one public domain resolving to this server; localhost, IP addresses and wildcard domains cannot be validated. A contact email must be set with letsencrypt:set --global email"),
+		mcp.WithString("app_name",
+			mcp.Required(),
+			mcp.Description("Name of the application"),
+		),
+	)
+}
+
+func (p *LetsEncryptServerPlugin) handleEnableLetsEncrypt(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return serverDomain.HandleAppChange(ctx, req, "enable letsencrypt", func(ctx context.Context, appName string) (string, error) {
+		domains, err := p.letsEncryptService.Enable(ctx, appName)
+		return fmt.Sprintf("✅ Let's Encrypt enabled for '%s' (%s)", appName, strings.Join(domains, ", ")), err
+	})
+}
+
+func (p *LetsEncryptServerPlugin) buildDisableLetsEncryptTool() mcp.Tool {
+	return mcp.NewTool(
+		"disable_letsencrypt",
+		mcp.WithDescription("Remove the Let's Encrypt certificate of an application (letsencrypt:disable); the application is then served over HTTP only"),
+		mcp.WithString("app_name",
+			mcp.Required(),
+			mcp.Description("Name of the application"),
+		),
+	)
+}
+
+func (p *LetsEncryptServerPlugin) handleDisableLetsEncrypt(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return serverDomain.HandleAppChange(ctx, req, "disable letsencrypt", func(ctx context.Context, appName string) (string, error) {
+		return fmt.Sprintf("✅ Let's Encrypt disabled for '%s'", appName), p.letsEncryptService.Disable(ctx, appName)
+	})
+}
+
+func (p *LetsEncryptServerPlugin) buildGetCertificateStatusTool() mcp.Tool {
+	return mcp.NewTool(
+		"get_certificate_status",
+		mcp.WithDescription("List the applications with a Let's Encrypt certificate as JSON (letsencrypt:list): expiry date, time before expiry and time before renewal"),
+	)
+}
+
+func (p *LetsEncryptServerPlugin) handleGetCertificateStatus(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	certificates, err := p.letsEncryptService.ListCertificates(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to list certificates: %v", err)), nil
+	}
+
+	jsonData, err := shared.MarshalOutput(certificates)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to encode certificates: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(jsonData)), nil
+}
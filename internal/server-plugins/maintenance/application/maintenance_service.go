@@ -0,0 +1,61 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/maintenance/domain"
+)
+
+// MaintenanceService provides application-level orchestration for the maintenance mode
+type MaintenanceService struct {
+	maintenanceRepo domain.MaintenanceRepository
+	logger          *slog.Logger
+}
+
+// NewMaintenanceService creates a new maintenance application service
+func NewMaintenanceService(maintenanceRepo domain.MaintenanceRepository, logger *slog.Logger) *MaintenanceService {
+	return &MaintenanceService{
+		maintenanceRepo: maintenanceRepo,
+		logger:          logger,
+	}
+}
+
+// ListStatuses lists the maintenance mode of all applications
+func (s *MaintenanceService) ListStatuses(ctx context.Context) ([]domain.MaintenanceStatus, error) {
+	return s.maintenanceRepo.ListStatuses(ctx)
+}
+
+// Enable puts an application behind the maintenance page. It returns whether the
+// application is deployed: an undeployed application serves no traffic, so the
+// maintenance page only shows once it is.
+func (s *MaintenanceService) Enable(ctx context.Context, appName string) (bool, error) {
+	if appName == "" {
+		return false, fmt.Errorf("app name cannot be empty")
+	}
+
+	deployed, err := s.maintenanceRepo.IsDeployed(ctx, appName)
+	if err != nil {
+		return false, err
+	}
+	if !deployed {
+		s.logger.Warn("Enabling maintenance on an application that is not deployed", "app_name", appName)
+	}
+
+	s.logger.Info("Enabling maintenance", "app_name", appName)
+	if err := s.maintenanceRepo.Enable(ctx, appName); err != nil {
+		return false, err
+	}
+	return deployed, nil
+}
+
+// Disable takes an application out of maintenance
+func (s *MaintenanceService) Disable(ctx context.Context, appName string) error {
+	if appName == "" {
+		return fmt.Errorf("app name cannot be empty")
+	}
+
+	s.logger.Info("Disabling maintenance", "app_name", appName)
+	return s.maintenanceRepo.Disable(ctx, appName)
+}
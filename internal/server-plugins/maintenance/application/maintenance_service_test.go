@@ -0,0 +1,55 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/server-plugin/plugintest"
+	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/maintenance/domain"
+)
+
+// fakeMaintenanceRepository returns a fixed deployment state and records enable calls
+type fakeMaintenanceRepository struct {
+	domain.MaintenanceRepository
+	deployed    bool
+	deployedErr error
+	enabled     plugintest.Calls[string]
+}
+
+func (f *fakeMaintenanceRepository) IsDeployed(ctx context.Context, appName string) (bool, error) {
+	return f.deployed, f.deployedErr
+}
+
+func (f *fakeMaintenanceRepository) Enable(ctx context.Context, appName string) error {
+	f.enabled.Record(appName)
+	return nil
+}
+
+func TestEnableReportsUndeployedApp(t *testing.T) {
+	for _, deployed := range []bool{true, false} {
+		repo := &fakeMaintenanceRepository{deployed: deployed}
+
+		got, err := NewMaintenanceService(repo, plugintest.Logger()).Enable(context.Background(), "my-app")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != deployed {
+			t.Fatalf("expected deployed=%v, got %v", deployed, got)
+		}
+		if len(repo.enabled) != 1 || repo.enabled[0] != "my-app" {
+			t.Fatalf("expected maintenance:enable for my-app, got %v", repo.enabled)
+		}
+	}
+}
+
+func TestEnableStopsWhenDeploymentStateIsUnknown(t *testing.T) {
+	repo := &fakeMaintenanceRepository{deployedErr: errors.New("app does not exist")}
+
+	if _, err := NewMaintenanceService(repo, plugintest.Logger()).Enable(context.Background(), "my-app"); err == nil {
+		t.Fatal("expected an error")
+	}
+	if len(repo.enabled) != 0 {
+		t.Fatalf("maintenance:enable should not run, got %v", repo.enabled)
+	}
+}
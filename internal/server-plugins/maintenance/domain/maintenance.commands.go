@@ -0,0 +1,36 @@
+package domain
+
+// MaintenanceCommand represents allowed Dokku commands for the maintenance plugin
+type MaintenanceCommand string
+
+const (
+	CommandPsReport           MaintenanceCommand = "ps:report"
+	CommandMaintenanceEnable  MaintenanceCommand = "maintenance:enable"
+	CommandMaintenanceDisable MaintenanceCommand = "maintenance:disable"
+	CommandMaintenanceReport  MaintenanceCommand = "maintenance:report"
+)
+
+// IsValid checks if the command is a valid maintenance command
+func (c MaintenanceCommand) IsValid() bool {
+	switch c {
+	case CommandPsReport, CommandMaintenanceEnable, CommandMaintenanceDisable, CommandMaintenanceReport:
+		return true
+	default:
+		return false
+	}
+}
+
+// String returns the string representation of the command
+func (c MaintenanceCommand) String() string {
+	return string(c)
+}
+
+// GetAllowedCommands returns all allowed maintenance commands
+func GetAllowedCommands() []MaintenanceCommand {
+	return []MaintenanceCommand{
+		CommandPsReport,
+		CommandMaintenanceEnable,
+		CommandMaintenanceDisable,
+		CommandMaintenanceReport,
+	}
+}
@@ -0,0 +1,13 @@
+package domain
+
+import (
+	"context"
+)
+
+// MaintenanceRepository defines methods for managing the maintenance mode of applications
+type MaintenanceRepository interface {
+	IsDeployed(ctx context.Context, appName string) (bool, error)
+	ListStatuses(ctx context.Context) ([]MaintenanceStatus, error)
+	Enable(ctx context.Context, appName string) error
+	Disable(ctx context.Context, appName string) error
+}
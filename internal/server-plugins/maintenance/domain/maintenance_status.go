@@ -0,0 +1,7 @@
+package domain
+
+// MaintenanceStatus is the maintenance mode of an application as reported by maintenance:report
+type MaintenanceStatus struct {
+	App     string `json:"app"`
+	Enabled bool   `json:"enabled"`
+}
@@ -0,0 +1,96 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	dokkuApi "github.com/dokku-mcp/dokku-mcp/internal/dokku-api"
+	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/maintenance/domain"
+)
+
+// DokkuMaintenanceAdapter implements the maintenance repository using Dokku CLI
+type DokkuMaintenanceAdapter struct {
+	client dokkuApi.DokkuClient
+	logger *slog.Logger
+}
+
+// NewDokkuMaintenanceAdapter creates a new maintenance adapter
+func NewDokkuMaintenanceAdapter(client dokkuApi.DokkuClient, logger *slog.Logger) domain.MaintenanceRepository {
+	return &DokkuMaintenanceAdapter{
+		client: client,
+		logger: logger,
+	}
+}
+
+// executeCommand wraps the client's ExecuteCommand with maintenance-specific context and validation
+func (a *DokkuMaintenanceAdapter) executeCommand(ctx context.Context, command domain.MaintenanceCommand, args []string) ([]byte, error) {
+	if !command.IsValid() {
+		return nil, fmt.Errorf("invalid maintenance command: %s", command)
+	}
+	return a.client.ExecuteCommand(ctx, command.String(), args)
+}
+
+// IsDeployed reports whether an application has been deployed, from ps:report
+func (a *DokkuMaintenanceAdapter) IsDeployed(ctx context.Context, appName string) (bool, error) {
+	output, err := a.executeCommand(ctx, domain.CommandPsReport, []string{appName, "--deployed"})
+	if err != nil {
+		return false, fmt.Errorf("failed to get the deployment state of %s: %w", appName, err)
+	}
+	value, _ := dokkuApi.StripWarningLines(string(output))
+	return strings.TrimSpace(value) == "true", nil
+}
+
+// ListStatuses retrieves the maintenance mode of every application from maintenance:report
+func (a *DokkuMaintenanceAdapter) ListStatuses(ctx context.Context) ([]domain.MaintenanceStatus, error) {
+	output, err := a.executeCommand(ctx, domain.CommandMaintenanceReport, []string{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the maintenance report: %w", err)
+	}
+	return parseMaintenanceReport(string(output)), nil
+}
+
+// Enable puts an application behind the maintenance page
+func (a *DokkuMaintenanceAdapter) Enable(ctx context.Context, appName string) error {
+	if _, err := a.executeCommand(ctx, domain.CommandMaintenanceEnable, []string{appName}); err != nil {
+		return fmt.Errorf("failed to enable maintenance for %s: %w", appName, err)
+	}
+	return nil
+}
+
+// Disable serves an application again
+func (a *DokkuMaintenanceAdapter) Disable(ctx context.Context, appName string) error {
+	if _, err := a.executeCommand(ctx, domain.CommandMaintenanceDisable, []string{appName}); err != nil {
+		return fmt.Errorf("failed to disable maintenance for %s: %w", appName, err)
+	}
+	return nil
+}
+
+// parseMaintenanceReport reads the maintenance:report sections, one per application:
+//
+//	=====> my-app maintenance information
+//	       Maintenance enabled:           false
+func parseMaintenanceReport(output string) []domain.MaintenanceStatus {
+	output, _ = dokkuApi.StripWarningLines(output)
+
+	statuses := []domain.MaintenanceStatus{}
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+
+		if header, ok := strings.CutPrefix(line, "=====>"); ok {
+			app, _, _ := strings.Cut(strings.TrimSpace(header), " ")
+			if app != "" {
+				statuses = append(statuses, domain.MaintenanceStatus{App: app})
+			}
+			continue
+		}
+
+		key, value, ok := dokkuApi.ParseColonKeyValueLine(line)
+		if !ok || len(statuses) == 0 || !strings.EqualFold(key, "Maintenance enabled") {
+			continue
+		}
+		statuses[len(statuses)-1].Enabled = value == "true"
+	}
+	return statuses
+}
@@ -0,0 +1,30 @@
+package infrastructure
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/maintenance/domain"
+)
+
+func TestParseMaintenanceReport(t *testing.T) {
+	statuses := parseMaintenanceReport(`=====> my-app maintenance information
+       Maintenance enabled:           true
+=====> other-app maintenance information
+       Maintenance enabled:           false
+`)
+
+	want := []domain.MaintenanceStatus{
+		{App: "my-app", Enabled: true},
+		{App: "other-app", Enabled: false},
+	}
+	if !reflect.DeepEqual(statuses, want) {
+		t.Fatalf("expected %+v, got %+v", want, statuses)
+	}
+}
+
+func TestParseMaintenanceReportWithoutApps(t *testing.T) {
+	if statuses := parseMaintenanceReport(" !     You haven't deployed any applications yet\n"); len(statuses) != 0 {
+		t.Fatalf("expected no statuses, got %+v", statuses)
+	}
+}
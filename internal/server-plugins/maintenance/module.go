@@ -0,0 +1,16 @@
+package maintenance
+
+import (
+	serverDomain "github.com/dokku-mcp/dokku-mcp/internal/server-plugin/domain"
+	"go.uber.org/fx"
+)
+
+var Module = fx.Module("maintenance",
+	fx.Provide(
+		fx.Annotate(
+			NewMaintenanceServerPlugin,
+			fx.As(new(serverDomain.ServerPlugin)),
+			fx.ResultTags(`group:"server_plugins"`),
+		),
+	),
+)
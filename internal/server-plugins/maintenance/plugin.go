@@ -0,0 +1,126 @@
+package maintenance
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	dokkuApi "github.com/dokku-mcp/dokku-mcp/internal/dokku-api"
+	serverDomain "github.com/dokku-mcp/dokku-mcp/internal/server-plugin/domain"
+	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/maintenance/application"
+	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/maintenance/infrastructure"
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// MaintenanceServerPlugin puts applications behind a maintenance page through the dokku-maintenance plugin
+type MaintenanceServerPlugin struct {
+	maintenanceService *application.MaintenanceService
+	logger             *slog.Logger
+}
+
+// NewMaintenanceServerPlugin creates a new maintenance server plugin
+func NewMaintenanceServerPlugin(client dokkuApi.DokkuClient, logger *slog.Logger) serverDomain.ServerPlugin {
+	adapter := infrastructure.NewDokkuMaintenanceAdapter(client, logger)
+	maintenanceService := application.NewMaintenanceService(adapter, logger)
+	return &MaintenanceServerPlugin{
+		maintenanceService: maintenanceService,
+		logger:             logger,
+	}
+}
+
+func (p *MaintenanceServerPlugin) ID() string   { return "maintenance" }
+func (p *MaintenanceServerPlugin) Name() string { return "Dokku Maintenance" }
+func (p *MaintenanceServerPlugin) Description() string {
+	return "Puts applications behind a 503 maintenance page"
+}
+func (p *MaintenanceServerPlugin) Version() string         { return "0.1.0" }
+func (p *MaintenanceServerPlugin) DokkuPluginName() string { return "maintenance" }
+
+// ResourceProvider implementation
+func (p *MaintenanceServerPlugin) GetResources(ctx context.Context) ([]serverDomain.Resource, error) {
+	return []serverDomain.Resource{
+		{
+			URI:         "dokku://maintenance/status",
+			Name:        "Maintenance Status",
+			Description: "Whether each application is in maintenance mode",
+			MIMEType:    "application/json",
+			Handler:     p.handleMaintenanceStatusResource,
+		},
+	}, nil
+}
+
+// ToolProvider implementation
+func (p *MaintenanceServerPlugin) GetTools(ctx context.Context) ([]serverDomain.Tool, error) {
+	return []serverDomain.Tool{
+		{
+			Name:        "enable_maintenance",
+			Description: "Put an application behind the maintenance page",
+			Builder:     p.buildEnableMaintenanceTool,
+			Handler:     p.handleEnableMaintenance,
+		},
+		{
+			Name:        "disable_maintenance",
+			Description: "Take an application out of maintenance",
+			Builder:     p.buildDisableMaintenanceTool,
+			Handler:     p.handleDisableMaintenance,
+		},
+	}, nil
+}
+
+func (p *MaintenanceServerPlugin) handleMaintenanceStatusResource(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	statuses, err := p.maintenanceService.ListStatuses(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get maintenance status: %w", err)
+	}
+	jsonData, err := shared.MarshalOutput(statuses)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize maintenance status: %w", err)
+	}
+	return []mcp.ResourceContents{
+		mcp.TextResourceContents{
+			URI:      req.Params.URI,
+			MIMEType: "application/json",
+			Text:     string(jsonData),
+		},
+	}, nil
+}
+
+func (p *MaintenanceServerPlugin) buildEnableMaintenanceTool() mcp.Tool {
+	return mcp.NewTool(
+		"enable_maintenance",
+		mcp.WithDescription("Put an application behind a 503 maintenance page (maintenance:enable); its processes keep running. Use disable_maintenance to serve it again"),
+		mcp.WithString("app_name",
+			mcp.Required(),
+			mcp.Description("Name of the application"),
+		),
+	)
+}
+
+func (p *MaintenanceServerPlugin) handleEnableMaintenance(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return serverDomain.HandleAppChange(ctx, req, "enable maintenance", func(ctx context.Context, appName string) (string, error) {
+		deployed, err := p.maintenanceService.Enable(ctx, appName)
+		message := fmt.Sprintf("✅ Maintenance enabled for '%s'", appName)
+		if !deployed {
+			message += fmt.Sprintf("\n⚠️ '%s' has not been deployed yet: the maintenance page only shows once it is deployed", appName)
+		}
+		return message, err
+	})
+}
+
+func (p *MaintenanceServerPlugin) buildDisableMaintenanceTool() mcp.Tool {
+	return mcp.NewTool(
+		"disable_maintenance",
+		mcp.WithDescription("Take an application out of maintenance (maintenance:disable); it serves its traffic again"),
+		mcp.WithString("app_name",
+			mcp.Required(),
+			mcp.Description("Name of the application"),
+		),
+	)
+}
+
+func (p *MaintenanceServerPlugin) handleDisableMaintenance(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return serverDomain.HandleAppChange(ctx, req, "disable maintenance", func(ctx context.Context, appName string) (string, error) {
+		return fmt.Sprintf("✅ Maintenance disabled for '%s'", appName), p.maintenanceService.Disable(ctx, appName)
+	})
+}
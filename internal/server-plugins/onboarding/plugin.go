@@ -2,13 +2,13 @@ package onboarding
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"time"
 
 	mcpserver "github.com/dokku-mcp/dokku-mcp/internal/server"
 	serverDomain "github.com/dokku-mcp/dokku-mcp/internal/server-plugin/domain"
 	onbDomain "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/onboarding/domain"
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
@@ -140,7 +140,7 @@ func (p *OnboardingServerPlugin) handleCapabilitiesIndexResource(ctx context.Con
 	index.Resources = resources
 	index.Prompts = caps.Prompts
 
-	b, err := json.MarshalIndent(index, "", "  ")
+	b, err := shared.MarshalOutput(index)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal capabilities index: %w", err)
 	}
@@ -155,7 +155,7 @@ func (p *OnboardingServerPlugin) handleIntentMapResource(ctx context.Context, re
 		"configure": {Synonyms: []string{"set env", "set variables", "secrets", "config"}, Tool: "configure_app", Params: []string{"app_name", "config"}},
 		"create":    {Synonyms: []string{"new app", "provision", "bootstrap"}, Tool: "create_app", Params: []string{"name", "buildpack"}},
 	}
-	jsonData, err := json.MarshalIndent(mapping, "", "  ")
+	jsonData, err := shared.MarshalOutput(mapping)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal intent map: %w", err)
 	}
@@ -0,0 +1,90 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/postgres/domain"
+)
+
+// PostgresService provides application-level orchestration for Postgres services
+type PostgresService struct {
+	postgresRepo domain.PostgresRepository
+	logger       *slog.Logger
+}
+
+// NewPostgresService creates a new postgres application service
+func NewPostgresService(postgresRepo domain.PostgresRepository, logger *slog.Logger) *PostgresService {
+	return &PostgresService{
+		postgresRepo: postgresRepo,
+		logger:       logger,
+	}
+}
+
+// ListServices lists the Postgres services of the host
+func (s *PostgresService) ListServices(ctx context.Context) ([]domain.Service, error) {
+	return s.postgresRepo.ListServices(ctx)
+}
+
+// GetService retrieves the detail of a Postgres service
+func (s *PostgresService) GetService(ctx context.Context, name string) (*domain.ServiceInfo, error) {
+	if err := domain.ValidateServiceName(name); err != nil {
+		return nil, err
+	}
+	return s.postgresRepo.GetService(ctx, name)
+}
+
+// CreateService validates and creates a Postgres service
+func (s *PostgresService) CreateService(ctx context.Context, name, imageVersion string) error {
+	if err := domain.ValidateServiceName(name); err != nil {
+		return err
+	}
+
+	s.logger.Info("Creating postgres service", "service", name, "image_version", imageVersion)
+	return s.postgresRepo.CreateService(ctx, name, imageVersion)
+}
+
+// DestroyService destroys a Postgres service once its name has been confirmed
+func (s *PostgresService) DestroyService(ctx context.Context, name, confirmation string) error {
+	if err := domain.ValidateServiceName(name); err != nil {
+		return err
+	}
+	if confirmation != name {
+		return fmt.Errorf("confirmation %q does not match service name %q", confirmation, name)
+	}
+
+	s.logger.Warn("Destroying postgres service", "service", name)
+	return s.postgresRepo.DestroyService(ctx, name)
+}
+
+// LinkService links a Postgres service to an application
+func (s *PostgresService) LinkService(ctx context.Context, name, appName, alias string) error {
+	if err := domain.ValidateServiceName(name); err != nil {
+		return err
+	}
+	if appName == "" {
+		return fmt.Errorf("app name cannot be empty")
+	}
+	if alias != "" {
+		if err := domain.ValidateAlias(alias); err != nil {
+			return err
+		}
+	}
+
+	s.logger.Info("Linking postgres service", "service", name, "app_name", appName, "alias", alias)
+	return s.postgresRepo.LinkService(ctx, name, appName, alias)
+}
+
+// UnlinkService unlinks a Postgres service from an application
+func (s *PostgresService) UnlinkService(ctx context.Context, name, appName string) error {
+	if err := domain.ValidateServiceName(name); err != nil {
+		return err
+	}
+	if appName == "" {
+		return fmt.Errorf("app name cannot be empty")
+	}
+
+	s.logger.Info("Unlinking postgres service", "service", name, "app_name", appName)
+	return s.postgresRepo.UnlinkService(ctx, name, appName)
+}
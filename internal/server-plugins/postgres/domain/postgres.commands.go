@@ -0,0 +1,41 @@
+package domain
+
+// PostgresCommand represents allowed Dokku commands for the postgres plugin
+type PostgresCommand string
+
+const (
+	CommandPostgresList    PostgresCommand = "postgres:list"
+	CommandPostgresInfo    PostgresCommand = "postgres:info"
+	CommandPostgresCreate  PostgresCommand = "postgres:create"
+	CommandPostgresLink    PostgresCommand = "postgres:link"
+	CommandPostgresUnlink  PostgresCommand = "postgres:unlink"
+	CommandPostgresDestroy PostgresCommand = "postgres:destroy"
+)
+
+// IsValid checks if the command is a valid postgres command
+func (c PostgresCommand) IsValid() bool {
+	switch c {
+	case CommandPostgresList, CommandPostgresInfo, CommandPostgresCreate,
+		CommandPostgresLink, CommandPostgresUnlink, CommandPostgresDestroy:
+		return true
+	default:
+		return false
+	}
+}
+
+// String returns the string representation of the command
+func (c PostgresCommand) String() string {
+	return string(c)
+}
+
+// GetAllowedCommands returns all allowed postgres commands
+func GetAllowedCommands() []PostgresCommand {
+	return []PostgresCommand{
+		CommandPostgresList,
+		CommandPostgresInfo,
+		CommandPostgresCreate,
+		CommandPostgresLink,
+		CommandPostgresUnlink,
+		CommandPostgresDestroy,
+	}
+}
@@ -0,0 +1,15 @@
+package domain
+
+import (
+	"context"
+)
+
+// PostgresRepository defines methods for managing Postgres services
+type PostgresRepository interface {
+	ListServices(ctx context.Context) ([]Service, error)
+	GetService(ctx context.Context, name string) (*ServiceInfo, error)
+	CreateService(ctx context.Context, name, imageVersion string) error
+	DestroyService(ctx context.Context, name string) error
+	LinkService(ctx context.Context, name, appName, alias string) error
+	UnlinkService(ctx context.Context, name, appName string) error
+}
@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
+)
+
+var (
+	// ErrInvalidServiceName is returned when a service name would be rejected by the postgres plugin
+	ErrInvalidServiceName = errors.New("invalid service name")
+	// ErrInvalidAlias is returned when a link alias is not a valid environment variable prefix
+	ErrInvalidAlias = errors.New("invalid link alias")
+)
+
+// Service is a Postgres service as listed by postgres:list
+type Service struct {
+	Name         string   `json:"name"`
+	Version      string   `json:"version,omitempty"`
+	Status       string   `json:"status,omitempty"`
+	ExposedPorts string   `json:"exposed_ports,omitempty"`
+	Links        []string `json:"links"`
+}
+
+// ServiceInfo is the detail of a Postgres service from postgres:info.
+// The DSN password is masked since the output goes back to the client.
+type ServiceInfo struct {
+	Service
+	DSN        string `json:"dsn,omitempty"`
+	InternalIP string `json:"internal_ip,omitempty"`
+	DataDir    string `json:"data_dir,omitempty"`
+}
+
+var (
+	serviceNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,62}$`)
+	aliasPattern       = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+)
+
+// ValidateServiceName checks that a service name is a lowercase slug
+func ValidateServiceName(name string) error {
+	if !serviceNamePattern.MatchString(name) {
+		return fmt.Errorf("%w: %q must be lowercase letters, digits, dashes and underscores", ErrInvalidServiceName, name)
+	}
+	return nil
+}
+
+// ValidateAlias checks that a link alias can prefix the environment variable set on the app (e.g. DATABASE)
+func ValidateAlias(alias string) error {
+	if !aliasPattern.MatchString(alias) {
+		return fmt.Errorf("%w: %q must be uppercase letters, digits and underscores", ErrInvalidAlias, alias)
+	}
+	return nil
+}
+
+// MaskDSN replaces the password of a connection URL
+func MaskDSN(dsn string) string {
+	parsed, err := url.Parse(dsn)
+	if err != nil || parsed.User == nil {
+		return dsn
+	}
+	if _, hasPassword := parsed.User.Password(); !hasPassword {
+		return dsn
+	}
+	// url.URL escapes "*" in user info, so swap the placeholder Redacted uses
+	return strings.Replace(parsed.Redacted(), ":xxxxx@", ":"+shared.MaskedValue+"@", 1)
+}
@@ -0,0 +1,15 @@
+//go:build !integration
+
+package domain_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestPostgres(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "[Server Plugins] - Postgres Domain Layer")
+}
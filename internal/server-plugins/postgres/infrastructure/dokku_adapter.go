@@ -0,0 +1,155 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	dokkuApi "github.com/dokku-mcp/dokku-mcp/internal/dokku-api"
+	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/postgres/domain"
+)
+
+// DokkuPostgresAdapter implements the postgres repository using Dokku CLI
+type DokkuPostgresAdapter struct {
+	client dokkuApi.DokkuClient
+	logger *slog.Logger
+}
+
+// NewDokkuPostgresAdapter creates a new postgres adapter
+func NewDokkuPostgresAdapter(client dokkuApi.DokkuClient, logger *slog.Logger) domain.PostgresRepository {
+	return &DokkuPostgresAdapter{
+		client: client,
+		logger: logger,
+	}
+}
+
+// executeCommand wraps the client's ExecuteCommand with postgres-specific context and validation
+func (a *DokkuPostgresAdapter) executeCommand(ctx context.Context, command domain.PostgresCommand, args []string) ([]byte, error) {
+	if !command.IsValid() {
+		return nil, fmt.Errorf("invalid postgres command: %s", command)
+	}
+	return a.client.ExecuteCommand(ctx, command.String(), args)
+}
+
+// ListServices retrieves the Postgres services from postgres:list
+func (a *DokkuPostgresAdapter) ListServices(ctx context.Context) ([]domain.Service, error) {
+	output, err := a.executeCommand(ctx, domain.CommandPostgresList, []string{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list postgres services: %w", err)
+	}
+	return parseServiceList(string(output)), nil
+}
+
+// GetService retrieves the detail of a Postgres service from postgres:info
+func (a *DokkuPostgresAdapter) GetService(ctx context.Context, name string) (*domain.ServiceInfo, error) {
+	output, err := a.executeCommand(ctx, domain.CommandPostgresInfo, []string{name})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get postgres service %s: %w", name, err)
+	}
+	return parseServiceInfo(name, string(output)), nil
+}
+
+// CreateService creates a Postgres service, optionally pinning the image version
+func (a *DokkuPostgresAdapter) CreateService(ctx context.Context, name, imageVersion string) error {
+	args := []string{name}
+	if imageVersion != "" {
+		args = append(args, "--image-version", imageVersion)
+	}
+	if _, err := a.executeCommand(ctx, domain.CommandPostgresCreate, args); err != nil {
+		return fmt.Errorf("failed to create postgres service %s: %w", name, err)
+	}
+	return nil
+}
+
+// DestroyService destroys a Postgres service and its data
+func (a *DokkuPostgresAdapter) DestroyService(ctx context.Context, name string) error {
+	if _, err := a.executeCommand(ctx, domain.CommandPostgresDestroy, []string{name, "--force"}); err != nil {
+		return fmt.Errorf("failed to destroy postgres service %s: %w", name, err)
+	}
+	return nil
+}
+
+// LinkService links a Postgres service to an application, exposing it as <alias>_URL
+func (a *DokkuPostgresAdapter) LinkService(ctx context.Context, name, appName, alias string) error {
+	args := []string{name, appName}
+	if alias != "" {
+		args = append(args, "--alias", alias)
+	}
+	if _, err := a.executeCommand(ctx, domain.CommandPostgresLink, args); err != nil {
+		return fmt.Errorf("failed to link postgres service %s to %s: %w", name, appName, err)
+	}
+	return nil
+}
+
+// UnlinkService unlinks a Postgres service from an application
+func (a *DokkuPostgresAdapter) UnlinkService(ctx context.Context, name, appName string) error {
+	if _, err := a.executeCommand(ctx, domain.CommandPostgresUnlink, []string{name, appName}); err != nil {
+		return fmt.Errorf("failed to unlink postgres service %s from %s: %w", name, appName, err)
+	}
+	return nil
+}
+
+// parseServiceList reads postgres:list, which prints either a table
+// (NAME VERSION STATUS EXPOSED PORTS LINKS) or, on recent plugin versions, only the names
+func parseServiceList(output string) []domain.Service {
+	output, _ = dokkuApi.StripWarningLines(output)
+
+	services := []domain.Service{}
+	for _, fields := range dokkuApi.ParseFieldsOutput(output, true) {
+		service := domain.Service{Name: fields[0], Links: []string{}}
+		if len(fields) >= 3 {
+			service.Version = fields[1]
+			service.Status = fields[2]
+		}
+		if len(fields) >= 4 && fields[3] != "-" {
+			service.ExposedPorts = fields[3]
+		}
+		if len(fields) >= 5 {
+			service.Links = parseLinks(strings.Join(fields[4:], " "))
+		}
+		services = append(services, service)
+	}
+	return services
+}
+
+// parseServiceInfo reads the key/value report printed by postgres:info
+func parseServiceInfo(name, output string) *domain.ServiceInfo {
+	info := make(map[string]string)
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "=====>") {
+			continue
+		}
+		if key, value, ok := dokkuApi.ParseColonKeyValueLine(line); ok {
+			info[strings.ToLower(key)] = value
+		}
+	}
+
+	service := &domain.ServiceInfo{
+		Service: domain.Service{
+			Name:    name,
+			Version: info["version"],
+			Status:  info["status"],
+			Links:   parseLinks(info["links"]),
+		},
+		DSN:        domain.MaskDSN(info["dsn"]),
+		InternalIP: info["internal ip"],
+		DataDir:    info["data dir"],
+	}
+	if ports := info["exposed ports"]; ports != "-" {
+		service.ExposedPorts = ports
+	}
+	return service
+}
+
+// parseLinks splits a list of linked apps separated by commas or spaces; "-" means none
+func parseLinks(value string) []string {
+	links := []string{}
+	for _, link := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ' ' }) {
+		if link != "-" {
+			links = append(links, link)
+		}
+	}
+	return links
+}
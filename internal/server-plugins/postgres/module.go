@@ -0,0 +1,16 @@
+package postgres
+
+import (
+	serverDomain "github.com/dokku-mcp/dokku-mcp/internal/server-plugin/domain"
+	"go.uber.org/fx"
+)
+
+var Module = fx.Module("postgres",
+	fx.Provide(
+		fx.Annotate(
+			NewPostgresServerPlugin,
+			fx.As(new(serverDomain.ServerPlugin)),
+			fx.ResultTags(`group:"server_plugins"`),
+		),
+	),
+)
@@ -0,0 +1,260 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	dokkuApi "github.com/dokku-mcp/dokku-mcp/internal/dokku-api"
+	serverDomain "github.com/dokku-mcp/dokku-mcp/internal/server-plugin/domain"
+	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/postgres/application"
+	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/postgres/infrastructure"
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// PostgresServerPlugin provides Postgres service management through the dokku-postgres plugin
+type PostgresServerPlugin struct {
+	postgresService *application.PostgresService
+	logger          *slog.Logger
+}
+
+// NewPostgresServerPlugin creates a new postgres server plugin
+func NewPostgresServerPlugin(client dokkuApi.DokkuClient, logger *slog.Logger) serverDomain.ServerPlugin {
+	adapter := infrastructure.NewDokkuPostgresAdapter(client, logger)
+	postgresService := application.NewPostgresService(adapter, logger)
+	return &PostgresServerPlugin{
+		postgresService: postgresService,
+		logger:          logger,
+	}
+}
+
+func (p *PostgresServerPlugin) ID() string   { return "postgres" }
+func (p *PostgresServerPlugin) Name() string { return "Dokku Postgres" }
+func (p *PostgresServerPlugin) Description() string {
+	return "Creates Postgres services and links them to applications"
+}
+func (p *PostgresServerPlugin) Version() string         { return "0.1.0" }
+func (p *PostgresServerPlugin) DokkuPluginName() string { return "postgres" }
+
+// ResourceProvider implementation
+func (p *PostgresServerPlugin) GetResources(ctx context.Context) ([]serverDomain.Resource, error) {
+	return []serverDomain.Resource{
+		{
+			URI:         "dokku://postgres/services",
+			Name:        "Postgres Services",
+			Description: "Postgres services with their version, status and linked applications",
+			MIMEType:    "application/json",
+			Handler:     p.handlePostgresServicesResource,
+		},
+	}, nil
+}
+
+// ToolProvider implementation
+func (p *PostgresServerPlugin) GetTools(ctx context.Context) ([]serverDomain.Tool, error) {
+	return []serverDomain.Tool{
+		{
+			Name:        "create_postgres_service",
+			Description: "Create a Postgres service",
+			Builder:     p.buildCreatePostgresServiceTool,
+			Handler:     p.handleCreatePostgresService,
+		},
+		{
+			Name:        "get_postgres_service_info",
+			Description: "Get the detail of a Postgres service",
+			Builder:     p.buildGetPostgresServiceInfoTool,
+			Handler:     p.handleGetPostgresServiceInfo,
+		},
+		{
+			Name:        "link_postgres_service",
+			Description: "Link a Postgres service to an application",
+			Builder:     p.buildLinkPostgresServiceTool,
+			Handler:     p.handleLinkPostgresService,
+		},
+		{
+			Name:        "unlink_postgres_service",
+			Description: "Unlink a Postgres service from an application",
+			Builder:     p.buildUnlinkPostgresServiceTool,
+			Handler:     p.handleUnlinkPostgresService,
+		},
+		{
+			Name:        "destroy_postgres_service",
+			Description: "Destroy a Postgres service and its data",
+			Builder:     p.buildDestroyPostgresServiceTool,
+			Handler:     p.handleDestroyPostgresService,
+		},
+	}, nil
+}
+
+func (p *PostgresServerPlugin) handlePostgresServicesResource(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	services, err := p.postgresService.ListServices(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list postgres services: %w", err)
+	}
+	jsonData, err := shared.MarshalOutput(services)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize postgres services: %w", err)
+	}
+	return []mcp.ResourceContents{
+		mcp.TextResourceContents{
+			URI:      req.Params.URI,
+			MIMEType: "application/json",
+			Text:     string(jsonData),
+		},
+	}, nil
+}
+
+func (p *PostgresServerPlugin) buildCreatePostgresServiceTool() mcp.Tool {
+	return mcp.NewTool(
+		"create_postgres_service",
+		mcp.WithDescription("Create a Postgres service (postgres:create). Link it to an application with link_postgres_service to expose DATABASE_URL"),
+		mcp.WithString("service_name",
+			mcp.Required(),
+			mcp.Description("Name of the service (lowercase letters, digits, dashes and underscores)"),
+		),
+		mcp.WithString("image_version",
+			mcp.Description("Postgres image version to run (e.g. 16.2); the plugin default otherwise"),
+		),
+	)
+}
+
+func (p *PostgresServerPlugin) handleCreatePostgresService(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	name, err := req.RequireString("service_name")
+	if err != nil {
+		return mcp.NewToolResultError("Service name is required"), nil
+	}
+
+	if err := p.postgresService.CreateService(ctx, name, req.GetString("image_version", "")); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to create postgres service: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("✅ Postgres service '%s' created", name)), nil
+}
+
+func (p *PostgresServerPlugin) buildGetPostgresServiceInfoTool() mcp.Tool {
+	return mcp.NewTool(
+		"get_postgres_service_info",
+		mcp.WithDescription("Get the detail of a Postgres service as JSON (postgres:info): version, status, linked applications and connection URL with the password masked"),
+		mcp.WithString("service_name",
+			mcp.Required(),
+			mcp.Description("Name of the service"),
+		),
+	)
+}
+
+func (p *PostgresServerPlugin) handleGetPostgresServiceInfo(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	name, err := req.RequireString("service_name")
+	if err != nil {
+		return mcp.NewToolResultError("Service name is required"), nil
+	}
+
+	info, err := p.postgresService.GetService(ctx, name)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get postgres service: %v", err)), nil
+	}
+
+	jsonData, err := shared.MarshalOutput(info)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to encode postgres service: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(jsonData)), nil
+}
+
+func (p *PostgresServerPlugin) buildLinkPostgresServiceTool() mcp.Tool {
+	return mcp.NewTool(
+		"link_postgres_service",
+		mcp.WithDescription("Link a Postgres service to an application (postgres:link). The connection URL is set as DATABASE_URL (or <alias>_URL) and the application restarts"),
+		mcp.WithString("service_name",
+			mcp.Required(),
+			mcp.Description("Name of the service"),
+		),
+		mcp.WithString("app_name",
+			mcp.Required(),
+			mcp.Description("Name of the application"),
+		),
+		mcp.WithString("alias",
+			mcp.Description("Environment variable prefix for the connection URL (default: DATABASE)"),
+		),
+	)
+}
+
+func (p *PostgresServerPlugin) handleLinkPostgresService(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	name, err := req.RequireString("service_name")
+	if err != nil {
+		return mcp.NewToolResultError("Service name is required"), nil
+	}
+	appName, err := req.RequireString("app_name")
+	if err != nil {
+		return mcp.NewToolResultError("Application name is required"), nil
+	}
+
+	if err := p.postgresService.LinkService(ctx, name, appName, req.GetString("alias", "")); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to link postgres service: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("✅ Postgres service '%s' linked to '%s'", name, appName)), nil
+}
+
+func (p *PostgresServerPlugin) buildUnlinkPostgresServiceTool() mcp.Tool {
+	return mcp.NewTool(
+		"unlink_postgres_service",
+		mcp.WithDescription("Unlink a Postgres service from an application (postgres:unlink). The connection URL is removed and the application restarts; the data is kept"),
+		mcp.WithString("service_name",
+			mcp.Required(),
+			mcp.Description("Name of the service"),
+		),
+		mcp.WithString("app_name",
+			mcp.Required(),
+			mcp.Description("Name of the application"),
+		),
+	)
+}
+
+func (p *PostgresServerPlugin) handleUnlinkPostgresService(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	name, err := req.RequireString("service_name")
+	if err != nil {
+		return mcp.NewToolResultError("Service name is required"), nil
+	}
+	appName, err := req.RequireString("app_name")
+	if err != nil {
+		return mcp.NewToolResultError("Application name is required"), nil
+	}
+
+	if err := p.postgresService.UnlinkService(ctx, name, appName); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to unlink postgres service: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("✅ Postgres service '%s' unlinked from '%s'", name, appName)), nil
+}
+
+func (p *PostgresServerPlugin) buildDestroyPostgresServiceTool() mcp.Tool {
+	return mcp.NewTool(
+		"destroy_postgres_service",
+		mcp.WithDescription("Destroy a Postgres service and ALL its data (postgres:destroy). This cannot be undone; unlink it from its applications first"),
+		mcp.WithString("service_name",
+			mcp.Required(),
+			mcp.Description("Name of the service"),
+		),
+		mcp.WithString("confirm_name",
+			mcp.Required(),
+			mcp.Description("Repeat the service name to confirm the destruction"),
+		),
+	)
+}
+
+func (p *PostgresServerPlugin) handleDestroyPostgresService(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	name, err := req.RequireString("service_name")
+	if err != nil {
+		return mcp.NewToolResultError("Service name is required"), nil
+	}
+	confirmation, err := req.RequireString("confirm_name")
+	if err != nil {
+		return mcp.NewToolResultError("Confirmation is required: repeat the service name in confirm_name"), nil
+	}
+
+	if err := p.postgresService.DestroyService(ctx, name, confirmation); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to destroy postgres service: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("✅ Postgres service '%s' destroyed", name)), nil
+}
@@ -0,0 +1,85 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/storage/domain"
+)
+
+// StorageService provides application-level orchestration for persistent storage
+type StorageService struct {
+	storageRepo domain.StorageRepository
+	logger      *slog.Logger
+}
+
+// NewStorageService creates a new storage application service
+func NewStorageService(storageRepo domain.StorageRepository, logger *slog.Logger) *StorageService {
+	return &StorageService{
+		storageRepo: storageRepo,
+		logger:      logger,
+	}
+}
+
+// ListMounts lists the bind mounts of an application
+func (s *StorageService) ListMounts(ctx context.Context, appName string) ([]domain.Mount, error) {
+	if appName == "" {
+		return nil, fmt.Errorf("app name cannot be empty")
+	}
+	return s.storageRepo.ListMounts(ctx, appName)
+}
+
+// ListAllMounts lists the bind mounts of every application, keyed by app name
+func (s *StorageService) ListAllMounts(ctx context.Context) (map[string][]domain.Mount, error) {
+	apps, err := s.storageRepo.ListApps(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	mounts := make(map[string][]domain.Mount, len(apps))
+	for _, appName := range apps {
+		appMounts, err := s.storageRepo.ListMounts(ctx, appName)
+		if err != nil {
+			s.logger.Warn("Failed to list storage mounts", "app_name", appName, "error", err)
+			continue
+		}
+		mounts[appName] = appMounts
+	}
+	return mounts, nil
+}
+
+// Mount validates the paths and bind-mounts a host directory into an application
+func (s *StorageService) Mount(ctx context.Context, appName, hostPath, containerPath string) (domain.Mount, error) {
+	if appName == "" {
+		return domain.Mount{}, fmt.Errorf("app name cannot be empty")
+	}
+	mount, err := domain.NewMount(hostPath, containerPath)
+	if err != nil {
+		return domain.Mount{}, err
+	}
+
+	s.logger.Info("Mounting storage", "app_name", appName, "mount", mount.String())
+	if err := s.storageRepo.Mount(ctx, appName, mount); err != nil {
+		return domain.Mount{}, err
+	}
+	return mount, nil
+}
+
+// Unmount validates the paths and removes a bind mount of an application; any
+// existing host path can be unmounted, including ones NewMount would refuse
+func (s *StorageService) Unmount(ctx context.Context, appName, hostPath, containerPath string) (domain.Mount, error) {
+	if appName == "" {
+		return domain.Mount{}, fmt.Errorf("app name cannot be empty")
+	}
+	mount, err := domain.ParseMount(hostPath, containerPath)
+	if err != nil {
+		return domain.Mount{}, err
+	}
+
+	s.logger.Info("Unmounting storage", "app_name", appName, "mount", mount.String())
+	if err := s.storageRepo.Unmount(ctx, appName, mount); err != nil {
+		return domain.Mount{}, err
+	}
+	return mount, nil
+}
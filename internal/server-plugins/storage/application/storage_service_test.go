@@ -0,0 +1,39 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/server-plugin/plugintest"
+	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/storage/domain"
+)
+
+// fakeStorageRepository records the mounts it is asked to create
+type fakeStorageRepository struct {
+	domain.StorageRepository
+	mounted plugintest.Calls[domain.Mount]
+}
+
+func (f *fakeStorageRepository) Mount(ctx context.Context, appName string, mount domain.Mount) error {
+	f.mounted.Record(mount)
+	return nil
+}
+
+func TestMountRunsStorageMount(t *testing.T) {
+	repo := &fakeStorageRepository{}
+
+	mount, err := NewStorageService(repo, plugintest.Logger()).Mount(context.Background(), "my-app", "/var/lib/dokku/data/storage/my-app", "/app/storage")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(repo.mounted) != 1 || repo.mounted[0] != mount {
+		t.Fatalf("expected storage:mount for %s, got %v", mount, repo.mounted)
+	}
+}
+
+func TestMountRejectsTraversalBeforeExecuting(t *testing.T) {
+	repo := &fakeStorageRepository{}
+
+	_, err := NewStorageService(repo, plugintest.Logger()).Mount(context.Background(), "my-app", "/var/lib/dokku/data/storage/../../../../etc", "/app/etc")
+	plugintest.ExpectRejectedBeforeExecuting(t, err, domain.ErrInvalidMount, "storage:mount", repo.mounted)
+}
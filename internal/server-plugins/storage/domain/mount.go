@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ErrInvalidMount is returned when a host or container path cannot be mounted safely
+var ErrInvalidMount = errors.New("invalid storage mount")
+
+// Mount is a bind mount of a host directory into the containers of an application
+type Mount struct {
+	HostPath      string `json:"host_path"`
+	ContainerPath string `json:"container_path"`
+	// Options are the volume options Dokku reports after the paths (e.g. ro)
+	Options string `json:"options,omitempty"`
+}
+
+// StorageRoot is the directory Dokku keeps persistent storage in (storage:ensure-directory)
+const StorageRoot = "/var/lib/dokku/data/storage"
+
+// volumeNamePattern matches the names Docker accepts for named volumes
+var volumeNamePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]+$`)
+
+// NewMount validates the paths of a new bind mount. The host path must be a
+// directory under StorageRoot or a Docker volume name, so that host system
+// paths (/, /etc, the Docker socket...) are never exposed to an app; the
+// container path must be absolute.
+func NewMount(hostPath, containerPath string) (Mount, error) {
+	mount, err := ParseMount(hostPath, containerPath)
+	if err != nil {
+		return Mount{}, err
+	}
+
+	if !volumeNamePattern.MatchString(mount.HostPath) && !strings.HasPrefix(mount.HostPath, StorageRoot+"/") {
+		return Mount{}, fmt.Errorf("%w: host path must be a directory under %s or a Docker volume name: %q",
+			ErrInvalidMount, StorageRoot, mount.HostPath)
+	}
+
+	return mount, nil
+}
+
+// ParseMount validates the syntax of the paths of an existing bind mount: the
+// host path cannot walk up with '..' and the container path must be absolute.
+// Unlike NewMount it accepts any host path, so that mounts created outside the
+// server can still be removed.
+func ParseMount(hostPath, containerPath string) (Mount, error) {
+	hostPath = strings.TrimSpace(hostPath)
+	containerPath = strings.TrimSpace(containerPath)
+
+	if hostPath == "" {
+		return Mount{}, fmt.Errorf("%w: host path cannot be empty", ErrInvalidMount)
+	}
+	if strings.Contains(hostPath, "..") {
+		return Mount{}, fmt.Errorf("%w: host path cannot contain '..'", ErrInvalidMount)
+	}
+	if !strings.HasPrefix(containerPath, "/") {
+		return Mount{}, fmt.Errorf("%w: container path must be absolute: %q", ErrInvalidMount, containerPath)
+	}
+	if strings.Contains(containerPath, "..") {
+		return Mount{}, fmt.Errorf("%w: container path cannot contain '..'", ErrInvalidMount)
+	}
+	if strings.Contains(hostPath, ":") || strings.Contains(containerPath, ":") {
+		return Mount{}, fmt.Errorf("%w: paths cannot contain ':'", ErrInvalidMount)
+	}
+
+	return Mount{HostPath: hostPath, ContainerPath: containerPath}, nil
+}
+
+// String returns the mount in the <host-dir>:<container-dir> form storage:mount expects
+func (m Mount) String() string {
+	return m.HostPath + ":" + m.ContainerPath
+}
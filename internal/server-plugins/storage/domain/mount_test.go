@@ -0,0 +1,54 @@
+package domain_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/storage/domain"
+)
+
+var _ = Describe("NewMount", func() {
+	It("accepts a host directory mounted on an absolute container path", func() {
+		mount, err := domain.NewMount("/var/lib/dokku/data/storage/my-app", "/app/storage")
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mount.String()).To(Equal("/var/lib/dokku/data/storage/my-app:/app/storage"))
+	})
+
+	It("accepts a Docker volume name", func() {
+		_, err := domain.NewMount("my-volume", "/data")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	DescribeTable("rejects unsafe paths",
+		func(hostPath, containerPath string) {
+			_, err := domain.NewMount(hostPath, containerPath)
+			Expect(err).To(MatchError(domain.ErrInvalidMount))
+		},
+		Entry("empty host path", "", "/data"),
+		Entry("host path traversal", "/var/lib/dokku/data/storage/../../../etc", "/data"),
+		Entry("relative container path", "/var/lib/dokku/data/storage/my-app", "data"),
+		Entry("container path traversal", "/var/lib/dokku/data/storage/my-app", "/app/../etc"),
+		Entry("extra mount options", "/var/lib/dokku/data/storage/my-app", "/data:rw"),
+		Entry("host root", "/", "/data"),
+		Entry("host system directory", "/etc", "/data"),
+		Entry("Docker socket", "/var/run/docker.sock", "/var/run/docker.sock"),
+		Entry("storage root itself", "/var/lib/dokku/data/storage", "/data"),
+		Entry("sibling of the storage root", "/var/lib/dokku/data/storage-old/my-app", "/data"),
+		Entry("relative host path", "data/my-app", "/data"),
+	)
+})
+
+var _ = Describe("ParseMount", func() {
+	It("accepts an existing mount of a host system path so it can be removed", func() {
+		mount, err := domain.ParseMount("/var/run/docker.sock", "/var/run/docker.sock")
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mount.String()).To(Equal("/var/run/docker.sock:/var/run/docker.sock"))
+	})
+
+	It("still rejects path traversal", func() {
+		_, err := domain.ParseMount("/var/lib/dokku/data/storage/../../../etc", "/data")
+		Expect(err).To(MatchError(domain.ErrInvalidMount))
+	})
+})
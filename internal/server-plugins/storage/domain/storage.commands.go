@@ -0,0 +1,36 @@
+package domain
+
+// StorageCommand represents allowed Dokku commands for the storage plugin
+type StorageCommand string
+
+const (
+	CommandAppsList       StorageCommand = "apps:list"
+	CommandStorageList    StorageCommand = "storage:list"
+	CommandStorageMount   StorageCommand = "storage:mount"
+	CommandStorageUnmount StorageCommand = "storage:unmount"
+)
+
+// IsValid checks if the command is a valid storage command
+func (c StorageCommand) IsValid() bool {
+	switch c {
+	case CommandAppsList, CommandStorageList, CommandStorageMount, CommandStorageUnmount:
+		return true
+	default:
+		return false
+	}
+}
+
+// String returns the string representation of the command
+func (c StorageCommand) String() string {
+	return string(c)
+}
+
+// GetAllowedCommands returns all allowed storage commands
+func GetAllowedCommands() []StorageCommand {
+	return []StorageCommand{
+		CommandAppsList,
+		CommandStorageList,
+		CommandStorageMount,
+		CommandStorageUnmount,
+	}
+}
@@ -0,0 +1,13 @@
+package domain
+
+import (
+	"context"
+)
+
+// StorageRepository defines methods for reading and managing the bind mounts of applications
+type StorageRepository interface {
+	ListApps(ctx context.Context) ([]string, error)
+	ListMounts(ctx context.Context, appName string) ([]Mount, error)
+	Mount(ctx context.Context, appName string, mount Mount) error
+	Unmount(ctx context.Context, appName string, mount Mount) error
+}
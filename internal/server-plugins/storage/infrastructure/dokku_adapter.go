@@ -0,0 +1,97 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	dokkuApi "github.com/dokku-mcp/dokku-mcp/internal/dokku-api"
+	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/storage/domain"
+)
+
+// DokkuStorageAdapter implements the storage repository using Dokku CLI
+type DokkuStorageAdapter struct {
+	client dokkuApi.DokkuClient
+	logger *slog.Logger
+}
+
+// NewDokkuStorageAdapter creates a new storage adapter
+func NewDokkuStorageAdapter(client dokkuApi.DokkuClient, logger *slog.Logger) domain.StorageRepository {
+	return &DokkuStorageAdapter{
+		client: client,
+		logger: logger,
+	}
+}
+
+// executeCommand wraps the client's ExecuteCommand with storage-specific context and validation
+func (a *DokkuStorageAdapter) executeCommand(ctx context.Context, command domain.StorageCommand, args []string) ([]byte, error) {
+	if !command.IsValid() {
+		return nil, fmt.Errorf("invalid storage command: %s", command)
+	}
+	return a.client.ExecuteCommand(ctx, command.String(), args)
+}
+
+// ListApps retrieves the names of all applications
+func (a *DokkuStorageAdapter) ListApps(ctx context.Context) ([]string, error) {
+	output, err := a.executeCommand(ctx, domain.CommandAppsList, []string{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list applications: %w", err)
+	}
+	return dokkuApi.ParseLinesSkipHeaders(string(output)), nil
+}
+
+// ListMounts retrieves the bind mounts of an application from storage:list
+func (a *DokkuStorageAdapter) ListMounts(ctx context.Context, appName string) ([]domain.Mount, error) {
+	output, err := a.executeCommand(ctx, domain.CommandStorageList, []string{appName})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list storage mounts for %s: %w", appName, err)
+	}
+	return parseStorageList(string(output)), nil
+}
+
+// Mount bind-mounts a host directory into the containers of an application
+func (a *DokkuStorageAdapter) Mount(ctx context.Context, appName string, mount domain.Mount) error {
+	if _, err := a.executeCommand(ctx, domain.CommandStorageMount, []string{appName, mount.String()}); err != nil {
+		return fmt.Errorf("failed to mount %s for %s: %w", mount, appName, err)
+	}
+	a.client.InvalidateByApp(appName)
+	return nil
+}
+
+// Unmount removes a bind mount of an application
+func (a *DokkuStorageAdapter) Unmount(ctx context.Context, appName string, mount domain.Mount) error {
+	if _, err := a.executeCommand(ctx, domain.CommandStorageUnmount, []string{appName, mount.String()}); err != nil {
+		return fmt.Errorf("failed to unmount %s for %s: %w", mount, appName, err)
+	}
+	a.client.InvalidateByApp(appName)
+	return nil
+}
+
+// parseStorageList reads the mounts listed by storage:list, one per line after the header:
+//
+//	=====> my-app volume bind-mounts:
+//	       /var/lib/dokku/data/storage/my-app:/app/storage
+//	       /var/lib/dokku/data/storage/shared:/shared:ro
+func parseStorageList(output string) []domain.Mount {
+	output, _ = dokkuApi.StripWarningLines(output)
+
+	mounts := []domain.Mount{}
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "=====>") {
+			continue
+		}
+
+		parts := strings.SplitN(line, ":", 3)
+		if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+			continue
+		}
+		mount := domain.Mount{HostPath: parts[0], ContainerPath: parts[1]}
+		if len(parts) == 3 {
+			mount.Options = parts[2]
+		}
+		mounts = append(mounts, mount)
+	}
+	return mounts
+}
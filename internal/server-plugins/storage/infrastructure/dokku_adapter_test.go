@@ -0,0 +1,29 @@
+package infrastructure
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/storage/domain"
+)
+
+func TestParseStorageList(t *testing.T) {
+	mounts := parseStorageList(`=====> my-app volume bind-mounts:
+       /var/lib/dokku/data/storage/my-app:/app/storage
+       /var/lib/dokku/data/storage/shared:/shared:ro
+`)
+
+	want := []domain.Mount{
+		{HostPath: "/var/lib/dokku/data/storage/my-app", ContainerPath: "/app/storage"},
+		{HostPath: "/var/lib/dokku/data/storage/shared", ContainerPath: "/shared", Options: "ro"},
+	}
+	if !reflect.DeepEqual(mounts, want) {
+		t.Fatalf("expected %+v, got %+v", want, mounts)
+	}
+}
+
+func TestParseStorageListWithoutMounts(t *testing.T) {
+	if mounts := parseStorageList("=====> my-app volume bind-mounts:\n"); len(mounts) != 0 {
+		t.Fatalf("expected no mounts, got %+v", mounts)
+	}
+}
@@ -0,0 +1,177 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	dokkuApi "github.com/dokku-mcp/dokku-mcp/internal/dokku-api"
+	serverDomain "github.com/dokku-mcp/dokku-mcp/internal/server-plugin/domain"
+	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/storage/application"
+	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/storage/domain"
+	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/storage/infrastructure"
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// StorageServerPlugin manages the persistent storage (bind mounts) of applications
+type StorageServerPlugin struct {
+	storageService *application.StorageService
+	logger         *slog.Logger
+}
+
+// NewStorageServerPlugin creates a new storage server plugin
+func NewStorageServerPlugin(client dokkuApi.DokkuClient, logger *slog.Logger) serverDomain.ServerPlugin {
+	adapter := infrastructure.NewDokkuStorageAdapter(client, logger)
+	storageService := application.NewStorageService(adapter, logger)
+	return &StorageServerPlugin{
+		storageService: storageService,
+		logger:         logger,
+	}
+}
+
+func (p *StorageServerPlugin) ID() string   { return "storage" }
+func (p *StorageServerPlugin) Name() string { return "Dokku Storage" }
+func (p *StorageServerPlugin) Description() string {
+	return "Mounts persistent host directories into application containers"
+}
+func (p *StorageServerPlugin) Version() string         { return "0.1.0" }
+func (p *StorageServerPlugin) DokkuPluginName() string { return "storage" }
+
+// ResourceProvider implementation
+func (p *StorageServerPlugin) GetResources(ctx context.Context) ([]serverDomain.Resource, error) {
+	return []serverDomain.Resource{
+		{
+			URI:         "dokku://storage/mounts",
+			Name:        "Storage Mounts",
+			Description: "Persistent storage bind mounts of each application",
+			MIMEType:    "application/json",
+			Handler:     p.handleStorageMountsResource,
+		},
+	}, nil
+}
+
+// ToolProvider implementation
+func (p *StorageServerPlugin) GetTools(ctx context.Context) ([]serverDomain.Tool, error) {
+	return []serverDomain.Tool{
+		{
+			Name:        "list_storage",
+			Description: "List the persistent storage mounts of an application",
+			Builder:     p.buildListStorageTool,
+			Handler:     p.handleListStorage,
+		},
+		{
+			Name:        "mount_storage",
+			Description: "Mount a host directory into the containers of an application",
+			Builder:     p.buildMountStorageTool,
+			Handler:     p.handleMountStorage,
+		},
+		{
+			Name:        "unmount_storage",
+			Description: "Remove a persistent storage mount of an application",
+			Builder:     p.buildUnmountStorageTool,
+			Handler:     p.handleUnmountStorage,
+		},
+	}, nil
+}
+
+func (p *StorageServerPlugin) handleStorageMountsResource(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	mounts, err := p.storageService.ListAllMounts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list storage mounts: %w", err)
+	}
+	jsonData, err := shared.MarshalOutput(mounts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize storage mounts: %w", err)
+	}
+	return []mcp.ResourceContents{
+		mcp.TextResourceContents{
+			URI:      req.Params.URI,
+			MIMEType: "application/json",
+			Text:     string(jsonData),
+		},
+	}, nil
+}
+
+func (p *StorageServerPlugin) buildListStorageTool() mcp.Tool {
+	return mcp.NewTool(
+		"list_storage",
+		mcp.WithDescription("List the persistent storage mounts of an application (storage:list)"),
+		mcp.WithString("app_name",
+			mcp.Required(),
+			mcp.Description("Name of the application"),
+		),
+	)
+}
+
+func (p *StorageServerPlugin) handleListStorage(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	appName, err := req.RequireString("app_name")
+	if err != nil {
+		return mcp.NewToolResultError("Application name is required"), nil
+	}
+
+	mounts, err := p.storageService.ListMounts(ctx, appName)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to list storage mounts: %v", err)), nil
+	}
+
+	jsonData, err := shared.MarshalOutput(mounts)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to encode storage mounts: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(jsonData)), nil
+}
+
+// withMountPaths adds the app_name, host_path and container_path parameters of the mount tools
+func withMountPaths() []mcp.ToolOption {
+	return []mcp.ToolOption{
+		mcp.WithString("app_name",
+			mcp.Required(),
+			mcp.Description("Name of the application"),
+		),
+		mcp.WithString("host_path",
+			mcp.Required(),
+			mcp.Description("Directory under /var/lib/dokku/data/storage on the Dokku host (e.g. /var/lib/dokku/data/storage/my-app) or Docker volume name; cannot contain '..'"),
+		),
+		mcp.WithString("container_path",
+			mcp.Required(),
+			mcp.Description("Absolute path inside the containers (e.g. /app/storage)"),
+		),
+	}
+}
+
+func (p *StorageServerPlugin) buildMountStorageTool() mcp.Tool {
+	options := append([]mcp.ToolOption{
+		mcp.WithDescription("Mount a host directory into the containers of an application (storage:mount). The mount applies from the next deploy or restart"),
+	}, withMountPaths()...)
+	return mcp.NewTool("mount_storage", options...)
+}
+
+func (p *StorageServerPlugin) handleMountStorage(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return p.handleMountChange(ctx, req, p.storageService.Mount, "mount", "✅ Mounted %s into '%s'; restart the app to apply it")
+}
+
+func (p *StorageServerPlugin) buildUnmountStorageTool() mcp.Tool {
+	options := append([]mcp.ToolOption{
+		mcp.WithDescription("Remove a persistent storage mount of an application (storage:unmount). The data stays on the host"),
+	}, withMountPaths()...)
+	return mcp.NewTool("unmount_storage", options...)
+}
+
+func (p *StorageServerPlugin) handleUnmountStorage(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return p.handleMountChange(ctx, req, p.storageService.Unmount, "unmount", "✅ Unmounted %s from '%s'; restart the app to apply it")
+}
+
+// handleMountChange reads the mount paths and runs a mount or unmount; missing paths are rejected by NewMount
+func (p *StorageServerPlugin) handleMountChange(
+	ctx context.Context,
+	req mcp.CallToolRequest,
+	change func(ctx context.Context, appName, hostPath, containerPath string) (domain.Mount, error),
+	action string,
+	successFormat string,
+) (*mcp.CallToolResult, error) {
+	return serverDomain.HandleAppChange(ctx, req, action+" storage", func(ctx context.Context, appName string) (string, error) {
+		mount, err := change(ctx, appName, req.GetString("host_path", ""), req.GetString("container_path", ""))
+		return fmt.Sprintf(successFormat, mount, appName), err
+	}, domain.ErrInvalidMount)
+}
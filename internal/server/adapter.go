@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log/slog"
 
+	dokkuApi "github.com/dokku-mcp/dokku-mcp/internal/dokku-api"
 	"github.com/dokku-mcp/dokku-mcp/internal/server-plugin/domain"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
@@ -22,20 +23,36 @@ type DynamicServerPluginProvider interface {
 	GetActiveServerPlugins() []domain.ServerPlugin
 }
 
+// MCPRegistrar is the part of the MCP server plugins capabilities are registered with.
+// *server.MCPServer implements it; tests can inject a fake that records registrations.
+type MCPRegistrar interface {
+	AddResource(resource mcp.Resource, handler server.ResourceHandlerFunc)
+	AddResourceTemplate(template mcp.ResourceTemplate, handler server.ResourceTemplateHandlerFunc)
+	AddTool(tool mcp.Tool, handler server.ToolHandlerFunc)
+	AddPrompt(prompt mcp.Prompt, handler server.PromptHandlerFunc)
+}
+
+var _ MCPRegistrar = (*server.MCPServer)(nil)
+
 // MCPAdapter bridges between our plugin system and the MCP server
 // Single responsibility: Adapt plugin capabilities to MCP server registration
 type MCPAdapter struct {
 	dynamicRegistry DynamicServerPluginProvider
-	mcpServer       *server.MCPServer
+	mcpServer       MCPRegistrar
 	logger          *slog.Logger
+
+	// dokkuVersion reports the discovered Dokku version, used to skip version-gated tools
+	dokkuVersion func() string
 }
 
-// NewMCPAdapter creates a new MCP adapter using the dynamic registry
-func NewMCPAdapter(dynamicRegistry DynamicServerPluginProvider, mcpServer *server.MCPServer, logger *slog.Logger) *MCPAdapter {
+// NewMCPAdapter creates a new MCP adapter using the dynamic registry.
+// dokkuVersion may be nil, in which case version-gated tools are always registered.
+func NewMCPAdapter(dynamicRegistry DynamicServerPluginProvider, mcpServer MCPRegistrar, logger *slog.Logger, dokkuVersion func() string) *MCPAdapter {
 	return &MCPAdapter{
 		dynamicRegistry: dynamicRegistry,
 		mcpServer:       mcpServer,
 		logger:          logger,
+		dokkuVersion:    dokkuVersion,
 	}
 }
 
@@ -112,14 +129,7 @@ func (a *MCPAdapter) registerResources(ctx context.Context) error {
 			"resource_count", len(resources))
 
 		for _, resource := range resources {
-			mcpResource := mcp.NewResource(
-				resource.URI,
-				resource.Name,
-				mcp.WithResourceDescription(resource.Description),
-				mcp.WithMIMEType(resource.MIMEType),
-			)
-
-			a.mcpServer.AddResource(mcpResource, resource.Handler)
+			a.addResource(resource)
 			a.logger.Debug("Resource registered",
 				"plugin", provider.ID(),
 				"resource", resource.Name,
@@ -131,6 +141,28 @@ func (a *MCPAdapter) registerResources(ctx context.Context) error {
 	return nil
 }
 
+// addResource registers a resource, or a resource template when its URI is one
+func (a *MCPAdapter) addResource(resource domain.Resource) {
+	if resource.Template {
+		template := mcp.NewResourceTemplate(
+			resource.URI,
+			resource.Name,
+			mcp.WithTemplateDescription(resource.Description),
+			mcp.WithTemplateMIMEType(resource.MIMEType),
+		)
+		a.mcpServer.AddResourceTemplate(template, server.ResourceTemplateHandlerFunc(resource.Handler))
+		return
+	}
+
+	mcpResource := mcp.NewResource(
+		resource.URI,
+		resource.Name,
+		mcp.WithResourceDescription(resource.Description),
+		mcp.WithMIMEType(resource.MIMEType),
+	)
+	a.mcpServer.AddResource(mcpResource, resource.Handler)
+}
+
 // registerTools registers all tools from tool providers
 func (a *MCPAdapter) registerTools(ctx context.Context) error {
 	providers := a.GetToolProviders()
@@ -151,6 +183,10 @@ func (a *MCPAdapter) registerTools(ctx context.Context) error {
 			"tool_count", len(tools))
 
 		for _, tool := range tools {
+			if !a.isToolSupported(provider.ID(), tool) {
+				continue
+			}
+
 			// Use the builder pattern to create the MCP tool
 			mcpTool := tool.Builder()
 
@@ -165,6 +201,39 @@ func (a *MCPAdapter) registerTools(ctx context.Context) error {
 	return nil
 }
 
+// isToolSupported reports whether the Dokku host meets the tool's minimum version.
+// Tools are kept when the requirement cannot be checked (unknown host version).
+func (a *MCPAdapter) isToolSupported(pluginID string, tool domain.Tool) bool {
+	if tool.MinDokkuVersion == "" || a.dokkuVersion == nil {
+		return true
+	}
+
+	required, err := dokkuApi.ParseDokkuVersion(tool.MinDokkuVersion)
+	if err != nil {
+		a.logger.Warn("Ignoring invalid minimum Dokku version for tool",
+			"plugin", pluginID, "tool", tool.Name, "error", err)
+		return true
+	}
+
+	current, err := dokkuApi.ParseDokkuVersion(a.dokkuVersion())
+	if err != nil {
+		a.logger.Debug("Dokku version unknown, registering version-gated tool",
+			"plugin", pluginID, "tool", tool.Name, "min_dokku_version", required.String())
+		return true
+	}
+
+	if current.Compare(required) < 0 {
+		a.logger.Info("Skipping tool not supported by this Dokku version",
+			"plugin", pluginID,
+			"tool", tool.Name,
+			"min_dokku_version", required.String(),
+			"dokku_version", current.String())
+		return false
+	}
+
+	return true
+}
+
 // registerPrompts registers all prompts from prompt providers
 func (a *MCPAdapter) registerPrompts(ctx context.Context) error {
 	providers := a.GetPromptProviders()
@@ -198,13 +267,7 @@ func (a *MCPAdapter) RegisterServerPlugin(ctx context.Context, plugin domain.Ser
 		resources, err := resourceProvider.GetResources(ctx)
 		if err == nil {
 			for _, resource := range resources {
-				mcpResource := mcp.NewResource(
-					resource.URI,
-					resource.Name,
-					mcp.WithResourceDescription(resource.Description),
-					mcp.WithMIMEType(resource.MIMEType),
-				)
-				a.mcpServer.AddResource(mcpResource, resource.Handler)
+				a.addResource(resource)
 			}
 		}
 	}
@@ -214,6 +277,9 @@ func (a *MCPAdapter) RegisterServerPlugin(ctx context.Context, plugin domain.Ser
 		tools, err := toolProvider.GetTools(ctx)
 		if err == nil {
 			for _, tool := range tools {
+				if !a.isToolSupported(plugin.ID(), tool) {
+					continue
+				}
 				mcpTool := tool.Builder()
 				a.mcpServer.AddTool(mcpTool, tool.Handler)
 			}
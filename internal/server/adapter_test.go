@@ -0,0 +1,155 @@
+package server
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/server-plugin/domain"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// stubToolPlugin provides a fixed set of tools
+type stubToolPlugin struct {
+	tools []domain.Tool
+}
+
+func (p *stubToolPlugin) ID() string              { return "stub" }
+func (p *stubToolPlugin) Name() string            { return "Stub" }
+func (p *stubToolPlugin) Description() string     { return "stub plugin" }
+func (p *stubToolPlugin) Version() string         { return "0.0.0" }
+func (p *stubToolPlugin) DokkuPluginName() string { return "" }
+
+func (p *stubToolPlugin) GetTools(ctx context.Context) ([]domain.Tool, error) {
+	return p.tools, nil
+}
+
+type stubRegistry struct {
+	plugins []domain.ServerPlugin
+}
+
+func (r *stubRegistry) GetActiveServerPlugins() []domain.ServerPlugin { return r.plugins }
+
+func newStubTool(name, minVersion string) domain.Tool {
+	return domain.Tool{
+		Name:            name,
+		Builder:         func() mcp.Tool { return mcp.NewTool(name) },
+		Handler:         func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) { return nil, nil },
+		MinDokkuVersion: minVersion,
+	}
+}
+
+func TestRegisterToolsSkipsVersionGatedTools(t *testing.T) {
+	cases := []struct {
+		name         string
+		dokkuVersion string
+		wantGated    bool
+	}{
+		{name: "older version", dokkuVersion: "0.24.9", wantGated: false},
+		{name: "matching version", dokkuVersion: "0.25.0", wantGated: true},
+		{name: "unknown version", dokkuVersion: "unknown", wantGated: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			plugin := &stubToolPlugin{tools: []domain.Tool{
+				newStubTool("always_available", ""),
+				newStubTool("needs_json_reports", "0.25.0"),
+			}}
+			mcpServer := server.NewMCPServer("test", "dev", server.WithToolCapabilities(true))
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+			adapter := NewMCPAdapter(&stubRegistry{plugins: []domain.ServerPlugin{plugin}}, mcpServer, logger, func() string {
+				return tc.dokkuVersion
+			})
+
+			if err := adapter.RegisterAllServerPlugins(context.Background()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if mcpServer.GetTool("always_available") == nil {
+				t.Fatalf("ungated tool should always be registered")
+			}
+			if got := mcpServer.GetTool("needs_json_reports") != nil; got != tc.wantGated {
+				t.Fatalf("expected gated tool registered=%v, got %v", tc.wantGated, got)
+			}
+		})
+	}
+}
+
+// recordingRegistrar records what the adapter registers instead of serving it
+type recordingRegistrar struct {
+	resources []string
+	templates []string
+	tools     []string
+	prompts   []string
+}
+
+func (r *recordingRegistrar) AddResource(resource mcp.Resource, handler server.ResourceHandlerFunc) {
+	r.resources = append(r.resources, resource.URI)
+}
+
+func (r *recordingRegistrar) AddResourceTemplate(template mcp.ResourceTemplate, handler server.ResourceTemplateHandlerFunc) {
+	r.templates = append(r.templates, template.URITemplate.Raw())
+}
+
+func (r *recordingRegistrar) AddTool(tool mcp.Tool, handler server.ToolHandlerFunc) {
+	r.tools = append(r.tools, tool.Name)
+}
+
+func (r *recordingRegistrar) AddPrompt(prompt mcp.Prompt, handler server.PromptHandlerFunc) {
+	r.prompts = append(r.prompts, prompt.Name)
+}
+
+// stubFullPlugin provides a resource and a prompt on top of its tools
+type stubFullPlugin struct {
+	stubToolPlugin
+}
+
+func (p *stubFullPlugin) GetResources(ctx context.Context) ([]domain.Resource, error) {
+	handler := func(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		return nil, nil
+	}
+	return []domain.Resource{
+		{URI: "dokku://stub", Name: "Stub", Handler: handler},
+		{URI: "dokku://stub{?page}", Name: "Stub pages", Handler: handler, Template: true},
+	}, nil
+}
+
+func (p *stubFullPlugin) GetPrompts(ctx context.Context) ([]domain.Prompt, error) {
+	return []domain.Prompt{{
+		Name:    "stub_prompt",
+		Builder: func() mcp.Prompt { return mcp.NewPrompt("stub_prompt") },
+		Handler: func(ctx context.Context, req mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+			return nil, nil
+		},
+	}}, nil
+}
+
+func TestRegisterServerPluginWithFakeRegistrar(t *testing.T) {
+	plugin := &stubFullPlugin{stubToolPlugin{tools: []domain.Tool{
+		newStubTool("first_tool", ""),
+		newStubTool("second_tool", ""),
+	}}}
+	registrar := &recordingRegistrar{}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	adapter := NewMCPAdapter(&stubRegistry{plugins: []domain.ServerPlugin{plugin}}, registrar, logger, nil)
+
+	if err := adapter.RegisterAllServerPlugins(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(registrar.tools) != 2 || registrar.tools[0] != "first_tool" || registrar.tools[1] != "second_tool" {
+		t.Fatalf("unexpected registered tools: %v", registrar.tools)
+	}
+	if len(registrar.resources) != 1 || registrar.resources[0] != "dokku://stub" {
+		t.Fatalf("unexpected registered resources: %v", registrar.resources)
+	}
+	if len(registrar.templates) != 1 || registrar.templates[0] != "dokku://stub{?page}" {
+		t.Fatalf("unexpected registered resource templates: %v", registrar.templates)
+	}
+	if len(registrar.prompts) != 1 || registrar.prompts[0] != "stub_prompt" {
+		t.Fatalf("unexpected registered prompts: %v", registrar.prompts)
+	}
+}
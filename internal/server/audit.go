@@ -0,0 +1,18 @@
+package server
+
+import (
+	"github.com/dokku-mcp/dokku-mcp/internal/shared/audit"
+	"github.com/dokku-mcp/dokku-mcp/pkg/config"
+)
+
+// auditLogCapacity bounds how many audit events are kept in memory
+const auditLogCapacity = 10000
+
+// NewAuditLog provides the audit log when multi_tenant.observability.audit_enabled
+// is set, and nil otherwise so consumers can tell that nothing is recorded
+func NewAuditLog(cfg *config.ServerConfig) audit.EventLog {
+	if !cfg.MultiTenant.Observability.AuditEnabled {
+		return nil
+	}
+	return audit.NewMemorySink(auditLogCapacity)
+}
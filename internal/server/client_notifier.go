@@ -0,0 +1,77 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
+	"github.com/dokku-mcp/dokku-mcp/pkg/config"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// ClientNotifier sends server-initiated notifications (deployment events, drift).
+// In single-tenant mode every connected client receives them. In multi-tenant mode
+// only the sessions that authenticated as a tenant, whose credentials have not
+// expired, do: the tenant is recorded from each request the session sends.
+type ClientNotifier struct {
+	multiTenant bool
+	mcpServer   *server.MCPServer
+	logger      *slog.Logger
+	mu          sync.RWMutex
+	tenants     map[string]*shared.TenantContext
+}
+
+// NewClientNotifier creates a notifier; its hooks must be installed on the MCP server
+func NewClientNotifier(cfg *config.ServerConfig, logger *slog.Logger) *ClientNotifier {
+	return &ClientNotifier{
+		multiTenant: cfg.MultiTenant.Enabled,
+		logger:      logger,
+		tenants:     make(map[string]*shared.TenantContext),
+	}
+}
+
+// hooks records the tenant of every session sending a request and forgets closed sessions
+func (n *ClientNotifier) hooks() *server.Hooks {
+	hooks := &server.Hooks{}
+	hooks.AddBeforeAny(func(ctx context.Context, id any, method mcp.MCPMethod, message any) {
+		session := server.ClientSessionFromContext(ctx)
+		tenant, ok := shared.GetTenantContext(ctx)
+		if session == nil || !ok {
+			return
+		}
+		n.mu.Lock()
+		n.tenants[session.SessionID()] = tenant
+		n.mu.Unlock()
+	})
+	hooks.AddOnUnregisterSession(func(ctx context.Context, session server.ClientSession) {
+		n.mu.Lock()
+		delete(n.tenants, session.SessionID())
+		n.mu.Unlock()
+	})
+	return hooks
+}
+
+// Notify sends a notification to the clients allowed to receive it
+func (n *ClientNotifier) Notify(method string, params map[string]any) {
+	if !n.multiTenant {
+		n.mcpServer.SendNotificationToAllClients(method, params)
+		return
+	}
+
+	n.mu.RLock()
+	sessions := make([]string, 0, len(n.tenants))
+	for sessionID, tenant := range n.tenants {
+		if !tenant.IsExpired() {
+			sessions = append(sessions, sessionID)
+		}
+	}
+	n.mu.RUnlock()
+
+	for _, sessionID := range sessions {
+		if err := n.mcpServer.SendNotificationToSpecificClient(sessionID, method, params); err != nil {
+			n.logger.Debug("Failed to notify client", "session_id", sessionID, "method", method, "error", err)
+		}
+	}
+}
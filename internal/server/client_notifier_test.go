@@ -0,0 +1,92 @@
+package server
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
+	"github.com/dokku-mcp/dokku-mcp/pkg/config"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// fakeSession is an initialized client session buffering its notifications
+type fakeSession struct {
+	id            string
+	notifications chan mcp.JSONRPCNotification
+}
+
+func newFakeSession(id string) *fakeSession {
+	return &fakeSession{id: id, notifications: make(chan mcp.JSONRPCNotification, 10)}
+}
+
+func (s *fakeSession) Initialize()                                         {}
+func (s *fakeSession) Initialized() bool                                   { return true }
+func (s *fakeSession) NotificationChannel() chan<- mcp.JSONRPCNotification { return s.notifications }
+func (s *fakeSession) SessionID() string                                   { return s.id }
+
+// newTestNotifier returns a notifier installed on an MCP server with the given sessions connected
+func newTestNotifier(t *testing.T, multiTenant bool, sessions ...*fakeSession) (*ClientNotifier, *server.MCPServer) {
+	t.Helper()
+
+	cfg := config.DefaultConfig()
+	cfg.MultiTenant.Enabled = multiTenant
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	notifier := NewClientNotifier(cfg, logger)
+	mcpServer := NewMCPServerInstance(cfg, notifier, logger)
+
+	for _, session := range sessions {
+		if err := mcpServer.RegisterSession(context.Background(), session); err != nil {
+			t.Fatalf("failed to register session: %v", err)
+		}
+	}
+	return notifier, mcpServer
+}
+
+// ping sends a request from session, authenticated as tenant when set
+func ping(mcpServer *server.MCPServer, session *fakeSession, tenant *shared.TenantContext) {
+	ctx := mcpServer.WithContext(context.Background(), session)
+	if tenant != nil {
+		ctx = shared.WithTenantContext(ctx, tenant)
+	}
+	mcpServer.HandleMessage(ctx, []byte(`{"jsonrpc":"2.0","id":1,"method":"ping"}`))
+}
+
+func TestClientNotifierNotifiesEveryClientInSingleTenantMode(t *testing.T) {
+	first, second := newFakeSession("first"), newFakeSession("second")
+	notifier, _ := newTestNotifier(t, false, first, second)
+
+	notifier.Notify("notifications/message", map[string]any{"level": "info"})
+
+	if len(first.notifications) != 1 || len(second.notifications) != 1 {
+		t.Fatalf("expected both clients to be notified, got %d and %d", len(first.notifications), len(second.notifications))
+	}
+}
+
+func TestClientNotifierOnlyNotifiesAuthenticatedTenants(t *testing.T) {
+	tenant, anonymous, expired := newFakeSession("tenant"), newFakeSession("anonymous"), newFakeSession("expired")
+	notifier, mcpServer := newTestNotifier(t, true, tenant, anonymous, expired)
+
+	past := time.Now().Add(-time.Minute)
+	ping(mcpServer, tenant, &shared.TenantContext{TenantID: "acme"})
+	ping(mcpServer, anonymous, nil)
+	ping(mcpServer, expired, &shared.TenantContext{TenantID: "acme", ExpiresAt: &past})
+
+	notifier.Notify("notifications/message", map[string]any{"level": "info"})
+
+	if len(tenant.notifications) != 1 {
+		t.Fatalf("expected the authenticated tenant to be notified")
+	}
+	if len(anonymous.notifications) != 0 || len(expired.notifications) != 0 {
+		t.Fatalf("expected anonymous and expired sessions not to be notified")
+	}
+
+	mcpServer.UnregisterSession(context.Background(), tenant.SessionID())
+	notifier.Notify("notifications/message", map[string]any{"level": "info"})
+	if len(tenant.notifications) != 1 {
+		t.Fatalf("expected a closed session to be forgotten")
+	}
+}
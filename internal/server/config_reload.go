@@ -0,0 +1,128 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"slices"
+	"sync"
+	"syscall"
+
+	dokkuApi "github.com/dokku-mcp/dokku-mcp/internal/dokku-api"
+	"github.com/dokku-mcp/dokku-mcp/pkg/config"
+	"github.com/dokku-mcp/dokku-mcp/pkg/logger"
+	"go.uber.org/fx"
+)
+
+// reloadableKeys are the settings a configuration reload applies without a restart
+var reloadableKeys = []string{"cache_ttl", "log_level", "security.blacklist"}
+
+// ConfigReloadResult reports what a configuration reload changed
+type ConfigReloadResult struct {
+	// Applied lists the reloadable settings that changed and are now live
+	Applied []string `json:"applied"`
+	// Ignored lists the settings that changed but only take effect after a restart
+	Ignored []string `json:"ignored"`
+}
+
+// ConfigReloader re-reads the configuration file and applies the settings that can change
+// while the server runs: the command blacklist, the log level and the cache TTL. They are
+// applied to the client and logger, which synchronize them; the configuration shared with
+// the other components is never written, so the reloader diffs against its own copy, where
+// only the applied settings are updated.
+type ConfigReloader struct {
+	mu      sync.Mutex
+	current *config.ServerConfig
+	// cacheEnabled is fixed at startup: without a cache, a new TTL has nothing to apply to
+	cacheEnabled bool
+	client       dokkuApi.DokkuClient
+	logger       *slog.Logger
+	load         func() (*config.ServerConfig, error)
+}
+
+// NewConfigReloader creates a reloader updating the running client and logger
+func NewConfigReloader(cfg *config.ServerConfig, client dokkuApi.DokkuClient, logger *slog.Logger) *ConfigReloader {
+	current := *cfg
+	return &ConfigReloader{
+		current:      &current,
+		cacheEnabled: cfg.CacheEnabled,
+		client:       client,
+		logger:       logger,
+		load:         config.LoadConfig,
+	}
+}
+
+// Reload re-reads the configuration. An invalid file leaves the running configuration
+// untouched; changed settings that need a restart are reported and logged, not applied.
+func (r *ConfigReloader) Reload() (*ConfigReloadResult, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	next, err := r.load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to reload configuration: %w", err)
+	}
+
+	result := &ConfigReloadResult{Applied: []string{}, Ignored: []string{}}
+	for _, key := range r.current.ChangedKeys(next) {
+		switch {
+		case key == "cache_ttl" && !r.cacheEnabled:
+			result.Ignored = append(result.Ignored, key)
+		case slices.Contains(reloadableKeys, key):
+			result.Applied = append(result.Applied, key)
+		default:
+			result.Ignored = append(result.Ignored, key)
+		}
+	}
+
+	r.client.SetBlacklist(next.Security.Blacklist)
+	if r.cacheEnabled {
+		r.client.SetCacheTTL(next.CacheTTL)
+	}
+	logger.SetLevel(next.LogLevel)
+	r.current.Security.Blacklist = next.Security.Blacklist
+	r.current.LogLevel = next.LogLevel
+	if r.cacheEnabled {
+		r.current.CacheTTL = next.CacheTTL
+	}
+
+	for _, key := range result.Ignored {
+		r.logger.Warn("Configuration setting changed but needs a restart to take effect", "setting", key)
+	}
+	r.logger.Info("Configuration reloaded", "applied", result.Applied, "ignored", result.Ignored)
+
+	return result, nil
+}
+
+// registerConfigReloadSignal reloads the configuration whenever the process receives SIGHUP
+func registerConfigReloadSignal(lc fx.Lifecycle, reloader *ConfigReloader, logger *slog.Logger) {
+	signals := make(chan os.Signal, 1)
+	done := make(chan struct{})
+
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			signal.Notify(signals, syscall.SIGHUP)
+			go func() {
+				for {
+					select {
+					case <-signals:
+						logger.Info("Received SIGHUP, reloading configuration")
+						if _, err := reloader.Reload(); err != nil {
+							logger.Error("Configuration reload failed, keeping the running configuration", "error", err)
+						}
+					case <-done:
+						return
+					}
+				}
+			}()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			signal.Stop(signals)
+			close(done)
+			return nil
+		},
+	})
+}
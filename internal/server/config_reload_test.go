@@ -0,0 +1,102 @@
+package server
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"slices"
+	"testing"
+	"time"
+
+	dokkuApi "github.com/dokku-mcp/dokku-mcp/internal/dokku-api"
+	"github.com/dokku-mcp/dokku-mcp/pkg/config"
+)
+
+func TestConfigReloadUpdatesBlacklistLive(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	cfg := config.DefaultConfig()
+	client := dokkuApi.NewDokkuClient(dokkuApi.DefaultClientConfig(), logger)
+	client.SetBlacklist(cfg.Security.Blacklist)
+
+	reloader := NewConfigReloader(cfg, client, logger)
+	reloader.load = func() (*config.ServerConfig, error) {
+		next := config.DefaultConfig()
+		next.Security.Blacklist = []string{"apps:destroy"}
+		next.CacheTTL = time.Minute
+		next.SSH.Host = "dokku.example.com"
+		return next, nil
+	}
+
+	if err := client.ValidateCommand("apps:destroy", []string{"my-app"}); err != nil {
+		t.Fatalf("expected apps:destroy to be allowed before the reload, got %v", err)
+	}
+
+	result, err := reloader.Reload()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := client.ValidateCommand("apps:destroy", []string{"my-app"}); err == nil {
+		t.Fatal("expected apps:destroy to be blacklisted after the reload")
+	}
+	if !slices.Equal(result.Applied, []string{"cache_ttl", "security.blacklist"}) {
+		t.Fatalf("unexpected applied settings: %v", result.Applied)
+	}
+	if !slices.Equal(result.Ignored, []string{"ssh.host"}) {
+		t.Fatalf("unexpected ignored settings: %v", result.Ignored)
+	}
+	if !slices.Equal(cfg.Security.Blacklist, config.DefaultConfig().Security.Blacklist) || cfg.CacheTTL != config.DefaultConfig().CacheTTL {
+		t.Fatalf("expected the shared configuration not to be written, got %+v", cfg.Security)
+	}
+
+	result, err = reloader.Reload()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Applied) != 0 || !slices.Equal(result.Ignored, []string{"ssh.host"}) {
+		t.Fatalf("expected only the pending restart to be reported again, got %+v", result)
+	}
+}
+
+func TestConfigReloadIgnoresCacheTTLWithoutCache(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	cfg := config.DefaultConfig()
+	cfg.CacheEnabled = false
+	client := dokkuApi.NewDokkuClient(dokkuApi.DefaultClientConfig(), logger)
+
+	reloader := NewConfigReloader(cfg, client, logger)
+	reloader.load = func() (*config.ServerConfig, error) {
+		next := config.DefaultConfig()
+		next.CacheEnabled = false
+		next.CacheTTL = time.Minute
+		return next, nil
+	}
+
+	result, err := reloader.Reload()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Applied) != 0 || !slices.Equal(result.Ignored, []string{"cache_ttl"}) {
+		t.Fatalf("expected cache_ttl not to be applied with the cache disabled, got %+v", result)
+	}
+}
+
+func TestConfigReloadKeepsConfigurationOnError(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	cfg := config.DefaultConfig()
+	cfg.Security.Blacklist = []string{"apps:destroy"}
+	client := dokkuApi.NewDokkuClient(dokkuApi.DefaultClientConfig(), logger)
+	client.SetBlacklist(cfg.Security.Blacklist)
+
+	reloader := NewConfigReloader(cfg, client, logger)
+	reloader.load = func() (*config.ServerConfig, error) {
+		return nil, errors.New("invalid log level: verbose")
+	}
+
+	if _, err := reloader.Reload(); err == nil {
+		t.Fatal("expected the reload to fail")
+	}
+	if err := client.ValidateCommand("apps:destroy", []string{"my-app"}); err == nil {
+		t.Fatal("expected the blacklist to be kept after a failed reload")
+	}
+}
@@ -0,0 +1,29 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+
+	dokkuApi "github.com/dokku-mcp/dokku-mcp/internal/dokku-api"
+)
+
+// DokkuFailureMessage explains a Dokku failure whose cause the client recognized in
+// its output (missing plugin, permission denied, disk quota)
+func DokkuFailureMessage(err error) (string, bool) {
+	command := "command"
+	var cmdErr *dokkuApi.CommandError
+	if errors.As(err, &cmdErr) {
+		command = fmt.Sprintf("'%s'", cmdErr.Command)
+	}
+
+	switch {
+	case errors.Is(err, dokkuApi.ErrPluginNotInstalled):
+		return fmt.Sprintf("Dokku %s is not available; install the Dokku plugin providing it on the host", command), true
+	case errors.Is(err, dokkuApi.ErrPermissionDenied):
+		return fmt.Sprintf("Dokku %s was denied permission on the host; check the ownership of the files it touches", command), true
+	case errors.Is(err, dokkuApi.ErrQuotaExceeded):
+		return fmt.Sprintf("Dokku %s failed because the host is out of disk space or over quota; free space (e.g. with prune_app_images) and retry", command), true
+	default:
+		return "", false
+	}
+}
@@ -3,8 +3,24 @@ package server
 import (
 	"regexp"
 	"strings"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
 )
 
+// MaxRawOutputBytes caps how much raw Dokku output a tool result carries
+const MaxRawOutputBytes = 4096
+
+// FormatRawOutput redacts credentials from raw Dokku output and truncates it to
+// MaxRawOutputBytes, for tools returning what Dokku printed
+func FormatRawOutput(output string) string {
+	lines := SanitizeLogLines(strings.Split(strings.TrimRight(output, "\n"), "\n"))
+	sanitized := strings.Join(lines, "\n")
+	if len(sanitized) <= MaxRawOutputBytes {
+		return sanitized
+	}
+	return strings.ToValidUTF8(sanitized[:MaxRawOutputBytes], "") + "\n... (output truncated)"
+}
+
 // SanitizeLogLines performs minimal redaction on log lines for safe exposure
 func SanitizeLogLines(lines []string) []string {
 	if len(lines) == 0 {
@@ -41,7 +57,22 @@ func SanitizeLogLines(lines []string) []string {
 		for _, pattern := range credentialPatterns {
 			l = pattern.regex.ReplaceAllString(l, pattern.replacement)
 		}
+		l = redactSensitiveAssignments(l)
 		out[i] = l
 	}
 	return out
 }
+
+// envAssignmentPattern matches KEY=value assignments such as config:set arguments
+var envAssignmentPattern = regexp.MustCompile(`\b([A-Za-z_][A-Za-z0-9_]*)=(\S+)`)
+
+// redactSensitiveAssignments redacts the value of KEY=value pairs whose key is sensitive
+func redactSensitiveAssignments(line string) string {
+	return envAssignmentPattern.ReplaceAllStringFunc(line, func(match string) string {
+		key, value, _ := strings.Cut(match, "=")
+		if value == "[redacted]" || !shared.IsSensitiveKey(key) {
+			return match
+		}
+		return key + "=[redacted]"
+	})
+}
@@ -0,0 +1,16 @@
+package server
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFormatRawOutputIsCapped(t *testing.T) {
+	out := FormatRawOutput(strings.Repeat("x", MaxRawOutputBytes*2))
+	if !strings.HasSuffix(out, "(output truncated)") {
+		t.Fatalf("expected truncation marker, got suffix %q", out[len(out)-30:])
+	}
+	if len(out) > MaxRawOutputBytes+len("\n... (output truncated)") {
+		t.Fatalf("expected output to be capped, got %d bytes", len(out))
+	}
+}
@@ -1,80 +1,57 @@
 package server
 
 import (
-	"encoding/json"
 	"log/slog"
 
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
-// ToolStatus represents the high-level status of a tool call
-type ToolStatus string
-
-const (
-	ToolStatusOK      ToolStatus = "ok"
-	ToolStatusError   ToolStatus = "error"
-	ToolStatusPartial ToolStatus = "partial"
-)
-
-// ToolLink provides follow-up actions for LLMs to chain safely
-type ToolLink struct {
-	Rel    string            `json:"rel"`
-	Tool   string            `json:"tool"`
-	Params map[string]string `json:"params,omitempty"`
-}
-
-// ToolResponse is the canonical envelope returned by tools
-type ToolResponse struct {
-	Status    ToolStatus       `json:"status"`
-	Code      string           `json:"code,omitempty"`
-	Message   string           `json:"message,omitempty"`
-	RequestID string           `json:"requestId,omitempty"`
-	Data      ToolResponseData `json:"data,omitempty"`
-	Links     []ToolLink       `json:"links,omitempty"`
-	Hint      string           `json:"hint,omitempty"`
-}
-
-type ToolResponseData map[string]json.RawMessage
-
-func NewToolResponseData() ToolResponseData {
-	return make(ToolResponseData)
+// ToolResult is the envelope returned by every tool: a human-readable message,
+// machine-readable data and the warnings that did not prevent the operation
+type ToolResult struct {
+	Success  bool     `json:"success"`
+	Message  string   `json:"message"`
+	Data     any      `json:"data"`
+	Warnings []string `json:"warnings"`
 }
 
-// marshal pretty JSON for readability in clients
-func (r ToolResponse) marshal(logger *slog.Logger) string {
-	b, err := json.MarshalIndent(r, "", "  ")
+// marshal JSON in the configured output format (pretty by default for readability in clients)
+func (r ToolResult) marshal(logger *slog.Logger) string {
+	if r.Warnings == nil {
+		r.Warnings = []string{}
+	}
+	b, err := shared.MarshalOutput(r)
 	if err != nil {
 		if logger != nil {
-			logger.Error("failed to marshal tool response", "error", err, "code", r.Code)
+			logger.Error("failed to marshal tool result", "error", err, "message", r.Message)
 		}
-		fallback := ToolResponse{Status: ToolStatusError, Code: "tool_response_marshal_error", Message: "failed to serialize tool response"}
-		fb, _ := json.MarshalIndent(fallback, "", "  ")
+		fallback := ToolResult{Success: false, Message: "failed to serialize tool result", Warnings: []string{}}
+		fb, _ := shared.MarshalOutput(fallback)
 		return string(fb)
 	}
 	return string(b)
 }
 
-// NewResult builds an MCP result from a ToolResponse in one line
-func NewResult(resp ToolResponse) *mcp.CallToolResult {
-	return NewResultWithLogger(resp, nil)
+// NewResult builds an MCP result from a ToolResult; an unsuccessful result is flagged as an error
+func NewResult(result ToolResult) *mcp.CallToolResult {
+	return NewResultWithLogger(result, nil)
 }
 
-// NewResultWithLogger builds an MCP result from a ToolResponse using the provided logger
-func NewResultWithLogger(resp ToolResponse, logger *slog.Logger) *mcp.CallToolResult {
-	return &mcp.CallToolResult{Content: []mcp.Content{mcp.TextContent{Type: "text", Text: resp.marshal(logger)}}}
-}
-
-// OK is a convenience for success responses
-func OK(message string, data ToolResponseData) *mcp.CallToolResult {
-	return NewResult(ToolResponse{Status: ToolStatusOK, Message: message, Data: data})
+// NewResultWithLogger builds an MCP result from a ToolResult using the provided logger
+func NewResultWithLogger(result ToolResult, logger *slog.Logger) *mcp.CallToolResult {
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{mcp.NewTextContent(result.marshal(logger))},
+		IsError: !result.Success,
+	}
 }
 
-// Error is a convenience for error responses
-func Error(code, message, hint string, data ToolResponseData) *mcp.CallToolResult {
-	return NewResult(ToolResponse{Status: ToolStatusError, Code: code, Message: message, Hint: hint, Data: data})
+// OK is a convenience for success results; data may be nil
+func OK(message string, data any, warnings ...string) *mcp.CallToolResult {
+	return NewResult(ToolResult{Success: true, Message: message, Data: data, Warnings: warnings})
 }
 
-// Partial is a convenience for partial success responses
-func Partial(message string, data ToolResponseData) *mcp.CallToolResult {
-	return NewResult(ToolResponse{Status: ToolStatusPartial, Message: message, Data: data})
+// Error is a convenience for error results
+func Error(message string) *mcp.CallToolResult {
+	return NewResult(ToolResult{Success: false, Message: message})
 }
@@ -0,0 +1,79 @@
+package server
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// decodeEnvelope returns the top-level fields of a tool result envelope
+func decodeEnvelope(t *testing.T, result *mcp.CallToolResult) map[string]json.RawMessage {
+	t.Helper()
+	if len(result.Content) != 1 {
+		t.Fatalf("expected a single content block, got %d", len(result.Content))
+	}
+	text, ok := result.Content[0].(mcp.TextContent)
+	if !ok {
+		t.Fatalf("expected text content, got %T", result.Content[0])
+	}
+	var envelope map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(text.Text), &envelope); err != nil {
+		t.Fatalf("expected a JSON envelope: %v", err)
+	}
+	for _, field := range []string{"success", "message", "data", "warnings"} {
+		if _, ok := envelope[field]; !ok {
+			t.Fatalf("expected the %q field in %s", field, text.Text)
+		}
+	}
+	if len(envelope) != 4 {
+		t.Fatalf("expected only the envelope fields, got %s", text.Text)
+	}
+	return envelope
+}
+
+func TestOKEnvelope(t *testing.T) {
+	result := OK("Application 'my-app' created", map[string]string{"app_name": "my-app"}, "No domain configured")
+	if result.IsError {
+		t.Fatal("expected a success result")
+	}
+
+	envelope := decodeEnvelope(t, result)
+	if string(envelope["success"]) != "true" {
+		t.Fatalf("expected success=true, got %s", envelope["success"])
+	}
+	var message string
+	if err := json.Unmarshal(envelope["message"], &message); err != nil || message != "Application 'my-app' created" {
+		t.Fatalf("unexpected message %s", envelope["message"])
+	}
+	var data map[string]string
+	if err := json.Unmarshal(envelope["data"], &data); err != nil || data["app_name"] != "my-app" {
+		t.Fatalf("unexpected data %s", envelope["data"])
+	}
+	var warnings []string
+	if err := json.Unmarshal(envelope["warnings"], &warnings); err != nil || len(warnings) != 1 || warnings[0] != "No domain configured" {
+		t.Fatalf("unexpected warnings %s", envelope["warnings"])
+	}
+}
+
+func TestErrorEnvelope(t *testing.T) {
+	result := Error("Application 'my-app' not found")
+	if !result.IsError {
+		t.Fatal("expected an error result")
+	}
+
+	envelope := decodeEnvelope(t, result)
+	if string(envelope["success"]) != "false" {
+		t.Fatalf("expected success=false, got %s", envelope["success"])
+	}
+	var message string
+	if err := json.Unmarshal(envelope["message"], &message); err != nil || message != "Application 'my-app' not found" {
+		t.Fatalf("unexpected message %s", envelope["message"])
+	}
+	if string(envelope["data"]) != "null" {
+		t.Fatalf("expected null data, got %s", envelope["data"])
+	}
+	if string(envelope["warnings"]) != "[]" {
+		t.Fatalf("expected an empty warnings list, got %s", envelope["warnings"])
+	}
+}
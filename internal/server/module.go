@@ -7,13 +7,15 @@ import (
 	plugins "github.com/dokku-mcp/dokku-mcp/internal/server-plugin/application"
 	"github.com/dokku-mcp/dokku-mcp/internal/server-plugin/domain"
 	"github.com/dokku-mcp/dokku-mcp/internal/server-plugin/infrastructure"
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
 	"github.com/dokku-mcp/dokku-mcp/pkg/config"
 	"github.com/mark3labs/mcp-go/server"
 	"go.uber.org/fx"
 )
 
 // NewMCPServerInstance creates a new MCP server instance.
-func NewMCPServerInstance(cfg *config.ServerConfig, logger *slog.Logger) *server.MCPServer {
+// The notifier's hooks are installed so it can track the tenant of each session.
+func NewMCPServerInstance(cfg *config.ServerConfig, notifier *ClientNotifier, logger *slog.Logger) *server.MCPServer {
 	logger.Debug("Creating MCP server instance")
 	version := "dev"
 	mcpServer := server.NewMCPServer(
@@ -22,7 +24,9 @@ func NewMCPServerInstance(cfg *config.ServerConfig, logger *slog.Logger) *server
 		server.WithToolCapabilities(true),
 		server.WithResourceCapabilities(true, true),
 		server.WithPromptCapabilities(true),
+		server.WithHooks(notifier.hooks()),
 	)
+	notifier.mcpServer = mcpServer
 	logger.Debug("MCP server instance created successfully")
 	return mcpServer
 }
@@ -30,14 +34,24 @@ func NewMCPServerInstance(cfg *config.ServerConfig, logger *slog.Logger) *server
 var Module = fx.Module("server",
 	fx.Provide(
 		NewMCPServerInstance,
+		NewClientNotifier,
+		func(cfg *config.ServerConfig) *shared.AppScope {
+			return shared.NewAppScope(cfg.Security.AppAllowlist, cfg.Security.AppDenylist)
+		},
 		fx.Annotate(
 			dokkuApi.NewDokkuClientFromConfig,
 			fx.As(new(dokkuApi.DokkuClient)),
 		),
+		NewConfigReloader,
+		NewAuditLog,
+		plugins.NewPluginSyncMonitor,
+		NewReadinessProbe,
 		plugins.NewServerPluginRegistry,
 		fx.Annotate(
-			func(dynamicRegistry *plugins.DynamicServerPluginRegistry, mcpServer *server.MCPServer, logger *slog.Logger) *MCPAdapter {
-				return NewMCPAdapter(dynamicRegistry, mcpServer, logger)
+			func(dynamicRegistry *plugins.DynamicServerPluginRegistry, mcpServer *server.MCPServer, client dokkuApi.DokkuClient, logger *slog.Logger) *MCPAdapter {
+				return NewMCPAdapter(dynamicRegistry, mcpServer, logger, func() string {
+					return client.GetCapabilities().Version
+				})
 			},
 		),
 		fx.Annotate(
@@ -50,7 +64,15 @@ var Module = fx.Module("server",
 		),
 		plugins.NewDynamicServerPluginRegistry,
 	),
+	fx.Invoke(dokkuApi.ValidateSSHKeyPermissions),
+	fx.Invoke(dokkuApi.ValidateSSHAuthMethods),
+	fx.Invoke(dokkuApi.ValidateSSHCommandEnvironment),
+	fx.Invoke(func(cfg *config.ServerConfig) {
+		shared.SetSensitiveKeyPatterns(cfg.Security.SensitiveKeyPatterns)
+		shared.SetOutputFormat(shared.OutputFormat(cfg.OutputFormat))
+	}),
 	fx.Invoke(registerServerHooks),
+	fx.Invoke(registerConfigReloadSignal),
 	fx.Invoke(func(registry *plugins.DynamicServerPluginRegistry, lc fx.Lifecycle) {
 		registry.RegisterHooks(lc)
 	}),
@@ -0,0 +1,135 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	dokkuApi "github.com/dokku-mcp/dokku-mcp/internal/dokku-api"
+	plugins "github.com/dokku-mcp/dokku-mcp/internal/server-plugin/application"
+)
+
+// staleSyncIntervals is how many sync intervals may pass without a plugin
+// synchronization before the sync loop is reported unhealthy
+const staleSyncIntervals = 3
+
+// SubsystemStatus is the health of one subsystem the server depends on
+type SubsystemStatus struct {
+	Healthy bool   `json:"healthy"`
+	Detail  string `json:"detail"`
+}
+
+// ReadinessReport tells whether the server can serve requests and, when it cannot, which subsystem is unhealthy
+type ReadinessReport struct {
+	Ready     bool      `json:"ready"`
+	CheckedAt time.Time `json:"checked_at"`
+	// SSH is reachable when the last command sent to the Dokku host reached it
+	SSH SubsystemStatus `json:"ssh"`
+	// Capabilities are discovered once the Dokku version is known
+	Capabilities SubsystemStatus `json:"capabilities"`
+	// PluginSync is healthy when the background plugin synchronization runs without errors
+	PluginSync SubsystemStatus `json:"plugin_sync"`
+}
+
+// HealthStatus is the readiness report stripped of its details, served unauthenticated on
+// /healthz: error texts and the Dokku version stay in the server_ready tool
+type HealthStatus struct {
+	Ready        bool `json:"ready"`
+	SSH          bool `json:"ssh"`
+	Capabilities bool `json:"capabilities"`
+	PluginSync   bool `json:"plugin_sync"`
+}
+
+// readinessClient is the part of the Dokku client the readiness probe reads
+type readinessClient interface {
+	dokkuApi.ConnectionMonitor
+	GetCapabilities() *dokkuApi.DokkuCapabilities
+}
+
+// pluginSyncReporter reports the state of the background plugin synchronization
+type pluginSyncReporter interface {
+	Status() plugins.PluginSyncStatus
+}
+
+// ReadinessProbe combines the state of the SSH connection, the capability
+// discovery and the plugin synchronization into a single readiness report
+type ReadinessProbe struct {
+	client      readinessClient
+	syncMonitor pluginSyncReporter
+	now         func() time.Time
+}
+
+// NewReadinessProbe creates a probe reading the Dokku client and the plugin synchronization
+func NewReadinessProbe(client dokkuApi.DokkuClient, syncMonitor *plugins.PluginSyncMonitor) *ReadinessProbe {
+	return newReadinessProbe(client, syncMonitor)
+}
+
+func newReadinessProbe(client readinessClient, syncMonitor pluginSyncReporter) *ReadinessProbe {
+	return &ReadinessProbe{client: client, syncMonitor: syncMonitor, now: time.Now}
+}
+
+// Ready checks every subsystem; the server is ready when all of them are healthy
+func (p *ReadinessProbe) Ready() *ReadinessReport {
+	now := p.now()
+	report := &ReadinessReport{
+		CheckedAt:    now,
+		SSH:          sshReadiness(p.client.ConnectionStatus()),
+		Capabilities: capabilitiesReadiness(p.client.GetCapabilities()),
+		PluginSync:   pluginSyncReadiness(p.syncMonitor.Status(), now),
+	}
+	report.Ready = report.SSH.Healthy && report.Capabilities.Healthy && report.PluginSync.Healthy
+	return report
+}
+
+func sshReadiness(status dokkuApi.ConnectionStatus) SubsystemStatus {
+	switch {
+	case status.CircuitState == dokkuApi.CircuitOpen:
+		return SubsystemStatus{Detail: "circuit breaker open after repeated connection failures"}
+	case status.LastCommandAt.IsZero():
+		return SubsystemStatus{Detail: "no command sent to the Dokku host yet"}
+	case !status.Reachable:
+		return SubsystemStatus{Detail: fmt.Sprintf("last command failed to reach the Dokku host: %s", status.LastError)}
+	default:
+		return SubsystemStatus{Healthy: true, Detail: fmt.Sprintf("last command reached the Dokku host at %s", status.LastCommandAt.Format(time.RFC3339))}
+	}
+}
+
+func capabilitiesReadiness(capabilities *dokkuApi.DokkuCapabilities) SubsystemStatus {
+	if capabilities == nil || !capabilities.IsDiscovered() {
+		return SubsystemStatus{Detail: "Dokku version not discovered yet"}
+	}
+	return SubsystemStatus{Healthy: true, Detail: fmt.Sprintf("Dokku %s, %d plugins", capabilities.Version, len(capabilities.Plugins))}
+}
+
+func pluginSyncReadiness(status plugins.PluginSyncStatus, now time.Time) SubsystemStatus {
+	switch {
+	case status.LastError != "":
+		return SubsystemStatus{Detail: fmt.Sprintf("last plugin sync failed: %s", status.LastError)}
+	case !status.LoopEnabled:
+		return SubsystemStatus{Healthy: true, Detail: "sync loop disabled"}
+	case !status.LoopRunning:
+		return SubsystemStatus{Detail: "sync loop stopped"}
+	case status.LastSyncAt.IsZero():
+		return SubsystemStatus{Detail: "no plugin sync has run yet"}
+	case now.Sub(status.LastSyncAt) > staleSyncIntervals*status.Interval:
+		return SubsystemStatus{Detail: fmt.Sprintf("no plugin sync since %s", status.LastSyncAt.Format(time.RFC3339))}
+	default:
+		return SubsystemStatus{Healthy: true, Detail: fmt.Sprintf("last plugin sync at %s", status.LastSyncAt.Format(time.RFC3339))}
+	}
+}
+
+// ServeHTTP answers /healthz with the health of each subsystem: 200 when ready, 503 otherwise
+func (p *ReadinessProbe) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	report := p.Ready()
+	w.Header().Set("Content-Type", "application/json")
+	if !report.Ready {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	_ = json.NewEncoder(w).Encode(HealthStatus{
+		Ready:        report.Ready,
+		SSH:          report.SSH.Healthy,
+		Capabilities: report.Capabilities.Healthy,
+		PluginSync:   report.PluginSync.Healthy,
+	})
+}
@@ -0,0 +1,172 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	dokkuApi "github.com/dokku-mcp/dokku-mcp/internal/dokku-api"
+	plugins "github.com/dokku-mcp/dokku-mcp/internal/server-plugin/application"
+)
+
+type fakeReadinessClient struct {
+	connection   dokkuApi.ConnectionStatus
+	capabilities *dokkuApi.DokkuCapabilities
+}
+
+func (f *fakeReadinessClient) ConnectionStatus() dokkuApi.ConnectionStatus {
+	return f.connection
+}
+
+func (f *fakeReadinessClient) GetCapabilities() *dokkuApi.DokkuCapabilities {
+	return f.capabilities
+}
+
+type fakeSyncReporter struct {
+	status plugins.PluginSyncStatus
+}
+
+func (f *fakeSyncReporter) Status() plugins.PluginSyncStatus {
+	return f.status
+}
+
+func TestReadinessProbeReport(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	discovered := dokkuApi.NewDokkuCapabilities()
+	discovered.UpdateVersion("0.35.12")
+	reachable := dokkuApi.ConnectionStatus{LastCommandAt: now.Add(-time.Second), Reachable: true, CircuitState: dokkuApi.CircuitClosed}
+	syncing := plugins.PluginSyncStatus{LoopEnabled: true, LoopRunning: true, Interval: time.Minute, LastSyncAt: now.Add(-30 * time.Second)}
+
+	cases := []struct {
+		name          string
+		connection    dokkuApi.ConnectionStatus
+		capabilities  *dokkuApi.DokkuCapabilities
+		sync          plugins.PluginSyncStatus
+		wantReady     bool
+		wantUnhealthy map[string]string
+	}{
+		{name: "all healthy", connection: reachable, capabilities: discovered, sync: syncing, wantReady: true},
+		{
+			name:         "sync loop disabled",
+			connection:   reachable,
+			capabilities: discovered,
+			sync:         plugins.PluginSyncStatus{LastSyncAt: now.Add(-time.Hour)},
+			wantReady:    true,
+		},
+		{
+			name:          "host unreachable",
+			connection:    dokkuApi.ConnectionStatus{LastCommandAt: now, LastError: "ssh unreachable: connection refused", CircuitState: dokkuApi.CircuitClosed},
+			capabilities:  discovered,
+			sync:          syncing,
+			wantUnhealthy: map[string]string{"ssh": "connection refused"},
+		},
+		{
+			name:          "circuit open",
+			connection:    dokkuApi.ConnectionStatus{LastCommandAt: now, CircuitState: dokkuApi.CircuitOpen},
+			capabilities:  discovered,
+			sync:          syncing,
+			wantUnhealthy: map[string]string{"ssh": "circuit breaker open"},
+		},
+		{
+			name:         "starting up",
+			capabilities: dokkuApi.NewDokkuCapabilities(),
+			sync:         plugins.PluginSyncStatus{LoopEnabled: true, LoopRunning: true, Interval: time.Minute},
+			wantUnhealthy: map[string]string{
+				"ssh":          "no command sent",
+				"capabilities": "not discovered",
+				"plugin_sync":  "no plugin sync has run",
+			},
+		},
+		{
+			name:          "sync failing",
+			connection:    reachable,
+			capabilities:  discovered,
+			sync:          plugins.PluginSyncStatus{LoopEnabled: true, LoopRunning: true, Interval: time.Minute, LastSyncAt: now, LastError: "plugin:list failed"},
+			wantUnhealthy: map[string]string{"plugin_sync": "plugin:list failed"},
+		},
+		{
+			name:          "sync stale",
+			connection:    reachable,
+			capabilities:  discovered,
+			sync:          plugins.PluginSyncStatus{LoopEnabled: true, LoopRunning: true, Interval: time.Minute, LastSyncAt: now.Add(-10 * time.Minute)},
+			wantUnhealthy: map[string]string{"plugin_sync": "no plugin sync since"},
+		},
+		{
+			name:          "sync loop stopped",
+			connection:    reachable,
+			capabilities:  discovered,
+			sync:          plugins.PluginSyncStatus{LoopEnabled: true, Interval: time.Minute, LastSyncAt: now},
+			wantUnhealthy: map[string]string{"plugin_sync": "sync loop stopped"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			probe := newReadinessProbe(
+				&fakeReadinessClient{connection: tc.connection, capabilities: tc.capabilities},
+				&fakeSyncReporter{status: tc.sync},
+			)
+			probe.now = func() time.Time { return now }
+
+			report := probe.Ready()
+			if report.Ready != tc.wantReady {
+				t.Fatalf("expected ready=%v, got %+v", tc.wantReady, report)
+			}
+			if !report.CheckedAt.Equal(now) {
+				t.Fatalf("expected the report to be checked at %s, got %s", now, report.CheckedAt)
+			}
+
+			subsystems := map[string]SubsystemStatus{"ssh": report.SSH, "capabilities": report.Capabilities, "plugin_sync": report.PluginSync}
+			for name, status := range subsystems {
+				want, unhealthy := tc.wantUnhealthy[name]
+				if status.Healthy == unhealthy {
+					t.Fatalf("expected %s healthy=%v, got %+v", name, !unhealthy, status)
+				}
+				if unhealthy && !strings.Contains(status.Detail, want) {
+					t.Fatalf("expected the %s detail to mention %q, got %q", name, want, status.Detail)
+				}
+			}
+		})
+	}
+}
+
+func TestReadinessProbeHealthz(t *testing.T) {
+	discovered := dokkuApi.NewDokkuCapabilities()
+	discovered.UpdateVersion("0.35.12")
+	client := &fakeReadinessClient{capabilities: discovered}
+	probe := newReadinessProbe(client, &fakeSyncReporter{})
+
+	get := func() (*httptest.ResponseRecorder, HealthStatus) {
+		t.Helper()
+		recorder := httptest.NewRecorder()
+		probe.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+		var status HealthStatus
+		if err := json.Unmarshal(recorder.Body.Bytes(), &status); err != nil {
+			t.Fatalf("expected a JSON health status: %v", err)
+		}
+		return recorder, status
+	}
+
+	client.connection = dokkuApi.ConnectionStatus{LastCommandAt: time.Now(), LastError: "ssh: handshake failed for deploy@10.0.0.5"}
+	recorder, report := get()
+	if recorder.Code != http.StatusServiceUnavailable || report.Ready || report.SSH || !report.Capabilities {
+		t.Fatalf("expected 503 while the host is unreachable, got %d %+v", recorder.Code, report)
+	}
+	for _, detail := range []string{"10.0.0.5", "0.35.12", "detail"} {
+		if strings.Contains(recorder.Body.String(), detail) {
+			t.Fatalf("expected /healthz not to disclose %q, got %s", detail, recorder.Body.String())
+		}
+	}
+
+	client.connection = dokkuApi.ConnectionStatus{LastCommandAt: time.Now(), Reachable: true}
+	recorder, report = get()
+	if recorder.Code != http.StatusOK || !report.Ready {
+		t.Fatalf("expected 200 once every subsystem is healthy, got %d %+v", recorder.Code, report)
+	}
+	if ct := recorder.Header().Get("Content-Type"); ct != "application/json" {
+		t.Fatalf("expected a JSON content type, got %q", ct)
+	}
+}
@@ -29,6 +29,7 @@ func registerServerHooks(
 	adapter *MCPAdapter,
 	dynamicRegistry *plugins.DynamicServerPluginRegistry,
 	authParams AuthenticatorParams,
+	readiness *ReadinessProbe,
 	logger *slog.Logger,
 ) {
 	var httpServer *http.Server
@@ -78,17 +79,24 @@ func registerServerHooks(
 					)
 				}
 
+				handler = sseServer
 				// Apply CORS middleware if enabled
 				if cfg.Transport.CORS.Enabled {
 					logger.Info("CORS middleware enabled",
 						"allowed_origins", cfg.Transport.CORS.AllowedOrigins,
 						"allowed_methods", cfg.Transport.CORS.AllowedMethods)
 					handler = CORSMiddleware(&cfg.Transport.CORS)(sseServer)
-					httpServer.Handler = handler
 				} else {
 					logger.Debug("CORS middleware disabled, using mcp-go default CORS (*)")
 				}
 
+				// The readiness probe is served unauthenticated next to the MCP endpoints, so it only
+				// reports status booleans; the detailed report needs the server_ready tool
+				mux := http.NewServeMux()
+				mux.Handle("/healthz", readiness)
+				mux.Handle("/", handler)
+				httpServer.Handler = mux
+
 				go func() {
 					logger.Info("SSE server listening", "address", addr)
 					if err := sseServer.Start(addr); err != nil && err != http.ErrServerClosed {
@@ -0,0 +1,72 @@
+package shared
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrAppOutOfScope is returned for a command or resource targeting an app outside the scope
+var ErrAppOutOfScope = errors.New("application is outside the apps this server may manage")
+
+// AppScope restricts the applications the server may manage. Entries are app
+// names; a trailing "*" matches a prefix (e.g. "staging-*"). An app matching the
+// denylist is out of scope even when allowlisted; an empty allowlist allows every
+// other app. A nil scope allows everything.
+type AppScope struct {
+	allow []string
+	deny  []string
+}
+
+// NewAppScope builds a scope from allow and deny entries, ignoring blank ones
+func NewAppScope(allow, deny []string) *AppScope {
+	return &AppScope{allow: normalizeScopeEntries(allow), deny: normalizeScopeEntries(deny)}
+}
+
+// Allows reports whether the server may manage the application
+func (s *AppScope) Allows(appName string) bool {
+	if s == nil {
+		return true
+	}
+	appName = strings.ToLower(appName)
+	if matchesScopeEntry(s.deny, appName) {
+		return false
+	}
+	return len(s.allow) == 0 || matchesScopeEntry(s.allow, appName)
+}
+
+// Restricted reports whether some applications are out of scope
+func (s *AppScope) Restricted() bool {
+	return s != nil && (len(s.allow) > 0 || len(s.deny) > 0)
+}
+
+// Check returns ErrAppOutOfScope for an application the server may not manage
+func (s *AppScope) Check(appName string) error {
+	if s.Allows(appName) {
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrAppOutOfScope, appName)
+}
+
+func normalizeScopeEntries(entries []string) []string {
+	normalized := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry = strings.ToLower(strings.TrimSpace(entry)); entry != "" {
+			normalized = append(normalized, entry)
+		}
+	}
+	return normalized
+}
+
+func matchesScopeEntry(entries []string, appName string) bool {
+	for _, entry := range entries {
+		if prefix, ok := strings.CutSuffix(entry, "*"); ok {
+			if strings.HasPrefix(appName, prefix) {
+				return true
+			}
+		} else if entry == appName {
+			return true
+		}
+	}
+	return false
+}
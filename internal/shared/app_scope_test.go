@@ -0,0 +1,40 @@
+package shared_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
+)
+
+var _ = Describe("AppScope", func() {
+	It("should allow every app without lists", func() {
+		Expect(shared.NewAppScope(nil, nil).Allows("api")).To(BeTrue())
+		Expect((*shared.AppScope)(nil).Allows("api")).To(BeTrue())
+	})
+
+	It("should only allow allowlisted apps, matching prefixes", func() {
+		scope := shared.NewAppScope([]string{"staging-*", "Docs"}, nil)
+
+		Expect(scope.Allows("staging-api")).To(BeTrue())
+		Expect(scope.Allows("docs")).To(BeTrue())
+		Expect(scope.Allows("api")).To(BeFalse())
+	})
+
+	It("should give the denylist precedence over the allowlist", func() {
+		scope := shared.NewAppScope([]string{"staging-*"}, []string{"staging-billing"})
+
+		Expect(scope.Allows("staging-api")).To(BeTrue())
+		Expect(scope.Allows("staging-billing")).To(BeFalse())
+	})
+
+	It("should return a typed error for out-of-scope apps", func() {
+		Expect(shared.NewAppScope(nil, []string{"billing"}).Check("billing")).To(MatchError(shared.ErrAppOutOfScope))
+	})
+
+	It("should only be restricted with allow or deny entries", func() {
+		Expect((*shared.AppScope)(nil).Restricted()).To(BeFalse())
+		Expect(shared.NewAppScope([]string{" "}, nil).Restricted()).To(BeFalse())
+		Expect(shared.NewAppScope(nil, []string{"billing"}).Restricted()).To(BeTrue())
+	})
+})
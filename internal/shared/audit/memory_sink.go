@@ -0,0 +1,47 @@
+package audit
+
+import (
+	"context"
+	"slices"
+	"sync"
+)
+
+// EventLog is an EventSink whose recorded events can be read back
+type EventLog interface {
+	EventSink
+	// Events returns the recorded events, oldest first
+	Events(ctx context.Context) ([]Event, error)
+}
+
+// MemorySink keeps the most recent events in memory; they are lost on restart
+type MemorySink struct {
+	mu       sync.Mutex
+	events   []Event
+	capacity int
+}
+
+// NewMemorySink creates a sink keeping at most capacity events, dropping the oldest first
+func NewMemorySink(capacity int) *MemorySink {
+	return &MemorySink{capacity: capacity}
+}
+
+func (s *MemorySink) Record(ctx context.Context, event Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.events = append(s.events, event)
+	if overflow := len(s.events) - s.capacity; s.capacity > 0 && overflow > 0 {
+		s.events = slices.Delete(s.events, 0, overflow)
+	}
+	return nil
+}
+
+func (s *MemorySink) Events(ctx context.Context) ([]Event, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.events), nil
+}
+
+func (s *MemorySink) Close() error {
+	return nil
+}
@@ -12,6 +12,11 @@ type DeploymentService interface {
 	Rollback(ctx context.Context, appName string, version string) error
 	GetHistory(ctx context.Context, appName string) ([]DeploymentSummary, error)
 	GetStatus(ctx context.Context, deploymentID string) (*DeploymentResult, error)
+	// GetActiveDeployment returns the app's deployment in progress, or nil when there is none
+	GetActiveDeployment(ctx context.Context, appName string) (*DeploymentResult, error)
+	// WaitForCompletion waits for a started deployment to succeed or fail
+	WaitForCompletion(ctx context.Context, deploymentID string) (*DeploymentResult, error)
+	GetLastDeployStatus(ctx context.Context, appName string) (DeploymentStatus, error)
 	Cancel(ctx context.Context, deploymentID string) error
 }
 
@@ -22,7 +27,9 @@ type DeployOptions struct {
 	Buildpack  *BuildpackName
 	BuildImage *DockerImage
 	RunImage   *DockerImage
-	Force      bool
+	// BuildDir is the repository subdirectory to build from; Dokku keeps it for later deploys
+	BuildDir string
+	Force    bool
 }
 
 // DeploymentResult represents the outcome of a deployment
@@ -34,6 +41,7 @@ type DeploymentResult struct {
 	CreatedAt   time.Time
 	CompletedAt *time.Time
 	ErrorMsg    string
+	Warnings    []string
 }
 
 // DeploymentSummary provides a lightweight view of deployment history
@@ -55,3 +63,20 @@ const (
 	DeploymentStatusFailed     DeploymentStatus = "failed"
 	DeploymentStatusRolledBack DeploymentStatus = "rolled_back"
 )
+
+// deployOutputKey carries the function receiving the build output of a deployment
+type deployOutputKey struct{}
+
+// WithDeployOutput returns a context under which a deployment hands the output of its
+// git:sync, git:from-archive and ps:rebuild to sink line by line. Only those commands
+// are streamed: the other commands run under the context, such as reading the app's
+// config, are not.
+func WithDeployOutput(ctx context.Context, sink func(line []byte)) context.Context {
+	return context.WithValue(ctx, deployOutputKey{}, sink)
+}
+
+// DeployOutputFromContext returns the sink set with WithDeployOutput, or nil
+func DeployOutputFromContext(ctx context.Context) func(line []byte) {
+	sink, _ := ctx.Value(deployOutputKey{}).(func(line []byte))
+	return sink
+}
@@ -48,6 +48,16 @@ func (d *DomainName) IsLocalhost() bool {
 	return localhostPattern.MatchString(d.value)
 }
 
+// IsLocal vérifie si le domaine désigne la machine locale (localhost, *.localhost ou 127.x.x.x)
+func (d *DomainName) IsLocal() bool {
+	return d.IsLocalhost() || strings.HasSuffix(d.value, ".localhost") || strings.HasPrefix(d.value, "127.")
+}
+
+// IsFQDN vérifie si c'est un nom de domaine pleinement qualifié (ni une IP, ni un nom sans point)
+func (d *DomainName) IsFQDN() bool {
+	return !d.IsIP() && !d.IsLocalhost() && strings.Contains(d.value, ".")
+}
+
 // IsIP vérifie si c'est une adresse IP
 func (d *DomainName) IsIP() bool {
 	return ipPattern.MatchString(d.value)
@@ -125,6 +135,19 @@ func validateDomain(domain string) error {
 		return nil
 	}
 
+	// Dokku accepte les domaines wildcard (*.example.com) : seul le label le plus
+	// à gauche peut être "*", et il doit rester au moins un domaine complet derrière
+	if strings.HasPrefix(domain, "*.") {
+		domain = strings.TrimPrefix(domain, "*.")
+		if !strings.Contains(domain, ".") {
+			return fmt.Errorf("un domaine wildcard doit couvrir un domaine complet (ex: *.example.com)")
+		}
+	}
+
+	if strings.Contains(domain, "*") {
+		return fmt.Errorf("wildcard uniquement autorisé en tant que premier label (*.example.com)")
+	}
+
 	if !domainPattern.MatchString(domain) {
 		return fmt.Errorf("format de domaine invalide")
 	}
@@ -0,0 +1,67 @@
+package shared_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
+)
+
+var _ = Describe("DomainName", func() {
+	Describe("NewDomainName", func() {
+		DescribeTable("creating a new domain name",
+			func(domain string, shouldFail bool) {
+				dn, err := shared.NewDomainName(domain)
+
+				if shouldFail {
+					Expect(err).To(HaveOccurred())
+					Expect(dn).To(BeNil())
+				} else {
+					Expect(err).ToNot(HaveOccurred())
+					Expect(dn).ToNot(BeNil())
+				}
+			},
+			Entry("valid domain", "example.com", false),
+			Entry("valid subdomain", "api.example.com", false),
+			Entry("valid domain with hyphen", "my-app.example.com", false),
+			Entry("localhost", "localhost", false),
+			Entry("IP address", "192.168.1.10", false),
+			Entry("wildcard domain", "*.example.com", false),
+			Entry("wildcard subdomain", "*.apps.example.com", false),
+			Entry("empty domain", "", true),
+			Entry("domain with space", "exa mple.com", true),
+			Entry("domain with underscore", "exa_mple.com", true),
+			Entry("label starting with hyphen", "-example.com", true),
+			Entry("empty label", "example..com", true),
+			Entry("label longer than 63 characters", "a123456789012345678901234567890123456789012345678901234567890123.com", true),
+			Entry("wildcard alone", "*.", true),
+			Entry("wildcard on a TLD", "*.com", true),
+			Entry("wildcard in the middle", "a.*.com", true),
+			Entry("partial wildcard label", "*example.com", true),
+		)
+	})
+
+	Describe("IsWildcard", func() {
+		It("reports wildcard domains", func() {
+			dn, err := shared.NewDomainName("*.example.com")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(dn.IsWildcard()).To(BeTrue())
+			Expect(dn.Value()).To(Equal("*.example.com"))
+		})
+	})
+
+	DescribeTable("IsLocal and IsFQDN",
+		func(domain string, local, fqdn bool) {
+			dn, err := shared.NewDomainName(domain)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(dn.IsLocal()).To(Equal(local))
+			Expect(dn.IsFQDN()).To(Equal(fqdn))
+		},
+		Entry("public domain", "app.example.com", false, true),
+		Entry("localhost", "localhost", true, false),
+		Entry("localhost subdomain", "my-app.localhost", true, true),
+		Entry("loopback IP", "127.0.0.1", true, false),
+		Entry("public IP", "203.0.113.10", false, false),
+		Entry("single label", "intranet", false, false),
+	)
+})
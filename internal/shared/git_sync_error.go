@@ -0,0 +1,41 @@
+package shared
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Git failures of a deployment that retrying cannot fix. They live here so any
+// consumer of DeploymentService can tell them apart with errors.Is.
+var (
+	// ErrGitAuthenticationFailed is returned when Dokku could not authenticate to the git remote
+	ErrGitAuthenticationFailed = errors.New("git authentication failed")
+	// ErrGitRefNotFound is returned when the branch, tag or commit to deploy does not exist in the remote
+	ErrGitRefNotFound = errors.New("git reference not found")
+	// ErrGitRepositoryNotFound is returned when the remote repository does not exist or is not visible
+	ErrGitRepositoryNotFound = errors.New("git repository not found")
+)
+
+// GitSyncError is a git:sync failure classified as one of the ErrGit* errors.
+// Detail is the git message that gave the failure away, e.g.
+// "fatal: couldn't find remote ref feature/missing".
+type GitSyncError struct {
+	Kind   error
+	Detail string
+	Err    error
+}
+
+func (e *GitSyncError) Error() string {
+	if e.Detail == "" {
+		return fmt.Sprintf("%v: %v", e.Kind, e.Err)
+	}
+	return fmt.Sprintf("%v (%s): %v", e.Kind, e.Detail, e.Err)
+}
+
+// Is lets errors.Is(err, ErrGitRefNotFound) match the classified kind
+func (e *GitSyncError) Is(target error) bool {
+	return target == e.Kind
+}
+
+// Unwrap exposes the underlying command error and its output
+func (e *GitSyncError) Unwrap() error { return e.Err }
@@ -0,0 +1,97 @@
+package shared
+
+import (
+	"bytes"
+	"encoding/json"
+	"sync"
+)
+
+// OutputFormat selects how JSON tool and resource responses are serialized
+type OutputFormat string
+
+const (
+	// OutputFormatPretty indents JSON for readability (default)
+	OutputFormatPretty OutputFormat = "pretty"
+	// OutputFormatCompact emits JSON without any whitespace
+	OutputFormatCompact OutputFormat = "compact"
+	// OutputFormatMinimal emits compact JSON without null or empty fields to save tokens
+	OutputFormatMinimal OutputFormat = "minimal"
+)
+
+var (
+	outputFormat   = OutputFormatPretty
+	outputFormatMu sync.RWMutex
+)
+
+// SetOutputFormat replaces the format used by MarshalOutput
+func SetOutputFormat(format OutputFormat) {
+	outputFormatMu.Lock()
+	outputFormat = format
+	outputFormatMu.Unlock()
+}
+
+// GetOutputFormat returns the format used by MarshalOutput
+func GetOutputFormat() OutputFormat {
+	outputFormatMu.RLock()
+	defer outputFormatMu.RUnlock()
+	return outputFormat
+}
+
+// MarshalOutput serializes a tool or resource response in the configured output format
+func MarshalOutput(v any) ([]byte, error) {
+	return MarshalOutputAs(v, GetOutputFormat())
+}
+
+// MarshalOutputAs serializes v in the given output format.
+// Minimal output drops null values, empty arrays and empty objects at every depth.
+// Empty strings, false and zero are kept since they carry meaning: an environment
+// variable may well be set to "". Object keys are sorted.
+func MarshalOutputAs(v any, format OutputFormat) ([]byte, error) {
+	switch format {
+	case OutputFormatCompact:
+		return json.Marshal(v)
+	case OutputFormatMinimal:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+		var generic any
+		if err := decoder.Decode(&generic); err != nil {
+			return nil, err
+		}
+		pruned, _ := pruneEmpty(generic)
+		return json.Marshal(pruned)
+	default:
+		return json.MarshalIndent(v, "", "  ")
+	}
+}
+
+// pruneEmpty removes empty values from decoded JSON and reports whether the value itself is empty
+func pruneEmpty(value any) (any, bool) {
+	switch typed := value.(type) {
+	case nil:
+		return nil, true
+	case []any:
+		kept := make([]any, 0, len(typed))
+		for _, item := range typed {
+			if pruned, empty := pruneEmpty(item); !empty {
+				kept = append(kept, pruned)
+			}
+		}
+		return kept, len(kept) == 0
+	case map[string]any:
+		for key, item := range typed {
+			pruned, empty := pruneEmpty(item)
+			if empty {
+				delete(typed, key)
+				continue
+			}
+			typed[key] = pruned
+		}
+		return typed, len(typed) == 0
+	default:
+		return value, false
+	}
+}
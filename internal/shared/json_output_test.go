@@ -0,0 +1,67 @@
+package shared_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
+)
+
+type outputSample struct {
+	Name     string            `json:"name"`
+	Domain   string            `json:"domain"`
+	Running  bool              `json:"running"`
+	Replicas int               `json:"replicas"`
+	Started  *string           `json:"started"`
+	Tags     []string          `json:"tags"`
+	Labels   map[string]string `json:"labels"`
+	Nested   []map[string]any  `json:"nested"`
+}
+
+var _ = Describe("MarshalOutput", func() {
+	sample := outputSample{
+		Name:   "my-app",
+		Tags:   []string{},
+		Labels: map[string]string{},
+		Nested: []map[string]any{{"empty": ""}, {"kept": "value", "gone": nil}},
+	}
+
+	AfterEach(func() {
+		shared.SetOutputFormat(shared.OutputFormatPretty)
+	})
+
+	It("should indent JSON by default", func() {
+		data, err := shared.MarshalOutput(sample)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(ContainSubstring("\n  \"name\": \"my-app\""))
+	})
+
+	It("should not indent compact output", func() {
+		shared.SetOutputFormat(shared.OutputFormatCompact)
+
+		data, err := shared.MarshalOutput(sample)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).NotTo(ContainSubstring("\n"))
+		Expect(string(data)).NotTo(ContainSubstring("  "))
+		Expect(string(data)).To(HavePrefix(`{"name":"my-app","domain":"",`))
+	})
+
+	It("should omit null and empty fields in minimal output", func() {
+		shared.SetOutputFormat(shared.OutputFormatMinimal)
+
+		data, err := shared.MarshalOutput(sample)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal(`{"domain":"","name":"my-app","nested":[{"empty":""},{"kept":"value"}],"replicas":0,"running":false}`))
+		Expect(strings.Contains(string(data), "null")).To(BeFalse())
+	})
+
+	It("should keep environment variables set to an empty value in minimal output", func() {
+		shared.SetOutputFormat(shared.OutputFormatMinimal)
+
+		data, err := shared.MarshalOutput(map[string]map[string]string{"config": {"DEBUG": "", "PORT": "5000"}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal(`{"config":{"DEBUG":"","PORT":"5000"}}`))
+	})
+})
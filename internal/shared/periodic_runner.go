@@ -0,0 +1,91 @@
+package shared
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// PeriodicRunner calls a function every interval in the background until stopped.
+// The context passed to the function is cancelled by Stop.
+type PeriodicRunner struct {
+	interval time.Duration
+	// runAtStart calls the function once as soon as the runner starts
+	runAtStart bool
+	run        func(ctx context.Context)
+	mu         sync.Mutex
+	stopChan   chan struct{}
+	wg         sync.WaitGroup
+}
+
+// NewPeriodicRunner creates a runner calling run every interval, and right away when runAtStart is set.
+// A zero or negative interval never runs.
+func NewPeriodicRunner(interval time.Duration, runAtStart bool, run func(ctx context.Context)) *PeriodicRunner {
+	return &PeriodicRunner{interval: interval, runAtStart: runAtStart, run: run}
+}
+
+// Start launches the background loop; it does nothing when already started
+func (r *PeriodicRunner) Start() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.interval <= 0 || r.stopChan != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopChan := make(chan struct{})
+	r.stopChan = stopChan
+	r.wg.Add(1)
+
+	go func() {
+		defer r.wg.Done()
+		defer cancel()
+
+		if r.runAtStart {
+			r.run(ctx)
+		}
+
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stopChan:
+				return
+			case <-ticker.C:
+				r.run(ctx)
+			}
+		}
+	}()
+
+	go func() {
+		<-stopChan
+		cancel()
+	}()
+}
+
+// Stop ends the background loop, cancelling a run in progress, and waits for it
+// to return or for ctx to be done
+func (r *PeriodicRunner) Stop(ctx context.Context) error {
+	r.mu.Lock()
+	if r.stopChan == nil {
+		r.mu.Unlock()
+		return nil
+	}
+	close(r.stopChan)
+	r.stopChan = nil
+	r.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		r.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,58 @@
+package shared_test
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
+)
+
+var _ = Describe("PeriodicRunner", func() {
+	It("should run at start and on every tick until stopped", func() {
+		var runs atomic.Int32
+		runner := shared.NewPeriodicRunner(10*time.Millisecond, true, func(ctx context.Context) { runs.Add(1) })
+
+		runner.Start()
+		runner.Start()
+		Eventually(runs.Load).Should(BeNumerically(">=", 3))
+
+		Expect(runner.Stop(context.Background())).To(Succeed())
+		stopped := runs.Load()
+		Consistently(runs.Load, 50*time.Millisecond).Should(Equal(stopped))
+		Expect(runner.Stop(context.Background())).To(Succeed())
+	})
+
+	It("should wait for the first tick without runAtStart", func() {
+		var runs atomic.Int32
+		runner := shared.NewPeriodicRunner(time.Hour, false, func(ctx context.Context) { runs.Add(1) })
+
+		runner.Start()
+		Consistently(runs.Load, 30*time.Millisecond).Should(BeZero())
+		Expect(runner.Stop(context.Background())).To(Succeed())
+	})
+
+	It("should cancel a run in progress when stopped", func() {
+		started := make(chan struct{})
+		runner := shared.NewPeriodicRunner(time.Hour, true, func(ctx context.Context) {
+			close(started)
+			<-ctx.Done()
+		})
+
+		runner.Start()
+		Eventually(started).Should(BeClosed())
+		Expect(runner.Stop(context.Background())).To(Succeed())
+	})
+
+	It("should never run with a zero interval", func() {
+		var runs atomic.Int32
+		runner := shared.NewPeriodicRunner(0, true, func(ctx context.Context) { runs.Add(1) })
+
+		runner.Start()
+		Consistently(runs.Load, 20*time.Millisecond).Should(BeZero())
+		Expect(runner.Stop(context.Background())).To(Succeed())
+	})
+})
@@ -0,0 +1,69 @@
+package shared
+
+import (
+	"strings"
+	"sync"
+)
+
+// MaskedValue replaces the value of a sensitive variable in tool output
+const MaskedValue = "********"
+
+// DefaultSensitiveKeyPatterns are the key fragments treated as sensitive when none are configured
+var DefaultSensitiveKeyPatterns = []string{"PASSWORD", "SECRET", "TOKEN", "KEY"}
+
+var (
+	sensitiveKeyPatterns = DefaultSensitiveKeyPatterns
+	sensitiveKeyMu       sync.RWMutex
+)
+
+// SetSensitiveKeyPatterns replaces the patterns used by IsSensitiveKey.
+// A pattern ending in "*" matches key prefixes (e.g. "AWS_*"), any other pattern
+// matches anywhere in the key. Matching is case-insensitive. An empty list restores the defaults.
+func SetSensitiveKeyPatterns(patterns []string) {
+	normalized := make([]string, 0, len(patterns))
+	for _, pattern := range patterns {
+		if pattern = strings.ToUpper(strings.TrimSpace(pattern)); pattern != "" {
+			normalized = append(normalized, pattern)
+		}
+	}
+	if len(normalized) == 0 {
+		normalized = DefaultSensitiveKeyPatterns
+	}
+
+	sensitiveKeyMu.Lock()
+	sensitiveKeyPatterns = normalized
+	sensitiveKeyMu.Unlock()
+}
+
+// IsSensitiveKey reports whether an environment variable key holds a secret
+func IsSensitiveKey(key string) bool {
+	key = strings.ToUpper(key)
+
+	sensitiveKeyMu.RLock()
+	defer sensitiveKeyMu.RUnlock()
+
+	for _, pattern := range sensitiveKeyPatterns {
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+			if strings.HasPrefix(key, prefix) {
+				return true
+			}
+			continue
+		}
+		if strings.Contains(key, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// MaskSensitiveValues returns a copy of vars with the values of sensitive keys masked
+func MaskSensitiveValues(vars map[string]string) map[string]string {
+	masked := make(map[string]string, len(vars))
+	for key, value := range vars {
+		if IsSensitiveKey(key) {
+			value = MaskedValue
+		}
+		masked[key] = value
+	}
+	return masked
+}
@@ -0,0 +1,48 @@
+package shared_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
+)
+
+var _ = Describe("IsSensitiveKey", func() {
+	AfterEach(func() {
+		shared.SetSensitiveKeyPatterns(nil)
+	})
+
+	DescribeTable("default patterns",
+		func(key string, expected bool) {
+			Expect(shared.IsSensitiveKey(key)).To(Equal(expected))
+		},
+		Entry("password", "DB_PASSWORD", true),
+		Entry("secret", "secret_key_base", true),
+		Entry("token", "GITHUB_TOKEN", true),
+		Entry("api key", "STRIPE_API_KEY", true),
+		Entry("plain", "PORT", false),
+		Entry("url", "DATABASE_URL", false),
+	)
+
+	It("should honor custom substring and prefix patterns", func() {
+		shared.SetSensitiveKeyPatterns([]string{"dsn", "AWS_*"})
+
+		Expect(shared.IsSensitiveKey("SENTRY_DSN")).To(BeTrue())
+		Expect(shared.IsSensitiveKey("AWS_REGION")).To(BeTrue())
+		Expect(shared.IsSensitiveKey("MY_AWS_REGION")).To(BeFalse())
+		Expect(shared.IsSensitiveKey("DB_PASSWORD")).To(BeFalse())
+	})
+
+	It("should restore the defaults for an empty list", func() {
+		shared.SetSensitiveKeyPatterns([]string{"DSN"})
+		shared.SetSensitiveKeyPatterns([]string{" "})
+
+		Expect(shared.IsSensitiveKey("DB_PASSWORD")).To(BeTrue())
+	})
+
+	It("should mask sensitive values only", func() {
+		masked := shared.MaskSensitiveValues(map[string]string{"DB_PASSWORD": "hunter2", "PORT": "5000"})
+
+		Expect(masked).To(Equal(map[string]string{"DB_PASSWORD": shared.MaskedValue, "PORT": "5000"}))
+	})
+})
@@ -2,8 +2,10 @@ package config
 
 import (
 	"fmt"
+	"slices"
 	"time"
 
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
 	"github.com/spf13/viper"
 )
 
@@ -30,6 +32,14 @@ type SSHConfig struct {
 	User       string `mapstructure:"user"`
 	KeyPath    string `mapstructure:"key_path"`
 	DisablePTY bool   `mapstructure:"disable_pty"` // Disable PTY allocation for non-interactive use (CI environments)
+	// Refuse to start when the key file is accessible to group or others (ssh would reject it)
+	StrictKeyPermissions bool `mapstructure:"strict_key_permissions"`
+	// Order authentication methods are tried in: key (key_path), agent, default_key (~/.ssh/id_rsa)
+	AuthMethods []string `mapstructure:"auth_methods"`
+	// PATH the ssh command runs with (empty keeps /usr/bin:/bin)
+	CommandPath string `mapstructure:"command_path"`
+	// Extra variables passed to the ssh command; loader, shell and ssh variables are rejected
+	CommandEnv map[string]string `mapstructure:"command_env"`
 }
 
 type PluginDiscoveryConfig struct {
@@ -37,8 +47,78 @@ type PluginDiscoveryConfig struct {
 	Enabled      bool          `mapstructure:"enabled"`
 }
 
+type CircuitBreakerConfig struct {
+	Enabled          bool          `mapstructure:"enabled"`
+	FailureThreshold int           `mapstructure:"failure_threshold"`
+	CoolDown         time.Duration `mapstructure:"cool_down"`
+}
+
+// Upper bounds of the retry settings: beyond them a command stuck on an unreachable
+// host would hold its caller for many minutes before failing
+const (
+	maxRetryAttempts  = 10
+	maxRetryBaseDelay = 30 * time.Second
+	maxRetryMaxDelay  = 5 * time.Minute
+)
+
+// RateLimitConfig spaces out the commands sent to the Dokku host
+type RateLimitConfig struct {
+	// RequestsPerSecond is the sustained command rate; 0 disables the limit
+	RequestsPerSecond float64 `mapstructure:"requests_per_second"`
+	// Burst is how many commands may start back to back before the rate applies
+	Burst int `mapstructure:"burst"`
+}
+
+type RetryConfig struct {
+	Enabled     bool          `mapstructure:"enabled"`
+	MaxAttempts int           `mapstructure:"max_attempts"`
+	BaseDelay   time.Duration `mapstructure:"base_delay"`
+	MaxDelay    time.Duration `mapstructure:"max_delay"`
+}
+
+type ImagesConfig struct {
+	// KeepPrevious is how many images besides the current one prune_app_images keeps by default
+	KeepPrevious int `mapstructure:"keep_previous"`
+}
+
+type AppListConfig struct {
+	// DefaultLimit is how many apps a page of the dokku://apps/list resource holds when no limit is given
+	DefaultLimit int `mapstructure:"default_limit"`
+}
+
+// DriftDetectionConfig controls the background job reporting apps changed outside the server
+type DriftDetectionConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Interval between two snapshots of every app's scale, domains and deployed commit
+	Interval time.Duration `mapstructure:"interval"`
+}
+
+type ScheduledDeploysConfig struct {
+	// File keeping pending schedules across restarts (empty: dokku-mcp/scheduled-deploys.json in the user config directory)
+	File          string        `mapstructure:"file"`
+	CheckInterval time.Duration `mapstructure:"check_interval"`
+}
+
 type SecurityConfig struct {
-	Blacklist []string `mapstructure:"blacklist"`
+	Blacklist            []string `mapstructure:"blacklist"`
+	SensitiveKeyPatterns []string `mapstructure:"sensitive_key_patterns"`
+	BreakGlass           bool     `mapstructure:"break_glass"`
+	// AllowConfigReveal lets the config resource return sensitive values in clear text (?reveal=true)
+	AllowConfigReveal bool `mapstructure:"allow_config_reveal"`
+	// AppAllowlist limits the apps the server may manage (empty: every app); a trailing "*" matches a prefix
+	AppAllowlist []string `mapstructure:"app_allowlist"`
+	// AppDenylist lists apps the server must never touch; it takes precedence over AppAllowlist
+	AppDenylist []string `mapstructure:"app_denylist"`
+	// LogRedaction tunes which command arguments are masked in command logs
+	LogRedaction LogRedactionConfig `mapstructure:"log_redaction"`
+}
+
+// LogRedactionConfig adjusts the commands whose arguments are masked in command logs
+type LogRedactionConfig struct {
+	// RedactCommands are masked in addition to the built-in ones (config:set, registry:login, letsencrypt:set)
+	RedactCommands []string `mapstructure:"redact_commands"`
+	// AllowCommands are logged with their arguments; sensitive KEY=value pairs stay masked
+	AllowCommands []string `mapstructure:"allow_commands"`
 }
 
 type MultiTenantConfig struct {
@@ -83,23 +163,36 @@ type BuildLogsConfig struct {
 }
 
 type ServerConfig struct {
-	Transport          TransportConfig       `mapstructure:"transport"`
-	Host               string                `mapstructure:"host"`
-	Port               int                   `mapstructure:"port"`
-	LogLevel           string                `mapstructure:"log_level"`
-	LogFormat          string                `mapstructure:"log_format"`
-	ExposeServerLogs   bool                  `mapstructure:"expose_server_logs"`
-	LogBufferCapacity  int                   `mapstructure:"log_buffer_capacity"`
-	DeploymentLogLines int                   `mapstructure:"deployment_log_lines"`
-	Timeout            time.Duration         `mapstructure:"timeout"`
-	DokkuPath          string                `mapstructure:"dokku_path"`
-	CacheEnabled       bool                  `mapstructure:"cache_enabled"`
-	CacheTTL           time.Duration         `mapstructure:"cache_ttl"`
-	SSH                SSHConfig             `mapstructure:"ssh"`
-	PluginDiscovery    PluginDiscoveryConfig `mapstructure:"plugin_discovery"`
-	Security           SecurityConfig        `mapstructure:"security"`
-	MultiTenant        MultiTenantConfig     `mapstructure:"multi_tenant"`
-	Logs               LogsConfig            `mapstructure:"logs"`
+	Transport           TransportConfig        `mapstructure:"transport"`
+	Host                string                 `mapstructure:"host"`
+	Port                int                    `mapstructure:"port"`
+	LogLevel            string                 `mapstructure:"log_level"`
+	LogFormat           string                 `mapstructure:"log_format"`
+	ExposeServerLogs    bool                   `mapstructure:"expose_server_logs"`
+	ExposeCommandOutput bool                   `mapstructure:"expose_command_output"`
+	ReadOnly            bool                   `mapstructure:"read_only"`
+	OutputFormat        string                 `mapstructure:"output_format"` // "pretty", "compact" or "minimal"
+	LogBufferCapacity   int                    `mapstructure:"log_buffer_capacity"`
+	DeploymentLogLines  int                    `mapstructure:"deployment_log_lines"`
+	DeployRetryDelay    time.Duration          `mapstructure:"deploy_retry_delay"`
+	ScheduledDeploys    ScheduledDeploysConfig `mapstructure:"scheduled_deploys"`
+	Images              ImagesConfig           `mapstructure:"images"`
+	AppList             AppListConfig          `mapstructure:"app_list"`
+	DriftDetection      DriftDetectionConfig   `mapstructure:"drift_detection"`
+	Timeout             time.Duration          `mapstructure:"timeout"`
+	DokkuPath           string                 `mapstructure:"dokku_path"`
+	DokkuVersion        string                 `mapstructure:"dokku_version"`
+	CommandAliases      map[string]string      `mapstructure:"command_aliases"`
+	CacheEnabled        bool                   `mapstructure:"cache_enabled"`
+	CacheTTL            time.Duration          `mapstructure:"cache_ttl"`
+	SSH                 SSHConfig              `mapstructure:"ssh"`
+	CircuitBreaker      CircuitBreakerConfig   `mapstructure:"circuit_breaker"`
+	Retry               RetryConfig            `mapstructure:"retry"`
+	RateLimit           RateLimitConfig        `mapstructure:"rate_limit"`
+	PluginDiscovery     PluginDiscoveryConfig  `mapstructure:"plugin_discovery"`
+	Security            SecurityConfig         `mapstructure:"security"`
+	MultiTenant         MultiTenantConfig      `mapstructure:"multi_tenant"`
+	Logs                LogsConfig             `mapstructure:"logs"`
 }
 
 func DefaultConfig() *ServerConfig {
@@ -116,29 +209,73 @@ func DefaultConfig() *ServerConfig {
 				MaxAge:         300, // 5 minutes
 			},
 		},
-		Host:               "localhost",
-		Port:               8080,
-		LogLevel:           "info",
-		LogFormat:          "json",
-		ExposeServerLogs:   false,
-		LogBufferCapacity:  2000,
-		DeploymentLogLines: 200,
-		Timeout:            30 * time.Second,
-		DokkuPath:          "/usr/bin/dokku",
-		CacheEnabled:       true,
-		CacheTTL:           5 * time.Minute,
+		Host:                "localhost",
+		Port:                8080,
+		LogLevel:            "info",
+		LogFormat:           "json",
+		ExposeServerLogs:    false,
+		ExposeCommandOutput: false,
+		ReadOnly:            false,
+		OutputFormat:        "pretty",
+		LogBufferCapacity:   2000,
+		DeploymentLogLines:  200,
+		DeployRetryDelay:    5 * time.Second,
+		ScheduledDeploys: ScheduledDeploysConfig{
+			CheckInterval: 30 * time.Second,
+		},
+		Images: ImagesConfig{
+			KeepPrevious: 2,
+		},
+		AppList: AppListConfig{
+			DefaultLimit: 100,
+		},
+		DriftDetection: DriftDetectionConfig{
+			Enabled:  false,
+			Interval: 10 * time.Minute,
+		},
+		Timeout:      30 * time.Second,
+		DokkuPath:    "/usr/bin/dokku",
+		DokkuVersion: "",
+		CacheEnabled: true,
+		CacheTTL:     5 * time.Minute,
 		SSH: SSHConfig{
 			Host:    "localhost",
 			Port:    3022,
 			User:    "dokku",
 			KeyPath: "dokku_mcp_test",
+			// Mirrors dokkuApi.DefaultAuthMethodOrder
+			AuthMethods: []string{"key", "agent", "default_key"},
+		},
+		CircuitBreaker: CircuitBreakerConfig{
+			Enabled:          true,
+			FailureThreshold: 5,
+			CoolDown:         30 * time.Second,
+		},
+		Retry: RetryConfig{
+			Enabled:     true,
+			MaxAttempts: 3,
+			BaseDelay:   500 * time.Millisecond,
+			MaxDelay:    5 * time.Second,
+		},
+		RateLimit: RateLimitConfig{
+			RequestsPerSecond: 0,
+			Burst:             5,
 		},
 		PluginDiscovery: PluginDiscoveryConfig{
 			SyncInterval: 1 * time.Minute,
 			Enabled:      true,
 		},
 		Security: SecurityConfig{
-			Blacklist: []string{},
+			Blacklist:            []string{},
+			SensitiveKeyPatterns: slices.Clone(shared.DefaultSensitiveKeyPatterns),
+			BreakGlass:           false,
+			AllowConfigReveal:    false,
+			AppAllowlist:         []string{},
+			AppDenylist:          []string{},
+			LogRedaction: LogRedactionConfig{
+				RedactCommands: []string{},
+				AllowCommands:  []string{},
+			},
 		},
 		MultiTenant: MultiTenantConfig{
 			Enabled: false,
@@ -197,10 +334,22 @@ func LoadConfig() (*ServerConfig, error) {
 	viper.SetDefault("log_level", config.LogLevel)
 	viper.SetDefault("log_format", config.LogFormat)
 	viper.SetDefault("expose_server_logs", config.ExposeServerLogs)
+	viper.SetDefault("expose_command_output", config.ExposeCommandOutput)
+	viper.SetDefault("read_only", config.ReadOnly)
+	viper.SetDefault("output_format", config.OutputFormat)
 	viper.SetDefault("log_buffer_capacity", config.LogBufferCapacity)
 	viper.SetDefault("deployment_log_lines", config.DeploymentLogLines)
+	viper.SetDefault("deploy_retry_delay", config.DeployRetryDelay)
+	viper.SetDefault("scheduled_deploys.file", config.ScheduledDeploys.File)
+	viper.SetDefault("scheduled_deploys.check_interval", config.ScheduledDeploys.CheckInterval)
+	viper.SetDefault("images.keep_previous", config.Images.KeepPrevious)
+	viper.SetDefault("app_list.default_limit", config.AppList.DefaultLimit)
+	viper.SetDefault("drift_detection.enabled", config.DriftDetection.Enabled)
+	viper.SetDefault("drift_detection.interval", config.DriftDetection.Interval)
+	viper.SetDefault("multi_tenant.observability.audit_enabled", config.MultiTenant.Observability.AuditEnabled)
 	viper.SetDefault("timeout", config.Timeout)
 	viper.SetDefault("dokku_path", config.DokkuPath)
+	viper.SetDefault("dokku_version", config.DokkuVersion)
 	viper.SetDefault("cache_enabled", config.CacheEnabled)
 	viper.SetDefault("cache_ttl", config.CacheTTL)
 
@@ -210,6 +359,24 @@ func LoadConfig() (*ServerConfig, error) {
 	viper.SetDefault("ssh.user", config.SSH.User)
 	viper.SetDefault("ssh.key_path", config.SSH.KeyPath)
 	viper.SetDefault("ssh.disable_pty", config.SSH.DisablePTY)
+	viper.SetDefault("ssh.strict_key_permissions", config.SSH.StrictKeyPermissions)
+	viper.SetDefault("ssh.auth_methods", config.SSH.AuthMethods)
+	viper.SetDefault("ssh.command_path", config.SSH.CommandPath)
+
+	// Circuit breaker configuration defaults
+	viper.SetDefault("circuit_breaker.enabled", config.CircuitBreaker.Enabled)
+	viper.SetDefault("circuit_breaker.failure_threshold", config.CircuitBreaker.FailureThreshold)
+	viper.SetDefault("circuit_breaker.cool_down", config.CircuitBreaker.CoolDown)
+
+	// Retry configuration defaults
+	viper.SetDefault("retry.enabled", config.Retry.Enabled)
+	viper.SetDefault("retry.max_attempts", config.Retry.MaxAttempts)
+	viper.SetDefault("retry.base_delay", config.Retry.BaseDelay)
+	viper.SetDefault("retry.max_delay", config.Retry.MaxDelay)
+
+	// Rate limit configuration defaults
+	viper.SetDefault("rate_limit.requests_per_second", config.RateLimit.RequestsPerSecond)
+	viper.SetDefault("rate_limit.burst", config.RateLimit.Burst)
 
 	// Plugin discovery configuration defaults
 	viper.SetDefault("plugin_discovery.sync_interval", config.PluginDiscovery.SyncInterval)
@@ -217,6 +384,13 @@ func LoadConfig() (*ServerConfig, error) {
 
 	// Security configuration defaults
 	viper.SetDefault("security.blacklist", config.Security.Blacklist)
+	viper.SetDefault("security.sensitive_key_patterns", config.Security.SensitiveKeyPatterns)
+	viper.SetDefault("security.break_glass", config.Security.BreakGlass)
+	viper.SetDefault("security.allow_config_reveal", config.Security.AllowConfigReveal)
+	viper.SetDefault("security.app_allowlist", config.Security.AppAllowlist)
+	viper.SetDefault("security.app_denylist", config.Security.AppDenylist)
+	viper.SetDefault("security.log_redaction.redact_commands", config.Security.LogRedaction.RedactCommands)
+	viper.SetDefault("security.log_redaction.allow_commands", config.Security.LogRedaction.AllowCommands)
 
 	// Logs configuration defaults
 	viper.SetDefault("logs.runtime.default_lines", config.Logs.Runtime.DefaultLines)
@@ -252,6 +426,26 @@ func validateConfig(config *ServerConfig) error {
 		return fmt.Errorf("the timeout must be positive")
 	}
 
+	if config.DeployRetryDelay < 0 {
+		return fmt.Errorf("the deploy retry delay cannot be negative")
+	}
+
+	if config.ScheduledDeploys.CheckInterval <= 0 {
+		return fmt.Errorf("scheduled_deploys.check_interval must be positive")
+	}
+
+	if config.Images.KeepPrevious < 0 {
+		return fmt.Errorf("images.keep_previous cannot be negative")
+	}
+
+	if config.AppList.DefaultLimit < 1 {
+		return fmt.Errorf("app_list.default_limit must be at least 1")
+	}
+
+	if config.DriftDetection.Enabled && config.DriftDetection.Interval < time.Minute {
+		return fmt.Errorf("drift_detection.interval must be at least 1m")
+	}
+
 	if config.DokkuPath == "" {
 		return fmt.Errorf("the Dokku path cannot be empty")
 	}
@@ -269,6 +463,37 @@ func validateConfig(config *ServerConfig) error {
 		return fmt.Errorf("the SSH user cannot be empty")
 	}
 
+	if config.CircuitBreaker.Enabled {
+		if config.CircuitBreaker.FailureThreshold <= 0 {
+			return fmt.Errorf("circuit_breaker.failure_threshold must be positive")
+		}
+		if config.CircuitBreaker.CoolDown <= 0 {
+			return fmt.Errorf("circuit_breaker.cool_down must be positive")
+		}
+	}
+
+	if config.Retry.Enabled {
+		if config.Retry.MaxAttempts <= 0 || config.Retry.MaxAttempts > maxRetryAttempts {
+			return fmt.Errorf("retry.max_attempts must be between 1 and %d, got %d", maxRetryAttempts, config.Retry.MaxAttempts)
+		}
+		if config.Retry.BaseDelay <= 0 || config.Retry.BaseDelay > maxRetryBaseDelay {
+			return fmt.Errorf("retry.base_delay must be positive and at most %s, got %s", maxRetryBaseDelay, config.Retry.BaseDelay)
+		}
+		if config.Retry.MaxDelay < 0 || config.Retry.MaxDelay > maxRetryMaxDelay {
+			return fmt.Errorf("retry.max_delay must be between 0 (no cap) and %s, got %s", maxRetryMaxDelay, config.Retry.MaxDelay)
+		}
+		if config.Retry.MaxDelay > 0 && config.Retry.MaxDelay < config.Retry.BaseDelay {
+			return fmt.Errorf("retry.max_delay must be greater than or equal to retry.base_delay")
+		}
+	}
+
+	if config.RateLimit.RequestsPerSecond < 0 {
+		return fmt.Errorf("rate_limit.requests_per_second cannot be negative")
+	}
+	if config.RateLimit.RequestsPerSecond > 0 && config.RateLimit.Burst < 1 {
+		return fmt.Errorf("rate_limit.burst must be at least 1")
+	}
+
 	validLogLevels := map[string]bool{
 		"debug": true, "info": true, "warn": true, "error": true,
 	}
@@ -283,6 +508,13 @@ func validateConfig(config *ServerConfig) error {
 		return fmt.Errorf("invalid log format: %s", config.LogFormat)
 	}
 
+	validOutputFormats := map[string]bool{
+		"pretty": true, "compact": true, "minimal": true,
+	}
+	if !validOutputFormats[config.OutputFormat] {
+		return fmt.Errorf("invalid output format: %s", config.OutputFormat)
+	}
+
 	// Validate logs configuration
 	if config.Logs.Runtime.DefaultLines <= 0 || config.Logs.Runtime.DefaultLines > 100000 {
 		return fmt.Errorf("logs.runtime.default_lines must be between 1 and 100000")
@@ -0,0 +1,48 @@
+package config
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidateConfigRetryBounds(t *testing.T) {
+	tests := []struct {
+		name    string
+		retry   RetryConfig
+		wantErr string
+	}{
+		{name: "defaults", retry: DefaultConfig().Retry},
+		{name: "single attempt", retry: RetryConfig{Enabled: true, MaxAttempts: 1, BaseDelay: time.Millisecond, MaxDelay: time.Second}},
+		{name: "largest values", retry: RetryConfig{Enabled: true, MaxAttempts: 10, BaseDelay: 30 * time.Second, MaxDelay: 5 * time.Minute}},
+		{name: "uncapped delay", retry: RetryConfig{Enabled: true, MaxAttempts: 3, BaseDelay: time.Second}},
+		{name: "zero attempts", retry: RetryConfig{Enabled: true, MaxAttempts: 0, BaseDelay: time.Second}, wantErr: "retry.max_attempts"},
+		{name: "negative attempts", retry: RetryConfig{Enabled: true, MaxAttempts: -1, BaseDelay: time.Second}, wantErr: "retry.max_attempts"},
+		{name: "too many attempts", retry: RetryConfig{Enabled: true, MaxAttempts: 11, BaseDelay: time.Second}, wantErr: "retry.max_attempts"},
+		{name: "zero base delay", retry: RetryConfig{Enabled: true, MaxAttempts: 3}, wantErr: "retry.base_delay"},
+		{name: "negative base delay", retry: RetryConfig{Enabled: true, MaxAttempts: 3, BaseDelay: -time.Second}, wantErr: "retry.base_delay"},
+		{name: "base delay too long", retry: RetryConfig{Enabled: true, MaxAttempts: 3, BaseDelay: 31 * time.Second}, wantErr: "retry.base_delay"},
+		{name: "negative max delay", retry: RetryConfig{Enabled: true, MaxAttempts: 3, BaseDelay: time.Second, MaxDelay: -time.Second}, wantErr: "retry.max_delay"},
+		{name: "max delay too long", retry: RetryConfig{Enabled: true, MaxAttempts: 3, BaseDelay: time.Second, MaxDelay: 6 * time.Minute}, wantErr: "retry.max_delay"},
+		{name: "max delay below base delay", retry: RetryConfig{Enabled: true, MaxAttempts: 3, BaseDelay: 2 * time.Second, MaxDelay: time.Second}, wantErr: "retry.max_delay"},
+		{name: "disabled retries are not checked", retry: RetryConfig{Enabled: false, MaxAttempts: -1, BaseDelay: -time.Second}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.Retry = tt.retry
+
+			err := validateConfig(cfg)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected an error about %s, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package config
+
+import (
+	"reflect"
+	"slices"
+)
+
+// ChangedKeys lists the settings that differ between two configurations, keyed like
+// the config file with dots between sections (e.g. "security.blacklist"), sorted
+func (c *ServerConfig) ChangedKeys(other *ServerConfig) []string {
+	var changed []string
+	diffStruct("", reflect.ValueOf(*c), reflect.ValueOf(*other), &changed)
+	slices.Sort(changed)
+	return changed
+}
+
+// diffStruct walks two values of the same config struct type and records the keys that differ
+func diffStruct(prefix string, a, b reflect.Value, changed *[]string) {
+	for i := 0; i < a.NumField(); i++ {
+		field := a.Type().Field(i)
+		key := field.Tag.Get("mapstructure")
+		if key == "" || !field.IsExported() {
+			continue
+		}
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+
+		if field.Type.Kind() == reflect.Struct {
+			diffStruct(key, a.Field(i), b.Field(i), changed)
+			continue
+		}
+		if !reflect.DeepEqual(a.Field(i).Interface(), b.Field(i).Interface()) {
+			*changed = append(*changed, key)
+		}
+	}
+}
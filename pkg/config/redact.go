@@ -0,0 +1,66 @@
+package config
+
+import (
+	"reflect"
+	"slices"
+	"time"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
+)
+
+// redactedKeys are masked even when the configured sensitive key patterns do not match them
+var redactedKeys = []string{"jwt_secret", "key_path"}
+
+// Redacted returns the configuration as nested maps keyed like the config file,
+// with secrets masked: the JWT secret, the SSH key path, string settings whose
+// key is sensitive (shared.IsSensitiveKey) and sensitive command_env variables.
+// Empty values are kept so an unset secret can be told apart from a set one.
+func (c *ServerConfig) Redacted() map[string]any {
+	return redactStruct(reflect.ValueOf(*c))
+}
+
+// redactStruct converts a config struct to a map keyed by its mapstructure tags
+func redactStruct(v reflect.Value) map[string]any {
+	out := make(map[string]any, v.NumField())
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		key := field.Tag.Get("mapstructure")
+		if key == "" || !field.IsExported() {
+			continue
+		}
+		out[key] = redactValue(key, v.Field(i))
+	}
+	return out
+}
+
+// redactValue converts a config value, masking it when its key is sensitive
+func redactValue(key string, v reflect.Value) any {
+	if d, ok := v.Interface().(time.Duration); ok {
+		return d.String()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		return redactStruct(v)
+	case reflect.String:
+		if v.String() != "" && isRedactedKey(key) {
+			return shared.MaskedValue
+		}
+		return v.String()
+	case reflect.Map:
+		out := make(map[string]any, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			name := iter.Key().String()
+			out[name] = redactValue(name, iter.Value())
+		}
+		return out
+	default:
+		return v.Interface()
+	}
+}
+
+// isRedactedKey reports whether the value of a config key must be masked
+func isRedactedKey(key string) bool {
+	return slices.Contains(redactedKeys, key) || shared.IsSensitiveKey(key)
+}
@@ -5,10 +5,16 @@ import (
 
 	"github.com/dokku-mcp/dokku-mcp/internal/server"
 	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/app"
+	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/checks"
 	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/core"
+	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/cron"
 	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/deployment"
 	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/domain"
+	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/letsencrypt"
+	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/maintenance"
 	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/onboarding"
+	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/postgres"
+	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/storage"
 	"github.com/dokku-mcp/dokku-mcp/pkg/config"
 	"github.com/dokku-mcp/dokku-mcp/pkg/logger"
 	"go.uber.org/fx"
@@ -41,6 +47,12 @@ func New() *fx.App {
 		core.CoreModule,
 		domain.Module,
 		deployment.Module,
+		cron.Module,
+		postgres.Module,
+		letsencrypt.Module,
+		maintenance.Module,
+		storage.Module,
+		checks.Module,
 		onboarding.Module,
 		app.Module,
 	)
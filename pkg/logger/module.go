@@ -10,27 +10,16 @@ import (
 
 var globalRing = NewRingBuffer(DefaultLogBufferCapacity)
 
+// level is shared by the handlers of the server logger so it can change at runtime
+var level = new(slog.LevelVar)
+
 const DefaultLogBufferCapacity = 2000
 
 func NewSlogLogger(cfg *config.ServerConfig) *slog.Logger {
 	var handler slog.Handler
 
-	// Configure log level
-	var level slog.Level
-	switch cfg.LogLevel {
-	case "debug":
-		level = slog.LevelDebug
-	case "info":
-		level = slog.LevelInfo
-	case "warn":
-		level = slog.LevelWarn
-	case "error":
-		level = slog.LevelError
-	default:
-		level = slog.LevelInfo
-	}
-
-	// Create handler based on format preference
+	// Configure log level; the level variable lets a configuration reload change it
+	SetLevel(cfg.LogLevel)
 	opts := &slog.HandlerOptions{
 		Level: level,
 	}
@@ -50,6 +39,20 @@ func NewSlogLogger(cfg *config.ServerConfig) *slog.Logger {
 	return slog.New(buffered)
 }
 
+// SetLevel changes the level of the server logger; unknown names fall back to info
+func SetLevel(name string) {
+	switch name {
+	case "debug":
+		level.Set(slog.LevelDebug)
+	case "warn":
+		level.Set(slog.LevelWarn)
+	case "error":
+		level.Set(slog.LevelError)
+	default:
+		level.Set(slog.LevelInfo)
+	}
+}
+
 var Module = fx.Module("logger",
 	fx.Provide(NewSlogLogger),
 )